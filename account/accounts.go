@@ -20,6 +20,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/status-im/status-go/account/generator"
+	"github.com/status-im/status-go/account/signer"
 	"github.com/status-im/status-go/eth-node/crypto"
 	"github.com/status-im/status-go/eth-node/keystore"
 	"github.com/status-im/status-go/eth-node/types"
@@ -100,6 +101,8 @@ type DefaultManager struct {
 	selectedChatAccount *SelectedExtKey // account that was processed during the last call to SelectAccount()
 	mainAccountAddress  types.Address
 	watchAddresses      []types.Address
+
+	externalSigners map[types.Address]signer.Signer // signers of the accounts whose keys aren't in the keystore
 }
 
 // GetKeystore is only used in tests
@@ -658,6 +661,60 @@ func (m *DefaultManager) DeleteAccount(address types.Address) error {
 	return m.keystore.Delete(types.Account{Address: address})
 }
 
+// SetExternalSigner makes the external signer sign for the account instead of the keystore
+func (m *DefaultManager) SetExternalSigner(address types.Address, s signer.Signer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.externalSigners == nil {
+		m.externalSigners = make(map[types.Address]signer.Signer)
+	}
+	replaced := m.externalSigners[address]
+	m.externalSigners[address] = s
+	m.closeUnusedExternalSigner(replaced)
+}
+
+func (m *DefaultManager) RemoveExternalSigner(address types.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	removed := m.externalSigners[address]
+	delete(m.externalSigners, address)
+	m.closeUnusedExternalSigner(removed)
+}
+
+// closeUnusedExternalSigner closes the connection to the signer once it doesn't sign for any account
+func (m *DefaultManager) closeUnusedExternalSigner(s signer.Signer) {
+	closer, ok := s.(interface{ Close() })
+	if !ok {
+		return
+	}
+	for _, used := range m.externalSigners {
+		if used == s {
+			return
+		}
+	}
+	closer.Close()
+}
+
+// ExternalSigner returns the external signer of the account, nil if it is signed for by the keystore
+func (m *DefaultManager) ExternalSigner(address types.Address) signer.Signer {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.externalSigners[address]
+}
+
+// ExternalSignerAccount returns the account signed for by its external signer, the password isn't needed
+// as the user approves the signatures on the signer
+func (m *DefaultManager) ExternalSignerAccount(address string) (*SelectedExtKey, bool) {
+	s := m.ExternalSigner(types.HexToAddress(address))
+	if s == nil {
+		return nil, false
+	}
+	return &SelectedExtKey{
+		Address:        types.HexToAddress(address),
+		ExternalSigner: s,
+	}, true
+}
+
 func (m *DefaultManager) GetVerifiedWalletAccount(db *accounts.Database, address, password string) (*SelectedExtKey, error) {
 	exists, err := db.AddressExists(types.HexToAddress(address))
 	if err != nil {
@@ -668,6 +725,10 @@ func (m *DefaultManager) GetVerifiedWalletAccount(db *accounts.Database, address
 		return nil, errors.New("account doesn't exist")
 	}
 
+	if selectedAccount, ok := m.ExternalSignerAccount(address); ok {
+		return selectedAccount, nil
+	}
+
 	key, err := m.VerifyAccountPassword(m.Keydir, address, password)
 	if _, ok := err.(*ErrCannotLocateKeyFile); ok {
 		key, err = m.generatePartialAccountKey(db, address, password)
@@ -745,6 +806,10 @@ func (m *DefaultManager) Sign(rpcParams SignParams, verifiedAccount *SelectedExt
 		return
 	}
 
+	if verifiedAccount.ExternalSigner != nil {
+		return SignPersonalMessage(verifiedAccount, rpcParams.Data)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), m.rpcTimeout)
 	defer cancel()
 	var gethResult hexutil.Bytes
@@ -758,3 +823,21 @@ func (m *DefaultManager) Sign(rpcParams SignParams, verifiedAccount *SelectedExt
 
 	return
 }
+
+// SignPersonalMessage signs the hex encoded `personal_sign` data with the signer of the account
+func SignPersonalMessage(verifiedAccount *SelectedExtKey, data interface{}) (types.HexBytes, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var message hexutil.Bytes
+	if err = json.Unmarshal(encoded, &message); err != nil {
+		return nil, err
+	}
+
+	sig, err := verifiedAccount.Signer().SignPersonalMessage(context.Background(), gethcommon.Address(verifiedAccount.Address), message)
+	if err != nil {
+		return nil, err
+	}
+	return types.HexBytes(sig), nil
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/status-im/status-go/account/signer"
 	"github.com/status-im/status-go/eth-node/crypto"
 	"github.com/status-im/status-go/eth-node/keystore"
 	"github.com/status-im/status-go/eth-node/types"
@@ -110,6 +111,32 @@ func TestVerifyAccountPasswordWithAccountBeforeEIP55(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestExternalSigners(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	externalSigner, err := signer.NewMockExternalService(key).Signer()
+	require.NoError(t, err)
+
+	manager := &DefaultManager{}
+	_, ok := manager.ExternalSignerAccount(address.Hex())
+	require.False(t, ok)
+
+	manager.SetExternalSigner(address, externalSigner)
+	selectedAccount, ok := manager.ExternalSignerAccount(address.Hex())
+	require.True(t, ok)
+	require.Equal(t, address, selectedAccount.Address)
+	require.Nil(t, selectedAccount.AccountKey)
+	require.Equal(t, externalSigner, selectedAccount.Signer())
+
+	manager.RemoveExternalSigner(address)
+	require.Nil(t, manager.ExternalSigner(address))
+
+	// the keystore signer is used otherwise
+	keystoreAccount := &SelectedExtKey{Address: address, AccountKey: &types.Key{PrivateKey: key}}
+	require.IsType(t, &signer.KeySigner{}, keystoreAccount.Signer())
+}
+
 func TestManagerTestSuite(t *testing.T) {
 	suite.Run(t, new(ManagerTestSuite))
 }
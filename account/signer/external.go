@@ -0,0 +1,138 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const textPlainContentType = "text/plain"
+
+// SignTransactionResult is the result of `account_signTransaction`
+type SignTransactionResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// ExternalSigner signs through the Clef compatible JSON-RPC API of an external signer, which is how the hardware
+// wallets are supported: the device is handled by the signer and the user approves each signature there.
+// The signatures are checked as the signer isn't trusted.
+type ExternalSigner struct {
+	client *gethrpc.Client
+}
+
+// NewExternalSigner connects to the signer listening on the endpoint, e.g. `http://localhost:8550` or the path
+// of the IPC socket
+func NewExternalSigner(ctx context.Context, endpoint string) (*ExternalSigner, error) {
+	client, err := gethrpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return newExternalSigner(client), nil
+}
+
+func newExternalSigner(client *gethrpc.Client) *ExternalSigner {
+	return &ExternalSigner{client: client}
+}
+
+func (s *ExternalSigner) Close() {
+	s.client.Close()
+}
+
+// Accounts returns the accounts the signer holds the keys of
+func (s *ExternalSigner) Accounts(ctx context.Context) ([]common.Address, error) {
+	var addresses []common.Address
+	err := s.client.CallContext(ctx, &addresses, "account_list")
+	return addresses, err
+}
+
+func (s *ExternalSigner) SignTx(ctx context.Context, address common.Address, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	args := apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(address),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.To() != nil {
+		to := common.NewMixedcaseAddress(*tx.To())
+		args.To = &to
+	}
+	data := hexutil.Bytes(tx.Data())
+	args.Data = &data
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		accessList := tx.AccessList()
+		args.AccessList = &accessList
+	case types.AccessListTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+		accessList := tx.AccessList()
+		args.AccessList = &accessList
+	default:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	}
+
+	var result SignTransactionResult
+	if err := s.client.CallContext(ctx, &result, "account_signTransaction", &args); err != nil {
+		return nil, err
+	}
+
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(result.Raw); err != nil {
+		return nil, err
+	}
+
+	// the signer must have signed the transaction it was given
+	txSigner := types.NewLondonSigner(chainID)
+	if txSigner.Hash(signedTx) != txSigner.Hash(tx) {
+		return nil, ErrInvalidSignature
+	}
+	sender, err := types.Sender(txSigner, signedTx)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	if sender != address {
+		return nil, ErrUnknownAccount
+	}
+	return signedTx, nil
+}
+
+func (s *ExternalSigner) SignPersonalMessage(ctx context.Context, address common.Address, message []byte) ([]byte, error) {
+	var sig hexutil.Bytes
+	err := s.client.CallContext(ctx, &sig, "account_signData", textPlainContentType, address, hexutil.Encode(message))
+	if err != nil {
+		return nil, err
+	}
+	return checkSignature(address, accounts.TextHash(message), sig)
+}
+
+func (s *ExternalSigner) SignTypedData(ctx context.Context, address common.Address, typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+
+	var sig hexutil.Bytes
+	err = s.client.CallContext(ctx, &sig, "account_signTypedData", address, typedData)
+	if err != nil {
+		return nil, err
+	}
+	return checkSignature(address, hash, sig)
+}
+
+// checkSignature checks the signature of the hash was made by the account and returns it with V being 27 or 28
+func checkSignature(address common.Address, hash []byte, sig []byte) ([]byte, error) {
+	sig, err := verifySignature(address, hash, sig)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
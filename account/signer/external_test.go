@@ -0,0 +1,49 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestExternalSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	service := NewMockExternalService(key)
+	s, err := service.Signer()
+	require.NoError(t, err)
+	defer s.Close()
+
+	addresses, err := s.Accounts(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{address}, addresses)
+
+	testSigner(t, s, address)
+	require.Equal(t, 3, service.Requests())
+
+	// legacy transactions are supported too
+	to := common.HexToAddress("0x2")
+	legacyTx := types.NewTransaction(1, to, big.NewInt(1), 21000, big.NewInt(1), []byte{1, 2})
+	signedTx, err := s.SignTx(context.Background(), address, legacyTx, big.NewInt(10))
+	require.NoError(t, err)
+	require.Equal(t, legacyTx.Data(), signedTx.Data())
+
+	// the external signer doesn't sign arbitrary hashes
+	_, err = SignHash(context.Background(), s, address, common.HexToHash("0x1"))
+	require.ErrorIs(t, err, ErrHashSigningUnsupported)
+
+	_, err = s.SignPersonalMessage(context.Background(), common.HexToAddress("0x1"), []byte("hello"))
+	require.Error(t, err)
+
+	service.SetReject(true)
+	_, err = s.SignTx(context.Background(), address, testTx(), big.NewInt(10))
+	require.Error(t, err)
+}
@@ -0,0 +1,25 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// KeySigner signs with a private key unlocked from the keystore
+type KeySigner struct {
+	hashSigner
+}
+
+func NewKeySigner(key *ecdsa.PrivateKey) *KeySigner {
+	s := &KeySigner{}
+	s.signHash = func(ctx context.Context, address common.Address, hash common.Hash) ([]byte, error) {
+		if key == nil || crypto.PubkeyToAddress(key.PublicKey) != address {
+			return nil, ErrUnknownAccount
+		}
+		return crypto.Sign(hash[:], key)
+	}
+	return s
+}
@@ -0,0 +1,127 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const keycardSignTimeout = 5 * time.Minute
+
+var (
+	ErrUnknownSignRequest = errors.New("unknown sign request")
+	ErrSignRequestTimeout = errors.New("the sign request timed out")
+)
+
+// KeycardSigner signs with a Keycard, the card only signs hashes and is handled by the client
+type KeycardSigner struct {
+	hashSigner
+}
+
+func NewKeycardSigner(signHash SignHashFn) *KeycardSigner {
+	s := &KeycardSigner{}
+	s.signHash = signHash
+	return s
+}
+
+// KeycardSignRequest asks the client to sign the hash with the Keycard holding the key of the account
+type KeycardSignRequest struct {
+	ID      string         `json:"id"`
+	Address common.Address `json:"address"`
+	Hash    common.Hash    `json:"hash"`
+}
+
+type keycardSignResponse struct {
+	signature []byte
+	err       error
+}
+
+// KeycardRequests forwards the hashes to sign to the client and waits until it submits the signature made by the
+// Keycard or rejects the request. The requests are only used once enabled by a client able to answer them.
+type KeycardRequests struct {
+	notify  func(request KeycardSignRequest)
+	timeout time.Duration
+	enabled atomic.Bool
+
+	mu      sync.Mutex
+	pending map[string]chan keycardSignResponse
+}
+
+func NewKeycardRequests(notify func(request KeycardSignRequest)) *KeycardRequests {
+	return &KeycardRequests{
+		notify:  notify,
+		timeout: keycardSignTimeout,
+		pending: make(map[string]chan keycardSignResponse),
+	}
+}
+
+// SetEnabled is called by the client when it starts or stops handling the sign requests
+func (r *KeycardRequests) SetEnabled(enabled bool) {
+	r.enabled.Store(enabled)
+}
+
+// Enabled returns true if a client handles the sign requests
+func (r *KeycardRequests) Enabled() bool {
+	return r.enabled.Load()
+}
+
+// SignHash is the SignHashFn of the KeycardSigner
+func (r *KeycardRequests) SignHash(ctx context.Context, address common.Address, hash common.Hash) ([]byte, error) {
+	request := KeycardSignRequest{
+		ID:      uuid.NewString(),
+		Address: address,
+		Hash:    hash,
+	}
+	ch := make(chan keycardSignResponse, 1)
+
+	r.mu.Lock()
+	r.pending[request.ID] = ch
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.pending, request.ID)
+		r.mu.Unlock()
+	}()
+
+	r.notify(request)
+
+	timer := time.NewTimer(r.timeout)
+	defer timer.Stop()
+	select {
+	case response := <-ch:
+		return response.signature, response.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+		return nil, ErrSignRequestTimeout
+	}
+}
+
+func (r *KeycardRequests) respond(requestID string, response keycardSignResponse) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ch, ok := r.pending[requestID]
+	if !ok {
+		return ErrUnknownSignRequest
+	}
+	delete(r.pending, requestID)
+	ch <- response
+	return nil
+}
+
+// Submit completes the request with the signature made by the Keycard
+func (r *KeycardRequests) Submit(requestID string, signature []byte) error {
+	return r.respond(requestID, keycardSignResponse{signature: signature})
+}
+
+// Reject fails the request, e.g. when the user cancels the signature
+func (r *KeycardRequests) Reject(requestID string) error {
+	return r.respond(requestID, keycardSignResponse{err: ErrSignatureRejected})
+}
@@ -0,0 +1,140 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+var errRequestDenied = errors.New("request denied")
+
+// MockExternalService is an in-process external signer serving the `account` namespace of the Clef API with
+// local keys, so the external signer flows can be tested without a device
+type MockExternalService struct {
+	mu     sync.Mutex
+	keys   map[common.Address]*ecdsa.PrivateKey
+	reject bool
+	// requests counts the sign requests received
+	requests int
+}
+
+func NewMockExternalService(keys ...*ecdsa.PrivateKey) *MockExternalService {
+	m := &MockExternalService{keys: make(map[common.Address]*ecdsa.PrivateKey)}
+	for _, key := range keys {
+		m.keys[crypto.PubkeyToAddress(key.PublicKey)] = key
+	}
+	return m
+}
+
+// SetReject makes the service deny the next requests, as when the user rejects them on the device
+func (m *MockExternalService) SetReject(reject bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reject = reject
+}
+
+// Requests returns the number of sign requests received
+func (m *MockExternalService) Requests() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.requests
+}
+
+// Signer returns an ExternalSigner connected to the service
+func (m *MockExternalService) Signer() (*ExternalSigner, error) {
+	server := gethrpc.NewServer()
+	if err := server.RegisterName("account", &mockAccountAPI{service: m}); err != nil {
+		return nil, err
+	}
+	return newExternalSigner(gethrpc.DialInProc(server)), nil
+}
+
+func (m *MockExternalService) key(address common.Address) (*ecdsa.PrivateKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests++
+	if m.reject {
+		return nil, errRequestDenied
+	}
+	key, ok := m.keys[address]
+	if !ok {
+		return nil, ErrUnknownAccount
+	}
+	return key, nil
+}
+
+// mockAccountAPI is the `account` namespace served by the MockExternalService
+type mockAccountAPI struct {
+	service *MockExternalService
+}
+
+// List implements `account_list`
+func (api *mockAccountAPI) List(ctx context.Context) ([]common.Address, error) {
+	api.service.mu.Lock()
+	defer api.service.mu.Unlock()
+	addresses := make([]common.Address, 0, len(api.service.keys))
+	for address := range api.service.keys {
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+// SignTransaction implements `account_signTransaction`
+func (api *mockAccountAPI) SignTransaction(ctx context.Context, args apitypes.SendTxArgs, methodSelector *string) (*SignTransactionResult, error) {
+	key, err := api.service.key(args.From.Address())
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := types.SignTx(args.ToTransaction(), types.NewLondonSigner((*hexutil.Big)(args.ChainID).ToInt()), key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &SignTransactionResult{Raw: raw}, nil
+}
+
+// SignData implements `account_signData` for the `text/plain` content type
+func (api *mockAccountAPI) SignData(ctx context.Context, contentType string, addr common.MixedcaseAddress, data string) (hexutil.Bytes, error) {
+	if contentType != textPlainContentType {
+		return nil, errors.New("unsupported content type")
+	}
+	message, err := hexutil.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return api.service.sign(addr.Address(), accounts.TextHash(message))
+}
+
+// SignTypedData implements `account_signTypedData`
+func (api *mockAccountAPI) SignTypedData(ctx context.Context, addr common.MixedcaseAddress, typedData apitypes.TypedData) (hexutil.Bytes, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	return api.service.sign(addr.Address(), hash)
+}
+
+func (m *MockExternalService) sign(address common.Address, hash []byte) (hexutil.Bytes, error) {
+	key, err := m.key(address)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
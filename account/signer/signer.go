@@ -0,0 +1,117 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const signatureLength = 65
+
+var (
+	ErrUnknownAccount         = errors.New("the signer doesn't hold the key of the account")
+	ErrHashSigningUnsupported = errors.New("the signer doesn't sign arbitrary hashes")
+	ErrInvalidSignature       = errors.New("invalid signature")
+	ErrSignatureRejected      = errors.New("the signature was rejected")
+)
+
+// Signer signs the transactions and the messages of the accounts it holds the keys of. The keys may be kept outside
+// of status-go, e.g. by a hardware wallet, the wallet accounts use the keystore signer unless an external one is set.
+type Signer interface {
+	// SignTx returns the transaction signed by the account for the chain
+	SignTx(ctx context.Context, address common.Address, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	// SignPersonalMessage signs the message prefixed as per EIP-191 (`personal_sign`), V is 27 or 28
+	SignPersonalMessage(ctx context.Context, address common.Address, message []byte) ([]byte, error)
+	// SignTypedData signs the EIP-712 typed data (`eth_signTypedData_v4`), V is 27 or 28
+	SignTypedData(ctx context.Context, address common.Address, typedData apitypes.TypedData) ([]byte, error)
+}
+
+// HashSigner is implemented by the signers which sign any hash, V is 0 or 1. The external signers don't as the user
+// can't tell what is signed.
+type HashSigner interface {
+	SignHash(ctx context.Context, address common.Address, hash common.Hash) ([]byte, error)
+}
+
+// SignHash signs the hash with the signer, ErrHashSigningUnsupported is returned if it doesn't sign hashes
+func SignHash(ctx context.Context, s Signer, address common.Address, hash common.Hash) ([]byte, error) {
+	hashSigner, ok := s.(HashSigner)
+	if !ok {
+		return nil, ErrHashSigningUnsupported
+	}
+	return hashSigner.SignHash(ctx, address, hash)
+}
+
+// SignHashFn signs the hash with the key of the account, V may be 0/1 or 27/28
+type SignHashFn func(ctx context.Context, address common.Address, hash common.Hash) ([]byte, error)
+
+// hashSigner implements the signatures of the transactions and messages on top of the signature of their hash
+type hashSigner struct {
+	signHash SignHashFn
+}
+
+// SignHash returns the signature of the hash by the account, the recovered address is checked
+func (s *hashSigner) SignHash(ctx context.Context, address common.Address, hash common.Hash) ([]byte, error) {
+	sig, err := s.signHash(ctx, address, hash)
+	if err != nil {
+		return nil, err
+	}
+	return verifySignature(address, hash[:], sig)
+}
+
+// verifySignature checks the signature of the hash was made by the account and returns it with V being 0 or 1
+func verifySignature(address common.Address, hash []byte, sig []byte) ([]byte, error) {
+	if len(sig) != signatureLength {
+		return nil, ErrInvalidSignature
+	}
+
+	sig = common.CopyBytes(sig)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	pubKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	if crypto.PubkeyToAddress(*pubKey) != address {
+		return nil, ErrUnknownAccount
+	}
+	return sig, nil
+}
+
+func (s *hashSigner) SignTx(ctx context.Context, address common.Address, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	txSigner := types.NewLondonSigner(chainID)
+	sig, err := s.SignHash(ctx, address, txSigner.Hash(tx))
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(txSigner, sig)
+}
+
+func (s *hashSigner) SignPersonalMessage(ctx context.Context, address common.Address, message []byte) ([]byte, error) {
+	sig, err := s.SignHash(ctx, address, common.BytesToHash(accounts.TextHash(message)))
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+func (s *hashSigner) SignTypedData(ctx context.Context, address common.Address, typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := s.SignHash(ctx, address, common.BytesToHash(hash))
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
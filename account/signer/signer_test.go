@@ -0,0 +1,162 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+func testTx() *types.Transaction {
+	to := common.HexToAddress("0x2")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(10),
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(100),
+	})
+}
+
+func testTypedData() apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+			},
+			"Mail": []apitypes.Type{
+				{Name: "contents", Type: "string"},
+			},
+		},
+		PrimaryType: "Mail",
+		Domain: apitypes.TypedDataDomain{
+			Name:    "test",
+			ChainId: math.NewHexOrDecimal256(10),
+		},
+		Message: apitypes.TypedDataMessage{
+			"contents": "hello",
+		},
+	}
+}
+
+// testSigner checks the signatures of the signer are made by the account
+func testSigner(t *testing.T, s Signer, address common.Address) {
+	ctx := context.Background()
+
+	signedTx, err := s.SignTx(ctx, address, testTx(), big.NewInt(10))
+	require.NoError(t, err)
+	sender, err := types.Sender(types.NewLondonSigner(big.NewInt(10)), signedTx)
+	require.NoError(t, err)
+	require.Equal(t, address, sender)
+
+	message := []byte("hello")
+	sig, err := s.SignPersonalMessage(ctx, address, message)
+	require.NoError(t, err)
+	require.Contains(t, []byte{27, 28}, sig[64])
+	sig[64] -= 27
+	pubKey, err := crypto.SigToPub(accounts.TextHash(message), sig)
+	require.NoError(t, err)
+	require.Equal(t, address, crypto.PubkeyToAddress(*pubKey))
+
+	typedData := testTypedData()
+	sig, err = s.SignTypedData(ctx, address, typedData)
+	require.NoError(t, err)
+	require.Contains(t, []byte{27, 28}, sig[64])
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	require.NoError(t, err)
+	sig[64] -= 27
+	pubKey, err = crypto.SigToPub(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, address, crypto.PubkeyToAddress(*pubKey))
+}
+
+func TestKeySigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	s := NewKeySigner(key)
+
+	testSigner(t, s, address)
+
+	hash := common.HexToHash("0x1234")
+	sig, err := SignHash(context.Background(), s, address, hash)
+	require.NoError(t, err)
+	require.Contains(t, []byte{0, 1}, sig[64])
+
+	_, err = s.SignPersonalMessage(context.Background(), common.HexToAddress("0x1"), []byte("hello"))
+	require.ErrorIs(t, err, ErrUnknownAccount)
+}
+
+func TestKeycardSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	// the client answers the requests with the signature of the card
+	var requests *KeycardRequests
+	requests = NewKeycardRequests(func(request KeycardSignRequest) {
+		go func() {
+			require.Equal(t, address, request.Address)
+			sig, err := crypto.Sign(request.Hash[:], key)
+			require.NoError(t, err)
+			sig[64] += 27
+			require.NoError(t, requests.Submit(request.ID, sig))
+		}()
+	})
+	testSigner(t, NewKeycardSigner(requests.SignHash), address)
+
+	// the signature made by another card is refused
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	key = otherKey
+	_, err = NewKeycardSigner(requests.SignHash).SignTx(context.Background(), address, testTx(), big.NewInt(10))
+	require.ErrorIs(t, err, ErrUnknownAccount)
+}
+
+func TestKeycardRequests(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	ids := make(chan string, 1)
+	requests := NewKeycardRequests(func(request KeycardSignRequest) {
+		ids <- request.ID
+	})
+	s := NewKeycardSigner(requests.SignHash)
+
+	require.False(t, requests.Enabled())
+	requests.SetEnabled(true)
+	require.True(t, requests.Enabled())
+
+	go func() {
+		require.NoError(t, requests.Reject(<-ids))
+	}()
+	_, err = s.SignPersonalMessage(context.Background(), address, []byte("hello"))
+	require.ErrorIs(t, err, ErrSignatureRejected)
+	require.ErrorIs(t, requests.Submit("unknown", nil), ErrUnknownSignRequest)
+
+	// an invalid signature is refused
+	go func() {
+		require.NoError(t, requests.Submit(<-ids, []byte{1, 2, 3}))
+	}()
+	_, err = s.SignPersonalMessage(context.Background(), address, []byte("hello"))
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	requests.timeout = 10 * time.Millisecond
+	_, err = s.SignPersonalMessage(context.Background(), address, []byte("hello"))
+	require.ErrorIs(t, err, ErrSignRequestTimeout)
+	// the request timed out
+	require.ErrorIs(t, requests.Submit(<-ids, nil), ErrUnknownSignRequest)
+}
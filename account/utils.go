@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/status-im/status-go/account/signer"
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/extkeys"
 	"github.com/status-im/status-go/multiaccounts"
@@ -44,6 +45,19 @@ type SelectedExtKey struct {
 	Address     types.Address
 	AccountKey  *types.Key
 	SubAccounts []types.Account
+	// ExternalSigner signs for the account when its key isn't in the keystore, e.g. it is on a hardware wallet
+	ExternalSigner signer.Signer
+}
+
+// Signer returns the signer of the account, the keystore one unless an external signer is set.
+func (k *SelectedExtKey) Signer() signer.Signer {
+	if k.ExternalSigner != nil {
+		return k.ExternalSigner
+	}
+	if k.AccountKey == nil {
+		return signer.NewKeySigner(nil)
+	}
+	return signer.NewKeySigner(k.AccountKey.PrivateKey)
 }
 
 // Hex dumps address of a given extended key as hex string.
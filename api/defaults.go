@@ -225,6 +225,15 @@ func buildWalletConfig(request *requests.WalletSecretsConfig, statusProxyEnabled
 	if request.StatusProxyBlockchainPassword != "" {
 		walletConfig.StatusProxyBlockchainPassword = request.StatusProxyBlockchainPassword
 	}
+	if len(request.BundlerURLs) > 0 {
+		walletConfig.BundlerURLs = request.BundlerURLs
+	}
+	if len(request.PaymasterURLs) > 0 {
+		walletConfig.PaymasterURLs = request.PaymasterURLs
+	}
+	if request.ZeroExAPIKey != "" {
+		walletConfig.ZeroExAPIKey = request.ZeroExAPIKey
+	}
 
 	walletConfig.StatusProxyEnabled = statusProxyEnabled
 
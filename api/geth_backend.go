@@ -2306,7 +2306,7 @@ func (b *GethStatusBackend) SignTypedData(typed typeddata.TypedData, address str
 		return types.HexBytes{}, err
 	}
 	chain := new(big.Int).SetUint64(b.StatusNode().Config().NetworkID)
-	sig, err := typeddata.Sign(typed, account.AccountKey.PrivateKey, chain)
+	sig, err := typeddata.SignWithSigner(context.Background(), typed, account.Signer(), common.Address(account.Address), chain)
 	if err != nil {
 		return types.HexBytes{}, err
 	}
@@ -2319,8 +2319,7 @@ func (b *GethStatusBackend) SignTypedDataV4(typed signercore.TypedData, address
 	if err != nil {
 		return types.HexBytes{}, err
 	}
-	chain := new(big.Int).SetUint64(b.StatusNode().Config().NetworkID)
-	sig, err := typeddata.SignTypedDataV4(typed, account.AccountKey.PrivateKey, chain)
+	sig, err := account.Signer().SignTypedData(context.Background(), common.Address(account.Address), typed)
 	if err != nil {
 		return types.HexBytes{}, err
 	}
@@ -2365,6 +2364,10 @@ func (b *GethStatusBackend) getVerifiedWalletAccount(address, password string) (
 		return nil, transactions.ErrAccountDoesntExist
 	}
 
+	if selectedAccount, ok := b.accountManager.ExternalSignerAccount(address); ok {
+		return selectedAccount, nil
+	}
+
 	key, err := b.accountManager.VerifyAccountPassword(config.KeyStoreDir, address, password)
 	if _, ok := err.(*account.ErrCannotLocateKeyFile); ok {
 		key, err = b.generatePartialAccountKey(db, address, password)
@@ -0,0 +1 @@
+ALTER TABLE keypairs_accounts ADD COLUMN multisig BOOLEAN NOT NULL DEFAULT FALSE;
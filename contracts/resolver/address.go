@@ -13,6 +13,12 @@ var contractAddressByChainID = map[uint64]common.Address{
 	11155111: common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"), // sepolia testnet
 }
 
+// The ENS NameWrapper owns the wrapped names in the registry
+var nameWrapperAddressByChainID = map[uint64]common.Address{
+	1:        common.HexToAddress("0xD4416b13d2b3a9aBae7AcD5D6C2BbDBE25686401"), // mainnet
+	11155111: common.HexToAddress("0x0635513f179D50A207757E05759CbD106d7dFcE8"), // sepolia testnet
+}
+
 func ContractAddress(chainID uint64) (common.Address, error) {
 	addr, exists := contractAddressByChainID[chainID]
 	if !exists {
@@ -20,3 +26,11 @@ func ContractAddress(chainID uint64) (common.Address, error) {
 	}
 	return addr, nil
 }
+
+func NameWrapperContractAddress(chainID uint64) (common.Address, error) {
+	addr, exists := nameWrapperAddressByChainID[chainID]
+	if !exists {
+		return *new(common.Address), errorNotAvailableOnChainID
+	}
+	return addr, nil
+}
@@ -107,6 +107,8 @@ const (
 	AccountTypeKey       AccountType = "key"
 	AccountTypeSeed      AccountType = "seed"
 	AccountTypeWatch     AccountType = "watch"
+	// AccountTypeMultisig is a Safe contract account, like watch only accounts it isn't related to any keypair
+	AccountTypeMultisig AccountType = "multisig"
 )
 
 const (
@@ -121,7 +123,12 @@ const (
 
 // Returns true if an account is a wallet account that logged in user has a control over, otherwise returns false.
 func (a *Account) IsWalletNonWatchOnlyAccount() bool {
-	return !a.Chat && len(a.Type) > 0 && a.Type != AccountTypeWatch
+	return !a.Chat && len(a.Type) > 0 && !a.IsWatchOnly()
+}
+
+// Returns true if an account has no local key, the watch only and the multisig accounts, otherwise returns false.
+func (a *Account) IsWatchOnly() bool {
+	return a.Type == AccountTypeWatch || a.Type == AccountTypeMultisig
 }
 
 // Returns true if an account is a wallet account that is ready for sending transactions, otherwise returns false.
@@ -371,6 +378,7 @@ func (db *Database) processRows(rows *sql.Rows) ([]*Keypair, []*Account, error)
 		accProdPreferredChainIDs sql.NullString
 		accTestPreferredChainIDs sql.NullString
 		accAddressWasNotShown    sql.NullBool
+		accMultisig              sql.NullBool
 	)
 
 	for rows.Next() {
@@ -381,7 +389,7 @@ func (db *Database) processRows(rows *sql.Rows) ([]*Keypair, []*Account, error)
 			&kpKeyUID, &kpName, &kpType, &kpDerivedFrom, &kpLastUsedDerivationIndex, &kpSyncedFrom, &kpClock, &kpRemoved,
 			&accAddress, &accKeyUID, &pubkey, &accPath, &accName, &accColorID, &accEmoji,
 			&accWallet, &accChat, &accHidden, &accOperable, &accClock, &accCreatedAt, &accPosition, &accRemoved,
-			&accProdPreferredChainIDs, &accTestPreferredChainIDs, &accAddressWasNotShown)
+			&accProdPreferredChainIDs, &accTestPreferredChainIDs, &accAddressWasNotShown, &accMultisig)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -468,6 +476,9 @@ func (db *Database) processRows(rows *sql.Rows) ([]*Keypair, []*Account, error)
 			acc.Removed = accRemoved.Bool
 		}
 		acc.Type = GetAccountTypeForKeypairType(kp.Type)
+		if acc.Type == AccountTypeWatch && accMultisig.Valid && accMultisig.Bool {
+			acc.Type = AccountTypeMultisig
+		}
 
 		if kp.KeyUID != "" {
 			if _, ok := keypairMap[kp.KeyUID]; !ok {
@@ -550,7 +561,8 @@ func (db *Database) getKeypairs(tx *sql.Tx, keyUID string, includeRemoved bool)
 			ka.removed,
 			ka.prod_preferred_chain_ids,
 			ka.test_preferred_chain_ids,
-                        ka.address_was_not_shown
+                        ka.address_was_not_shown,
+			ka.multisig
 		FROM
 			keypairs k
 		LEFT JOIN
@@ -653,7 +665,8 @@ func (db *Database) getAccounts(tx *sql.Tx, address types.Address, includeRemove
 			ka.removed,
 			ka.prod_preferred_chain_ids,
 			ka.test_preferred_chain_ids,
-			ka.address_was_not_shown
+			ka.address_was_not_shown,
+			ka.multisig
 		FROM
 			keypairs_accounts ka
 		LEFT JOIN
@@ -823,7 +836,7 @@ func (db *Database) GetActiveWatchOnlyAccounts() (res []*Account, err error) {
 		return nil, err
 	}
 	for _, acc := range accounts {
-		if acc.Type == AccountTypeWatch {
+		if acc.IsWatchOnly() {
 			res = append(res, acc)
 		}
 	}
@@ -837,7 +850,7 @@ func (db *Database) GetAllWatchOnlyAccounts() (res []*Account, err error) {
 		return nil, err
 	}
 	for _, acc := range accounts {
-		if acc.Type == AccountTypeWatch {
+		if acc.IsWatchOnly() {
 			res = append(res, acc)
 		}
 	}
@@ -1000,7 +1013,7 @@ func (db *Database) saveOrUpdateAccounts(tx *sql.Tx, accounts []*Account, update
 		}
 
 		// Apply default values if account is new and not a watch only
-		if !exists && acc.Type != AccountTypeWatch {
+		if !exists && !acc.IsWatchOnly() {
 			if acc.ProdPreferredChainIDs == "" {
 				acc.ProdPreferredChainIDs = ProdPreferredChainIDsDefault
 			}
@@ -1012,9 +1025,9 @@ func (db *Database) saveOrUpdateAccounts(tx *sql.Tx, accounts []*Account, update
 
 		_, err = tx.Exec(`
 			INSERT OR IGNORE INTO
-				keypairs_accounts (address, key_uid, pubkey, path, wallet, address_was_not_shown, chat, created_at, updated_at)
+				keypairs_accounts (address, key_uid, pubkey, path, wallet, address_was_not_shown, chat, multisig, created_at, updated_at)
 			VALUES
-				(?, ?, ?, ?, ?, ?, ?, datetime('now'), datetime('now'));
+				(?, ?, ?, ?, ?, ?, ?, ?, datetime('now'), datetime('now'));
 
 			UPDATE
 				keypairs_accounts
@@ -1033,7 +1046,7 @@ func (db *Database) saveOrUpdateAccounts(tx *sql.Tx, accounts []*Account, update
 			WHERE
 				address = ?;
 		`,
-			acc.Address, keyUID, acc.PublicKey, acc.Path, acc.Wallet, acc.AddressWasNotShown, acc.Chat,
+			acc.Address, keyUID, acc.PublicKey, acc.Path, acc.Wallet, acc.AddressWasNotShown, acc.Chat, acc.Type == AccountTypeMultisig,
 			acc.Name, acc.ColorID, acc.Emoji, acc.Hidden, acc.Operable, acc.Clock, acc.Position, acc.Removed,
 			acc.ProdPreferredChainIDs, acc.TestPreferredChainIDs, acc.Address)
 
@@ -260,6 +260,49 @@ func TestWatchOnlyAccounts(t *testing.T) {
 	require.True(t, err == ErrDbAccountNotFound)
 }
 
+func TestMultisigAccounts(t *testing.T) {
+	db, stop := setupTestDB(t)
+	defer stop()
+
+	safe := &Account{
+		Address:               types.Address{0x21},
+		Type:                  AccountTypeMultisig,
+		Name:                  "Treasury",
+		ColorID:               common.CustomizationColorPrimary,
+		Emoji:                 "emoji-1",
+		ProdPreferredChainIDs: "10",
+	}
+	err := db.SaveOrUpdateAccounts([]*Account{safe}, false)
+	require.NoError(t, err)
+
+	dbAcc, err := db.GetAccountByAddress(safe.Address)
+	require.NoError(t, err)
+	require.Equal(t, AccountTypeMultisig, dbAcc.Type)
+	require.True(t, dbAcc.IsWatchOnly())
+	require.False(t, dbAcc.IsWalletNonWatchOnlyAccount())
+	// the default chains are not applied, a Safe is only deployed on some chains
+	require.Equal(t, "10", dbAcc.ProdPreferredChainIDs)
+	require.Equal(t, "", dbAcc.TestPreferredChainIDs)
+
+	woAccounts, err := db.GetActiveWatchOnlyAccounts()
+	require.NoError(t, err)
+	require.Len(t, woAccounts, 1)
+
+	// the type is kept when the account is updated as a watch only account, e.g. when it's received from a paired device
+	err = db.SaveOrUpdateAccounts([]*Account{{
+		Address: safe.Address,
+		Type:    AccountTypeWatch,
+		Name:    "Treasury updated",
+		ColorID: common.CustomizationColorPrimary,
+		Emoji:   "emoji-1",
+	}}, false)
+	require.NoError(t, err)
+	dbAcc, err = db.GetAccountByAddress(safe.Address)
+	require.NoError(t, err)
+	require.Equal(t, AccountTypeMultisig, dbAcc.Type)
+	require.Equal(t, "Treasury updated", dbAcc.Name)
+}
+
 func TestUpdateKeypairName(t *testing.T) {
 	db, stop := setupTestDB(t)
 	defer stop()
@@ -111,6 +111,11 @@ func (b *StatusNode) initServices(config *params.NodeConfig, mediaServer *server
 	if config.WalletConfig.Enabled {
 		walletService := b.walletService(accDB, b.appDB, &b.accountsFeed, settingsFeed, &b.walletFeed, config.WalletConfig.StatusProxyStageName)
 		services = append(services, walletService)
+
+		// Connector adds the tokens suggested by dApps to the wallet
+		if b.connectorSrvc != nil {
+			b.connectorSrvc.SetTokenManager(walletService.GetTokenManager())
+		}
 	}
 
 	// CollectiblesManager needs the WakuExt service to get metadata for
@@ -558,6 +558,12 @@ type WalletConfig struct {
 	StatusProxyEnabled            bool              `json:"StatusProxyEnabled"`
 	StatusProxyStageName          string            `json:"StatusProxyStageName"`
 	EnableCelerBridge             bool              `json:"EnableCelerBridge"`
+	// BundlerURLs are the ERC-4337 bundler endpoints per chain, smart accounts can be used only on chains with a bundler
+	BundlerURLs map[uint64]string `json:"BundlerURLs"`
+	// PaymasterURLs are the optional paymaster endpoints per chain, used to sponsor the smart accounts user operations
+	PaymasterURLs map[uint64]string `json:"PaymasterURLs"`
+	// ZeroExAPIKey enables 0x as an additional swap quote provider
+	ZeroExAPIKey string `json:"ZeroExAPIKey"`
 }
 
 // MarshalJSON custom marshalling to avoid exposing sensitive data in log,
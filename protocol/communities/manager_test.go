@@ -113,6 +113,7 @@ func (s *ManagerSuite) getHistoryTasksCount() int {
 
 type testCollectiblesManager struct {
 	response map[uint64]map[gethcommon.Address]thirdparty.TokenBalancesPerContractAddress
+	traits   map[string][]thirdparty.CollectibleTrait
 }
 
 func (m *testCollectiblesManager) setResponse(chainID uint64, walletAddress gethcommon.Address, contractAddress gethcommon.Address, balances []thirdparty.TokenBalance) {
@@ -129,6 +130,13 @@ func (m *testCollectiblesManager) setResponse(chainID uint64, walletAddress geth
 	m.response[chainID][walletAddress][contractAddress] = balances
 }
 
+func (m *testCollectiblesManager) setTraits(id thirdparty.CollectibleUniqueID, traits []thirdparty.CollectibleTrait) {
+	if m.traits == nil {
+		m.traits = make(map[string][]thirdparty.CollectibleTrait)
+	}
+	m.traits[id.HashKey()] = traits
+}
+
 func (m *testCollectiblesManager) FetchBalancesByOwnerAndContractAddress(ctx context.Context, chainID walletCommon.ChainID, ownerAddress gethcommon.Address, contractAddresses []gethcommon.Address) (thirdparty.TokenBalancesPerContractAddress, error) {
 	return m.response[uint64(chainID)][ownerAddress], nil
 }
@@ -163,6 +171,20 @@ func (m *testCollectiblesManager) FetchCollectibleOwnersByContractAddress(ctx co
 	return ret, nil
 }
 
+func (m *testCollectiblesManager) FetchAssetsByCollectibleUniqueID(ctx context.Context, uniqueIDs []thirdparty.CollectibleUniqueID, asyncFetch bool) ([]thirdparty.FullCollectibleData, error) {
+	ret := make([]thirdparty.FullCollectibleData, 0, len(uniqueIDs))
+	for _, id := range uniqueIDs {
+		// like the collectibles manager, only the ID is set for the collectibles which aren't cached
+		data := thirdparty.CollectibleData{ID: id}
+		if traits, ok := m.traits[id.HashKey()]; ok {
+			data.Provider = "test"
+			data.Traits = traits
+		}
+		ret = append(ret, thirdparty.FullCollectibleData{CollectibleData: data})
+	}
+	return ret, nil
+}
+
 func (m *testCollectiblesManager) FetchCachedBalancesByOwnerAndContractAddress(ctx context.Context, chainID walletCommon.ChainID, ownerAddress gethcommon.Address, contractAddresses []gethcommon.Address) (thirdparty.TokenBalancesPerContractAddress, error) {
 	return m.response[uint64(chainID)][ownerAddress], nil
 }
@@ -340,6 +362,187 @@ func (s *ManagerSuite) TestRetrieveCollectibles() {
 	s.Require().False(resp.Satisfied)
 }
 
+func (s *ManagerSuite) TestRetrieveERC1155Collectibles() {
+	m, cm, _ := s.setupManagerForTokenPermissions()
+
+	var chainID uint64 = 5
+	contractAddress := gethcommon.HexToAddress("0x3d6afaa395c31fcd391fe3d562e75fe9e8ec7e6a")
+	walletAddresses := []gethcommon.Address{
+		gethcommon.HexToAddress("0xD6b912e09E797D291E8D0eA3D3D17F8000e01c32"),
+		gethcommon.HexToAddress("0x2"),
+	}
+
+	var permissions = []*CommunityTokenPermission{
+		{
+			CommunityTokenPermission: &protobuf.CommunityTokenPermission{
+				Id:   "some-id",
+				Type: protobuf.CommunityTokenPermission_BECOME_MEMBER,
+				TokenCriteria: []*protobuf.TokenCriteria{
+					{
+						ContractAddresses: map[uint64]string{chainID: contractAddress.Hex()},
+						TokenIds:          []uint64{7},
+						Type:              protobuf.CommunityTokenType_ERC1155,
+						AmountInWei:       "3",
+					},
+				},
+			},
+		},
+	}
+
+	preParsedPermissions := preParsedCommunityPermissionsData(permissions)
+
+	accountChainIDsCombination := []*AccountChainIDsCombination{
+		{
+			Address:  walletAddresses[0],
+			ChainIDs: []uint64{chainID},
+		},
+		{
+			Address:  walletAddresses[1],
+			ChainIDs: []uint64{chainID},
+		},
+	}
+
+	// Only the balance of the badge is counted
+	cm.setResponse(chainID, walletAddresses[0], contractAddress, []thirdparty.TokenBalance{tokenBalance(7, 2), tokenBalance(8, 5)})
+	resp, err := m.PermissionChecker.CheckPermissions(preParsedPermissions, accountChainIDsCombination, false)
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.Require().False(resp.Satisfied)
+
+	// The balances of the accounts are summed
+	cm.setResponse(chainID, walletAddresses[1], contractAddress, []thirdparty.TokenBalance{tokenBalance(7, 1)})
+	resp, err = m.PermissionChecker.CheckPermissions(preParsedPermissions, accountChainIDsCombination, false)
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.Require().True(resp.Satisfied)
+	s.Require().Len(resp.ValidCombinations, 2)
+}
+
+func (s *ManagerSuite) TestRetrieveCollectiblesWithTraits() {
+	m, cm, _ := s.setupManagerForTokenPermissions()
+
+	var chainID uint64 = 5
+	contractAddress := gethcommon.HexToAddress("0x3d6afaa395c31fcd391fe3d562e75fe9e8ec7e6a")
+	walletAddress := gethcommon.HexToAddress("0xD6b912e09E797D291E8D0eA3D3D17F8000e01c32")
+
+	var permissions = []*CommunityTokenPermission{
+		{
+			CommunityTokenPermission: &protobuf.CommunityTokenPermission{
+				Id:   "some-id",
+				Type: protobuf.CommunityTokenPermission_BECOME_MEMBER,
+				TokenCriteria: []*protobuf.TokenCriteria{
+					{
+						ContractAddresses: map[uint64]string{chainID: contractAddress.Hex()},
+						Type:              protobuf.CommunityTokenType_ERC721,
+						AmountInWei:       "1",
+						Traits: []*protobuf.TokenTrait{
+							{TraitType: "Background", Value: "Gold"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	preParsedPermissions := preParsedCommunityPermissionsData(permissions)
+
+	accountChainIDsCombination := []*AccountChainIDsCombination{
+		{
+			Address:  walletAddress,
+			ChainIDs: []uint64{chainID},
+		},
+	}
+
+	collectibleID := func(tokenID uint64) thirdparty.CollectibleUniqueID {
+		return thirdparty.CollectibleUniqueID{
+			ContractID: thirdparty.ContractID{
+				ChainID: walletCommon.ChainID(chainID),
+				Address: contractAddress,
+			},
+			TokenID: uintToDecBig(tokenID),
+		}
+	}
+
+	cm.setResponse(chainID, walletAddress, contractAddress, []thirdparty.TokenBalance{tokenBalance(1, 1), tokenBalance(2, 1)})
+	cm.setTraits(collectibleID(1), []thirdparty.CollectibleTrait{{TraitType: "Background", Value: "Silver"}})
+	cm.setTraits(collectibleID(2), []thirdparty.CollectibleTrait{{TraitType: "Eyes", Value: "Gold"}})
+
+	resp, err := m.PermissionChecker.CheckPermissions(preParsedPermissions, accountChainIDsCombination, false)
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.Require().False(resp.Satisfied)
+
+	cm.setTraits(collectibleID(2), []thirdparty.CollectibleTrait{{TraitType: "Eyes", Value: "Gold"}, {TraitType: "background", Value: "gold"}})
+
+	resp, err = m.PermissionChecker.CheckPermissions(preParsedPermissions, accountChainIDsCombination, false)
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.Require().True(resp.Satisfied)
+}
+
+func (s *ManagerSuite) TestRetrieveCollectiblesWithTraitsNotCached() {
+	m, cm, _ := s.setupManagerForTokenPermissions()
+
+	var chainID uint64 = 5
+	contractAddress := gethcommon.HexToAddress("0x3d6afaa395c31fcd391fe3d562e75fe9e8ec7e6a")
+	walletAddress := gethcommon.HexToAddress("0xD6b912e09E797D291E8D0eA3D3D17F8000e01c32")
+
+	var permissions = []*CommunityTokenPermission{
+		{
+			CommunityTokenPermission: &protobuf.CommunityTokenPermission{
+				Id:   "some-id",
+				Type: protobuf.CommunityTokenPermission_BECOME_MEMBER,
+				TokenCriteria: []*protobuf.TokenCriteria{
+					{
+						ContractAddresses: map[uint64]string{chainID: contractAddress.Hex()},
+						Type:              protobuf.CommunityTokenType_ERC721,
+						AmountInWei:       "1",
+						Traits: []*protobuf.TokenTrait{
+							{TraitType: "Background", Value: "Gold"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	preParsedPermissions := preParsedCommunityPermissionsData(permissions)
+
+	accountChainIDsCombination := []*AccountChainIDsCombination{
+		{
+			Address:  walletAddress,
+			ChainIDs: []uint64{chainID},
+		},
+	}
+
+	collectibleID := func(tokenID uint64) thirdparty.CollectibleUniqueID {
+		return thirdparty.CollectibleUniqueID{
+			ContractID: thirdparty.ContractID{
+				ChainID: walletCommon.ChainID(chainID),
+				Address: contractAddress,
+			},
+			TokenID: uintToDecBig(tokenID),
+		}
+	}
+
+	// the metadata of the collectible isn't cached yet, its traits are unknown and don't match the criteria
+	cm.setResponse(chainID, walletAddress, contractAddress, []thirdparty.TokenBalance{tokenBalance(1, 1)})
+
+	resp, err := m.PermissionChecker.CheckPermissions(preParsedPermissions, accountChainIDsCombination, false)
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.Require().False(resp.Satisfied)
+
+	// the other collectibles are still checked
+	cm.setResponse(chainID, walletAddress, contractAddress, []thirdparty.TokenBalance{tokenBalance(1, 1), tokenBalance(2, 1)})
+	cm.setTraits(collectibleID(2), []thirdparty.CollectibleTrait{{TraitType: "Background", Value: "Gold"}})
+
+	resp, err = m.PermissionChecker.CheckPermissions(preParsedPermissions, accountChainIDsCombination, false)
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.Require().True(resp.Satisfied)
+}
+
 func (s *ManagerSuite) TestCreateCommunity() {
 	request := &requests.CreateCommunity{
 		Name:        "status",
@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -110,6 +111,10 @@ func (p *DefaultPermissionChecker) getOwnedERC721Tokens(walletAddresses []gethco
 	return ownedERC721Tokens, nil
 }
 
+func (p *DefaultPermissionChecker) getCollectiblesTraits(ids []thirdparty.CollectibleUniqueID) (collectiblesTraits, error) {
+	return fetchCollectiblesTraits(p.logger, p.collectiblesManager, ids)
+}
+
 func (p *DefaultPermissionChecker) accountChainsCombinationToMap(combinations []*AccountChainIDsCombination) map[gethcommon.Address][]uint64 {
 	result := make(map[gethcommon.Address][]uint64)
 	for _, combination := range combinations {
@@ -221,10 +226,145 @@ func (p *DefaultPermissionChecker) checkPermissionsOrDefault(permissions []*Comm
 
 type ownedERC721TokensGetter = func(walletAddresses []gethcommon.Address, tokenRequirements map[uint64]map[string]*protobuf.TokenCriteria, chainIDs []uint64) (CollectiblesByChain, error)
 type balancesByChainGetter = func(ctx context.Context, accounts, tokens []gethcommon.Address, chainIDs []uint64) (BalancesByChain, error)
+type collectiblesTraitsGetter = func(ids []thirdparty.CollectibleUniqueID) (collectiblesTraits, error)
+
+// collectiblesTraits holds the traits of the collectibles by CollectibleUniqueID.HashKey()
+type collectiblesTraits = map[string][]thirdparty.CollectibleTrait
+
+// collectiblesTraitsFetchTimeout bounds the wait for the metadata of the collectibles not cached yet
+const collectiblesTraitsFetchTimeout = 30 * time.Second
+
+// fetchCollectiblesTraits waits for the metadata of the collectibles not cached yet. The traits of a collectible whose
+// metadata is still missing are unknown, the collectible is left out so that it doesn't match the trait criteria while
+// the other collectibles are still checked.
+func fetchCollectiblesTraits(logger *zap.Logger, collectiblesManager CollectiblesManager, ids []thirdparty.CollectibleUniqueID) (collectiblesTraits, error) {
+	if collectiblesManager == nil {
+		return nil, errors.New("no collectibles manager")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), collectiblesTraitsFetchTimeout)
+	defer cancel()
+
+	// `asyncFetch` makes the call wait for the missing metadata to be fetched
+	collectibles, err := collectiblesManager.FetchAssetsByCollectibleUniqueID(ctx, ids, true)
+	if err != nil {
+		return nil, err
+	}
+
+	traits := make(collectiblesTraits, len(collectibles))
+	for _, collectible := range collectibles {
+		id := collectible.CollectibleData.ID
+		// the data of a collectible which isn't cached has only the ID set
+		if collectible.CollectibleData.Provider == "" {
+			logger.Debug("collectible traits unknown, its metadata isn't fetched yet", zap.String("id", id.HashKey()))
+			continue
+		}
+		traits[id.HashKey()] = collectible.CollectibleData.Traits
+	}
+	return traits, nil
+}
+
+// collectiblesWithTraitCriteria returns the owned collectibles whose traits are needed to check the ERC721 criteria
+func collectiblesWithTraitCriteria(permissions []*CommunityTokenPermission, ownedERC721Tokens CollectiblesByChain) []thirdparty.CollectibleUniqueID {
+	ids := make([]thirdparty.CollectibleUniqueID, 0)
+	added := make(map[string]bool)
+
+	for _, permission := range permissions {
+		for _, criteria := range permission.TokenCriteria {
+			if criteria.Type != protobuf.CommunityTokenType_ERC721 || len(criteria.Traits) == 0 {
+				continue
+			}
+
+			for chainID, hexContractAddress := range criteria.ContractAddresses {
+				contractAddress := gethcommon.HexToAddress(hexContractAddress)
+				for _, balancesByContract := range ownedERC721Tokens[chainID] {
+					for _, tokenBalance := range balancesByContract[contractAddress] {
+						id := thirdparty.CollectibleUniqueID{
+							ContractID: thirdparty.ContractID{
+								ChainID: walletcommon.ChainID(chainID),
+								Address: contractAddress,
+							},
+							TokenID: tokenBalance.TokenID,
+						}
+						if added[id.HashKey()] {
+							continue
+						}
+						added[id.HashKey()] = true
+						ids = append(ids, id)
+					}
+				}
+			}
+		}
+	}
+
+	return ids
+}
+
+func hasTraits(tokenTraits []thirdparty.CollectibleTrait, requiredTraits []*protobuf.TokenTrait) bool {
+	for _, requiredTrait := range requiredTraits {
+		found := false
+		for _, tokenTrait := range tokenTraits {
+			if strings.EqualFold(tokenTrait.TraitType, requiredTrait.TraitType) && strings.EqualFold(tokenTrait.Value, requiredTrait.Value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// filterTokenBalancesByTraits keeps the tokens having all the traits of the criteria, a token with unknown traits has none
+func filterTokenBalancesByTraits(tokenBalances []thirdparty.TokenBalance, chainID uint64, contractAddress gethcommon.Address, criteria *protobuf.TokenCriteria, traits collectiblesTraits) []thirdparty.TokenBalance {
+	if len(criteria.Traits) == 0 {
+		return tokenBalances
+	}
+
+	filtered := make([]thirdparty.TokenBalance, 0, len(tokenBalances))
+	for _, tokenBalance := range tokenBalances {
+		id := thirdparty.CollectibleUniqueID{
+			ContractID: thirdparty.ContractID{
+				ChainID: walletcommon.ChainID(chainID),
+				Address: contractAddress,
+			},
+			TokenID: tokenBalance.TokenID,
+		}
+		if hasTraits(traits[id.HashKey()], criteria.Traits) {
+			filtered = append(filtered, tokenBalance)
+		}
+	}
+	return filtered
+}
+
+// erc1155Balance sums the balances of the token IDs of the criteria, or of all the tokens if there are none
+func erc1155Balance(tokenBalances []thirdparty.TokenBalance, tokenIDs []uint64) *big.Int {
+	balance := new(big.Int)
+	for _, tokenBalance := range tokenBalances {
+		if tokenBalance.Balance == nil || tokenBalance.Balance.Sign() <= 0 {
+			continue
+		}
+
+		matches := len(tokenIDs) == 0
+		for _, tokenID := range tokenIDs {
+			if tokenBalance.TokenID.Cmp(new(big.Int).SetUint64(tokenID)) == 0 {
+				matches = true
+				break
+			}
+		}
+
+		if matches {
+			balance.Add(balance, tokenBalance.Balance.Int)
+		}
+	}
+	return balance
+}
 
 func (p *DefaultPermissionChecker) checkTokenRequirement(
 	tokenRequirement *protobuf.TokenCriteria,
 	accounts []gethcommon.Address, ownedERC20TokenBalances BalancesByChain, ownedERC721Tokens CollectiblesByChain,
+	ownedCollectiblesTraits collectiblesTraits,
 	accountsChainIDsCombinations map[gethcommon.Address]map[uint64]bool,
 ) (TokenRequirementResponse, error) {
 	tokenRequirementResponse := TokenRequirementResponse{TokenCriteria: tokenRequirement}
@@ -255,7 +395,7 @@ func (p *DefaultPermissionChecker) checkTokenRequirement(
 					continue
 				}
 
-				tokenBalances := ownedERC721Tokens[chainID][account][contractAddress]
+				tokenBalances := filterTokenBalancesByTraits(ownedERC721Tokens[chainID][account][contractAddress], chainID, contractAddress, tokenRequirement, ownedCollectiblesTraits)
 				accumulatedCount += uint64(len(tokenBalances))
 
 				if len(tokenBalances) > 0 {
@@ -290,6 +430,42 @@ func (p *DefaultPermissionChecker) checkTokenRequirement(
 			}
 		}
 
+	case protobuf.CommunityTokenType_ERC1155:
+
+		if len(ownedERC721Tokens) == 0 {
+			return tokenRequirementResponse, nil
+		}
+
+		requiredAmount, success := new(big.Int).SetString(tokenRequirement.AmountInWei, 10)
+		if !success {
+			return tokenRequirementResponse, fmt.Errorf("invalid ERC1155 amount: %s", tokenRequirement.AmountInWei)
+		}
+		accumulatedBalance := new(big.Int)
+
+		for chainID, addressStr := range tokenRequirement.ContractAddresses {
+			contractAddress := gethcommon.HexToAddress(addressStr)
+
+			for account, balancesByContract := range ownedERC721Tokens[chainID] {
+				balance := erc1155Balance(balancesByContract[contractAddress], tokenRequirement.TokenIds)
+				if balance.Sign() == 0 {
+					continue
+				}
+
+				if _, exists := accountsChainIDsCombinations[account]; !exists {
+					accountsChainIDsCombinations[account] = make(map[uint64]bool)
+				}
+
+				// account owns some of the required tokens on this chain, so let's add it the chain IDs
+				accountsChainIDsCombinations[account][chainID] = true
+
+				accumulatedBalance.Add(accumulatedBalance, balance)
+				if accumulatedBalance.Cmp(requiredAmount) >= 0 {
+					tokenRequirementResponse.Satisfied = true
+					return tokenRequirementResponse, nil
+				}
+			}
+		}
+
 	case protobuf.CommunityTokenType_ERC20:
 
 		if len(ownedERC20TokenBalances) == 0 {
@@ -375,7 +551,7 @@ func (p *DefaultPermissionChecker) checkTokenRequirement(
 }
 
 func (p *DefaultPermissionChecker) checkPermissions(permissionsParsedData *PreParsedCommunityPermissionsData, accountsAndChainIDs []*AccountChainIDsCombination, shortcircuit bool,
-	getOwnedERC721Tokens ownedERC721TokensGetter, getBalancesByChain balancesByChainGetter) (*CheckPermissionsResponse, error) {
+	getOwnedERC721Tokens ownedERC721TokensGetter, getBalancesByChain balancesByChainGetter, getCollectiblesTraits collectiblesTraitsGetter) (*CheckPermissionsResponse, error) {
 
 	response := &CheckPermissionsResponse{
 		Satisfied:         false,
@@ -431,6 +607,16 @@ func (p *DefaultPermissionChecker) checkPermissions(permissionsParsedData *PrePa
 		ownedERC721Tokens = collectibles
 	}
 
+	// the metadata is only fetched for the collectibles checked against traits
+	ownedCollectiblesTraits := make(collectiblesTraits)
+	if ids := collectiblesWithTraitCriteria(permissionsParsedData.Permissions, ownedERC721Tokens); len(ids) > 0 {
+		traits, err := getCollectiblesTraits(ids)
+		if err != nil {
+			return nil, err
+		}
+		ownedCollectiblesTraits = traits
+	}
+
 	accountsChainIDsCombinations := make(map[gethcommon.Address]map[uint64]bool)
 
 	for _, tokenPermission := range permissionsParsedData.Permissions {
@@ -441,7 +627,7 @@ func (p *DefaultPermissionChecker) checkPermissions(permissionsParsedData *PrePa
 		// If only one is not met, the entire permission is marked
 		// as not fulfilled
 		for _, tokenRequirement := range tokenPermission.TokenCriteria {
-			tokenRequirementResponse, err := p.checkTokenRequirement(tokenRequirement, accounts, ownedERC20TokenBalances, ownedERC721Tokens, accountsChainIDsCombinations)
+			tokenRequirementResponse, err := p.checkTokenRequirement(tokenRequirement, accounts, ownedERC20TokenBalances, ownedERC721Tokens, ownedCollectiblesTraits, accountsChainIDsCombinations)
 			if err != nil {
 				p.logger.Error("failed to check token requirement", zap.Error(err))
 			}
@@ -489,7 +675,7 @@ func (p *DefaultPermissionChecker) handlePermissionsCheck(permissionsParsedData
 		return p.getOwnedERC721Tokens(walletAddresses, tokenRequirements, chainIDs, getBalancesByOwnerAndContractAddress)
 	}
 
-	return p.checkPermissions(permissionsParsedData, accountsAndChainIDs, shortcircuit, getOwnedERC721Tokens, getBalancesByChain)
+	return p.checkPermissions(permissionsParsedData, accountsAndChainIDs, shortcircuit, getOwnedERC721Tokens, getBalancesByChain, p.getCollectiblesTraits)
 }
 
 func (p *DefaultPermissionChecker) CheckCachedPermissions(permissionsParsedData *PreParsedCommunityPermissionsData, accountsAndChainIDs []*AccountChainIDsCombination, shortcircuit bool) (*CheckPermissionsResponse, error) {
@@ -536,7 +722,7 @@ func (p *DefaultPermissionChecker) CheckPermissionsWithPreFetchedData(permission
 		return p.getOwnedERC721Tokens(walletAddresses, tokenRequirements, chainIDs, getCollectiblesBalances)
 	}
 
-	return p.checkPermissions(permissionsParsedData, accountsAndChainIDs, shortcircuit, getOwnedERC721Tokens, p.tokenManager.GetBalancesByChain)
+	return p.checkPermissions(permissionsParsedData, accountsAndChainIDs, shortcircuit, getOwnedERC721Tokens, p.tokenManager.GetBalancesByChain, p.getCollectiblesTraits)
 }
 
 func preParsedPermissionsData(permissions []*CommunityTokenPermission) *PreParsedPermissionsData {
@@ -207,7 +207,7 @@ func (s *PermissionCheckerSuite) TestCheckPermissions() {
 					}, nil
 				}
 
-				response, err := permissionChecker.checkPermissions(permissionsData[protobuf.CommunityTokenPermission_BECOME_MEMBER], accountsAndChainIDs, true, getOwnedERC721Tokens, getBalancesByChain)
+				response, err := permissionChecker.checkPermissions(permissionsData[protobuf.CommunityTokenPermission_BECOME_MEMBER], accountsAndChainIDs, true, getOwnedERC721Tokens, getBalancesByChain, nil)
 				s.Require().NoError(err)
 				s.Require().Equal(tc.shouldSatisfy, response.Satisfied)
 			})
@@ -108,6 +108,7 @@ func isERC721CriteriaSatisfied(tokenBalances []thirdparty.TokenBalance, criteria
 func (m *Manager) calculatePermissionedBalancesERC721(
 	accountAddresses []gethcommon.Address,
 	balances CollectiblesByChain,
+	traits collectiblesTraits,
 	tokenPermissions []*CommunityTokenPermission,
 ) map[gethcommon.Address]map[string]*PermissionedBalance {
 	res := make(map[gethcommon.Address]map[string]*PermissionedBalance)
@@ -118,7 +119,7 @@ func (m *Manager) calculatePermissionedBalancesERC721(
 
 	for _, permission := range tokenPermissions {
 		for _, criteria := range permission.TokenCriteria {
-			if criteria.Type != protobuf.CommunityTokenType_ERC721 {
+			if criteria.Type != protobuf.CommunityTokenType_ERC721 && criteria.Type != protobuf.CommunityTokenType_ERC1155 {
 				continue
 			}
 
@@ -138,6 +139,7 @@ func (m *Manager) calculatePermissionedBalancesERC721(
 					if !ok || len(tokenBalances) == 0 {
 						continue
 					}
+					tokenBalances = filterTokenBalancesByTraits(tokenBalances, chainID, contractAddress, criteria, traits)
 
 					// Skip the contract address if it has been used already in the sum.
 					if _, ok := usedBalances[usedKey]; ok {
@@ -159,7 +161,12 @@ func (m *Manager) calculatePermissionedBalancesERC721(
 						}
 					}
 
-					if isERC721CriteriaSatisfied(tokenBalances, criteria) {
+					if criteria.Type == protobuf.CommunityTokenType_ERC1155 {
+						res[accountAddress][criteria.Symbol].Amount.Add(
+							res[accountAddress][criteria.Symbol].Amount.Int,
+							erc1155Balance(tokenBalances, criteria.TokenIds),
+						)
+					} else if len(tokenBalances) > 0 && isERC721CriteriaSatisfied(tokenBalances, criteria) {
 						// We don't care about summing balances, thus setting as 1 is
 						// sufficient.
 						res[accountAddress][criteria.Symbol].Amount = &bigint.BigInt{Int: big.NewInt(1)}
@@ -177,11 +184,12 @@ func (m *Manager) calculatePermissionedBalances(
 	accountAddresses []gethcommon.Address,
 	erc20Balances BalancesByChain,
 	erc721Balances CollectiblesByChain,
+	erc721Traits collectiblesTraits,
 	tokenPermissions []*CommunityTokenPermission,
 ) map[gethcommon.Address][]PermissionedBalance {
 	res := make(map[gethcommon.Address][]PermissionedBalance, 0)
 
-	aggregatedERC721Balances := m.calculatePermissionedBalancesERC721(accountAddresses, erc721Balances, tokenPermissions)
+	aggregatedERC721Balances := m.calculatePermissionedBalancesERC721(accountAddresses, erc721Balances, erc721Traits, tokenPermissions)
 	for accountAddress, tokens := range aggregatedERC721Balances {
 		for _, permissionedToken := range tokens {
 			if permissionedToken.Amount.Sign() > 0 {
@@ -280,5 +288,14 @@ func (m *Manager) GetPermissionedBalances(
 		erc721Balances = balances
 	}
 
-	return m.calculatePermissionedBalances(allChainIDs, accountAddresses, erc20Balances, erc721Balances, tokenPermissions), nil
+	erc721Traits := make(collectiblesTraits)
+	if ids := collectiblesWithTraitCriteria(tokenPermissions, erc721Balances); len(ids) > 0 {
+		traits, err := fetchCollectiblesTraits(m.logger, m.collectiblesManager, ids)
+		if err != nil {
+			return nil, err
+		}
+		erc721Traits = traits
+	}
+
+	return m.calculatePermissionedBalances(allChainIDs, accountAddresses, erc20Balances, erc721Balances, erc721Traits, tokenPermissions), nil
 }
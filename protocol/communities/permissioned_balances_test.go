@@ -205,6 +205,7 @@ func (s *ManagerSuite) Test_calculatePermissionedBalances() {
 		accountAddresses,
 		erc20Balances,
 		erc721Balances,
+		nil,
 		tokenPermissions,
 	)
 
@@ -22,7 +22,8 @@ func ExtractTokenCriteria(permissions []*CommunityTokenPermission) (erc20TokenCr
 	for _, tokenPermission := range permissions {
 		for _, tokenRequirement := range tokenPermission.TokenCriteria {
 
-			isERC721 := tokenRequirement.Type == protobuf.CommunityTokenType_ERC721
+			// ERC1155 tokens are collectibles too, their balances are fetched along the ERC721 ones
+			isERC721 := tokenRequirement.Type == protobuf.CommunityTokenType_ERC721 || tokenRequirement.Type == protobuf.CommunityTokenType_ERC1155
 			isERC20 := tokenRequirement.Type == protobuf.CommunityTokenType_ERC20
 			isENS := tokenRequirement.Type == protobuf.CommunityTokenType_ENS
 
@@ -181,6 +181,10 @@ func (m *CollectiblesManagerMock) FetchCollectibleOwnersByContractAddress(ctx co
 	return ret, nil
 }
 
+func (m *CollectiblesManagerMock) FetchAssetsByCollectibleUniqueID(ctx context.Context, uniqueIDs []thirdparty.CollectibleUniqueID, asyncFetch bool) ([]thirdparty.FullCollectibleData, error) {
+	return []thirdparty.FullCollectibleData{}, nil
+}
+
 func (m *CollectiblesManagerMock) SetCollectibleOwnershipResponse(id thirdparty.CollectibleUniqueID, balances []thirdparty.AccountBalance) {
 	if m.collectibleOwnershipResponse == nil {
 		m.collectibleOwnershipResponse = map[string][]thirdparty.AccountBalance{}
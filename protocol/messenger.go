@@ -69,7 +69,9 @@ import (
 	localnotifications "github.com/status-im/status-go/services/local-notifications"
 	mailserversDB "github.com/status-im/status-go/services/mailservers"
 	"github.com/status-im/status-go/services/wallet"
+	"github.com/status-im/status-go/services/wallet/activity"
 	"github.com/status-im/status-go/services/wallet/community"
+	"github.com/status-im/status-go/services/wallet/safe"
 	"github.com/status-im/status-go/services/wallet/token"
 	"github.com/status-im/status-go/signal"
 	"github.com/status-im/status-go/telemetry"
@@ -168,6 +170,8 @@ type Messenger struct {
 	contractMaker         *contracts.ContractMaker
 	verificationDatabase  *verification.Persistence
 	savedAddressesManager *wallet.SavedAddressesManager
+	activityNotesManager  *activity.NotesManager
+	safePersistence       *safe.Persistence
 	walletAPI             *wallet.API
 
 	// TODO(samyoul) Determine if/how the remaining usage of this mutex can be removed
@@ -564,6 +568,11 @@ func NewMessenger(
 	}
 
 	savedAddressesManager := wallet.NewSavedAddressesManager(c.walletDb)
+	activityNotesManager := activity.NewNotesManager(c.walletDb)
+	var safePersistence *safe.Persistence
+	if c.walletDb != nil {
+		safePersistence = safe.NewPersistence(c.walletDb)
+	}
 
 	selfContact, err := buildSelfContact(identity, settings, c.multiAccount, c.account)
 	if err != nil {
@@ -669,6 +678,8 @@ func NewMessenger(
 		},
 		logger:                           logger,
 		savedAddressesManager:            savedAddressesManager,
+		activityNotesManager:             activityNotesManager,
+		safePersistence:                  safePersistence,
 		retrievedMessagesIteratorFactory: NewDefaultMessagesIterator,
 	}
 
@@ -952,6 +963,11 @@ func (m *Messenger) Start() (*MessengerResponse, error) {
 		return nil, err
 	}
 
+	err = m.garbageCollectRemovedActivityNotes()
+	if err != nil {
+		return nil, err
+	}
+
 	displayName, err := m.settings.DisplayName()
 	if err != nil {
 		return nil, err
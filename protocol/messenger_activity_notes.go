@@ -0,0 +1,106 @@
+package protocol
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/status-im/status-go/protocol/common"
+	"github.com/status-im/status-go/protocol/protobuf"
+	v1protocol "github.com/status-im/status-go/protocol/v1"
+	"github.com/status-im/status-go/services/wallet/activity"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+)
+
+// SetActivityNote attaches a private note and label to the transaction, an empty note and label removes it.
+// The note is synced to the paired devices
+func (m *Messenger) SetActivityNote(ctx context.Context, note activity.Note) error {
+	note.UpdateClock, _ = m.getLastClockWithRelatedChat()
+	_, err := m.activityNotesManager.SetNoteIfNewer(note)
+	if err != nil {
+		return err
+	}
+
+	stored, err := m.activityNotesManager.GetNote(note.ChainID, note.TxHash)
+	if err != nil {
+		return err
+	}
+	if stored == nil {
+		stored = &activity.Note{
+			ChainID:     note.ChainID,
+			TxHash:      note.TxHash,
+			Removed:     true,
+			UpdateClock: note.UpdateClock,
+		}
+	}
+	return m.syncActivityNote(ctx, stored, m.dispatchMessage)
+}
+
+// GetActivityNotes returns the notes of the transactions, all notes if no transaction is provided
+func (m *Messenger) GetActivityNotes(txHashes []gethcommon.Hash) ([]*activity.Note, error) {
+	return m.activityNotesManager.GetNotes(txHashes)
+}
+
+func (m *Messenger) garbageCollectRemovedActivityNotes() error {
+	return m.activityNotesManager.DeleteSoftRemovedNotes(uint64(time.Now().AddDate(0, 0, -30).Unix()))
+}
+
+func (m *Messenger) syncActivityNote(ctx context.Context, note *activity.Note, rawMessageHandler RawMessageHandler) error {
+	if !m.hasPairedDevices() {
+		return nil
+	}
+
+	clock, chat := m.getLastClockWithRelatedChat()
+
+	encodedMessage, err := proto.Marshal(&protobuf.SyncActivityNote{
+		ChainId:     uint64(note.ChainID),
+		TxHash:      note.TxHash.Bytes(),
+		Note:        note.Note,
+		Label:       note.Label,
+		Removed:     note.Removed,
+		UpdateClock: note.UpdateClock,
+	})
+	if err != nil {
+		return err
+	}
+
+	rawMessage := common.RawMessage{
+		LocalChatID: chat.ID,
+		Payload:     encodedMessage,
+		MessageType: protobuf.ApplicationMetadataMessage_SYNC_ACTIVITY_NOTE,
+		ResendType:  common.ResendTypeDataSync,
+	}
+
+	_, err = rawMessageHandler(ctx, rawMessage)
+	if err != nil {
+		return err
+	}
+
+	chat.LastClockValue = clock
+	return m.saveChat(chat)
+}
+
+func (m *Messenger) HandleSyncActivityNote(state *ReceivedMessageState, syncMessage *protobuf.SyncActivityNote, statusMessage *v1protocol.StatusMessage) error {
+	note := activity.Note{
+		ChainID:     walletCommon.ChainID(syncMessage.ChainId),
+		TxHash:      gethcommon.BytesToHash(syncMessage.TxHash),
+		Note:        syncMessage.Note,
+		Label:       syncMessage.Label,
+		Removed:     syncMessage.Removed,
+		UpdateClock: syncMessage.UpdateClock,
+	}
+
+	updated, err := m.activityNotesManager.SetNoteIfNewer(note)
+	if err != nil {
+		return err
+	}
+	if updated {
+		if note.Removed || (note.Note == "" && note.Label == "") {
+			note = activity.Note{ChainID: note.ChainID, TxHash: note.TxHash, Removed: true, UpdateClock: note.UpdateClock}
+		}
+		state.Response.AddActivityNote(&note)
+	}
+	return nil
+}
@@ -1336,7 +1336,7 @@ func (m *Messenger) generateCommunityRequestsForSigning(memberPubKey string, com
 
 	msgsToSign := make([]account.SignParams, 0)
 	for _, walletAccount := range walletAccounts {
-		if walletAccount.Chat || walletAccount.Type == accounts.AccountTypeWatch {
+		if walletAccount.Chat || walletAccount.IsWatchOnly() {
 			continue
 		}
 
@@ -1383,7 +1383,7 @@ func (m *Messenger) SignData(signParams []account.SignParams) ([]string, error)
 			return nil, err
 		}
 
-		if account.Chat || account.Type == accounts.AccountTypeWatch {
+		if account.Chat || account.IsWatchOnly() {
 			return nil, errors.New(ErrForbiddenProfileOrWatchOnlyAccount)
 		}
 
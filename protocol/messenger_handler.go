@@ -3372,13 +3372,22 @@ func (m *Messenger) handleSyncWatchOnlyAccount(message *protobuf.SyncAccount, fr
 		}
 	}
 
-	acc := mapSyncAccountToAccount(message, accountOperability, accounts.AccountTypeWatch)
+	accType := accounts.AccountTypeWatch
+	if message.Multisig {
+		accType = accounts.AccountTypeMultisig
+	}
+	acc := mapSyncAccountToAccount(message, accountOperability, accType)
 
 	err = m.settings.SaveOrUpdateAccounts([]*accounts.Account{acc}, false)
 	if err != nil {
 		return nil, err
 	}
 
+	err = m.saveSyncedSafes(acc.Address, message.Safes)
+	if err != nil {
+		return nil, err
+	}
+
 	if m.config.accountsFeed != nil {
 		var eventType accountsevent.EventType
 		if acc.Removed {
@@ -292,6 +292,18 @@ func (m *Messenger) SyncDevices(ctx context.Context, ensName, photoPath string,
 		}
 	}
 
+	activityNotes, err := m.activityNotesManager.GetRawNotes()
+	if err != nil {
+		return err
+	}
+
+	for _, note := range activityNotes {
+		err = m.syncActivityNote(ctx, note, rawMessageHandler)
+		if err != nil {
+			return err
+		}
+	}
+
 	if err = m.syncEnsUsernameDetails(ctx, rawMessageHandler); err != nil {
 		return err
 	}
@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"golang.org/x/exp/maps"
 
@@ -9,6 +10,7 @@ import (
 
 	"github.com/status-im/status-go/services/browsers"
 	"github.com/status-im/status-go/services/wallet"
+	"github.com/status-im/status-go/services/wallet/activity"
 
 	"github.com/status-im/status-go/appmetrics"
 	"github.com/status-im/status-go/images"
@@ -90,6 +92,7 @@ type MessengerResponse struct {
 	trustStatus                      map[string]verification.TrustStatus
 	emojiReactions                   map[string]*EmojiReaction
 	savedAddresses                   map[string]*wallet.SavedAddress
+	activityNotes                    map[string]*activity.Note
 	ensUsernameDetails               []*ensservice.UsernameDetail
 	updatedProfileShowcaseContactIDs map[string]bool
 	seenAndUnseenMessages            map[string]*SeenUnseenMessages
@@ -138,6 +141,7 @@ func (r *MessengerResponse) MarshalJSON() ([]byte, error) {
 		DiscordMessages                  []*protobuf.DiscordMessage              `json:"discordMessages,omitempty"`
 		DiscordMessageAttachments        []*protobuf.DiscordMessageAttachment    `json:"discordMessageAtachments,omitempty"`
 		SavedAddresses                   []*wallet.SavedAddress                  `json:"savedAddresses,omitempty"`
+		ActivityNotes                    []*activity.Note                        `json:"activityNotes,omitempty"`
 		EnsUsernameDetails               []*ensservice.UsernameDetail            `json:"ensUsernameDetails,omitempty"`
 		UpdatedProfileShowcaseContactIDs []string                                `json:"updatedProfileShowcaseContactIDs,omitempty"`
 		SeenAndUnseenMessages            []*SeenUnseenMessages                   `json:"seenAndUnseenMessages,omitempty"`
@@ -163,6 +167,7 @@ func (r *MessengerResponse) MarshalJSON() ([]byte, error) {
 		Messages:                         r.Messages(),
 		VerificationRequests:             r.VerificationRequests(),
 		SavedAddresses:                   r.SavedAddresses(),
+		ActivityNotes:                    r.ActivityNotes(),
 		Notifications:                    r.Notifications(),
 		Chats:                            r.Chats(),
 		Communities:                      r.Communities(),
@@ -332,6 +337,7 @@ func (r *MessengerResponse) IsEmpty() bool {
 		len(r.verificationRequests)+
 		len(r.requestsToJoinCommunity)+
 		len(r.savedAddresses)+
+		len(r.activityNotes)+
 		len(r.updatedProfileShowcaseContactIDs)+
 		len(r.seenAndUnseenMessages)+
 		len(r.ensUsernameDetails) == 0 &&
@@ -368,6 +374,7 @@ func (r *MessengerResponse) Merge(response *MessengerResponse) error {
 	r.AddEmojiReactions(response.EmojiReactions())
 	r.AddInstallations(response.Installations())
 	r.AddSavedAddresses(response.SavedAddresses())
+	r.AddActivityNotes(response.ActivityNotes())
 	r.AddEnsUsernameDetails(response.EnsUsernameDetails())
 	r.AddRequestsToJoinCommunity(response.RequestsToJoinCommunity())
 	r.AddBookmarks(response.GetBookmarks())
@@ -539,6 +546,24 @@ func (r *MessengerResponse) SavedAddresses() []*wallet.SavedAddress {
 	return maps.Values(r.savedAddresses)
 }
 
+func (r *MessengerResponse) AddActivityNotes(notes []*activity.Note) {
+	for _, n := range notes {
+		r.AddActivityNote(n)
+	}
+}
+
+func (r *MessengerResponse) AddActivityNote(note *activity.Note) {
+	if r.activityNotes == nil {
+		r.activityNotes = make(map[string]*activity.Note)
+	}
+
+	r.activityNotes[fmt.Sprintf("%d-%s", note.ChainID, note.TxHash.Hex())] = note
+}
+
+func (r *MessengerResponse) ActivityNotes() []*activity.Note {
+	return maps.Values(r.activityNotes)
+}
+
 func (r *MessengerResponse) AddEnsUsernameDetail(detail *ensservice.UsernameDetail) {
 	r.ensUsernameDetails = append(r.ensUsernameDetails, detail)
 }
@@ -253,6 +253,17 @@ func (m *Messenger) HandleSyncRawMessages(rawMessages []*protobuf.RawMessage) er
 				m.logger.Error("failed to handleSyncSavedAddress when HandleSyncRawMessages", zap.Error(err))
 				continue
 			}
+		case protobuf.ApplicationMetadataMessage_SYNC_ACTIVITY_NOTE:
+			var message protobuf.SyncActivityNote
+			err := proto.Unmarshal(rawMessage.GetPayload(), &message)
+			if err != nil {
+				return err
+			}
+			err = m.HandleSyncActivityNote(state, &message, nil)
+			if err != nil {
+				m.logger.Error("failed to HandleSyncActivityNote when HandleSyncRawMessages", zap.Error(err))
+				continue
+			}
 		case protobuf.ApplicationMetadataMessage_SYNC_ENS_USERNAME_DETAIL:
 			var message protobuf.SyncEnsUsernameDetail
 			err := proto.Unmarshal(rawMessage.GetPayload(), &message)
@@ -5,10 +5,13 @@ import (
 	"errors"
 	"testing"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
+
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/multiaccounts/accounts"
 	"github.com/status-im/status-go/protocol/encryption/multidevice"
 	"github.com/status-im/status-go/protocol/tt"
+	"github.com/status-im/status-go/services/wallet/safe"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -619,3 +622,81 @@ func (s *MessengerSyncWalletSuite) TestSyncWalletAccountOrderAfterDeletion() {
 
 	s.Require().True(haveSameElements(dbAccounts1, dbAccounts2, accounts.SameAccountsIncludingPosition))
 }
+
+func (s *MessengerSyncWalletSuite) TestSyncMultisigAccount() {
+	profileKp := accounts.GetProfileKeypairForTest(true, false, false)
+	err := s.m.settings.SaveOrUpdateKeypair(profileKp)
+	s.Require().NoError(err, "profile keypair alice.settings.SaveOrUpdateKeypair")
+
+	alicesOtherDevice, err := newMessengerWithKey(s.shh, s.m.identity, s.logger, nil)
+	s.Require().NoError(err)
+	err = alicesOtherDevice.settings.SaveOrUpdateKeypair(profileKp)
+	s.Require().NoError(err, "profile keypair alicesOtherDevice.settings.SaveOrUpdateKeypair")
+
+	// Pair devices
+	im1 := &multidevice.InstallationMetadata{
+		Name:       "alice's-other-device",
+		DeviceType: "alice's-other-device-type",
+	}
+	err = alicesOtherDevice.SetInstallationMetadata(alicesOtherDevice.installationID, im1)
+	s.Require().NoError(err)
+	response, err := alicesOtherDevice.SendPairInstallation(context.Background(), "", nil)
+	s.Require().NoError(err)
+	s.Require().NotNil(response)
+
+	_, err = WaitOnMessengerResponse(
+		s.m,
+		func(r *MessengerResponse) bool { return len(r.Installations()) > 0 },
+		"installation not received",
+	)
+	s.Require().NoError(err)
+
+	_, err = s.m.EnableInstallation(alicesOtherDevice.installationID)
+	s.Require().NoError(err)
+
+	// Store a multisig account and its Safe on alice's device
+	multisigAccount := &accounts.Account{
+		Address: types.Address{0x5a},
+		Type:    accounts.AccountTypeMultisig,
+		Name:    "Treasury",
+	}
+	err = s.m.settings.SaveOrUpdateAccounts([]*accounts.Account{multisigAccount}, false)
+	s.Require().NoError(err)
+
+	treasury := &safe.Safe{
+		ChainID:   10,
+		Address:   gethcommon.Address(multisigAccount.Address),
+		Version:   "1.3.0",
+		Owners:    []gethcommon.Address{{0x01}, {0x02}},
+		Threshold: 2,
+		Nonce:     7,
+		UpdatedAt: 100,
+	}
+	err = s.m.safePersistence.UpsertSafe(treasury)
+	s.Require().NoError(err)
+
+	err = s.m.SyncDevices(context.Background(), "ens-name", "profile-image", nil)
+	s.Require().NoError(err)
+
+	err = tt.RetryWithBackOff(func() error {
+		response, err := alicesOtherDevice.RetrieveAll()
+		if err != nil {
+			return err
+		}
+
+		if len(response.WatchOnlyAccounts) != 1 {
+			return errors.New("no sync multisig account received")
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+
+	// the account keeps its type and its Safe is known on alice's other device
+	dbAccount, err := alicesOtherDevice.settings.GetAccountByAddress(multisigAccount.Address)
+	s.Require().NoError(err)
+	s.Require().Equal(accounts.AccountTypeMultisig, dbAccount.Type)
+
+	dbSafe, err := alicesOtherDevice.safePersistence.GetSafe(treasury.ChainID, treasury.Address)
+	s.Require().NoError(err)
+	s.Require().Equal(treasury, dbSafe)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/status-im/status-go/protocol/common"
 	"github.com/status-im/status-go/protocol/encryption/multidevice"
 	"github.com/status-im/status-go/protocol/protobuf"
+	"github.com/status-im/status-go/services/wallet/safe"
 )
 
 var (
@@ -204,7 +205,7 @@ func (m *Messenger) deleteKeystoreFileForAddress(address types.Address) error {
 		return nil
 	}
 
-	if acc.Type != accounts.AccountTypeWatch {
+	if !acc.IsWatchOnly() {
 		kp, err := m.settings.GetKeypairByKeyUID(acc.KeyUID)
 		if err != nil {
 			return err
@@ -353,7 +354,7 @@ func (m *Messenger) DeleteKeypair(keyUID string) error {
 }
 
 func (m *Messenger) prepareSyncAccountMessage(acc *accounts.Account) *protobuf.SyncAccount {
-	return &protobuf.SyncAccount{
+	message := &protobuf.SyncAccount{
 		Clock:                 acc.Clock,
 		Address:               acc.Address.Bytes(),
 		KeyUid:                acc.KeyUID,
@@ -370,7 +371,81 @@ func (m *Messenger) prepareSyncAccountMessage(acc *accounts.Account) *protobuf.S
 		Position:              acc.Position,
 		ProdPreferredChainIDs: acc.ProdPreferredChainIDs,
 		TestPreferredChainIDs: acc.TestPreferredChainIDs,
+		Multisig:              acc.Type == accounts.AccountTypeMultisig,
 	}
+	if message.Multisig && !acc.Removed {
+		message.Safes = m.prepareSyncSafes(acc.Address)
+	}
+	return message
+}
+
+// prepareSyncSafes returns the Safes of the multisig account on all chains, the account is synced without them if they
+// can't be read
+func (m *Messenger) prepareSyncSafes(address types.Address) []*protobuf.SyncSafe {
+	if m.safePersistence == nil {
+		return nil
+	}
+
+	safeAddress := ethcommon.Address(address)
+	safes, err := m.safePersistence.GetSafes(&safeAddress)
+	if err != nil {
+		m.logger.Error("failed to get the Safes of the multisig account", zap.Error(err))
+		return nil
+	}
+
+	syncSafes := make([]*protobuf.SyncSafe, 0, len(safes))
+	for _, s := range safes {
+		owners := make([][]byte, 0, len(s.Owners))
+		for _, owner := range s.Owners {
+			owners = append(owners, owner.Bytes())
+		}
+		syncSafes = append(syncSafes, &protobuf.SyncSafe{
+			ChainId:   s.ChainID,
+			Version:   s.Version,
+			Owners:    owners,
+			Threshold: s.Threshold,
+			Nonce:     s.Nonce,
+			UpdatedAt: s.UpdatedAt,
+		})
+	}
+	return syncSafes
+}
+
+// saveSyncedSafes stores the Safes of a synced multisig account, a Safe read from the contract more recently on this
+// device is kept
+func (m *Messenger) saveSyncedSafes(address types.Address, syncSafes []*protobuf.SyncSafe) error {
+	if m.safePersistence == nil {
+		return nil
+	}
+
+	safeAddress := ethcommon.Address(address)
+	for _, syncSafe := range syncSafes {
+		dbSafe, err := m.safePersistence.GetSafe(syncSafe.ChainId, safeAddress)
+		if err != nil && err != safe.ErrSafeNotFound {
+			return err
+		}
+		if dbSafe != nil && dbSafe.UpdatedAt >= syncSafe.UpdatedAt {
+			continue
+		}
+
+		owners := make([]ethcommon.Address, 0, len(syncSafe.Owners))
+		for _, owner := range syncSafe.Owners {
+			owners = append(owners, ethcommon.BytesToAddress(owner))
+		}
+		err = m.safePersistence.UpsertSafe(&safe.Safe{
+			ChainID:   syncSafe.ChainId,
+			Address:   safeAddress,
+			Version:   syncSafe.Version,
+			Owners:    owners,
+			Threshold: syncSafe.Threshold,
+			Nonce:     syncSafe.Nonce,
+			UpdatedAt: syncSafe.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (m *Messenger) getMyInstallationMetadata() (*multidevice.InstallationMetadata, error) {
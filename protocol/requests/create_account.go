@@ -105,6 +105,11 @@ type WalletSecretsConfig struct {
 	StatusProxyBlockchainUser     string `json:"statusProxyBlockchainUser"`
 	StatusProxyBlockchainPassword string `json:"statusProxyBlockchainPassword"`
 
+	BundlerURLs   map[uint64]string `json:"bundlerURLs"`
+	PaymasterURLs map[uint64]string `json:"paymasterURLs"`
+
+	ZeroExAPIKey string `json:"zeroExApiKey"`
+
 	// Testing
 	GanacheURL string `json:"ganacheURL"`
 }
@@ -49,6 +49,16 @@ func (p *CreateCommunityTokenPermission) Validate() error {
 		if len(c.ContractAddresses) > 0 && amountBig.Cmp(big.NewInt(0)) == 0 {
 			return ErrCreateCommunityTokenPermissionInvalidTokenCriteria
 		}
+
+		if len(c.Traits) > 0 && c.Type != protobuf.CommunityTokenType_ERC721 {
+			return ErrCreateCommunityTokenPermissionInvalidTokenCriteria
+		}
+
+		for _, trait := range c.Traits {
+			if trait.TraitType == "" {
+				return ErrCreateCommunityTokenPermissionInvalidTokenCriteria
+			}
+		}
 	}
 
 	return nil
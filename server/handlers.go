@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -49,6 +50,7 @@ const (
 	walletCommunityImagesPath   = walletBasePath + "/communityImages"
 	walletCollectionImagesPath  = walletBasePath + "/collectionImages"
 	walletCollectibleImagesPath = walletBasePath + "/collectibleImages"
+	walletCollectibleMediaPath  = walletBasePath + "/collectibleMedia"
 
 	// Handler routes for pairing
 	accountImagesPath   = "/accountImages"
@@ -1285,3 +1287,106 @@ func handleWalletCollectibleImages(db *sql.DB, logger *zap.Logger) http.HandlerF
 		}
 	}
 }
+
+// IsServableMediaType tells if the wallet collectible media can be served. Only raster images, videos and audio are,
+// the documents which could run scripts, as HTML or SVG, would run them in the media server origin
+func IsServableMediaType(mimeType string) bool {
+	return (strings.HasPrefix(mimeType, "image/") && mimeType != "image/svg+xml") ||
+		strings.HasPrefix(mimeType, "video/") ||
+		strings.HasPrefix(mimeType, "audio/") ||
+		mimeType == "application/ogg"
+}
+
+func handleWalletCollectibleMedia(db *sql.DB, logger *zap.Logger) http.HandlerFunc {
+	if db == nil {
+		return handleRequestDBMissing(logger)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+
+		if len(params["chainID"]) == 0 {
+			logger.Error("no chainID")
+			return
+		}
+
+		if len(params["contractAddress"]) == 0 {
+			logger.Error("no contractAddress")
+			return
+		}
+
+		if len(params["tokenID"]) == 0 {
+			logger.Error("no tokenID")
+			return
+		}
+
+		if len(params["mediaType"]) == 0 {
+			logger.Error("no mediaType")
+			return
+		}
+
+		chainID, err := strconv.ParseUint(params["chainID"][0], 10, 64)
+		if err != nil {
+			logger.Error("invalid chainID in wallet collectible media", zap.Error(err))
+			return
+		}
+		contractAddress := eth_common.HexToAddress(params["contractAddress"][0])
+		tokenID, ok := big.NewInt(0).SetString(params["tokenID"][0], 10)
+		if !ok {
+			logger.Error("invalid tokenID in wallet collectible media")
+			return
+		}
+		mediaType := params["mediaType"][0]
+		thumbnail := len(params["thumbnail"]) > 0 && params["thumbnail"][0] == "true"
+
+		column := "payload"
+		if thumbnail {
+			column = "thumbnail"
+		}
+
+		var payload []byte
+		var mimeType string
+		var fetchedAt int64
+		err = db.QueryRow(`SELECT `+column+`, mime_type, fetched_at FROM collectible_media_cache WHERE chain_id = ? AND contract_address = ? AND token_id = ? AND media_type = ?`,
+			chainID,
+			contractAddress,
+			(*bigint.SQLBigIntBytes)(tokenID),
+			mediaType).Scan(&payload, &mimeType, &fetchedAt)
+		if err != nil {
+			logger.Error("failed to find wallet collectible media", zap.Error(err))
+			http.NotFound(w, r)
+			return
+		}
+		if len(payload) == 0 {
+			logger.Error("empty wallet collectible media")
+			http.NotFound(w, r)
+			return
+		}
+
+		// Keeps the media being displayed from being evicted
+		_, err = db.Exec(`UPDATE collectible_media_cache SET last_accessed_at = ? WHERE chain_id = ? AND contract_address = ? AND token_id = ? AND media_type = ?`,
+			time.Now().Unix(),
+			chainID,
+			contractAddress,
+			(*bigint.SQLBigIntBytes)(tokenID),
+			mediaType)
+		if err != nil {
+			logger.Error("failed to update wallet collectible media access time", zap.Error(err))
+		}
+
+		// The stored type isn't trusted, the media is served only if the content is safe to render
+		mimeType = http.DetectContentType(payload)
+		if !IsServableMediaType(mimeType) {
+			logger.Error("unsupported wallet collectible media type", zap.String("mimeType", mimeType))
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", mimeType)
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Content-Security-Policy", "sandbox")
+
+		// ServeContent handles the range requests needed by the video players
+		http.ServeContent(w, r, "", time.Unix(fetchedAt, 0), bytes.NewReader(payload))
+	}
+}
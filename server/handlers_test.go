@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"image/color"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -18,6 +19,7 @@ import (
 	"github.com/status-im/status-go/multiaccounts"
 	mc "github.com/status-im/status-go/multiaccounts/common"
 
+	eth_common "github.com/ethereum/go-ethereum/common"
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
@@ -27,7 +29,9 @@ import (
 	"github.com/status-im/status-go/protocol/protobuf"
 	"github.com/status-im/status-go/protocol/sqlite"
 	"github.com/status-im/status-go/protocol/tt"
+	"github.com/status-im/status-go/services/wallet/bigint"
 	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
 )
 
 func TestHandlersSuite(t *testing.T) {
@@ -661,3 +665,46 @@ func (s *HandlersSuite) TestHandleAccountImagesImpl() {
 	handleAccountImagesImpl(db, s.logger, w, p)
 	s.Require().Equal(http.StatusOK, w.Code)
 }
+
+func (s *HandlersSuite) TestHandleWalletCollectibleMedia() {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	s.Require().NoError(err)
+
+	insertMedia := func(tokenID int64, mimeType string, payload []byte) {
+		_, err := db.Exec(`INSERT INTO collectible_media_cache (chain_id, contract_address, token_id, media_type, source_url, mime_type, payload, size, fetched_at, last_accessed_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			1, eth_common.HexToAddress("0x1"), (*bigint.SQLBigIntBytes)(big.NewInt(tokenID)), "image", "https://example.com", mimeType, payload, len(payload), 0, 0)
+		s.Require().NoError(err)
+	}
+	mediaURL := func(tokenID int64) string {
+		return "https://localhost" + walletCollectibleMediaPath + "?chainID=1&contractAddress=0x0000000000000000000000000000000000000001&mediaType=image&tokenID=" + big.NewInt(tokenID).String()
+	}
+
+	gif := []byte("GIF89a....")
+	insertMedia(1, "image/gif", gif)
+	// the stored type isn't trusted
+	insertMedia(2, "image/png", []byte("<html><script>alert(1)</script></html>"))
+	insertMedia(3, "image/svg+xml", []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`))
+
+	rr := s.httpGetReqRecorder(handleWalletCollectibleMedia(db, s.logger), mediaURL(1))
+	s.Require().Equal(http.StatusOK, rr.Code)
+	s.Require().Equal(gif, rr.Body.Bytes())
+	s.Require().Equal("image/gif", rr.Header().Get("Content-Type"))
+	s.Require().Equal("nosniff", rr.Header().Get("X-Content-Type-Options"))
+	s.Require().Equal("sandbox", rr.Header().Get("Content-Security-Policy"))
+
+	rr = s.httpGetReqRecorder(handleWalletCollectibleMedia(db, s.logger), mediaURL(2))
+	s.Require().Equal(http.StatusNotFound, rr.Code)
+
+	rr = s.httpGetReqRecorder(handleWalletCollectibleMedia(db, s.logger), mediaURL(3))
+	s.Require().Equal(http.StatusNotFound, rr.Code)
+}
+
+func (s *HandlersSuite) TestIsServableMediaType() {
+	s.Require().True(IsServableMediaType("image/png"))
+	s.Require().True(IsServableMediaType("video/mp4"))
+	s.Require().True(IsServableMediaType("audio/mpeg"))
+	s.Require().False(IsServableMediaType("image/svg+xml"))
+	s.Require().False(IsServableMediaType("text/html; charset=utf-8"))
+	s.Require().False(IsServableMediaType("application/octet-stream"))
+}
@@ -60,6 +60,7 @@ func NewMediaServer(db *sql.DB, downloader *ipfs.Downloader, multiaccountsDB *mu
 		walletCommunityImagesPath:           handleWalletCommunityImages(s.walletDB, s.logger),
 		walletCollectionImagesPath:          handleWalletCollectionImages(s.walletDB, s.logger),
 		walletCollectibleImagesPath:         handleWalletCollectibleImages(s.walletDB, s.logger),
+		walletCollectibleMediaPath:          handleWalletCollectibleMedia(s.walletDB, s.logger),
 	})
 
 	return s, nil
@@ -224,3 +225,18 @@ func (s *MediaServer) MakeWalletCollectibleImagesURL(collectibleID thirdparty.Co
 
 	return u.String()
 }
+
+// MakeWalletCollectibleMediaURL returns the URL of the cached collectible media, mediaType is either "image" or "animation"
+func (s *MediaServer) MakeWalletCollectibleMediaURL(collectibleID thirdparty.CollectibleUniqueID, mediaType string, thumbnail bool) string {
+	u := s.MakeBaseURL()
+	u.Path = walletCollectibleMediaPath
+	u.RawQuery = url.Values{
+		"chainID":         {collectibleID.ContractID.ChainID.String()},
+		"contractAddress": {collectibleID.ContractID.Address.Hex()},
+		"tokenID":         {collectibleID.TokenID.String()},
+		"mediaType":       {mediaType},
+		"thumbnail":       {strconv.FormatBool(thumbnail)},
+	}.Encode()
+
+	return u.String()
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"net/http"
 	"net/url"
 	"testing"
@@ -15,9 +16,13 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	eth_common "github.com/ethereum/go-ethereum/common"
+
 	"github.com/status-im/status-go/images"
 	"github.com/status-im/status-go/protocol/common"
 	"github.com/status-im/status-go/server/servertest"
+	"github.com/status-im/status-go/services/wallet/bigint"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
 )
 
 const (
@@ -145,6 +150,22 @@ func (s *ServerURLSuite) TestServer_MakeStickerURL() {
 		s.serverNoPort.MakeStickerURL("0xdeadbeef4ac0"))
 }
 
+func (s *ServerURLSuite) TestServer_MakeWalletCollectibleMediaURL() {
+	id := thirdparty.CollectibleUniqueID{
+		ContractID: thirdparty.ContractID{
+			ChainID: 1,
+			Address: eth_common.HexToAddress("0x06012c8cf97bead5deae237070f9587f8e7a266d"),
+		},
+		TokenID: &bigint.BigInt{Int: big.NewInt(1234)},
+	}
+	s.Require().Equal(
+		baseURLWithCustomPort+"/wallet/collectibleMedia?chainID=1&contractAddress=0x06012c8cf97BEaD5deAe237070F9587f8E7A266d&mediaType=image&thumbnail=true&tokenID=1234",
+		s.server.MakeWalletCollectibleMediaURL(id, "image", true))
+	s.testNoPort(
+		baseURLWithDefaultPort+"/wallet/collectibleMedia?chainID=1&contractAddress=0x06012c8cf97BEaD5deAe237070F9587f8E7A266d&mediaType=animation&thumbnail=false&tokenID=1234",
+		s.serverNoPort.MakeWalletCollectibleMediaURL(id, "animation", false))
+}
+
 // TestQRCodeGeneration tests if we provide all the correct parameters to the media server
 // do we get a valid QR code or not as part of the response payload.
 // we have stored a generated QR code in tests folder, and we compare their bytes.
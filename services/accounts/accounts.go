@@ -251,7 +251,7 @@ func (api *API) checkAccountValidity(account *accounts.Account) error {
 		return errors.New("`ColorID` field of an account must be set")
 	}
 
-	if account.Type != accounts.AccountTypeWatch {
+	if !account.IsWatchOnly() {
 
 		if len(account.KeyUID) == 0 {
 			return errors.New("`KeyUID` field of an account must be set")
@@ -306,7 +306,7 @@ func (api *API) AddAccount(ctx context.Context, password string, account *accoun
 		return err
 	}
 
-	if account.Type != accounts.AccountTypeWatch {
+	if !account.IsWatchOnly() {
 		kp, err := api.db.GetKeypairByKeyUID(account.KeyUID)
 		if err != nil {
 			if err == accounts.ErrDbKeypairNotFound {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/status-im/status-go/services/connector/commands"
 	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/services/wallet/simulation"
 )
 
 var (
@@ -29,11 +30,16 @@ func NewAPI(s *Service) *API {
 		RpcClient:     s.rpc,
 		Db:            s.db,
 		ClientHandler: c,
+		Simulator:     simulation.NewSimulator(s.rpc),
 	})
 	r.Register("personal_sign", &commands.PersonalSignCommand{
 		Db:            s.db,
 		ClientHandler: c,
 	})
+	r.Register("eth_signTypedData_v4", &commands.SignTypedDataCommand{
+		Db:            s.db,
+		ClientHandler: c,
+	})
 
 	// Accounts query and dapp permissions
 	// NOTE: Some dApps expect same behavior for both eth_accounts and eth_requestAccounts
@@ -53,6 +59,18 @@ func NewAPI(s *Service) *API {
 		Db:             s.db,
 		NetworkManager: s.nm,
 	})
+	r.Register("wallet_addEthereumChain", &commands.AddEthereumChainCommand{
+		Db:             s.db,
+		NetworkManager: s.nm,
+		ClientHandler:  c,
+	})
+
+	// Assets
+	r.Register("wallet_watchAsset", &commands.WatchAssetCommand{
+		Db:            s.db,
+		TokenManager:  s.tokenManager,
+		ClientHandler: c,
+	})
 
 	// Permissions
 	r.Register("wallet_requestPermissions", &commands.RequestPermissionsCommand{})
@@ -151,3 +169,27 @@ func (api *API) PersonalSignAccepted(args commands.PersonalSignAcceptedArgs) err
 func (api *API) PersonalSignRejected(args commands.RejectedArgs) error {
 	return api.c.PersonalSignRejected(args)
 }
+
+func (api *API) SignTypedDataAccepted(args commands.SignTypedDataAcceptedArgs) error {
+	return api.c.SignTypedDataAccepted(args)
+}
+
+func (api *API) SignTypedDataRejected(args commands.RejectedArgs) error {
+	return api.c.SignTypedDataRejected(args)
+}
+
+func (api *API) AddEthereumChainAccepted(args commands.AddEthereumChainAcceptedArgs) error {
+	return api.c.AddEthereumChainAccepted(args)
+}
+
+func (api *API) AddEthereumChainRejected(args commands.RejectedArgs) error {
+	return api.c.AddEthereumChainRejected(args)
+}
+
+func (api *API) WatchAssetAccepted(args commands.WatchAssetAcceptedArgs) error {
+	return api.c.WatchAssetAccepted(args)
+}
+
+func (api *API) WatchAssetRejected(args commands.RejectedArgs) error {
+	return api.c.WatchAssetRejected(args)
+}
@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/status-im/status-go/rpc/network"
+	"github.com/status-im/status-go/services/connector/chainutils"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/signal"
+)
+
+var (
+	ErrNoAddEthereumChainParamsFound = errors.New("no add ethereum chain params found")
+	ErrNativeCurrencyMismatch        = errors.New("native currency doesn't match the network")
+)
+
+type AddEthereumChainCommand struct {
+	NetworkManager *network.Manager
+	Db             *sql.DB
+	ClientHandler  ClientSideHandlerInterface
+}
+
+type NativeCurrencyParams struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint64 `json:"decimals"`
+}
+
+// AddEthereumChainParams follows EIP-3085
+type AddEthereumChainParams struct {
+	ChainID           string                `json:"chainId"`
+	ChainName         string                `json:"chainName"`
+	RPCURLs           []string              `json:"rpcUrls"`
+	NativeCurrency    *NativeCurrencyParams `json:"nativeCurrency"`
+	BlockExplorerURLs []string              `json:"blockExplorerUrls"`
+}
+
+func (r *RPCRequest) getAddEthereumChainParams() (*AddEthereumChainParams, error) {
+	if r.Params == nil || len(r.Params) == 0 {
+		return nil, ErrEmptyRPCParams
+	}
+
+	paramMap, ok := r.Params[0].(map[string]interface{})
+	if !ok {
+		return nil, ErrNoAddEthereumChainParamsFound
+	}
+
+	paramBytes, err := json.Marshal(paramMap)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling add ethereum chain param: %v", err)
+	}
+
+	var params AddEthereumChainParams
+	err = json.Unmarshal(paramBytes, &params)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling add ethereum chain param: %v", err)
+	}
+
+	if params.ChainID == "" {
+		return nil, ErrNoChainIDParamsFound
+	}
+
+	return &params, nil
+}
+
+func (c *AddEthereumChainCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
+	err := request.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := request.getAddEthereumChainParams()
+	if err != nil {
+		return nil, err
+	}
+
+	requestedChainID, err := hexStringToUint64(params.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Custom networks can't be added by dApps, only the networks known to the wallet are accepted
+	chainIDs, err := chainutils.GetSupportedChainIDs(c.NetworkManager)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(chainIDs, requestedChainID) {
+		return nil, ErrUnsupportedNetwork
+	}
+
+	network := c.NetworkManager.Find(requestedChainID)
+	if network == nil {
+		return nil, ErrUnsupportedNetwork
+	}
+
+	if params.NativeCurrency != nil &&
+		(params.NativeCurrency.Symbol != network.NativeCurrencySymbol || params.NativeCurrency.Decimals != network.NativeCurrencyDecimals) {
+		return nil, ErrNativeCurrencyMismatch
+	}
+
+	dApp, err := persistence.SelectDAppByUrl(c.Db, request.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if dApp == nil {
+		return nil, ErrDAppIsNotPermittedByUser
+	}
+
+	if dApp.ChainID == requestedChainID {
+		return nil, nil
+	}
+
+	err = c.ClientHandler.RequestAddEthereumChain(signal.ConnectorDApp{
+		URL:     request.URL,
+		Name:    request.Name,
+		IconURL: request.IconURL,
+	}, requestedChainID, network.ChainName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Like MetaMask, the dApp is switched to the chain once the user accepts it
+	dApp.ChainID = requestedChainID
+
+	err = persistence.UpsertDApp(c.Db, dApp)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := chainutils.GetHexChainID(walletCommon.ChainID(dApp.ChainID).String())
+	if err != nil {
+		return nil, err
+	}
+
+	signal.SendConnectorDAppChainIdSwitched(signal.ConnectorDAppChainIdSwitchedSignal{
+		URL:     request.URL,
+		ChainId: chainId,
+	})
+
+	return nil, nil
+}
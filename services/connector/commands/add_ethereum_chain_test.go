@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/status-im/status-go/eth-node/types"
+	persistence "github.com/status-im/status-go/services/connector/database"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/signal"
+)
+
+func prepareAddEthereumChainRequest(dApp signal.ConnectorDApp, chainID string, symbol string) (RPCRequest, error) {
+	params := map[string]interface{}{
+		"chainId":   chainID,
+		"chainName": "Optimism",
+		"rpcUrls":   []string{"https://mainnet.optimism.io"},
+		"nativeCurrency": map[string]interface{}{
+			"name":     "Ether",
+			"symbol":   symbol,
+			"decimals": 18,
+		},
+	}
+
+	return ConstructRPCRequest(Method_AddEthereumChain, []interface{}{params}, &dApp)
+}
+
+func TestFailToAddUnsupportedEthereumChain(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, walletCommon.EthereumMainnet)
+	assert.NoError(t, err)
+
+	request, err := prepareAddEthereumChainRequest(testDAppData, "0x89", "MATIC")
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrUnsupportedNetwork, err)
+}
+
+func TestFailToAddEthereumChainWithNativeCurrencyMismatch(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, walletCommon.EthereumMainnet)
+	assert.NoError(t, err)
+
+	request, err := prepareAddEthereumChainRequest(testDAppData, "0xa", "OP")
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrNativeCurrencyMismatch, err)
+}
+
+func TestFailToAddEthereumChainForUnpermittedDApp(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	request, err := prepareAddEthereumChainRequest(testDAppData, "0xa", "ETH")
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrDAppIsNotPermittedByUser, err)
+}
+
+func TestAddEthereumChainWithSignalAccepted(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, walletCommon.EthereumMainnet)
+	assert.NoError(t, err)
+
+	request, err := prepareAddEthereumChainRequest(testDAppData, "0xa", "ETH")
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorAddEthereumChain:
+			var ev signal.ConnectorAddEthereumChainSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+			assert.Equal(t, walletCommon.OptimismMainnet, ev.ChainID)
+			assert.Equal(t, "Optimism", ev.ChainName)
+
+			err = state.handler.AddEthereumChainAccepted(AddEthereumChainAcceptedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Nil(t, response)
+
+	dApp, err := persistence.SelectDAppByUrl(state.walletDb, testDAppData.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, walletCommon.OptimismMainnet, dApp.ChainID)
+}
+
+func TestAddEthereumChainWithSignalRejected(t *testing.T) {
+	state, close := setupCommand(t, Method_AddEthereumChain)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, walletCommon.EthereumMainnet)
+	assert.NoError(t, err)
+
+	request, err := prepareAddEthereumChainRequest(testDAppData, "0xa", "ETH")
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorAddEthereumChain:
+			var ev signal.ConnectorAddEthereumChainSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			err = state.handler.AddEthereumChainRejected(RejectedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrAddEthereumChainRejectedByUser, err)
+
+	dApp, err := persistence.SelectDAppByUrl(state.walletDb, testDAppData.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, walletCommon.EthereumMainnet, dApp.ChainID)
+}
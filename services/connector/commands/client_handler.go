@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/services/wallet/simulation"
 	"github.com/status-im/status-go/signal"
 	"github.com/status-im/status-go/transactions"
 )
@@ -21,6 +22,9 @@ var (
 	ErrRequestAccountsRejectedByUser          = fmt.Errorf("request accounts was rejected by user")
 	ErrSendTransactionRejectedByUser          = fmt.Errorf("send transaction was rejected by user")
 	ErrPersonalSignRejectedByUser             = fmt.Errorf("personal sign was rejected by user")
+	ErrSignTypedDataRejectedByUser            = fmt.Errorf("sign typed data was rejected by user")
+	ErrAddEthereumChainRejectedByUser         = fmt.Errorf("add ethereum chain was rejected by user")
+	ErrWatchAssetRejectedByUser               = fmt.Errorf("watch asset was rejected by user")
 	ErrEmptyRequestID                         = fmt.Errorf("empty requestID")
 	ErrAnotherConnectorOperationIsAwaitingFor = fmt.Errorf("another connector operation is awaiting for user input")
 )
@@ -31,6 +35,9 @@ const (
 	RequestAccountsAccepted MessageType = iota
 	SendTransactionAccepted
 	PersonalSignAccepted
+	SignTypedDataAccepted
+	AddEthereumChainAccepted
+	WatchAssetAccepted
 	Rejected
 )
 
@@ -115,7 +122,7 @@ func (c *ClientSideHandler) RequestAccountsRejected(args RejectedArgs) error {
 	return nil
 }
 
-func (c *ClientSideHandler) RequestSendTransaction(dApp signal.ConnectorDApp, chainID uint64, txArgs *transactions.SendTxArgs) (types.Hash, error) {
+func (c *ClientSideHandler) RequestSendTransaction(dApp signal.ConnectorDApp, chainID uint64, txArgs *transactions.SendTxArgs, txSimulation *simulation.Result) (types.Hash, error) {
 	if !c.setRequestRunning() {
 		return types.Hash{}, ErrAnotherConnectorOperationIsAwaitingFor
 	}
@@ -126,8 +133,17 @@ func (c *ClientSideHandler) RequestSendTransaction(dApp signal.ConnectorDApp, ch
 		return types.Hash{}, fmt.Errorf("failed to marshal txArgs: %v", err)
 	}
 
+	txSimulationJson := ""
+	if txSimulation != nil {
+		txSimulationBytes, err := json.Marshal(txSimulation)
+		if err != nil {
+			return types.Hash{}, fmt.Errorf("failed to marshal txSimulation: %v", err)
+		}
+		txSimulationJson = string(txSimulationBytes)
+	}
+
 	requestID := c.generateRequestID(dApp)
-	signal.SendConnectorSendTransaction(dApp, chainID, string(txArgsJson), requestID)
+	signal.SendConnectorSendTransaction(dApp, chainID, string(txArgsJson), txSimulationJson, requestID)
 
 	timeout := time.After(WalletResponseMaxInterval)
 
@@ -170,14 +186,14 @@ func (c *ClientSideHandler) SendTransactionRejected(args RejectedArgs) error {
 	return nil
 }
 
-func (c *ClientSideHandler) RequestPersonalSign(dApp signal.ConnectorDApp, challenge, address string) (string, error) {
+func (c *ClientSideHandler) RequestPersonalSign(dApp signal.ConnectorDApp, challenge, address, siwe string) (string, error) {
 	if !c.setRequestRunning() {
 		return "", ErrAnotherConnectorOperationIsAwaitingFor
 	}
 	defer c.clearRequestRunning()
 
 	requestID := c.generateRequestID(dApp)
-	signal.SendConnectorPersonalSign(dApp, requestID, challenge, address)
+	signal.SendConnectorPersonalSign(dApp, requestID, challenge, address, siwe)
 
 	timeout := time.After(WalletResponseMaxInterval)
 
@@ -219,3 +235,161 @@ func (c *ClientSideHandler) PersonalSignRejected(args RejectedArgs) error {
 	c.responseChannel <- Message{Type: Rejected, Data: args}
 	return nil
 }
+
+func (c *ClientSideHandler) RequestSignTypedData(dApp signal.ConnectorDApp, chainID uint64, address string, typedData string) (string, error) {
+	if !c.setRequestRunning() {
+		return "", ErrAnotherConnectorOperationIsAwaitingFor
+	}
+	defer c.clearRequestRunning()
+
+	requestID := c.generateRequestID(dApp)
+	signal.SendConnectorSignTypedData(dApp, requestID, chainID, address, typedData)
+
+	timeout := time.After(WalletResponseMaxInterval)
+
+	for {
+		select {
+		case msg := <-c.responseChannel:
+			switch msg.Type {
+			case SignTypedDataAccepted:
+				response := msg.Data.(SignTypedDataAcceptedArgs)
+				if response.RequestID == requestID {
+					return response.Signature, nil
+				}
+			case Rejected:
+				response := msg.Data.(RejectedArgs)
+				if response.RequestID == requestID {
+					return "", ErrSignTypedDataRejectedByUser
+				}
+			}
+		case <-timeout:
+			return "", ErrWalletResponseTimeout
+		}
+	}
+}
+
+func (c *ClientSideHandler) SignTypedDataAccepted(args SignTypedDataAcceptedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: SignTypedDataAccepted, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) SignTypedDataRejected(args RejectedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: Rejected, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) RequestAddEthereumChain(dApp signal.ConnectorDApp, chainID uint64, chainName string) error {
+	if !c.setRequestRunning() {
+		return ErrAnotherConnectorOperationIsAwaitingFor
+	}
+	defer c.clearRequestRunning()
+
+	requestID := c.generateRequestID(dApp)
+	signal.SendConnectorAddEthereumChain(dApp, requestID, chainID, chainName)
+
+	timeout := time.After(WalletResponseMaxInterval)
+
+	for {
+		select {
+		case msg := <-c.responseChannel:
+			switch msg.Type {
+			case AddEthereumChainAccepted:
+				response := msg.Data.(AddEthereumChainAcceptedArgs)
+				if response.RequestID == requestID {
+					return nil
+				}
+			case Rejected:
+				response := msg.Data.(RejectedArgs)
+				if response.RequestID == requestID {
+					return ErrAddEthereumChainRejectedByUser
+				}
+			}
+		case <-timeout:
+			return ErrWalletResponseTimeout
+		}
+	}
+}
+
+func (c *ClientSideHandler) AddEthereumChainAccepted(args AddEthereumChainAcceptedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: AddEthereumChainAccepted, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) AddEthereumChainRejected(args RejectedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: Rejected, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) RequestWatchAsset(dApp signal.ConnectorDApp, chainID uint64, asset *WatchAssetParams) error {
+	if !c.setRequestRunning() {
+		return ErrAnotherConnectorOperationIsAwaitingFor
+	}
+	defer c.clearRequestRunning()
+
+	requestID := c.generateRequestID(dApp)
+	signal.SendConnectorWatchAsset(signal.ConnectorWatchAssetSignal{
+		ConnectorDApp: dApp,
+		RequestID:     requestID,
+		ChainID:       chainID,
+		Address:       asset.Address.Hex(),
+		Symbol:        asset.Symbol,
+		Decimals:      asset.Decimals,
+		Image:         asset.Image,
+	})
+
+	timeout := time.After(WalletResponseMaxInterval)
+
+	for {
+		select {
+		case msg := <-c.responseChannel:
+			switch msg.Type {
+			case WatchAssetAccepted:
+				response := msg.Data.(WatchAssetAcceptedArgs)
+				if response.RequestID == requestID {
+					return nil
+				}
+			case Rejected:
+				response := msg.Data.(RejectedArgs)
+				if response.RequestID == requestID {
+					return ErrWatchAssetRejectedByUser
+				}
+			}
+		case <-timeout:
+			return ErrWalletResponseTimeout
+		}
+	}
+}
+
+func (c *ClientSideHandler) WatchAssetAccepted(args WatchAssetAcceptedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: WatchAssetAccepted, Data: args}
+	return nil
+}
+
+func (c *ClientSideHandler) WatchAssetRejected(args RejectedArgs) error {
+	if args.RequestID == "" {
+		return ErrEmptyRequestID
+	}
+
+	c.responseChannel <- Message{Type: Rejected, Data: args}
+	return nil
+}
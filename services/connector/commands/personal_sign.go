@@ -3,10 +3,15 @@ package commands
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
 	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/services/wallet/siwe"
 	"github.com/status-im/status-go/signal"
 )
 
@@ -52,6 +57,31 @@ func (r *RPCRequest) getPersonalSignParams() (*PersonalSignParams, error) {
 	}, nil
 }
 
+// analyzeSIWEChallenge checks an EIP-4361 sign-in challenge against the requesting dApp, the user is warned about
+// the mismatches, e.g. a message for another domain is likely a phishing attempt. The malformed sign-in messages
+// can't be checked, so the user is warned about them too.
+func analyzeSIWEChallenge(params *PersonalSignParams, origin string, chainID uint64) (string, error) {
+	result, err := siwe.Analyze(params.Challenge, siwe.Expectations{
+		Origin:  origin,
+		ChainID: chainID,
+		Address: common.HexToAddress(params.Address),
+	})
+	if err != nil {
+		log.Warn("invalid sign-in with ethereum message", "origin", origin, "error", err)
+		result = &siwe.Result{Warnings: []siwe.Warning{siwe.WarningMalformed}}
+	}
+
+	if result == nil {
+		return "", nil
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(resultJSON), nil
+}
+
 func (c *PersonalSignCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
 	err := request.Validate()
 	if err != nil {
@@ -72,9 +102,14 @@ func (c *PersonalSignCommand) Execute(ctx context.Context, request RPCRequest) (
 		return "", ErrDAppIsNotPermittedByUser
 	}
 
+	siweResult, err := analyzeSIWEChallenge(params, request.URL, dApp.ChainID)
+	if err != nil {
+		return "", err
+	}
+
 	return c.ClientHandler.RequestPersonalSign(signal.ConnectorDApp{
 		URL:     request.URL,
 		Name:    request.Name,
 		IconURL: request.IconURL,
-	}, params.Challenge, params.Address)
+	}, params.Challenge, params.Address, siweResult)
 }
@@ -7,7 +7,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
 	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/services/wallet/siwe"
 	"github.com/status-im/status-go/signal"
 )
 
@@ -156,3 +159,87 @@ func TestPersonalSignWithSignalRejected(t *testing.T) {
 	_, err = state.cmd.Execute(state.ctx, request)
 	assert.Equal(t, ErrPersonalSignRejectedByUser, err)
 }
+
+func TestPersonalSignWithSIWEDomainMismatch(t *testing.T) {
+	state, close := setupCommand(t, Method_PersonalSign)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	address := "0x4B0897b0513FdBeEc7C469D9aF4fA6C0752aBea7"
+	message := "app.example.com wants you to sign in with your Ethereum account:\n" + address + "\n\n" +
+		"URI: https://app.example.com\nVersion: 1\nChain ID: 1\nNonce: 32891756ab\nIssued At: 2024-08-01T10:00:00Z"
+	request, err := preparePersonalSignRequest(testDAppData, hexutil.Encode([]byte(message)), address)
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorPersonalSign:
+			var ev signal.ConnectorPersonalSignSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			var result siwe.Result
+			err = json.Unmarshal([]byte(ev.SIWE), &result)
+			assert.NoError(t, err)
+			assert.Equal(t, "app.example.com", result.Message.Domain)
+			assert.Equal(t, []siwe.Warning{siwe.WarningDomainMismatch}, result.Warnings)
+
+			err = state.handler.PersonalSignRejected(RejectedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrPersonalSignRejectedByUser, err)
+}
+
+func TestPersonalSignWithMalformedSIWE(t *testing.T) {
+	state, close := setupCommand(t, Method_PersonalSign)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	// the sign-in header is there but the message misses the mandatory fields
+	address := "0x4B0897b0513FdBeEc7C469D9aF4fA6C0752aBea7"
+	message := "app.example.com wants you to sign in with your Ethereum account:\n" + address + "\n\nURI: https://app.example.com"
+	request, err := preparePersonalSignRequest(testDAppData, hexutil.Encode([]byte(message)), address)
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorPersonalSign:
+			var ev signal.ConnectorPersonalSignSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			var result siwe.Result
+			err = json.Unmarshal([]byte(ev.SIWE), &result)
+			assert.NoError(t, err)
+			assert.Nil(t, result.Message)
+			assert.Equal(t, []siwe.Warning{siwe.WarningMalformed}, result.Warnings)
+
+			err = state.handler.PersonalSignRejected(RejectedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrPersonalSignRejectedByUser, err)
+}
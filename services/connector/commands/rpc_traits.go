@@ -6,8 +6,12 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/params"
+	"github.com/status-im/status-go/services/wallet/simulation"
+	"github.com/status-im/status-go/services/wallet/token"
 	"github.com/status-im/status-go/signal"
 	"github.com/status-im/status-go/transactions"
 )
@@ -21,6 +25,9 @@ const (
 	Method_RequestPermissions  = "wallet_requestPermissions"
 	Method_RevokePermissions   = "wallet_revokePermissions"
 	Method_SwitchEthereumChain = "wallet_switchEthereumChain"
+	Method_SignTypedDataV4     = "eth_signTypedData_v4"
+	Method_AddEthereumChain    = "wallet_addEthereumChain"
+	Method_WatchAsset          = "wallet_watchAsset"
 )
 
 // errors
@@ -61,6 +68,19 @@ type PersonalSignAcceptedArgs struct {
 	Signature string `json:"signature"`
 }
 
+type SignTypedDataAcceptedArgs struct {
+	RequestID string `json:"requestId"`
+	Signature string `json:"signature"`
+}
+
+type AddEthereumChainAcceptedArgs struct {
+	RequestID string `json:"requestId"`
+}
+
+type WatchAssetAcceptedArgs struct {
+	RequestID string `json:"requestId"`
+}
+
 type RejectedArgs struct {
 	RequestID string `json:"requestId"`
 }
@@ -70,13 +90,25 @@ type ClientSideHandlerInterface interface {
 	RequestAccountsAccepted(args RequestAccountsAcceptedArgs) error
 	RequestAccountsRejected(args RejectedArgs) error
 
-	RequestSendTransaction(dApp signal.ConnectorDApp, chainID uint64, txArgs *transactions.SendTxArgs) (types.Hash, error)
+	RequestSendTransaction(dApp signal.ConnectorDApp, chainID uint64, txArgs *transactions.SendTxArgs, txSimulation *simulation.Result) (types.Hash, error)
 	SendTransactionAccepted(args SendTransactionAcceptedArgs) error
 	SendTransactionRejected(args RejectedArgs) error
 
-	RequestPersonalSign(dApp signal.ConnectorDApp, challenge, address string) (string, error)
+	RequestPersonalSign(dApp signal.ConnectorDApp, challenge, address, siwe string) (string, error)
 	PersonalSignAccepted(args PersonalSignAcceptedArgs) error
 	PersonalSignRejected(args RejectedArgs) error
+
+	RequestSignTypedData(dApp signal.ConnectorDApp, chainID uint64, address string, typedData string) (string, error)
+	SignTypedDataAccepted(args SignTypedDataAcceptedArgs) error
+	SignTypedDataRejected(args RejectedArgs) error
+
+	RequestAddEthereumChain(dApp signal.ConnectorDApp, chainID uint64, chainName string) error
+	AddEthereumChainAccepted(args AddEthereumChainAcceptedArgs) error
+	AddEthereumChainRejected(args RejectedArgs) error
+
+	RequestWatchAsset(dApp signal.ConnectorDApp, chainID uint64, asset *WatchAssetParams) error
+	WatchAssetAccepted(args WatchAssetAcceptedArgs) error
+	WatchAssetRejected(args RejectedArgs) error
 }
 
 type NetworkManagerInterface interface {
@@ -87,12 +119,26 @@ type RPCClientInterface interface {
 	CallRaw(body string) string
 }
 
+type TokenManagerInterface interface {
+	DiscoverToken(ctx context.Context, chainID uint64, address common.Address) (*token.Token, error)
+	UpsertCustom(token token.Token) error
+}
+
 func RPCRequestFromJSON(inputJSON string) (RPCRequest, error) {
 	var request RPCRequest
 
 	err := json.Unmarshal([]byte(inputJSON), &request)
 	if err != nil {
-		return RPCRequest{}, fmt.Errorf("error unmarshalling JSON: %v", err)
+		// `wallet_watchAsset` params are a single object instead of an array, it's handled as the first param
+		var objectParamsRequest struct {
+			RPCRequest
+			Params map[string]interface{} `json:"params"`
+		}
+		if json.Unmarshal([]byte(inputJSON), &objectParamsRequest) != nil {
+			return RPCRequest{}, fmt.Errorf("error unmarshalling JSON: %v", err)
+		}
+		request = objectParamsRequest.RPCRequest
+		request.Params = []interface{}{objectParamsRequest.Params}
 	}
 	return request, nil
 }
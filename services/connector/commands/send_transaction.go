@@ -8,11 +8,14 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/status-im/status-go/rpc"
 	persistence "github.com/status-im/status-go/services/connector/database"
 	"github.com/status-im/status-go/services/wallet/router/fees"
+	"github.com/status-im/status-go/services/wallet/simulation"
 	"github.com/status-im/status-go/signal"
 	"github.com/status-im/status-go/transactions"
 )
@@ -27,6 +30,7 @@ type SendTransactionCommand struct {
 	RpcClient     rpc.ClientInterface
 	Db            *sql.DB
 	ClientHandler ClientSideHandlerInterface
+	Simulator     *simulation.Simulator // optional, used to preview the transaction
 }
 
 func (r *RPCRequest) getSendTransactionParams() (*transactions.SendTxArgs, error) {
@@ -53,6 +57,22 @@ func (r *RPCRequest) getSendTransactionParams() (*transactions.SendTxArgs, error
 	return &sendTxArgs, nil
 }
 
+func callMsgFromSendTxArgs(args *transactions.SendTxArgs) ethereum.CallMsg {
+	msg := ethereum.CallMsg{
+		From:  common.Address(args.From),
+		Value: args.Value.ToInt(),
+		Data:  args.GetInput(),
+	}
+	if args.To != nil {
+		to := common.Address(*args.To)
+		msg.To = &to
+	}
+	if args.Gas != nil {
+		msg.Gas = uint64(*args.Gas)
+	}
+	return msg
+}
+
 func (c *SendTransactionCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
 	err := request.Validate()
 	if err != nil {
@@ -116,11 +136,20 @@ func (c *SendTransactionCommand) Execute(ctx context.Context, request RPCRequest
 		params.Nonce = (*hexutil.Uint64)(&nonce)
 	}
 
+	var txSimulation *simulation.Result
+	if c.Simulator != nil {
+		txSimulation, err = c.Simulator.Simulate(ctx, dApp.ChainID, callMsgFromSendTxArgs(params))
+		if err != nil {
+			// the preview is not mandatory, the user can still decide on the transaction
+			log.Warn("failed to simulate dApp transaction", "url", request.URL, "err", err)
+		}
+	}
+
 	hash, err := c.ClientHandler.RequestSendTransaction(signal.ConnectorDApp{
 		URL:     request.URL,
 		Name:    request.Name,
 		IconURL: request.IconURL,
-	}, dApp.ChainID, params)
+	}, dApp.ChainID, params, txSimulation)
 	if err != nil {
 		return "", err
 	}
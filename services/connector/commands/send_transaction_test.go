@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"math/big"
@@ -8,11 +9,13 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/status-im/status-go/eth-node/types"
 	mock_client "github.com/status-im/status-go/rpc/chain/mock/client"
+	"github.com/status-im/status-go/services/wallet/simulation"
 	"github.com/status-im/status-go/signal"
 	"github.com/status-im/status-go/transactions"
 )
@@ -178,3 +181,59 @@ func TestSendTransactionWithSignalRejected(t *testing.T) {
 	_, err = state.cmd.Execute(state.ctx, request)
 	assert.Equal(t, ErrSendTransactionRejectedByUser, err)
 }
+
+func TestSendTransactionWithSimulation(t *testing.T) {
+	state, close := setupCommand(t, Method_EthSendTransaction)
+	t.Cleanup(close)
+
+	fakedTransactionHash := types.Hash{0x051}
+
+	accountAddress := types.Address{0x01}
+	err := PersistDAppData(state.walletDb, testDAppData, accountAddress, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareSendTransactionRequest(testDAppData, accountAddress)
+	assert.NoError(t, err)
+
+	state.cmd.(*SendTransactionCommand).Simulator = simulation.NewSimulator(state.rpcClient)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorSendTransaction:
+			var ev signal.ConnectorSendTransactionSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			var txSimulation simulation.Result
+			err = json.Unmarshal([]byte(ev.TxSimulation), &txSimulation)
+			assert.NoError(t, err)
+			assert.True(t, txSimulation.Reverted)
+			assert.Equal(t, "not allowed", txSimulation.RevertReason)
+
+			err = state.handler.SendTransactionAccepted(SendTransactionAcceptedArgs{
+				Hash:      fakedTransactionHash,
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	mockedChainClient := mock_client.NewMockClientInterface(state.mockCtrl)
+	state.rpcClient.EXPECT().EthClient(uint64(1)).Times(3).Return(mockedChainClient, nil)
+	mockedChainClient.EXPECT().SuggestGasPrice(state.ctx).Times(1).Return(big.NewInt(1), nil)
+	mockedChainClient.EXPECT().SuggestGasTipCap(state.ctx).Times(1).Return(big.NewInt(0), errors.New("EIP-1559 is not enabled"))
+	mockedChainClient.EXPECT().PendingNonceAt(state.ctx, common.Address(accountAddress)).Times(1).Return(uint64(10), nil)
+	mockedChainClient.EXPECT().CallContext(state.ctx, gomock.Any(), "debug_traceCall", gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+		func(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+			return json.Unmarshal([]byte(`{"type":"CALL","error":"execution reverted","revertReason":"not allowed"}`), result)
+		})
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, response, fakedTransactionHash.String())
+}
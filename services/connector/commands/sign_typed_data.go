@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+
+	signercore "github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/services/typeddata"
+	"github.com/status-im/status-go/signal"
+)
+
+var (
+	ErrInvalidTypedDataParams = errors.New("typed data params must be an address and the typed data")
+	ErrInvalidTypedData       = errors.New("typed data is not a valid JSON object")
+)
+
+type SignTypedDataCommand struct {
+	Db            *sql.DB
+	ClientHandler ClientSideHandlerInterface
+}
+
+type SignTypedDataParams struct {
+	Address   string
+	TypedData string
+}
+
+func (r *RPCRequest) getSignTypedDataParams() (*SignTypedDataParams, error) {
+	if r.Params == nil || len(r.Params) == 0 {
+		return nil, ErrEmptyRPCParams
+	}
+
+	if len(r.Params) != 2 {
+		return nil, ErrInvalidTypedDataParams
+	}
+
+	address, ok := r.Params[0].(string)
+	if !ok {
+		return nil, ErrInvalidTypedDataParams
+	}
+
+	// dApps send the typed data either as a JSON string or as an object
+	var typedData string
+	switch data := r.Params[1].(type) {
+	case string:
+		typedData = data
+	case map[string]interface{}:
+		typedDataBytes, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		typedData = string(typedDataBytes)
+	default:
+		return nil, ErrInvalidTypedData
+	}
+
+	return &SignTypedDataParams{
+		Address:   address,
+		TypedData: typedData,
+	}, nil
+}
+
+// validateTypedDataV4 checks that the typed data can be signed on the given chain,
+// the same checks are done by `walletconnect.SafeSignTypedDataForDApps` once the user accepts the request
+func validateTypedDataV4(typedJSON string, chainID uint64) error {
+	var typed typeddata.TypedData
+	err := json.Unmarshal([]byte(typedJSON), &typed)
+	if err != nil {
+		return ErrInvalidTypedData
+	}
+
+	err = typed.Validate()
+	if err != nil {
+		return err
+	}
+
+	chain := new(big.Int).SetUint64(chainID)
+	if _, exist := typed.Domain[typeddata.ChainIDKey]; exist {
+		if err := typed.ValidateChainID(chain); err != nil {
+			return err
+		}
+	}
+
+	var typedV4 signercore.TypedData
+	err = json.Unmarshal([]byte(typedJSON), &typedV4)
+	if err != nil {
+		return ErrInvalidTypedData
+	}
+
+	_, err = typeddata.HashTypedDataV4(typedV4, chain)
+	return err
+}
+
+func (c *SignTypedDataCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
+	err := request.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	params, err := request.getSignTypedDataParams()
+	if err != nil {
+		return "", err
+	}
+
+	dApp, err := persistence.SelectDAppByUrl(c.Db, request.URL)
+	if err != nil {
+		return "", err
+	}
+
+	if dApp == nil {
+		return "", ErrDAppIsNotPermittedByUser
+	}
+
+	if !strings.EqualFold(params.Address, dApp.SharedAccount.Hex()) {
+		return "", ErrParamsFromAddressIsNotShared
+	}
+
+	err = validateTypedDataV4(params.TypedData, dApp.ChainID)
+	if err != nil {
+		return "", err
+	}
+
+	return c.ClientHandler.RequestSignTypedData(signal.ConnectorDApp{
+		URL:     request.URL,
+		Name:    request.Name,
+		IconURL: request.IconURL,
+	}, dApp.ChainID, dApp.SharedAccount.Hex(), params.TypedData)
+}
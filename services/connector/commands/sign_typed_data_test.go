@@ -0,0 +1,170 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/signal"
+)
+
+const testTypedDataAddress = "0x4B0897b0513FdBeEc7C469D9aF4fA6C0752aBea7"
+
+const testTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"}
+		],
+		"Mail": [
+			{"name": "from", "type": "address"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {"name": "Ether Mail", "version": "1", "chainId": 1},
+	"message": {"from": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", "contents": "Hello, Bob!"}
+}`
+
+func prepareSignTypedDataRequest(dApp signal.ConnectorDApp, address string, typedData string) (RPCRequest, error) {
+	return ConstructRPCRequest(Method_SignTypedDataV4, []interface{}{address, typedData}, &dApp)
+}
+
+func TestFailToSignTypedDataForUnpermittedDApp(t *testing.T) {
+	state, close := setupCommand(t, Method_SignTypedDataV4)
+	t.Cleanup(close)
+
+	request, err := prepareSignTypedDataRequest(testDAppData, testTypedDataAddress, testTypedData)
+	assert.NoError(t, err)
+
+	result, err := state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrDAppIsNotPermittedByUser, err)
+	assert.Empty(t, result)
+}
+
+func TestFailToSignTypedDataWithNotSharedAccount(t *testing.T) {
+	state, close := setupCommand(t, Method_SignTypedDataV4)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareSignTypedDataRequest(testDAppData, testTypedDataAddress, testTypedData)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrParamsFromAddressIsNotShared, err)
+}
+
+func TestFailToSignTypedDataForAnotherChain(t *testing.T) {
+	state, close := setupCommand(t, Method_SignTypedDataV4)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.HexToAddress(testTypedDataAddress), uint64(0xa))
+	assert.NoError(t, err)
+
+	request, err := prepareSignTypedDataRequest(testDAppData, testTypedDataAddress, testTypedData)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Error(t, err)
+}
+
+func TestFailToSignTypedDataWithSignalTimout(t *testing.T) {
+	state, close := setupCommand(t, Method_SignTypedDataV4)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.HexToAddress(testTypedDataAddress), uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareSignTypedDataRequest(testDAppData, testTypedDataAddress, testTypedData)
+	assert.NoError(t, err)
+
+	backupWalletResponseMaxInterval := WalletResponseMaxInterval
+	WalletResponseMaxInterval = 1 * time.Millisecond
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrWalletResponseTimeout, err)
+	WalletResponseMaxInterval = backupWalletResponseMaxInterval
+}
+
+func TestSignTypedDataWithSignalAccepted(t *testing.T) {
+	state, close := setupCommand(t, Method_SignTypedDataV4)
+	t.Cleanup(close)
+
+	fakedSignature := "0x051"
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.HexToAddress(testTypedDataAddress), uint64(0x1))
+	assert.NoError(t, err)
+
+	// typed data sent as an object
+	var typedDataObject map[string]interface{}
+	err = json.Unmarshal([]byte(testTypedData), &typedDataObject)
+	assert.NoError(t, err)
+	request, err := ConstructRPCRequest(Method_SignTypedDataV4, []interface{}{testTypedDataAddress, typedDataObject}, &testDAppData)
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorSignTypedData:
+			var ev signal.ConnectorSignTypedDataSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(0x1), ev.ChainID)
+			assert.Equal(t, types.HexToAddress(testTypedDataAddress).Hex(), ev.Address)
+			assert.JSONEq(t, testTypedData, ev.TypedData)
+
+			err = state.handler.SignTypedDataAccepted(SignTypedDataAcceptedArgs{
+				Signature: fakedSignature,
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, response, fakedSignature)
+}
+
+func TestSignTypedDataWithSignalRejected(t *testing.T) {
+	state, close := setupCommand(t, Method_SignTypedDataV4)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.HexToAddress(testTypedDataAddress), uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareSignTypedDataRequest(testDAppData, testTypedDataAddress, testTypedData)
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorSignTypedData:
+			var ev signal.ConnectorSignTypedDataSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			err = state.handler.SignTypedDataRejected(RejectedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrSignTypedDataRejectedByUser, err)
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/status-im/status-go/appdatabase"
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/params"
@@ -16,11 +19,14 @@ import (
 	"github.com/status-im/status-go/rpc/network"
 	persistence "github.com/status-im/status-go/services/connector/database"
 	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/token"
 	"github.com/status-im/status-go/signal"
 	"github.com/status-im/status-go/t/helpers"
 	"github.com/status-im/status-go/walletdatabase"
 )
 
+const testAssetAddress = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+
 var testDAppData = signal.ConnectorDApp{
 	URL:     "http://testDAppURL",
 	Name:    "testDAppName",
@@ -54,6 +60,26 @@ type testState struct {
 	handler   *ClientSideHandler
 	mockCtrl  *gomock.Controller
 	rpcClient *mock_rpcclient.MockClientInterface
+	tokens    *testTokenManager
+}
+
+type testTokenManager struct {
+	onChain map[common.Address]token.Token
+	tokens  []token.Token
+}
+
+func (m *testTokenManager) DiscoverToken(ctx context.Context, chainID uint64, address common.Address) (*token.Token, error) {
+	t, ok := m.onChain[address]
+	if !ok {
+		return nil, errors.New("not a token contract")
+	}
+	t.ChainID = chainID
+	return &t, nil
+}
+
+func (m *testTokenManager) UpsertCustom(token token.Token) error {
+	m.tokens = append(m.tokens, token)
+	return nil
 }
 
 func setupCommand(t *testing.T, method string) (state testState, close func()) {
@@ -71,12 +97,18 @@ func setupCommand(t *testing.T, method string) (state testState, close func()) {
 
 	err := networkManager.Init([]params.Network{
 		{
-			ChainID: walletCommon.EthereumMainnet,
-			Layer:   1,
+			ChainID:                walletCommon.EthereumMainnet,
+			ChainName:              "Mainnet",
+			Layer:                  1,
+			NativeCurrencySymbol:   "ETH",
+			NativeCurrencyDecimals: 18,
 		},
 		{
-			ChainID: walletCommon.OptimismMainnet,
-			Layer:   1,
+			ChainID:                walletCommon.OptimismMainnet,
+			ChainName:              "Optimism",
+			Layer:                  1,
+			NativeCurrencySymbol:   "ETH",
+			NativeCurrencyDecimals: 18,
 		},
 	})
 	require.NoError(t, err)
@@ -123,6 +155,33 @@ func setupCommand(t *testing.T, method string) (state testState, close func()) {
 			Db:             state.walletDb,
 			NetworkManager: networkManager,
 		}
+	case Method_SignTypedDataV4:
+		state.cmd = &SignTypedDataCommand{
+			Db:            state.walletDb,
+			ClientHandler: state.handler,
+		}
+	case Method_AddEthereumChain:
+		state.cmd = &AddEthereumChainCommand{
+			Db:             state.walletDb,
+			NetworkManager: networkManager,
+			ClientHandler:  state.handler,
+		}
+	case Method_WatchAsset:
+		state.tokens = &testTokenManager{
+			onChain: map[common.Address]token.Token{
+				common.HexToAddress(testAssetAddress): {
+					Address:  common.HexToAddress(testAssetAddress),
+					Name:     "USD Coin",
+					Symbol:   "USDC",
+					Decimals: 6,
+				},
+			},
+		}
+		state.cmd = &WatchAssetCommand{
+			Db:            state.walletDb,
+			TokenManager:  state.tokens,
+			ClientHandler: state.handler,
+		}
 	}
 
 	return state, func() {
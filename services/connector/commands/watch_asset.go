@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	persistence "github.com/status-im/status-go/services/connector/database"
+	"github.com/status-im/status-go/signal"
+)
+
+const (
+	watchAssetTypeERC20    = "ERC20"
+	watchAssetMaxSymbolLen = 11
+	watchAssetMaxDecimals  = 36
+)
+
+var (
+	ErrNoWatchAssetParamsFound    = errors.New("no watch asset params found")
+	ErrUnsupportedAssetType       = errors.New("unsupported asset type, only ERC20 is supported")
+	ErrInvalidAssetAddress        = errors.New("invalid asset address")
+	ErrInvalidAssetSymbol         = errors.New("invalid asset symbol")
+	ErrInvalidAssetDecimals       = errors.New("invalid asset decimals")
+	ErrTokenManagerNotInitialized = errors.New("token manager is not initialized")
+	ErrAssetSymbolMismatch        = errors.New("asset symbol doesn't match the symbol of the token contract")
+	ErrAssetDecimalsMismatch      = errors.New("asset decimals don't match the decimals of the token contract")
+)
+
+type WatchAssetCommand struct {
+	Db            *sql.DB
+	TokenManager  TokenManagerInterface
+	ClientHandler ClientSideHandlerInterface
+}
+
+type WatchAssetParams struct {
+	Address  common.Address
+	Symbol   string
+	Decimals uint
+	Image    string
+}
+
+// watchAssetRequest follows EIP-747
+type watchAssetRequest struct {
+	Type    string `json:"type"`
+	Options struct {
+		Address  string `json:"address"`
+		Symbol   string `json:"symbol"`
+		Decimals uint   `json:"decimals"`
+		Image    string `json:"image"`
+	} `json:"options"`
+}
+
+func (r *RPCRequest) getWatchAssetParams() (*WatchAssetParams, error) {
+	if r.Params == nil || len(r.Params) == 0 {
+		return nil, ErrEmptyRPCParams
+	}
+
+	paramMap, ok := r.Params[0].(map[string]interface{})
+	if !ok {
+		return nil, ErrNoWatchAssetParamsFound
+	}
+
+	paramBytes, err := json.Marshal(paramMap)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling watch asset param: %v", err)
+	}
+
+	var asset watchAssetRequest
+	err = json.Unmarshal(paramBytes, &asset)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling watch asset param: %v", err)
+	}
+
+	if asset.Type != watchAssetTypeERC20 {
+		return nil, ErrUnsupportedAssetType
+	}
+
+	if !common.IsHexAddress(asset.Options.Address) {
+		return nil, ErrInvalidAssetAddress
+	}
+
+	if len(asset.Options.Symbol) == 0 || len(asset.Options.Symbol) > watchAssetMaxSymbolLen {
+		return nil, ErrInvalidAssetSymbol
+	}
+
+	if asset.Options.Decimals > watchAssetMaxDecimals {
+		return nil, ErrInvalidAssetDecimals
+	}
+
+	return &WatchAssetParams{
+		Address:  common.HexToAddress(asset.Options.Address),
+		Symbol:   asset.Options.Symbol,
+		Decimals: asset.Options.Decimals,
+		Image:    asset.Options.Image,
+	}, nil
+}
+
+func (c *WatchAssetCommand) Execute(ctx context.Context, request RPCRequest) (interface{}, error) {
+	err := request.Validate()
+	if err != nil {
+		return false, err
+	}
+
+	params, err := request.getWatchAssetParams()
+	if err != nil {
+		return false, err
+	}
+
+	dApp, err := persistence.SelectDAppByUrl(c.Db, request.URL)
+	if err != nil {
+		return false, err
+	}
+
+	if dApp == nil {
+		return false, ErrDAppIsNotPermittedByUser
+	}
+
+	if c.TokenManager == nil {
+		return false, ErrTokenManagerNotInitialized
+	}
+
+	// the dApp may claim any symbol and decimals, they must match the ones of the contract
+	onChainToken, err := c.TokenManager.DiscoverToken(ctx, dApp.ChainID, params.Address)
+	if err != nil {
+		return false, err
+	}
+	if onChainToken.Symbol != params.Symbol {
+		return false, ErrAssetSymbolMismatch
+	}
+	if onChainToken.Decimals != params.Decimals {
+		return false, ErrAssetDecimalsMismatch
+	}
+
+	err = c.ClientHandler.RequestWatchAsset(signal.ConnectorDApp{
+		URL:     request.URL,
+		Name:    request.Name,
+		IconURL: request.IconURL,
+	}, dApp.ChainID, params)
+	if err != nil {
+		return false, err
+	}
+
+	err = c.TokenManager.UpsertCustom(*onChainToken)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
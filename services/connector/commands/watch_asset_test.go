@@ -0,0 +1,193 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/signal"
+)
+
+func prepareWatchAssetRequest(dApp signal.ConnectorDApp, assetType string, symbol string, decimals int) (RPCRequest, error) {
+	params := map[string]interface{}{
+		"type": assetType,
+		"options": map[string]interface{}{
+			"address":  testAssetAddress,
+			"symbol":   symbol,
+			"decimals": decimals,
+			"image":    "https://example.com/usdc.png",
+		},
+	}
+
+	return ConstructRPCRequest(Method_WatchAsset, []interface{}{params}, &dApp)
+}
+
+func TestWatchAssetParamsAsObject(t *testing.T) {
+	request, err := RPCRequestFromJSON(`{"jsonrpc":"2.0","id":1,"method":"wallet_watchAsset","params":{"type":"ERC20","options":{"address":"` + testAssetAddress + `","symbol":"USDC","decimals":6}},"url":"http://testDAppURL","name":"testDAppName"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, Method_WatchAsset, request.Method)
+	assert.Equal(t, "http://testDAppURL", request.URL)
+
+	params, err := request.getWatchAssetParams()
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToAddress(testAssetAddress), params.Address)
+	assert.Equal(t, "USDC", params.Symbol)
+	assert.Equal(t, uint(6), params.Decimals)
+}
+
+func TestFailToWatchInvalidAsset(t *testing.T) {
+	state, close := setupCommand(t, Method_WatchAsset)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		assetType string
+		symbol    string
+		decimals  int
+		err       error
+	}{
+		{"ERC721", "USDC", 6, ErrUnsupportedAssetType},
+		{"ERC20", "", 6, ErrInvalidAssetSymbol},
+		{"ERC20", "VERYLONGSYMBOL", 6, ErrInvalidAssetSymbol},
+		{"ERC20", "USDC", 37, ErrInvalidAssetDecimals},
+	}
+
+	for _, tc := range testCases {
+		request, err := prepareWatchAssetRequest(testDAppData, tc.assetType, tc.symbol, tc.decimals)
+		assert.NoError(t, err)
+
+		result, err := state.cmd.Execute(state.ctx, request)
+		assert.Equal(t, tc.err, err)
+		assert.Equal(t, false, result)
+	}
+	assert.Empty(t, state.tokens.tokens)
+}
+
+func TestFailToWatchAssetNotMatchingContract(t *testing.T) {
+	state, close := setupCommand(t, Method_WatchAsset)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	// the user is not asked to add a token the contract doesn't match
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		t.Errorf("unexpected signal: %s", s)
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	testCases := []struct {
+		symbol   string
+		decimals int
+		err      error
+	}{
+		{"USDT", 6, ErrAssetSymbolMismatch},
+		{"usdc", 6, ErrAssetSymbolMismatch},
+		{"USDC", 18, ErrAssetDecimalsMismatch},
+	}
+
+	for _, tc := range testCases {
+		request, err := prepareWatchAssetRequest(testDAppData, "ERC20", tc.symbol, tc.decimals)
+		assert.NoError(t, err)
+
+		result, err := state.cmd.Execute(state.ctx, request)
+		assert.Equal(t, tc.err, err)
+		assert.Equal(t, false, result)
+	}
+	assert.Empty(t, state.tokens.tokens)
+}
+
+func TestFailToWatchAssetForUnpermittedDApp(t *testing.T) {
+	state, close := setupCommand(t, Method_WatchAsset)
+	t.Cleanup(close)
+
+	request, err := prepareWatchAssetRequest(testDAppData, "ERC20", "USDC", 6)
+	assert.NoError(t, err)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrDAppIsNotPermittedByUser, err)
+}
+
+func TestWatchAssetWithSignalAccepted(t *testing.T) {
+	state, close := setupCommand(t, Method_WatchAsset)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0xa))
+	assert.NoError(t, err)
+
+	request, err := prepareWatchAssetRequest(testDAppData, "ERC20", "USDC", 6)
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorWatchAsset:
+			var ev signal.ConnectorWatchAssetSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(0xa), ev.ChainID)
+			assert.Equal(t, "USDC", ev.Symbol)
+			assert.Equal(t, uint(6), ev.Decimals)
+
+			err = state.handler.WatchAssetAccepted(WatchAssetAcceptedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	response, err := state.cmd.Execute(state.ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, true, response)
+
+	assert.Len(t, state.tokens.tokens, 1)
+	assert.Equal(t, common.HexToAddress(testAssetAddress), state.tokens.tokens[0].Address)
+	assert.Equal(t, uint64(0xa), state.tokens.tokens[0].ChainID)
+	assert.Equal(t, "USD Coin", state.tokens.tokens[0].Name)
+	assert.Equal(t, "USDC", state.tokens.tokens[0].Symbol)
+	assert.Equal(t, uint(6), state.tokens.tokens[0].Decimals)
+}
+
+func TestWatchAssetWithSignalRejected(t *testing.T) {
+	state, close := setupCommand(t, Method_WatchAsset)
+	t.Cleanup(close)
+
+	err := PersistDAppData(state.walletDb, testDAppData, types.Address{0x01}, uint64(0x1))
+	assert.NoError(t, err)
+
+	request, err := prepareWatchAssetRequest(testDAppData, "ERC20", "USDC", 6)
+	assert.NoError(t, err)
+
+	signal.SetMobileSignalHandler(signal.MobileSignalHandler(func(s []byte) {
+		var evt EventType
+		err := json.Unmarshal(s, &evt)
+		assert.NoError(t, err)
+
+		switch evt.Type {
+		case signal.EventConnectorWatchAsset:
+			var ev signal.ConnectorWatchAssetSignal
+			err := json.Unmarshal(evt.Event, &ev)
+			assert.NoError(t, err)
+
+			err = state.handler.WatchAssetRejected(RejectedArgs{
+				RequestID: ev.RequestID,
+			})
+			assert.NoError(t, err)
+		}
+	}))
+	t.Cleanup(signal.ResetMobileSignalHandler)
+
+	_, err = state.cmd.Execute(state.ctx, request)
+	assert.Equal(t, ErrWatchAssetRejectedByUser, err)
+	assert.Empty(t, state.tokens.tokens)
+}
@@ -8,6 +8,7 @@ import (
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/status-im/status-go/rpc"
 	"github.com/status-im/status-go/rpc/network"
+	"github.com/status-im/status-go/services/connector/commands"
 )
 
 func NewService(db *sql.DB, rpc rpc.ClientInterface, nm *network.Manager) *Service {
@@ -19,9 +20,16 @@ func NewService(db *sql.DB, rpc rpc.ClientInterface, nm *network.Manager) *Servi
 }
 
 type Service struct {
-	db  *sql.DB
-	rpc rpc.ClientInterface
-	nm  *network.Manager
+	db           *sql.DB
+	rpc          rpc.ClientInterface
+	nm           *network.Manager
+	tokenManager commands.TokenManagerInterface
+}
+
+// SetTokenManager provides the wallet token manager used by `wallet_watchAsset`,
+// it has to be called before the APIs are created
+func (s *Service) SetTokenManager(tokenManager commands.TokenManagerInterface) {
+	s.tokenManager = tokenManager
 }
 
 func (s *Service) Start() error {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/big"
 	"net/url"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -35,6 +36,7 @@ import (
 	"github.com/status-im/status-go/rpc"
 	"github.com/status-im/status-go/services/utils"
 	wcommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
 	"github.com/status-im/status-go/transactions"
 )
 
@@ -81,6 +83,8 @@ type API struct {
 	db                 *Database
 	syncUserDetailFunc *syncUsernameDetail
 
+	collectiblesProvider CollectiblesProvider
+
 	timeSource func() time.Time
 }
 
@@ -90,6 +94,11 @@ func (api *API) Stop() {
 	})
 }
 
+// SetCollectiblesProvider sets the provider used to load the NFT avatars
+func (api *API) SetCollectiblesProvider(provider CollectiblesProvider) {
+	api.collectiblesProvider = provider
+}
+
 func (api *API) unixTime() uint64 {
 	return uint64(api.timeSource().Unix())
 }
@@ -682,6 +691,459 @@ func (api *API) SetPubKeyEstimate(ctx context.Context, chainID uint64, txArgs tr
 	return estimate + 1000, nil
 }
 
+// RecordsUpdate holds the resolver records to set, addresses are keyed by SLIP-44 coin type
+type RecordsUpdate struct {
+	Texts     map[string]string        `json:"texts"`
+	Addresses map[uint64]hexutil.Bytes `json:"addresses"`
+}
+
+func (api *API) getNameResolver(chainID uint64) (*nameResolver, error) {
+	registryAddress, err := resolver.ContractAddress(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err := api.contractMaker.RPCClient.EthClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newNameResolver(backend, registryAddress), nil
+}
+
+// TextRecords reads the text records of the name, offchain (CCIP-read) and wildcard resolvers are supported
+func (api *API) TextRecords(ctx context.Context, chainID uint64, username string, keys []string) (map[string]string, error) {
+	err := ValidateENSUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := api.getNameResolver(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveTexts(ctx, r, username, keys)
+}
+
+func (api *API) Text(ctx context.Context, chainID uint64, username string, key string) (string, error) {
+	records, err := api.TextRecords(ctx, chainID, username, []string{key})
+	if err != nil {
+		return "", err
+	}
+
+	return records[key], nil
+}
+
+func resolveTexts(ctx context.Context, r *nameResolver, username string, keys []string) (map[string]string, error) {
+	node := NameHash(username)
+	calls := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		data, err := parsedPublicResolverABI.Pack("text", node, key)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, data)
+	}
+
+	results, err := r.resolve(ctx, username, calls...)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make(map[string]string, len(keys))
+	for i, result := range results {
+		values, err := parsedPublicResolverABI.Unpack("text", result)
+		if err != nil {
+			return nil, err
+		}
+		records[keys[i]] = values[0].(string)
+	}
+	return records, nil
+}
+
+// AddressOfCoin reads the ENSIP-9 multi-coin address record of the name, in the coin's binary format
+func (api *API) AddressOfCoin(ctx context.Context, chainID uint64, username string, coinType uint64) (hexutil.Bytes, error) {
+	err := ValidateENSUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := api.getNameResolver(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parsedPublicResolverABI.Pack("addr0", NameHash(username), new(big.Int).SetUint64(coinType))
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := r.resolve(ctx, username, data)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := parsedPublicResolverABI.Unpack("addr0", results[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return values[0].([]byte), nil
+}
+
+func resolveAddress(ctx context.Context, r *nameResolver, username string) (common.Address, error) {
+	data, err := parsedPublicResolverABI.Pack("addr", NameHash(username))
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	results, err := r.resolve(ctx, username, data)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	values, err := parsedPublicResolverABI.Unpack("addr", results[0])
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return values[0].(common.Address), nil
+}
+
+// Avatar resolves the ENSIP-12 avatar of the name, nil is returned if the name has none.
+// NFT avatars have to be owned by the name's address, their image comes from the collectibles providers.
+func (api *API) Avatar(ctx context.Context, chainID uint64, username string) (*Avatar, error) {
+	err := ValidateENSUsername(username)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := api.getNameResolver(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := resolveTexts(ctx, r, username, []string{AvatarTextKey})
+	if err != nil {
+		return nil, err
+	}
+
+	record := strings.TrimSpace(records[AvatarTextKey])
+	if record == "" {
+		return nil, nil
+	}
+
+	avatar := &Avatar{Record: record}
+	nft, err := parseNFTAvatar(record)
+	if err != nil {
+		return nil, err
+	}
+
+	if nft == nil {
+		avatar.URL, err = resolveAvatarURI(record)
+		if err != nil {
+			return nil, err
+		}
+		return avatar, nil
+	}
+
+	address, err := resolveAddress(ctx, r, username)
+	if err != nil {
+		return nil, err
+	}
+
+	nftBackend, err := api.contractMaker.RPCClient.EthClient(nft.chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	owned, err := nft.isOwnedBy(ctx, nftBackend, address)
+	if err != nil {
+		return nil, err
+	}
+	if !owned {
+		return nil, ErrAvatarNotOwned
+	}
+
+	collectibleID := nft.collectibleID()
+	avatar.Collectible = &collectibleID
+
+	if api.collectiblesProvider == nil {
+		return avatar, nil
+	}
+
+	collectibles, err := api.collectiblesProvider.FetchAssetsByCollectibleUniqueID(ctx, []thirdparty.CollectibleUniqueID{collectibleID}, false)
+	if err != nil {
+		log.Warn("fetching ENS avatar collectible failed", "username", username, "error", err)
+		return avatar, nil
+	}
+
+	if len(collectibles) > 0 && collectibles[0].CollectibleData.ImageURL != "" {
+		imageURL, err := resolveAvatarURI(collectibles[0].CollectibleData.ImageURL)
+		if err == nil {
+			avatar.URL = imageURL
+		}
+	}
+
+	return avatar, nil
+}
+
+func (api *API) SetRecords(ctx context.Context, chainID uint64, txArgs transactions.SendTxArgs, password string, username string, records RecordsUpdate) (string, error) {
+	callMsg, err := api.SetRecordsPrepareTxCallMsg(ctx, chainID, txArgs, username, records)
+	if err != nil {
+		return "", err
+	}
+
+	return api.sendCallMsg(chainID, txArgs, password, callMsg, transactions.SetENSRecords)
+}
+
+// SetRecordsPrepareTxCallMsg builds the call setting the text and address records on the name's resolver,
+// several records are set at once through the resolver's multicall
+func (api *API) SetRecordsPrepareTxCallMsg(ctx context.Context, chainID uint64, txArgs transactions.SendTxArgs, username string, records RecordsUpdate) (ethereum.CallMsg, error) {
+	err := ValidateENSUsername(username)
+	if err != nil {
+		return ethereum.CallMsg{}, err
+	}
+
+	node := NameHash(username)
+	calls := make([][]byte, 0, len(records.Texts)+len(records.Addresses))
+
+	keys := make([]string, 0, len(records.Texts))
+	for key := range records.Texts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		data, err := parsedPublicResolverABI.Pack("setText", node, key, records.Texts[key])
+		if err != nil {
+			return ethereum.CallMsg{}, err
+		}
+		calls = append(calls, data)
+	}
+
+	coinTypes := make([]uint64, 0, len(records.Addresses))
+	for coinType := range records.Addresses {
+		coinTypes = append(coinTypes, coinType)
+	}
+	sort.Slice(coinTypes, func(i, j int) bool { return coinTypes[i] < coinTypes[j] })
+	for _, coinType := range coinTypes {
+		data, err := parsedPublicResolverABI.Pack("setAddr", node, new(big.Int).SetUint64(coinType), []byte(records.Addresses[coinType]))
+		if err != nil {
+			return ethereum.CallMsg{}, err
+		}
+		calls = append(calls, data)
+	}
+
+	if len(calls) == 0 {
+		return ethereum.CallMsg{}, errors.New("no records to set")
+	}
+
+	data := calls[0]
+	if len(calls) > 1 {
+		data, err = parsedPublicResolverABI.Pack("multicall", calls)
+		if err != nil {
+			return ethereum.CallMsg{}, err
+		}
+	}
+
+	resolverAddress, err := api.Resolver(ctx, chainID, username)
+	if err != nil {
+		return ethereum.CallMsg{}, err
+	}
+	if *resolverAddress == (common.Address{}) {
+		return ethereum.CallMsg{}, ErrNoResolver
+	}
+
+	return ethereum.CallMsg{
+		From:  common.Address(txArgs.From),
+		To:    resolverAddress,
+		Value: big.NewInt(0),
+		Data:  data,
+	}, nil
+}
+
+func (api *API) SetRecordsPrepareTx(ctx context.Context, chainID uint64, txArgs transactions.SendTxArgs, username string, records RecordsUpdate) (interface{}, error) {
+	callMsg, err := api.SetRecordsPrepareTxCallMsg(ctx, chainID, txArgs, username, records)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCallArg(callMsg), nil
+}
+
+func (api *API) SetRecordsEstimate(ctx context.Context, chainID uint64, txArgs transactions.SendTxArgs, username string, records RecordsUpdate) (uint64, error) {
+	callMsg, err := api.SetRecordsPrepareTxCallMsg(ctx, chainID, txArgs, username, records)
+	if err != nil {
+		return 0, err
+	}
+
+	return api.estimateCallMsg(ctx, chainID, callMsg)
+}
+
+// SetSubname creates the `label.parent` subname owned by `owner`, or changes the owner if it exists.
+// The subname uses the parent's resolver, both registry owned and NameWrapper wrapped parents are supported.
+func (api *API) SetSubname(ctx context.Context, chainID uint64, txArgs transactions.SendTxArgs, password string, parent string, label string, owner common.Address) (string, error) {
+	callMsg, err := api.SetSubnamePrepareTxCallMsg(ctx, chainID, txArgs, parent, label, owner)
+	if err != nil {
+		return "", err
+	}
+
+	return api.sendCallMsg(chainID, txArgs, password, callMsg, transactions.SetENSSubname)
+}
+
+func (api *API) SetSubnamePrepareTxCallMsg(ctx context.Context, chainID uint64, txArgs transactions.SendTxArgs, parent string, label string, owner common.Address) (ethereum.CallMsg, error) {
+	err := ValidateENSUsername(parent)
+	if err != nil {
+		return ethereum.CallMsg{}, err
+	}
+	err = ValidateSubnameLabel(label)
+	if err != nil {
+		return ethereum.CallMsg{}, err
+	}
+
+	parentOwner, err := api.OwnerOf(ctx, chainID, parent)
+	if err != nil {
+		return ethereum.CallMsg{}, err
+	}
+
+	resolverAddress, err := api.Resolver(ctx, chainID, parent)
+	if err != nil {
+		return ethereum.CallMsg{}, err
+	}
+
+	from := common.Address(txArgs.From)
+	parentNode := NameHash(parent)
+
+	var to common.Address
+	var data []byte
+	nameWrapperAddress, err := resolver.NameWrapperContractAddress(chainID)
+	if err == nil && *parentOwner == nameWrapperAddress {
+		wrappedOwner, err := api.wrappedNameOwner(ctx, chainID, nameWrapperAddress, parentNode)
+		if err != nil {
+			return ethereum.CallMsg{}, err
+		}
+		if wrappedOwner != from {
+			return ethereum.CallMsg{}, ErrNotNameOwner
+		}
+
+		to = nameWrapperAddress
+		data, err = parsedNameWrapperABI.Pack("setSubnodeRecord", parentNode, label, owner, *resolverAddress, uint64(0), uint32(0), uint64(0))
+		if err != nil {
+			return ethereum.CallMsg{}, err
+		}
+	} else {
+		if *parentOwner != from {
+			return ethereum.CallMsg{}, ErrNotNameOwner
+		}
+
+		to, err = resolver.ContractAddress(chainID)
+		if err != nil {
+			return ethereum.CallMsg{}, err
+		}
+		registryABI, err := abi.JSON(strings.NewReader(resolver.ENSRegistryWithFallbackABI))
+		if err != nil {
+			return ethereum.CallMsg{}, err
+		}
+		data, err = registryABI.Pack("setSubnodeRecord", parentNode, UsernameToLabel(label), owner, *resolverAddress, uint64(0))
+		if err != nil {
+			return ethereum.CallMsg{}, err
+		}
+	}
+
+	return ethereum.CallMsg{
+		From:  from,
+		To:    &to,
+		Value: big.NewInt(0),
+		Data:  data,
+	}, nil
+}
+
+func (api *API) SetSubnamePrepareTx(ctx context.Context, chainID uint64, txArgs transactions.SendTxArgs, parent string, label string, owner common.Address) (interface{}, error) {
+	callMsg, err := api.SetSubnamePrepareTxCallMsg(ctx, chainID, txArgs, parent, label, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCallArg(callMsg), nil
+}
+
+func (api *API) SetSubnameEstimate(ctx context.Context, chainID uint64, txArgs transactions.SendTxArgs, parent string, label string, owner common.Address) (uint64, error) {
+	callMsg, err := api.SetSubnamePrepareTxCallMsg(ctx, chainID, txArgs, parent, label, owner)
+	if err != nil {
+		return 0, err
+	}
+
+	return api.estimateCallMsg(ctx, chainID, callMsg)
+}
+
+func (api *API) wrappedNameOwner(ctx context.Context, chainID uint64, nameWrapperAddress common.Address, node common.Hash) (common.Address, error) {
+	backend, err := api.contractMaker.RPCClient.EthClient(chainID)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	data, err := parsedNameWrapperABI.Pack("ownerOf", node.Big())
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	result, err := backend.CallContract(ctx, ethereum.CallMsg{To: &nameWrapperAddress, Data: data}, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	values, err := parsedNameWrapperABI.Unpack("ownerOf", result)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return values[0].(common.Address), nil
+}
+
+func (api *API) sendCallMsg(chainID uint64, txArgs transactions.SendTxArgs, password string, callMsg ethereum.CallMsg, trxType transactions.PendingTrxType) (string, error) {
+	backend, err := api.contractMaker.RPCClient.EthClient(chainID)
+	if err != nil {
+		return "", err
+	}
+
+	txOpts := txArgs.ToTransactOpts(utils.GetSigner(chainID, api.accountsManager, api.config.KeyStoreDir, txArgs.From, password))
+	contract := bind.NewBoundContract(*callMsg.To, abi.ABI{}, backend, backend, backend)
+	tx, err := contract.RawTransact(txOpts, callMsg.Data)
+	if err != nil {
+		return "", err
+	}
+
+	err = api.pendingTracker.TrackPendingTransaction(
+		wcommon.ChainID(chainID),
+		tx.Hash(),
+		common.Address(txArgs.From),
+		*callMsg.To,
+		trxType,
+		transactions.AutoDelete,
+		"",
+	)
+	if err != nil {
+		log.Error("TrackPendingTransaction error", "error", err)
+		return "", err
+	}
+
+	return tx.Hash().String(), nil
+}
+
+func (api *API) estimateCallMsg(ctx context.Context, chainID uint64, callMsg ethereum.CallMsg) (uint64, error) {
+	ethClient, err := api.contractMaker.RPCClient.EthClient(chainID)
+	if err != nil {
+		return 0, err
+	}
+
+	estimate, err := ethClient.EstimateGas(ctx, callMsg)
+	if err != nil {
+		return 0, err
+	}
+	return estimate + 1000, nil
+}
+
 func (api *API) ResourceURL(ctx context.Context, chainID uint64, username string) (*URI, error) {
 	scheme := "https"
 	contentHash, err := api.ContentHash(ctx, chainID, username)
@@ -0,0 +1,158 @@
+package ens
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/status-im/status-go/contracts/erc721"
+	"github.com/status-im/status-go/contracts/ierc1155"
+	"github.com/status-im/status-go/params"
+	"github.com/status-im/status-go/services/wallet/bigint"
+	wcommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+const AvatarTextKey = "avatar"
+
+const (
+	defaultIpfsGatewayURL = "https://ipfs.io/ipfs/"
+	arweaveGatewayURL     = "https://arweave.net/"
+)
+
+const (
+	nftStandardERC721  = "erc721"
+	nftStandardERC1155 = "erc1155"
+)
+
+var (
+	ErrInvalidAvatarRecord  = errors.New("invalid avatar record")
+	ErrUnsupportedAvatarURI = errors.New("unsupported avatar URI")
+	ErrAvatarNotOwned       = errors.New("avatar NFT is not owned by the name's address")
+)
+
+// CollectiblesProvider fetches the metadata of the NFTs set as avatar
+type CollectiblesProvider interface {
+	FetchAssetsByCollectibleUniqueID(ctx context.Context, uniqueIDs []thirdparty.CollectibleUniqueID, asyncFetch bool) ([]thirdparty.FullCollectibleData, error)
+}
+
+// Avatar is the ENSIP-12 avatar of a name
+type Avatar struct {
+	// Record is the raw `avatar` text record
+	Record string `json:"record"`
+	// URL is the http(s) or data URL of the image, empty if the NFT image is unknown
+	URL string `json:"url"`
+	// Collectible is set when the avatar is an NFT owned by the name's address
+	Collectible *thirdparty.CollectibleUniqueID `json:"collectible,omitempty"`
+}
+
+// nftAvatar is an avatar record pointing to an NFT, e.g. `eip155:1/erc721:0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB/1`
+type nftAvatar struct {
+	chainID  uint64
+	standard string
+	contract common.Address
+	tokenID  *big.Int
+}
+
+func (n *nftAvatar) collectibleID() thirdparty.CollectibleUniqueID {
+	return thirdparty.CollectibleUniqueID{
+		ContractID: thirdparty.ContractID{
+			ChainID: wcommon.ChainID(n.chainID),
+			Address: n.contract,
+		},
+		TokenID: &bigint.BigInt{Int: n.tokenID},
+	}
+}
+
+// parseNFTAvatar returns nil if the record is not an NFT reference
+func parseNFTAvatar(record string) (*nftAvatar, error) {
+	lowerRecord := strings.ToLower(strings.TrimSpace(record))
+	if !strings.HasPrefix(lowerRecord, "eip155:") {
+		return nil, nil
+	}
+
+	parts := strings.Split(lowerRecord[len("eip155:"):], "/")
+	if len(parts) != 3 {
+		return nil, ErrInvalidAvatarRecord
+	}
+
+	chainID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidAvatarRecord
+	}
+
+	standard, contract, found := strings.Cut(parts[1], ":")
+	if !found || (standard != nftStandardERC721 && standard != nftStandardERC1155) || !common.IsHexAddress(contract) {
+		return nil, ErrInvalidAvatarRecord
+	}
+
+	tokenID, ok := new(big.Int).SetString(parts[2], 10)
+	if !ok || tokenID.Sign() < 0 {
+		return nil, ErrInvalidAvatarRecord
+	}
+
+	return &nftAvatar{
+		chainID:  chainID,
+		standard: standard,
+		contract: common.HexToAddress(contract),
+		tokenID:  tokenID,
+	}, nil
+}
+
+// isOwnedBy checks the NFT is held by the address, as ENSIP-12 requires
+func (n *nftAvatar) isOwnedBy(ctx context.Context, caller bind.ContractCaller, address common.Address) (bool, error) {
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	if n.standard == nftStandardERC1155 {
+		contract, err := ierc1155.NewIerc1155Caller(n.contract, caller)
+		if err != nil {
+			return false, err
+		}
+		balance, err := contract.BalanceOf(callOpts, address, n.tokenID)
+		if err != nil {
+			return false, err
+		}
+		return balance.Sign() > 0, nil
+	}
+
+	contract, err := erc721.NewErc721Caller(n.contract, caller)
+	if err != nil {
+		return false, err
+	}
+	owner, err := contract.OwnerOf(callOpts, n.tokenID)
+	if err != nil {
+		return false, err
+	}
+	return owner == address, nil
+}
+
+func ipfsGatewayURL() string {
+	if params.IpfsGatewayURL != "" {
+		return params.IpfsGatewayURL
+	}
+	return defaultIpfsGatewayURL
+}
+
+// resolveAvatarURI returns the URL the avatar image can be loaded from
+func resolveAvatarURI(uri string) (string, error) {
+	uri = strings.TrimSpace(uri)
+	lowerURI := strings.ToLower(uri)
+
+	switch {
+	case strings.HasPrefix(lowerURI, "https://"), strings.HasPrefix(lowerURI, "http://"), strings.HasPrefix(lowerURI, "data:"):
+		return uri, nil
+	case strings.HasPrefix(lowerURI, "ipfs://"):
+		path := strings.TrimPrefix(uri[len("ipfs://"):], "ipfs/")
+		return ipfsGatewayURL() + path, nil
+	case strings.HasPrefix(lowerURI, "/ipfs/"):
+		return ipfsGatewayURL() + uri[len("/ipfs/"):], nil
+	case strings.HasPrefix(lowerURI, "ar://"):
+		return arweaveGatewayURL + uri[len("ar://"):], nil
+	}
+	return "", ErrUnsupportedAvatarURI
+}
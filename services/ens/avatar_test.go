@@ -0,0 +1,126 @@
+package ens
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/status-im/status-go/contracts/erc721"
+	"github.com/status-im/status-go/contracts/ierc1155"
+)
+
+func TestParseNFTAvatar(t *testing.T) {
+	nft, err := parseNFTAvatar("eip155:1/erc721:0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB/1")
+	require.NoError(t, err)
+	require.Equal(t, &nftAvatar{
+		chainID:  1,
+		standard: nftStandardERC721,
+		contract: common.HexToAddress("0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB"),
+		tokenID:  big.NewInt(1),
+	}, nft)
+
+	nft, err = parseNFTAvatar("eip155:10/erc1155:0x495f947276749ce646f68ac8c248420045cb7b5e/8112316025873927737505937898915153732580103913704334048512380490797008551937")
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), nft.chainID)
+	require.Equal(t, nftStandardERC1155, nft.standard)
+	require.Equal(t, "8112316025873927737505937898915153732580103913704334048512380490797008551937", nft.tokenID.String())
+
+	id := nft.collectibleID()
+	require.Equal(t, uint64(10), uint64(id.ContractID.ChainID))
+	require.Equal(t, nft.contract, id.ContractID.Address)
+
+	nft, err = parseNFTAvatar("https://example.com/avatar.png")
+	require.NoError(t, err)
+	require.Nil(t, nft)
+
+	for _, record := range []string{
+		"eip155:1/erc721:0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB",
+		"eip155:x/erc721:0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB/1",
+		"eip155:1/erc20:0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB/1",
+		"eip155:1/erc721:0x1234/1",
+		"eip155:1/erc721:0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB/0x1",
+	} {
+		_, err = parseNFTAvatar(record)
+		require.ErrorIs(t, err, ErrInvalidAvatarRecord, record)
+	}
+}
+
+func TestResolveAvatarURI(t *testing.T) {
+	for uri, expected := range map[string]string{
+		"https://example.com/avatar.png":     "https://example.com/avatar.png",
+		"data:image/svg+xml;base64,PHN2Zy8+": "data:image/svg+xml;base64,PHN2Zy8+",
+		"ipfs://QmTest/avatar.png":           ipfsGatewayURL() + "QmTest/avatar.png",
+		"ipfs://ipfs/QmTest":                 ipfsGatewayURL() + "QmTest",
+		"/ipfs/QmTest":                       ipfsGatewayURL() + "QmTest",
+		"ar://abcdef":                        arweaveGatewayURL + "abcdef",
+	} {
+		resolved, err := resolveAvatarURI(uri)
+		require.NoError(t, err)
+		require.Equal(t, expected, resolved)
+	}
+
+	_, err := resolveAvatarURI("ftp://example.com/avatar.png")
+	require.ErrorIs(t, err, ErrUnsupportedAvatarURI)
+}
+
+// fakeNFTCaller answers the ERC-721 ownerOf and ERC-1155 balanceOf calls
+type fakeNFTCaller struct {
+	owner   common.Address
+	balance int64
+}
+
+func (f *fakeNFTCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (f *fakeNFTCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	for _, contractABI := range []string{erc721.Erc721ABI, ierc1155.Ierc1155ABI} {
+		parsed, err := abi.JSON(strings.NewReader(contractABI))
+		if err != nil {
+			return nil, err
+		}
+		method, err := parsed.MethodById(call.Data[:4])
+		if err != nil {
+			continue
+		}
+		switch method.Name {
+		case "ownerOf":
+			return method.Outputs.Pack(f.owner)
+		case "balanceOf":
+			return method.Outputs.Pack(big.NewInt(f.balance))
+		}
+	}
+	return nil, errors.New("execution reverted")
+}
+
+func TestNFTAvatarOwnership(t *testing.T) {
+	owner := common.HexToAddress("0x1")
+	other := common.HexToAddress("0x2")
+	caller := &fakeNFTCaller{owner: owner, balance: 1}
+
+	erc721Avatar, err := parseNFTAvatar("eip155:1/erc721:0xb47e3cd837dDF8e4c57F05d70Ab865de6e193BBB/1")
+	require.NoError(t, err)
+	owned, err := erc721Avatar.isOwnedBy(context.Background(), caller, owner)
+	require.NoError(t, err)
+	require.True(t, owned)
+	owned, err = erc721Avatar.isOwnedBy(context.Background(), caller, other)
+	require.NoError(t, err)
+	require.False(t, owned)
+
+	erc1155Avatar, err := parseNFTAvatar("eip155:1/erc1155:0x495f947276749ce646f68ac8c248420045cb7b5e/1")
+	require.NoError(t, err)
+	owned, err = erc1155Avatar.isOwnedBy(context.Background(), caller, other)
+	require.NoError(t, err)
+	require.True(t, owned)
+	caller.balance = 0
+	owned, err = erc1155Avatar.isOwnedBy(context.Background(), caller, other)
+	require.NoError(t, err)
+	require.False(t, owned)
+}
@@ -0,0 +1,19 @@
+package ens
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// The subset of the ENS NameWrapper used to manage the subnames of wrapped names
+const nameWrapperABI = `[
+	{"name":"ownerOf","type":"function","stateMutability":"view","inputs":[{"name":"id","type":"uint256"}],"outputs":[{"name":"owner","type":"address"}]},
+	{"name":"setSubnodeRecord","type":"function","stateMutability":"nonpayable","inputs":[{"name":"parentNode","type":"bytes32"},{"name":"label","type":"string"},{"name":"owner","type":"address"},{"name":"resolver","type":"address"},{"name":"ttl","type":"uint64"},{"name":"fuses","type":"uint32"},{"name":"expiry","type":"uint64"}],"outputs":[{"name":"node","type":"bytes32"}]}
+]`
+
+var parsedNameWrapperABI, _ = abi.JSON(strings.NewReader(nameWrapperABI))
+
+var ErrNotNameOwner = errors.New("the sender does not own the name")
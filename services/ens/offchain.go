@@ -0,0 +1,313 @@
+package ens
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/status-im/status-go/contracts/resolver"
+)
+
+// EIP-3668 recommends limiting the number of lookups a single call can trigger
+const maxOffchainLookups = 4
+
+const maxGatewayResponseSize = 1 << 20
+
+const nameResolutionABI = `[
+	{"name":"resolve","type":"function","stateMutability":"view","inputs":[{"name":"name","type":"bytes"},{"name":"data","type":"bytes"}],"outputs":[{"name":"","type":"bytes"}]},
+	{"name":"supportsInterface","type":"function","stateMutability":"view","inputs":[{"name":"interfaceID","type":"bytes4"}],"outputs":[{"name":"","type":"bool"}]},
+	{"name":"OffchainLookup","type":"error","inputs":[{"name":"sender","type":"address"},{"name":"urls","type":"string[]"},{"name":"callData","type":"bytes"},{"name":"callbackFunction","type":"bytes4"},{"name":"extraData","type":"bytes"}]}
+]`
+
+// ENSIP-10 IExtendedResolver interface ID, i.e. the `resolve(bytes,bytes)` selector
+var extendedResolverInterfaceID = [4]byte{0x90, 0x61, 0xb9, 0x23}
+
+var (
+	parsedNameResolutionABI, _ = abi.JSON(strings.NewReader(nameResolutionABI))
+	parsedPublicResolverABI, _ = abi.JSON(strings.NewReader(resolver.PublicResolverABI))
+)
+
+var (
+	ErrNoResolver             = errors.New("no resolver set for the name")
+	ErrOffchainLookupFailed   = errors.New("offchain lookup failed")
+	ErrTooManyOffchainLookups = errors.New("too many offchain lookups")
+	ErrOffchainLookupSender   = errors.New("offchain lookup sender is not the called contract")
+)
+
+// nameResolver resolves names the way ENSIP-10 describes it: the resolver of the closest
+// ancestor is used when the name has none and the resolver is an extended (wildcard) one.
+// Calls reverting with an EIP-3668 OffchainLookup are answered through the listed gateways.
+type nameResolver struct {
+	caller     bind.ContractCaller
+	registry   common.Address
+	httpClient *http.Client
+}
+
+func newNameResolver(caller bind.ContractCaller, registry common.Address) *nameResolver {
+	return &nameResolver{
+		caller:     caller,
+		registry:   registry,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// findResolver returns the resolver of the name or of its closest ancestor having one,
+// exact is set when the resolver is the name's own
+func (r *nameResolver) findResolver(ctx context.Context, name string) (resolverAddress common.Address, exact bool, err error) {
+	registry, err := resolver.NewENSRegistryWithFallbackCaller(r.registry, r.caller)
+	if err != nil {
+		return common.Address{}, false, err
+	}
+
+	callOpts := &bind.CallOpts{Context: ctx}
+	current := name
+	for current != "" {
+		resolverAddress, err = registry.Resolver(callOpts, NameHash(current))
+		if err != nil {
+			return common.Address{}, false, err
+		}
+		if resolverAddress != (common.Address{}) {
+			return resolverAddress, current == name, nil
+		}
+
+		_, parent, found := strings.Cut(current, ".")
+		if !found {
+			break
+		}
+		current = parent
+	}
+	return common.Address{}, false, ErrNoResolver
+}
+
+func (r *nameResolver) supportsInterface(ctx context.Context, contract common.Address, interfaceID [4]byte) bool {
+	data, err := parsedNameResolutionABI.Pack("supportsInterface", interfaceID)
+	if err != nil {
+		return false
+	}
+	result, err := r.caller.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return false
+	}
+	values, err := parsedNameResolutionABI.Unpack("supportsInterface", result)
+	if err != nil || len(values) == 0 {
+		return false
+	}
+	supported, _ := values[0].(bool)
+	return supported
+}
+
+// resolve calls the name's resolver with each of the given resolver call data, e.g. `text(node, key)`
+func (r *nameResolver) resolve(ctx context.Context, name string, calls ...[]byte) ([][]byte, error) {
+	resolverAddress, exact, err := r.findResolver(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	extended := r.supportsInterface(ctx, resolverAddress, extendedResolverInterfaceID)
+	if !extended && !exact {
+		return nil, ErrNoResolver
+	}
+
+	results := make([][]byte, 0, len(calls))
+	for _, data := range calls {
+		if !extended {
+			result, err := r.call(ctx, resolverAddress, data)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+			continue
+		}
+
+		resolveData, err := parsedNameResolutionABI.Pack("resolve", DNSEncode(name), data)
+		if err != nil {
+			return nil, err
+		}
+		result, err := r.call(ctx, resolverAddress, resolveData)
+		if err != nil {
+			return nil, err
+		}
+		values, err := parsedNameResolutionABI.Unpack("resolve", result)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, values[0].([]byte))
+	}
+	return results, nil
+}
+
+// call runs the contract call and follows the offchain lookups it asks for
+func (r *nameResolver) call(ctx context.Context, to common.Address, data []byte) ([]byte, error) {
+	for i := 0; i <= maxOffchainLookups; i++ {
+		result, err := r.caller.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+		if err == nil {
+			return result, nil
+		}
+
+		lookup, ok := offchainLookupFromError(err)
+		if !ok {
+			return nil, err
+		}
+		if lookup.Sender != to {
+			return nil, ErrOffchainLookupSender
+		}
+
+		response, err := r.fetchOffchain(ctx, lookup)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err = lookup.callback(response)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, ErrTooManyOffchainLookups
+}
+
+type offchainLookup struct {
+	Sender           common.Address
+	URLs             []string
+	CallData         []byte
+	CallbackFunction [4]byte
+	ExtraData        []byte
+}
+
+// callback returns the call data of `callbackFunction(response, extraData)`
+func (l *offchainLookup) callback(response []byte) ([]byte, error) {
+	args := abi.Arguments{{Type: bytesType}, {Type: bytesType}}
+	encoded, err := args.Pack(response, l.ExtraData)
+	if err != nil {
+		return nil, err
+	}
+	return append(l.CallbackFunction[:], encoded...), nil
+}
+
+var bytesType, _ = abi.NewType("bytes", "", nil)
+
+func offchainLookupFromError(err error) (*offchainLookup, bool) {
+	var dataErr gethrpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil, false
+	}
+	return decodeOffchainLookup(common.FromHex(hexData))
+}
+
+func decodeOffchainLookup(data []byte) (*offchainLookup, bool) {
+	lookupError := parsedNameResolutionABI.Errors["OffchainLookup"]
+	values, err := lookupError.Unpack(data)
+	if err != nil {
+		return nil, false
+	}
+	args, ok := values.([]interface{})
+	if !ok || len(args) != 5 {
+		return nil, false
+	}
+
+	lookup := &offchainLookup{}
+	lookup.Sender, ok = args[0].(common.Address)
+	if !ok {
+		return nil, false
+	}
+	lookup.URLs, ok = args[1].([]string)
+	if !ok {
+		return nil, false
+	}
+	lookup.CallData, ok = args[2].([]byte)
+	if !ok {
+		return nil, false
+	}
+	lookup.CallbackFunction, ok = args[3].([4]byte)
+	if !ok {
+		return nil, false
+	}
+	lookup.ExtraData, ok = args[4].([]byte)
+	if !ok {
+		return nil, false
+	}
+	return lookup, true
+}
+
+type gatewayResponse struct {
+	Data    hexutil.Bytes `json:"data"`
+	Message string        `json:"message"`
+}
+
+// fetchOffchain queries the gateways in order, the next one is only tried after a server error
+func (r *nameResolver) fetchOffchain(ctx context.Context, lookup *offchainLookup) ([]byte, error) {
+	sender := strings.ToLower(lookup.Sender.Hex())
+	callData := hexutil.Encode(lookup.CallData)
+
+	var lastErr error = ErrOffchainLookupFailed
+	for _, gatewayURL := range lookup.URLs {
+		var req *http.Request
+		var err error
+		requestURL := strings.ReplaceAll(gatewayURL, "{sender}", sender)
+		if strings.Contains(gatewayURL, "{data}") {
+			requestURL = strings.ReplaceAll(requestURL, "{data}", callData)
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+		} else {
+			body, _ := json.Marshal(map[string]string{"data": callData, "sender": sender})
+			req, err = http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(body))
+			if err == nil {
+				req.Header.Set("Content-Type", "application/json")
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		data, retry, err := r.doGatewayRequest(req)
+		if err == nil {
+			return data, nil
+		}
+		if !retry {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func (r *nameResolver) doGatewayRequest(req *http.Request) (data []byte, retry bool, err error) {
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, true, errors.Wrap(ErrOffchainLookupFailed, err.Error())
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxGatewayResponseSize))
+	if err != nil {
+		return nil, true, errors.Wrap(ErrOffchainLookupFailed, err.Error())
+	}
+
+	var response gatewayResponse
+	_ = json.Unmarshal(body, &response)
+
+	if resp.StatusCode != http.StatusOK {
+		err = errors.Wrap(ErrOffchainLookupFailed, fmt.Sprintf("gateway returned %d %s", resp.StatusCode, response.Message))
+		// client errors are final, server errors may be answered by another gateway
+		return nil, resp.StatusCode >= http.StatusInternalServerError, err
+	}
+	if response.Data == nil {
+		return nil, false, errors.Wrap(ErrOffchainLookupFailed, "gateway response has no data")
+	}
+	return response.Data, false, nil
+}
@@ -0,0 +1,221 @@
+package ens
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/contracts/resolver"
+)
+
+var (
+	testRegistryAddress = common.HexToAddress("0xe25")
+	testResolverAddress = common.HexToAddress("0x4e5")
+	testCallback        = [4]byte{0x12, 0x34, 0x56, 0x78}
+)
+
+type revertError struct {
+	data []byte
+}
+
+func (e *revertError) Error() string {
+	return "execution reverted"
+}
+
+func (e *revertError) ErrorData() interface{} {
+	return hexutil.Encode(e.data)
+}
+
+// fakeENSCaller answers the registry and supportsInterface calls, the other calls are passed to callFn
+type fakeENSCaller struct {
+	resolvers map[common.Hash]common.Address
+	extended  bool
+	callFn    func(to common.Address, data []byte) ([]byte, error)
+}
+
+func (f *fakeENSCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (f *fakeENSCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if *call.To == testRegistryAddress {
+		registryABI, err := abi.JSON(strings.NewReader(resolver.ENSRegistryWithFallbackABI))
+		if err != nil {
+			return nil, err
+		}
+		method, err := registryABI.MethodById(call.Data[:4])
+		if err != nil {
+			return nil, err
+		}
+		args, err := method.Inputs.Unpack(call.Data[4:])
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(f.resolvers[common.Hash(args[0].([32]byte))])
+	}
+
+	if method, err := parsedNameResolutionABI.MethodById(call.Data[:4]); err == nil && method.Name == "supportsInterface" {
+		args, err := method.Inputs.Unpack(call.Data[4:])
+		if err != nil {
+			return nil, err
+		}
+		return method.Outputs.Pack(f.extended && args[0].([4]byte) == extendedResolverInterfaceID)
+	}
+
+	return f.callFn(*call.To, call.Data)
+}
+
+func offchainLookupRevert(t *testing.T, urls []string, callData []byte, extraData []byte) error {
+	lookupError := parsedNameResolutionABI.Errors["OffchainLookup"]
+	encoded, err := lookupError.Inputs.Pack(testResolverAddress, urls, callData, testCallback, extraData)
+	require.NoError(t, err)
+	return &revertError{data: append(lookupError.ID[:4], encoded...)}
+}
+
+func TestDNSEncode(t *testing.T) {
+	require.Equal(t, []byte{0}, DNSEncode(""))
+	require.Equal(t, []byte("\x03foo\x03eth\x00"), DNSEncode("foo.eth"))
+	require.Equal(t, []byte("\x01a\x07example\x03eth\x00"), DNSEncode("a.example.eth"))
+}
+
+func TestResolveWithExactResolver(t *testing.T) {
+	caller := &fakeENSCaller{
+		resolvers: map[common.Hash]common.Address{NameHash("name.eth"): testResolverAddress},
+		callFn: func(to common.Address, data []byte) ([]byte, error) {
+			require.Equal(t, testResolverAddress, to)
+			method, err := parsedPublicResolverABI.MethodById(data[:4])
+			require.NoError(t, err)
+			require.Equal(t, "text", method.Name)
+			args, err := method.Inputs.Unpack(data[4:])
+			require.NoError(t, err)
+			return method.Outputs.Pack("value of " + args[1].(string))
+		},
+	}
+	r := newNameResolver(caller, testRegistryAddress)
+
+	records, err := resolveTexts(context.Background(), r, "name.eth", []string{"url", "com.twitter"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"url": "value of url", "com.twitter": "value of com.twitter"}, records)
+
+	// the parent's resolver is only used if it is a wildcard resolver
+	_, err = resolveTexts(context.Background(), r, "sub.name.eth", []string{"url"})
+	require.ErrorIs(t, err, ErrNoResolver)
+
+	_, err = resolveTexts(context.Background(), r, "other.eth", []string{"url"})
+	require.ErrorIs(t, err, ErrNoResolver)
+}
+
+func TestResolveOffchain(t *testing.T) {
+	extraData := []byte("extra")
+	var gatewayCalls []string
+	var lookupCallData []byte
+
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gatewayCalls = append(gatewayCalls, req.Method+" "+req.URL.Path)
+		switch {
+		case strings.HasPrefix(req.URL.Path, "/down/"):
+			w.WriteHeader(http.StatusBadGateway)
+		case strings.HasPrefix(req.URL.Path, "/get/"):
+			require.Equal(t, "/get/"+strings.ToLower(testResolverAddress.Hex())+"/"+hexutil.Encode(lookupCallData)+".json", req.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		case req.URL.Path == "/post":
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			var payload map[string]string
+			require.NoError(t, json.Unmarshal(body, &payload))
+			require.Equal(t, hexutil.Encode(lookupCallData), payload["data"])
+			require.Equal(t, strings.ToLower(testResolverAddress.Hex()), payload["sender"])
+
+			response, err := parsedPublicResolverABI.Methods["text"].Outputs.Pack("https://status.app")
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string]string{"data": hexutil.Encode(response)})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "not found"})
+		}
+	}))
+	defer gateway.Close()
+
+	urls := []string{gateway.URL + "/get/{sender}/{data}.json", gateway.URL + "/post"}
+	caller := &fakeENSCaller{
+		resolvers: map[common.Hash]common.Address{NameHash("offchain.eth"): testResolverAddress},
+		extended:  true,
+	}
+	caller.callFn = func(to common.Address, data []byte) ([]byte, error) {
+		require.Equal(t, testResolverAddress, to)
+		if [4]byte(data[:4]) == testCallback {
+			args, err := abi.Arguments{{Type: bytesType}, {Type: bytesType}}.Unpack(data[4:])
+			require.NoError(t, err)
+			require.Equal(t, extraData, args[1].([]byte))
+			return parsedNameResolutionABI.Methods["resolve"].Outputs.Pack(args[0].([]byte))
+		}
+
+		method, err := parsedNameResolutionABI.MethodById(data[:4])
+		require.NoError(t, err)
+		require.Equal(t, "resolve", method.Name)
+		args, err := method.Inputs.Unpack(data[4:])
+		require.NoError(t, err)
+		require.Equal(t, DNSEncode("sub.offchain.eth"), args[0].([]byte))
+		lookupCallData = args[1].([]byte)
+		return nil, offchainLookupRevert(t, urls, lookupCallData, extraData)
+	}
+	r := newNameResolver(caller, testRegistryAddress)
+
+	// the wildcard resolver of the parent resolves the subname through the gateway
+	records, err := resolveTexts(context.Background(), r, "sub.offchain.eth", []string{"url"})
+	require.NoError(t, err)
+	require.Equal(t, "https://status.app", records["url"])
+	require.Equal(t, []string{"GET " + "/get/" + strings.ToLower(testResolverAddress.Hex()) + "/" + hexutil.Encode(lookupCallData) + ".json", "POST /post"}, gatewayCalls)
+
+	// client errors are not retried with the next gateway
+	gatewayCalls = nil
+	urls = []string{gateway.URL + "/missing", gateway.URL + "/post"}
+	_, err = resolveTexts(context.Background(), r, "sub.offchain.eth", []string{"url"})
+	require.ErrorIs(t, err, ErrOffchainLookupFailed)
+	require.Equal(t, []string{"POST /missing"}, gatewayCalls)
+
+	urls = []string{gateway.URL + "/down/{data}"}
+	_, err = resolveTexts(context.Background(), r, "sub.offchain.eth", []string{"url"})
+	require.ErrorIs(t, err, ErrOffchainLookupFailed)
+}
+
+func TestResolveOffchainLookupLimit(t *testing.T) {
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"data": "0x"})
+	}))
+	defer gateway.Close()
+
+	calls := 0
+	caller := &fakeENSCaller{
+		resolvers: map[common.Hash]common.Address{NameHash("loop.eth"): testResolverAddress},
+	}
+	caller.callFn = func(to common.Address, data []byte) ([]byte, error) {
+		calls++
+		return nil, offchainLookupRevert(t, []string{gateway.URL}, nil, nil)
+	}
+	r := newNameResolver(caller, testRegistryAddress)
+
+	_, err := resolveTexts(context.Background(), r, "loop.eth", []string{"url"})
+	require.ErrorIs(t, err, ErrTooManyOffchainLookups)
+	require.Equal(t, maxOffchainLookups+1, calls)
+
+	// reverts which are not lookups are returned as is
+	revertErr := errors.New("execution reverted")
+	caller.callFn = func(to common.Address, data []byte) ([]byte, error) {
+		return nil, revertErr
+	}
+	_, err = resolveTexts(context.Background(), r, "loop.eth", []string{"url"})
+	require.ErrorIs(t, err, revertErr)
+}
@@ -32,6 +32,31 @@ func ValidateENSUsername(username string) error {
 	return nil
 }
 
+// ValidateSubnameLabel checks the label is a single, non empty, name component
+func ValidateSubnameLabel(label string) error {
+	if label == "" || strings.Contains(label, ".") {
+		return fmt.Errorf("subname label must be a single non empty label")
+	}
+	if len(label) > 63 {
+		return fmt.Errorf("subname label is too long")
+	}
+
+	return nil
+}
+
+// DNSEncode returns the name in DNS wire format, as used by ENSIP-10 `resolve(bytes,bytes)`
+func DNSEncode(name string) []byte {
+	encoded := make([]byte, 0, len(name)+2)
+	if len(name) > 0 {
+		for _, label := range strings.Split(name, ".") {
+			encoded = append(encoded, byte(len(label)))
+			encoded = append(encoded, label...)
+		}
+	}
+
+	return append(encoded, 0)
+}
+
 func UsernameToLabel(username string) [32]byte {
 	usernameHashed := crypto.Keccak256([]byte(username))
 	var label [32]byte
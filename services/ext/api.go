@@ -15,6 +15,7 @@ import (
 	"github.com/status-im/status-go/account"
 	"github.com/status-im/status-go/services/browsers"
 	"github.com/status-im/status-go/services/wallet"
+	"github.com/status-im/status-go/services/wallet/activity"
 	"github.com/status-im/status-go/services/wallet/bigint"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -1144,6 +1145,19 @@ func (api *PublicAPI) RemainingCapacityForSavedAddresses(ctx context.Context, te
 	return api.service.messenger.RemainingCapacityForSavedAddresses(testnetMode)
 }
 
+// Activity notes APIs
+
+// SetActivityNote attaches a private note and label to a transaction and syncs it with the paired devices.
+// Setting an empty note and label removes it
+func (api *PublicAPI) SetActivityNote(ctx context.Context, note activity.Note) error {
+	return api.service.messenger.SetActivityNote(ctx, note)
+}
+
+// GetActivityNotes returns the notes of the transactions, all notes if no transaction hash is provided
+func (api *PublicAPI) GetActivityNotes(ctx context.Context, txHashes []ethcommon.Hash) ([]*activity.Note, error) {
+	return api.service.messenger.GetActivityNotes(txHashes)
+}
+
 // PushNotifications server endpoints
 func (api *PublicAPI) StartPushNotificationsServer() error {
 	err := api.service.accountsDB.SaveSettingField(settings.PushNotificationsServerEnabled, true)
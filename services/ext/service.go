@@ -760,7 +760,7 @@ func (s *Service) fetchCommunityCollectibleMetadata(community *communities.Commu
 
 func tokenCriterionContainsCollectible(tokenCriterion *protobuf.TokenCriteria, id thirdparty.CollectibleUniqueID) bool {
 	// Check if token type matches
-	if tokenCriterion.Type != protobuf.CommunityTokenType_ERC721 {
+	if tokenCriterion.Type != protobuf.CommunityTokenType_ERC721 && tokenCriterion.Type != protobuf.CommunityTokenType_ERC1155 {
 		return false
 	}
 
@@ -76,6 +76,11 @@ func (api *PublicAPI) Sign(rpcParams SignParams, verifiedAccount *account.Select
 		return
 	}
 
+	// the keys of the external signers aren't in the keystore used by the upstream `personal_sign`
+	if verifiedAccount.ExternalSigner != nil {
+		return account.SignPersonalMessage(verifiedAccount, rpcParams.Data)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), api.rpcTimeout)
 	defer cancel()
 	var gethResult hexutil.Bytes
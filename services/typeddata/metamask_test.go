@@ -1,6 +1,7 @@
 package typeddata
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"encoding/json"
 	"math/big"
@@ -11,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/status-im/status-go/account/signer"
 	"github.com/status-im/status-go/eth-node/types"
 )
 
@@ -73,6 +75,23 @@ func (s *TypedDataSuite) TestTypedDataV4() {
 	s.Require().Equal("0x65cbd956f2fae28a601bebc9b906cea0191744bd4c4247bcd27cd08f8eb6b71c78efdf7a31dc9abee78f492292721f362d296cf86b4538e07b51303b67f749061b", types.EncodeHex(signature))
 }
 
+func (s *TypedDataSuite) TestSigner() {
+	keySigner := signer.NewKeySigner(s.privateKey)
+	address := crypto.PubkeyToAddress(s.privateKey.PublicKey)
+
+	signature, err := keySigner.SignTypedData(context.Background(), address, s.typedDataV4)
+	s.Require().NoError(err)
+	s.Require().Equal("0x65cbd956f2fae28a601bebc9b906cea0191744bd4c4247bcd27cd08f8eb6b71c78efdf7a31dc9abee78f492292721f362d296cf86b4538e07b51303b67f749061b", types.EncodeHex(signature))
+
+	var typed TypedData
+	s.Require().NoError(json.Unmarshal([]byte(typedDataV3), &typed))
+	expected, err := Sign(typed, s.privateKey, big.NewInt(1))
+	s.Require().NoError(err)
+	signature, err = SignWithSigner(context.Background(), typed, keySigner, address, big.NewInt(1))
+	s.Require().NoError(err)
+	s.Require().Equal(expected, signature)
+}
+
 const typedDataV3 = `
 {
 	"types": {
@@ -1,6 +1,7 @@
 package typeddata
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
@@ -10,6 +11,8 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 
 	signercore "github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/status-im/status-go/account/signer"
 )
 
 var (
@@ -77,3 +80,18 @@ func Sign(typed TypedData, prv *ecdsa.PrivateKey, chain *big.Int) ([]byte, error
 	sig[64] += 27
 	return sig, nil
 }
+
+// SignWithSigner is Sign done by the signer of the account, the hash is signed as there is no standard
+// request for the legacy typed data
+func SignWithSigner(ctx context.Context, typed TypedData, s signer.Signer, address common.Address, chain *big.Int) ([]byte, error) {
+	hash, err := ValidateAndHash(typed, chain)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer.SignHash(ctx, s, address, hash)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/status-im/status-go/services/wallet/bigint"
 	"github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/poisoning"
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 	"github.com/status-im/status-go/services/wallet/transfer"
 	"github.com/status-im/status-go/transactions"
@@ -34,6 +35,7 @@ const (
 	MultiTransactionPT PayloadType = iota + 1
 	SimpleTransactionPT
 	PendingTransactionPT
+	ScheduledTransferPT
 )
 
 var (
@@ -53,6 +55,7 @@ type Entry struct {
 	payloadType     PayloadType
 	transaction     *transfer.TransactionIdentity
 	id              common.MultiTransactionIDType
+	scheduledID     int64 // set for ScheduledTransferPT, scheduled transfer IDs are not multi-transaction IDs
 	timestamp       int64
 	activityType    Type
 	activityStatus  Status
@@ -69,6 +72,7 @@ type Entry struct {
 	transferType    *TransferType
 	contractAddress *eth.Address
 	communityID     *string
+	poisoning       *poisoning.Assessment // set for the transfers flagged as address poisoning
 
 	isNew bool // isNew is used to indicate if the entry is newer than session start (changed state also)
 }
@@ -78,6 +82,7 @@ type EntryData struct {
 	PayloadType     PayloadType                    `json:"payloadType"`
 	Transaction     *transfer.TransactionIdentity  `json:"transaction,omitempty"`
 	ID              *common.MultiTransactionIDType `json:"id,omitempty"`
+	ScheduledID     *int64                         `json:"scheduledId,omitempty"`
 	Timestamp       *int64                         `json:"timestamp,omitempty"`
 	ActivityType    *Type                          `json:"activityType,omitempty"`
 	ActivityStatus  *Status                        `json:"activityStatus,omitempty"`
@@ -94,6 +99,7 @@ type EntryData struct {
 	TransferType    *TransferType                  `json:"transferType,omitempty"`
 	ContractAddress *eth.Address                   `json:"contractAddress,omitempty"`
 	CommunityID     *string                        `json:"communityId,omitempty"`
+	Poisoning       *poisoning.Assessment          `json:"poisoning,omitempty"`
 
 	IsNew *bool `json:"isNew,omitempty"`
 
@@ -119,10 +125,13 @@ func (e *Entry) MarshalJSON() ([]byte, error) {
 		TransferType:    e.transferType,
 		ContractAddress: e.contractAddress,
 		CommunityID:     e.communityID,
+		Poisoning:       e.poisoning,
 	}
 
 	if e.payloadType == MultiTransactionPT {
 		data.ID = common.NewAndSet(e.id)
+	} else if e.payloadType == ScheduledTransferPT {
+		data.ScheduledID = common.NewAndSet(e.scheduledID)
 	} else {
 		data.Transaction = e.transaction
 	}
@@ -145,6 +154,9 @@ func (e *Entry) UnmarshalJSON(data []byte) error {
 	if aux.ID != nil {
 		e.id = *aux.ID
 	}
+	if aux.ScheduledID != nil {
+		e.scheduledID = *aux.ScheduledID
+	}
 	if aux.Timestamp != nil {
 		e.timestamp = *aux.Timestamp
 	}
@@ -166,6 +178,7 @@ func (e *Entry) UnmarshalJSON(data []byte) error {
 	e.chainIDIn = aux.ChainIDIn
 	e.transferType = aux.TransferType
 	e.communityID = aux.CommunityID
+	e.poisoning = aux.Poisoning
 
 	e.isNew = aux.IsNew != nil && *aux.IsNew
 
@@ -249,6 +262,7 @@ func (e *Entry) getIdentity() EntryIdentity {
 	return EntryIdentity{
 		payloadType: e.payloadType,
 		id:          e.id,
+		scheduledID: e.scheduledID,
 		transaction: e.transaction,
 	}
 }
@@ -354,20 +368,61 @@ type FilterDependencies struct {
 	tokenFromSymbol func(chainID *common.ChainID, symbol string) *Token
 	// use to get current timestamp
 	currentTimestamp func() int64
+	// use to find the addresses known by a name matching the search text (ENS, saved addresses and contacts). Optional
+	addressesFromName func(text string) []eth.Address
+	// use to find the tokens with a symbol or name matching the search text. Optional
+	tokensFromName func(text string) []Token
+}
+
+// getActivityEntries returns the scheduled transfers followed by the transactions history entries. Scheduled transfers are
+// executed in the future so they're always on top of the history ordered by timestamp
+func getActivityEntries(ctx context.Context, deps FilterDependencies, addresses []eth.Address, allAddresses bool, chainIDs []common.ChainID, filter Filter, offset int, limit int) ([]Entry, error) {
+	if len(addresses) == 0 {
+		return nil, errors.New("no addresses provided")
+	}
+
+	search := newSearchTerms(deps, filter.SearchText)
+
+	scheduled, err := getScheduledTransferEntries(ctx, deps, addresses, chainIDs, filter, search)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	if offset < len(scheduled) {
+		end := offset + limit
+		if end > len(scheduled) {
+			end = len(scheduled)
+		}
+		entries = scheduled[offset:end]
+		limit -= len(entries)
+		offset = 0
+	} else {
+		offset -= len(scheduled)
+	}
+
+	if limit <= 0 {
+		return entries, nil
+	}
+
+	historyEntries, err := getHistoryEntries(ctx, deps, addresses, allAddresses, chainIDs, filter, search, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return append(entries, historyEntries...), nil
 }
 
-// getActivityEntries queries the transfers, pending_transactions, and multi_transactions tables based on filter parameters and arguments
+// getHistoryEntries queries the transfers, pending_transactions, and multi_transactions tables based on filter parameters and arguments
 // it returns metadata for all entries ordered by timestamp column
 //
 // addresses are mandatory and used to detect activity types SendAT and ReceiveAT for transfers entries
 //
 // allAddresses optimization indicates if the passed addresses include all the owners in the wallet DB
 //
+// search is the resolved filter.SearchText, nil if the search is disabled
+//
 // Adding a no-limit option was never considered or required.
-func getActivityEntries(ctx context.Context, deps FilterDependencies, addresses []eth.Address, allAddresses bool, chainIDs []common.ChainID, filter Filter, offset int, limit int) ([]Entry, error) {
-	if len(addresses) == 0 {
-		return nil, errors.New("no addresses provided")
-	}
+func getHistoryEntries(ctx context.Context, deps FilterDependencies, addresses []eth.Address, allAddresses bool, chainIDs []common.ChainID, filter Filter, search *searchTerms, offset int, limit int) ([]Entry, error) {
 
 	includeAllTokenTypeAssets := len(filter.Assets) == 0 && !filter.FilterOutAssets
 
@@ -471,8 +526,17 @@ func getActivityEntries(ctx context.Context, deps FilterDependencies, addresses
 		}
 	}
 
+	searchDisabled := search == nil
+	searchPattern := ""
+	searchNative := false
+	if !searchDisabled {
+		searchPattern = search.pattern
+		searchNative = search.native
+	}
+	searchAddresses, searchTokens, searchTokenCodes := search.sqlValues()
+
 	queryString := fmt.Sprintf(queryFormatString, involvedAddresses, toAddresses, assetsTokenCodes, assetsERC20, assetsERC721, networks,
-		layer2Networks, mintATQuery, joinedMTTypes)
+		layer2Networks, mintATQuery, searchAddresses, searchTokens, searchTokenCodes, joinedMTTypes)
 
 	// The duplicated temporary table UNION with CTE acts as an optimization
 	// As soon as we use filter_addresses CTE or filter_addresses_table temp table
@@ -498,6 +562,9 @@ func getActivityEntries(ctx context.Context, deps FilterDependencies, addresses
 		deps.currentTimestamp(),
 		648000, // 7.5 days in seconds for layer 2 finalization. 0.5 day is buffer to not create false positive.
 		960,    // A block on layer 1 is every 12s, finalization require 64 blocks. A buffer of 16 blocks is added to not create false positives.
+		filter.HideSpam,
+		filter.HidePoisoned,
+		searchDisabled, searchPattern, searchNative,
 		limit, offset)
 	if err != nil {
 		return nil, err
@@ -707,9 +774,39 @@ func getActivityEntries(ctx context.Context, deps FilterDependencies, addresses
 		return nil, err
 	}
 
+	err = fillPoisoningAssessments(deps.db, entries)
+	if err != nil {
+		return nil, err
+	}
+
 	return entries, nil
 }
 
+func transferID(transaction *transfer.TransactionIdentity) poisoning.TransferID {
+	return poisoning.TransferID{ChainID: uint64(transaction.ChainID), Hash: transaction.Hash, Address: transaction.Address}
+}
+
+// fillPoisoningAssessments sets the assessments of the transfers flagged as address poisoning
+func fillPoisoningAssessments(db *sql.DB, entries []Entry) error {
+	transfers := make([]poisoning.TransferID, 0, len(entries))
+	for _, entry := range entries {
+		if entry.payloadType == SimpleTransactionPT && entry.transaction != nil {
+			transfers = append(transfers, transferID(entry.transaction))
+		}
+	}
+
+	assessments, err := poisoning.NewPersistence(db).GetAssessments(transfers)
+	if err != nil {
+		return err
+	}
+	for i, entry := range entries {
+		if entry.payloadType == SimpleTransactionPT && entry.transaction != nil {
+			entries[i].poisoning = assessments[transferID(entry.transaction)]
+		}
+	}
+	return nil
+}
+
 func getTrInAndOutAmounts(activityType Type, trAmount sql.NullString, pTrAmount *big.Int) (inAmount *hexutil.Big, outAmount *hexutil.Big) {
 	var amount *big.Int
 	ok := false
@@ -5,10 +5,14 @@ import (
 	"database/sql"
 	"encoding/hex"
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/requests"
+	"github.com/status-im/status-go/services/wallet/router/sendtype"
+	"github.com/status-im/status-go/services/wallet/scheduledtransfer"
 	"github.com/status-im/status-go/services/wallet/testutils"
 	"github.com/status-im/status-go/services/wallet/transfer"
 	"github.com/status-im/status-go/t/helpers"
@@ -1190,6 +1194,93 @@ func TestGetActivityEntriesFilterByToAddresses(t *testing.T) {
 	require.Equal(t, 2, len(entries))
 }
 
+func TestGetActivityEntriesSearch(t *testing.T) {
+	deps, close := setupTestActivityDB(t)
+	defer close()
+
+	// Adds ETH/Sepolia, ETH/Optimism, USDC/Mainnet, USDC/Sepolia transfers
+	trs, fromTrs, toTrs := transfer.GenerateTestTransfers(t, deps.db, 1, 4)
+	for i := range trs {
+		transfer.InsertTestTransfer(t, deps.db, trs[i].To, &trs[i])
+	}
+	allAddresses := append(fromTrs, toTrs...)
+
+	deps.addressesFromName = func(text string) []eth.Address {
+		if text == "alice" {
+			return []eth.Address{trs[2].From}
+		}
+		return nil
+	}
+	deps.tokensFromName = func(text string) []Token {
+		var tokens []Token
+		for _, t := range transfer.TestTokens {
+			if strings.Contains(strings.ToLower(t.Name), strings.ToLower(text)) {
+				tokenType := Erc20
+				if t.IsNative() {
+					tokenType = Native
+				}
+				tokens = append(tokens, Token{TokenType: tokenType, ChainID: common.ChainID(t.ChainID), Address: t.Address})
+			}
+		}
+		return tokens
+	}
+
+	var filter Filter
+	filter.SearchText = "nothing to find"
+	entries, err := getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, filter, 0, 15)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(entries))
+
+	// Notes
+	notes := NewNotesManager(deps.db)
+	_, err = notes.SetNoteIfNewer(Note{ChainID: trs[1].ChainID, TxHash: trs[1].Hash, Note: "Rent for 50% of May", UpdateClock: 1})
+	require.NoError(t, err)
+
+	filter.SearchText = "50%"
+	entries, err = getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, filter, 0, 15)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+	require.Equal(t, trs[1].Hash, entries[0].transaction.Hash)
+
+	// The wildcards typed by the user are matched literally
+	filter.SearchText = "60%"
+	entries, err = getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, filter, 0, 15)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(entries))
+
+	// Names
+	filter.SearchText = "alice"
+	entries, err = getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, filter, 0, 15)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+	require.Equal(t, trs[2].Hash, entries[0].transaction.Hash)
+
+	filter.SearchText = trs[3].To.Hex()
+	entries, err = getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, filter, 0, 15)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+	require.Equal(t, trs[3].Hash, entries[0].transaction.Hash)
+
+	// Tokens
+	filter.SearchText = "USD Coin"
+	entries, err = getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, filter, 0, 15)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+
+	// Removed notes are not matched
+	_, err = notes.SetNoteIfNewer(Note{ChainID: trs[1].ChainID, TxHash: trs[1].Hash, UpdateClock: 2})
+	require.NoError(t, err)
+
+	filter.SearchText = "rent"
+	entries, err = getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, filter, 0, 15)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(entries))
+}
+
+func TestEscapeLikePattern(t *testing.T) {
+	require.Equal(t, `50\% of a\_b\\c`, escapeLikePattern(`50% of a_b\c`))
+}
+
 func TestGetActivityEntriesFilterByNetworks(t *testing.T) {
 	deps, close := setupTestActivityDB(t)
 	defer close()
@@ -1516,3 +1607,106 @@ func TestGetActivityEntriesSkipEthGasFeeOnlyTransfers(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 2, len(entries))
 }
+
+func TestGetActivityEntriesScheduledTransfers(t *testing.T) {
+	deps, close := setupTestActivityDB(t)
+	defer close()
+
+	trs, fromTrs, toTrs := transfer.GenerateTestTransfers(t, deps.db, 1, 4)
+	for i := range trs {
+		transfer.InsertTestTransfer(t, deps.db, trs[i].To, &trs[i])
+	}
+	allAddresses := append(fromTrs, toTrs...)
+
+	persistence := scheduledtransfer.NewPersistence(deps.db)
+	insertScheduledTransfer := func(sendType sendtype.SendType, nextExecution int64, status scheduledtransfer.Status) int64 {
+		id, err := persistence.InsertScheduledTransfer(&scheduledtransfer.ScheduledTransfer{
+			Params: &requests.RouteInputParams{
+				SendType:             sendType,
+				AddrFrom:             trs[0].From,
+				AddrTo:               trs[1].To,
+				AmountIn:             (*hexutil.Big)(big.NewInt(100)),
+				TokenID:              "ETH",
+				DisabledFromChainIDs: []uint64{42161},
+			},
+			StartAt:       nextExecution,
+			NextExecution: nextExecution,
+			Status:        status,
+		})
+		require.NoError(t, err)
+		return id
+	}
+	transferID := insertScheduledTransfer(sendtype.Transfer, mockupTime.Unix()+100, scheduledtransfer.StatusActive)
+	bridgeID := insertScheduledTransfer(sendtype.Bridge, mockupTime.Unix()+200, scheduledtransfer.StatusDue)
+	insertScheduledTransfer(sendtype.Transfer, mockupTime.Unix()+300, scheduledtransfer.StatusCancelled)
+
+	// scheduled transfers are on top of the history
+	entries, err := getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, Filter{}, 0, 3)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(entries))
+	require.Equal(t, Entry{
+		payloadType:    ScheduledTransferPT,
+		scheduledID:    bridgeID,
+		timestamp:      mockupTime.Unix() + 200,
+		activityType:   BridgeAT,
+		activityStatus: ScheduledAS,
+		amountOut:      (*hexutil.Big)(big.NewInt(100)),
+		amountIn:       (*hexutil.Big)(big.NewInt(0)),
+		tokenOut:       tokenFromSymbol(nil, "ETH"),
+		symbolOut:      common.NewAndSet("ETH"),
+		sender:         &trs[0].From,
+		recipient:      &trs[1].To,
+	}, entries[0])
+	require.Equal(t, transferID, entries[1].scheduledID)
+	require.Equal(t, SendAT, entries[1].activityType)
+	require.Equal(t, SimpleTransactionPT, entries[2].payloadType)
+	require.Equal(t, trs[3].Hash, entries[2].transaction.Hash)
+
+	// the offset includes the scheduled transfers
+	entries, err = getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, Filter{}, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+	require.Equal(t, ScheduledTransferPT, entries[0].payloadType)
+	require.Equal(t, trs[3].Hash, entries[1].transaction.Hash)
+
+	entries, err = getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, Filter{}, 3, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(entries))
+	require.Equal(t, trs[2].Hash, entries[0].transaction.Hash)
+
+	var filter Filter
+	filter.Statuses = []Status{ScheduledAS}
+	entries, err = getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, filter, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+
+	filter.Statuses = []Status{CompleteAS, FinalizedAS}
+	entries, err = getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{}, filter, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(entries))
+	require.Equal(t, SimpleTransactionPT, entries[0].payloadType)
+
+	filter = Filter{Types: []Type{SendAT}}
+	entries, err = getActivityEntries(context.Background(), deps, []eth.Address{trs[0].From}, false, []common.ChainID{}, filter, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, ScheduledTransferPT, entries[0].payloadType)
+	require.Equal(t, transferID, entries[0].scheduledID)
+	require.Equal(t, common.NoMultiTransactionID, entries[0].id)
+
+	// the transfer can't be sent from chain 42161, the bridge can still bridge to it
+	entries, err = getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{42161}, filter, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(entries))
+	filter = Filter{Types: []Type{BridgeAT}}
+	entries, err = getActivityEntries(context.Background(), deps, allAddresses, true, []common.ChainID{42161}, filter, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+	require.Equal(t, bridgeID, entries[0].scheduledID)
+
+	// scheduled transfers of other accounts are not included
+	entries, err = getActivityEntries(context.Background(), deps, []eth.Address{trs[1].From}, false, []common.ChainID{}, Filter{}, 0, 10)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		require.NotEqual(t, ScheduledTransferPT, entry.payloadType)
+	}
+}
@@ -0,0 +1,479 @@
+package activity
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	eth "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/status-im/status-go/services/wallet/async"
+	"github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+)
+
+const (
+	// EventActivityExportProgress contains an ExportProgress payload, sent after each exported page
+	EventActivityExportProgress walletevent.EventType = "wallet-activity-export-progress"
+	// EventActivityExportDone contains an ExportResponse payload
+	EventActivityExportDone walletevent.EventType = "wallet-activity-export-done"
+
+	exportPageSize = 100
+)
+
+var exportTask = async.TaskType{
+	ID:     5,
+	Policy: async.ReplacementPolicyIgnoreNew,
+}
+
+var (
+	ErrUnsupportedExportFormat = errors.New("unsupported export format")
+	ErrMissingExportFilePath   = errors.New("missing export file path")
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatJSON ExportFormat = "json"
+)
+
+type ExportOptions struct {
+	Format   ExportFormat `json:"format"`
+	FilePath string       `json:"filePath"`
+	// Currency of the fiat values, the user currency if empty
+	Currency string `json:"currency"`
+}
+
+// Validate checks the format and the file path of the export
+func (o *ExportOptions) Validate() error {
+	if o.Format != ExportFormatCSV && o.Format != ExportFormatJSON {
+		return ErrUnsupportedExportFormat
+	}
+	if o.FilePath == "" {
+		return ErrMissingExportFilePath
+	}
+	return nil
+}
+
+type ExportProgress struct {
+	Exported int `json:"exported"`
+}
+
+type ExportResponse struct {
+	FilePath  string    `json:"filePath"`
+	Count     int       `json:"count"`
+	ErrorCode ErrorCode `json:"errorCode"`
+}
+
+// ExchangeRates provides the daily fiat rates used to value the exported entries
+type ExchangeRates interface {
+	FetchAndCacheMissingRates(token string, currency string) error
+	GetExchangeRateForDay(token string, currency string, date time.Time) (float32, error)
+}
+
+// ExportRecord is an exported activity entry, amounts are in token units and fiat values in the export currency.
+// Fiat values are left empty when no rate is known for the day of the entry
+type ExportRecord struct {
+	Timestamp    int64    `json:"timestamp"`
+	Date         string   `json:"date"`
+	Type         string   `json:"type"`
+	Status       string   `json:"status"`
+	Hashes       []string `json:"hashes,omitempty"`
+	MultiTxID    int      `json:"multiTxId,omitempty"`
+	ChainIDOut   *uint64  `json:"chainIdOut,omitempty"`
+	ChainIDIn    *uint64  `json:"chainIdIn,omitempty"`
+	Sender       string   `json:"sender,omitempty"`
+	Recipient    string   `json:"recipient,omitempty"`
+	SymbolOut    string   `json:"symbolOut,omitempty"`
+	AmountOut    string   `json:"amountOut,omitempty"`
+	TokenIDOut   string   `json:"tokenIdOut,omitempty"`
+	SymbolIn     string   `json:"symbolIn,omitempty"`
+	AmountIn     string   `json:"amountIn,omitempty"`
+	TokenIDIn    string   `json:"tokenIdIn,omitempty"`
+	FeeSymbol    string   `json:"feeSymbol,omitempty"`
+	Fee          string   `json:"fee,omitempty"`
+	Currency     string   `json:"currency"`
+	FiatValueOut string   `json:"fiatValueOut,omitempty"`
+	FiatValueIn  string   `json:"fiatValueIn,omitempty"`
+	FiatFee      string   `json:"fiatFee,omitempty"`
+}
+
+var exportCSVHeader = []string{
+	"timestamp", "date", "type", "status", "hashes", "multi_tx_id", "chain_id_out", "chain_id_in", "sender", "recipient",
+	"symbol_out", "amount_out", "token_id_out", "symbol_in", "amount_in", "token_id_in", "fee_symbol", "fee",
+	"currency", "fiat_value_out", "fiat_value_in", "fiat_fee",
+}
+
+func optionalUintToString(value *uint64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatUint(*value, 10)
+}
+
+func (r *ExportRecord) csvRow() []string {
+	multiTxID := ""
+	if r.MultiTxID > 0 {
+		multiTxID = strconv.Itoa(r.MultiTxID)
+	}
+	return []string{
+		strconv.FormatInt(r.Timestamp, 10), r.Date, r.Type, r.Status, strings.Join(r.Hashes, ";"), multiTxID,
+		optionalUintToString(r.ChainIDOut), optionalUintToString(r.ChainIDIn), r.Sender, r.Recipient,
+		r.SymbolOut, r.AmountOut, r.TokenIDOut, r.SymbolIn, r.AmountIn, r.TokenIDIn, r.FeeSymbol, r.Fee,
+		r.Currency, r.FiatValueOut, r.FiatValueIn, r.FiatFee,
+	}
+}
+
+var activityTypeNames = map[Type]string{
+	SendAT:               "send",
+	ReceiveAT:            "receive",
+	BuyAT:                "buy",
+	SwapAT:               "swap",
+	BridgeAT:             "bridge",
+	ContractDeploymentAT: "contract-deployment",
+	MintAT:               "mint",
+	ApproveAT:            "approve",
+}
+
+var activityStatusNames = map[Status]string{
+	FailedAS:    "failed",
+	PendingAS:   "pending",
+	CompleteAS:  "complete",
+	FinalizedAS: "finalized",
+	ScheduledAS: "scheduled",
+}
+
+type exportWriter interface {
+	write(record *ExportRecord) error
+	close() error
+}
+
+type csvExportWriter struct {
+	file   *os.File
+	writer *csv.Writer
+}
+
+func newCSVExportWriter(file *os.File) (*csvExportWriter, error) {
+	w := &csvExportWriter{
+		file:   file,
+		writer: csv.NewWriter(file),
+	}
+	return w, w.writer.Write(exportCSVHeader)
+}
+
+func (w *csvExportWriter) write(record *ExportRecord) error {
+	return w.writer.Write(record.csvRow())
+}
+
+func (w *csvExportWriter) close() error {
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil {
+		return err
+	}
+	return w.file.Close()
+}
+
+// jsonExportWriter streams the records as a JSON array
+type jsonExportWriter struct {
+	file    *os.File
+	encoder *json.Encoder
+	count   int
+}
+
+func newJSONExportWriter(file *os.File) (*jsonExportWriter, error) {
+	_, err := file.WriteString("[\n")
+	return &jsonExportWriter{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, err
+}
+
+func (w *jsonExportWriter) write(record *ExportRecord) error {
+	if w.count > 0 {
+		if _, err := w.file.WriteString(","); err != nil {
+			return err
+		}
+	}
+	w.count++
+	return w.encoder.Encode(record)
+}
+
+func (w *jsonExportWriter) close() error {
+	if _, err := w.file.WriteString("]\n"); err != nil {
+		return err
+	}
+	return w.file.Close()
+}
+
+// formatAmount returns the amount in token units without trailing zeros
+func formatAmount(amount *big.Int, decimals uint) string {
+	value := new(big.Rat).SetFrac(amount, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	formatted := value.FloatString(int(decimals))
+	if strings.Contains(formatted, ".") {
+		formatted = strings.TrimRight(strings.TrimRight(formatted, "0"), ".")
+	}
+	return formatted
+}
+
+// exporter converts the entries to records, it caches the exchange rates fetched for the export
+type exporter struct {
+	s        *Service
+	currency string
+	rates    map[string]bool // symbols whose rates are fetched, false when they are not available
+}
+
+func (e *exporter) fiatValue(symbol string, amount string, timestamp int64) string {
+	if e.s.exchange == nil || symbol == "" || amount == "" {
+		return ""
+	}
+
+	available, fetched := e.rates[symbol]
+	if !fetched {
+		err := e.s.exchange.FetchAndCacheMissingRates(symbol, e.currency)
+		if err != nil {
+			log.Warn("failed to fetch exchange rates for export", "symbol", symbol, "currency", e.currency, "error", err)
+		}
+		available = err == nil
+		e.rates[symbol] = available
+	}
+	if !available {
+		return ""
+	}
+
+	rate, err := e.s.exchange.GetExchangeRateForDay(symbol, e.currency, time.Unix(timestamp, 0).UTC())
+	if err != nil || rate == 0 {
+		return ""
+	}
+
+	value, ok := new(big.Float).SetString(amount)
+	if !ok {
+		return ""
+	}
+	value.Mul(value, big.NewFloat(float64(rate)))
+	return value.Text('f', 2)
+}
+
+// tokenAmount returns the symbol and the formatted amount of a token, amounts of unknown tokens are raw
+func (e *exporter) tokenAmount(token *Token, symbol *string, amount *big.Int) (string, string, string) {
+	sym := ""
+	if symbol != nil {
+		sym = *symbol
+	}
+	if amount == nil {
+		return sym, "", ""
+	}
+
+	if token == nil {
+		return sym, amount.String(), ""
+	}
+
+	switch token.TokenType {
+	case Erc721, Erc1155:
+		tokenID := ""
+		if token.TokenID != nil {
+			tokenID = (*big.Int)(token.TokenID).String()
+		}
+		return sym, amount.String(), tokenID
+	default:
+		t := e.s.tokenManager.LookupTokenIdentity(uint64(token.ChainID), token.Address, token.TokenType == Native)
+		if t == nil {
+			return sym, amount.String(), ""
+		}
+		if sym == "" {
+			sym = t.Symbol
+		}
+		return sym, formatAmount(amount, t.Decimals), ""
+	}
+}
+
+// entryFees returns the details of the entry and the fees paid by the accounts in the native token of the chain
+func (e *exporter) entryFees(ctx context.Context, entry *Entry) (*EntryDetails, string, string, error) {
+	var details *EntryDetails
+	var err error
+	var chainID uint64
+
+	switch entry.payloadType {
+	case MultiTransactionPT:
+		details, err = getMultiTxDetails(ctx, e.s.db, int(entry.id))
+		if entry.chainIDOut != nil {
+			chainID = uint64(*entry.chainIDOut)
+		}
+	case SimpleTransactionPT:
+		details, err = getTxDetails(ctx, e.s.db, entry.transaction.Hash.Hex())
+		chainID = uint64(entry.transaction.ChainID)
+		// the fees of incoming transfers are paid by the sender
+		if entry.sender == nil || *entry.sender != entry.transaction.Address {
+			return details, "", "", err
+		}
+	default:
+		return nil, "", "", nil
+	}
+	if err != nil || details == nil || details.TotalFees == nil || chainID == 0 {
+		return details, "", "", err
+	}
+
+	native := e.s.tokenManager.LookupTokenIdentity(chainID, eth.Address{}, true)
+	if native == nil {
+		return details, "", "", nil
+	}
+	return details, native.Symbol, formatAmount(details.TotalFees.ToInt(), native.Decimals), nil
+}
+
+func (e *exporter) record(ctx context.Context, entry *Entry) *ExportRecord {
+	record := &ExportRecord{
+		Timestamp: entry.timestamp,
+		Date:      time.Unix(entry.timestamp, 0).UTC().Format(time.RFC3339),
+		Type:      activityTypeNames[entry.activityType],
+		Status:    activityStatusNames[entry.activityStatus],
+		Currency:  e.currency,
+	}
+
+	if entry.payloadType == MultiTransactionPT {
+		record.MultiTxID = int(entry.id)
+	} else if entry.transaction != nil {
+		record.Hashes = []string{entry.transaction.Hash.Hex()}
+	}
+	if entry.chainIDOut != nil {
+		record.ChainIDOut = common.NewAndSet(uint64(*entry.chainIDOut))
+	}
+	if entry.chainIDIn != nil {
+		record.ChainIDIn = common.NewAndSet(uint64(*entry.chainIDIn))
+	}
+	if entry.sender != nil {
+		record.Sender = entry.sender.Hex()
+	}
+	if entry.recipient != nil {
+		record.Recipient = entry.recipient.Hex()
+	}
+
+	record.SymbolOut, record.AmountOut, record.TokenIDOut = e.tokenAmount(entry.tokenOut, entry.symbolOut, entry.amountOut.ToInt())
+	record.SymbolIn, record.AmountIn, record.TokenIDIn = e.tokenAmount(entry.tokenIn, entry.symbolIn, entry.amountIn.ToInt())
+
+	details, feeSymbol, fee, err := e.entryFees(ctx, entry)
+	if err != nil {
+		// the entry is still exported, only its fees are missing
+		log.Warn("failed to get activity entry fees for export", "error", err)
+	}
+	if details != nil && entry.payloadType == MultiTransactionPT {
+		for _, chainDetails := range details.ChainDetails {
+			if chainDetails.Hash != (eth.Hash{}) {
+				record.Hashes = append(record.Hashes, chainDetails.Hash.Hex())
+			}
+		}
+	}
+	record.FeeSymbol = feeSymbol
+	record.Fee = fee
+
+	if record.TokenIDOut == "" {
+		record.FiatValueOut = e.fiatValue(record.SymbolOut, record.AmountOut, entry.timestamp)
+	}
+	if record.TokenIDIn == "" {
+		record.FiatValueIn = e.fiatValue(record.SymbolIn, record.AmountIn, entry.timestamp)
+	}
+	record.FiatFee = e.fiatValue(record.FeeSymbol, record.Fee, entry.timestamp)
+
+	return record
+}
+
+func (s *Service) exportActivity(ctx context.Context, requestID int32, addresses []eth.Address, chainIDs []common.ChainID, filter Filter, options ExportOptions) (int, error) {
+	currency := options.Currency
+	if currency == "" {
+		var err error
+		currency, err = s.accountsDB.GetCurrency()
+		if err != nil {
+			return 0, err
+		}
+	}
+	e := &exporter{
+		s:        s,
+		currency: currency,
+		rates:    make(map[string]bool),
+	}
+
+	// The export is written next to the destination and moved once complete to never leave a partial file
+	tmpFilePath := options.FilePath + ".tmp"
+	file, err := os.Create(tmpFilePath)
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmpFilePath)
+
+	var writer exportWriter
+	if options.Format == ExportFormatCSV {
+		writer, err = newCSVExportWriter(file)
+	} else {
+		writer, err = newJSONExportWriter(file)
+	}
+	if err != nil {
+		file.Close()
+		return 0, err
+	}
+
+	allAddresses := s.areAllAddresses(addresses)
+	deps := s.getDeps()
+	count := 0
+	for offset := 0; ; offset += exportPageSize {
+		entries, err := getActivityEntries(ctx, deps, addresses, allAddresses, chainIDs, filter, offset, exportPageSize)
+		if err != nil {
+			file.Close()
+			return count, err
+		}
+
+		for i := range entries {
+			if err = writer.write(e.record(ctx, &entries[i])); err != nil {
+				file.Close()
+				return count, err
+			}
+		}
+		count += len(entries)
+
+		if len(entries) < exportPageSize {
+			break
+		}
+		sendResponseEvent(s.eventFeed, &requestID, EventActivityExportProgress, ExportProgress{Exported: count}, nil)
+	}
+
+	if err = writer.close(); err != nil {
+		return count, err
+	}
+	return count, os.Rename(tmpFilePath, options.FilePath)
+}
+
+// ExportActivityAsync writes all the entries matching the filter to a file, only one export runs at a time.
+// EventActivityExportProgress events are sent while the entries are exported and an EventActivityExportDone event
+// with the result once the export is complete
+func (s *Service) ExportActivityAsync(requestID int32, addresses []eth.Address, chainIDs []common.ChainID, filter Filter, options ExportOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+
+	s.scheduler.Enqueue(requestID, exportTask, func(ctx context.Context) (interface{}, error) {
+		return s.exportActivity(ctx, requestID, addresses, chainIDs, filter, options)
+	}, func(result interface{}, taskType async.TaskType, err error) {
+		res := ExportResponse{
+			FilePath:  options.FilePath,
+			ErrorCode: ErrorCodeFailed,
+		}
+
+		if errors.Is(err, context.Canceled) || errors.Is(err, async.ErrTaskOverwritten) {
+			res.ErrorCode = ErrorCodeTaskCanceled
+		} else if err == nil {
+			res.Count = result.(int)
+			res.ErrorCode = ErrorCodeSuccess
+		} else {
+			err = fmt.Errorf("activity export failed: %w", err)
+		}
+
+		sendResponseEvent(s.eventFeed, &requestID, EventActivityExportDone, res, err)
+	})
+	return nil
+}
@@ -0,0 +1,92 @@
+package activity
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/status-go/services/wallet/walletevent"
+)
+
+func TestFormatAmount(t *testing.T) {
+	require.Equal(t, "1.5", formatAmount(big.NewInt(1500000), 6))
+	require.Equal(t, "0.000001", formatAmount(big.NewInt(1), 6))
+	require.Equal(t, "42", formatAmount(big.NewInt(42000000), 6))
+	require.Equal(t, "7", formatAmount(big.NewInt(7), 0))
+}
+
+func waitForExportDone(t *testing.T, ch chan walletevent.Event) *ExportResponse {
+	for {
+		select {
+		case res := <-ch:
+			if res.Type == EventActivityExportDone {
+				payload, err := walletevent.GetPayload[ExportResponse](res)
+				require.NoError(t, err)
+				return payload
+			}
+		case <-time.NewTimer(shouldNotWaitTimeout).C:
+			require.Fail(t, "timeout while waiting for EventActivityExportDone")
+		}
+	}
+}
+
+func TestService_ExportActivity(t *testing.T) {
+	state := setupTestService(t)
+	defer state.close()
+
+	allAddresses, _, ch, cleanup := setupTransactions(t, state, 5, nil)
+	defer cleanup()
+
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "activity.json")
+	err := state.service.ExportActivityAsync(1, allAddresses, allNetworksFilter(), Filter{}, ExportOptions{
+		Format:   ExportFormatJSON,
+		FilePath: jsonPath,
+		Currency: "usd",
+	})
+	require.NoError(t, err)
+
+	res := waitForExportDone(t, ch)
+	require.Equal(t, ErrorCodeSuccess, res.ErrorCode)
+	require.Equal(t, 5, res.Count)
+
+	content, err := os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	var records []ExportRecord
+	require.NoError(t, json.Unmarshal(content, &records))
+	require.Len(t, records, 5)
+	for _, record := range records {
+		require.Len(t, record.Hashes, 1)
+		require.Equal(t, "usd", record.Currency)
+		require.NotEmpty(t, record.Type)
+	}
+
+	csvPath := filepath.Join(dir, "activity.csv")
+	err = state.service.ExportActivityAsync(2, allAddresses, allNetworksFilter(), Filter{}, ExportOptions{
+		Format:   ExportFormatCSV,
+		FilePath: csvPath,
+		Currency: "usd",
+	})
+	require.NoError(t, err)
+
+	res = waitForExportDone(t, ch)
+	require.Equal(t, ErrorCodeSuccess, res.ErrorCode)
+	require.Equal(t, 5, res.Count)
+
+	_, err = os.Stat(csvPath)
+	require.NoError(t, err)
+	_, err = os.Stat(csvPath + ".tmp")
+	require.True(t, os.IsNotExist(err))
+
+	err = state.service.ExportActivityAsync(3, allAddresses, allNetworksFilter(), Filter{}, ExportOptions{
+		Format:   "xml",
+		FilePath: csvPath,
+	})
+	require.ErrorIs(t, err, ErrUnsupportedExportFormat)
+}
@@ -55,6 +55,7 @@ const (
 	PendingAS                 // in pending DB or at least one transaction in pending for multi-transactions
 	CompleteAS                // success status
 	FinalizedAS               // all multi-transactions have success status
+	ScheduledAS               // scheduled transfer waiting for its execution time or confirmation
 )
 
 func allActivityStatusesFilter() []Status {
@@ -98,6 +99,15 @@ type Filter struct {
 	Collectibles          []Token `json:"collectibles"`
 	FilterOutAssets       bool    `json:"filterOutAssets"`
 	FilterOutCollectibles bool    `json:"filterOutCollectibles"`
+
+	// HideSpam hides the transfers of the tokens and collectibles classified as spam
+	HideSpam bool `json:"hideSpam"`
+	// HidePoisoned hides the transfers flagged as address poisoning
+	HidePoisoned bool `json:"hidePoisoned"`
+
+	// SearchText matches the counterparty names (ENS, saved addresses and contacts), the token symbols and names,
+	// the collectible names and the notes of the transactions
+	SearchText string `json:"searchText"`
 }
 
 func (f *Filter) IsEmpty() bool {
@@ -109,7 +119,10 @@ func (f *Filter) IsEmpty() bool {
 		len(f.Assets) == 0 &&
 		len(f.Collectibles) == 0 &&
 		!f.FilterOutAssets &&
-		!f.FilterOutCollectibles
+		!f.FilterOutCollectibles &&
+		!f.HideSpam &&
+		!f.HidePoisoned &&
+		f.SearchText == ""
 }
 
 func GetRecipients(ctx context.Context, db *sql.DB, chainIDs []common.ChainID, addresses []eth.Address, offset int, limit int) (recipients []eth.Address, hasMore bool, err error) {
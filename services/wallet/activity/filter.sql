@@ -12,6 +12,9 @@
 -- 1. Filtering by symbol (multi_transactions and pending_transactions tables) where the chain ID is ignored, basically the filter_networks will account for that
 -- 2. Filtering by token identity (chain and address for transfers table) where the symbol is ignored and all the token identities must be provided
 --
+-- Text search matches the counterparties known by name (search_addresses), the tokens by identity or symbol, the collectible names and the notes of the transactions.
+-- The names are resolved to addresses and tokens before running the query
+--
 WITH filter_conditions AS (
 	SELECT
 		? AS startFilterDisabled,
@@ -44,6 +47,11 @@ WITH filter_conditions AS (
 		? AS nowTimestamp,
 		? AS layer2FinalisationDuration,
 		? AS layer1FinalisationDuration,
+		? AS hideSpam,
+		? AS hidePoisoned,
+		? AS searchDisabled,
+		? AS searchPattern,
+		? AS searchNative,
 		X'0000000000000000000000000000000000000000' AS zeroAddress,
 		'0x28c427b0611d99da5c4f7368abe57e86b045b483c4689ae93e90745802335b87' as communityMintEvent
 ),
@@ -156,6 +164,33 @@ layer2_networks(network_id) AS (
 ),
 mint_methods(method_hash) AS (
 	%s
+),
+search_addresses(address) AS (
+	VALUES
+		%s
+),
+search_tokens(chain_id, token_address) AS (
+	VALUES
+		%s
+),
+search_token_codes(token_code) AS (
+	VALUES
+		%s
+),
+search_notes(chain_id, tx_hash) AS (
+	SELECT
+		activity_notes.chain_id,
+		activity_notes.tx_hash
+	FROM
+		activity_notes,
+		filter_conditions
+	WHERE
+		NOT searchDisabled
+		AND activity_notes.removed = 0
+		AND (
+			activity_notes.note LIKE searchPattern ESCAPE '\'
+			OR activity_notes.label LIKE searchPattern ESCAPE '\'
+		)
 )
 
 SELECT
@@ -341,6 +376,88 @@ WHERE
 		includeAllNetworks
 		OR (transfers.network_id IN filter_networks)
 	)
+	AND (
+		NOT hideSpam
+		OR transfers.type NOT IN ('erc20', 'erc721', 'erc1155')
+		OR NOT EXISTS (
+			SELECT
+				1
+			FROM
+				asset_spam_classifications spam
+			WHERE
+				spam.chain_id = transfers.network_id
+				AND spam.address = transfers.token_address
+				AND spam.asset_type = CASE
+					WHEN transfers.type = 'erc20' THEN 'token'
+					ELSE 'collectible'
+				END
+				AND (
+					CASE
+						WHEN spam.user_verdict != '' THEN spam.user_verdict
+						ELSE spam.verdict
+					END
+				) = 'spam'
+		)
+	)
+	AND (
+		NOT hidePoisoned
+		OR NOT EXISTS (
+			SELECT
+				1
+			FROM
+				address_poisoning_flags poisoning
+			WHERE
+				poisoning.network_id = transfers.network_id
+				AND poisoning.hash = transfers.hash
+				AND poisoning.address = transfers.address
+		)
+	)
+	AND (
+		searchDisabled
+		OR transfers.tx_from_address IN search_addresses
+		OR transfers.tx_to_address IN search_addresses
+		OR (
+			transfers.type = 'eth'
+			AND searchNative
+		)
+		OR (
+			transfers.type = 'erc20'
+			AND (
+				transfers.network_id,
+				transfers.token_address
+			) IN search_tokens
+		)
+		OR (
+			transfers.network_id,
+			transfers.tx_hash
+		) IN search_notes
+		OR (
+			transfers.type IN ('erc721', 'erc1155')
+			AND (
+				EXISTS (
+					SELECT
+						1
+					FROM
+						collectible_data_cache
+					WHERE
+						collectible_data_cache.chain_id = transfers.network_id
+						AND collectible_data_cache.contract_address = transfers.token_address
+						AND collectible_data_cache.token_id = transfers.token_id
+						AND collectible_data_cache.name LIKE searchPattern ESCAPE '\'
+				)
+				OR EXISTS (
+					SELECT
+						1
+					FROM
+						collection_data_cache
+					WHERE
+						collection_data_cache.chain_id = transfers.network_id
+						AND collection_data_cache.contract_address = transfers.token_address
+						AND collection_data_cache.name LIKE searchPattern ESCAPE '\'
+				)
+			)
+		)
+	)
 	AND (
 		filterAllActivityStatus
 		OR (
@@ -439,6 +556,16 @@ WHERE
 			pending_transactions.network_id IN filter_networks
 		)
 	)
+	AND (
+		searchDisabled
+		OR pending_transactions.from_address IN search_addresses
+		OR pending_transactions.to_address IN search_addresses
+		OR UPPER(pending_transactions.symbol) IN search_token_codes
+		OR (
+			pending_transactions.network_id,
+			pending_transactions.hash
+		) IN search_notes
+	)
 UNION
 ALL
 SELECT
@@ -525,6 +652,37 @@ WHERE
 		filterAllToAddresses
 		OR (multi_transactions.to_address IN filter_to_addresses)
 	)
+	AND (
+		searchDisabled
+		OR multi_transactions.from_address IN search_addresses
+		OR multi_transactions.to_address IN search_addresses
+		OR UPPER(multi_transactions.from_asset) IN search_token_codes
+		OR UPPER(multi_transactions.to_asset) IN search_token_codes
+		OR multi_transactions.from_tx_hash IN (
+			SELECT
+				tx_hash
+			FROM
+				search_notes
+		)
+		OR multi_transactions.to_tx_hash IN (
+			SELECT
+				tx_hash
+			FROM
+				search_notes
+		)
+		OR EXISTS (
+			SELECT
+				1
+			FROM
+				transfers
+			WHERE
+				transfers.multi_transaction_id = multi_transactions.id
+				AND (
+					transfers.network_id,
+					transfers.tx_hash
+				) IN search_notes
+		)
+	)
 	AND (
 		includeAllTokenTypeAssets
 		OR (
@@ -0,0 +1,160 @@
+package activity
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	eth "github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/common"
+)
+
+var ErrNoteTooLong = errors.New("activity note is too long")
+
+const (
+	maxNoteLength  = 1000
+	maxLabelLength = 100
+)
+
+// Note is a private note and label the user attached to a transaction, identified by its chain and hash.
+// Removed notes are kept with an empty text so the removal is synced to the paired devices
+type Note struct {
+	ChainID     common.ChainID `json:"chainId"`
+	TxHash      eth.Hash       `json:"txHash"`
+	Note        string         `json:"note"`
+	Label       string         `json:"label"`
+	Removed     bool           `json:"removed"`
+	UpdateClock uint64         `json:"-"` // wall clock used to deconflict concurrent updates
+}
+
+type NotesManager struct {
+	db *sql.DB
+}
+
+func NewNotesManager(db *sql.DB) *NotesManager {
+	return &NotesManager{db: db}
+}
+
+func (n *Note) validate() error {
+	if len([]rune(n.Note)) > maxNoteLength || len([]rune(n.Label)) > maxLabelLength {
+		return ErrNoteTooLong
+	}
+	return nil
+}
+
+// SetNoteIfNewer stores the note unless a newer update of the same transaction note is already known.
+// An empty note and label removes the note
+func (nm *NotesManager) SetNoteIfNewer(note Note) (updated bool, err error) {
+	note.Note = strings.TrimSpace(note.Note)
+	note.Label = strings.TrimSpace(note.Label)
+	if err = note.validate(); err != nil {
+		return false, err
+	}
+	if note.Note == "" && note.Label == "" {
+		note.Removed = true
+	}
+	if note.Removed {
+		note.Note = ""
+		note.Label = ""
+	}
+
+	tx, err := nm.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	var dbUpdateClock uint64
+	err = tx.QueryRow(`SELECT update_clock FROM activity_notes WHERE chain_id = ? AND tx_hash = ?`,
+		note.ChainID, note.TxHash).Scan(&dbUpdateClock)
+	if err == nil && dbUpdateClock >= note.UpdateClock {
+		return false, nil
+	}
+	if err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO activity_notes (chain_id, tx_hash, note, label, removed, update_clock)
+		VALUES (?, ?, ?, ?, ?, ?)`, note.ChainID, note.TxHash, note.Note, note.Label, note.Removed, note.UpdateClock)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+const notesColumns = "chain_id, tx_hash, note, label, removed, update_clock"
+
+func getNotesFromDBRows(rows *sql.Rows) ([]*Note, error) {
+	var notes []*Note
+	for rows.Next() {
+		note := &Note{}
+		var txHash []byte
+		err := rows.Scan(&note.ChainID, &txHash, &note.Note, &note.Label, &note.Removed, &note.UpdateClock)
+		if err != nil {
+			return nil, err
+		}
+		note.TxHash = eth.BytesToHash(txHash)
+		notes = append(notes, note)
+	}
+	return notes, rows.Err()
+}
+
+// GetNote returns the note of the transaction, nil if there is none
+func (nm *NotesManager) GetNote(chainID common.ChainID, txHash eth.Hash) (*Note, error) {
+	rows, err := nm.db.Query(`SELECT `+notesColumns+` FROM activity_notes WHERE chain_id = ? AND tx_hash = ? AND removed = 0`,
+		chainID, txHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notes, err := getNotesFromDBRows(rows)
+	if err != nil || len(notes) == 0 {
+		return nil, err
+	}
+	return notes[0], nil
+}
+
+// GetNotes returns the notes of the transactions, all notes if no transaction is provided
+func (nm *NotesManager) GetNotes(txHashes []eth.Hash) ([]*Note, error) {
+	query := `SELECT ` + notesColumns + ` FROM activity_notes WHERE removed = 0`
+	args := make([]interface{}, 0, len(txHashes))
+	if len(txHashes) > 0 {
+		placeholders := make([]string, len(txHashes))
+		for i, hash := range txHashes {
+			placeholders[i] = "?"
+			args = append(args, hash)
+		}
+		query += ` AND tx_hash IN (` + strings.Join(placeholders, ",") + `)`
+	}
+
+	rows, err := nm.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return getNotesFromDBRows(rows)
+}
+
+// GetRawNotes returns all the notes including the removed ones, used for syncing
+func (nm *NotesManager) GetRawNotes() ([]*Note, error) {
+	rows, err := nm.db.Query(`SELECT ` + notesColumns + ` FROM activity_notes`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return getNotesFromDBRows(rows)
+}
+
+// DeleteSoftRemovedNotes drops the tombstones of the notes removed before the threshold
+func (nm *NotesManager) DeleteSoftRemovedNotes(threshold uint64) error {
+	_, err := nm.db.Exec(`DELETE FROM activity_notes WHERE removed = 1 AND update_clock < ?`, threshold)
+	return err
+}
@@ -0,0 +1,92 @@
+package activity
+
+import (
+	"strings"
+	"testing"
+
+	eth "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+func setupNotesTestDB(t *testing.T) *NotesManager {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	return NewNotesManager(db)
+}
+
+func TestNotesManager_SetNoteIfNewer(t *testing.T) {
+	nm := setupNotesTestDB(t)
+
+	hash := eth.HexToHash("0x1234")
+	updated, err := nm.SetNoteIfNewer(Note{ChainID: 1, TxHash: hash, Note: " Rent ", Label: "home", UpdateClock: 2})
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	note, err := nm.GetNote(1, hash)
+	require.NoError(t, err)
+	require.Equal(t, "Rent", note.Note)
+	require.Equal(t, "home", note.Label)
+
+	// Same transaction hash on another chain is another note
+	note, err = nm.GetNote(10, hash)
+	require.NoError(t, err)
+	require.Nil(t, note)
+
+	// Older updates are ignored
+	updated, err = nm.SetNoteIfNewer(Note{ChainID: 1, TxHash: hash, Note: "Old", UpdateClock: 1})
+	require.NoError(t, err)
+	require.False(t, updated)
+
+	updated, err = nm.SetNoteIfNewer(Note{ChainID: 1, TxHash: hash, Note: "Rent for May", UpdateClock: 3})
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	notes, err := nm.GetNotes([]eth.Hash{hash})
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	require.Equal(t, "Rent for May", notes[0].Note)
+	require.Equal(t, "", notes[0].Label)
+
+	_, err = nm.SetNoteIfNewer(Note{ChainID: 1, TxHash: hash, Note: strings.Repeat("a", maxNoteLength+1), UpdateClock: 4})
+	require.ErrorIs(t, err, ErrNoteTooLong)
+}
+
+func TestNotesManager_RemovedNotes(t *testing.T) {
+	nm := setupNotesTestDB(t)
+
+	hash := eth.HexToHash("0x1234")
+	_, err := nm.SetNoteIfNewer(Note{ChainID: 1, TxHash: hash, Note: "Rent", UpdateClock: 1})
+	require.NoError(t, err)
+
+	// An empty note removes it
+	updated, err := nm.SetNoteIfNewer(Note{ChainID: 1, TxHash: hash, UpdateClock: 2})
+	require.NoError(t, err)
+	require.True(t, updated)
+
+	notes, err := nm.GetNotes(nil)
+	require.NoError(t, err)
+	require.Len(t, notes, 0)
+
+	// The removal is kept to be synced
+	notes, err = nm.GetRawNotes()
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	require.True(t, notes[0].Removed)
+	require.Equal(t, uint64(2), notes[0].UpdateClock)
+
+	// An older update from a paired device doesn't restore it
+	updated, err = nm.SetNoteIfNewer(Note{ChainID: 1, TxHash: hash, Note: "Rent", UpdateClock: 1})
+	require.NoError(t, err)
+	require.False(t, updated)
+
+	require.NoError(t, nm.DeleteSoftRemovedNotes(3))
+	notes, err = nm.GetRawNotes()
+	require.NoError(t, err)
+	require.Len(t, notes, 0)
+}
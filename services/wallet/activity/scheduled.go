@@ -0,0 +1,142 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	eth "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/requests"
+	"github.com/status-im/status-go/services/wallet/router/sendtype"
+	"github.com/status-im/status-go/services/wallet/scheduledtransfer"
+)
+
+const scheduledTransfersQueryFormatString = `SELECT id, from_address, to_address, send_type, token_id, amount_in, route_params, next_execution
+	FROM scheduled_transfers
+	WHERE status IN (?, ?) AND from_address IN (%s)
+	ORDER BY next_execution DESC, id DESC`
+
+// getScheduledTransferEntries returns the scheduled transfers matching the filter. The chains of a scheduled transfer are
+// only known once its route is calculated, so a transfer matches the chains it isn't disabled on
+func getScheduledTransferEntries(ctx context.Context, deps FilterDependencies, addresses []eth.Address, chainIDs []common.ChainID, filter Filter, search *searchTerms) ([]Entry, error) {
+	if len(filter.Statuses) > 0 && !sliceContains(filter.Statuses, ScheduledAS) {
+		return nil, nil
+	}
+
+	rows, err := deps.db.QueryContext(ctx, fmt.Sprintf(scheduledTransfersQueryFormatString, joinAddresses(addresses)),
+		scheduledtransfer.StatusActive, scheduledtransfer.StatusDue)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assetSymbols []string
+	for _, asset := range filter.Assets {
+		assetSymbols = append(assetSymbols, deps.tokenSymbol(asset))
+	}
+
+	var entries []Entry
+	for rows.Next() {
+		var id, nextExecution int64
+		var fromAddress, toAddress eth.Address
+		var sendType sendtype.SendType
+		var tokenID, amountIn, routeParams string
+		err := rows.Scan(&id, &fromAddress, &toAddress, &sendType, &tokenID, &amountIn, &routeParams, &nextExecution)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(chainIDs) > 0 {
+			var params requests.RouteInputParams
+			err = json.Unmarshal([]byte(routeParams), &params)
+			if err != nil {
+				log.Warn("invalid scheduled transfer route params", "id", id, "err", err)
+				continue
+			}
+			if !scheduledTransferMatchesChains(&params, chainIDs) {
+				continue
+			}
+		}
+
+		activityType := SendAT
+		if sendType == sendtype.Bridge {
+			activityType = BridgeAT
+		}
+
+		if !scheduledTransferMatchesFilter(filter, activityType, sendType.IsCollectiblesTransfer(), toAddress, nextExecution) {
+			continue
+		}
+
+		amount, err := hexutil.DecodeBig(amountIn)
+		if err != nil {
+			log.Warn("invalid scheduled transfer amount", "id", id, "amount", amountIn)
+			amount = big.NewInt(0)
+		}
+
+		entry := Entry{
+			payloadType:    ScheduledTransferPT,
+			scheduledID:    id,
+			timestamp:      nextExecution,
+			activityType:   activityType,
+			activityStatus: ScheduledAS,
+			amountOut:      (*hexutil.Big)(amount),
+			amountIn:       (*hexutil.Big)(big.NewInt(0)),
+			sender:         &fromAddress,
+			recipient:      &toAddress,
+		}
+
+		if search != nil && !search.matchesAddress(fromAddress) && !search.matchesAddress(toAddress) &&
+			(sendType.IsCollectiblesTransfer() || !search.matchesSymbol(tokenID)) {
+			continue
+		}
+
+		if !sendType.IsCollectiblesTransfer() {
+			if len(assetSymbols) > 0 && !sliceContains(assetSymbols, tokenID) {
+				continue
+			}
+			entry.tokenOut = deps.tokenFromSymbol(nil, tokenID)
+			entry.symbolOut = common.NewAndSet(tokenID)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// scheduledTransferMatchesChains returns true if the transfer can be sent from, or bridged to, any of the chains
+func scheduledTransferMatchesChains(params *requests.RouteInputParams, chainIDs []common.ChainID) bool {
+	for _, chainID := range chainIDs {
+		if !common.ArrayContainsElement(uint64(chainID), params.DisabledFromChainIDs) {
+			return true
+		}
+		if params.SendType == sendtype.Bridge && !common.ArrayContainsElement(uint64(chainID), params.DisabledToChainIDs) {
+			return true
+		}
+	}
+	return false
+}
+
+func scheduledTransferMatchesFilter(filter Filter, activityType Type, collectible bool, toAddress eth.Address, timestamp int64) bool {
+	if len(filter.Types) > 0 && !sliceContains(filter.Types, activityType) {
+		return false
+	}
+	if len(filter.CounterpartyAddresses) > 0 && !sliceChecksCondition(filter.CounterpartyAddresses, func(a *eth.Address) bool { return *a == toAddress }) {
+		return false
+	}
+	if filter.Period.StartTimestamp > 0 && timestamp < filter.Period.StartTimestamp {
+		return false
+	}
+	if filter.Period.EndTimestamp > 0 && timestamp > filter.Period.EndTimestamp {
+		return false
+	}
+	if collectible {
+		return !filter.FilterOutCollectibles && len(filter.Collectibles) == 0
+	}
+	return !filter.FilterOutAssets
+}
@@ -0,0 +1,113 @@
+package activity
+
+import (
+	"fmt"
+	"strings"
+
+	eth "github.com/ethereum/go-ethereum/common"
+)
+
+const searchEscapeChar = `\`
+
+// searchTerms is the search text of the filter resolved against the names known by the wallet
+type searchTerms struct {
+	// pattern is the LIKE pattern matching the text anywhere in the notes and collectible names
+	pattern string
+	// addresses are the counterparties known by a name matching the text
+	addresses []eth.Address
+	// tokens are the ERC20 tokens with a symbol or name matching the text
+	tokens []Token
+	// native is set if the native token of a chain matches the text
+	native bool
+	// symbols are the upper case symbols of the matching tokens, used for the symbol bearing entries
+	symbols []string
+}
+
+// newSearchTerms returns nil if the search is disabled
+func newSearchTerms(deps FilterDependencies, text string) *searchTerms {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	s := &searchTerms{
+		pattern: "%" + escapeLikePattern(text) + "%",
+	}
+
+	if eth.IsHexAddress(text) {
+		s.addresses = append(s.addresses, eth.HexToAddress(text))
+	}
+	if deps.addressesFromName != nil {
+		s.addresses = append(s.addresses, deps.addressesFromName(text)...)
+	}
+
+	symbolsSet := make(map[string]struct{})
+	addSymbol := func(symbol string) {
+		symbol = strings.ToUpper(symbol)
+		if _, ok := symbolsSet[symbol]; symbol != "" && !ok {
+			symbolsSet[symbol] = struct{}{}
+			s.symbols = append(s.symbols, symbol)
+		}
+	}
+	// The symbol typed by the user is matched even if the token is not known, e.g. a custom token
+	addSymbol(text)
+
+	if deps.tokensFromName != nil {
+		for _, token := range deps.tokensFromName(text) {
+			switch token.TokenType {
+			case Native:
+				s.native = true
+			case Erc20:
+				s.tokens = append(s.tokens, token)
+			default:
+				continue
+			}
+			if deps.tokenSymbol != nil {
+				addSymbol(deps.tokenSymbol(token))
+			}
+		}
+	}
+
+	return s
+}
+
+func escapeLikePattern(text string) string {
+	replacer := strings.NewReplacer(searchEscapeChar, searchEscapeChar+searchEscapeChar, "%", searchEscapeChar+"%", "_", searchEscapeChar+"_")
+	return replacer.Replace(text)
+}
+
+// quoteSQLString returns the text as a SQL string literal, used for the values of the temporary tables
+func quoteSQLString(text string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(text, "'", "''"))
+}
+
+func (s *searchTerms) matchesAddress(address eth.Address) bool {
+	return sliceChecksCondition(s.addresses, func(a *eth.Address) bool { return *a == address })
+}
+
+func (s *searchTerms) matchesSymbol(symbol string) bool {
+	return sliceContains(s.symbols, strings.ToUpper(symbol))
+}
+
+// sqlValues returns the VALUES of the search temporary tables
+func (s *searchTerms) sqlValues() (addresses string, tokens string, symbols string) {
+	addresses = noEntriesInTmpTableSQLValues
+	tokens = noEntriesInTwoColumnsTmpTableSQLValues
+	symbols = noEntriesInTmpTableSQLValues
+	if s == nil {
+		return
+	}
+
+	if len(s.addresses) > 0 {
+		addresses = joinAddresses(s.addresses)
+	}
+	if len(s.tokens) > 0 {
+		tokens = joinItems(s.tokens, func(item Token) string {
+			return fmt.Sprintf("%d, X'%s'", item.ChainID, item.Address.Hex()[2:])
+		})
+	}
+	if len(s.symbols) > 0 {
+		symbols = joinItems(s.symbols, quoteSQLString)
+	}
+	return
+}
@@ -55,6 +55,14 @@ var (
 	}
 )
 
+// NameResolver resolves the names matching the search text of the filter
+type NameResolver interface {
+	// AddressesFromName returns the addresses of the ENS names, saved addresses and contacts matching the text
+	AddressesFromName(text string) ([]common.Address, error)
+	// TokensFromName returns the tokens with a symbol or name matching the text
+	TokensFromName(text string) ([]*token.Token, error)
+}
+
 // Service provides an async interface, ensuring only one filter request, of each type, is running at a time. It also provides lazy load of NFT info and token mapping
 type Service struct {
 	db           *sql.DB
@@ -74,13 +82,15 @@ type Service struct {
 	debounceDuration time.Duration
 
 	pendingTracker *transactions.PendingTxTracker
+	exchange       ExchangeRates // optional, fiat values are not exported without it
+	resolver       NameResolver  // optional, the search text is only matched against notes and collectible names without it
 }
 
 func (s *Service) nextSessionID() SessionID {
 	return SessionID(s.lastSessionID.Add(1))
 }
 
-func NewService(db *sql.DB, accountsDB *accounts.Database, tokenManager token.ManagerInterface, collectibles collectibles.ManagerInterface, eventFeed *event.Feed, pendingTracker *transactions.PendingTxTracker) *Service {
+func NewService(db *sql.DB, accountsDB *accounts.Database, tokenManager token.ManagerInterface, collectibles collectibles.ManagerInterface, eventFeed *event.Feed, pendingTracker *transactions.PendingTxTracker, exchange ExchangeRates, resolver NameResolver) *Service {
 	return &Service{
 		db:           db,
 		accountsDB:   accountsDB,
@@ -94,6 +104,8 @@ func NewService(db *sql.DB, accountsDB *accounts.Database, tokenManager token.Ma
 		debounceDuration: 1 * time.Second,
 
 		pendingTracker: pendingTracker,
+		exchange:       exchange,
+		resolver:       resolver,
 	}
 }
 
@@ -391,6 +403,38 @@ func (s *Service) getDeps() FilterDependencies {
 		currentTimestamp: func() int64 {
 			return time.Now().Unix()
 		},
+		addressesFromName: func(text string) []common.Address {
+			if s.resolver == nil {
+				return nil
+			}
+			addresses, err := s.resolver.AddressesFromName(text)
+			if err != nil {
+				log.Warn("failed to resolve addresses for activity search", "err", err)
+			}
+			return addresses
+		},
+		tokensFromName: func(text string) []Token {
+			if s.resolver == nil {
+				return nil
+			}
+			tokens, err := s.resolver.TokensFromName(text)
+			if err != nil {
+				log.Warn("failed to resolve tokens for activity search", "err", err)
+			}
+			res := make([]Token, 0, len(tokens))
+			for _, t := range tokens {
+				tokenType := Erc20
+				if t.IsNative() {
+					tokenType = Native
+				}
+				res = append(res, Token{
+					TokenType: tokenType,
+					ChainID:   w_common.ChainID(t.ChainID),
+					Address:   t.Address,
+				})
+			}
+			return res
+		},
 	}
 }
 
@@ -12,6 +12,7 @@ import (
 	gocommon "github.com/status-im/status-go/common"
 	"github.com/status-im/status-go/services/wallet/async"
 	"github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/scheduledtransfer"
 	"github.com/status-im/status-go/services/wallet/transfer"
 	"github.com/status-im/status-go/services/wallet/walletevent"
 	"github.com/status-im/status-go/transactions"
@@ -23,6 +24,7 @@ type EntryIdentity struct {
 	payloadType PayloadType
 	transaction *transfer.TransactionIdentity
 	id          common.MultiTransactionIDType
+	scheduledID int64
 }
 
 func (e EntryIdentity) same(a EntryIdentity) bool {
@@ -31,7 +33,8 @@ func (e EntryIdentity) same(a EntryIdentity) bool {
 			(a.transaction.ChainID == e.transaction.ChainID &&
 				a.transaction.Hash == e.transaction.Hash &&
 				a.transaction.Address == e.transaction.Address)) &&
-		a.id == e.id
+		a.id == e.id &&
+		a.scheduledID == e.scheduledID
 }
 
 func (e EntryIdentity) key() string {
@@ -39,7 +42,7 @@ func (e EntryIdentity) key() string {
 	if e.transaction != nil {
 		txID = strconv.FormatUint(uint64(e.transaction.ChainID), 10) + e.transaction.Hash.Hex() + e.transaction.Address.Hex()
 	}
-	return strconv.Itoa(e.payloadType) + txID + strconv.FormatInt(int64(e.id), 16)
+	return strconv.Itoa(e.payloadType) + txID + strconv.FormatInt(int64(e.id), 16) + "-" + strconv.FormatInt(e.scheduledID, 16)
 }
 
 type SessionID int32
@@ -119,11 +122,7 @@ func (s *Service) internalFilter(f fullFilterParams, offset int, count int, proc
 func mirrorIdentities(entries []Entry) []EntryIdentity {
 	model := make([]EntryIdentity, 0, len(entries))
 	for _, a := range entries {
-		model = append(model, EntryIdentity{
-			payloadType: a.payloadType,
-			transaction: a.transaction,
-			id:          a.id,
-		})
+		model = append(model, a.getIdentity())
 	}
 	return model
 }
@@ -311,11 +310,7 @@ func (s *Service) GetMoreForFilterSession(id SessionID, pageCount int) error {
 
 			// Mirror client identities for checking updates
 			for _, a := range entries {
-				session.model = append(session.model, EntryIdentity{
-					payloadType: a.payloadType,
-					transaction: a.transaction,
-					id:          a.id,
-				})
+				session.model = append(session.model, a.getIdentity())
 			}
 
 			// Overwrite the offset to account for new entries
@@ -340,7 +335,9 @@ func (s *Service) processEvents() {
 	for event := range s.ch {
 		if event.Type == transactions.EventPendingTransactionUpdate ||
 			event.Type == transactions.EventPendingTransactionStatusChanged ||
-			event.Type == transfer.EventNewTransfers {
+			event.Type == transfer.EventNewTransfers ||
+			event.Type == scheduledtransfer.EventScheduledTransferDue ||
+			event.Type == scheduledtransfer.EventScheduledTransferExecuted {
 			eventCount++
 		}
 		// debounce events updates
@@ -400,7 +397,7 @@ func (s *Service) detectNew(changeCount int) {
 					Entry: &entry,
 				})
 				// Insert in session model at modelPos index
-				session.model = append(session.model[:modelPos], append([]EntryIdentity{{payloadType: entry.payloadType, transaction: entry.transaction, id: entry.id}}, session.model[modelPos:]...)...)
+				session.model = append(session.model[:modelPos], append([]EntryIdentity{entry.getIdentity()}, session.model[modelPos:]...)...)
 			}
 		}
 
@@ -0,0 +1,95 @@
+package wallet
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/token"
+)
+
+// The wallet addresses of the contacts are the accounts they share in their profile showcase
+const contactsAddressesByNameQuery = `
+	SELECT DISTINCT
+		psa.address
+	FROM
+		contacts c
+	JOIN
+		profile_showcase_accounts_contacts psa
+	ON
+		c.id = psa.contact_id
+	LEFT JOIN
+		ens_verification_records v
+	ON
+		c.id = v.public_key
+	WHERE
+		c.removed = 0
+		AND (
+			c.display_name LIKE ? ESCAPE '\'
+			OR c.local_nickname LIKE ? ESCAPE '\'
+			OR (v.verified AND v.name LIKE ? ESCAPE '\')
+			OR psa.name LIKE ? ESCAPE '\'
+		)
+`
+
+// activityNameResolver resolves the names used to search the activity
+type activityNameResolver struct {
+	appDB                 *sql.DB
+	savedAddressesManager *SavedAddressesManager
+	tokenManager          *token.Manager
+}
+
+func (r *activityNameResolver) AddressesFromName(text string) ([]common.Address, error) {
+	lowerText := strings.ToLower(text)
+
+	var addresses []common.Address
+	savedAddresses, err := r.savedAddressesManager.GetSavedAddresses()
+	if err != nil {
+		return nil, err
+	}
+	for _, sa := range savedAddresses {
+		if strings.Contains(strings.ToLower(sa.Name), lowerText) || strings.Contains(strings.ToLower(sa.ENSName), lowerText) {
+			addresses = append(addresses, sa.Address)
+		}
+	}
+
+	if r.appDB == nil {
+		return addresses, nil
+	}
+
+	pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text) + "%"
+	rows, err := r.appDB.Query(contactsAddressesByNameQuery, pattern, pattern, pattern, pattern)
+	if err != nil {
+		return addresses, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var address string
+		if err := rows.Scan(&address); err != nil {
+			return addresses, err
+		}
+		if common.IsHexAddress(address) {
+			addresses = append(addresses, common.HexToAddress(address))
+		}
+	}
+	return addresses, rows.Err()
+}
+
+func (r *activityNameResolver) TokensFromName(text string) ([]*token.Token, error) {
+	lowerText := strings.ToLower(text)
+
+	tokens, err := r.tokenManager.GetAllTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*token.Token, 0)
+	for _, t := range tokens {
+		if strings.Contains(strings.ToLower(t.Symbol), lowerText) || strings.Contains(strings.ToLower(t.Name), lowerText) {
+			res = append(res, t)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,310 @@
+package allowance
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/contracts/ierc20"
+	ethTypes "github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/rpc/chain"
+	"github.com/status-im/status-go/services/wallet/bigint"
+	wcommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/transactions"
+)
+
+const (
+	// Approval (index_topic_1 address owner, index_topic_2 address spender, uint256 value)
+	approvalEventSignature = "Approval(address,address,uint256)"
+	// ERC721 approvals have the token id as the third indexed topic, they're not allowances
+	erc20ApprovalEventIndexedParameters = 3
+
+	defaultScanBlockRange = 500000
+	minScanBlockRange     = 1000
+)
+
+// errors of the providers limiting the number of logs returned by eth_getLogs
+var tooManyResultsErrors = []string{
+	"query returned more than",
+	"log response size exceeded",
+	"query exceeds max results",
+	"too many logs",
+}
+
+// Allowance is an amount of an ERC20 token a spender is allowed to transfer from the owner account
+type Allowance struct {
+	ChainID     wcommon.ChainID `json:"chainId"`
+	Owner       common.Address  `json:"owner"`
+	Token       common.Address  `json:"token"`
+	Spender     common.Address  `json:"spender"`
+	Amount      *bigint.BigInt  `json:"amount"`
+	Unlimited   bool            `json:"unlimited"`
+	BlockNumber uint64          `json:"blockNumber"` // block of the last Approval event
+	UpdatedAt   int64           `json:"updatedAt"`
+}
+
+type Filter struct {
+	ChainIDs       []wcommon.ChainID `json:"chainIds"`
+	Owners         []common.Address  `json:"owners"`
+	Tokens         []common.Address  `json:"tokens"`
+	Spenders       []common.Address  `json:"spenders"`
+	UnlimitedOnly  bool              `json:"unlimitedOnly"`
+	IncludeRevoked bool              `json:"includeRevoked"`
+}
+
+// isUnlimited returns true for allowances which are practically unlimited, some tokens decrease even the max allowance on
+// transfers so anything above half of the max uint256 is considered unlimited
+func isUnlimited(amount *big.Int) bool {
+	return amount.Cmp(new(big.Int).Rsh(wcommon.MaxUint256, 1)) > 0
+}
+
+// Manager keeps track of the allowances given by the wallet accounts
+type Manager struct {
+	persistence *Persistence
+	rpcClient   rpc.ClientInterface
+	transactor  transactions.TransactorIface
+}
+
+func NewManager(db *sql.DB, rpcClient rpc.ClientInterface, transactor transactions.TransactorIface) *Manager {
+	return &Manager{
+		persistence: NewPersistence(db),
+		rpcClient:   rpcClient,
+		transactor:  transactor,
+	}
+}
+
+func (m *Manager) GetAllowances(filter Filter) ([]*Allowance, error) {
+	return m.persistence.GetAllowances(filter)
+}
+
+// FetchAllowances scans the Approval logs of the owners since the last scan and refreshes the current allowance of each known
+// (token, spender) pair, since allowances spent by `transferFrom` don't always emit an Approval event
+func (m *Manager) FetchAllowances(ctx context.Context, chainIDs []wcommon.ChainID, owners []common.Address) error {
+	for _, chainID := range chainIDs {
+		client, err := m.rpcClient.EthClient(uint64(chainID))
+		if err != nil {
+			return err
+		}
+
+		for _, owner := range owners {
+			err = m.fetchAllowances(ctx, client, chainID, owner)
+			if err != nil {
+				log.Error("failed to fetch token allowances", "chainID", chainID, "owner", owner, "err", err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Manager) fetchAllowances(ctx context.Context, client chain.ClientInterface, chainID wcommon.ChainID, owner common.Address) error {
+	lastBlock, err := client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	fromBlock, err := m.scanStartBlock(chainID, owner)
+	if err != nil {
+		return err
+	}
+
+	known, err := m.persistence.GetAllowances(Filter{
+		ChainIDs:       []wcommon.ChainID{chainID},
+		Owners:         []common.Address{owner},
+		IncludeRevoked: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	allowances := make(map[[2]common.Address]*Allowance)
+	for _, a := range known {
+		allowances[[2]common.Address{a.Token, a.Spender}] = a
+	}
+
+	logs, err := approvalLogs(ctx, client, owner, fromBlock, lastBlock)
+	if err != nil {
+		return err
+	}
+	for _, a := range allowancesFromLogs(chainID, logs) {
+		allowances[[2]common.Address{a.Token, a.Spender}] = a
+	}
+
+	updated := make([]*Allowance, 0, len(allowances))
+	for _, a := range allowances {
+		amount, err := m.currentAllowance(ctx, client, a)
+		if err != nil {
+			log.Warn("failed to get current allowance", "chainID", chainID, "token", a.Token, "spender", a.Spender, "err", err)
+			amount = a.Amount.Int
+		}
+		a.Amount = &bigint.BigInt{Int: amount}
+		a.Unlimited = isUnlimited(amount)
+		a.UpdatedAt = time.Now().Unix()
+		updated = append(updated, a)
+	}
+
+	err = m.persistence.UpsertAllowances(updated)
+	if err != nil {
+		return err
+	}
+
+	return m.persistence.SetLastScannedBlock(chainID, owner, lastBlock)
+}
+
+// scanStartBlock returns the block the Approval logs of the owner are scanned from, the account can't have approved
+// anything before its first transaction so the scan starts there, or from genesis while that block isn't known
+func (m *Manager) scanStartBlock(chainID wcommon.ChainID, owner common.Address) (uint64, error) {
+	lastScannedBlock, scanned, err := m.persistence.GetLastScannedBlock(chainID, owner)
+	if err != nil {
+		return 0, err
+	}
+	if scanned {
+		return lastScannedBlock + 1, nil
+	}
+
+	firstSeenBlock, found, err := m.persistence.GetFirstSeenBlock(chainID, owner)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		log.Debug("first block of the account not known, scanning allowances from genesis", "chainID", chainID, "owner", owner)
+		return 0, nil
+	}
+	return firstSeenBlock, nil
+}
+
+type logFilterer interface {
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// approvalLogs returns the Approval logs of the owner between the blocks, the block range of the requests is halved
+// while the provider refuses to return that many logs
+func approvalLogs(ctx context.Context, client logFilterer, owner common.Address, fromBlock uint64, lastBlock uint64) ([]types.Log, error) {
+	logs := make([]types.Log, 0)
+	blockRange := uint64(defaultScanBlockRange)
+	for fromBlock <= lastBlock {
+		toBlock := fromBlock + blockRange - 1
+		if toBlock > lastBlock {
+			toBlock = lastBlock
+		}
+
+		rangeLogs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(fromBlock),
+			ToBlock:   new(big.Int).SetUint64(toBlock),
+			Topics: [][]common.Hash{
+				{wcommon.GetEventSignatureHash(approvalEventSignature)},
+				{common.BytesToHash(owner.Bytes())},
+			},
+		})
+		if err != nil {
+			if isTooManyResultsError(err) && blockRange > minScanBlockRange {
+				blockRange /= 2
+				continue
+			}
+			return nil, err
+		}
+
+		logs = append(logs, rangeLogs...)
+		fromBlock = toBlock + 1
+	}
+	return logs, nil
+}
+
+func isTooManyResultsError(err error) bool {
+	for _, message := range tooManyResultsErrors {
+		if strings.Contains(err.Error(), message) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Manager) currentAllowance(ctx context.Context, client chain.ClientInterface, a *Allowance) (*big.Int, error) {
+	caller, err := ierc20.NewIERC20Caller(a.Token, client)
+	if err != nil {
+		return nil, err
+	}
+	return caller.Allowance(&bind.CallOpts{Context: ctx}, a.Owner, a.Spender)
+}
+
+// allowancesFromLogs returns the last allowance set for each (token, spender) pair by the ERC20 Approval logs
+func allowancesFromLogs(chainID wcommon.ChainID, logs []types.Log) []*Allowance {
+	approvalHash := wcommon.GetEventSignatureHash(approvalEventSignature)
+	addressIdx := common.HashLength - common.AddressLength
+
+	result := make([]*Allowance, 0)
+	byPair := make(map[[2]common.Address]*Allowance)
+	for _, l := range logs {
+		if l.Removed || len(l.Topics) != erc20ApprovalEventIndexedParameters || l.Topics[0] != approvalHash || len(l.Data) != common.HashLength {
+			continue
+		}
+
+		a := &Allowance{
+			ChainID:     chainID,
+			Owner:       common.BytesToAddress(l.Topics[1][addressIdx:]),
+			Token:       l.Address,
+			Spender:     common.BytesToAddress(l.Topics[2][addressIdx:]),
+			Amount:      &bigint.BigInt{Int: new(big.Int).SetBytes(l.Data)},
+			BlockNumber: l.BlockNumber,
+		}
+		a.Unlimited = isUnlimited(a.Amount.Int)
+
+		key := [2]common.Address{a.Token, a.Spender}
+		if existing, ok := byPair[key]; ok {
+			if existing.BlockNumber <= a.BlockNumber {
+				*existing = *a
+			}
+			continue
+		}
+		byPair[key] = a
+		result = append(result, a)
+	}
+	return result
+}
+
+// RevokeTxArgs returns the args of the `approve(spender, 0)` transaction revoking the allowance
+func (m *Manager) RevokeTxArgs(owner common.Address, token common.Address, spender common.Address) (*transactions.SendTxArgs, error) {
+	erc20ABI, err := abi.JSON(strings.NewReader(ierc20.IERC20ABI))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := erc20ABI.Pack("approve", spender, big.NewInt(0))
+	if err != nil {
+		return nil, err
+	}
+
+	to := ethTypes.Address(token)
+	input := ethTypes.HexBytes(data)
+	return &transactions.SendTxArgs{
+		From:  ethTypes.Address(owner),
+		To:    &to,
+		Value: (*hexutil.Big)(big.NewInt(0)),
+		Data:  input,
+	}, nil
+}
+
+// Revoke sends the transaction setting the allowance of the spender to 0, the stored allowance is updated once the
+// Approval log is found by the next fetch
+func (m *Manager) Revoke(chainID wcommon.ChainID, owner common.Address, token common.Address, spender common.Address,
+	verifiedAccount *account.SelectedExtKey) (ethTypes.Hash, error) {
+	sendArgs, err := m.RevokeTxArgs(owner, token, spender)
+	if err != nil {
+		return ethTypes.Hash{}, err
+	}
+
+	hash, _, err := m.transactor.SendTransactionWithChainID(uint64(chainID), *sendArgs, -1, verifiedAccount)
+	return hash, err
+}
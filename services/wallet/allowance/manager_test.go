@@ -0,0 +1,132 @@
+package allowance
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	wcommon "github.com/status-im/status-go/services/wallet/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testApprovalLog(spender common.Address, amount *big.Int, blockNumber uint64) types.Log {
+	return types.Log{
+		Address:     testToken,
+		Topics:      []common.Hash{wcommon.GetEventSignatureHash(approvalEventSignature), common.BytesToHash(testOwner.Bytes()), common.BytesToHash(spender.Bytes())},
+		Data:        common.BigToHash(amount).Bytes(),
+		BlockNumber: blockNumber,
+	}
+}
+
+// testLogFilterer returns the logs in the requested range, or the error when the range is larger than maxRange
+type testLogFilterer struct {
+	logs     []types.Log
+	maxRange uint64
+	err      error
+	queries  [][2]uint64
+}
+
+func (f *testLogFilterer) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	f.queries = append(f.queries, [2]uint64{from, to})
+	if f.maxRange > 0 && to-from+1 > f.maxRange {
+		return nil, f.err
+	}
+
+	logs := make([]types.Log, 0)
+	for _, l := range f.logs {
+		if l.BlockNumber >= from && l.BlockNumber <= to {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+func TestAllowancesFromLogs(t *testing.T) {
+	erc721Approval := testApprovalLog(testSpender2, big.NewInt(1), 5)
+	erc721Approval.Topics = append(erc721Approval.Topics, common.BigToHash(big.NewInt(1)))
+	erc721Approval.Data = nil
+
+	removed := testApprovalLog(testSpender1, big.NewInt(70), 30)
+	removed.Removed = true
+
+	invalidData := testApprovalLog(testSpender2, big.NewInt(1), 40)
+	invalidData.Data = invalidData.Data[1:]
+
+	otherToken := testApprovalLog(testSpender1, wcommon.MaxUint256, 15)
+	otherToken.Address = common.HexToAddress("0x5")
+
+	allowances := allowancesFromLogs(wcommon.ChainID(1), []types.Log{
+		testApprovalLog(testSpender1, big.NewInt(50), 20),
+		erc721Approval,
+		testApprovalLog(testSpender1, wcommon.MaxUint256, 10),
+		removed,
+		invalidData,
+		otherToken,
+	})
+	require.Len(t, allowances, 2)
+
+	// the last approval of the pair is kept whatever the order of the logs
+	require.Equal(t, testOwner, allowances[0].Owner)
+	require.Equal(t, testToken, allowances[0].Token)
+	require.Equal(t, testSpender1, allowances[0].Spender)
+	require.Equal(t, big.NewInt(50), allowances[0].Amount.Int)
+	require.Equal(t, uint64(20), allowances[0].BlockNumber)
+	require.False(t, allowances[0].Unlimited)
+
+	require.Equal(t, otherToken.Address, allowances[1].Token)
+	require.True(t, allowances[1].Unlimited)
+}
+
+func TestApprovalLogsRetriesSmallerRanges(t *testing.T) {
+	filterer := &testLogFilterer{
+		logs: []types.Log{
+			testApprovalLog(testSpender1, big.NewInt(1), 100),
+			testApprovalLog(testSpender1, big.NewInt(2), 300_000),
+			testApprovalLog(testSpender2, big.NewInt(3), 900_000),
+		},
+		maxRange: defaultScanBlockRange / 4,
+		err:      errors.New("query returned more than 10000 results"),
+	}
+
+	logs, err := approvalLogs(context.Background(), filterer, testOwner, 100, 1_000_000)
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+
+	require.Equal(t, [2]uint64{100, defaultScanBlockRange + 99}, filterer.queries[0])
+	require.Equal(t, [2]uint64{100, defaultScanBlockRange/2 + 99}, filterer.queries[1])
+	require.Equal(t, [2]uint64{100, defaultScanBlockRange/4 + 99}, filterer.queries[2])
+	// the range stays small once halved
+	for _, q := range filterer.queries[2:] {
+		require.LessOrEqual(t, q[1]-q[0]+1, uint64(defaultScanBlockRange/4))
+	}
+	require.Equal(t, uint64(1_000_000), filterer.queries[len(filterer.queries)-1][1])
+}
+
+func TestApprovalLogsFailsOnOtherErrors(t *testing.T) {
+	rpcErr := errors.New("connection refused")
+	filterer := &testLogFilterer{
+		maxRange: defaultScanBlockRange / 4,
+		err:      rpcErr,
+	}
+
+	_, err := approvalLogs(context.Background(), filterer, testOwner, 0, 1_000_000)
+	require.ErrorIs(t, err, rpcErr)
+	require.Len(t, filterer.queries, 1)
+
+	// the range is not halved anymore once below the minimum
+	filterer.maxRange = minScanBlockRange / 2
+	filterer.err = errors.New("query returned more than 10000 results")
+	filterer.queries = nil
+	_, err = approvalLogs(context.Background(), filterer, testOwner, 0, 1_000_000)
+	require.ErrorIs(t, err, filterer.err)
+	last := filterer.queries[len(filterer.queries)-1]
+	require.Less(t, last[1]-last[0]+1, uint64(minScanBlockRange))
+	require.Greater(t, last[1]-last[0]+1, filterer.maxRange)
+}
@@ -0,0 +1,165 @@
+package allowance
+
+import (
+	"database/sql"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+	wcommon "github.com/status-im/status-go/services/wallet/common"
+)
+
+type Persistence struct {
+	db *sql.DB
+}
+
+func NewPersistence(db *sql.DB) *Persistence {
+	return &Persistence{
+		db: db,
+	}
+}
+
+const allowanceColumns = "chain_id, owner, token, spender, amount, unlimited, block_number, updated_at"
+
+func (p *Persistence) UpsertAllowances(allowances []*Allowance) (err error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	insert, err := tx.Prepare(fmt.Sprintf(`INSERT OR REPLACE INTO token_allowances (%s) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, allowanceColumns))
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	for _, a := range allowances {
+		updatedAt := a.UpdatedAt
+		if updatedAt == 0 {
+			updatedAt = time.Now().Unix()
+		}
+		_, err = insert.Exec(a.ChainID, a.Owner, a.Token, a.Spender, (*bigint.SQLBigIntBytes)(a.Amount.Int), a.Unlimited, a.BlockNumber, updatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addressesArgs(addresses []common.Address) (string, []interface{}) {
+	placeholders := make([]string, 0, len(addresses))
+	args := make([]interface{}, 0, len(addresses))
+	for _, address := range addresses {
+		placeholders = append(placeholders, "?")
+		args = append(args, address)
+	}
+	return strings.Join(placeholders, ","), args
+}
+
+func (p *Persistence) GetAllowances(filter Filter) ([]*Allowance, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+
+	if len(filter.ChainIDs) > 0 {
+		placeholders := make([]string, 0, len(filter.ChainIDs))
+		for _, chainID := range filter.ChainIDs {
+			placeholders = append(placeholders, "?")
+			args = append(args, chainID)
+		}
+		conditions = append(conditions, fmt.Sprintf("chain_id IN (%s)", strings.Join(placeholders, ",")))
+	}
+
+	for column, addresses := range map[string][]common.Address{
+		"owner":   filter.Owners,
+		"token":   filter.Tokens,
+		"spender": filter.Spenders,
+	} {
+		if len(addresses) == 0 {
+			continue
+		}
+		placeholders, addressArgs := addressesArgs(addresses)
+		conditions = append(conditions, fmt.Sprintf("%s IN (%s)", column, placeholders))
+		args = append(args, addressArgs...)
+	}
+
+	if filter.UnlimitedOnly {
+		conditions = append(conditions, "unlimited = 1")
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM token_allowances", allowanceColumns)
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY chain_id, block_number DESC"
+
+	rows, err := p.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	allowances := make([]*Allowance, 0)
+	for rows.Next() {
+		a := &Allowance{
+			Amount: &bigint.BigInt{Int: new(big.Int)},
+		}
+		err = rows.Scan(&a.ChainID, &a.Owner, &a.Token, &a.Spender, (*bigint.SQLBigIntBytes)(a.Amount.Int), &a.Unlimited, &a.BlockNumber, &a.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if !filter.IncludeRevoked && a.Amount.Sign() == 0 {
+			continue
+		}
+		allowances = append(allowances, a)
+	}
+
+	return allowances, rows.Err()
+}
+
+func (p *Persistence) GetLastScannedBlock(chainID wcommon.ChainID, owner common.Address) (uint64, bool, error) {
+	var lastBlock uint64
+	err := p.db.QueryRow(`SELECT last_block FROM token_allowances_scan WHERE chain_id = ? AND owner = ?`, chainID, owner).Scan(&lastBlock)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return lastBlock, true, nil
+}
+
+func (p *Persistence) SetLastScannedBlock(chainID wcommon.ChainID, owner common.Address, lastBlock uint64) error {
+	_, err := p.db.Exec(`INSERT OR REPLACE INTO token_allowances_scan (chain_id, owner, last_block) VALUES (?, ?, ?)`, chainID, owner, lastBlock)
+	return err
+}
+
+// GetFirstSeenBlock returns the block of the first transaction of the account, known once the transfers history of the
+// account was scanned
+func (p *Persistence) GetFirstSeenBlock(chainID wcommon.ChainID, owner common.Address) (uint64, bool, error) {
+	var firstBlock sql.NullInt64
+	err := p.db.QueryRow(`SELECT blk_start FROM blocks_ranges_sequential WHERE network_id = ? AND address = ?`, chainID, owner).Scan(&firstBlock)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if !firstBlock.Valid {
+		return 0, false, nil
+	}
+	return uint64(firstBlock.Int64), true, nil
+}
@@ -0,0 +1,135 @@
+package allowance
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+	wcommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupAllowancePersistenceTest(t *testing.T) (*Persistence, func()) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	return NewPersistence(db), func() {
+		require.NoError(t, db.Close())
+	}
+}
+
+var (
+	testOwner    = common.HexToAddress("0x1")
+	testToken    = common.HexToAddress("0x2")
+	testSpender1 = common.HexToAddress("0x3")
+	testSpender2 = common.HexToAddress("0x4")
+)
+
+func TestAllowancesPersistence(t *testing.T) {
+	p, cleanup := setupAllowancePersistenceTest(t)
+	defer cleanup()
+
+	err := p.UpsertAllowances([]*Allowance{
+		{ChainID: wcommon.ChainID(1), Owner: testOwner, Token: testToken, Spender: testSpender1, Amount: &bigint.BigInt{Int: wcommon.MaxUint256}, Unlimited: true, BlockNumber: 10},
+		{ChainID: wcommon.ChainID(1), Owner: testOwner, Token: testToken, Spender: testSpender2, Amount: &bigint.BigInt{Int: big.NewInt(100)}, BlockNumber: 20},
+		{ChainID: wcommon.ChainID(10), Owner: testOwner, Token: testToken, Spender: testSpender1, Amount: &bigint.BigInt{Int: big.NewInt(0)}, BlockNumber: 30},
+	})
+	require.NoError(t, err)
+
+	allowances, err := p.GetAllowances(Filter{})
+	require.NoError(t, err)
+	require.Len(t, allowances, 2)
+	require.Equal(t, testSpender2, allowances[0].Spender)
+	require.Equal(t, big.NewInt(100), allowances[0].Amount.Int)
+	require.Equal(t, testSpender1, allowances[1].Spender)
+	require.Equal(t, wcommon.MaxUint256, allowances[1].Amount.Int)
+
+	allowances, err = p.GetAllowances(Filter{UnlimitedOnly: true})
+	require.NoError(t, err)
+	require.Len(t, allowances, 1)
+	require.True(t, allowances[0].Unlimited)
+
+	allowances, err = p.GetAllowances(Filter{ChainIDs: []wcommon.ChainID{10}, IncludeRevoked: true})
+	require.NoError(t, err)
+	require.Len(t, allowances, 1)
+	require.Equal(t, int64(0), allowances[0].Amount.Int64())
+
+	allowances, err = p.GetAllowances(Filter{Spenders: []common.Address{testSpender2}, Owners: []common.Address{testOwner}})
+	require.NoError(t, err)
+	require.Len(t, allowances, 1)
+
+	// revoking replaces the allowance
+	err = p.UpsertAllowances([]*Allowance{
+		{ChainID: wcommon.ChainID(1), Owner: testOwner, Token: testToken, Spender: testSpender1, Amount: &bigint.BigInt{Int: big.NewInt(0)}, BlockNumber: 40},
+	})
+	require.NoError(t, err)
+	allowances, err = p.GetAllowances(Filter{UnlimitedOnly: true})
+	require.NoError(t, err)
+	require.Empty(t, allowances)
+}
+
+func TestLastScannedBlock(t *testing.T) {
+	p, cleanup := setupAllowancePersistenceTest(t)
+	defer cleanup()
+
+	_, scanned, err := p.GetLastScannedBlock(wcommon.ChainID(1), testOwner)
+	require.NoError(t, err)
+	require.False(t, scanned)
+
+	require.NoError(t, p.SetLastScannedBlock(wcommon.ChainID(1), testOwner, 100))
+	require.NoError(t, p.SetLastScannedBlock(wcommon.ChainID(1), testOwner, 200))
+
+	lastBlock, scanned, err := p.GetLastScannedBlock(wcommon.ChainID(1), testOwner)
+	require.NoError(t, err)
+	require.True(t, scanned)
+	require.Equal(t, uint64(200), lastBlock)
+}
+
+func TestFirstSeenBlock(t *testing.T) {
+	p, cleanup := setupAllowancePersistenceTest(t)
+	defer cleanup()
+
+	_, found, err := p.GetFirstSeenBlock(wcommon.ChainID(1), testOwner)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// the first block is not known while the history is scanned
+	_, err = p.db.Exec(`INSERT INTO blocks_ranges_sequential (network_id, address, blk_first, blk_last) VALUES (?, ?, ?, ?)`, 1, testOwner, 500, 1000)
+	require.NoError(t, err)
+	_, found, err = p.GetFirstSeenBlock(wcommon.ChainID(1), testOwner)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	_, err = p.db.Exec(`UPDATE blocks_ranges_sequential SET blk_start = ? WHERE network_id = ? AND address = ?`, 120, 1, testOwner)
+	require.NoError(t, err)
+	firstBlock, found, err := p.GetFirstSeenBlock(wcommon.ChainID(1), testOwner)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, uint64(120), firstBlock)
+}
+
+func TestScanStartBlock(t *testing.T) {
+	p, cleanup := setupAllowancePersistenceTest(t)
+	defer cleanup()
+	m := &Manager{persistence: p}
+
+	// the whole chain is scanned while the first block of the account isn't known
+	fromBlock, err := m.scanStartBlock(wcommon.ChainID(1), testOwner)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), fromBlock)
+
+	_, err = p.db.Exec(`INSERT INTO blocks_ranges_sequential (network_id, address, blk_start, blk_first, blk_last) VALUES (?, ?, ?, ?, ?)`, 1, testOwner, 120, 500, 1000)
+	require.NoError(t, err)
+	fromBlock, err = m.scanStartBlock(wcommon.ChainID(1), testOwner)
+	require.NoError(t, err)
+	require.Equal(t, uint64(120), fromBlock)
+
+	require.NoError(t, p.SetLastScannedBlock(wcommon.ChainID(1), testOwner, 200))
+	fromBlock, err = m.scanStartBlock(wcommon.ChainID(1), testOwner)
+	require.NoError(t, err)
+	require.Equal(t, uint64(201), fromBlock)
+}
@@ -16,27 +16,38 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	signercore "github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/google/uuid"
 	abi_spec "github.com/status-im/status-go/abi-spec"
 	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/account/signer"
 	status_common "github.com/status-im/status-go/common"
 	statusErrors "github.com/status-im/status-go/errors"
 	"github.com/status-im/status-go/eth-node/crypto"
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/params"
 	"github.com/status-im/status-go/rpc/network"
-	"github.com/status-im/status-go/services/typeddata"
 	"github.com/status-im/status-go/services/wallet/activity"
+	"github.com/status-im/status-go/services/wallet/allowance"
 	"github.com/status-im/status-go/services/wallet/collectibles"
 	wcommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/currency"
+	"github.com/status-im/status-go/services/wallet/feemonitor"
 	"github.com/status-im/status-go/services/wallet/history"
+	"github.com/status-im/status-go/services/wallet/market"
+	"github.com/status-im/status-go/services/wallet/nativebridge"
 	"github.com/status-im/status-go/services/wallet/onramp"
+	"github.com/status-im/status-go/services/wallet/poisoning"
+	"github.com/status-im/status-go/services/wallet/portfolio"
 	"github.com/status-im/status-go/services/wallet/requests"
 	"github.com/status-im/status-go/services/wallet/responses"
 	"github.com/status-im/status-go/services/wallet/router"
 	"github.com/status-im/status-go/services/wallet/router/fees"
 	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
 	"github.com/status-im/status-go/services/wallet/router/sendtype"
+	"github.com/status-im/status-go/services/wallet/safe"
+	"github.com/status-im/status-go/services/wallet/scheduledtransfer"
+	"github.com/status-im/status-go/services/wallet/siwe"
+	"github.com/status-im/status-go/services/wallet/spam"
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 	"github.com/status-im/status-go/services/wallet/token"
 	"github.com/status-im/status-go/services/wallet/transfer"
@@ -46,6 +57,17 @@ import (
 )
 
 func NewAPI(s *Service) *API {
+	router := newRouter(s)
+	if smartAccount, ok := router.GetPathProcessors()[pathprocessor.ProcessorSmartAccountTransferName].(*pathprocessor.SmartAccountProcessor); ok {
+		s.pendingTxManager.SetUserOperationStatusFetcher(smartAccount)
+	}
+
+	// scheduled transfers calculate their routes with a router of their own, not to discard the route of the user
+	return &API{s, s.reader, router, newRouter(s)}
+}
+
+// newRouter creates a router with all the path processors enabled for the service
+func newRouter(s *Service) *router.Router {
 	rpcClient := s.GetRPCClient()
 	transactor := s.GetTransactor()
 	tokenManager := s.GetTokenManager()
@@ -55,10 +77,14 @@ func NewAPI(s *Service) *API {
 
 	router := router.NewRouter(rpcClient, transactor, tokenManager, s.GetMarketManager(), s.GetCollectiblesService(),
 		s.GetCollectiblesManager(), ensService, stickersService)
+	router.SetPoisoningDetector(s.poisoningDetector)
 
 	transfer := pathprocessor.NewTransferProcessor(rpcClient, transactor)
 	router.AddPathProcessor(transfer)
 
+	disperse := pathprocessor.NewDisperseProcessor(rpcClient, transactor)
+	router.AddPathProcessor(disperse)
+
 	erc721Transfer := pathprocessor.NewERC721Processor(rpcClient, transactor)
 	router.AddPathProcessor(erc721Transfer)
 
@@ -68,15 +94,23 @@ func NewAPI(s *Service) *API {
 	hop := pathprocessor.NewHopBridgeProcessor(rpcClient, transactor, tokenManager, rpcClient.NetworkManager)
 	router.AddPathProcessor(hop)
 
+	nativeBridge := pathprocessor.NewNativeBridgeProcessor(rpcClient, transactor, tokenManager)
+	router.AddPathProcessor(nativeBridge)
+
 	if featureFlags.EnableCelerBridge {
 		// TODO: Celar Bridge is out of scope for 2.30, check it thoroughly once we decide to include it again
 		cbridge := pathprocessor.NewCelerBridgeProcessor(rpcClient, transactor, tokenManager)
 		router.AddPathProcessor(cbridge)
 	}
 
-	paraswap := pathprocessor.NewSwapParaswapProcessor(rpcClient, transactor, tokenManager)
+	paraswap := pathprocessor.NewSwapParaswapProcessor(transactor)
 	router.AddPathProcessor(paraswap)
 
+	if zeroExAPIKey := s.Config().WalletConfig.ZeroExAPIKey; zeroExAPIKey != "" {
+		zeroEx := pathprocessor.NewSwapZeroExProcessor(zeroExAPIKey, transactor)
+		router.AddPathProcessor(zeroEx)
+	}
+
 	ensRegister := pathprocessor.NewENSRegisterProcessor(rpcClient, transactor, ensService)
 	router.AddPathProcessor(ensRegister)
 
@@ -89,14 +123,20 @@ func NewAPI(s *Service) *API {
 	buyStickers := pathprocessor.NewStickersBuyProcessor(rpcClient, transactor, stickersService)
 	router.AddPathProcessor(buyStickers)
 
-	return &API{s, s.reader, router}
+	if bundlers := s.GetBundlers(); bundlers.HasBundlers() {
+		smartAccount := pathprocessor.NewSmartAccountProcessor(rpcClient, bundlers, s.pendingTxManager)
+		router.AddPathProcessor(smartAccount)
+	}
+
+	return router
 }
 
 // API is class with methods available over RPC.
 type API struct {
-	s      *Service
-	reader *Reader
-	router *router.Router
+	s                        *Service
+	reader                   *Reader
+	router                   *router.Router
+	scheduledTransfersRouter *router.Router
 }
 
 func (api *API) StartWallet(ctx context.Context) error {
@@ -105,6 +145,7 @@ func (api *API) StartWallet(ctx context.Context) error {
 
 func (api *API) StopWallet(ctx context.Context) error {
 	api.router.Stop()
+	api.scheduledTransfersRouter.Stop()
 	return api.s.Stop()
 }
 
@@ -163,6 +204,84 @@ func (api *API) FetchOrGetCachedWalletBalances(ctx context.Context, addresses []
 	return api.reader.FetchOrGetCachedWalletBalances(ctx, clients, addresses, false)
 }
 
+type BalancesOptions struct {
+	// HideSpam removes the balances of the tokens classified as spam
+	HideSpam bool `json:"hideSpam"`
+}
+
+func (api *API) filterBalances(balances map[common.Address][]token.StorageToken, options BalancesOptions) (map[common.Address][]token.StorageToken, error) {
+	if !options.HideSpam {
+		return balances, nil
+	}
+	return api.s.spamClassifier.FilterSpamBalances(balances)
+}
+
+// GetWalletTokenWithOptions is GetWalletToken with the balances filtered according to the options
+func (api *API) GetWalletTokenWithOptions(ctx context.Context, addresses []common.Address, options BalancesOptions) (map[common.Address][]token.StorageToken, error) {
+	log.Debug("wallet.api.GetWalletTokenWithOptions", "addr.count", len(addresses), "hideSpam", options.HideSpam)
+
+	balances, err := api.GetWalletToken(ctx, addresses)
+	if err != nil {
+		return nil, err
+	}
+	return api.filterBalances(balances, options)
+}
+
+// FetchOrGetCachedWalletBalancesWithOptions is FetchOrGetCachedWalletBalances with the balances filtered according to the options
+func (api *API) FetchOrGetCachedWalletBalancesWithOptions(ctx context.Context, addresses []common.Address, options BalancesOptions) (map[common.Address][]token.StorageToken, error) {
+	log.Debug("wallet.api.FetchOrGetCachedWalletBalancesWithOptions", "addr.count", len(addresses), "hideSpam", options.HideSpam)
+
+	balances, err := api.FetchOrGetCachedWalletBalances(ctx, addresses)
+	if err != nil {
+		return nil, err
+	}
+	return api.filterBalances(balances, options)
+}
+
+// GetSpamClassifications returns the classified tokens or collectibles contracts, `onlySpam` keeps the spam ones only
+func (api *API) GetSpamClassifications(assetType spam.AssetType, onlySpam bool) ([]*spam.Classification, error) {
+	log.Debug("wallet.api.GetSpamClassifications", "assetType", assetType, "onlySpam", onlySpam)
+
+	return api.s.spamClassifier.GetClassifications(assetType, onlySpam)
+}
+
+// SetAssetSpamVerdict overrides the spam verdict of a token or collectibles contract, an empty verdict restores the computed one
+func (api *API) SetAssetSpamVerdict(chainID wcommon.ChainID, address common.Address, assetType spam.AssetType, verdict spam.Verdict) error {
+	log.Debug("wallet.api.SetAssetSpamVerdict", "chainID", chainID, "address", address, "assetType", assetType, "verdict", verdict)
+
+	return api.s.spamClassifier.SetUserVerdict(uint64(chainID), address, assetType, verdict)
+}
+
+// AddKnownScamContracts imports a list of scam contracts, `source` identifies the list
+func (api *API) AddKnownScamContracts(source string, contracts []spam.KnownScamContract) error {
+	log.Debug("wallet.api.AddKnownScamContracts", "source", source, "contracts.count", len(contracts))
+
+	return api.s.spamClassifier.AddKnownScamContracts(source, contracts)
+}
+
+// CheckRecipientAddress returns the warnings for the saved addresses and previous counterparties the address resembles
+// without matching them, the address poisoning lookalikes
+func (api *API) CheckRecipientAddress(address common.Address) ([]*poisoning.RecipientWarning, error) {
+	log.Debug("wallet.api.CheckRecipientAddress", "address", address)
+
+	return api.s.poisoningDetector.CheckRecipient(address)
+}
+
+// GetPortfolioPnL returns the cost basis, the unrealized and the realized P&L of the accounts computed from the synced
+// transfers, `currency` defaults to the user currency
+func (api *API) GetPortfolioPnL(ctx context.Context, addresses []common.Address, method portfolio.CostBasisMethod, currency string) (*portfolio.Report, error) {
+	log.Debug("wallet.api.GetPortfolioPnL", "addr.count", len(addresses), "method", method, "currency", currency)
+
+	if currency == "" {
+		var err error
+		currency, err = api.s.accountsDB.GetCurrency()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return api.s.portfolio.GetPnL(ctx, addresses, method, currency)
+}
+
 type DerivedAddress struct {
 	Address        common.Address `json:"address"`
 	PublicKey      types.HexBytes `json:"public-key,omitempty"`
@@ -265,6 +384,29 @@ func (api *API) GetTokenList(ctx context.Context) (*token.ListWrapper, error) {
 	return rst, nil
 }
 
+// SubscribeTokenList adds the tokens of the Uniswap format token list at the given URL to the token list,
+// the list is refreshed periodically and `wallet-token-list-updated` is sent when a new version is fetched
+func (api *API) SubscribeTokenList(ctx context.Context, listURL string) (*token.TokenListSubscription, error) {
+	log.Debug("wallet.api.SubscribeTokenList", "listURL", listURL)
+	return api.s.tokenManager.SubscribeTokenList(ctx, listURL)
+}
+
+func (api *API) UnsubscribeTokenList(ctx context.Context, listURL string) error {
+	log.Debug("wallet.api.UnsubscribeTokenList", "listURL", listURL)
+	return api.s.tokenManager.UnsubscribeTokenList(listURL)
+}
+
+func (api *API) GetTokenListSubscriptions(ctx context.Context) ([]*token.TokenListSubscription, error) {
+	log.Debug("wallet.api.GetTokenListSubscriptions")
+	return api.s.tokenManager.GetTokenListSubscriptions()
+}
+
+// UpdateTokenLists fetches the token lists subscribed to without waiting for the periodic refresh
+func (api *API) UpdateTokenLists(ctx context.Context) error {
+	log.Debug("wallet.api.UpdateTokenLists")
+	return api.s.tokenManager.UpdateTokenLists(ctx)
+}
+
 // @deprecated
 func (api *API) GetTokens(ctx context.Context, chainID uint64) ([]*token.Token, error) {
 	log.Debug("call to get tokens")
@@ -350,6 +492,282 @@ func (api *API) WatchTransactionByChainID(ctx context.Context, chainID uint64, t
 	return api.s.transactionManager.WatchTransaction(ctx, chainID, transactionHash)
 }
 
+// SpeedUpPendingTransaction resends a pending transaction with the same nonce and higher fees
+func (api *API) SpeedUpPendingTransaction(ctx context.Context, chainID wcommon.ChainID, hash common.Hash, password string) (types.Hash, error) {
+	log.Debug("wallet.api.SpeedUpPendingTransaction", "chainID", chainID, "hash", hash)
+	return api.replacePendingTransaction(ctx, chainID, hash, transactions.ReplacementSpeedUp, password)
+}
+
+// CancelPendingTransaction replaces a pending transaction with a 0 value transfer to self using the same nonce and higher fees
+func (api *API) CancelPendingTransaction(ctx context.Context, chainID wcommon.ChainID, hash common.Hash, password string) (types.Hash, error) {
+	log.Debug("wallet.api.CancelPendingTransaction", "chainID", chainID, "hash", hash)
+	return api.replacePendingTransaction(ctx, chainID, hash, transactions.ReplacementCancel, password)
+}
+
+func (api *API) replacePendingTransaction(ctx context.Context, chainID wcommon.ChainID, hash common.Hash, replacementType transactions.ReplacementType, password string) (types.Hash, error) {
+	pendingTx, err := api.s.pendingTxManager.GetPendingEntry(chainID, hash)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	selectedAccount, err := api.getVerifiedWalletAccount(pendingTx.From.Hex(), password)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return api.s.transactionManager.ReplacePendingTransaction(ctx, api.router.GetFeesManager(), pendingTx, replacementType, selectedAccount)
+}
+
+// GetTokenAllowances returns the stored ERC20 allowances given by the accounts, revoked ones are skipped unless requested by the filter
+func (api *API) GetTokenAllowances(ctx context.Context, filter allowance.Filter) ([]*allowance.Allowance, error) {
+	log.Debug("wallet.api.GetTokenAllowances", "filter", filter)
+	return api.s.allowanceManager.GetAllowances(filter)
+}
+
+// FetchTokenAllowances scans the chains for the allowances given by the accounts and refreshes the stored ones
+func (api *API) FetchTokenAllowances(ctx context.Context, chainIDs []wcommon.ChainID, owners []common.Address) error {
+	log.Debug("wallet.api.FetchTokenAllowances", "chainIDs", chainIDs, "owners", owners)
+	return api.s.allowanceManager.FetchAllowances(ctx, chainIDs, owners)
+}
+
+// GetRevokeTokenAllowanceTxArgs returns the args of the transaction revoking an allowance, to be used with `BuildTransaction`
+func (api *API) GetRevokeTokenAllowanceTxArgs(ctx context.Context, owner common.Address, token common.Address, spender common.Address) (*transactions.SendTxArgs, error) {
+	log.Debug("wallet.api.GetRevokeTokenAllowanceTxArgs", "owner", owner, "token", token, "spender", spender)
+	return api.s.allowanceManager.RevokeTxArgs(owner, token, spender)
+}
+
+// RevokeTokenAllowance sends the transaction setting the allowance of the spender to 0
+func (api *API) RevokeTokenAllowance(ctx context.Context, chainID wcommon.ChainID, owner common.Address, token common.Address, spender common.Address,
+	password string) (types.Hash, error) {
+	log.Debug("wallet.api.RevokeTokenAllowance", "chainID", chainID, "owner", owner, "token", token, "spender", spender)
+
+	selectedAccount, err := api.getVerifiedWalletAccount(owner.Hex(), password)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return api.s.allowanceManager.Revoke(chainID, owner, token, spender, selectedAccount)
+}
+
+// GetBridgeWithdrawals returns the withdrawals sent through the canonical L2 bridges, newest first
+func (api *API) GetBridgeWithdrawals(ctx context.Context, pendingOnly bool) ([]*nativebridge.Withdrawal, error) {
+	log.Debug("wallet.api.GetBridgeWithdrawals", "pendingOnly", pendingOnly)
+	return api.s.nativeBridge.GetWithdrawals(pendingOnly)
+}
+
+// GetProveBridgeWithdrawalTxArgs returns the args of the L1 transaction proving the withdrawal, to be used with `BuildTransaction`
+func (api *API) GetProveBridgeWithdrawalTxArgs(ctx context.Context, id int64) (*transactions.SendTxArgs, error) {
+	log.Debug("wallet.api.GetProveBridgeWithdrawalTxArgs", "id", id)
+	return api.s.nativeBridge.ProveTxArgs(ctx, id)
+}
+
+// ProveBridgeWithdrawal sends the L1 transaction proving the withdrawal
+func (api *API) ProveBridgeWithdrawal(ctx context.Context, id int64, password string) (types.Hash, error) {
+	log.Debug("wallet.api.ProveBridgeWithdrawal", "id", id)
+
+	selectedAccount, err := api.getVerifiedBridgeWithdrawalAccount(id, password)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return api.s.nativeBridge.Prove(ctx, id, selectedAccount)
+}
+
+// GetFinalizeBridgeWithdrawalTxArgs returns the args of the L1 transaction finalizing the withdrawal, to be used with `BuildTransaction`
+func (api *API) GetFinalizeBridgeWithdrawalTxArgs(ctx context.Context, id int64) (*transactions.SendTxArgs, error) {
+	log.Debug("wallet.api.GetFinalizeBridgeWithdrawalTxArgs", "id", id)
+	return api.s.nativeBridge.FinalizeTxArgs(ctx, id)
+}
+
+// FinalizeBridgeWithdrawal sends the L1 transaction releasing the withdrawn funds
+func (api *API) FinalizeBridgeWithdrawal(ctx context.Context, id int64, password string) (types.Hash, error) {
+	log.Debug("wallet.api.FinalizeBridgeWithdrawal", "id", id)
+
+	selectedAccount, err := api.getVerifiedBridgeWithdrawalAccount(id, password)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return api.s.nativeBridge.Finalize(ctx, id, selectedAccount)
+}
+
+// SetBridgeWithdrawalTxHash stores the hash of the prove or finalize transaction sent with `SendTransactionWithSignature`
+func (api *API) SetBridgeWithdrawalTxHash(ctx context.Context, id int64, hash common.Hash) (*nativebridge.Withdrawal, error) {
+	log.Debug("wallet.api.SetBridgeWithdrawalTxHash", "id", id, "hash", hash)
+	return api.s.nativeBridge.SetStageTxHash(id, hash)
+}
+
+func (api *API) getVerifiedBridgeWithdrawalAccount(id int64, password string) (*account.SelectedExtKey, error) {
+	withdrawal, err := api.s.nativeBridge.GetWithdrawal(id)
+	if err != nil {
+		return nil, err
+	}
+	return api.getVerifiedWalletAccount(withdrawal.FromAddress.Hex(), password)
+}
+
+// ImportSafe reads the owners and the threshold of the Safe multisig deployed at the address and starts tracking its
+// transactions. The account is added separately with the `multisig` type.
+func (api *API) ImportSafe(ctx context.Context, chainID uint64, address common.Address) (*safe.Safe, error) {
+	log.Debug("wallet.api.ImportSafe", "chainID", chainID, "address", address)
+	return api.s.safes.ImportSafe(ctx, chainID, address)
+}
+
+// RemoveSafe stops tracking the Safe and removes its transactions
+func (api *API) RemoveSafe(ctx context.Context, chainID uint64, address common.Address) error {
+	log.Debug("wallet.api.RemoveSafe", "chainID", chainID, "address", address)
+	return api.s.safes.RemoveSafe(chainID, address)
+}
+
+// GetSafes returns the imported Safes with the given address on all the chains, all of them if the address is not set
+func (api *API) GetSafes(ctx context.Context, address *common.Address) ([]*safe.Safe, error) {
+	log.Debug("wallet.api.GetSafes", "address", address)
+	return api.s.safes.GetSafes(address)
+}
+
+// RefreshSafe reads the current configuration of the Safe and updates the status of its transactions
+func (api *API) RefreshSafe(ctx context.Context, chainID uint64, address common.Address) (*safe.Safe, error) {
+	log.Debug("wallet.api.RefreshSafe", "chainID", chainID, "address", address)
+	return api.s.safes.RefreshSafe(ctx, chainID, address)
+}
+
+// SetSafeTxServiceURL sets the Safe transaction service used to exchange the transactions with the co-signers on the
+// chain, the default one is removed if the URL is empty
+func (api *API) SetSafeTxServiceURL(ctx context.Context, chainID uint64, serviceURL string) {
+	log.Debug("wallet.api.SetSafeTxServiceURL", "chainID", chainID, "serviceURL", serviceURL)
+	api.s.safes.SetTxServiceURL(chainID, serviceURL)
+}
+
+// GetSafeTransactions returns the transactions of the Safe ordered by nonce
+func (api *API) GetSafeTransactions(ctx context.Context, chainID uint64, address common.Address, pendingOnly bool) ([]*safe.Transaction, error) {
+	log.Debug("wallet.api.GetSafeTransactions", "chainID", chainID, "address", address, "pendingOnly", pendingOnly)
+	return api.s.safes.GetTransactions(chainID, address, pendingOnly)
+}
+
+// ProposeSafeTransaction proposes the transaction described by the args to the Safe, e.g. the ones returned by
+// `GetRevokeTokenAllowanceTxArgs`. The proposer is a local owner account, its confirmation is added.
+func (api *API) ProposeSafeTransaction(ctx context.Context, chainID uint64, safeAddress common.Address, sendArgs transactions.SendTxArgs,
+	proposer common.Address, password string, publish bool) (*safe.Transaction, error) {
+	log.Debug("wallet.api.ProposeSafeTransaction", "chainID", chainID, "safe", safeAddress, "proposer", proposer, "publish", publish)
+
+	if sendArgs.To == nil {
+		return nil, transactions.ErrInvalidSendTxArgs
+	}
+	selectedAccount, err := api.getVerifiedWalletAccount(proposer.Hex(), password)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.s.safes.ProposeTransaction(ctx, chainID, safeAddress, safe.Call{
+		To:    common.Address(*sendArgs.To),
+		Value: sendArgs.Value,
+		Data:  hexutil.Bytes(sendArgs.GetInput()),
+	}, selectedAccount, publish)
+}
+
+// ProposeSafeTransactionsFromRoute proposes the txs built with `BuildTransactionsFromRoute` for a route sending from a
+// Safe, one Safe transaction each. The proposer is a local owner account, its confirmation is added.
+func (api *API) ProposeSafeTransactionsFromRoute(ctx context.Context, uuid string, proposer common.Address, password string,
+	publish bool) ([]*safe.Transaction, error) {
+	log.Debug("wallet.api.ProposeSafeTransactionsFromRoute", "uuid", uuid, "proposer", proposer, "publish", publish)
+
+	unlock := api.s.transactionManager.LockRouterTransactions()
+	defer unlock()
+
+	_, routeInputParams := api.router.GetBestRouteAndAssociatedInputParams()
+	if routeInputParams.Uuid != uuid {
+		return nil, ErrCannotResolveRouteId
+	}
+	builtTxs := api.s.transactionManager.GetBuiltRouterTransactions()
+	if len(builtTxs) == 0 {
+		return nil, transfer.ErrNoTrsansactionsBeingBuilt
+	}
+	defer api.s.transactionManager.ClearLocalRouterTransactionsData()
+
+	selectedAccount, err := api.getVerifiedWalletAccount(proposer.Hex(), password)
+	if err != nil {
+		return nil, err
+	}
+
+	proposed := make([]*safe.Transaction, 0, len(builtTxs))
+	for _, tx := range builtTxs {
+		if tx.To() == nil {
+			return proposed, transactions.ErrInvalidSendTxArgs
+		}
+		safeTx, err := api.s.safes.ProposeTransaction(ctx, tx.ChainId().Uint64(), routeInputParams.AddrFrom, safe.Call{
+			To:    *tx.To(),
+			Value: (*hexutil.Big)(tx.Value()),
+			Data:  tx.Data(),
+		}, selectedAccount, publish)
+		if safeTx != nil {
+			proposed = append(proposed, safeTx)
+		}
+		if err != nil {
+			return proposed, err
+		}
+	}
+	return proposed, nil
+}
+
+// ConfirmSafeTransaction adds the confirmation of a local owner account
+func (api *API) ConfirmSafeTransaction(ctx context.Context, safeTxHash common.Hash, owner common.Address, password string,
+	publish bool) (*safe.Transaction, error) {
+	log.Debug("wallet.api.ConfirmSafeTransaction", "safeTxHash", safeTxHash, "owner", owner, "publish", publish)
+
+	selectedAccount, err := api.getVerifiedWalletAccount(owner.Hex(), password)
+	if err != nil {
+		return nil, err
+	}
+	return api.s.safes.ConfirmTransaction(ctx, safeTxHash, selectedAccount, publish)
+}
+
+// AddSafeConfirmation adds the confirmation of a co-signer, the signature is checked against the Safe owners
+func (api *API) AddSafeConfirmation(ctx context.Context, safeTxHash common.Hash, owner common.Address, signature hexutil.Bytes) (*safe.Transaction, error) {
+	log.Debug("wallet.api.AddSafeConfirmation", "safeTxHash", safeTxHash, "owner", owner)
+	return api.s.safes.AddConfirmation(safeTxHash, owner, signature)
+}
+
+// ImportSafeTransaction imports a Safe transaction with its confirmations shared by a co-signer, e.g. in a Status
+// message. The JSON of the transaction returned by the other Safe endpoints is the shared format.
+func (api *API) ImportSafeTransaction(ctx context.Context, tx *safe.Transaction) (*safe.Transaction, error) {
+	log.Debug("wallet.api.ImportSafeTransaction", "safeTxHash", tx.SafeTxHash)
+	return api.s.safes.ImportTransaction(tx)
+}
+
+// PublishSafeTransaction publishes the transaction and its confirmations to the Safe transaction service
+func (api *API) PublishSafeTransaction(ctx context.Context, safeTxHash common.Hash) error {
+	log.Debug("wallet.api.PublishSafeTransaction", "safeTxHash", safeTxHash)
+	return api.s.safes.PublishTransaction(ctx, safeTxHash)
+}
+
+// SyncSafeTransactions imports the pending transactions of the Safe and their confirmations from the Safe transaction service
+func (api *API) SyncSafeTransactions(ctx context.Context, chainID uint64, address common.Address) ([]*safe.Transaction, error) {
+	log.Debug("wallet.api.SyncSafeTransactions", "chainID", chainID, "address", address)
+	return api.s.safes.SyncWithTxService(ctx, chainID, address)
+}
+
+// GetExecuteSafeTransactionTxArgs returns the args of the tx executing the Safe transaction, to be used with `BuildTransaction`
+func (api *API) GetExecuteSafeTransactionTxArgs(ctx context.Context, safeTxHash common.Hash, executor common.Address) (*transactions.SendTxArgs, error) {
+	log.Debug("wallet.api.GetExecuteSafeTransactionTxArgs", "safeTxHash", safeTxHash, "executor", executor)
+	return api.s.safes.ExecTxArgs(ctx, safeTxHash, executor)
+}
+
+// ExecuteSafeTransaction sends the tx executing the Safe transaction once it has enough confirmations
+func (api *API) ExecuteSafeTransaction(ctx context.Context, safeTxHash common.Hash, executor common.Address, password string) (types.Hash, error) {
+	log.Debug("wallet.api.ExecuteSafeTransaction", "safeTxHash", safeTxHash, "executor", executor)
+
+	selectedAccount, err := api.getVerifiedWalletAccount(executor.Hex(), password)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	return api.s.safes.Execute(ctx, safeTxHash, selectedAccount)
+}
+
+// SetSafeExecutionTxHash stores the hash of the execution tx sent with `SendTransactionWithSignature`
+func (api *API) SetSafeExecutionTxHash(ctx context.Context, safeTxHash common.Hash, hash common.Hash) (*safe.Transaction, error) {
+	log.Debug("wallet.api.SetSafeExecutionTxHash", "safeTxHash", safeTxHash, "hash", hash)
+	return api.s.safes.SetExecutionTxHash(safeTxHash, hash)
+}
+
 func (api *API) GetCryptoOnRamps(ctx context.Context) ([]onramp.CryptoOnRamp, error) {
 	log.Debug("call to GetCryptoOnRamps")
 	return api.s.cryptoOnRampManager.GetProviders(ctx)
@@ -380,6 +798,11 @@ func (api *API) GetCollectibleOwnership(id thirdparty.CollectibleUniqueID) ([]th
 	return api.s.collectiblesManager.GetCollectibleOwnership(id)
 }
 
+func (api *API) FetchAssetsByCollectibleUniqueID(ctx context.Context, uniqueIDs []thirdparty.CollectibleUniqueID, asyncFetch bool) ([]thirdparty.FullCollectibleData, error) {
+	log.Debug("call to FetchAssetsByCollectibleUniqueID")
+	return api.s.collectiblesManager.FetchAssetsByCollectibleUniqueID(ctx, uniqueIDs, asyncFetch)
+}
+
 func (api *API) RefetchOwnedCollectibles() error {
 	log.Debug("wallet.api.RefetchOwnedCollectibles")
 
@@ -391,6 +814,13 @@ func (api *API) GetOwnedCollectiblesAsync(requestID int32, chainIDs []wcommon.Ch
 	log.Debug("wallet.api.GetOwnedCollectiblesAsync", "requestID", requestID, "chainIDs.count", len(chainIDs), "addr.count", len(addresses), "offset", offset, "limit", limit, "dataType", dataType, "fetchCriteria", fetchCriteria)
 
 	api.s.collectibles.GetOwnedCollectiblesAsync(requestID, chainIDs, addresses, filter, offset, limit, dataType, fetchCriteria)
+
+	if filter.HideSpam {
+		// the spam is hidden only once classified, the collectibles are filtered again if more got classified
+		api.classifySpamAsync(api.s.spamClassifier.ClassifyOwnedCollectibles, func() {
+			api.s.collectibles.GetOwnedCollectiblesAsync(requestID, chainIDs, addresses, filter, offset, limit, dataType, fetchCriteria)
+		})
+	}
 	return nil
 }
 
@@ -452,6 +882,24 @@ func (api *API) FetchPrices(ctx context.Context, symbols []string, currencies []
 	return api.s.marketManager.FetchPrices(symbols, currencies)
 }
 
+// FetchPricesWithConfidence returns the prices with their confidence, reconciled between the price sources
+// if the reconciliation is enabled
+func (api *API) FetchPricesWithConfidence(ctx context.Context, symbols []string, currencies []string) (market.DataPerTokenAndCurrency, error) {
+	log.Debug("call to FetchPricesWithConfidence")
+	return api.s.marketManager.FetchPricesWithConfidence(symbols, currencies)
+}
+
+// SetPriceReconciliationEnabled enables comparing the prices of all the price sources and rejecting the outliers
+func (api *API) SetPriceReconciliationEnabled(ctx context.Context, enabled bool) error {
+	log.Debug("call to SetPriceReconciliationEnabled", "enabled", enabled)
+	api.s.marketManager.SetReconciliationEnabled(enabled)
+	return nil
+}
+
+func (api *API) IsPriceReconciliationEnabled(ctx context.Context) (bool, error) {
+	return api.s.marketManager.IsReconciliationEnabled(), nil
+}
+
 // @deprecated
 func (api *API) FetchMarketValues(ctx context.Context, symbols []string, currency string) (map[string]thirdparty.TokenMarketValues, error) {
 	log.Debug("call to FetchMarketValues")
@@ -489,6 +937,28 @@ func (api *API) GetTransactionEstimatedTime(ctx context.Context, chainID uint64,
 	return api.router.GetFeesManager().TransactionEstimatedTime(ctx, chainID, gweiToWei(maxFeePerGas)), nil
 }
 
+// GetFeeStats returns the fee percentiles over the latest blocks of the chain and the short-horizon base fee forecast
+func (api *API) GetFeeStats(ctx context.Context, chainID uint64) (*feemonitor.FeeStats, error) {
+	log.Debug("wallet.api.GetFeeStats", "chainID", chainID)
+	return api.s.feeMonitor.GetFeeStats(ctx, chainID)
+}
+
+// AddFeeAlert registers a fee alert, the `wallet-fee-alert-triggered` event is sent when its condition is met
+func (api *API) AddFeeAlert(ctx context.Context, alert *feemonitor.Alert) (*feemonitor.Alert, error) {
+	log.Debug("wallet.api.AddFeeAlert", "chainID", alert.ChainID, "metric", alert.Metric, "condition", alert.Condition)
+	return api.s.feeMonitor.AddAlert(alert)
+}
+
+func (api *API) DeleteFeeAlert(ctx context.Context, id int64) error {
+	log.Debug("wallet.api.DeleteFeeAlert", "id", id)
+	return api.s.feeMonitor.DeleteAlert(id)
+}
+
+func (api *API) GetFeeAlerts(ctx context.Context, chainID uint64) ([]*feemonitor.Alert, error) {
+	log.Debug("wallet.api.GetFeeAlerts", "chainID", chainID)
+	return api.s.feeMonitor.GetAlerts(chainID)
+}
+
 func gweiToWei(val *big.Float) *big.Int {
 	res, _ := new(big.Float).Mul(val, big.NewFloat(1000000000)).Int(nil)
 	return res
@@ -506,6 +976,18 @@ func (api *API) GetSuggestedRoutesAsync(ctx context.Context, input *requests.Rou
 	api.router.SuggestedRoutesAsync(input)
 }
 
+func (api *API) GetSuggestedBatchRoutes(ctx context.Context, input *requests.BatchRouteInputParams) (*router.SuggestedRoutes, error) {
+	log.Debug("call to GetSuggestedBatchRoutes")
+
+	return api.router.SuggestedBatchRoutes(ctx, input)
+}
+
+func (api *API) GetSuggestedBatchRoutesAsync(ctx context.Context, input *requests.BatchRouteInputParams) {
+	log.Debug("call to GetSuggestedBatchRoutesAsync")
+
+	api.router.SuggestedBatchRoutesAsync(input)
+}
+
 func (api *API) StopSuggestedRoutesAsyncCalculation(ctx context.Context) {
 	log.Debug("call to StopSuggestedRoutesAsyncCalculation")
 
@@ -666,12 +1148,12 @@ func (api *API) GetAddressDetails(ctx context.Context, chainID uint64, address s
 func (api *API) SignMessage(ctx context.Context, message types.HexBytes, address common.Address, password string) (string, error) {
 	log.Debug("[WalletAPI::SignMessage]", "message", message, "address", address)
 
-	selectedAccount, err := api.s.gethManager.VerifyAccountPassword(api.s.Config().KeyStoreDir, address.Hex(), password)
+	selectedAccount, err := api.getVerifiedWalletAccount(address.Hex(), password)
 	if err != nil {
 		return "", err
 	}
 
-	return api.s.transactionManager.SignMessage(message, selectedAccount)
+	return api.s.transactionManager.SignMessage(ctx, message, selectedAccount)
 }
 
 func (api *API) BuildTransaction(ctx context.Context, chainID uint64, sendTxArgsJSON string) (response *transfer.TxResponse, err error) {
@@ -735,7 +1217,12 @@ func (api *API) CreateMultiTransaction(ctx context.Context, multiTransactionComm
 	}
 
 	if password != "" {
-		selectedAccount, err := api.getVerifiedWalletAccount(multiTransactionCommand.FromAddress.Hex(), password)
+		signerAddress, err := api.getSignerAddress(ctx, multiTransactionCommand.FromAddress, data)
+		if err != nil {
+			return nil, err
+		}
+
+		selectedAccount, err := api.getVerifiedWalletAccount(signerAddress.Hex(), password)
 		if err != nil {
 			return nil, err
 		}
@@ -756,6 +1243,19 @@ func (api *API) CreateMultiTransaction(ctx context.Context, multiTransactionComm
 	return nil, api.s.transactionManager.SendTransactionForSigningToKeycard(ctx, cmd, data, api.router.GetPathProcessors())
 }
 
+// getSignerAddress returns the account whose key signs the transactions, for smart accounts that is the owner of the smart account
+func (api *API) getSignerAddress(ctx context.Context, fromAddress common.Address, data []*pathprocessor.MultipathProcessorTxArgs) (common.Address, error) {
+	if len(data) == 0 || data[0].Name != pathprocessor.ProcessorSmartAccountTransferName {
+		return fromAddress, nil
+	}
+
+	processor, ok := api.router.GetPathProcessors()[pathprocessor.ProcessorSmartAccountTransferName].(*pathprocessor.SmartAccountProcessor)
+	if !ok {
+		return common.Address{}, pathprocessor.ErrBundlerNotConfigured
+	}
+	return processor.Owner(ctx, data[0].ChainID, fromAddress)
+}
+
 func updateFields(sd *responses.SendDetails, inputParams requests.RouteInputParams) {
 	sd.SendType = int(inputParams.SendType)
 	sd.FromAddress = types.Address(inputParams.AddrFrom)
@@ -783,6 +1283,9 @@ func (api *API) BuildTransactionsFromRoute(ctx context.Context, buildInputParams
 		defer status_common.LogOnPanic()
 		api.router.StopSuggestedRoutesAsyncCalculation()
 
+		unlock := api.s.transactionManager.LockRouterTransactions()
+		defer unlock()
+
 		var err error
 		response := &responses.RouterTransactionsForSigning{
 			SendDetails: &responses.SendDetails{
@@ -812,6 +1315,7 @@ func (api *API) BuildTransactionsFromRoute(ctx context.Context, buildInputParams
 		signal.SendWalletEvent(signal.RouterSendingTransactionsStarted, response.SendDetails)
 
 		response.SigningDetails, err = api.s.transactionManager.BuildTransactionsFromRoute(
+			context.WithoutCancel(ctx),
 			route,
 			api.router.GetPathProcessors(),
 			transfer.BuildRouteExtraParams{
@@ -823,6 +1327,11 @@ func (api *API) BuildTransactionsFromRoute(ctx context.Context, buildInputParams
 				SlippagePercentage: buildInputParams.SlippagePercentage,
 			},
 		)
+		if err != nil {
+			return
+		}
+
+		response.SigningDetails.Simulations = api.s.transactionManager.SimulateRouterTransactions(ctx, api.s.simulator)
 	}()
 }
 
@@ -845,6 +1354,9 @@ func (api *API) SendRouterTransactionsWithSignatures(ctx context.Context, sendIn
 	go func() {
 		defer status_common.LogOnPanic()
 
+		unlock := api.s.transactionManager.LockRouterTransactions()
+		defer unlock()
+
 		var (
 			err              error
 			routeInputParams requests.RouteInputParams
@@ -859,10 +1371,12 @@ func (api *API) SendRouterTransactionsWithSignatures(ctx context.Context, sendIn
 			clearLocalData := true
 			if routeInputParams.SendType == sendtype.Swap {
 				// in case of swap don't clear local data if an approval is placed, but swap tx is not sent yet
-				if api.s.transactionManager.ApprovalRequiredForPath(pathprocessor.ProcessorSwapParaswapName) &&
-					api.s.transactionManager.ApprovalPlacedForPath(pathprocessor.ProcessorSwapParaswapName) &&
-					!api.s.transactionManager.TxPlacedForPath(pathprocessor.ProcessorSwapParaswapName) {
-					clearLocalData = false
+				for _, swapProcessorName := range []string{pathprocessor.ProcessorSwapParaswapName, pathprocessor.ProcessorSwapZeroExName} {
+					if api.s.transactionManager.ApprovalRequiredForPath(swapProcessorName) &&
+						api.s.transactionManager.ApprovalPlacedForPath(swapProcessorName) &&
+						!api.s.transactionManager.TxPlacedForPath(swapProcessorName) {
+						clearLocalData = false
+					}
 				}
 			}
 
@@ -890,55 +1404,151 @@ func (api *API) SendRouterTransactionsWithSignatures(ctx context.Context, sendIn
 			return
 		}
 
-		//////////////////////////////////////////////////////////////////////////////
-		// prepare multitx
-		var mtType transfer.MultiTransactionType = transfer.MultiTransactionSend
-		if routeInputParams.SendType == sendtype.Bridge {
-			mtType = transfer.MultiTransactionBridge
-		} else if routeInputParams.SendType == sendtype.Swap {
-			mtType = transfer.MultiTransactionSwap
-		}
+		response.SentTransactions, err = api.sendRouterTransactions(context.WithoutCancel(ctx), routeInputParams)
+	}()
+}
 
-		multiTx := transfer.NewMultiTransaction(
-			/* Timestamp:     */ uint64(time.Now().Unix()),
-			/* FromNetworkID: */ 0,
-			/* ToNetworkID:	  */ 0,
-			/* FromTxHash:    */ common.Hash{},
-			/* ToTxHash:      */ common.Hash{},
-			/* FromAddress:   */ routeInputParams.AddrFrom,
-			/* ToAddress:     */ routeInputParams.AddrTo,
-			/* FromAsset:     */ routeInputParams.TokenID,
-			/* ToAsset:       */ routeInputParams.ToTokenID,
-			/* FromAmount:    */ routeInputParams.AmountIn,
-			/* ToAmount:      */ routeInputParams.AmountOut,
-			/* Type:		  */ mtType,
-			/* CrossTxID:	  */ "",
-		)
+// sendRouterTransactions sends the signed router transactions as a new multi transaction and starts watching them
+func (api *API) sendRouterTransactions(ctx context.Context, routeInputParams requests.RouteInputParams) ([]*responses.RouterSentTransaction, error) {
+	var mtType transfer.MultiTransactionType = transfer.MultiTransactionSend
+	if routeInputParams.SendType == sendtype.Bridge {
+		mtType = transfer.MultiTransactionBridge
+	} else if routeInputParams.SendType == sendtype.Swap {
+		mtType = transfer.MultiTransactionSwap
+	}
+
+	multiTx := transfer.NewMultiTransaction(
+		/* Timestamp:     */ uint64(time.Now().Unix()),
+		/* FromNetworkID: */ 0,
+		/* ToNetworkID:	  */ 0,
+		/* FromTxHash:    */ common.Hash{},
+		/* ToTxHash:      */ common.Hash{},
+		/* FromAddress:   */ routeInputParams.AddrFrom,
+		/* ToAddress:     */ routeInputParams.AddrTo,
+		/* FromAsset:     */ routeInputParams.TokenID,
+		/* ToAsset:       */ routeInputParams.ToTokenID,
+		/* FromAmount:    */ routeInputParams.AmountIn,
+		/* ToAmount:      */ routeInputParams.AmountOut,
+		/* Type:		  */ mtType,
+		/* CrossTxID:	  */ "",
+	)
+
+	_, err := api.s.transactionManager.InsertMultiTransaction(multiTx)
+	if err != nil {
+		return nil, err
+	}
+
+	sentTransactions, err := api.s.transactionManager.SendRouterTransactions(ctx, multiTx)
+	if err != nil {
+		return nil, err
+	}
 
-		_, err = api.s.transactionManager.InsertMultiTransaction(multiTx)
+	for hash, path := range api.s.transactionManager.SentTxsForPath(pathprocessor.ProcessorBridgeNativeName) {
+		if !nativebridge.IsWithdrawal(path.FromChain.ChainID, path.ToChain.ChainID) {
+			continue
+		}
+		_, err := api.s.nativeBridge.TrackWithdrawal(multiTx.ID, path.FromChain.ChainID, path.ToChain.ChainID, common.Hash(hash),
+			routeInputParams.AddrFrom, routeInputParams.AddrTo, path.FromToken.Symbol, path.AmountIn)
 		if err != nil {
-			return
+			log.Error("failed to track bridge withdrawal", "chainID", path.FromChain.ChainID, "hash", hash, "err", err)
 		}
-		//////////////////////////////////////////////////////////////////////////////
+	}
 
-		response.SentTransactions, err = api.s.transactionManager.SendRouterTransactions(ctx, multiTx)
-		var (
-			chainIDs  []uint64
-			addresses []common.Address
-		)
-		for _, tx := range response.SentTransactions {
-			chainIDs = append(chainIDs, tx.FromChain)
-			addresses = append(addresses, common.Address(tx.FromAddress))
-			go func(chainId uint64, txHash common.Hash) {
-				defer status_common.LogOnPanic()
-				err = api.s.transactionManager.WatchTransaction(context.Background(), chainId, txHash)
-				if err != nil {
-					return
-				}
-			}(tx.FromChain, common.Hash(tx.Hash))
-		}
-		err = api.s.transferController.CheckRecentHistory(chainIDs, addresses)
-	}()
+	var (
+		chainIDs  []uint64
+		addresses []common.Address
+	)
+	for _, tx := range sentTransactions {
+		chainIDs = append(chainIDs, tx.FromChain)
+		addresses = append(addresses, common.Address(tx.FromAddress))
+		go func(chainId uint64, txHash common.Hash) {
+			defer status_common.LogOnPanic()
+			err := api.s.transactionManager.WatchTransaction(context.Background(), chainId, txHash)
+			if err != nil {
+				log.Error("failed to watch transaction", "chainID", chainId, "hash", txHash, "err", err)
+			}
+		}(tx.FromChain, common.Hash(tx.Hash))
+	}
+	return sentTransactions, api.s.transferController.CheckRecentHistory(chainIDs, addresses)
+}
+
+// ScheduleTransfer schedules a transfer executed at `executeAt` (unix time) and repeated based on the recurrence. The route
+// is calculated when the transfer is executed.
+func (api *API) ScheduleTransfer(ctx context.Context, input *requests.RouteInputParams, executeAt int64, recurrence scheduledtransfer.Recurrence) (*scheduledtransfer.ScheduledTransfer, error) {
+	log.Debug("wallet.api.ScheduleTransfer", "executeAt", executeAt, "recurrence", recurrence)
+	return api.s.scheduledTransfers.Schedule(input, executeAt, recurrence)
+}
+
+func (api *API) GetScheduledTransfers(ctx context.Context, statuses []scheduledtransfer.Status) ([]*scheduledtransfer.ScheduledTransfer, error) {
+	log.Debug("wallet.api.GetScheduledTransfers", "statuses", statuses)
+	return api.s.scheduledTransfers.GetScheduledTransfers(statuses)
+}
+
+func (api *API) CancelScheduledTransfer(ctx context.Context, id int64) error {
+	log.Debug("wallet.api.CancelScheduledTransfer", "id", id)
+	return api.s.scheduledTransfers.Cancel(id)
+}
+
+// ConfirmScheduledTransfer sends a due scheduled transfer, it has to be called after the `wallet-scheduled-transfer-due`
+// event since the account key is needed to sign the transactions
+func (api *API) ConfirmScheduledTransfer(ctx context.Context, id int64, password string) ([]*responses.RouterSentTransaction, error) {
+	log.Debug("wallet.api.ConfirmScheduledTransfer", "id", id)
+
+	var sentTransactions []*responses.RouterSentTransaction
+	_, err := api.s.scheduledTransfers.Execute(id, func(st *scheduledtransfer.ScheduledTransfer) (err error) {
+		sentTransactions, err = api.executeScheduledTransfer(ctx, st, password)
+		return err
+	})
+	return sentTransactions, err
+}
+
+// executeScheduledTransfer calculates the best route for the scheduled transfer, signs its transactions with the account key
+// and sends them. It fails with transfer.ErrRouterTransactionsInUse while the user is sending the transactions of another
+// route, the transfer stays due then.
+func (api *API) executeScheduledTransfer(ctx context.Context, st *scheduledtransfer.ScheduledTransfer, password string) ([]*responses.RouterSentTransaction, error) {
+	selectedAccount, err := api.getVerifiedWalletAccount(st.Params.AddrFrom.Hex(), password)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := api.s.transactionManager.TryLockUnusedRouterTransactions()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	routeInputParams := *st.Params
+	routeInputParams.Uuid = uuid.NewString()
+	suggestedRoutes, err := api.scheduledTransfersRouter.SuggestedRoutes(ctx, &routeInputParams)
+	if err != nil {
+		return nil, err
+	}
+
+	defer api.s.transactionManager.ClearLocalRouterTransactionsData()
+	signingDetails, err := api.s.transactionManager.BuildTransactionsFromRoute(
+		ctx,
+		suggestedRoutes.Best,
+		api.scheduledTransfersRouter.GetPathProcessors(),
+		transfer.BuildRouteExtraParams{
+			AddressFrom: routeInputParams.AddrFrom,
+			AddressTo:   routeInputParams.AddrTo,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures, err := api.s.transactionManager.SignRouterTransactions(ctx, signingDetails.Hashes, selectedAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	err = api.s.transactionManager.ValidateAndAddSignaturesToRouterTransactions(signatures)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.sendRouterTransactions(ctx, routeInputParams)
 }
 
 func (api *API) GetMultiTransactions(ctx context.Context, transactionIDs []wcommon.MultiTransactionIDType) ([]*transfer.MultiTransaction, error) {
@@ -956,11 +1566,40 @@ func (api *API) FetchAllCurrencyFormats() (currency.FormatPerSymbol, error) {
 	return api.s.currency.FetchAllCurrencyFormats()
 }
 
+// spamClassificationTimeout bounds the classification of the assets run in the background when the spam is hidden
+const spamClassificationTimeout = 2 * time.Minute
+
+// classifySpamAsync runs the classification in the background, `onClassified` is called if assets got classified to
+// filter the data again as the spam is hidden only once classified
+func (api *API) classifySpamAsync(classify func(ctx context.Context) (int, error), onClassified func()) {
+	go func() {
+		defer status_common.LogOnPanic()
+
+		ctx, cancel := context.WithTimeout(context.Background(), spamClassificationTimeout)
+		defer cancel()
+
+		classified, err := classify(ctx)
+		if err != nil {
+			log.Error("failed to classify spam", "error", err)
+			return
+		}
+		if classified > 0 {
+			onClassified()
+		}
+	}()
+}
+
 // @deprecated replaced by session APIs; see #12120
 func (api *API) FilterActivityAsync(requestID int32, addresses []common.Address, chainIDs []wcommon.ChainID, filter activity.Filter, offset int, limit int) error {
 	log.Debug("wallet.api.FilterActivityAsync", "requestID", requestID, "addr.count", len(addresses), "chainIDs.count", len(chainIDs), "offset", offset, "limit", limit)
 
 	api.s.activity.FilterActivityAsync(requestID, addresses, chainIDs, filter, offset, limit)
+
+	if filter.HideSpam {
+		api.classifySpamAsync(api.s.spamClassifier.ClassifyTransferredAssets, func() {
+			api.s.activity.FilterActivityAsync(requestID, addresses, chainIDs, filter, offset, limit)
+		})
+	}
 	return nil
 }
 
@@ -975,13 +1614,66 @@ func (api *API) CancelActivityFilterTask(requestID int32) error {
 func (api *API) StartActivityFilterSession(addresses []common.Address, chainIDs []wcommon.ChainID, filter activity.Filter, firstPageCount int) (activity.SessionID, error) {
 	log.Debug("wallet.api.StartActivityFilterSession", "addr.count", len(addresses), "chainIDs.count", len(chainIDs), "firstPageCount", firstPageCount)
 
-	return api.s.activity.StartFilterSession(addresses, chainIDs, filter, firstPageCount), nil
+	sessionID := api.s.activity.StartFilterSession(addresses, chainIDs, filter, firstPageCount)
+
+	if filter.HideSpam {
+		api.classifySpamAsync(api.s.spamClassifier.ClassifyTransferredAssets, func() {
+			api.refreshActivityFilterSession(sessionID, firstPageCount)
+		})
+	}
+	return sessionID, nil
+}
+
+// refreshActivityFilterSession sends the entries of the session again, filtered with its current filter
+func (api *API) refreshActivityFilterSession(sessionID activity.SessionID, firstPageCount int) {
+	if err := api.s.activity.ResetFilterSession(sessionID, firstPageCount); err != nil {
+		log.Debug("failed to refresh activity filter session", "sessionID", sessionID, "error", err)
+	}
+}
+
+// ExportActivityAsync writes the activity matching the filter to a CSV or JSON file for reporting, the progress and the
+// result are sent as wallet events
+func (api *API) ExportActivityAsync(requestID int32, addresses []common.Address, chainIDs []wcommon.ChainID, filter activity.Filter, options activity.ExportOptions) error {
+	log.Debug("wallet.api.ExportActivityAsync", "requestID", requestID, "addr.count", len(addresses), "chainIDs.count", len(chainIDs), "format", options.Format)
+
+	if !filter.HideSpam {
+		return api.s.activity.ExportActivityAsync(requestID, addresses, chainIDs, filter, options)
+	}
+
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	// the spam is hidden only once classified, the export starts after the classification
+	go func() {
+		defer status_common.LogOnPanic()
+
+		ctx, cancel := context.WithTimeout(context.Background(), spamClassificationTimeout)
+		defer cancel()
+
+		if _, err := api.s.spamClassifier.ClassifyTransferredAssets(ctx); err != nil {
+			log.Error("failed to classify transferred assets", "error", err)
+		}
+		if err := api.s.activity.ExportActivityAsync(requestID, addresses, chainIDs, filter, options); err != nil {
+			log.Error("failed to export activity", "requestID", requestID, "error", err)
+		}
+	}()
+	return nil
 }
 
 func (api *API) UpdateActivityFilterForSession(sessionID activity.SessionID, filter activity.Filter, firstPageCount int) error {
 	log.Debug("wallet.api.UpdateActivityFilterForSession", "sessionID", sessionID, "firstPageCount", firstPageCount)
 
-	return api.s.activity.UpdateFilterForSession(sessionID, filter, firstPageCount)
+	err := api.s.activity.UpdateFilterForSession(sessionID, filter, firstPageCount)
+	if err != nil {
+		return err
+	}
+
+	if filter.HideSpam {
+		api.classifySpamAsync(api.s.spamClassifier.ClassifyTransferredAssets, func() {
+			api.refreshActivityFilterSession(sessionID, firstPageCount)
+		})
+	}
+	return nil
 }
 
 func (api *API) ResetActivityFilterSession(id activity.SessionID, firstPageCount int) error {
@@ -1059,6 +1751,19 @@ func (api *API) getVerifiedWalletAccount(address, password string) (*account.Sel
 		return nil, transactions.ErrAccountDoesntExist
 	}
 
+	if selectedAccount, ok := api.s.gethManager.ExternalSignerAccount(address); ok {
+		return selectedAccount, nil
+	}
+
+	selectedAccount, err := api.getKeycardAccount(address)
+	if err != nil {
+		log.Error("failed to get the keypair of the account", "account", address, "error", err)
+		return nil, err
+	}
+	if selectedAccount != nil {
+		return selectedAccount, nil
+	}
+
 	keyStoreDir := api.s.Config().KeyStoreDir
 	key, err := api.s.gethManager.VerifyAccountPassword(keyStoreDir, address, password)
 	if err != nil {
@@ -1072,6 +1777,96 @@ func (api *API) getVerifiedWalletAccount(address, password string) (*account.Sel
 	}, nil
 }
 
+// getKeycardAccount returns the account signed for by the Keycard if its keypair was migrated to one and the client
+// enabled the Keycard sign requests, nil otherwise. The hashes to sign are sent to the client with the
+// `wallet.keycard.sign-request` signal.
+func (api *API) getKeycardAccount(address string) (*account.SelectedExtKey, error) {
+	if !api.s.keycardSignRequests.Enabled() {
+		return nil, nil
+	}
+
+	acc, err := api.s.accountsDB.GetAccountByAddress(types.HexToAddress(address))
+	if err != nil {
+		return nil, err
+	}
+	if acc.IsWatchOnly() {
+		return nil, nil
+	}
+
+	keypair, err := api.s.accountsDB.GetKeypairByKeyUID(acc.KeyUID)
+	if err != nil {
+		return nil, err
+	}
+	if !keypair.MigratedToKeycard() {
+		return nil, nil
+	}
+
+	return &account.SelectedExtKey{
+		Address:        acc.Address,
+		ExternalSigner: signer.NewKeycardSigner(api.s.keycardSignRequests.SignHash),
+	}, nil
+}
+
+// ConnectExternalSigner connects to the Clef compatible signer listening on the URL, e.g. the one handling a hardware
+// wallet. It signs for the wallet accounts it holds the keys of, which are returned, instead of the keystore.
+func (api *API) ConnectExternalSigner(ctx context.Context, url string) ([]common.Address, error) {
+	log.Debug("wallet.api.ConnectExternalSigner", "url", url)
+
+	externalSigner, err := signer.NewExternalSigner(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := externalSigner.Accounts(ctx)
+	if err != nil {
+		externalSigner.Close()
+		return nil, err
+	}
+
+	connected := make([]common.Address, 0, len(addresses))
+	for _, address := range addresses {
+		exists, err := api.s.accountsDB.AddressExists(types.Address(address))
+		if err != nil {
+			externalSigner.Close()
+			return nil, err
+		}
+		if exists {
+			api.s.gethManager.SetExternalSigner(types.Address(address), externalSigner)
+			connected = append(connected, address)
+		}
+	}
+	if len(connected) == 0 {
+		externalSigner.Close()
+	}
+	return connected, nil
+}
+
+// DisconnectExternalSigner makes the keystore sign for the account again
+func (api *API) DisconnectExternalSigner(ctx context.Context, address common.Address) error {
+	log.Debug("wallet.api.DisconnectExternalSigner", "address", address)
+	api.s.gethManager.RemoveExternalSigner(types.Address(address))
+	return nil
+}
+
+// SetKeycardSignRequestsEnabled is called by a client able to answer the `wallet.keycard.sign-request` signal, accounts
+// migrated to a Keycard are then signed for by the Keycard instead of verifying their password
+func (api *API) SetKeycardSignRequestsEnabled(ctx context.Context, enabled bool) error {
+	log.Debug("wallet.api.SetKeycardSignRequestsEnabled", "enabled", enabled)
+	api.s.keycardSignRequests.SetEnabled(enabled)
+	return nil
+}
+
+// SubmitKeycardSignature answers the `wallet.keycard.sign-request` signal with the signature of the hash made by the Keycard
+func (api *API) SubmitKeycardSignature(ctx context.Context, requestID string, signature types.HexBytes) error {
+	log.Debug("wallet.api.SubmitKeycardSignature", "requestID", requestID)
+	return api.s.keycardSignRequests.Submit(requestID, signature)
+}
+
+// RejectKeycardSignature answers the `wallet.keycard.sign-request` signal when the user cancels the signature
+func (api *API) RejectKeycardSignature(ctx context.Context, requestID string) error {
+	log.Debug("wallet.api.RejectKeycardSignature", "requestID", requestID)
+	return api.s.keycardSignRequests.Reject(requestID)
+}
+
 // AddWalletConnectSession adds or updates a session wallet connect session
 func (api *API) AddWalletConnectSession(ctx context.Context, session_json string) error {
 	log.Debug("wallet.api.AddWalletConnectSession", "rpcURL", len(session_json))
@@ -1121,9 +1916,7 @@ func (api *API) SignTypedDataV4(typedJson string, address string, password strin
 		return types.HexBytes{}, err
 	}
 
-	// This is not used down the line but required by the typeddata.SignTypedDataV4 function call
-	chain := new(big.Int).SetUint64(api.s.config.NetworkID)
-	sig, err := typeddata.SignTypedDataV4(typed, account.AccountKey.PrivateKey, chain)
+	sig, err := account.Signer().SignTypedData(context.Background(), common.Address(account.Address), typed)
 	if err != nil {
 		return types.HexBytes{}, err
 	}
@@ -1143,7 +1936,42 @@ func (api *API) SafeSignTypedDataForDApps(typedJson string, address string, pass
 		return types.HexBytes{}, err
 	}
 
-	return walletconnect.SafeSignTypedDataForDApps(typedJson, account.AccountKey.PrivateKey, chainID, legacy)
+	return walletconnect.SafeSignTypedDataForDAppsWithSigner(context.Background(), typedJson, account.Signer(), common.Address(account.Address), chainID, legacy)
+}
+
+// AnalyzeSIWEMessage returns the breakdown of an EIP-4361 sign-in message requested with "personal_sign" and the warnings
+// found checking it against the requesting dApp origin, its chain and the signing account, nil if the challenge is not
+// a sign-in message. The challenge is either hex encoded or plain text.
+func (api *API) AnalyzeSIWEMessage(ctx context.Context, challenge string, origin string, chainID uint64, address common.Address) (*siwe.Result, error) {
+	log.Debug("wallet.api.AnalyzeSIWEMessage", "len(challenge)", len(challenge), "origin", origin, "chainID", chainID, "address", address)
+	return siwe.Analyze(challenge, siwe.Expectations{
+		Origin:  origin,
+		ChainID: chainID,
+		Address: address,
+	})
+}
+
+// AnalyzeWalletConnectSIWEMessage is `AnalyzeSIWEMessage` for the requests received through a wallet connect session,
+// the origin is the URL of the session dApp
+func (api *API) AnalyzeWalletConnectSIWEMessage(ctx context.Context, topic walletconnect.Topic, challenge string, chainID uint64, address common.Address) (*siwe.Result, error) {
+	log.Debug("wallet.api.AnalyzeWalletConnectSIWEMessage", "topic", topic, "len(challenge)", len(challenge), "chainID", chainID, "address", address)
+
+	session, err := walletconnect.GetSessionByTopic(api.s.db, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.AnalyzeSIWEMessage(ctx, challenge, session.URL, chainID, address)
+}
+
+// VerifySIWESignature checks that an EIP-4361 sign-in message was signed by its sign-in address and returns its
+// breakdown with the warnings found checking it against the given origin and chain, the empty values are not checked
+func (api *API) VerifySIWESignature(ctx context.Context, challenge string, signature types.HexBytes, origin string, chainID uint64) (*siwe.Result, error) {
+	log.Debug("wallet.api.VerifySIWESignature", "len(challenge)", len(challenge), "origin", origin, "chainID", chainID)
+	return siwe.Verify(challenge, signature, siwe.Expectations{
+		Origin:  origin,
+		ChainID: chainID,
+	})
 }
 
 func (api *API) RestartWalletReloadTimer(ctx context.Context) error {
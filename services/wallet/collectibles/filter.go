@@ -12,6 +12,7 @@ import (
 	"github.com/status-im/status-go/protocol/communities/token"
 	"github.com/status-im/status-go/services/wallet/bigint"
 	wcommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/spam"
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 )
 
@@ -50,6 +51,9 @@ type Filter struct {
 	CommunityPrivilegesLevels []token.PrivilegesLevel          `json:"community_privileges_levels"`
 
 	FilterCommunity FilterCommunityType `json:"filter_community"`
+
+	// HideSpam hides the collectibles whose contract is classified as spam
+	HideSpam bool `json:"hide_spam"`
 }
 
 func filterOwnedCollectibles(ctx context.Context, db *sql.DB, chainIDs []wcommon.ChainID, addresses []common.Address, filter Filter, offset int, limit int) ([]thirdparty.CollectibleUniqueID, error) {
@@ -101,6 +105,12 @@ func filterOwnedCollectibles(ctx context.Context, db *sql.DB, chainIDs []wcommon
 		qConditions = append(qConditions, sq.Eq{"data.community_privileges_level": filter.CommunityPrivilegesLevels})
 	}
 
+	if filter.HideSpam {
+		qConditions = append(qConditions, sq.Expr(`NOT EXISTS (SELECT 1 FROM asset_spam_classifications spam
+			WHERE spam.chain_id = ownership.chain_id AND spam.address = ownership.contract_address AND spam.asset_type = ? AND `+spam.SpamCondition+`)`,
+			spam.AssetTypeCollectible))
+	}
+
 	q = q.Where(qConditions)
 
 	q = q.Limit(uint64(limit))
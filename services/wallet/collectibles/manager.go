@@ -64,7 +64,11 @@ type Manager struct {
 	communityManager   *community.Manager
 	ownershipDB        *OwnershipDB
 
-	mediaServer *server.MediaServer
+	mediaServer  *server.MediaServer
+	mediaDB      *CollectibleMediaDB
+	mediaFetcher *mediaFetcher
+	// Keys of the media being downloaded, to not download them twice
+	mediaFetching sync.Map
 
 	statuses       *sync.Map
 	statusNotifier *connection.StatusNotifier
@@ -108,6 +112,8 @@ func NewManager(
 		communityManager:   communityManager,
 		ownershipDB:        ownershipDB,
 		mediaServer:        mediaServer,
+		mediaDB:            NewCollectibleMediaDB(db),
+		mediaFetcher:       newMediaFetcher(),
 		statuses:           statuses,
 		statusNotifier:     statusNotifier,
 		feed:               feed,
@@ -206,6 +212,10 @@ func (o *Manager) FetchBalancesByOwnerAndContractAddress(ctx context.Context, ch
 				TokenID: fullData.CollectibleData.ID.TokenID,
 				Balance: &bigint.BigInt{Int: big.NewInt(1)},
 			}
+			// ERC1155 tokens can be owned more than once
+			if fullData.AccountBalance != nil && fullData.AccountBalance.Int != nil && fullData.AccountBalance.Sign() > 0 {
+				balance.Balance = fullData.AccountBalance
+			}
 			ret[contractAddress] = append(ret[contractAddress], balance)
 		}
 	} else {
@@ -721,6 +731,8 @@ func (o *Manager) processFullCollectibleData(ctx context.Context, assets []third
 		return nil, err
 	}
 
+	o.cacheMediaAsync(collectiblesData)
+
 	if len(missingCollectionIDs) > 0 {
 		// Calling this ensures collection data is fetched and cached (if not already available)
 		_, err := o.FetchCollectionsDataByContractID(ctx, missingCollectionIDs)
@@ -853,6 +865,14 @@ func (o *Manager) getCacheFullCollectibleData(uniqueIDs []thirdparty.Collectible
 		return nil, err
 	}
 
+	var mediaInfo map[string]map[MediaType]CollectibleMediaInfo
+	if o.mediaServer != nil {
+		mediaInfo, err = o.mediaDB.GetMediaInfo(uniqueIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	contractIDs := make([]thirdparty.ContractID, 0, len(uniqueIDs))
 	for _, id := range uniqueIDs {
 		contractIDs = append(contractIDs, id.ContractID)
@@ -873,6 +893,8 @@ func (o *Manager) getCacheFullCollectibleData(uniqueIDs []thirdparty.Collectible
 		}
 		if o.mediaServer != nil && len(collectibleData.ImagePayload) > 0 {
 			collectibleData.ImageURL = o.mediaServer.MakeWalletCollectibleImagesURL(collectibleData.ID)
+		} else if media, ok := mediaInfo[id.HashKey()]; ok {
+			o.setCachedMediaURLs(&collectibleData, media)
 		}
 
 		collectionData, ok := collectionsData[id.ContractID.HashKey()]
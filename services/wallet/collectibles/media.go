@@ -0,0 +1,301 @@
+package collectibles
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	gocommon "github.com/status-im/status-go/common"
+	"github.com/status-im/status-go/images"
+	"github.com/status-im/status-go/server"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+const (
+	mediaRequestTimeout = 30 * time.Second
+	maxImageSize        = 10 << 20
+	maxAnimationSize    = 30 << 20
+	// mediaCacheMaxSize is the size the cache is evicted to after new media is stored
+	mediaCacheMaxSize = 512 << 20
+	thumbnailDim      = images.LargeDim
+	// maxImagePixels bounds the memory used to decode an image into a thumbnail, a small payload can declare huge dimensions
+	maxImagePixels = 25_000_000
+)
+
+var (
+	ErrMediaTooLarge       = errors.New("media exceeds the size limit")
+	ErrUnsupportedMediaURL = errors.New("unsupported media URL")
+	ErrUnsupportedMedia    = errors.New("unsupported media type")
+	ErrImageTooLarge       = errors.New("image dimensions exceed the limit")
+	ErrMediaHostNotPublic  = errors.New("media host is not a public address")
+)
+
+// mediaFetcher downloads the collectibles media, refusing anything larger than the size limit
+type mediaFetcher struct {
+	httpClient *http.Client
+}
+
+// newMediaFetcher returns a fetcher connecting to public addresses only. The media URLs are set by the NFT creators,
+// they must not reach the local network of the user. The addresses are checked when dialing, which covers the redirects.
+func newMediaFetcher() *mediaFetcher {
+	dialer := &net.Dialer{
+		Timeout: mediaRequestTimeout,
+		Control: checkMediaHostAddress,
+	}
+	return &mediaFetcher{
+		httpClient: &http.Client{
+			Timeout: mediaRequestTimeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: mediaRequestTimeout,
+			},
+		},
+	}
+}
+
+// checkMediaHostAddress rejects the loopback, private, link-local and unspecified addresses, it's called with the
+// resolved address of each connection
+func checkMediaHostAddress(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return ErrMediaHostNotPublic
+	}
+	return nil
+}
+
+func isFetchableMediaURL(mediaURL string) bool {
+	u, err := url.Parse(mediaURL)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
+func maxMediaSize(mediaType MediaType) int64 {
+	if mediaType == MediaTypeAnimation {
+		return maxAnimationSize
+	}
+	return maxImageSize
+}
+
+// fetch returns the content of the URL and its mime type
+func (f *mediaFetcher) fetch(ctx context.Context, mediaURL string, maxSize int64) ([]byte, string, error) {
+	if !isFetchableMediaURL(mediaURL) {
+		return nil, "", ErrUnsupportedMediaURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, mediaURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status code %d fetching media", resp.StatusCode)
+	}
+	if resp.ContentLength > maxSize {
+		return nil, "", ErrMediaTooLarge
+	}
+
+	// Read one byte more than allowed to detect the responses without Content-Length exceeding the limit
+	payload, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(payload)) > maxSize {
+		return nil, "", ErrMediaTooLarge
+	}
+	if len(payload) == 0 {
+		return nil, "", errors.New("empty media")
+	}
+
+	mimeType := detectMimeType(payload)
+	if mimeType == "" {
+		return nil, "", ErrUnsupportedMedia
+	}
+	return payload, mimeType, nil
+}
+
+// detectMimeType sniffs the content type, it returns an empty string if the media can't be served.
+// The type declared by the host isn't trusted, as the media is served from the local media server origin
+func detectMimeType(payload []byte) string {
+	detected := http.DetectContentType(payload)
+	if !server.IsServableMediaType(detected) {
+		return ""
+	}
+	return detected
+}
+
+// generateThumbnail returns a thumbnail of raster images, nil for any other media.
+// JPEG images are kept as JPEG, the others are encoded as PNG to preserve the transparency
+func generateThumbnail(payload []byte) ([]byte, error) {
+	imageType := images.GetType(payload)
+	if imageType == images.UNKNOWN || imageType == images.ICO {
+		return nil, nil
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	if int64(config.Width)*int64(config.Height) > maxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, err := images.DecodeImageData(payload, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	thumbnail := images.ShrinkOnly(thumbnailDim, img)
+
+	buf := &bytes.Buffer{}
+	if imageType == images.JPEG {
+		err = images.Encode(buf, thumbnail, images.EncodeConfig{Quality: images.MaxJpegQuality})
+	} else {
+		err = png.Encode(buf, thumbnail)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type pendingMedia struct {
+	id        thirdparty.CollectibleUniqueID
+	mediaType MediaType
+	url       string
+}
+
+func mediaFetchingKey(id thirdparty.CollectibleUniqueID, mediaType MediaType) string {
+	return id.HashKey() + "+" + string(mediaType)
+}
+
+// cacheMediaAsync downloads the media of the collectibles not cached yet, or whose URL changed.
+// An EventCollectiblesDataUpdated is sent for the collectibles whose media was stored, so the client
+// replaces the remote URLs by the media server ones
+func (o *Manager) cacheMediaAsync(collectibles []thirdparty.CollectibleData) {
+	// The media is only useful if it can be served
+	if o.mediaServer == nil || len(collectibles) == 0 {
+		return
+	}
+
+	ids := make([]thirdparty.CollectibleUniqueID, 0, len(collectibles))
+	for _, c := range collectibles {
+		ids = append(ids, c.ID)
+	}
+	cached, err := o.mediaDB.GetMediaInfo(ids)
+	if err != nil {
+		log.Error("GetMediaInfo failed", "err", err)
+		return
+	}
+
+	pending := make([]pendingMedia, 0)
+	for _, c := range collectibles {
+		// Community collectibles images are already stored locally
+		if len(c.ImagePayload) > 0 {
+			continue
+		}
+		for mediaType, mediaURL := range map[MediaType]string{MediaTypeImage: c.ImageURL, MediaTypeAnimation: c.AnimationURL} {
+			if !isFetchableMediaURL(mediaURL) {
+				continue
+			}
+			if info, ok := cached[c.ID.HashKey()][mediaType]; ok && info.SourceURL == mediaURL {
+				continue
+			}
+			if _, fetching := o.mediaFetching.LoadOrStore(mediaFetchingKey(c.ID, mediaType), true); fetching {
+				continue
+			}
+			pending = append(pending, pendingMedia{id: c.ID, mediaType: mediaType, url: mediaURL})
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	go func() {
+		defer gocommon.LogOnPanic()
+
+		updated := make(map[string]thirdparty.CollectibleUniqueID)
+		for _, m := range pending {
+			err := o.cacheMedia(context.Background(), m)
+			o.mediaFetching.Delete(mediaFetchingKey(m.id, m.mediaType))
+			if err != nil {
+				log.Debug("collectible media not cached", "id", m.id.HashKey(), "mediaType", m.mediaType, "err", err)
+				continue
+			}
+			updated[m.id.HashKey()] = m.id
+		}
+
+		evicted, err := o.mediaDB.EvictToSize(mediaCacheMaxSize)
+		if err != nil {
+			log.Error("collectible media eviction failed", "err", err)
+		} else if evicted > 0 {
+			log.Debug("collectible media evicted", "count", evicted)
+		}
+
+		if len(updated) > 0 {
+			o.signalUpdatedCollectiblesData(mapToList(updated))
+		}
+	}()
+}
+
+func (o *Manager) cacheMedia(ctx context.Context, m pendingMedia) error {
+	payload, mimeType, err := o.mediaFetcher.fetch(ctx, m.url, maxMediaSize(m.mediaType))
+	if err != nil {
+		return err
+	}
+
+	var thumbnail []byte
+	if m.mediaType == MediaTypeImage {
+		thumbnail, err = generateThumbnail(payload)
+		if err != nil {
+			// The original is still served
+			log.Debug("collectible thumbnail not generated", "id", m.id.HashKey(), "err", err)
+		}
+	}
+
+	return o.mediaDB.SetMedia(CollectibleMedia{
+		ID:        m.id,
+		MediaType: m.mediaType,
+		SourceURL: m.url,
+		MimeType:  mimeType,
+		Payload:   payload,
+		Thumbnail: thumbnail,
+	})
+}
+
+// setCachedMediaURLs replaces the remote media URLs by the media server ones when the media is cached
+func (o *Manager) setCachedMediaURLs(c *thirdparty.CollectibleData, media map[MediaType]CollectibleMediaInfo) {
+	if info, ok := media[MediaTypeImage]; ok && info.SourceURL == c.ImageURL {
+		c.ImageURL = o.mediaServer.MakeWalletCollectibleMediaURL(c.ID, string(MediaTypeImage), false)
+		if info.HasThumbnail {
+			c.ThumbnailURL = o.mediaServer.MakeWalletCollectibleMediaURL(c.ID, string(MediaTypeImage), true)
+		}
+	}
+	if info, ok := media[MediaTypeAnimation]; ok && info.SourceURL == c.AnimationURL {
+		c.AnimationURL = o.mediaServer.MakeWalletCollectibleMediaURL(c.ID, string(MediaTypeAnimation), false)
+		if c.AnimationMediaType == "" {
+			c.AnimationMediaType = info.MimeType
+		}
+	}
+}
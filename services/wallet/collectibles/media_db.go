@@ -0,0 +1,176 @@
+package collectibles
+
+import (
+	"database/sql"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+	w_common "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+type MediaType string
+
+const (
+	MediaTypeImage     MediaType = "image"
+	MediaTypeAnimation MediaType = "animation"
+)
+
+// CollectibleMedia is a collectible image or animation downloaded from its host
+type CollectibleMedia struct {
+	ID        thirdparty.CollectibleUniqueID
+	MediaType MediaType
+	SourceURL string
+	MimeType  string
+	Payload   []byte
+	// Thumbnail is only generated for raster images
+	Thumbnail []byte
+}
+
+// CollectibleMediaInfo describes a cached media without its content
+type CollectibleMediaInfo struct {
+	SourceURL    string
+	MimeType     string
+	HasThumbnail bool
+}
+
+type CollectibleMediaDB struct {
+	db *sql.DB
+}
+
+func NewCollectibleMediaDB(sqlDb *sql.DB) *CollectibleMediaDB {
+	return &CollectibleMediaDB{
+		db: sqlDb,
+	}
+}
+
+func (o *CollectibleMediaDB) SetMedia(media CollectibleMedia) error {
+	now := time.Now().Unix()
+	_, err := o.db.Exec(`INSERT OR REPLACE INTO collectible_media_cache
+		(chain_id, contract_address, token_id, media_type, source_url, mime_type, payload, thumbnail, size, fetched_at, last_accessed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		media.ID.ContractID.ChainID,
+		media.ID.ContractID.Address,
+		(*bigint.SQLBigIntBytes)(media.ID.TokenID.Int),
+		media.MediaType,
+		media.SourceURL,
+		media.MimeType,
+		media.Payload,
+		media.Thumbnail,
+		len(media.Payload)+len(media.Thumbnail),
+		now,
+		now,
+	)
+	return err
+}
+
+// GetMediaInfo returns the media cached for each collectible, keyed by the collectible HashKey
+func (o *CollectibleMediaDB) GetMediaInfo(ids []thirdparty.CollectibleUniqueID) (map[string]map[MediaType]CollectibleMediaInfo, error) {
+	ret := make(map[string]map[MediaType]CollectibleMediaInfo)
+
+	stmt, err := o.db.Prepare(`SELECT media_type, source_url, mime_type, thumbnail IS NOT NULL
+		FROM collectible_media_cache
+		WHERE chain_id = ? AND contract_address = ? AND token_id = ?`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	for _, id := range ids {
+		err := func() error {
+			rows, err := stmt.Query(
+				id.ContractID.ChainID,
+				id.ContractID.Address,
+				(*bigint.SQLBigIntBytes)(id.TokenID.Int),
+			)
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+
+			for rows.Next() {
+				var mediaType MediaType
+				var info CollectibleMediaInfo
+				err := rows.Scan(&mediaType, &info.SourceURL, &info.MimeType, &info.HasThumbnail)
+				if err != nil {
+					return err
+				}
+				if _, ok := ret[id.HashKey()]; !ok {
+					ret[id.HashKey()] = make(map[MediaType]CollectibleMediaInfo)
+				}
+				ret[id.HashKey()][mediaType] = info
+			}
+			return rows.Err()
+		}()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+// EvictToSize removes the least recently accessed media until the cache is not larger than maxSize bytes.
+// Returns the number of media removed
+func (o *CollectibleMediaDB) EvictToSize(maxSize int64) (evicted int, err error) {
+	tx, err := o.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	rows, err := tx.Query(`SELECT chain_id, contract_address, token_id, media_type, size
+		FROM collectible_media_cache
+		ORDER BY last_accessed_at DESC, fetched_at DESC`)
+	if err != nil {
+		return 0, err
+	}
+
+	type mediaKey struct {
+		chainID         w_common.ChainID
+		contractAddress common.Address
+		tokenID         *big.Int
+		mediaType       MediaType
+	}
+
+	toEvict := make([]mediaKey, 0)
+	var total int64
+	for rows.Next() {
+		var key mediaKey
+		var size int64
+		key.tokenID = new(big.Int)
+		err = rows.Scan(&key.chainID, &key.contractAddress, (*bigint.SQLBigIntBytes)(key.tokenID), &key.mediaType, &size)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		total += size
+		if total > maxSize {
+			toEvict = append(toEvict, key)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		rows.Close()
+		return 0, err
+	}
+	rows.Close()
+
+	for _, key := range toEvict {
+		_, err = tx.Exec(`DELETE FROM collectible_media_cache WHERE chain_id = ? AND contract_address = ? AND token_id = ? AND media_type = ?`,
+			key.chainID, key.contractAddress, (*bigint.SQLBigIntBytes)(key.tokenID), key.mediaType)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(toEvict), nil
+}
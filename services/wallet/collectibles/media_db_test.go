@@ -0,0 +1,98 @@
+package collectibles
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupCollectibleMediaDBTest(t *testing.T) (*CollectibleMediaDB, func()) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	return NewCollectibleMediaDB(db), func() {
+		require.NoError(t, db.Close())
+	}
+}
+
+func testCollectibleID(tokenID int64) thirdparty.CollectibleUniqueID {
+	return thirdparty.CollectibleUniqueID{
+		ContractID: thirdparty.ContractID{
+			ChainID: 1,
+			Address: common.HexToAddress("0x1234"),
+		},
+		TokenID: &bigint.BigInt{Int: big.NewInt(tokenID)},
+	}
+}
+
+func TestCollectibleMediaDB_SetAndGetMedia(t *testing.T) {
+	db, cleanDB := setupCollectibleMediaDBTest(t)
+	defer cleanDB()
+
+	id := testCollectibleID(1)
+	err := db.SetMedia(CollectibleMedia{
+		ID:        id,
+		MediaType: MediaTypeImage,
+		SourceURL: "https://example.com/1.png",
+		MimeType:  "image/png",
+		Payload:   []byte{0x1, 0x2},
+		Thumbnail: []byte{0x1},
+	})
+	require.NoError(t, err)
+	err = db.SetMedia(CollectibleMedia{
+		ID:        id,
+		MediaType: MediaTypeAnimation,
+		SourceURL: "https://example.com/1.mp4",
+		MimeType:  "video/mp4",
+		Payload:   []byte{0x1, 0x2, 0x3},
+	})
+	require.NoError(t, err)
+
+	info, err := db.GetMediaInfo([]thirdparty.CollectibleUniqueID{id, testCollectibleID(2)})
+	require.NoError(t, err)
+	require.Len(t, info, 1)
+	require.Equal(t, map[MediaType]CollectibleMediaInfo{
+		MediaTypeImage:     {SourceURL: "https://example.com/1.png", MimeType: "image/png", HasThumbnail: true},
+		MediaTypeAnimation: {SourceURL: "https://example.com/1.mp4", MimeType: "video/mp4", HasThumbnail: false},
+	}, info[id.HashKey()])
+}
+
+func TestCollectibleMediaDB_EvictToSize(t *testing.T) {
+	db, cleanDB := setupCollectibleMediaDBTest(t)
+	defer cleanDB()
+
+	ids := []thirdparty.CollectibleUniqueID{testCollectibleID(1), testCollectibleID(2), testCollectibleID(3)}
+	for i, id := range ids {
+		err := db.SetMedia(CollectibleMedia{
+			ID:        id,
+			MediaType: MediaTypeImage,
+			SourceURL: "https://example.com/image.png",
+			MimeType:  "image/png",
+			Payload:   make([]byte, 100),
+		})
+		require.NoError(t, err)
+		// Most recently accessed last
+		_, err = db.db.Exec(`UPDATE collectible_media_cache SET last_accessed_at = ? WHERE token_id = ?`, i, (*bigint.SQLBigIntBytes)(id.TokenID.Int))
+		require.NoError(t, err)
+	}
+
+	evicted, err := db.EvictToSize(300)
+	require.NoError(t, err)
+	require.Equal(t, 0, evicted)
+
+	evicted, err = db.EvictToSize(250)
+	require.NoError(t, err)
+	require.Equal(t, 1, evicted)
+
+	info, err := db.GetMediaInfo(ids)
+	require.NoError(t, err)
+	require.Len(t, info, 2)
+	require.NotContains(t, info, ids[0].HashKey())
+}
@@ -0,0 +1,134 @@
+package collectibles
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/status-go/images"
+)
+
+func generateTestPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	buf := &bytes.Buffer{}
+	require.NoError(t, png.Encode(buf, img))
+	return buf.Bytes()
+}
+
+func TestMediaFetcher_Fetch(t *testing.T) {
+	payload := generateTestPNG(t, 16, 16)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image":
+			// The declared type is wrong, the content is sniffed
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(payload)
+		case "/video":
+			w.Header().Set("Content-Type", "video/x-custom; codecs=avc1")
+			_, _ = w.Write([]byte("\x1A\x45\xDF\xA3 webm"))
+		case "/html":
+			// The declared type is not trusted
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte("<html><script>alert(1)</script></html>"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// the test server is on the loopback address, refused by the fetcher of the manager
+	fetcher := &mediaFetcher{httpClient: &http.Client{Timeout: mediaRequestTimeout}}
+
+	data, mimeType, err := fetcher.fetch(context.Background(), server.URL+"/image", maxImageSize)
+	require.NoError(t, err)
+	require.Equal(t, payload, data)
+	require.Equal(t, "image/png", mimeType)
+
+	_, mimeType, err = fetcher.fetch(context.Background(), server.URL+"/video", maxAnimationSize)
+	require.NoError(t, err)
+	require.Equal(t, "video/webm", mimeType)
+
+	_, _, err = fetcher.fetch(context.Background(), server.URL+"/html", maxImageSize)
+	require.ErrorIs(t, err, ErrUnsupportedMedia)
+
+	_, _, err = fetcher.fetch(context.Background(), server.URL+"/image", int64(len(payload)-1))
+	require.ErrorIs(t, err, ErrMediaTooLarge)
+
+	_, _, err = fetcher.fetch(context.Background(), server.URL+"/missing", maxImageSize)
+	require.Error(t, err)
+
+	_, _, err = fetcher.fetch(context.Background(), "ipfs://QmHash", maxImageSize)
+	require.ErrorIs(t, err, ErrUnsupportedMediaURL)
+}
+
+func TestDetectMimeType(t *testing.T) {
+	svg := []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"><rect width="10" height="10"/></svg>`)
+	require.Equal(t, "", detectMimeType(svg))
+	require.Equal(t, "", detectMimeType([]byte("<html><script>alert(1)</script></html>")))
+	require.Equal(t, "image/gif", detectMimeType([]byte("GIF89a....")))
+	require.Equal(t, "", detectMimeType([]byte{0x1, 0x2}))
+}
+
+func TestGenerateThumbnail(t *testing.T) {
+	thumbnail, err := generateThumbnail(generateTestPNG(t, 600, 600))
+	require.NoError(t, err)
+	require.Equal(t, images.PNG, images.GetType(thumbnail))
+	width, height, err := images.GetImageDimensions(thumbnail)
+	require.NoError(t, err)
+	require.Equal(t, int(thumbnailDim), width)
+	require.Equal(t, int(thumbnailDim), height)
+
+	// Small images are not enlarged
+	thumbnail, err = generateThumbnail(generateTestPNG(t, 32, 32))
+	require.NoError(t, err)
+	width, _, err = images.GetImageDimensions(thumbnail)
+	require.NoError(t, err)
+	require.Equal(t, 32, width)
+
+	thumbnail, err = generateThumbnail([]byte(`<svg></svg>`))
+	require.NoError(t, err)
+	require.Nil(t, thumbnail)
+}
+
+func TestMediaFetcher_RejectsLocalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(generateTestPNG(t, 16, 16))
+	}))
+	defer server.Close()
+
+	_, _, err := newMediaFetcher().fetch(context.Background(), server.URL+"/image", maxImageSize)
+	require.ErrorIs(t, err, ErrMediaHostNotPublic)
+
+	for _, address := range []string{"127.0.0.1:80", "[::1]:80", "10.0.0.1:80", "192.168.1.1:443", "169.254.169.254:80", "[fe80::1]:80", "0.0.0.0:80"} {
+		require.ErrorIs(t, checkMediaHostAddress("tcp", address, nil), ErrMediaHostNotPublic, address)
+	}
+	require.NoError(t, checkMediaHostAddress("tcp", "93.184.215.14:443", nil))
+	require.NoError(t, checkMediaHostAddress("tcp6", "[2606:2800:21f:cb07:6820:80da:af6b:8b2c]:443", nil))
+}
+
+func TestGenerateThumbnail_RejectsLargeDimensions(t *testing.T) {
+	// a PNG header declaring a 10000x10000 image is enough, the pixels are never decoded
+	payload := generateTestPNG(t, 1, 1)
+	binary.BigEndian.PutUint32(payload[16:20], 10000)
+	binary.BigEndian.PutUint32(payload[20:24], 10000)
+	// checksum of the IHDR chunk type and data
+	binary.BigEndian.PutUint32(payload[29:33], crc32.ChecksumIEEE(payload[12:29]))
+
+	_, err := generateThumbnail(payload)
+	require.ErrorIs(t, err, ErrImageTooLarge)
+}
@@ -25,6 +25,8 @@ const (
 	OptimismSepolia    uint64 = 11155420
 	ArbitrumMainnet    uint64 = 42161
 	ArbitrumSepolia    uint64 = 421614
+	BaseMainnet        uint64 = 8453
+	BaseSepolia        uint64 = 84532
 	BinanceChainID     uint64 = 56 // obsolete?
 	BinanceTestChainID uint64 = 97 // obsolete?
 	AnvilMainnet       uint64 = 31337
@@ -0,0 +1,143 @@
+package feemonitor
+
+import (
+	"database/sql"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+)
+
+type Metric int
+
+const (
+	MetricBaseFee     Metric = iota // base fee of the next block
+	MetricPriorityFee               // median priority fee of the latest block
+)
+
+type Condition int
+
+const (
+	ConditionBelow Condition = iota
+	ConditionAbove
+)
+
+// Alert notifies the client when a fee of a chain goes below or above the threshold. A non repeating alert is triggered
+// once, a repeating alert is triggered again after the condition stopped being met.
+type Alert struct {
+	ID          int64        `json:"id"`
+	ChainID     uint64       `json:"chainId"`
+	Metric      Metric       `json:"metric"`
+	Condition   Condition    `json:"condition"`
+	Threshold   *hexutil.Big `json:"threshold"` // in wei
+	Repeat      bool         `json:"repeat"`
+	Triggered   bool         `json:"triggered"`
+	TriggeredAt int64        `json:"triggeredAt"`
+	CreatedAt   int64        `json:"createdAt"`
+}
+
+func (a *Alert) validate() error {
+	if a.Metric != MetricBaseFee && a.Metric != MetricPriorityFee {
+		return ErrInvalidMetric
+	}
+	if a.Condition != ConditionBelow && a.Condition != ConditionAbove {
+		return ErrInvalidCondition
+	}
+	if a.Threshold == nil || a.Threshold.ToInt().Sign() <= 0 {
+		return ErrInvalidThreshold
+	}
+	return nil
+}
+
+// value returns the value of the alert metric, nil if the stats don't have it
+func (a *Alert) value(stats *FeeStats) *big.Int {
+	switch a.Metric {
+	case MetricBaseFee:
+		if stats.NextBaseFee != nil {
+			return stats.NextBaseFee.ToInt()
+		}
+		return stats.BaseFee.ToInt()
+	case MetricPriorityFee:
+		return stats.PriorityFee.ToInt()
+	}
+	return nil
+}
+
+func (a *Alert) conditionMet(value *big.Int) bool {
+	if a.Condition == ConditionBelow {
+		return value.Cmp(a.Threshold.ToInt()) < 0
+	}
+	return value.Cmp(a.Threshold.ToInt()) > 0
+}
+
+type Persistence struct {
+	db *sql.DB
+}
+
+func NewPersistence(db *sql.DB) *Persistence {
+	return &Persistence{
+		db: db,
+	}
+}
+
+func (p *Persistence) InsertAlert(a *Alert) (int64, error) {
+	res, err := p.db.Exec(`INSERT INTO fee_alerts (chain_id, metric, condition, threshold, repeat, triggered, triggered_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, a.ChainID, a.Metric, a.Condition, (*bigint.SQLBigIntBytes)(a.Threshold.ToInt()), a.Repeat,
+		a.Triggered, a.TriggeredAt, a.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (p *Persistence) UpdateAlertTriggered(a *Alert) error {
+	_, err := p.db.Exec(`UPDATE fee_alerts SET triggered = ?, triggered_at = ? WHERE id = ?`, a.Triggered, a.TriggeredAt, a.ID)
+	return err
+}
+
+func (p *Persistence) DeleteAlert(id int64) error {
+	res, err := p.db.Exec(`DELETE FROM fee_alerts WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAlertNotFound
+	}
+	return nil
+}
+
+// GetAlerts returns the alerts of the chain, all alerts if chainID is 0
+func (p *Persistence) GetAlerts(chainID uint64) ([]*Alert, error) {
+	query := `SELECT id, chain_id, metric, condition, threshold, repeat, triggered, triggered_at, created_at FROM fee_alerts`
+	args := []interface{}{}
+	if chainID != 0 {
+		query += ` WHERE chain_id = ?`
+		args = append(args, chainID)
+	}
+	query += ` ORDER BY id`
+
+	rows, err := p.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	alerts := make([]*Alert, 0)
+	for rows.Next() {
+		a := &Alert{}
+		threshold := new(big.Int)
+		err = rows.Scan(&a.ID, &a.ChainID, &a.Metric, &a.Condition, (*bigint.SQLBigIntBytes)(threshold), &a.Repeat, &a.Triggered,
+			&a.TriggeredAt, &a.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		a.Threshold = (*hexutil.Big)(threshold)
+		alerts = append(alerts, a)
+	}
+	return alerts, rows.Err()
+}
@@ -0,0 +1,136 @@
+package feemonitor
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/event"
+
+	"github.com/status-im/status-go/services/wallet/walletevent"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestMonitor(t *testing.T) (*Monitor, chan walletevent.Event, func()) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+
+	feed := &event.Feed{}
+	ch := make(chan walletevent.Event, 10)
+	sub := feed.Subscribe(ch)
+
+	return NewMonitor(db, nil, feed), ch, func() {
+		sub.Unsubscribe()
+		require.NoError(t, db.Close())
+	}
+}
+
+func TestAlertsPersistence(t *testing.T) {
+	m, _, cleanup := setupTestMonitor(t)
+	defer cleanup()
+
+	id, err := m.persistence.InsertAlert(&Alert{ChainID: 1, Metric: MetricBaseFee, Condition: ConditionBelow, Threshold: (*hexutil.Big)(big.NewInt(10_000_000_000))})
+	require.NoError(t, err)
+	_, err = m.persistence.InsertAlert(&Alert{ChainID: 10, Metric: MetricPriorityFee, Condition: ConditionAbove, Threshold: (*hexutil.Big)(big.NewInt(1)), Repeat: true})
+	require.NoError(t, err)
+
+	alerts, err := m.GetAlerts(0)
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+
+	alerts, err = m.GetAlerts(1)
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	require.Equal(t, id, alerts[0].ID)
+	require.Equal(t, big.NewInt(10_000_000_000), alerts[0].Threshold.ToInt())
+
+	require.NoError(t, m.DeleteAlert(id))
+	require.ErrorIs(t, m.DeleteAlert(id), ErrAlertNotFound)
+}
+
+func TestAlertValidation(t *testing.T) {
+	require.ErrorIs(t, (&Alert{Metric: Metric(5), Threshold: (*hexutil.Big)(big.NewInt(1))}).validate(), ErrInvalidMetric)
+	require.ErrorIs(t, (&Alert{Condition: Condition(5), Threshold: (*hexutil.Big)(big.NewInt(1))}).validate(), ErrInvalidCondition)
+	require.ErrorIs(t, (&Alert{}).validate(), ErrInvalidThreshold)
+	require.NoError(t, (&Alert{Threshold: (*hexutil.Big)(big.NewInt(1))}).validate())
+}
+
+func TestCheckAlerts(t *testing.T) {
+	m, ch, cleanup := setupTestMonitor(t)
+	defer cleanup()
+
+	onceID, err := m.persistence.InsertAlert(&Alert{ChainID: 1, Metric: MetricBaseFee, Condition: ConditionBelow, Threshold: (*hexutil.Big)(big.NewInt(100))})
+	require.NoError(t, err)
+	repeatID, err := m.persistence.InsertAlert(&Alert{ChainID: 1, Metric: MetricBaseFee, Condition: ConditionBelow, Threshold: (*hexutil.Big)(big.NewInt(100)), Repeat: true})
+	require.NoError(t, err)
+
+	statsWithBaseFee := func(fee int64) *FeeStats {
+		h := newChainHistory(historySize)
+		h.add(testBlocks(1, fee), big.NewInt(fee))
+		return h.stats(1)
+	}
+
+	m.checkAlerts(1, statsWithBaseFee(200))
+	require.Len(t, ch, 0)
+
+	m.checkAlerts(1, statsWithBaseFee(50))
+	require.Len(t, ch, 2)
+	for _, id := range []int64{onceID, repeatID} {
+		triggered, err := walletevent.GetPayload[AlertTriggered](<-ch)
+		require.NoError(t, err)
+		require.Equal(t, id, triggered.Alert.ID)
+		require.Equal(t, big.NewInt(50), triggered.Value.ToInt())
+	}
+
+	// triggered alerts are not sent again while the condition is met
+	m.checkAlerts(1, statsWithBaseFee(40))
+	require.Len(t, ch, 0)
+
+	// only the repeating alert is rearmed
+	m.checkAlerts(1, statsWithBaseFee(200))
+	m.checkAlerts(1, statsWithBaseFee(50))
+	require.Len(t, ch, 1)
+	event := <-ch
+	require.Equal(t, EventFeeAlertTriggered, event.Type)
+	triggered, err := walletevent.GetPayload[AlertTriggered](event)
+	require.NoError(t, err)
+	require.Equal(t, repeatID, triggered.Alert.ID)
+
+	alerts, err := m.GetAlerts(1)
+	require.NoError(t, err)
+	require.True(t, alerts[0].Triggered)
+	require.True(t, alerts[1].Triggered)
+}
+
+func TestAlertChains(t *testing.T) {
+	m, _, cleanup := setupTestMonitor(t)
+	defer cleanup()
+
+	_, err := m.persistence.InsertAlert(&Alert{ChainID: 1, Metric: MetricBaseFee, Condition: ConditionBelow, Threshold: (*hexutil.Big)(big.NewInt(100))})
+	require.NoError(t, err)
+	_, err = m.persistence.InsertAlert(&Alert{ChainID: 10, Metric: MetricBaseFee, Condition: ConditionBelow, Threshold: (*hexutil.Big)(big.NewInt(100)), Triggered: true})
+	require.NoError(t, err)
+	_, err = m.persistence.InsertAlert(&Alert{ChainID: 42161, Metric: MetricBaseFee, Condition: ConditionBelow, Threshold: (*hexutil.Big)(big.NewInt(100)), Repeat: true, Triggered: true})
+	require.NoError(t, err)
+
+	// the alerts triggered once don't need the chain to be polled anymore
+	chainIDs, err := m.alertChains()
+	require.NoError(t, err)
+	require.Equal(t, map[uint64]bool{1: true, 42161: true}, chainIDs)
+}
+
+func TestPollingStopsWithoutAlerts(t *testing.T) {
+	m, _, cleanup := setupTestMonitor(t)
+	defer cleanup()
+
+	m.polling = true
+	m.histories[1] = newChainHistory(historySize)
+
+	require.False(t, m.update(context.Background()))
+	require.False(t, m.polling)
+	require.Empty(t, m.histories)
+}
@@ -0,0 +1,15 @@
+package feemonitor
+
+import (
+	"github.com/status-im/status-go/errors"
+)
+
+// Abbreviation `WFM` for the error code stands for Wallet Fee Monitor
+var (
+	ErrAlertNotFound     = &errors.ErrorResponse{Code: errors.ErrorCode("WFM-001"), Details: "fee alert not found"}
+	ErrInvalidMetric     = &errors.ErrorResponse{Code: errors.ErrorCode("WFM-002"), Details: "invalid fee alert metric"}
+	ErrInvalidCondition  = &errors.ErrorResponse{Code: errors.ErrorCode("WFM-003"), Details: "invalid fee alert condition"}
+	ErrInvalidThreshold  = &errors.ErrorResponse{Code: errors.ErrorCode("WFM-004"), Details: "fee alert threshold must be positive"}
+	ErrNoFeeHistory      = &errors.ErrorResponse{Code: errors.ErrorCode("WFM-005"), Details: "no fee history for the chain"}
+	ErrChainNotMonitored = &errors.ErrorResponse{Code: errors.ErrorCode("WFM-006"), Details: "chain is not enabled"}
+)
@@ -0,0 +1,252 @@
+package feemonitor
+
+import (
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/status-im/status-go/services/wallet/router/fees"
+)
+
+const (
+	// EIP-1559 limits the base fee change between two consecutive blocks to 12.5%
+	maxBaseFeeChangeDenominator = 8
+	// number of the latest blocks used to calculate the base fee trend
+	trendBlocks = 20
+)
+
+var (
+	// percentiles of the priority fees requested for each block
+	rewardPercentiles = []float64{10, 50, 90}
+	// percentiles of the fees over the history exposed to the client
+	statsPercentiles = []int{10, 25, 50, 75, 90}
+	// forecast horizons, in blocks
+	forecastHorizons = []int{1, 5, 10, 25}
+)
+
+// BlockFees are the fees paid in a block
+type BlockFees struct {
+	Number       uint64
+	BaseFee      *big.Int
+	GasUsedRatio float64
+	PriorityFees []*big.Int // priority fees paid at `rewardPercentiles`
+}
+
+// chainHistory keeps the fees of the latest blocks of a chain, ordered by block number
+type chainHistory struct {
+	blocks      []*BlockFees
+	nextBaseFee *big.Int
+	size        int
+}
+
+func newChainHistory(size int) *chainHistory {
+	return &chainHistory{
+		size: size,
+	}
+}
+
+func parseBig(hex string) *big.Int {
+	value, err := hexutil.DecodeBig(hex)
+	if err != nil {
+		return big.NewInt(0)
+	}
+	return value
+}
+
+// blocksFromFeeHistory converts the `eth_feeHistory` response, the base fee of the block after the newest block is returned
+// separately since the block isn't mined yet
+func blocksFromFeeHistory(history *fees.FeeHistory) ([]*BlockFees, *big.Int, error) {
+	oldestBlock, err := hexutil.DecodeUint64(history.OldestBlock)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blocks := make([]*BlockFees, 0, len(history.GasUsedRatio))
+	for i, ratio := range history.GasUsedRatio {
+		if i >= len(history.BaseFeePerGas) {
+			break
+		}
+		block := &BlockFees{
+			Number:       oldestBlock + uint64(i),
+			BaseFee:      parseBig(history.BaseFeePerGas[i]),
+			GasUsedRatio: ratio,
+		}
+		if i < len(history.Reward) {
+			for _, reward := range history.Reward[i] {
+				block.PriorityFees = append(block.PriorityFees, parseBig(reward))
+			}
+		}
+		blocks = append(blocks, block)
+	}
+
+	var nextBaseFee *big.Int
+	if len(history.BaseFeePerGas) > len(blocks) {
+		nextBaseFee = parseBig(history.BaseFeePerGas[len(blocks)])
+	}
+	return blocks, nextBaseFee, nil
+}
+
+// add merges the blocks into the history, blocks already in the history are replaced in case of a reorg
+func (h *chainHistory) add(blocks []*BlockFees, nextBaseFee *big.Int) {
+	byNumber := make(map[uint64]*BlockFees, len(h.blocks)+len(blocks))
+	for _, block := range h.blocks {
+		byNumber[block.Number] = block
+	}
+	for _, block := range blocks {
+		byNumber[block.Number] = block
+	}
+
+	merged := make([]*BlockFees, 0, len(byNumber))
+	for _, block := range byNumber {
+		merged = append(merged, block)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Number < merged[j].Number })
+	if len(merged) > h.size {
+		merged = merged[len(merged)-h.size:]
+	}
+
+	h.blocks = merged
+	if nextBaseFee != nil {
+		h.nextBaseFee = nextBaseFee
+	}
+}
+
+func (h *chainHistory) latestBlock() *BlockFees {
+	if len(h.blocks) == 0 {
+		return nil
+	}
+	return h.blocks[len(h.blocks)-1]
+}
+
+// Forecast is the expected base fee after a number of blocks. The min and max values are the limits set by EIP-1559.
+type Forecast struct {
+	Blocks     int          `json:"blocks"`
+	BaseFee    *hexutil.Big `json:"baseFee"`
+	MinBaseFee *hexutil.Big `json:"minBaseFee"`
+	MaxBaseFee *hexutil.Big `json:"maxBaseFee"`
+}
+
+// FeeStats describe the fees of the latest blocks of a chain, percentiles are keyed by the percentile
+type FeeStats struct {
+	ChainID                uint64               `json:"chainId"`
+	BlockNumber            uint64               `json:"blockNumber"`
+	Blocks                 int                  `json:"blocks"`
+	BaseFee                *hexutil.Big         `json:"baseFee"`
+	NextBaseFee            *hexutil.Big         `json:"nextBaseFee,omitempty"`
+	PriorityFee            *hexutil.Big         `json:"priorityFee"` // median priority fee of the latest block
+	BaseFeePercentiles     map[int]*hexutil.Big `json:"baseFeePercentiles"`
+	PriorityFeePercentiles map[int]*hexutil.Big `json:"priorityFeePercentiles"`
+	Forecast               []*Forecast          `json:"forecast,omitempty"`
+}
+
+func (h *chainHistory) stats(chainID uint64) *FeeStats {
+	latest := h.latestBlock()
+	if latest == nil {
+		return nil
+	}
+
+	stats := &FeeStats{
+		ChainID:                chainID,
+		BlockNumber:            latest.Number,
+		Blocks:                 len(h.blocks),
+		BaseFee:                (*hexutil.Big)(latest.BaseFee),
+		PriorityFee:            (*hexutil.Big)(medianPriorityFee(latest)),
+		BaseFeePercentiles:     make(map[int]*hexutil.Big),
+		PriorityFeePercentiles: make(map[int]*hexutil.Big),
+	}
+	if h.nextBaseFee != nil {
+		stats.NextBaseFee = (*hexutil.Big)(h.nextBaseFee)
+	}
+
+	baseFees := make([]*big.Int, 0, len(h.blocks))
+	priorityFees := make([]*big.Int, 0, len(h.blocks))
+	for _, block := range h.blocks {
+		baseFees = append(baseFees, block.BaseFee)
+		priorityFees = append(priorityFees, medianPriorityFee(block))
+	}
+	for _, p := range statsPercentiles {
+		stats.BaseFeePercentiles[p] = (*hexutil.Big)(percentile(baseFees, p))
+		stats.PriorityFeePercentiles[p] = (*hexutil.Big)(percentile(priorityFees, p))
+	}
+
+	stats.Forecast = h.forecast()
+	return stats
+}
+
+func medianPriorityFee(block *BlockFees) *big.Int {
+	if len(block.PriorityFees) == 0 {
+		return big.NewInt(0)
+	}
+	return block.PriorityFees[len(block.PriorityFees)/2]
+}
+
+// percentile returns the nearest-rank percentile of the values
+func percentile(values []*big.Int, p int) *big.Int {
+	if len(values) == 0 {
+		return big.NewInt(0)
+	}
+	sorted := make([]*big.Int, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+
+	rank := int(math.Ceil(float64(p)/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return new(big.Int).Set(sorted[rank])
+}
+
+// forecast extrapolates the average base fee change of the latest blocks, starting from the base fee of the next block.
+// It's a short-horizon estimate meant for timing non-urgent transactions, not a guarantee.
+func (h *chainHistory) forecast() []*Forecast {
+	if h.nextBaseFee == nil || h.nextBaseFee.Sign() == 0 {
+		// chain without EIP-1559 base fee
+		return nil
+	}
+
+	trend := h.baseFeeTrend()
+	next, _ := new(big.Float).SetInt(h.nextBaseFee).Float64()
+
+	forecast := make([]*Forecast, 0, len(forecastHorizons))
+	for _, blocks := range forecastHorizons {
+		steps := float64(blocks - 1)
+		forecast = append(forecast, &Forecast{
+			Blocks:     blocks,
+			BaseFee:    (*hexutil.Big)(floatToBig(next * math.Pow(1+trend, steps))),
+			MinBaseFee: (*hexutil.Big)(floatToBig(next * math.Pow(1-1.0/maxBaseFeeChangeDenominator, steps))),
+			MaxBaseFee: (*hexutil.Big)(floatToBig(next * math.Pow(1+1.0/maxBaseFeeChangeDenominator, steps))),
+		})
+	}
+	return forecast
+}
+
+// baseFeeTrend returns the average relative base fee change per block over the latest blocks
+func (h *chainHistory) baseFeeTrend() float64 {
+	fees := make([]*big.Int, 0, trendBlocks+1)
+	start := len(h.blocks) - trendBlocks
+	if start < 0 {
+		start = 0
+	}
+	for _, block := range h.blocks[start:] {
+		fees = append(fees, block.BaseFee)
+	}
+	fees = append(fees, h.nextBaseFee)
+
+	first, _ := new(big.Float).SetInt(fees[0]).Float64()
+	last, _ := new(big.Float).SetInt(fees[len(fees)-1]).Float64()
+	if len(fees) < 2 || first == 0 {
+		return 0
+	}
+
+	// geometric mean of the changes between the consecutive blocks
+	trend := math.Pow(last/first, 1/float64(len(fees)-1)) - 1
+	maxChange := 1.0 / maxBaseFeeChangeDenominator
+	return math.Max(-maxChange, math.Min(maxChange, trend))
+}
+
+func floatToBig(value float64) *big.Int {
+	result, _ := big.NewFloat(value).Int(nil)
+	return result
+}
@@ -0,0 +1,97 @@
+package feemonitor
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/status-im/status-go/services/wallet/router/fees"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlocksFromFeeHistory(t *testing.T) {
+	blocks, nextBaseFee, err := blocksFromFeeHistory(&fees.FeeHistory{
+		OldestBlock:   "0x10",
+		BaseFeePerGas: []string{"0x64", "0x6e", "0x78"},
+		GasUsedRatio:  []float64{0.9, 0.6},
+		Reward:        [][]string{{"0x1", "0x2", "0x3"}, {"0x4", "0x5", "0x6"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	require.Equal(t, uint64(16), blocks[0].Number)
+	require.Equal(t, big.NewInt(100), blocks[0].BaseFee)
+	require.Equal(t, uint64(17), blocks[1].Number)
+	require.Equal(t, big.NewInt(110), blocks[1].BaseFee)
+	require.Equal(t, []*big.Int{big.NewInt(4), big.NewInt(5), big.NewInt(6)}, blocks[1].PriorityFees)
+	require.Equal(t, big.NewInt(120), nextBaseFee)
+}
+
+func testBlocks(fromNumber uint64, baseFees ...int64) []*BlockFees {
+	blocks := make([]*BlockFees, 0, len(baseFees))
+	for i, fee := range baseFees {
+		blocks = append(blocks, &BlockFees{
+			Number:       fromNumber + uint64(i),
+			BaseFee:      big.NewInt(fee),
+			PriorityFees: []*big.Int{big.NewInt(1), big.NewInt(fee / 10), big.NewInt(fee)},
+		})
+	}
+	return blocks
+}
+
+func TestChainHistoryAdd(t *testing.T) {
+	h := newChainHistory(4)
+	h.add(testBlocks(1, 100, 100, 100), big.NewInt(100))
+	// block 3 is replaced by the reorged one
+	h.add(testBlocks(3, 200, 200, 200), big.NewInt(300))
+
+	require.Len(t, h.blocks, 4)
+	require.Equal(t, uint64(2), h.blocks[0].Number)
+	require.Equal(t, big.NewInt(200), h.blocks[1].BaseFee)
+	require.Equal(t, uint64(5), h.latestBlock().Number)
+	require.Equal(t, big.NewInt(300), h.nextBaseFee)
+}
+
+func TestChainHistoryStats(t *testing.T) {
+	h := newChainHistory(historySize)
+	require.Nil(t, h.stats(1))
+
+	h.add(testBlocks(1, 100, 200, 300, 400, 500, 600, 700, 800, 900, 1000), big.NewInt(1000))
+	stats := h.stats(1)
+	require.Equal(t, uint64(10), stats.BlockNumber)
+	require.Equal(t, 10, stats.Blocks)
+	require.Equal(t, big.NewInt(1000), stats.BaseFee.ToInt())
+	require.Equal(t, big.NewInt(1000), stats.NextBaseFee.ToInt())
+	require.Equal(t, big.NewInt(100), stats.PriorityFee.ToInt())
+	require.Equal(t, big.NewInt(100), stats.BaseFeePercentiles[10].ToInt())
+	require.Equal(t, big.NewInt(500), stats.BaseFeePercentiles[50].ToInt())
+	require.Equal(t, big.NewInt(900), stats.BaseFeePercentiles[90].ToInt())
+	require.Equal(t, big.NewInt(50), stats.PriorityFeePercentiles[50].ToInt())
+
+	require.Len(t, stats.Forecast, len(forecastHorizons))
+	next := stats.Forecast[0]
+	require.Equal(t, 1, next.Blocks)
+	require.Equal(t, big.NewInt(1000), next.BaseFee.ToInt())
+
+	// the fee is rising faster than allowed, the forecast is capped by the EIP-1559 limit
+	later := stats.Forecast[1]
+	require.Equal(t, 5, later.Blocks)
+	require.Equal(t, later.MaxBaseFee, later.BaseFee)
+	require.Equal(t, 1, later.BaseFee.ToInt().Cmp(big.NewInt(1000)))
+	require.Equal(t, -1, later.MinBaseFee.ToInt().Cmp(big.NewInt(1000)))
+}
+
+func TestForecastWithoutBaseFee(t *testing.T) {
+	h := newChainHistory(historySize)
+	h.add(testBlocks(1, 0, 0), big.NewInt(0))
+	require.Nil(t, h.stats(1).Forecast)
+}
+
+func TestFlatForecast(t *testing.T) {
+	h := newChainHistory(historySize)
+	h.add(testBlocks(1, 100, 100, 100), big.NewInt(100))
+	for _, forecast := range h.forecast() {
+		require.Equal(t, (*hexutil.Big)(big.NewInt(100)), forecast.BaseFee)
+	}
+}
@@ -0,0 +1,390 @@
+package feemonitor
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+
+	gocommon "github.com/status-im/status-go/common"
+	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/services/wallet/router/fees"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+)
+
+const (
+	// EventFeeAlertTriggered is sent when the condition of a fee alert is met
+	EventFeeAlertTriggered walletevent.EventType = "wallet-fee-alert-triggered"
+
+	monitorInterval = 1 * time.Minute
+	// number of blocks kept in the history of each chain
+	historySize = 300
+	// number of blocks requested when the history of a chain is empty
+	initialHistoryBlocks = 100
+	// number of blocks requested on each update, blocks mined between the updates on fast chains are not in the history
+	updateHistoryBlocks = 20
+)
+
+type AlertTriggered struct {
+	Alert *Alert       `json:"alert"`
+	Value *hexutil.Big `json:"value"`
+	Stats *FeeStats    `json:"stats"`
+}
+
+// Monitor keeps the fee history of the enabled chains with alerts and notifies the client when the fee alerts are triggered
+type Monitor struct {
+	rpcClient   rpc.ClientInterface
+	feeManager  *fees.FeeManager
+	persistence *Persistence
+	walletFeed  *event.Feed
+
+	// the fee history is only polled while there are active alerts
+	pollingMu sync.Mutex
+	ctx       context.Context
+	cancelFn  context.CancelFunc
+	polling   bool
+
+	historiesMu sync.RWMutex
+	histories   map[uint64]*chainHistory
+	alertsMu    sync.Mutex
+}
+
+func NewMonitor(db *sql.DB, rpcClient rpc.ClientInterface, walletFeed *event.Feed) *Monitor {
+	return &Monitor{
+		rpcClient:   rpcClient,
+		feeManager:  &fees.FeeManager{RPCClient: rpcClient},
+		persistence: NewPersistence(db),
+		walletFeed:  walletFeed,
+		histories:   make(map[uint64]*chainHistory),
+	}
+}
+
+func (m *Monitor) Start() {
+	m.pollingMu.Lock()
+	m.ctx, m.cancelFn = context.WithCancel(context.Background())
+	m.pollingMu.Unlock()
+
+	m.startPolling()
+}
+
+func (m *Monitor) Stop() {
+	m.pollingMu.Lock()
+	defer m.pollingMu.Unlock()
+	if m.cancelFn != nil {
+		m.cancelFn()
+	}
+	m.polling = false
+}
+
+// startPolling starts polling the fee history of the chains with active alerts if it isn't polled already
+func (m *Monitor) startPolling() {
+	m.pollingMu.Lock()
+	defer m.pollingMu.Unlock()
+	if m.ctx == nil || m.ctx.Err() != nil || m.polling {
+		return
+	}
+
+	m.polling = true
+	go m.poll(m.ctx)
+}
+
+func (m *Monitor) poll(ctx context.Context) {
+	defer gocommon.LogOnPanic()
+
+	ticker := time.NewTicker(monitorInterval)
+	defer ticker.Stop()
+	for {
+		if !m.update(ctx) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// stopPolling stops polling if no alert is active, it is checked under the polling lock so that an alert added
+// meanwhile starts polling again
+func (m *Monitor) stopPolling(ctx context.Context) bool {
+	m.pollingMu.Lock()
+	defer m.pollingMu.Unlock()
+	if ctx.Err() != nil {
+		return true
+	}
+
+	chainIDs, err := m.alertChains()
+	if err != nil || len(chainIDs) > 0 {
+		return false
+	}
+	m.polling = false
+
+	m.historiesMu.Lock()
+	m.histories = make(map[uint64]*chainHistory)
+	m.historiesMu.Unlock()
+	return true
+}
+
+func (m *Monitor) monitoredChains() ([]uint64, error) {
+	networks, err := m.rpcClient.GetNetworkManager().GetActiveNetworks()
+	if err != nil {
+		return nil, err
+	}
+
+	chainIDs := make([]uint64, 0, len(networks))
+	for _, network := range networks {
+		if network.Enabled {
+			chainIDs = append(chainIDs, network.ChainID)
+		}
+	}
+	return chainIDs, nil
+}
+
+// alertChains returns the chains with active alerts, the alerts not triggered yet and the repeated ones
+func (m *Monitor) alertChains() (map[uint64]bool, error) {
+	alerts, err := m.persistence.GetAlerts(0)
+	if err != nil {
+		return nil, err
+	}
+
+	chainIDs := make(map[uint64]bool)
+	for _, alert := range alerts {
+		if !alert.Triggered || alert.Repeat {
+			chainIDs[alert.ChainID] = true
+		}
+	}
+	return chainIDs, nil
+}
+
+// update updates the fee history of the enabled chains with active alerts and checks their alerts, it returns false
+// once there are no active alerts left
+func (m *Monitor) update(ctx context.Context) bool {
+	alertChainIDs, err := m.alertChains()
+	if err != nil {
+		log.Error("fee monitor: failed to get alerts", "err", err)
+		return true
+	}
+	if len(alertChainIDs) == 0 && m.stopPolling(ctx) {
+		return false
+	}
+
+	chainIDs, err := m.monitoredChains()
+	if err != nil {
+		log.Error("fee monitor: failed to get networks", "err", err)
+		return true
+	}
+
+	polled := make(map[uint64]bool, len(chainIDs))
+	for _, chainID := range chainIDs {
+		if !alertChainIDs[chainID] {
+			continue
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+		polled[chainID] = true
+
+		stats, err := m.updateChain(ctx, chainID)
+		if err != nil {
+			log.Warn("fee monitor: failed to update fee history", "chainID", chainID, "err", err)
+			continue
+		}
+		m.checkAlerts(chainID, stats)
+	}
+
+	// the histories of the chains not polled anymore would get stale
+	m.historiesMu.Lock()
+	for chainID := range m.histories {
+		if !polled[chainID] {
+			delete(m.histories, chainID)
+		}
+	}
+	m.historiesMu.Unlock()
+	return true
+}
+
+func (m *Monitor) updateChain(ctx context.Context, chainID uint64) (*FeeStats, error) {
+	m.historiesMu.RLock()
+	history, ok := m.histories[chainID]
+	m.historiesMu.RUnlock()
+
+	blockCount := uint64(updateHistoryBlocks)
+	if !ok {
+		blockCount = initialHistoryBlocks
+	}
+
+	feeHistory, err := m.feeManager.GetFeeHistory(ctx, chainID, blockCount, rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, nextBaseFee, err := blocksFromFeeHistory(feeHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	m.historiesMu.Lock()
+	defer m.historiesMu.Unlock()
+	history, ok = m.histories[chainID]
+	if !ok {
+		history = newChainHistory(historySize)
+		m.histories[chainID] = history
+	}
+	history.add(blocks, nextBaseFee)
+	return history.stats(chainID), nil
+}
+
+// GetFeeStats returns the fee percentiles and the base fee forecast of the chain, the history is fetched if the chain
+// isn't polled
+func (m *Monitor) GetFeeStats(ctx context.Context, chainID uint64) (*FeeStats, error) {
+	m.historiesMu.RLock()
+	history, ok := m.histories[chainID]
+	var stats *FeeStats
+	if ok {
+		stats = history.stats(chainID)
+	}
+	m.historiesMu.RUnlock()
+
+	if stats == nil {
+		var err error
+		stats, err = m.fetchStats(ctx, chainID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if stats == nil {
+		return nil, ErrNoFeeHistory
+	}
+	return stats, nil
+}
+
+// fetchStats returns the stats of a fee history fetched only for the request, it isn't kept as it wouldn't be updated
+func (m *Monitor) fetchStats(ctx context.Context, chainID uint64) (*FeeStats, error) {
+	feeHistory, err := m.feeManager.GetFeeHistory(ctx, chainID, initialHistoryBlocks, rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, nextBaseFee, err := blocksFromFeeHistory(feeHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	history := newChainHistory(historySize)
+	history.add(blocks, nextBaseFee)
+	return history.stats(chainID), nil
+}
+
+func (m *Monitor) AddAlert(alert *Alert) (*Alert, error) {
+	err := alert.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	chainIDs, err := m.monitoredChains()
+	if err != nil {
+		return nil, err
+	}
+	monitored := false
+	for _, chainID := range chainIDs {
+		monitored = monitored || chainID == alert.ChainID
+	}
+	if !monitored {
+		return nil, ErrChainNotMonitored
+	}
+
+	alert.Triggered = false
+	alert.TriggeredAt = 0
+	alert.CreatedAt = time.Now().Unix()
+	alert.ID, err = m.persistence.InsertAlert(alert)
+	if err != nil {
+		return nil, err
+	}
+
+	m.startPolling()
+	return alert, nil
+}
+
+func (m *Monitor) DeleteAlert(id int64) error {
+	m.alertsMu.Lock()
+	defer m.alertsMu.Unlock()
+	return m.persistence.DeleteAlert(id)
+}
+
+// GetAlerts returns the alerts of the chain, all alerts if chainID is 0
+func (m *Monitor) GetAlerts(chainID uint64) ([]*Alert, error) {
+	return m.persistence.GetAlerts(chainID)
+}
+
+func (m *Monitor) checkAlerts(chainID uint64, stats *FeeStats) {
+	if stats == nil {
+		return
+	}
+
+	m.alertsMu.Lock()
+	defer m.alertsMu.Unlock()
+
+	alerts, err := m.persistence.GetAlerts(chainID)
+	if err != nil {
+		log.Error("fee monitor: failed to get alerts", "chainID", chainID, "err", err)
+		return
+	}
+
+	for _, alert := range alerts {
+		value := alert.value(stats)
+		if value == nil {
+			continue
+		}
+
+		met := alert.conditionMet(value)
+		if met && !alert.Triggered {
+			alert.Triggered = true
+			alert.TriggeredAt = time.Now().Unix()
+		} else if !met && alert.Triggered && alert.Repeat {
+			// rearm the alert once the condition isn't met anymore
+			alert.Triggered = false
+		} else {
+			continue
+		}
+
+		err = m.persistence.UpdateAlertTriggered(alert)
+		if err != nil {
+			log.Error("fee monitor: failed to update alert", "id", alert.ID, "err", err)
+			continue
+		}
+		if alert.Triggered {
+			m.sendAlertTriggered(alert, value, stats)
+		}
+	}
+}
+
+func (m *Monitor) sendAlertTriggered(alert *Alert, value *big.Int, stats *FeeStats) {
+	if m.walletFeed == nil {
+		return
+	}
+
+	message, err := json.Marshal(&AlertTriggered{
+		Alert: alert,
+		Value: (*hexutil.Big)(value),
+		Stats: stats,
+	})
+	if err != nil {
+		log.Error("fee monitor: failed to marshal alert", "id", alert.ID, "err", err)
+		return
+	}
+
+	m.walletFeed.Send(walletevent.Event{
+		Type:    EventFeeAlertTriggered,
+		ChainID: alert.ChainID,
+		Message: string(message),
+		At:      time.Now().Unix(),
+	})
+}
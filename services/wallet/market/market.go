@@ -2,6 +2,8 @@ package market
 
 import (
 	"context"
+	"math"
+	"sort"
 	"sync"
 	"time"
 
@@ -23,9 +25,21 @@ const (
 	MaxAgeInSecondsForBalances int64 = 60
 )
 
+const (
+	// priceOutlierTolerance is the relative deviation from the median of the quotes above which a quote is rejected
+	priceOutlierTolerance = 0.05
+	// minQuotesForFullConfidence is the number of agreeing sources needed for a price to get the full confidence
+	minQuotesForFullConfidence = 2
+	// twoQuotesTolerance is the relative difference below which two quotes agree, two sources can't outvote each other
+	// so they must agree more closely than a quote and the median of several quotes
+	twoQuotesTolerance = 0.01
+)
+
 type DataPoint struct {
-	Price     float64
-	UpdatedAt int64
+	Price     float64 `json:"price"`
+	UpdatedAt int64   `json:"updatedAt"`
+	// Confidence in [0, 1] of the price, see reconcilePrice
+	Confidence float64 `json:"confidence"`
 }
 
 type MarketValuesSnapshot struct {
@@ -47,6 +61,11 @@ type Manager struct {
 	IsConnectedLock sync.RWMutex
 	circuitbreaker  *circuitbreaker.CircuitBreaker
 	providers       []thirdparty.MarketDataProvider
+
+	// Each source is a list of providers serving the same data, tried in order
+	reconciliationSources [][]thirdparty.MarketDataProvider
+	reconciliationEnabled bool
+	reconciliationLock    sync.RWMutex
 }
 
 func NewManager(providers []thirdparty.MarketDataProvider, feed *event.Feed) *Manager {
@@ -87,7 +106,37 @@ func (pm *Manager) setIsConnected(value bool) {
 	pm.IsConnected = value
 }
 
-func (pm *Manager) makeCall(providers []thirdparty.MarketDataProvider, f func(provider thirdparty.MarketDataProvider) (interface{}, error)) (interface{}, error) {
+// SetReconciliationSources sets the sources compared when the reconciliation is enabled
+func (pm *Manager) SetReconciliationSources(sources [][]thirdparty.MarketDataProvider) {
+	pm.reconciliationLock.Lock()
+	defer pm.reconciliationLock.Unlock()
+	pm.reconciliationSources = sources
+}
+
+// SetReconciliationEnabled enables fetching the prices from all the reconciliation sources and rejecting the outliers,
+// instead of taking the prices of the first provider answering
+func (pm *Manager) SetReconciliationEnabled(enabled bool) {
+	pm.reconciliationLock.Lock()
+	defer pm.reconciliationLock.Unlock()
+	pm.reconciliationEnabled = enabled
+}
+
+func (pm *Manager) IsReconciliationEnabled() bool {
+	pm.reconciliationLock.RLock()
+	defer pm.reconciliationLock.RUnlock()
+	return pm.reconciliationEnabled
+}
+
+func (pm *Manager) getReconciliationSources() [][]thirdparty.MarketDataProvider {
+	pm.reconciliationLock.RLock()
+	defer pm.reconciliationLock.RUnlock()
+	if !pm.reconciliationEnabled || len(pm.reconciliationSources) < 2 {
+		return nil
+	}
+	return pm.reconciliationSources
+}
+
+func (pm *Manager) execute(providers []thirdparty.MarketDataProvider, f func(provider thirdparty.MarketDataProvider) (interface{}, error)) (interface{}, error) {
 	cmd := circuitbreaker.NewCommand(context.Background(), nil)
 	for _, provider := range providers {
 		provider := provider
@@ -98,16 +147,25 @@ func (pm *Manager) makeCall(providers []thirdparty.MarketDataProvider, f func(pr
 	}
 
 	result := pm.circuitbreaker.Execute(cmd)
-	pm.setIsConnected(result.Error() == nil)
-
 	if result.Error() != nil {
-		log.Error("Error fetching prices", "error", result.Error())
 		return nil, result.Error()
 	}
 
 	return result.Result()[0], nil
 }
 
+func (pm *Manager) makeCall(providers []thirdparty.MarketDataProvider, f func(provider thirdparty.MarketDataProvider) (interface{}, error)) (interface{}, error) {
+	result, err := pm.execute(providers, f)
+	pm.setIsConnected(err == nil)
+
+	if err != nil {
+		log.Error("Error fetching prices", "error", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (pm *Manager) FetchHistoricalDailyPrices(symbol string, currency string, limit int, allData bool, aggregate int) ([]thirdparty.HistoricalPrice, error) {
 	result, err := pm.makeCall(pm.providers, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
 		return provider.FetchHistoricalDailyPrices(symbol, currency, limit, allData, aggregate)
@@ -253,20 +311,197 @@ func (pm *Manager) FetchPrice(symbol string, currency string) (float64, error) {
 }
 
 func (pm *Manager) FetchPrices(symbols []string, currencies []string) (map[string]map[string]float64, error) {
-	response, err := pm.makeCall(pm.providers, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
-		return provider.FetchPrices(symbols, currencies)
-	})
-
+	dataPoints, err := pm.FetchPricesWithConfidence(symbols, currencies)
 	if err != nil {
-		log.Error("Error fetching prices", "error", err)
 		return nil, err
 	}
 
-	prices := response.(map[string]map[string]float64)
-	pm.updatePriceCache(prices)
+	prices := make(map[string]map[string]float64)
+	for symbol, dataPointsPerCurrency := range dataPoints {
+		prices[symbol] = make(map[string]float64)
+		for currency, dataPoint := range dataPointsPerCurrency {
+			prices[symbol][currency] = dataPoint.Price
+		}
+	}
 	return prices, nil
 }
 
+// FetchPricesWithConfidence fetches the prices, reconciled between the sources if the reconciliation is enabled
+func (pm *Manager) FetchPricesWithConfidence(symbols []string, currencies []string) (DataPerTokenAndCurrency, error) {
+	var dataPoints DataPerTokenAndCurrency
+
+	sources := pm.getReconciliationSources()
+	if len(sources) > 0 {
+		var err error
+		dataPoints, err = pm.fetchReconciledPrices(sources, symbols, currencies)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		response, err := pm.makeCall(pm.providers, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
+			return provider.FetchPrices(symbols, currencies)
+		})
+
+		if err != nil {
+			log.Error("Error fetching prices", "error", err)
+			return nil, err
+		}
+
+		now := time.Now().Unix()
+		dataPoints = make(DataPerTokenAndCurrency)
+		for symbol, pricesPerCurrency := range response.(map[string]map[string]float64) {
+			dataPoints[symbol] = make(map[string]DataPoint)
+			for currency, price := range pricesPerCurrency {
+				_, confidence := reconcilePrice([]float64{price})
+				dataPoints[symbol][currency] = DataPoint{
+					Price:      price,
+					UpdatedAt:  now,
+					Confidence: confidence,
+				}
+			}
+		}
+	}
+
+	pm.updatePriceCache(dataPoints)
+	return dataPoints, nil
+}
+
+// fetchReconciledPrices fetches the prices from all the sources concurrently and reconciles them
+func (pm *Manager) fetchReconciledPrices(sources [][]thirdparty.MarketDataProvider, symbols []string, currencies []string) (DataPerTokenAndCurrency, error) {
+	results := make([]map[string]map[string]float64, len(sources))
+	errs := make([]error, len(sources))
+
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func(i int, source []thirdparty.MarketDataProvider) {
+			defer wg.Done()
+			response, err := pm.execute(source, func(provider thirdparty.MarketDataProvider) (interface{}, error) {
+				return provider.FetchPrices(symbols, currencies)
+			})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i] = response.(map[string]map[string]float64)
+		}(i, source)
+	}
+	wg.Wait()
+
+	var firstErr error
+	succeeded := 0
+	for i, err := range errs {
+		if err != nil {
+			log.Warn("price source not available for reconciliation", "source", sources[i][0].ID(), "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		succeeded++
+	}
+	pm.setIsConnected(succeeded > 0)
+	if succeeded == 0 {
+		log.Error("Error fetching prices", "error", firstErr)
+		return nil, firstErr
+	}
+
+	now := time.Now().Unix()
+	cachedDataPoints := pm.getCachedPricesFor(symbols, currencies)
+	dataPoints := make(DataPerTokenAndCurrency)
+	for _, symbol := range symbols {
+		for _, currency := range currencies {
+			quotes := make([]float64, 0, len(results))
+			found := false
+			for _, result := range results {
+				if price, ok := result[symbol][currency]; ok {
+					found = true
+					quotes = append(quotes, price)
+				}
+			}
+			if !found {
+				continue
+			}
+
+			dataPoint := DataPoint{UpdatedAt: now}
+			dataPoint.Price, dataPoint.Confidence = reconcilePrice(quotes)
+			if dataPoint.Price == 0 {
+				// no quorum, the last accepted price is kept if there is one
+				log.Warn("price sources disagree, no price accepted", "symbol", symbol, "currency", currency, "quotes", quotes)
+				dataPoint = cachedDataPoints[symbol][currency]
+				if dataPoint.UpdatedAt == 0 {
+					continue
+				}
+			} else if dataPoint.Confidence < 1 && len(quotes) > 1 {
+				log.Warn("price sources disagree", "symbol", symbol, "currency", currency, "quotes", quotes, "price", dataPoint.Price, "confidence", dataPoint.Confidence)
+			}
+			if _, ok := dataPoints[symbol]; !ok {
+				dataPoints[symbol] = make(map[string]DataPoint)
+			}
+			dataPoints[symbol][currency] = dataPoint
+		}
+	}
+
+	return dataPoints, nil
+}
+
+func median(sorted []float64) float64 {
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// reconcilePrice combines the quotes of the sources for a price. Missing (zero) and invalid quotes are ignored, and
+// the quotes deviating from their median by more than priceOutlierTolerance are rejected as outliers.
+// The price is the median of the remaining quotes. The confidence is the share of quotes agreeing on the price,
+// reduced when fewer than minQuotesForFullConfidence quotes back it, so a single source gets 0.5.
+// There is no price (0) without a quorum, i.e. when the agreeing quotes aren't the majority.
+// Two quotes are compared with each other instead: they get the full confidence only within twoQuotesTolerance, the
+// confidence of a single source within priceOutlierTolerance, and no price beyond it
+func reconcilePrice(quotes []float64) (price float64, confidence float64) {
+	valid := make([]float64, 0, len(quotes))
+	for _, quote := range quotes {
+		if quote > 0 && !math.IsInf(quote, 0) && !math.IsNaN(quote) {
+			valid = append(valid, quote)
+		}
+	}
+	if len(valid) == 0 {
+		return 0, 0
+	}
+	sort.Float64s(valid)
+
+	if len(valid) == 2 {
+		difference := (valid[1] - valid[0]) / valid[0]
+		switch {
+		case difference <= twoQuotesTolerance:
+			return median(valid), 1
+		case difference <= priceOutlierTolerance:
+			return median(valid), 1.0 / minQuotesForFullConfidence
+		default:
+			return 0, 0
+		}
+	}
+
+	reference := median(valid)
+	inliers := make([]float64, 0, len(valid))
+	for _, quote := range valid {
+		if math.Abs(quote-reference)/reference <= priceOutlierTolerance {
+			inliers = append(inliers, quote)
+		}
+	}
+	if len(inliers)*2 <= len(valid) {
+		return 0, 0
+	}
+
+	confidence = float64(len(inliers)) / float64(len(valid))
+	if len(inliers) < minQuotesForFullConfidence {
+		confidence *= float64(len(inliers)) / minQuotesForFullConfidence
+	}
+	return median(inliers), confidence
+}
+
 func (pm *Manager) getCachedPricesFor(symbols []string, currencies []string) DataPerTokenAndCurrency {
 	return Read(&pm.priceCache, func(tokenPriceCache TokenPriceCache) DataPerTokenAndCurrency {
 		prices := make(DataPerTokenAndCurrency)
@@ -280,18 +515,15 @@ func (pm *Manager) getCachedPricesFor(symbols []string, currencies []string) Dat
 	})
 }
 
-func (pm *Manager) updatePriceCache(prices map[string]map[string]float64) {
+func (pm *Manager) updatePriceCache(prices DataPerTokenAndCurrency) {
 	Write(&pm.priceCache, func(tokenPriceCache TokenPriceCache) TokenPriceCache {
 		for token, pricesPerCurrency := range prices {
 			_, present := tokenPriceCache[token]
 			if !present {
 				tokenPriceCache[token] = make(map[string]DataPoint)
 			}
-			for currency, price := range pricesPerCurrency {
-				tokenPriceCache[token][currency] = DataPoint{
-					Price:     price,
-					UpdatedAt: time.Now().Unix(),
-				}
+			for currency, dataPoint := range pricesPerCurrency {
+				tokenPriceCache[token][currency] = dataPoint
 			}
 		}
 
@@ -331,7 +563,7 @@ func (pm *Manager) GetOrFetchPrices(symbols []string, currencies []string, maxAg
 	})
 
 	if len(symbolsToFetch) > 0 {
-		_, err := pm.FetchPrices(symbolsToFetch, currencies)
+		_, err := pm.FetchPricesWithConfidence(symbolsToFetch, currencies)
 		if err != nil {
 			return nil, err
 		}
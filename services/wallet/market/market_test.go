@@ -242,3 +242,121 @@ func TestGetOrFetchTokenMarketValues(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcilePrice(t *testing.T) {
+	testCases := []struct {
+		description        string
+		quotes             []float64
+		expectedPrice      float64
+		expectedConfidence float64
+	}{
+		{"no quotes", []float64{}, 0, 0},
+		{"missing quotes", []float64{0, 0}, 0, 0},
+		{"single source", []float64{100}, 100, 0.5},
+		{"agreeing sources", []float64{100, 101, 99}, 100, 1},
+		{"outlier rejected", []float64{100, 102, 1000}, 101, 2.0 / 3.0},
+		{"missing quote ignored", []float64{100, 0, 100}, 100, 1},
+		{"disagreeing sources", []float64{100, 200}, 0, 0},
+		{"two agreeing sources", []float64{100, 100.5}, 100.25, 1},
+		{"two loosely agreeing sources", []float64{100, 104}, 102, 0.5},
+		{"two sources beyond the outlier tolerance", []float64{100, 109}, 0, 0},
+		{"no majority", []float64{100, 101, 200, 300}, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		price, confidence := reconcilePrice(tc.quotes)
+		require.InDelta(t, tc.expectedPrice, price, 1e-9, tc.description)
+		require.InDelta(t, tc.expectedConfidence, confidence, 1e-9, tc.description)
+	}
+}
+
+func TestFetchPricesReconciliation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	priceProvider := mock_market.NewMockPriceProvider(ctrl)
+	priceProvider.SetMockPrices(mockPrices)
+	agreeingProvider := mock_market.NewMockPriceProvider(ctrl)
+	agreeingProvider.SetMockPrices(map[string]map[string]float64{
+		"BTC": {"USD": 1.23457},
+		"ETH": {"USD": 4.56789},
+	})
+	wrongProvider := mock_market.NewMockPriceProvider(ctrl)
+	wrongProvider.SetMockPrices(map[string]map[string]float64{
+		"BTC": {"USD": 1.23456},
+		"ETH": {"USD": 456.789},
+	})
+	providerWithError := mock_market.NewMockPriceProviderWithError(ctrl, errors.New("error"))
+
+	manager := setupMarketManager(t, []thirdparty.MarketDataProvider{wrongProvider})
+	manager.SetReconciliationSources([][]thirdparty.MarketDataProvider{
+		{wrongProvider},
+		{providerWithError, priceProvider},
+		{agreeingProvider},
+	})
+
+	// Without reconciliation the first provider is trusted
+	rst, err := manager.FetchPricesWithConfidence([]string{"ETH"}, []string{"USD"})
+	require.NoError(t, err)
+	require.Equal(t, 456.789, rst["ETH"]["USD"].Price)
+	require.Equal(t, 0.5, rst["ETH"]["USD"].Confidence)
+
+	manager.SetReconciliationEnabled(true)
+	require.True(t, manager.IsReconciliationEnabled())
+
+	rst, err = manager.FetchPricesWithConfidence([]string{"BTC", "ETH"}, []string{"USD"})
+	require.NoError(t, err)
+	require.Equal(t, 1.23456, rst["BTC"]["USD"].Price)
+	require.Equal(t, 1.0, rst["BTC"]["USD"].Confidence)
+	require.Equal(t, 4.56789, rst["ETH"]["USD"].Price)
+	require.InDelta(t, 2.0/3.0, rst["ETH"]["USD"].Confidence, 1e-9)
+
+	cache := manager.priceCache.Get()
+	require.Equal(t, 4.56789, cache["ETH"]["USD"].Price)
+	require.InDelta(t, 2.0/3.0, cache["ETH"]["USD"].Confidence, 1e-9)
+
+	// All the sources failing is an error
+	manager.SetReconciliationSources([][]thirdparty.MarketDataProvider{{providerWithError}, {providerWithError}})
+	_, err = manager.FetchPrices([]string{"BTC"}, []string{"USD"})
+	require.Error(t, err)
+}
+
+func TestFetchPricesReconciliationWithoutQuorum(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	priceProvider := mock_market.NewMockPriceProvider(ctrl)
+	priceProvider.SetMockPrices(map[string]map[string]float64{
+		"ETH": {"USD": 100},
+	})
+	agreeingProvider := mock_market.NewMockPriceProvider(ctrl)
+	agreeingProvider.SetMockPrices(map[string]map[string]float64{
+		"ETH": {"USD": 101},
+	})
+	wrongProvider := mock_market.NewMockPriceProvider(ctrl)
+	wrongProvider.SetMockPrices(map[string]map[string]float64{
+		"ETH": {"USD": 200},
+	})
+
+	manager := setupMarketManager(t, []thirdparty.MarketDataProvider{priceProvider})
+	manager.SetReconciliationEnabled(true)
+
+	// No price is accepted from two disagreeing sources
+	manager.SetReconciliationSources([][]thirdparty.MarketDataProvider{{priceProvider}, {wrongProvider}})
+	rst, err := manager.FetchPricesWithConfidence([]string{"ETH"}, []string{"USD"})
+	require.NoError(t, err)
+	require.NotContains(t, rst, "ETH")
+
+	manager.SetReconciliationSources([][]thirdparty.MarketDataProvider{{priceProvider}, {agreeingProvider}})
+	rst, err = manager.FetchPricesWithConfidence([]string{"ETH"}, []string{"USD"})
+	require.NoError(t, err)
+	require.Equal(t, 100.5, rst["ETH"]["USD"].Price)
+	accepted := rst["ETH"]["USD"]
+
+	// The last accepted price is kept when the sources disagree
+	manager.SetReconciliationSources([][]thirdparty.MarketDataProvider{{agreeingProvider}, {wrongProvider}})
+	rst, err = manager.FetchPricesWithConfidence([]string{"ETH"}, []string{"USD"})
+	require.NoError(t, err)
+	require.Equal(t, accepted, rst["ETH"]["USD"])
+	require.Equal(t, accepted, manager.priceCache.Get()["ETH"]["USD"])
+}
@@ -0,0 +1,152 @@
+package nativebridge
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/status-go/rpc/chain"
+)
+
+const (
+	// ArbSys precompile on L2
+	arbSysABI = `[
+		{"anonymous":false,"inputs":[{"indexed":false,"name":"caller","type":"address"},{"indexed":true,"name":"destination","type":"address"},{"indexed":true,"name":"hash","type":"uint256"},{"indexed":true,"name":"position","type":"uint256"},{"indexed":false,"name":"arbBlockNum","type":"uint256"},{"indexed":false,"name":"ethBlockNum","type":"uint256"},{"indexed":false,"name":"timestamp","type":"uint256"},{"indexed":false,"name":"callvalue","type":"uint256"},{"indexed":false,"name":"data","type":"bytes"}],"name":"L2ToL1Tx","type":"event"}
+	]`
+	// NodeInterface virtual contract on L2, only available through eth_call
+	arbitrumNodeInterfaceABI = `[
+		{"inputs":[{"name":"size","type":"uint64"},{"name":"leaf","type":"uint64"}],"name":"constructOutboxProof","outputs":[{"name":"send","type":"bytes32"},{"name":"root","type":"bytes32"},{"name":"proof","type":"bytes32[]"}],"stateMutability":"view","type":"function"}
+	]`
+	// Outbox on L1
+	arbitrumOutboxABI = `[
+		{"inputs":[{"name":"index","type":"uint256"}],"name":"isSpent","outputs":[{"name":"","type":"bool"}],"stateMutability":"view","type":"function"},
+		{"inputs":[{"name":"proof","type":"bytes32[]"},{"name":"index","type":"uint256"},{"name":"l2Sender","type":"address"},{"name":"to","type":"address"},{"name":"l2Block","type":"uint256"},{"name":"l1Block","type":"uint256"},{"name":"l2Timestamp","type":"uint256"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"}],"name":"executeTransaction","outputs":[],"stateMutability":"nonpayable","type":"function"},
+		{"anonymous":false,"inputs":[{"indexed":true,"name":"outputRoot","type":"bytes32"},{"indexed":true,"name":"l2BlockHash","type":"bytes32"}],"name":"SendRootUpdated","type":"event"}
+	]`
+
+	// confirmed send roots are posted about every hour, the latest one is searched in the last day of L1 blocks
+	arbitrumSendRootLookback = 7200
+)
+
+// arbitrumMessageFromReceipt returns the message sent to L1 by the withdrawal tx
+func arbitrumMessageFromReceipt(receipt *types.Receipt) (*withdrawalMessage, error) {
+	arbSys, err := abi.JSON(strings.NewReader(arbSysABI))
+	if err != nil {
+		return nil, err
+	}
+	event := arbSys.Events["L2ToL1Tx"]
+
+	for _, log := range receipt.Logs {
+		if log.Address != ArbitrumArbSys || len(log.Topics) != 4 || log.Topics[0] != event.ID {
+			continue
+		}
+
+		values, err := event.Inputs.NonIndexed().Unpack(log.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		return &withdrawalMessage{
+			L2BlockNumber: values[1].(*big.Int).Uint64(),
+			Sender:        values[0].(common.Address),
+			Target:        common.BytesToAddress(log.Topics[1].Bytes()),
+			Value:         (*hexutil.Big)(values[4].(*big.Int)),
+			Data:          values[5].([]byte),
+			Position:      (*hexutil.Big)(log.Topics[3].Big()),
+			L1BlockNumber: values[2].(*big.Int).Uint64(),
+			Timestamp:     values[3].(*big.Int).Uint64(),
+		}, nil
+	}
+	return nil, ErrMessageNotFound
+}
+
+// arbitrumConfirmedSendCount returns the number of L2 to L1 messages covered by the latest send root confirmed on L1.
+// The outbox emits the L2 block of each confirmed root, its header keeps the send count in the first 8 bytes of the mix digest
+func arbitrumConfirmedSendCount(ctx context.Context, l1Client chain.ClientInterface, l2Client chain.ClientInterface, bridge *Bridge) (uint64, error) {
+	outbox, err := abi.JSON(strings.NewReader(arbitrumOutboxABI))
+	if err != nil {
+		return 0, err
+	}
+
+	lastBlock, err := l1Client.BlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var fromBlock uint64
+	if lastBlock > arbitrumSendRootLookback {
+		fromBlock = lastBlock - arbitrumSendRootLookback
+	}
+
+	logs, err := l1Client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(lastBlock),
+		Addresses: []common.Address{bridge.Outbox},
+		Topics:    [][]common.Hash{{outbox.Events["SendRootUpdated"].ID}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	for i := len(logs) - 1; i >= 0; i-- {
+		if len(logs[i].Topics) != 3 {
+			continue
+		}
+		header, err := l2Client.HeaderByHash(ctx, logs[i].Topics[2])
+		if err != nil {
+			return 0, err
+		}
+		return binary.BigEndian.Uint64(header.MixDigest[:8]), nil
+	}
+	return 0, nil
+}
+
+// isArbitrumExecutable checks if the message is covered by a confirmed send root, it can be executed on L1 from then on
+func isArbitrumExecutable(ctx context.Context, l1Client chain.ClientInterface, l2Client chain.ClientInterface, bridge *Bridge, message *withdrawalMessage) (bool, error) {
+	sendCount, err := arbitrumConfirmedSendCount(ctx, l1Client, l2Client, bridge)
+	if err != nil {
+		return false, err
+	}
+	return message.Position.ToInt().Cmp(new(big.Int).SetUint64(sendCount)) < 0, nil
+}
+
+// packArbitrumExecute packs the outbox call executing the message, the proof is built by the L2 node interface against the
+// latest confirmed send root
+func packArbitrumExecute(ctx context.Context, l1Client chain.ClientInterface, l2Client chain.ClientInterface, bridge *Bridge, message *withdrawalMessage) ([]byte, error) {
+	sendCount, err := arbitrumConfirmedSendCount(ctx, l1Client, l2Client, bridge)
+	if err != nil {
+		return nil, err
+	}
+	position := message.Position.ToInt()
+	if position.Cmp(new(big.Int).SetUint64(sendCount)) >= 0 {
+		return nil, ErrWithdrawalNotReady
+	}
+
+	values, err := callContract(ctx, l2Client, ArbitrumNodeInterface, arbitrumNodeInterfaceABI, "constructOutboxProof", sendCount, position.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	proof := values[2].([][32]byte)
+
+	outbox, err := abi.JSON(strings.NewReader(arbitrumOutboxABI))
+	if err != nil {
+		return nil, err
+	}
+	return outbox.Pack("executeTransaction", proof, position, message.Sender, message.Target,
+		new(big.Int).SetUint64(message.L2BlockNumber), new(big.Int).SetUint64(message.L1BlockNumber),
+		new(big.Int).SetUint64(message.Timestamp), message.Value.ToInt(), []byte(message.Data))
+}
+
+// isArbitrumFinalized checks if the message was executed on L1, the outbox marks the executed messages by their position
+func isArbitrumFinalized(ctx context.Context, l1Client chain.ClientInterface, bridge *Bridge, message *withdrawalMessage) (bool, error) {
+	values, err := callContract(ctx, l1Client, bridge.Outbox, arbitrumOutboxABI, "isSpent", message.Position.ToInt())
+	if err != nil {
+		return false, err
+	}
+	return values[0].(bool), nil
+}
@@ -0,0 +1,128 @@
+package nativebridge
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+)
+
+// Kind is the family of the canonical bridge of a L2 chain
+type Kind int
+
+const (
+	KindOPStack Kind = iota + 1
+	KindArbitrum
+)
+
+var (
+	// predeploys shared by all OP Stack chains
+	OPStackL2StandardBridge    = common.HexToAddress("0x4200000000000000000000000000000000000010")
+	OPStackL2ToL1MessagePasser = common.HexToAddress("0x4200000000000000000000000000000000000016")
+	// token address used by the L2 standard bridge for ETH withdrawals
+	OPStackLegacyERC20ETH = common.HexToAddress("0xDeadDeAddeAddEAddeadDEaDDEAdDeaDDeAD0000")
+
+	// precompile sending the messages from Arbitrum chains to L1
+	ArbitrumArbSys = common.HexToAddress("0x0000000000000000000000000000000000000064")
+	// virtual contract of the Arbitrum nodes building the outbox proofs
+	ArbitrumNodeInterface = common.HexToAddress("0x00000000000000000000000000000000000000C8")
+)
+
+// Bridge is the canonical bridge between a L2 chain and its L1 chain
+type Bridge struct {
+	Kind      Kind
+	L1ChainID uint64
+	L2ChainID uint64
+
+	// OP Stack contracts on L1
+	L1StandardBridge   common.Address
+	OptimismPortal     common.Address
+	DisputeGameFactory common.Address
+
+	// Arbitrum contracts on L1
+	Inbox  common.Address
+	Outbox common.Address
+
+	// expected time between the withdrawal initiation and the finalization, used as an estimate only
+	WithdrawalPeriod time.Duration
+}
+
+const (
+	opStackWithdrawalPeriod  = 7 * 24 * time.Hour
+	arbitrumWithdrawalPeriod = 7 * 24 * time.Hour
+)
+
+var bridges = []*Bridge{
+	{
+		Kind:               KindOPStack,
+		L1ChainID:          walletCommon.EthereumMainnet,
+		L2ChainID:          walletCommon.OptimismMainnet,
+		L1StandardBridge:   common.HexToAddress("0x99C9fc46f92E8a1c0deC1b1747d010903E884bE1"),
+		OptimismPortal:     common.HexToAddress("0xbEb5Fc579115071764c7423A4f12eDde41f106Ed"),
+		DisputeGameFactory: common.HexToAddress("0xe5965Ab5962eDc7477C8520243A95517CD252fA9"),
+		WithdrawalPeriod:   opStackWithdrawalPeriod,
+	},
+	{
+		Kind:               KindOPStack,
+		L1ChainID:          walletCommon.EthereumSepolia,
+		L2ChainID:          walletCommon.OptimismSepolia,
+		L1StandardBridge:   common.HexToAddress("0xFBb0621E0B23b5478B630BD55a5f21f67730B0F1"),
+		OptimismPortal:     common.HexToAddress("0x16Fc5058F25648194471939df75CF27A2fdC48BC"),
+		DisputeGameFactory: common.HexToAddress("0x05F9613aDB30026FFd634f38e5C4dFd30a197Fa1"),
+		WithdrawalPeriod:   opStackWithdrawalPeriod,
+	},
+	{
+		Kind:               KindOPStack,
+		L1ChainID:          walletCommon.EthereumMainnet,
+		L2ChainID:          walletCommon.BaseMainnet,
+		L1StandardBridge:   common.HexToAddress("0x3154Cf16ccdb4C6d922629664174b904d80F2C35"),
+		OptimismPortal:     common.HexToAddress("0x49048044D57e1C92A77f79988d21Fa8fAF74E97e"),
+		DisputeGameFactory: common.HexToAddress("0x43edB88C4B80fDD2AdFF2412A7BebF9dF42cB40e"),
+		WithdrawalPeriod:   opStackWithdrawalPeriod,
+	},
+	{
+		Kind:               KindOPStack,
+		L1ChainID:          walletCommon.EthereumSepolia,
+		L2ChainID:          walletCommon.BaseSepolia,
+		L1StandardBridge:   common.HexToAddress("0xfd0Bf71F60660E2f608ed56e1659C450eB113120"),
+		OptimismPortal:     common.HexToAddress("0x49f53e41452C74589E85cA1677426Ba426459e85"),
+		DisputeGameFactory: common.HexToAddress("0xd6E6dBf4F7EA0ac412fD8b65ED297e64BB7a06E1"),
+		WithdrawalPeriod:   opStackWithdrawalPeriod,
+	},
+	{
+		Kind:             KindArbitrum,
+		L1ChainID:        walletCommon.EthereumMainnet,
+		L2ChainID:        walletCommon.ArbitrumMainnet,
+		Inbox:            common.HexToAddress("0x4Dbd4fc535Ac27206064B68FfCf827b0A60BAB3f"),
+		Outbox:           common.HexToAddress("0x0B9857ae2D4A3DBe74ffE1d7DF045bb7F96E4840"),
+		WithdrawalPeriod: arbitrumWithdrawalPeriod,
+	},
+	{
+		Kind:             KindArbitrum,
+		L1ChainID:        walletCommon.EthereumSepolia,
+		L2ChainID:        walletCommon.ArbitrumSepolia,
+		Inbox:            common.HexToAddress("0xaAe29B0366299461418F5324a79Afc425BE5ae21"),
+		Outbox:           common.HexToAddress("0x65f07C7D521164a4d5DaC6eB8Fac8DA067A3B78F"),
+		WithdrawalPeriod: arbitrumWithdrawalPeriod,
+	},
+}
+
+// FindBridge returns the canonical bridge between the chains, `deposit` is true when funds are moved from L1 to L2
+func FindBridge(fromChainID uint64, toChainID uint64) (bridge *Bridge, deposit bool, found bool) {
+	for _, b := range bridges {
+		if b.L1ChainID == fromChainID && b.L2ChainID == toChainID {
+			return b, true, true
+		}
+		if b.L2ChainID == fromChainID && b.L1ChainID == toChainID {
+			return b, false, true
+		}
+	}
+	return nil, false, false
+}
+
+// IsWithdrawal returns true if moving funds between the chains is a withdrawal through a canonical bridge
+func IsWithdrawal(fromChainID uint64, toChainID uint64) bool {
+	_, deposit, found := FindBridge(fromChainID, toChainID)
+	return found && !deposit
+}
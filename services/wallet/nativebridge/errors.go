@@ -0,0 +1,18 @@
+package nativebridge
+
+import (
+	"github.com/status-im/status-go/errors"
+)
+
+// Abbreviation `WNB` for the error code stands for Wallet Native Bridge
+var (
+	ErrWithdrawalNotFound      = &errors.ErrorResponse{Code: errors.ErrorCode("WNB-001"), Details: "bridge withdrawal not found"}
+	ErrBridgeNotFound          = &errors.ErrorResponse{Code: errors.ErrorCode("WNB-002"), Details: "no canonical bridge between the chains"}
+	ErrNotAWithdrawal          = &errors.ErrorResponse{Code: errors.ErrorCode("WNB-003"), Details: "the transaction is a deposit, not a withdrawal"}
+	ErrWithdrawalNotReady      = &errors.ErrorResponse{Code: errors.ErrorCode("WNB-004"), Details: "the withdrawal is not ready for this step"}
+	ErrWithdrawalMessageNotSet = &errors.ErrorResponse{Code: errors.ErrorCode("WNB-005"), Details: "the withdrawal message is not known yet"}
+	ErrProveNotRequired        = &errors.ErrorResponse{Code: errors.ErrorCode("WNB-006"), Details: "withdrawals of the bridge don't need to be proven"}
+	ErrMessageNotFound         = &errors.ErrorResponse{Code: errors.ErrorCode("WNB-008"), Details: "no withdrawal message in the transaction logs"}
+	ErrOutputRootMismatch      = &errors.ErrorResponse{Code: errors.ErrorCode("WNB-009"), Details: "the output root proof doesn't match the dispute game claim"}
+	ErrNoDisputeGame           = &errors.ErrorResponse{Code: errors.ErrorCode("WNB-010"), Details: "no dispute game covers the withdrawal yet"}
+)
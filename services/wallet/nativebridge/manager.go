@@ -0,0 +1,493 @@
+package nativebridge
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/status-im/status-go/account"
+	gocommon "github.com/status-im/status-go/common"
+	ethTypes "github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/rpc"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+	"github.com/status-im/status-go/transactions"
+)
+
+const (
+	// EventBridgeWithdrawalUpdated is sent when a withdrawal moves to another stage
+	EventBridgeWithdrawalUpdated walletevent.EventType = "wallet-bridge-withdrawal-updated"
+
+	checkInterval = 5 * time.Minute
+)
+
+// Manager tracks the withdrawals sent through the canonical bridges and sends the prove and finalize txs on L1
+type Manager struct {
+	rpcClient   rpc.ClientInterface
+	transactor  transactions.TransactorIface
+	persistence *Persistence
+	walletFeed  *event.Feed
+	cancelFn    context.CancelFunc
+	mu          sync.Mutex
+	now         func() time.Time
+}
+
+func NewManager(db *sql.DB, rpcClient rpc.ClientInterface, transactor transactions.TransactorIface, walletFeed *event.Feed) *Manager {
+	return &Manager{
+		rpcClient:   rpcClient,
+		transactor:  transactor,
+		persistence: NewPersistence(db),
+		walletFeed:  walletFeed,
+		now:         time.Now,
+	}
+}
+
+func (m *Manager) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelFn = cancel
+
+	go func() {
+		defer gocommon.LogOnPanic()
+		m.checkWithdrawals(ctx)
+
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.checkWithdrawals(ctx)
+			}
+		}
+	}()
+}
+
+func (m *Manager) Stop() {
+	if m.cancelFn != nil {
+		m.cancelFn()
+	}
+}
+
+// TrackWithdrawal starts tracking the withdrawal sent on L2 as part of the bridge multi-transaction
+func (m *Manager) TrackWithdrawal(multiTransactionID walletCommon.MultiTransactionIDType, fromChainID uint64, toChainID uint64,
+	txHash common.Hash, from common.Address, to common.Address, tokenID string, amount *hexutil.Big) (*Withdrawal, error) {
+	bridge, deposit, found := FindBridge(fromChainID, toChainID)
+	if !found {
+		return nil, ErrBridgeNotFound
+	}
+	if deposit {
+		return nil, ErrNotAWithdrawal
+	}
+
+	now := m.now().Unix()
+	w := &Withdrawal{
+		MultiTransactionID: multiTransactionID,
+		Kind:               bridge.Kind,
+		L1ChainID:          bridge.L1ChainID,
+		L2ChainID:          bridge.L2ChainID,
+		TxHash:             txHash,
+		FromAddress:        from,
+		ToAddress:          to,
+		TokenID:            tokenID,
+		Amount:             amount,
+		Stage:              StageInitiated,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var err error
+	w.ID, err = m.persistence.InsertWithdrawal(w)
+	if err != nil {
+		return nil, err
+	}
+	m.sendUpdated(w)
+	return w, nil
+}
+
+func (m *Manager) GetWithdrawal(id int64) (*Withdrawal, error) {
+	return m.persistence.GetWithdrawal(id)
+}
+
+// GetWithdrawals returns the tracked withdrawals, newest first
+func (m *Manager) GetWithdrawals(pendingOnly bool) ([]*Withdrawal, error) {
+	return m.persistence.GetWithdrawals(pendingOnly)
+}
+
+func (m *Manager) checkWithdrawals(ctx context.Context) {
+	withdrawals, err := m.persistence.GetWithdrawals(true)
+	if err != nil {
+		log.Error("native bridge: failed to get withdrawals", "err", err)
+		return
+	}
+
+	for _, w := range withdrawals {
+		if ctx.Err() != nil {
+			return
+		}
+		err = m.checkWithdrawal(ctx, w.ID)
+		if err != nil {
+			log.Warn("native bridge: failed to check withdrawal", "id", w.ID, "err", err)
+		}
+	}
+}
+
+// checkWithdrawal moves the withdrawal to the next stage once it's reached.
+// The chains are queried without holding the lock, the withdrawal is only updated if it wasn't meanwhile.
+func (m *Manager) checkWithdrawal(ctx context.Context, id int64) error {
+	w, err := m.persistence.GetWithdrawal(id)
+	if err != nil {
+		return err
+	}
+	bridge, _, found := FindBridge(w.L2ChainID, w.L1ChainID)
+	if !found {
+		return ErrBridgeNotFound
+	}
+
+	stage, readyAt, lastError, messageSet, updatedAt := w.Stage, w.ReadyAt, w.LastError, w.message != nil, w.UpdatedAt
+	err = m.nextStage(ctx, bridge, w)
+	if err != nil {
+		return err
+	}
+	if w.Stage == stage && w.ReadyAt == readyAt && w.LastError == lastError && (w.message != nil) == messageSet {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// e.g. the prove tx was sent meanwhile, the next check starts from the new stage
+	current, err := m.persistence.GetWithdrawal(id)
+	if err != nil {
+		return err
+	}
+	if current.Stage != stage || current.UpdatedAt != updatedAt {
+		return nil
+	}
+
+	w.UpdatedAt = m.now().Unix()
+	err = m.persistence.UpdateWithdrawal(w)
+	if err != nil {
+		return err
+	}
+	m.sendUpdated(w)
+	return nil
+}
+
+// receipt returns the receipt of a mined tx, nil if the tx is still pending
+func (m *Manager) receipt(ctx context.Context, chainID uint64, hash common.Hash) (*types.Receipt, error) {
+	client, err := m.rpcClient.EthClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := client.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	return receipt, err
+}
+
+func (m *Manager) nextStage(ctx context.Context, bridge *Bridge, w *Withdrawal) error {
+	l1Client, err := m.rpcClient.EthClient(w.L1ChainID)
+	if err != nil {
+		return err
+	}
+
+	switch w.Stage {
+	case StageInitiated:
+		if w.message == nil {
+			receipt, err := m.receipt(ctx, w.L2ChainID, w.TxHash)
+			if err != nil || receipt == nil {
+				return err
+			}
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				w.Stage = StageFailed
+				w.LastError = "withdrawal transaction failed"
+				return nil
+			}
+
+			if bridge.Kind == KindOPStack {
+				w.message, err = opStackMessageFromReceipt(receipt)
+			} else {
+				w.message, err = arbitrumMessageFromReceipt(receipt)
+			}
+			if err == ErrMessageNotFound {
+				w.Stage = StageFailed
+				w.LastError = err.Error()
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if bridge.Kind == KindArbitrum {
+				w.ReadyAt = int64(w.message.Timestamp) + int64(bridge.WithdrawalPeriod.Seconds())
+			} else {
+				// output roots are proposed about every hour
+				w.ReadyAt = m.now().Add(time.Hour).Unix()
+			}
+		}
+
+		if bridge.Kind == KindArbitrum {
+			// the send root covering the message is confirmed after the challenge period
+			if m.now().Unix() < w.ReadyAt {
+				return nil
+			}
+			l2Client, err := m.rpcClient.EthClient(w.L2ChainID)
+			if err != nil {
+				return err
+			}
+			executable, err := isArbitrumExecutable(ctx, l1Client, l2Client, bridge, w.message)
+			if err != nil {
+				return err
+			}
+			if executable {
+				w.Stage = StageReadyToFinalize
+				w.ReadyAt = 0
+			}
+			return nil
+		}
+
+		provable, err := isOPStackProvable(ctx, l1Client, bridge, w.message)
+		if err != nil {
+			return err
+		}
+		if provable {
+			w.Stage = StageReadyToProve
+			w.ReadyAt = 0
+		}
+
+	case StageProving:
+		receipt, err := m.receipt(ctx, w.L1ChainID, w.ProveTxHash)
+		if err != nil || receipt == nil {
+			return err
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			w.Stage = StageReadyToProve
+			w.LastError = "prove transaction failed"
+			return nil
+		}
+		w.Stage = StageProven
+		w.ReadyAt = m.now().Add(bridge.WithdrawalPeriod).Unix()
+
+	case StageProven:
+		if isOPStackFinalizable(ctx, l1Client, bridge, w.message, w.FromAddress) {
+			w.Stage = StageReadyToFinalize
+			w.ReadyAt = 0
+		}
+
+	case StageReadyToFinalize:
+		// the withdrawal can also be finalized outside of the wallet
+		var finalized bool
+		if bridge.Kind == KindOPStack {
+			finalized, err = isOPStackFinalized(ctx, l1Client, bridge, w.message)
+		} else {
+			finalized, err = isArbitrumFinalized(ctx, l1Client, bridge, w.message)
+		}
+		if err != nil {
+			return err
+		}
+		if finalized {
+			w.Stage = StageFinalized
+		}
+
+	case StageFinalizing:
+		receipt, err := m.receipt(ctx, w.L1ChainID, w.FinalizeTxHash)
+		if err != nil || receipt == nil {
+			return err
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			w.Stage = StageReadyToFinalize
+			w.LastError = "finalize transaction failed"
+			return nil
+		}
+		w.Stage = StageFinalized
+	}
+	return nil
+}
+
+func (m *Manager) withdrawalInStage(id int64, stage Stage) (*Withdrawal, *Bridge, error) {
+	w, err := m.persistence.GetWithdrawal(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if w.Stage != stage {
+		return nil, nil, ErrWithdrawalNotReady
+	}
+	if w.message == nil {
+		return nil, nil, ErrWithdrawalMessageNotSet
+	}
+	bridge, _, found := FindBridge(w.L2ChainID, w.L1ChainID)
+	if !found {
+		return nil, nil, ErrBridgeNotFound
+	}
+	return w, bridge, nil
+}
+
+func withdrawalTxArgs(w *Withdrawal, to common.Address, data []byte) *transactions.SendTxArgs {
+	toAddress := ethTypes.Address(to)
+	return &transactions.SendTxArgs{
+		From:               ethTypes.Address(w.FromAddress),
+		To:                 &toAddress,
+		Value:              (*hexutil.Big)(big.NewInt(0)),
+		Data:               data,
+		MultiTransactionID: w.MultiTransactionID,
+	}
+}
+
+// ProveTxArgs returns the args of the L1 tx proving an OP Stack withdrawal, the tx has to be sent by the withdrawal account
+// since only the account proving the withdrawal can finalize it
+func (m *Manager) ProveTxArgs(ctx context.Context, id int64) (*transactions.SendTxArgs, error) {
+	w, bridge, err := m.withdrawalInStage(id, StageReadyToProve)
+	if err != nil {
+		return nil, err
+	}
+	if bridge.Kind != KindOPStack {
+		return nil, ErrProveNotRequired
+	}
+
+	l1Client, err := m.rpcClient.EthClient(w.L1ChainID)
+	if err != nil {
+		return nil, err
+	}
+	l2Client, err := m.rpcClient.EthClient(w.L2ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := packOPStackProve(ctx, l1Client, l2Client, bridge, w.message)
+	if err != nil {
+		return nil, err
+	}
+	return withdrawalTxArgs(w, bridge.OptimismPortal, data), nil
+}
+
+// FinalizeTxArgs returns the args of the L1 tx releasing the withdrawn funds, Arbitrum messages are executed on the outbox
+// with the proof built by the L2 node interface
+func (m *Manager) FinalizeTxArgs(ctx context.Context, id int64) (*transactions.SendTxArgs, error) {
+	w, bridge, err := m.withdrawalInStage(id, StageReadyToFinalize)
+	if err != nil {
+		return nil, err
+	}
+
+	if bridge.Kind == KindArbitrum {
+		l1Client, err := m.rpcClient.EthClient(w.L1ChainID)
+		if err != nil {
+			return nil, err
+		}
+		l2Client, err := m.rpcClient.EthClient(w.L2ChainID)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := packArbitrumExecute(ctx, l1Client, l2Client, bridge, w.message)
+		if err != nil {
+			return nil, err
+		}
+		return withdrawalTxArgs(w, bridge.Outbox, data), nil
+	}
+
+	data, err := packOPStackFinalize(w.message)
+	if err != nil {
+		return nil, err
+	}
+	return withdrawalTxArgs(w, bridge.OptimismPortal, data), nil
+}
+
+// SetStageTxHash stores the hash of the sent prove or finalize tx, the stage is updated once the tx is mined
+func (m *Manager) SetStageTxHash(id int64, hash common.Hash) (*Withdrawal, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, err := m.persistence.GetWithdrawal(id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch w.Stage {
+	case StageReadyToProve:
+		w.Stage = StageProving
+		w.ProveTxHash = hash
+	case StageReadyToFinalize:
+		w.Stage = StageFinalizing
+		w.FinalizeTxHash = hash
+	default:
+		return nil, ErrWithdrawalNotReady
+	}
+	w.LastError = ""
+	w.UpdatedAt = m.now().Unix()
+
+	err = m.persistence.UpdateWithdrawal(w)
+	if err != nil {
+		return nil, err
+	}
+	m.sendUpdated(w)
+	return w, nil
+}
+
+func (m *Manager) sendStageTx(id int64, sendArgs *transactions.SendTxArgs, verifiedAccount *account.SelectedExtKey) (ethTypes.Hash, error) {
+	w, err := m.persistence.GetWithdrawal(id)
+	if err != nil {
+		return ethTypes.Hash{}, err
+	}
+
+	hash, _, err := m.transactor.SendTransactionWithChainID(w.L1ChainID, *sendArgs, -1, verifiedAccount)
+	if err != nil {
+		return ethTypes.Hash{}, err
+	}
+
+	_, err = m.SetStageTxHash(id, common.Hash(hash))
+	return hash, err
+}
+
+// Prove sends the tx proving the withdrawal on L1
+func (m *Manager) Prove(ctx context.Context, id int64, verifiedAccount *account.SelectedExtKey) (ethTypes.Hash, error) {
+	sendArgs, err := m.ProveTxArgs(ctx, id)
+	if err != nil {
+		return ethTypes.Hash{}, err
+	}
+	return m.sendStageTx(id, sendArgs, verifiedAccount)
+}
+
+// Finalize sends the tx finalizing the withdrawal on L1
+func (m *Manager) Finalize(ctx context.Context, id int64, verifiedAccount *account.SelectedExtKey) (ethTypes.Hash, error) {
+	sendArgs, err := m.FinalizeTxArgs(ctx, id)
+	if err != nil {
+		return ethTypes.Hash{}, err
+	}
+	return m.sendStageTx(id, sendArgs, verifiedAccount)
+}
+
+func (m *Manager) sendUpdated(w *Withdrawal) {
+	if m.walletFeed == nil {
+		return
+	}
+
+	message, err := json.Marshal(w)
+	if err != nil {
+		log.Error("native bridge: failed to marshal withdrawal", "id", w.ID, "err", err)
+		return
+	}
+
+	m.walletFeed.Send(walletevent.Event{
+		Type:     EventBridgeWithdrawalUpdated,
+		Accounts: []common.Address{w.FromAddress},
+		ChainID:  w.L1ChainID,
+		Message:  string(message),
+		At:       m.now().Unix(),
+	})
+}
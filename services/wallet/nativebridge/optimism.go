@@ -0,0 +1,268 @@
+package nativebridge
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/status-im/status-go/rpc/chain"
+)
+
+const (
+	// L2ToL1MessagePasser predeploy on L2
+	l2ToL1MessagePasserABI = `[
+		{"anonymous":false,"inputs":[{"indexed":true,"name":"nonce","type":"uint256"},{"indexed":true,"name":"sender","type":"address"},{"indexed":true,"name":"target","type":"address"},{"indexed":false,"name":"value","type":"uint256"},{"indexed":false,"name":"gasLimit","type":"uint256"},{"indexed":false,"name":"data","type":"bytes"},{"indexed":false,"name":"withdrawalHash","type":"bytes32"}],"name":"MessagePassed","type":"event"}
+	]`
+	// OptimismPortal on L1, the version using the fault proofs
+	optimismPortalABI = `[
+		{"inputs":[],"name":"respectedGameType","outputs":[{"name":"","type":"uint32"}],"stateMutability":"view","type":"function"},
+		{"inputs":[{"name":"_withdrawalHash","type":"bytes32"},{"name":"_proofSubmitter","type":"address"}],"name":"checkWithdrawal","outputs":[],"stateMutability":"view","type":"function"},
+		{"inputs":[{"name":"","type":"bytes32"}],"name":"finalizedWithdrawals","outputs":[{"name":"","type":"bool"}],"stateMutability":"view","type":"function"},
+		{"inputs":[{"components":[{"name":"nonce","type":"uint256"},{"name":"sender","type":"address"},{"name":"target","type":"address"},{"name":"value","type":"uint256"},{"name":"gasLimit","type":"uint256"},{"name":"data","type":"bytes"}],"name":"_tx","type":"tuple"},{"name":"_disputeGameIndex","type":"uint256"},{"components":[{"name":"version","type":"bytes32"},{"name":"stateRoot","type":"bytes32"},{"name":"messagePasserStorageRoot","type":"bytes32"},{"name":"latestBlockhash","type":"bytes32"}],"name":"_outputRootProof","type":"tuple"},{"name":"_withdrawalProof","type":"bytes[]"}],"name":"proveWithdrawalTransaction","outputs":[],"stateMutability":"nonpayable","type":"function"},
+		{"inputs":[{"components":[{"name":"nonce","type":"uint256"},{"name":"sender","type":"address"},{"name":"target","type":"address"},{"name":"value","type":"uint256"},{"name":"gasLimit","type":"uint256"},{"name":"data","type":"bytes"}],"name":"_tx","type":"tuple"}],"name":"finalizeWithdrawalTransaction","outputs":[],"stateMutability":"nonpayable","type":"function"}
+	]`
+	// DisputeGameFactory on L1
+	disputeGameFactoryABI = `[
+		{"inputs":[],"name":"gameCount","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+		{"inputs":[{"name":"_gameType","type":"uint32"},{"name":"_start","type":"uint256"},{"name":"_n","type":"uint256"}],"name":"findLatestGames","outputs":[{"components":[{"name":"index","type":"uint256"},{"name":"metadata","type":"bytes32"},{"name":"timestamp","type":"uint64"},{"name":"rootClaim","type":"bytes32"},{"name":"extraData","type":"bytes"}],"name":"games_","type":"tuple[]"}],"stateMutability":"view","type":"function"}
+	]`
+)
+
+// withdrawalTransaction matches the `Types.WithdrawalTransaction` struct of the OptimismPortal
+type withdrawalTransaction struct {
+	Nonce    *big.Int
+	Sender   common.Address
+	Target   common.Address
+	Value    *big.Int
+	GasLimit *big.Int
+	Data     []byte
+}
+
+// outputRootProof matches the `Types.OutputRootProof` struct of the OptimismPortal
+type outputRootProof struct {
+	Version                  [32]byte
+	StateRoot                [32]byte
+	MessagePasserStorageRoot [32]byte
+	LatestBlockhash          [32]byte
+}
+
+func (p outputRootProof) hash() common.Hash {
+	return crypto.Keccak256Hash(p.Version[:], p.StateRoot[:], p.MessagePasserStorageRoot[:], p.LatestBlockhash[:])
+}
+
+type gameSearchResult struct {
+	Index     *big.Int
+	Metadata  [32]byte
+	Timestamp uint64
+	RootClaim [32]byte
+	ExtraData []byte
+}
+
+type disputeGame struct {
+	Index         *big.Int
+	RootClaim     common.Hash
+	L2BlockNumber uint64
+}
+
+func (m *withdrawalMessage) opStackTransaction() withdrawalTransaction {
+	return withdrawalTransaction{
+		Nonce:    m.Nonce.ToInt(),
+		Sender:   m.Sender,
+		Target:   m.Target,
+		Value:    m.Value.ToInt(),
+		GasLimit: m.GasLimit.ToInt(),
+		Data:     m.Data,
+	}
+}
+
+// opStackMessageFromReceipt returns the message passed to L1 by the withdrawal tx
+func opStackMessageFromReceipt(receipt *types.Receipt) (*withdrawalMessage, error) {
+	passerABI, err := abi.JSON(strings.NewReader(l2ToL1MessagePasserABI))
+	if err != nil {
+		return nil, err
+	}
+	event := passerABI.Events["MessagePassed"]
+
+	for _, log := range receipt.Logs {
+		if log.Address != OPStackL2ToL1MessagePasser || len(log.Topics) != 4 || log.Topics[0] != event.ID {
+			continue
+		}
+
+		values, err := event.Inputs.NonIndexed().Unpack(log.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		withdrawalHash := values[3].([32]byte)
+		return &withdrawalMessage{
+			L2BlockNumber:  receipt.BlockNumber.Uint64(),
+			Nonce:          (*hexutil.Big)(log.Topics[1].Big()),
+			Sender:         common.BytesToAddress(log.Topics[2].Bytes()),
+			Target:         common.BytesToAddress(log.Topics[3].Bytes()),
+			Value:          (*hexutil.Big)(values[0].(*big.Int)),
+			GasLimit:       (*hexutil.Big)(values[1].(*big.Int)),
+			Data:           values[2].([]byte),
+			WithdrawalHash: common.Hash(withdrawalHash),
+		}, nil
+	}
+	return nil, ErrMessageNotFound
+}
+
+func callContract(ctx context.Context, client chain.ClientInterface, contract common.Address, contractABI string, method string,
+	args ...interface{}) ([]interface{}, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		return nil, err
+	}
+	input, err := parsedABI.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := client.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: input}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parsedABI.Unpack(method, output)
+}
+
+// latestDisputeGame returns the latest dispute game of the type accepted by the portal, withdrawals from the L2 blocks up to
+// the one of the game can be proven against it
+func latestDisputeGame(ctx context.Context, l1Client chain.ClientInterface, bridge *Bridge) (*disputeGame, error) {
+	values, err := callContract(ctx, l1Client, bridge.OptimismPortal, optimismPortalABI, "respectedGameType")
+	if err != nil {
+		return nil, err
+	}
+	gameType := values[0].(uint32)
+
+	values, err = callContract(ctx, l1Client, bridge.DisputeGameFactory, disputeGameFactoryABI, "gameCount")
+	if err != nil {
+		return nil, err
+	}
+	gameCount := values[0].(*big.Int)
+	if gameCount.Sign() == 0 {
+		return nil, ErrNoDisputeGame
+	}
+
+	values, err = callContract(ctx, l1Client, bridge.DisputeGameFactory, disputeGameFactoryABI, "findLatestGames", gameType,
+		new(big.Int).Sub(gameCount, big.NewInt(1)), big.NewInt(1))
+	if err != nil {
+		return nil, err
+	}
+	games := *abi.ConvertType(values[0], new([]gameSearchResult)).(*[]gameSearchResult)
+	// the extra data of the output games starts with the L2 block number
+	if len(games) == 0 || len(games[0].ExtraData) < common.HashLength {
+		return nil, ErrNoDisputeGame
+	}
+
+	return &disputeGame{
+		Index:         games[0].Index,
+		RootClaim:     common.Hash(games[0].RootClaim),
+		L2BlockNumber: new(big.Int).SetBytes(games[0].ExtraData[:common.HashLength]).Uint64(),
+	}, nil
+}
+
+func isOPStackProvable(ctx context.Context, l1Client chain.ClientInterface, bridge *Bridge, message *withdrawalMessage) (bool, error) {
+	game, err := latestDisputeGame(ctx, l1Client, bridge)
+	if err == ErrNoDisputeGame {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return game.L2BlockNumber >= message.L2BlockNumber, nil
+}
+
+// isOPStackFinalizable returns true once the proof is mature and the dispute game is resolved, the portal reverts the check
+// call otherwise
+func isOPStackFinalizable(ctx context.Context, l1Client chain.ClientInterface, bridge *Bridge, message *withdrawalMessage,
+	proofSubmitter common.Address) bool {
+	_, err := callContract(ctx, l1Client, bridge.OptimismPortal, optimismPortalABI, "checkWithdrawal", message.WithdrawalHash,
+		proofSubmitter)
+	return err == nil
+}
+
+func isOPStackFinalized(ctx context.Context, l1Client chain.ClientInterface, bridge *Bridge, message *withdrawalMessage) (bool, error) {
+	values, err := callContract(ctx, l1Client, bridge.OptimismPortal, optimismPortalABI, "finalizedWithdrawals", message.WithdrawalHash)
+	if err != nil {
+		return false, err
+	}
+	return values[0].(bool), nil
+}
+
+type l2Block struct {
+	Hash      common.Hash `json:"hash"`
+	StateRoot common.Hash `json:"stateRoot"`
+}
+
+type storageProof struct {
+	StorageHash  common.Hash `json:"storageHash"`
+	StorageProof []struct {
+		Proof []hexutil.Bytes `json:"proof"`
+	} `json:"storageProof"`
+}
+
+// packOPStackProve builds the data of the tx proving the withdrawal against the latest dispute game
+func packOPStackProve(ctx context.Context, l1Client chain.ClientInterface, l2Client chain.ClientInterface, bridge *Bridge,
+	message *withdrawalMessage) ([]byte, error) {
+	game, err := latestDisputeGame(ctx, l1Client, bridge)
+	if err != nil {
+		return nil, err
+	}
+	if game.L2BlockNumber < message.L2BlockNumber {
+		return nil, ErrNoDisputeGame
+	}
+	blockNumber := hexutil.EncodeUint64(game.L2BlockNumber)
+
+	var block l2Block
+	err = l2Client.CallContext(ctx, &block, "eth_getBlockByNumber", blockNumber, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// the withdrawal is stored in the `sentMessages` mapping, the first slot of the message passer
+	slot := crypto.Keccak256Hash(message.WithdrawalHash.Bytes(), common.Hash{}.Bytes())
+	var proof storageProof
+	err = l2Client.CallContext(ctx, &proof, "eth_getProof", OPStackL2ToL1MessagePasser, []string{slot.Hex()}, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if len(proof.StorageProof) == 0 {
+		return nil, ErrMessageNotFound
+	}
+
+	rootProof := outputRootProof{
+		StateRoot:                block.StateRoot,
+		MessagePasserStorageRoot: proof.StorageHash,
+		LatestBlockhash:          block.Hash,
+	}
+	if rootProof.hash() != game.RootClaim {
+		return nil, ErrOutputRootMismatch
+	}
+
+	withdrawalProof := make([][]byte, 0, len(proof.StorageProof[0].Proof))
+	for _, node := range proof.StorageProof[0].Proof {
+		withdrawalProof = append(withdrawalProof, node)
+	}
+
+	portalABI, err := abi.JSON(strings.NewReader(optimismPortalABI))
+	if err != nil {
+		return nil, err
+	}
+	return portalABI.Pack("proveWithdrawalTransaction", message.opStackTransaction(), game.Index, rootProof, withdrawalProof)
+}
+
+func packOPStackFinalize(message *withdrawalMessage) ([]byte, error) {
+	portalABI, err := abi.JSON(strings.NewReader(optimismPortalABI))
+	if err != nil {
+		return nil, err
+	}
+	return portalABI.Pack("finalizeWithdrawalTransaction", message.opStackTransaction())
+}
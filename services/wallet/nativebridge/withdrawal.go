@@ -0,0 +1,208 @@
+package nativebridge
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+)
+
+// Stage is the step a withdrawal is at, OP Stack withdrawals have to be proven on L1 before they can be finalized,
+// Arbitrum withdrawals only have to be finalized
+type Stage int
+
+const (
+	StageInitiated       Stage = iota // the withdrawal tx is sent on L2
+	StageReadyToProve                 // the L2 block of the withdrawal is covered by a proposed output root
+	StageProving                      // the prove tx is sent on L1
+	StageProven                       // waiting for the proof maturity delay
+	StageReadyToFinalize              // the funds can be claimed on L1
+	StageFinalizing                   // the finalize tx is sent on L1
+	StageFinalized
+	StageFailed // the withdrawal tx failed on L2
+)
+
+func (s Stage) IsPending() bool {
+	return s != StageFinalized && s != StageFailed
+}
+
+// Withdrawal is a withdrawal sent through a canonical bridge, it belongs to the bridge multi-transaction. The prove and
+// finalize txs are sent as part of the same multi-transaction.
+type Withdrawal struct {
+	ID                 int64                               `json:"id"`
+	MultiTransactionID walletCommon.MultiTransactionIDType `json:"multiTransactionId"`
+	Kind               Kind                                `json:"kind"`
+	L1ChainID          uint64                              `json:"l1ChainId"`
+	L2ChainID          uint64                              `json:"l2ChainId"`
+	TxHash             common.Hash                         `json:"txHash"`
+	FromAddress        common.Address                      `json:"fromAddress"`
+	ToAddress          common.Address                      `json:"toAddress"`
+	TokenID            string                              `json:"tokenId"`
+	Amount             *hexutil.Big                        `json:"amount"`
+	Stage              Stage                               `json:"stage"`
+	ProveTxHash        common.Hash                         `json:"proveTxHash"`
+	FinalizeTxHash     common.Hash                         `json:"finalizeTxHash"`
+	ReadyAt            int64                               `json:"readyAt"` // estimated time of the next step, 0 if unknown
+	LastError          string                              `json:"lastError"`
+	CreatedAt          int64                               `json:"createdAt"`
+	UpdatedAt          int64                               `json:"updatedAt"`
+
+	message *withdrawalMessage
+}
+
+// withdrawalMessage is the L2 to L1 message sent by the withdrawal tx, only the fields of the bridge kind are set
+type withdrawalMessage struct {
+	L2BlockNumber uint64 `json:"l2BlockNumber"`
+
+	// OP Stack `MessagePassed` event
+	Nonce          *hexutil.Big   `json:"nonce,omitempty"`
+	Sender         common.Address `json:"sender,omitempty"`
+	Target         common.Address `json:"target,omitempty"`
+	Value          *hexutil.Big   `json:"value,omitempty"`
+	GasLimit       *hexutil.Big   `json:"gasLimit,omitempty"`
+	Data           hexutil.Bytes  `json:"data,omitempty"`
+	WithdrawalHash common.Hash    `json:"withdrawalHash,omitempty"`
+
+	// Arbitrum `L2ToL1Tx` event, the sender, target, value and data are shared with OP Stack
+	Position      *hexutil.Big `json:"position,omitempty"`
+	L1BlockNumber uint64       `json:"l1BlockNumber,omitempty"`
+	Timestamp     uint64       `json:"timestamp,omitempty"`
+}
+
+type Persistence struct {
+	db *sql.DB
+}
+
+func NewPersistence(db *sql.DB) *Persistence {
+	return &Persistence{
+		db: db,
+	}
+}
+
+const withdrawalColumns = `id, multi_transaction_id, kind, l1_chain_id, l2_chain_id, tx_hash, from_address, to_address, token_id, amount,
+	stage, prove_tx_hash, finalize_tx_hash, ready_at, last_error, message, created_at, updated_at`
+
+func encodeMessage(m *withdrawalMessage) (string, error) {
+	if m == nil {
+		return "", nil
+	}
+	encoded, err := json.Marshal(m)
+	return string(encoded), err
+}
+
+func (p *Persistence) InsertWithdrawal(w *Withdrawal) (int64, error) {
+	message, err := encodeMessage(w.message)
+	if err != nil {
+		return 0, err
+	}
+
+	amount := "0x0"
+	if w.Amount != nil {
+		amount = w.Amount.String()
+	}
+
+	res, err := p.db.Exec(`INSERT INTO bridge_withdrawals (multi_transaction_id, kind, l1_chain_id, l2_chain_id, tx_hash, from_address,
+		to_address, token_id, amount, stage, prove_tx_hash, finalize_tx_hash, ready_at, last_error, message, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		w.MultiTransactionID, w.Kind, w.L1ChainID, w.L2ChainID, w.TxHash, w.FromAddress, w.ToAddress, w.TokenID, amount, w.Stage,
+		w.ProveTxHash, w.FinalizeTxHash, w.ReadyAt, w.LastError, message, w.CreatedAt, w.UpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// UpdateWithdrawal stores the progress of the withdrawal
+func (p *Persistence) UpdateWithdrawal(w *Withdrawal) error {
+	message, err := encodeMessage(w.message)
+	if err != nil {
+		return err
+	}
+
+	res, err := p.db.Exec(`UPDATE bridge_withdrawals SET stage = ?, prove_tx_hash = ?, finalize_tx_hash = ?, ready_at = ?, last_error = ?,
+		message = ?, updated_at = ? WHERE id = ?`, w.Stage, w.ProveTxHash, w.FinalizeTxHash, w.ReadyAt, w.LastError, message, w.UpdatedAt, w.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrWithdrawalNotFound
+	}
+	return nil
+}
+
+func (p *Persistence) GetWithdrawal(id int64) (*Withdrawal, error) {
+	rows, err := p.db.Query(fmt.Sprintf("SELECT %s FROM bridge_withdrawals WHERE id = ?", withdrawalColumns), id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	withdrawals, err := rowsToWithdrawals(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(withdrawals) == 0 {
+		return nil, ErrWithdrawalNotFound
+	}
+	return withdrawals[0], nil
+}
+
+// GetWithdrawals returns the withdrawals, newest first, only the not finalized ones if `pendingOnly` is set
+func (p *Persistence) GetWithdrawals(pendingOnly bool) ([]*Withdrawal, error) {
+	query := fmt.Sprintf("SELECT %s FROM bridge_withdrawals", withdrawalColumns)
+	args := []interface{}{}
+	if pendingOnly {
+		query += " WHERE stage NOT IN (?, ?)"
+		args = append(args, StageFinalized, StageFailed)
+	}
+	query += " ORDER BY id DESC"
+
+	rows, err := p.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return rowsToWithdrawals(rows)
+}
+
+func rowsToWithdrawals(rows *sql.Rows) ([]*Withdrawal, error) {
+	withdrawals := make([]*Withdrawal, 0)
+	for rows.Next() {
+		w := &Withdrawal{}
+		var amount, message string
+		err := rows.Scan(&w.ID, &w.MultiTransactionID, &w.Kind, &w.L1ChainID, &w.L2ChainID, &w.TxHash, &w.FromAddress, &w.ToAddress,
+			&w.TokenID, &amount, &w.Stage, &w.ProveTxHash, &w.FinalizeTxHash, &w.ReadyAt, &w.LastError, &message, &w.CreatedAt,
+			&w.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		value, ok := new(big.Int).SetString(amount, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid withdrawal amount %s", amount)
+		}
+		w.Amount = (*hexutil.Big)(value)
+
+		if message != "" {
+			w.message = &withdrawalMessage{}
+			err = json.Unmarshal([]byte(message), w.message)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		withdrawals = append(withdrawals, w)
+	}
+	return withdrawals, rows.Err()
+}
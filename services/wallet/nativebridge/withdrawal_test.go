@@ -0,0 +1,196 @@
+package nativebridge
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupWithdrawalsTest(t *testing.T) (*Manager, func()) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+
+	manager := NewManager(db, nil, nil, nil)
+	manager.now = func() time.Time {
+		return time.Unix(1000, 0)
+	}
+	return manager, func() {
+		require.NoError(t, db.Close())
+	}
+}
+
+var (
+	testFrom = common.HexToAddress("0x1")
+	testTo   = common.HexToAddress("0x2")
+)
+
+func TestFindBridge(t *testing.T) {
+	bridge, deposit, found := FindBridge(walletCommon.EthereumMainnet, walletCommon.OptimismMainnet)
+	require.True(t, found)
+	require.True(t, deposit)
+	require.Equal(t, KindOPStack, bridge.Kind)
+
+	bridge, deposit, found = FindBridge(walletCommon.ArbitrumSepolia, walletCommon.EthereumSepolia)
+	require.True(t, found)
+	require.False(t, deposit)
+	require.Equal(t, KindArbitrum, bridge.Kind)
+
+	_, _, found = FindBridge(walletCommon.OptimismMainnet, walletCommon.ArbitrumMainnet)
+	require.False(t, found)
+
+	require.True(t, IsWithdrawal(walletCommon.BaseMainnet, walletCommon.EthereumMainnet))
+	require.False(t, IsWithdrawal(walletCommon.EthereumMainnet, walletCommon.BaseMainnet))
+	require.False(t, IsWithdrawal(walletCommon.BaseMainnet, walletCommon.EthereumSepolia))
+}
+
+func TestTrackWithdrawal(t *testing.T) {
+	manager, cleanup := setupWithdrawalsTest(t)
+	defer cleanup()
+
+	_, err := manager.TrackWithdrawal(1, walletCommon.EthereumMainnet, walletCommon.OptimismMainnet, common.HexToHash("0x1"), testFrom,
+		testTo, "ETH", (*hexutil.Big)(big.NewInt(100)))
+	require.ErrorIs(t, err, ErrNotAWithdrawal)
+
+	_, err = manager.TrackWithdrawal(1, walletCommon.OptimismMainnet, walletCommon.ArbitrumMainnet, common.HexToHash("0x1"), testFrom,
+		testTo, "ETH", (*hexutil.Big)(big.NewInt(100)))
+	require.ErrorIs(t, err, ErrBridgeNotFound)
+
+	w, err := manager.TrackWithdrawal(1, walletCommon.OptimismMainnet, walletCommon.EthereumMainnet, common.HexToHash("0x1"), testFrom,
+		testTo, "ETH", (*hexutil.Big)(big.NewInt(100)))
+	require.NoError(t, err)
+	require.Equal(t, StageInitiated, w.Stage)
+	require.Equal(t, walletCommon.EthereumMainnet, w.L1ChainID)
+	require.Equal(t, walletCommon.OptimismMainnet, w.L2ChainID)
+
+	_, err = manager.TrackWithdrawal(2, walletCommon.ArbitrumMainnet, walletCommon.EthereumMainnet, common.HexToHash("0x2"), testFrom,
+		testTo, "ETH", (*hexutil.Big)(big.NewInt(200)))
+	require.NoError(t, err)
+
+	stored, err := manager.GetWithdrawal(w.ID)
+	require.NoError(t, err)
+	require.Equal(t, w.TxHash, stored.TxHash)
+	require.Equal(t, testFrom, stored.FromAddress)
+	require.Equal(t, testTo, stored.ToAddress)
+	require.Equal(t, big.NewInt(100), stored.Amount.ToInt())
+	require.Equal(t, int64(1000), stored.CreatedAt)
+
+	withdrawals, err := manager.GetWithdrawals(true)
+	require.NoError(t, err)
+	require.Len(t, withdrawals, 2)
+	require.Equal(t, KindArbitrum, withdrawals[0].Kind)
+	require.Equal(t, KindOPStack, withdrawals[1].Kind)
+
+	_, err = manager.GetWithdrawal(100)
+	require.ErrorIs(t, err, ErrWithdrawalNotFound)
+}
+
+func TestWithdrawalStages(t *testing.T) {
+	manager, cleanup := setupWithdrawalsTest(t)
+	defer cleanup()
+
+	w, err := manager.TrackWithdrawal(1, walletCommon.OptimismMainnet, walletCommon.EthereumMainnet, common.HexToHash("0x1"), testFrom,
+		testTo, "ETH", (*hexutil.Big)(big.NewInt(100)))
+	require.NoError(t, err)
+
+	_, err = manager.SetStageTxHash(w.ID, common.HexToHash("0x10"))
+	require.ErrorIs(t, err, ErrWithdrawalNotReady)
+
+	_, err = manager.ProveTxArgs(context.Background(), w.ID)
+	require.ErrorIs(t, err, ErrWithdrawalNotReady)
+
+	w.Stage = StageReadyToProve
+	w.LastError = "prove transaction failed"
+	w.message = &withdrawalMessage{
+		L2BlockNumber:  10,
+		Nonce:          (*hexutil.Big)(big.NewInt(1)),
+		Sender:         OPStackL2StandardBridge,
+		Target:         common.HexToAddress("0x3"),
+		Value:          (*hexutil.Big)(big.NewInt(100)),
+		GasLimit:       (*hexutil.Big)(big.NewInt(200000)),
+		Data:           []byte{1, 2, 3},
+		WithdrawalHash: common.HexToHash("0x4"),
+	}
+	require.NoError(t, manager.persistence.UpdateWithdrawal(w))
+
+	w, err = manager.SetStageTxHash(w.ID, common.HexToHash("0x10"))
+	require.NoError(t, err)
+	require.Equal(t, StageProving, w.Stage)
+	require.Equal(t, common.HexToHash("0x10"), w.ProveTxHash)
+	require.Empty(t, w.LastError)
+
+	stored, err := manager.GetWithdrawal(w.ID)
+	require.NoError(t, err)
+	require.Equal(t, StageProving, stored.Stage)
+	require.Equal(t, w.message, stored.message)
+
+	stored.Stage = StageReadyToFinalize
+	require.NoError(t, manager.persistence.UpdateWithdrawal(stored))
+
+	sendArgs, err := manager.FinalizeTxArgs(context.Background(), w.ID)
+	require.NoError(t, err)
+	require.Equal(t, testFrom, common.Address(sendArgs.From))
+	require.Equal(t, common.HexToAddress("0xbEb5Fc579115071764c7423A4f12eDde41f106Ed"), common.Address(*sendArgs.To))
+	require.Equal(t, walletCommon.MultiTransactionIDType(1), sendArgs.MultiTransactionID)
+
+	w, err = manager.SetStageTxHash(w.ID, common.HexToHash("0x11"))
+	require.NoError(t, err)
+	require.Equal(t, StageFinalizing, w.Stage)
+	require.Equal(t, common.HexToHash("0x11"), w.FinalizeTxHash)
+
+	withdrawals, err := manager.GetWithdrawals(true)
+	require.NoError(t, err)
+	require.Len(t, withdrawals, 1)
+
+	w.Stage = StageFinalized
+	require.NoError(t, manager.persistence.UpdateWithdrawal(w))
+
+	withdrawals, err = manager.GetWithdrawals(true)
+	require.NoError(t, err)
+	require.Len(t, withdrawals, 0)
+}
+
+func TestArbitrumMessageFromReceipt(t *testing.T) {
+	arbSys, err := abi.JSON(strings.NewReader(arbSysABI))
+	require.NoError(t, err)
+	event := arbSys.Events["L2ToL1Tx"]
+
+	data, err := event.Inputs.NonIndexed().Pack(testFrom, big.NewInt(200), big.NewInt(20), big.NewInt(1500), big.NewInt(100), []byte{})
+	require.NoError(t, err)
+	receipt := &types.Receipt{
+		BlockNumber: big.NewInt(200),
+		Logs: []*types.Log{{
+			Address: ArbitrumArbSys,
+			Topics:  []common.Hash{event.ID, common.BytesToHash(testTo.Bytes()), common.HexToHash("0x5"), common.BigToHash(big.NewInt(7))},
+			Data:    data,
+		}},
+	}
+
+	message, err := arbitrumMessageFromReceipt(receipt)
+	require.NoError(t, err)
+	require.Equal(t, &withdrawalMessage{
+		L2BlockNumber: 200,
+		Sender:        testFrom,
+		Target:        testTo,
+		Value:         (*hexutil.Big)(big.NewInt(100)),
+		Data:          []byte{},
+		Position:      (*hexutil.Big)(big.NewInt(7)),
+		L1BlockNumber: 20,
+		Timestamp:     1500,
+	}, message)
+
+	_, err = arbitrumMessageFromReceipt(&types.Receipt{BlockNumber: big.NewInt(200)})
+	require.ErrorIs(t, err, ErrMessageNotFound)
+}
@@ -0,0 +1,104 @@
+package poisoning
+
+import (
+	"database/sql"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	w_common "github.com/status-im/status-go/services/wallet/common"
+)
+
+// Detector flags the transfers used for address poisoning: the transfers from addresses imitating the ones the user
+// sends to, meant to be copied from the activity, and the zero-value or fake token transfers carrying them
+type Detector struct {
+	persistence *Persistence
+}
+
+func NewDetector(db *sql.DB) *Detector {
+	return &Detector{
+		persistence: NewPersistence(db),
+	}
+}
+
+// OnTransfersLoaded assesses the transfers of the accounts once new ones are loaded, it is meant to be the callback of
+// the transfers watcher
+func (d *Detector) OnTransfersLoaded(chainID uint64, addresses []common.Address, _ *big.Int) {
+	for _, address := range addresses {
+		if err := d.AssessTransfers(chainID, address); err != nil {
+			log.Error("failed to assess transfers for address poisoning", "chainID", chainID, "address", address, "error", err)
+		}
+	}
+}
+
+// AssessTransfers flags the transfers of the account on the chain, all of them are assessed again as a lookalike may
+// have been received before the user sent to the address it imitates
+func (d *Detector) AssessTransfers(chainID uint64, account common.Address) error {
+	known, err := d.persistence.knownAddresses()
+	if err != nil {
+		return err
+	}
+
+	transfers, err := d.persistence.accountTransfers(chainID, account)
+	if err != nil {
+		return err
+	}
+
+	assessments := make(map[common.Hash]*Assessment)
+	for _, t := range transfers {
+		if assessment := assess(t, account, known); assessment != nil {
+			assessments[t.hash] = assessment
+		}
+	}
+	return d.persistence.saveAssessments(chainID, account, assessments)
+}
+
+// CheckRecipient returns the warnings for the known addresses the recipient resembles, none if the recipient is known
+func (d *Detector) CheckRecipient(recipient common.Address) ([]*RecipientWarning, error) {
+	known, err := d.persistence.knownAddresses()
+	if err != nil {
+		return nil, err
+	}
+	return known.lookalikes(recipient), nil
+}
+
+// GetAssessments returns the assessments of the flagged transfers among the given ones
+func (d *Detector) GetAssessments(transfers []TransferID) (map[TransferID]*Assessment, error) {
+	return d.persistence.GetAssessments(transfers)
+}
+
+// assess returns the assessment of the transfer of the account, nil if nothing is detected
+func assess(t *accountTransfer, account common.Address, known knownAddresses) *Assessment {
+	outgoing := t.from == account
+	counterparty := t.from
+	if outgoing {
+		counterparty = t.to
+	}
+	if counterparty == account || counterparty == (common.Address{}) {
+		return nil
+	}
+
+	assessment := &Assessment{Counterparty: counterparty}
+	if lookalikes := known.lookalikes(counterparty); len(lookalikes) > 0 {
+		assessment.Flags |= FlagLookalike
+		assessment.Resembles = &lookalikes[0].Resembles
+	}
+
+	signedByAccount := t.sender == account
+	if !signedByAccount {
+		valueTransfer := t.transferType == string(w_common.EthTransfer) || t.transferType == string(w_common.Erc20Transfer)
+		if valueTransfer && t.zeroValue {
+			assessment.Flags |= FlagZeroValue
+		}
+		// a spam token transfer emitted on behalf of the account or coming from a lookalike plants the address
+		if t.spamToken && (outgoing || assessment.Flags&FlagLookalike != 0) {
+			assessment.Flags |= FlagFakeToken
+		}
+	}
+
+	if assessment.Flags == 0 {
+		return nil
+	}
+	return assessment
+}
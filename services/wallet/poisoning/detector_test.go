@@ -0,0 +1,130 @@
+package poisoning
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+var (
+	testAccount      = common.HexToAddress("0xacc0000000000000000000000000000000000acc")
+	testCounterparty = common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678")
+	testLookalike    = common.HexToAddress("0x1234500000000000000000000000000000045678")
+	testSpamToken    = common.HexToAddress("0x5ba0000000000000000000000000000000000000")
+)
+
+type testTransfer struct {
+	hash         common.Hash
+	sender       common.Address
+	from         common.Address
+	to           common.Address
+	transferType string
+	amount       int64
+	token        common.Address
+}
+
+func insertTestTransfer(t *testing.T, db *sql.DB, tr testTransfer) {
+	blockHash := common.HexToHash("0xb")
+	_, err := db.Exec(`INSERT OR IGNORE INTO blocks (network_id, address, blk_number, blk_hash) VALUES (1, ?, 1, ?)`,
+		testAccount, blockHash)
+	require.NoError(t, err)
+
+	_, err = db.Exec(`INSERT INTO transfers (network_id, hash, address, blk_hash, sender, type, blk_number, timestamp,
+		amount_padded128hex, token_address, tx_from_address, tx_to_address) VALUES (1, ?, ?, ?, ?, ?, 1, 1, ?, ?, ?, ?)`,
+		tr.hash, testAccount, blockHash, tr.sender, tr.transferType, fmt.Sprintf("%032x", tr.amount), tr.token, tr.from, tr.to)
+	require.NoError(t, err)
+}
+
+func setupDetectorTest(t *testing.T) (*Detector, *sql.DB) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	return NewDetector(db), db
+}
+
+func TestAssessTransfers(t *testing.T) {
+	detector, db := setupDetectorTest(t)
+
+	attacker := common.HexToAddress("0xbad")
+	sent := testTransfer{hash: common.HexToHash("0x1"), sender: testAccount, from: testAccount, to: testCounterparty, transferType: "eth", amount: 100}
+	received := testTransfer{hash: common.HexToHash("0x2"), sender: attacker, from: attacker, to: testAccount, transferType: "eth", amount: 100}
+	zeroValue := testTransfer{hash: common.HexToHash("0x3"), sender: testLookalike, from: testLookalike, to: testAccount, transferType: "erc20", token: common.HexToAddress("0x7")}
+	// the `transferFrom` of 0 tokens from the account to the lookalike made by the attacker
+	transferFrom := testTransfer{hash: common.HexToHash("0x4"), sender: attacker, from: testAccount, to: testLookalike, transferType: "erc20", token: common.HexToAddress("0x7")}
+	fakeToken := testTransfer{hash: common.HexToHash("0x5"), sender: attacker, from: testLookalike, to: testAccount, transferType: "erc20", amount: 100, token: testSpamToken}
+	for _, tr := range []testTransfer{sent, received, zeroValue, transferFrom, fakeToken} {
+		insertTestTransfer(t, db, tr)
+	}
+	_, err := db.Exec(`INSERT INTO asset_spam_classifications (chain_id, address, asset_type, score, verdict, reasons, user_verdict, updated_at)
+		VALUES (1, ?, 'token', 100, 'spam', '', '', 1)`, testSpamToken)
+	require.NoError(t, err)
+
+	require.NoError(t, detector.AssessTransfers(1, testAccount))
+
+	ids := make([]TransferID, 0)
+	for _, tr := range []testTransfer{sent, received, zeroValue, transferFrom, fakeToken} {
+		ids = append(ids, TransferID{ChainID: 1, Hash: tr.hash, Address: testAccount})
+	}
+	assessments, err := detector.GetAssessments(ids)
+	require.NoError(t, err)
+	require.Len(t, assessments, 3)
+
+	zeroValueAssessment := assessments[TransferID{ChainID: 1, Hash: zeroValue.hash, Address: testAccount}]
+	require.Equal(t, FlagLookalike|FlagZeroValue, zeroValueAssessment.Flags)
+	require.Equal(t, testLookalike, zeroValueAssessment.Counterparty)
+	require.Equal(t, testCounterparty, *zeroValueAssessment.Resembles)
+
+	// the transfer not signed by the account doesn't make the lookalike a known counterparty
+	transferFromAssessment := assessments[TransferID{ChainID: 1, Hash: transferFrom.hash, Address: testAccount}]
+	require.Equal(t, FlagLookalike|FlagZeroValue, transferFromAssessment.Flags)
+
+	fakeTokenAssessment := assessments[TransferID{ChainID: 1, Hash: fakeToken.hash, Address: testAccount}]
+	require.Equal(t, FlagLookalike|FlagFakeToken, fakeTokenAssessment.Flags)
+
+	// the flags are cleared once the user trusts the address
+	_, err = db.Exec(`INSERT INTO saved_addresses (address, name) VALUES (?, 'lookalike')`, testLookalike)
+	require.NoError(t, err)
+	require.NoError(t, detector.AssessTransfers(1, testAccount))
+	assessments, err = detector.GetAssessments(ids)
+	require.NoError(t, err)
+	require.Len(t, assessments, 2)
+	require.Equal(t, FlagZeroValue, assessments[TransferID{ChainID: 1, Hash: zeroValue.hash, Address: testAccount}].Flags)
+	require.Nil(t, assessments[TransferID{ChainID: 1, Hash: zeroValue.hash, Address: testAccount}].Resembles)
+}
+
+func TestCheckRecipient(t *testing.T) {
+	detector, db := setupDetectorTest(t)
+
+	insertTestTransfer(t, db, testTransfer{hash: common.HexToHash("0x1"), sender: testAccount, from: testAccount, to: testCounterparty, transferType: "eth", amount: 1})
+	saved := common.HexToAddress("0xabcdef0000000000000000000000000000fedcba")
+	_, err := db.Exec(`INSERT INTO saved_addresses (address, name) VALUES (?, 'saved')`, saved)
+	require.NoError(t, err)
+
+	for _, address := range []common.Address{testCounterparty, saved, testAccount, common.HexToAddress("0x2")} {
+		warnings, err := detector.CheckRecipient(address)
+		require.NoError(t, err)
+		require.Empty(t, warnings, address.Hex())
+	}
+
+	warnings, err := detector.CheckRecipient(testLookalike)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	require.Equal(t, testCounterparty, warnings[0].Resembles)
+	require.Equal(t, SourceCounterparty, warnings[0].Source)
+
+	savedLookalike := common.HexToAddress("0xabcdef1111111111111111111111111111fedcba")
+	warnings, err = detector.CheckRecipient(savedLookalike)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	require.Equal(t, saved, warnings[0].Resembles)
+	require.Equal(t, SourceSavedAddress, warnings[0].Source)
+}
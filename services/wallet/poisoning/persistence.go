@@ -0,0 +1,181 @@
+package poisoning
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/spam"
+)
+
+const zeroAmountPadded128Hex = "00000000000000000000000000000000"
+
+// accountTransfer is the subset of a transfers row needed to assess it
+type accountTransfer struct {
+	hash         common.Hash
+	sender       common.Address // signer of the transaction
+	from         common.Address
+	to           common.Address
+	transferType string
+	zeroValue    bool
+	spamToken    bool
+}
+
+// TransferID identifies a transfers row, the hash is the id of the transfer and the address its owner account
+type TransferID struct {
+	ChainID uint64
+	Hash    common.Hash
+	Address common.Address
+}
+
+type Persistence struct {
+	db *sql.DB
+}
+
+func NewPersistence(db *sql.DB) *Persistence {
+	return &Persistence{db: db}
+}
+
+func (p *Persistence) queryAddresses(known knownAddresses, source Source, query string, args ...interface{}) error {
+	rows, err := p.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var address common.Address
+		if err := rows.Scan(&address); err != nil {
+			return err
+		}
+		known[address] = source
+	}
+	return rows.Err()
+}
+
+// knownAddresses returns the addresses the user trusts: the counterparties of the transfers signed by the accounts,
+// the saved addresses and the accounts themselves
+func (p *Persistence) knownAddresses() (knownAddresses, error) {
+	known := make(knownAddresses)
+
+	// the transfers signed by someone else, e.g. the `transferFrom` of 0 tokens, don't make their recipient known
+	err := p.queryAddresses(known, SourceCounterparty, `SELECT DISTINCT tx_to_address FROM transfers
+		WHERE tx_to_address IS NOT NULL AND tx_from_address = address AND sender = address`)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.queryAddresses(known, SourceSavedAddress, `SELECT address FROM saved_addresses WHERE removed = 0`)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.queryAddresses(known, SourceAccount, `SELECT DISTINCT address FROM transfers`)
+	if err != nil {
+		return nil, err
+	}
+	return known, nil
+}
+
+// accountTransfers returns the transfers of the account on the chain, the ones without parties are skipped
+func (p *Persistence) accountTransfers(chainID uint64, account common.Address) ([]*accountTransfer, error) {
+	rows, err := p.db.Query(`
+		SELECT transfers.hash, transfers.sender, transfers.tx_from_address, transfers.tx_to_address, transfers.type,
+			COALESCE(transfers.amount_padded128hex = ?, 0),
+			EXISTS (
+				SELECT 1 FROM asset_spam_classifications spam
+				WHERE spam.chain_id = transfers.network_id AND spam.address = transfers.token_address
+					AND spam.asset_type = ? AND `+spam.SpamCondition+`
+			)
+		FROM transfers
+		WHERE transfers.network_id = ? AND transfers.address = ?
+			AND transfers.tx_from_address IS NOT NULL AND transfers.tx_to_address IS NOT NULL`,
+		zeroAmountPadded128Hex, spam.AssetTypeToken, chainID, account)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transfers := make([]*accountTransfer, 0)
+	for rows.Next() {
+		t := &accountTransfer{}
+		var sender, from, to sql.RawBytes
+		err := rows.Scan(&t.hash, &sender, &from, &to, &t.transferType, &t.zeroValue, &t.spamToken)
+		if err != nil {
+			return nil, err
+		}
+		t.sender = common.BytesToAddress(sender)
+		t.from = common.BytesToAddress(from)
+		t.to = common.BytesToAddress(to)
+		transfers = append(transfers, t)
+	}
+	return transfers, rows.Err()
+}
+
+// saveAssessments replaces the assessments of the transfers of the account on the chain
+func (p *Persistence) saveAssessments(chainID uint64, account common.Address, assessments map[common.Hash]*Assessment) (err error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.Exec(`DELETE FROM address_poisoning_flags WHERE network_id = ? AND address = ?`, chainID, account)
+	if err != nil {
+		return err
+	}
+
+	for hash, assessment := range assessments {
+		_, err = tx.Exec(`INSERT INTO address_poisoning_flags (network_id, hash, address, counterparty, resembles, flags)
+			VALUES (?, ?, ?, ?, ?, ?)`, chainID, hash, account, assessment.Counterparty, assessment.Resembles, assessment.Flags)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAssessments returns the assessments of the flagged transfers among the given ones
+func (p *Persistence) GetAssessments(transfers []TransferID) (map[TransferID]*Assessment, error) {
+	assessments := make(map[TransferID]*Assessment)
+	if len(transfers) == 0 {
+		return assessments, nil
+	}
+
+	placeholders := make([]string, 0, len(transfers))
+	args := make([]interface{}, 0, 3*len(transfers))
+	for _, t := range transfers {
+		placeholders = append(placeholders, "(?, ?, ?)")
+		args = append(args, t.ChainID, t.Hash, t.Address)
+	}
+
+	rows, err := p.db.Query(`SELECT network_id, hash, address, counterparty, resembles, flags FROM address_poisoning_flags
+		WHERE (network_id, hash, address) IN (VALUES `+strings.Join(placeholders, ", ")+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id TransferID
+		var resembles sql.RawBytes
+		assessment := &Assessment{}
+		err := rows.Scan(&id.ChainID, &id.Hash, &id.Address, &assessment.Counterparty, &resembles, &assessment.Flags)
+		if err != nil {
+			return nil, err
+		}
+		if len(resembles) > 0 {
+			assessment.Resembles = new(common.Address)
+			*assessment.Resembles = common.BytesToAddress(resembles)
+		}
+		assessments[id] = assessment
+	}
+	return assessments, rows.Err()
+}
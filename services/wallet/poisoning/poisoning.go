@@ -0,0 +1,81 @@
+package poisoning
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Flags is the bitmask of the address poisoning patterns detected for a transfer
+type Flags uint
+
+const (
+	// FlagLookalike is set when the counterparty resembles a known address without matching it
+	FlagLookalike Flags = 1 << iota
+	// FlagZeroValue is set for the zero-value transfers not signed by the account, e.g. the `transferFrom` of 0 tokens
+	// anybody can make on behalf of the account
+	FlagZeroValue
+	// FlagFakeToken is set for the transfers of a token classified as spam not signed by the account, sent from a
+	// lookalike address or emitted on behalf of the account
+	FlagFakeToken
+)
+
+// Source tells where a known address comes from
+type Source string
+
+const (
+	SourceAccount      Source = "account"
+	SourceSavedAddress Source = "savedAddress"
+	SourceCounterparty Source = "counterparty" // an address the user previously sent to
+)
+
+// Assessment is the result of the detection for a transfer, only the flagged transfers are assessed
+type Assessment struct {
+	Flags        Flags           `json:"flags"`
+	Counterparty common.Address  `json:"counterparty"`
+	Resembles    *common.Address `json:"resembles,omitempty"`
+}
+
+// RecipientWarning is returned when the recipient of a transaction resembles a known address without matching it
+type RecipientWarning struct {
+	Address        common.Address `json:"address"`
+	Resembles      common.Address `json:"resembles"`
+	Source         Source         `json:"source"`
+	MatchingPrefix int            `json:"matchingPrefix"`
+	MatchingSuffix int            `json:"matchingSuffix"`
+}
+
+type knownAddresses map[common.Address]Source
+
+// lookalikes returns the warnings for the known addresses resembling the address, the most similar first, none if the
+// address is known
+func (k knownAddresses) lookalikes(address common.Address) []*RecipientWarning {
+	warnings := make([]*RecipientWarning, 0)
+	if _, ok := k[address]; ok {
+		return warnings
+	}
+
+	for known, source := range k {
+		if !IsLookalike(address, known) {
+			continue
+		}
+		prefix, suffix := MatchingAffixes(address, known)
+		warnings = append(warnings, &RecipientWarning{
+			Address:        address,
+			Resembles:      known,
+			Source:         source,
+			MatchingPrefix: prefix,
+			MatchingSuffix: suffix,
+		})
+	}
+
+	sort.Slice(warnings, func(i, j int) bool {
+		matchingI := warnings[i].MatchingPrefix + warnings[i].MatchingSuffix
+		matchingJ := warnings[j].MatchingPrefix + warnings[j].MatchingSuffix
+		if matchingI != matchingJ {
+			return matchingI > matchingJ
+		}
+		return warnings[i].Resembles.Hex() < warnings[j].Resembles.Hex()
+	})
+	return warnings
+}
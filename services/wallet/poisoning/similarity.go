@@ -0,0 +1,41 @@
+package poisoning
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// The wallets shorten the addresses to a few leading and trailing characters, the poisoning addresses are generated
+	// to match them
+	minAffixLength         = 3
+	minMatchingAffixLength = 7
+)
+
+// MatchingAffixes returns the number of leading and trailing hex characters the addresses have in common, the case is
+// ignored as the checksum doesn't change the address
+func MatchingAffixes(a common.Address, b common.Address) (prefix int, suffix int) {
+	hexA := strings.ToLower(a.Hex()[2:])
+	hexB := strings.ToLower(b.Hex()[2:])
+
+	for prefix < len(hexA) && hexA[prefix] == hexB[prefix] {
+		prefix++
+	}
+	if prefix == len(hexA) {
+		return prefix, 0
+	}
+	for suffix < len(hexA) && hexA[len(hexA)-1-suffix] == hexB[len(hexB)-1-suffix] {
+		suffix++
+	}
+	return prefix, suffix
+}
+
+// IsLookalike tells if the addresses are different but would look the same once shortened
+func IsLookalike(a common.Address, b common.Address) bool {
+	if a == b {
+		return false
+	}
+	prefix, suffix := MatchingAffixes(a, b)
+	return prefix >= minAffixLength && suffix >= minAffixLength && prefix+suffix >= minMatchingAffixLength
+}
@@ -0,0 +1,65 @@
+package poisoning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestMatchingAffixes(t *testing.T) {
+	a := common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678")
+
+	prefix, suffix := MatchingAffixes(a, common.HexToAddress("0x1234500000000000000000000000000000045678"))
+	require.Equal(t, 5, prefix)
+	require.Equal(t, 5, suffix)
+
+	// the checksum case is ignored
+	prefix, suffix = MatchingAffixes(a, common.HexToAddress("0x1234567890ABCDEF000000000000000000000000"))
+	require.Equal(t, 16, prefix)
+	require.Equal(t, 0, suffix)
+
+	prefix, suffix = MatchingAffixes(a, a)
+	require.Equal(t, 40, prefix)
+	require.Equal(t, 0, suffix)
+}
+
+func TestIsLookalike(t *testing.T) {
+	a := common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678")
+
+	for address, lookalike := range map[string]bool{
+		"0x1234000000000000000000000000000000005678": true,
+		"0x123000000000000000000000000000000000e678": false, // 3 + 3 characters
+		"0x1234567000000000000000000000000000000078": false, // 2 trailing characters
+		"0x1234567890abcdef1234567890abcdef12345678": false, // the same address
+		"0x0000000000000000000000000000000000000000": false,
+	} {
+		require.Equal(t, lookalike, IsLookalike(a, common.HexToAddress(address)), address)
+	}
+}
+
+func TestLookalikes(t *testing.T) {
+	counterparty := common.HexToAddress("0x1234567890abcdef1234567890abcdef12345678")
+	saved := common.HexToAddress("0x1234500000000000000000000000000000005678")
+	known := knownAddresses{
+		counterparty: SourceCounterparty,
+		saved:        SourceSavedAddress,
+	}
+
+	require.Empty(t, known.lookalikes(saved))
+
+	lookalike := common.HexToAddress("0x1234560000000000000000000000000000345678")
+	warnings := known.lookalikes(lookalike)
+	require.Len(t, warnings, 2)
+	// the most similar address first
+	require.Equal(t, &RecipientWarning{
+		Address:        lookalike,
+		Resembles:      counterparty,
+		Source:         SourceCounterparty,
+		MatchingPrefix: 6,
+		MatchingSuffix: 6,
+	}, warnings[0])
+	require.Equal(t, saved, warnings[1].Resembles)
+	require.Equal(t, SourceSavedAddress, warnings[1].Source)
+}
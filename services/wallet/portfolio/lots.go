@@ -0,0 +1,165 @@
+package portfolio
+
+import (
+	"errors"
+	"sort"
+)
+
+type CostBasisMethod string
+
+const (
+	FIFO        CostBasisMethod = "fifo"
+	LIFO        CostBasisMethod = "lifo"
+	AverageCost CostBasisMethod = "average"
+)
+
+var ErrUnsupportedCostBasisMethod = errors.New("unsupported cost basis method")
+
+func (m CostBasisMethod) validate() error {
+	switch m {
+	case FIFO, LIFO, AverageCost:
+		return nil
+	}
+	return ErrUnsupportedCostBasisMethod
+}
+
+// dustRatio is the relative difference under which quantities are considered equal, it absorbs the rounding of the
+// conversion of the raw amounts to token units
+const dustRatio = 1e-9
+
+// lot is a quantity of a token acquired at once, cost is the total cost basis of the quantity in the report currency
+type lot struct {
+	quantity  float64
+	cost      float64
+	timestamp int64
+}
+
+// holding tracks the lots of a token held by an account and the gains realized when disposing of them
+type holding struct {
+	method CostBasisMethod
+	// lots are ordered by acquisition time, the average cost method keeps a single lot
+	lots []lot
+
+	realized  float64
+	proceeds  float64
+	uncovered float64
+}
+
+func newHolding(method CostBasisMethod) *holding {
+	return &holding{
+		method: method,
+		lots:   make([]lot, 0),
+	}
+}
+
+func (h *holding) acquire(l lot) {
+	if l.quantity <= 0 {
+		return
+	}
+
+	if h.method == AverageCost && len(h.lots) > 0 {
+		h.lots[0].quantity += l.quantity
+		h.lots[0].cost += l.cost
+		if l.timestamp > h.lots[0].timestamp {
+			h.lots[0].timestamp = l.timestamp
+		}
+		return
+	}
+
+	// lots moved from another account keep their acquisition time
+	i := sort.Search(len(h.lots), func(i int) bool { return h.lots[i].timestamp > l.timestamp })
+	h.lots = append(h.lots, lot{})
+	copy(h.lots[i+1:], h.lots[i:])
+	h.lots[i] = l
+}
+
+// take removes the quantity from the lots in the order of the cost basis method, the quantity exceeding the lots held
+// is returned as missing
+func (h *holding) take(quantity float64) (taken []lot, missing float64) {
+	taken = make([]lot, 0)
+	for quantity > 0 && len(h.lots) > 0 {
+		i := 0
+		if h.method == LIFO {
+			i = len(h.lots) - 1
+		}
+
+		l := h.lots[i]
+		if l.quantity-quantity <= l.quantity*dustRatio {
+			taken = append(taken, l)
+			quantity -= l.quantity
+			h.lots = append(h.lots[:i], h.lots[i+1:]...)
+			continue
+		}
+
+		part := lot{
+			quantity:  quantity,
+			cost:      l.cost * quantity / l.quantity,
+			timestamp: l.timestamp,
+		}
+		h.lots[i].quantity -= part.quantity
+		h.lots[i].cost -= part.cost
+		taken = append(taken, part)
+		quantity = 0
+	}
+
+	if quantity > 0 {
+		missing = quantity
+	}
+	return taken, missing
+}
+
+// dispose realizes the gain of the quantity disposed of for the proceeds, the quantity without known acquisition has
+// a zero cost basis
+func (h *holding) dispose(quantity float64, proceeds float64) {
+	taken, missing := h.take(quantity)
+	h.uncovered += missing
+	h.proceeds += proceeds
+	h.realized += proceeds - lotsCost(taken)
+}
+
+func (h *holding) quantity() float64 {
+	quantity := 0.0
+	for _, l := range h.lots {
+		quantity += l.quantity
+	}
+	return quantity
+}
+
+func (h *holding) costBasis() float64 {
+	return lotsCost(h.lots)
+}
+
+func lotsCost(lots []lot) float64 {
+	cost := 0.0
+	for _, l := range lots {
+		cost += l.cost
+	}
+	return cost
+}
+
+func lotsQuantity(lots []lot) float64 {
+	quantity := 0.0
+	for _, l := range lots {
+		quantity += l.quantity
+	}
+	return quantity
+}
+
+// scaleLots adjusts the quantities of the lots to the quantity received, the cost basis is kept.
+// Used when bridging, the bridge fees are paid in the bridged token
+func scaleLots(lots []lot, quantity float64) []lot {
+	total := lotsQuantity(lots)
+	if total <= 0 {
+		return lots
+	}
+
+	scaled := make([]lot, len(lots))
+	for i, l := range lots {
+		scaled[i] = lot{
+			quantity:  l.quantity * quantity / total,
+			cost:      l.cost,
+			timestamp: l.timestamp,
+		}
+	}
+	return scaled
+}
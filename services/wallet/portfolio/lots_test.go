@@ -0,0 +1,79 @@
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHolding(method CostBasisMethod) *holding {
+	h := newHolding(method)
+	h.acquire(lot{quantity: 1, cost: 100, timestamp: 1})
+	h.acquire(lot{quantity: 1, cost: 300, timestamp: 2})
+	return h
+}
+
+func TestHoldingFIFO(t *testing.T) {
+	h := newTestHolding(FIFO)
+	h.dispose(1.5, 600)
+
+	require.InDelta(t, 0.5, h.quantity(), 1e-9)
+	require.InDelta(t, 150, h.costBasis(), 1e-9)
+	require.InDelta(t, 600-250, h.realized, 1e-9)
+	require.Zero(t, h.uncovered)
+}
+
+func TestHoldingLIFO(t *testing.T) {
+	h := newTestHolding(LIFO)
+	h.dispose(1.5, 600)
+
+	require.InDelta(t, 0.5, h.quantity(), 1e-9)
+	require.InDelta(t, 50, h.costBasis(), 1e-9)
+	require.InDelta(t, 600-350, h.realized, 1e-9)
+}
+
+func TestHoldingAverageCost(t *testing.T) {
+	h := newTestHolding(AverageCost)
+	require.Len(t, h.lots, 1)
+
+	h.dispose(1.5, 600)
+
+	require.InDelta(t, 0.5, h.quantity(), 1e-9)
+	require.InDelta(t, 100, h.costBasis(), 1e-9)
+	require.InDelta(t, 600-300, h.realized, 1e-9)
+}
+
+func TestHoldingUncoveredDisposal(t *testing.T) {
+	h := newTestHolding(FIFO)
+	h.dispose(3, 900)
+
+	require.Zero(t, h.quantity())
+	require.InDelta(t, 1, h.uncovered, 1e-9)
+	require.InDelta(t, 900-400, h.realized, 1e-9)
+	require.InDelta(t, 900, h.proceeds, 1e-9)
+}
+
+func TestHoldingKeepsAcquisitionOrder(t *testing.T) {
+	h := newTestHolding(FIFO)
+	// lots moved from another account keep their acquisition time
+	h.acquire(lot{quantity: 1, cost: 50, timestamp: 0})
+
+	taken, missing := h.take(1)
+	require.Zero(t, missing)
+	require.Len(t, taken, 1)
+	require.Equal(t, int64(0), taken[0].timestamp)
+}
+
+func TestScaleLots(t *testing.T) {
+	lots := scaleLots([]lot{{quantity: 1, cost: 100}, {quantity: 3, cost: 300}}, 2)
+	require.InDelta(t, 0.5, lots[0].quantity, 1e-9)
+	require.InDelta(t, 1.5, lots[1].quantity, 1e-9)
+	require.InDelta(t, 400, lotsCost(lots), 1e-9)
+}
+
+func TestCostBasisMethodValidate(t *testing.T) {
+	require.NoError(t, FIFO.validate())
+	require.NoError(t, LIFO.validate())
+	require.NoError(t, AverageCost.validate())
+	require.ErrorIs(t, CostBasisMethod("hifo").validate(), ErrUnsupportedCostBasisMethod)
+}
@@ -0,0 +1,406 @@
+package portfolio
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/status-im/status-go/services/wallet/market"
+	"github.com/status-im/status-go/services/wallet/token"
+)
+
+var ErrNoAddresses = errors.New("no addresses provided")
+
+// ExchangeRates provides the daily fiat rates of the tokens
+type ExchangeRates interface {
+	FetchAndCacheMissingRates(token string, currency string) error
+	GetExchangeRateForDay(token string, currency string, date time.Time) (float32, error)
+}
+
+// PriceProvider provides the current fiat prices of the tokens
+type PriceProvider interface {
+	GetOrFetchPrices(symbols []string, currencies []string, maxAgeInSeconds int64) (market.DataPerTokenAndCurrency, error)
+}
+
+type TokenPnL struct {
+	Symbol        string  `json:"symbol"`
+	Quantity      float64 `json:"quantity"`
+	CostBasis     float64 `json:"costBasis"`
+	MarketValue   float64 `json:"marketValue"`
+	UnrealizedPnL float64 `json:"unrealizedPnL"`
+	RealizedPnL   float64 `json:"realizedPnL"`
+	Proceeds      float64 `json:"proceeds"`
+	// UncoveredQuantity is the quantity disposed of without a known acquisition, it is counted with a zero cost basis
+	UncoveredQuantity float64 `json:"uncoveredQuantity"`
+	// MissingPrices is set when the price of a transfer or the current price is not known, they are valued at zero
+	MissingPrices bool `json:"missingPrices"`
+}
+
+func (t *TokenPnL) add(other *TokenPnL) {
+	t.Quantity += other.Quantity
+	t.CostBasis += other.CostBasis
+	t.MarketValue += other.MarketValue
+	t.UnrealizedPnL += other.UnrealizedPnL
+	t.RealizedPnL += other.RealizedPnL
+	t.Proceeds += other.Proceeds
+	t.UncoveredQuantity += other.UncoveredQuantity
+	t.MissingPrices = t.MissingPrices || other.MissingPrices
+}
+
+type AccountPnL struct {
+	Address       common.Address `json:"address"`
+	Tokens        []*TokenPnL    `json:"tokens"`
+	CostBasis     float64        `json:"costBasis"`
+	MarketValue   float64        `json:"marketValue"`
+	UnrealizedPnL float64        `json:"unrealizedPnL"`
+	RealizedPnL   float64        `json:"realizedPnL"`
+}
+
+// Report is the P&L of the accounts, Tokens aggregates the tokens of all the accounts
+type Report struct {
+	Method        CostBasisMethod `json:"method"`
+	Currency      string          `json:"currency"`
+	Accounts      []*AccountPnL   `json:"accounts"`
+	Tokens        []*TokenPnL     `json:"tokens"`
+	CostBasis     float64         `json:"costBasis"`
+	MarketValue   float64         `json:"marketValue"`
+	UnrealizedPnL float64         `json:"unrealizedPnL"`
+	RealizedPnL   float64         `json:"realizedPnL"`
+	Timestamp     int64           `json:"timestamp"`
+}
+
+// Manager computes the cost basis and the P&L of the accounts from the synced transfers. Transfers are acquisitions
+// or disposals at the market value of their day, gas fees are disposals of the native token, transfers between the
+// accounts and bridges move the lots with their cost basis
+type Manager struct {
+	db           *sql.DB
+	persistence  *Persistence
+	tokenManager token.ManagerInterface
+	exchange     ExchangeRates
+	prices       PriceProvider
+}
+
+func NewManager(db *sql.DB, tokenManager token.ManagerInterface, exchange ExchangeRates, prices PriceProvider) *Manager {
+	return &Manager{
+		db:           db,
+		persistence:  NewPersistence(db),
+		tokenManager: tokenManager,
+		exchange:     exchange,
+		prices:       prices,
+	}
+}
+
+type holdingKey struct {
+	account common.Address
+	symbol  string
+}
+
+// computation holds the state of a P&L computation
+type computation struct {
+	m        *Manager
+	method   CostBasisMethod
+	currency string
+
+	holdings      map[holdingKey]*holding
+	missingPrices map[holdingKey]bool
+	// inTransit holds the lots sent to a bridge until they are received on the destination chain
+	inTransit map[int64]*bridgedLots
+	// fetchedRates tells if the rates of a symbol are fetched, false when they are not available
+	fetchedRates map[string]bool
+	tokens       map[tokenKey]*token.Token
+}
+
+type bridgedLots struct {
+	key  holdingKey
+	lots []lot
+}
+
+type tokenKey struct {
+	chainID uint64
+	address common.Address
+	native  bool
+}
+
+func (m *Manager) newComputation(method CostBasisMethod, currency string) *computation {
+	return &computation{
+		m:             m,
+		method:        method,
+		currency:      currency,
+		holdings:      make(map[holdingKey]*holding),
+		missingPrices: make(map[holdingKey]bool),
+		inTransit:     make(map[int64]*bridgedLots),
+		fetchedRates:  make(map[string]bool),
+		tokens:        make(map[tokenKey]*token.Token),
+	}
+}
+
+func (c *computation) holding(key holdingKey) *holding {
+	h, ok := c.holdings[key]
+	if !ok {
+		h = newHolding(c.method)
+		c.holdings[key] = h
+	}
+	return h
+}
+
+func (c *computation) token(e *transferEvent) *token.Token {
+	key := tokenKey{e.chainID, e.tokenAddress, e.native}
+	if e.native {
+		key.address = common.Address{}
+	}
+	t, ok := c.tokens[key]
+	if !ok {
+		t = c.m.tokenManager.LookupTokenIdentity(key.chainID, key.address, key.native)
+		c.tokens[key] = t
+	}
+	return t
+}
+
+// priceForDay returns the price of the symbol on the day of the timestamp, the prices are persisted once fetched
+func (c *computation) priceForDay(symbol string, timestamp int64) (float64, bool) {
+	day := dayStart(timestamp)
+	price, found, err := c.m.persistence.GetDailyPrice(symbol, c.currency, day)
+	if err != nil {
+		log.Error("failed to get daily price", "symbol", symbol, "error", err)
+	}
+	if found {
+		return price, true
+	}
+
+	if c.m.exchange == nil {
+		return 0, false
+	}
+
+	available, fetched := c.fetchedRates[symbol]
+	if !fetched {
+		err = c.m.exchange.FetchAndCacheMissingRates(symbol, c.currency)
+		if err != nil {
+			log.Warn("failed to fetch exchange rates", "symbol", symbol, "currency", c.currency, "error", err)
+		}
+		available = err == nil
+		c.fetchedRates[symbol] = available
+	}
+	if !available {
+		return 0, false
+	}
+
+	rate, err := c.m.exchange.GetExchangeRateForDay(symbol, c.currency, time.Unix(day, 0).UTC())
+	if err != nil || rate == 0 {
+		return 0, false
+	}
+
+	price = float64(rate)
+	// today's price still changes
+	if day < dayStart(time.Now().Unix()) {
+		if err = c.m.persistence.SaveDailyPrice(symbol, c.currency, day, price); err != nil {
+			log.Error("failed to save daily price", "symbol", symbol, "error", err)
+		}
+	}
+	return price, true
+}
+
+func (c *computation) value(key holdingKey, quantity float64, timestamp int64) float64 {
+	price, ok := c.priceForDay(key.symbol, timestamp)
+	if !ok {
+		c.missingPrices[key] = true
+	}
+	return quantity * price
+}
+
+func toQuantity(amount *big.Int, decimals uint) float64 {
+	quantity, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(amount),
+		new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)),
+	).Float64()
+	return quantity
+}
+
+func (c *computation) process(e *transferEvent, accounts map[common.Address]bool) {
+	t := c.token(e)
+	if t == nil {
+		// tokens out of the lists are not valued
+		return
+	}
+
+	key := holdingKey{e.account, t.Symbol}
+	quantity := toQuantity(e.amount, t.Decimals)
+
+	switch {
+	case e.outgoing() && accounts[e.to]:
+		// moved between the accounts with the cost basis
+		lots, missing := c.holding(key).take(quantity)
+		c.holding(key).uncovered += missing
+		to := c.holding(holdingKey{e.to, t.Symbol})
+		for _, l := range lots {
+			to.acquire(l)
+		}
+	case e.incoming() && accounts[e.from]:
+		// processed with the outgoing transfer
+	case e.outgoing() && e.isBridge():
+		lots, missing := c.holding(key).take(quantity)
+		c.holding(key).uncovered += missing
+		c.inTransit[e.multiTxID] = &bridgedLots{key: key, lots: lots}
+	case e.incoming() && e.isBridge() && c.inTransit[e.multiTxID] != nil:
+		bridged := c.inTransit[e.multiTxID]
+		delete(c.inTransit, e.multiTxID)
+		for _, l := range scaleLots(bridged.lots, quantity) {
+			c.holding(key).acquire(l)
+		}
+	case e.incoming():
+		c.holding(key).acquire(lot{
+			quantity:  quantity,
+			cost:      c.value(key, quantity, e.timestamp),
+			timestamp: e.timestamp,
+		})
+	case e.outgoing():
+		c.holding(key).dispose(quantity, c.value(key, quantity, e.timestamp))
+	}
+}
+
+// currentPrices returns the current prices of the symbols, the latest daily prices are used while offline
+func (c *computation) currentPrices(symbols []string) map[string]float64 {
+	result := make(map[string]float64, len(symbols))
+	if len(symbols) == 0 {
+		return result
+	}
+
+	var prices market.DataPerTokenAndCurrency
+	if c.m.prices != nil {
+		var err error
+		prices, err = c.m.prices.GetOrFetchPrices(symbols, []string{c.currency}, market.MaxAgeInSecondsForBalances)
+		if err != nil {
+			log.Warn("failed to fetch current prices", "error", err)
+		}
+	}
+
+	for _, symbol := range symbols {
+		if price := prices[symbol][c.currency].Price; price > 0 {
+			result[symbol] = price
+			continue
+		}
+		price, found, err := c.m.persistence.GetLatestDailyPrice(symbol, c.currency)
+		if err != nil {
+			log.Error("failed to get latest daily price", "symbol", symbol, "error", err)
+		}
+		if found {
+			result[symbol] = price
+		}
+	}
+	return result
+}
+
+func (c *computation) report(accounts []common.Address) *Report {
+	// lots never received on the destination chain are still owned
+	for _, bridged := range c.inTransit {
+		for _, l := range bridged.lots {
+			c.holding(bridged.key).acquire(l)
+		}
+	}
+
+	symbolsMap := make(map[string]bool)
+	for key := range c.holdings {
+		symbolsMap[key.symbol] = true
+	}
+	symbols := make([]string, 0, len(symbolsMap))
+	for symbol := range symbolsMap {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	prices := c.currentPrices(symbols)
+
+	report := &Report{
+		Method:    c.method,
+		Currency:  c.currency,
+		Accounts:  make([]*AccountPnL, 0, len(accounts)),
+		Tokens:    make([]*TokenPnL, 0),
+		Timestamp: time.Now().Unix(),
+	}
+	totals := make(map[string]*TokenPnL)
+
+	for _, account := range accounts {
+		accountPnL := &AccountPnL{
+			Address: account,
+			Tokens:  make([]*TokenPnL, 0),
+		}
+
+		for _, symbol := range symbols {
+			key := holdingKey{account, symbol}
+			h, ok := c.holdings[key]
+			if !ok {
+				continue
+			}
+
+			price, hasPrice := prices[symbol]
+			quantity := h.quantity()
+			tokenPnL := &TokenPnL{
+				Symbol:            symbol,
+				Quantity:          quantity,
+				CostBasis:         h.costBasis(),
+				MarketValue:       quantity * price,
+				RealizedPnL:       h.realized,
+				Proceeds:          h.proceeds,
+				UncoveredQuantity: h.uncovered,
+				MissingPrices:     c.missingPrices[key] || (!hasPrice && quantity > 0),
+			}
+			tokenPnL.UnrealizedPnL = tokenPnL.MarketValue - tokenPnL.CostBasis
+			accountPnL.Tokens = append(accountPnL.Tokens, tokenPnL)
+
+			accountPnL.CostBasis += tokenPnL.CostBasis
+			accountPnL.MarketValue += tokenPnL.MarketValue
+			accountPnL.UnrealizedPnL += tokenPnL.UnrealizedPnL
+			accountPnL.RealizedPnL += tokenPnL.RealizedPnL
+
+			total, ok := totals[symbol]
+			if !ok {
+				total = &TokenPnL{Symbol: symbol}
+				totals[symbol] = total
+				report.Tokens = append(report.Tokens, total)
+			}
+			total.add(tokenPnL)
+		}
+
+		report.Accounts = append(report.Accounts, accountPnL)
+		report.CostBasis += accountPnL.CostBasis
+		report.MarketValue += accountPnL.MarketValue
+		report.UnrealizedPnL += accountPnL.UnrealizedPnL
+		report.RealizedPnL += accountPnL.RealizedPnL
+	}
+	return report
+}
+
+// GetPnL computes the cost basis, the unrealized and the realized P&L per token and per account in the currency
+func (m *Manager) GetPnL(ctx context.Context, accounts []common.Address, method CostBasisMethod, currency string) (*Report, error) {
+	if len(accounts) == 0 {
+		return nil, ErrNoAddresses
+	}
+	if err := method.validate(); err != nil {
+		return nil, err
+	}
+
+	events, err := getTransferEvents(ctx, m.db, accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	c := m.newComputation(method, currency)
+
+	accountsMap := make(map[common.Address]bool, len(accounts))
+	for _, account := range accounts {
+		accountsMap[account] = true
+	}
+
+	for _, e := range events {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		c.process(e, accountsMap)
+	}
+
+	return c.report(accounts), nil
+}
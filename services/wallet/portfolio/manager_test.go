@@ -0,0 +1,173 @@
+package portfolio
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/token"
+	mock_token "github.com/status-im/status-go/services/wallet/token/mock/token"
+	"github.com/status-im/status-go/services/wallet/transfer"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+const (
+	testDay1 = int64(1700006400)
+	testDay2 = testDay1 + 24*60*60
+)
+
+var (
+	testAccount1 = common.HexToAddress("0x1")
+	testAccount2 = common.HexToAddress("0x2")
+	testExternal = common.HexToAddress("0x3")
+	testBridge   = common.HexToAddress("0x4")
+)
+
+// testExchangeRates returns the rates of the symbols per day
+type testExchangeRates struct {
+	rates map[string]map[int64]float32
+}
+
+func (r *testExchangeRates) FetchAndCacheMissingRates(token string, currency string) error {
+	return nil
+}
+
+func (r *testExchangeRates) GetExchangeRateForDay(token string, currency string, date time.Time) (float32, error) {
+	return r.rates[token][date.Unix()], nil
+}
+
+func setupTestComputation(t *testing.T, method CostBasisMethod) *computation {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	mockCtrl := gomock.NewController(t)
+	tokenManager := mock_token.NewMockManagerInterface(mockCtrl)
+	tokenManager.EXPECT().LookupTokenIdentity(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(chainID uint64, address common.Address, native bool) *token.Token {
+			if !native {
+				return nil
+			}
+			return &token.Token{ChainID: chainID, Symbol: "ETH", Decimals: 18}
+		}).AnyTimes()
+
+	exchange := &testExchangeRates{
+		rates: map[string]map[int64]float32{
+			"ETH": {testDay1: 1000, testDay2: 2000},
+		},
+	}
+	return NewManager(db, tokenManager, exchange, nil).newComputation(method, "USD")
+}
+
+func ethAmount(quantity float64) *big.Int {
+	amount, _ := new(big.Float).Mul(big.NewFloat(quantity), big.NewFloat(1e18)).Int(nil)
+	return amount
+}
+
+func ethTransfer(chainID uint64, account, from, to common.Address, quantity float64, timestamp int64) *transferEvent {
+	return &transferEvent{
+		chainID:     chainID,
+		account:     account,
+		from:        from,
+		to:          to,
+		native:      true,
+		amount:      ethAmount(quantity),
+		timestamp:   timestamp,
+		multiTxType: -1,
+	}
+}
+
+func bridgeTransfer(e *transferEvent, multiTxID int64) *transferEvent {
+	e.multiTxID = multiTxID
+	e.multiTxType = int64(transfer.MultiTransactionBridge)
+	return e
+}
+
+func TestProcessInternalMove(t *testing.T) {
+	c := setupTestComputation(t, FIFO)
+	accounts := map[common.Address]bool{testAccount1: true, testAccount2: true}
+
+	for _, e := range []*transferEvent{
+		ethTransfer(1, testAccount1, testExternal, testAccount1, 2, testDay1),
+		ethTransfer(1, testAccount1, testAccount1, testAccount2, 1, testDay2),
+		// the incoming side of the move is processed with the outgoing one
+		ethTransfer(1, testAccount2, testAccount1, testAccount2, 1, testDay2),
+	} {
+		c.process(e, accounts)
+	}
+
+	// the lot is moved with its cost basis, no gain is realized
+	from := c.holdings[holdingKey{testAccount1, "ETH"}]
+	require.InDelta(t, 1, from.quantity(), 1e-9)
+	require.InDelta(t, 1000, from.costBasis(), 1e-9)
+	require.Zero(t, from.realized)
+
+	to := c.holdings[holdingKey{testAccount2, "ETH"}]
+	require.InDelta(t, 1, to.quantity(), 1e-9)
+	require.InDelta(t, 1000, to.costBasis(), 1e-9)
+	require.Zero(t, to.realized)
+	require.Zero(t, to.uncovered)
+
+	report := c.report([]common.Address{testAccount1, testAccount2})
+	require.InDelta(t, 2000, report.CostBasis, 1e-9)
+	require.Zero(t, report.RealizedPnL)
+}
+
+func TestProcessBridge(t *testing.T) {
+	c := setupTestComputation(t, FIFO)
+	accounts := map[common.Address]bool{testAccount1: true}
+	key := holdingKey{testAccount1, "ETH"}
+
+	c.process(ethTransfer(1, testAccount1, testExternal, testAccount1, 1, testDay1), accounts)
+	c.process(bridgeTransfer(ethTransfer(1, testAccount1, testAccount1, testBridge, 1, testDay2), 7), accounts)
+
+	// the lots are in transit until received on the destination chain
+	require.Zero(t, c.holdings[key].quantity())
+	require.Contains(t, c.inTransit, int64(7))
+
+	// the bridge fee reduces the quantity but not the cost basis
+	c.process(bridgeTransfer(ethTransfer(10, testAccount1, testBridge, testAccount1, 0.99, testDay2), 7), accounts)
+	require.Empty(t, c.inTransit)
+	require.InDelta(t, 0.99, c.holdings[key].quantity(), 1e-9)
+	require.InDelta(t, 1000, c.holdings[key].costBasis(), 1e-9)
+	require.Zero(t, c.holdings[key].realized)
+}
+
+func TestProcessBridgeInTransit(t *testing.T) {
+	c := setupTestComputation(t, FIFO)
+	accounts := map[common.Address]bool{testAccount1: true}
+
+	c.process(ethTransfer(1, testAccount1, testExternal, testAccount1, 1, testDay1), accounts)
+	c.process(bridgeTransfer(ethTransfer(1, testAccount1, testAccount1, testBridge, 1, testDay2), 7), accounts)
+
+	// the lots not received yet are still owned
+	report := c.report([]common.Address{testAccount1})
+	require.Len(t, report.Tokens, 1)
+	require.InDelta(t, 1, report.Tokens[0].Quantity, 1e-9)
+	require.InDelta(t, 1000, report.Tokens[0].CostBasis, 1e-9)
+	require.Zero(t, report.RealizedPnL)
+}
+
+func TestProcessGasFee(t *testing.T) {
+	c := setupTestComputation(t, FIFO)
+	accounts := map[common.Address]bool{testAccount1: true}
+	key := holdingKey{testAccount1, "ETH"}
+
+	c.process(ethTransfer(1, testAccount1, testExternal, testAccount1, 1, testDay1), accounts)
+	// gas fees are disposals of the native token at the market value
+	c.process(ethTransfer(1, testAccount1, testAccount1, common.Address{}, 0.1, testDay2), accounts)
+
+	h := c.holdings[key]
+	require.InDelta(t, 0.9, h.quantity(), 1e-9)
+	require.InDelta(t, 900, h.costBasis(), 1e-9)
+	require.InDelta(t, 200, h.proceeds, 1e-9)
+	require.InDelta(t, 100, h.realized, 1e-9)
+}
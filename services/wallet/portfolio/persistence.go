@@ -0,0 +1,53 @@
+package portfolio
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Persistence struct {
+	db *sql.DB
+}
+
+func NewPersistence(db *sql.DB) *Persistence {
+	return &Persistence{db: db}
+}
+
+// dayStart returns the unix timestamp of the start of the UTC day of the timestamp
+func dayStart(timestamp int64) int64 {
+	t := time.Unix(timestamp, 0).UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix()
+}
+
+func (p *Persistence) GetDailyPrice(symbol string, currency string, day int64) (float64, bool, error) {
+	var price float64
+	err := p.db.QueryRow(`SELECT price FROM portfolio_daily_prices WHERE symbol = ? AND currency = ? AND day = ?`,
+		symbol, currency, day).Scan(&price)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return price, true, nil
+}
+
+func (p *Persistence) SaveDailyPrice(symbol string, currency string, day int64, price float64) error {
+	_, err := p.db.Exec(`INSERT OR REPLACE INTO portfolio_daily_prices (symbol, currency, day, price) VALUES (?, ?, ?, ?)`,
+		symbol, currency, day, price)
+	return err
+}
+
+// GetLatestDailyPrice returns the most recent price known, used to value the holdings while offline
+func (p *Persistence) GetLatestDailyPrice(symbol string, currency string) (float64, bool, error) {
+	var price float64
+	err := p.db.QueryRow(`SELECT price FROM portfolio_daily_prices WHERE symbol = ? AND currency = ? ORDER BY day DESC LIMIT 1`,
+		symbol, currency).Scan(&price)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return price, true, nil
+}
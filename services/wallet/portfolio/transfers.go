@@ -0,0 +1,183 @@
+package portfolio
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/status-go/services/wallet/transfer"
+)
+
+// transferEvent is a movement of a fungible token in or out of an account
+type transferEvent struct {
+	chainID      uint64
+	account      common.Address
+	from         common.Address
+	to           common.Address
+	native       bool
+	tokenAddress common.Address
+	amount       *big.Int
+	timestamp    int64
+	multiTxID    int64
+	multiTxType  int64 // -1 when the transfer is not part of a multi-transaction
+}
+
+func (e *transferEvent) incoming() bool {
+	return e.to == e.account && e.from != e.account
+}
+
+func (e *transferEvent) outgoing() bool {
+	return e.from == e.account && e.to != e.account
+}
+
+func (e *transferEvent) isBridge() bool {
+	return e.multiTxID > 0 && e.multiTxType == int64(transfer.MultiTransactionBridge)
+}
+
+func accountsArgs(accounts []common.Address) (string, []interface{}) {
+	placeholders := make([]string, len(accounts))
+	args := make([]interface{}, len(accounts))
+	for i, account := range accounts {
+		placeholders[i] = "?"
+		args[i] = account
+	}
+	return strings.Join(placeholders, ","), args
+}
+
+// getTransferEvents returns the successful transfers of native and ERC20 tokens of the accounts and the gas fees they
+// paid, ordered by time
+func getTransferEvents(ctx context.Context, db *sql.DB, accounts []common.Address) ([]*transferEvent, error) {
+	if len(accounts) == 0 {
+		return []*transferEvent{}, nil
+	}
+
+	events, err := getTokenTransferEvents(ctx, db, accounts)
+	if err != nil {
+		return nil, err
+	}
+	fees, err := getGasFeeEvents(ctx, db, accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	// the fees are processed after the transfers of their transaction
+	events = append(events, fees...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].timestamp < events[j].timestamp
+	})
+	return events, nil
+}
+
+func getTokenTransferEvents(ctx context.Context, db *sql.DB, accounts []common.Address) ([]*transferEvent, error) {
+	placeholders, args := accountsArgs(accounts)
+
+	query := `SELECT transfers.network_id, transfers.address, transfers.tx_from_address, transfers.tx_to_address,
+			transfers.type, transfers.token_address, transfers.amount_padded128hex, transfers.timestamp,
+			COALESCE(transfers.multi_transaction_id, 0), COALESCE(multi_transactions.type, -1)
+		FROM transfers
+		LEFT JOIN multi_transactions ON transfers.multi_transaction_id = multi_transactions.id
+		WHERE transfers.loaded = 1 AND transfers.type IN ('eth', 'erc20') AND (transfers.status IS NULL OR transfers.status = 1)
+			AND transfers.address IN (` + placeholders + `)
+		ORDER BY transfers.timestamp ASC, transfers.blk_number ASC, transfers.log_index ASC`
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*transferEvent, 0)
+	for rows.Next() {
+		var fromDB, toDB, tokenAddressDB []byte
+		var trType string
+		var amountDB sql.NullString
+		e := &transferEvent{}
+		err = rows.Scan(&e.chainID, &e.account, &fromDB, &toDB, &trType, &tokenAddressDB, &amountDB, &e.timestamp,
+			&e.multiTxID, &e.multiTxType)
+		if err != nil {
+			return nil, err
+		}
+
+		if !amountDB.Valid {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(amountDB.String, 16)
+		if !ok || amount.Sign() == 0 {
+			continue
+		}
+
+		e.amount = amount
+		e.from = common.BytesToAddress(fromDB)
+		e.to = common.BytesToAddress(toDB)
+		e.native = trType == "eth"
+		e.tokenAddress = common.BytesToAddress(tokenAddressDB)
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// getGasFeeEvents returns the gas fees paid by the accounts as outgoing transfers of the native token, including the
+// fees of failed transactions
+func getGasFeeEvents(ctx context.Context, db *sql.DB, accounts []common.Address) ([]*transferEvent, error) {
+	placeholders, args := accountsArgs(accounts)
+
+	// all the transfers of a transaction have the same gas fields
+	query := `SELECT network_id, address, timestamp, gas_used, tx_type, gas_price_clamped64, gas_tip_cap_clamped64,
+			gas_fee_cap_clamped64, base_gas_fee
+		FROM transfers
+		WHERE loaded = 1 AND tx_from_address = address AND gas_used > 0 AND address IN (` + placeholders + `)
+		GROUP BY network_id, tx_hash, address
+		ORDER BY timestamp ASC, blk_number ASC`
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*transferEvent, 0)
+	for rows.Next() {
+		var gasUsed, txType, gasPrice, gasTipCap, gasFeeCap sql.NullInt64
+		var baseFee sql.NullString
+		e := &transferEvent{
+			native:      true,
+			multiTxType: -1,
+		}
+		err = rows.Scan(&e.chainID, &e.account, &e.timestamp, &gasUsed, &txType, &gasPrice, &gasTipCap, &gasFeeCap, &baseFee)
+		if err != nil {
+			return nil, err
+		}
+
+		price := effectiveGasPrice(txType.Int64, gasPrice.Int64, gasTipCap.Int64, gasFeeCap.Int64, baseFee.String)
+		if price == nil || price.Sign() == 0 {
+			continue
+		}
+
+		e.amount = new(big.Int).Mul(price, big.NewInt(gasUsed.Int64))
+		e.from = e.account
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// effectiveGasPrice returns the price paid per gas, nil if the base fee of an EIP-1559 transaction is not known
+func effectiveGasPrice(txType int64, gasPrice int64, gasTipCap int64, gasFeeCap int64, baseFee string) *big.Int {
+	if txType < types.DynamicFeeTxType {
+		return big.NewInt(gasPrice)
+	}
+
+	base, ok := new(big.Int).SetString(baseFee, 0)
+	if !ok {
+		return nil
+	}
+	price := new(big.Int).Add(base, big.NewInt(gasTipCap))
+	if feeCap := big.NewInt(gasFeeCap); price.Cmp(feeCap) > 0 {
+		return feeCap
+	}
+	return price
+}
@@ -0,0 +1,19 @@
+package portfolio
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestEffectiveGasPrice(t *testing.T) {
+	require.Equal(t, big.NewInt(30), effectiveGasPrice(types.LegacyTxType, 30, 0, 0, ""))
+	require.Equal(t, big.NewInt(22), effectiveGasPrice(types.DynamicFeeTxType, 40, 2, 40, "20"))
+	require.Equal(t, big.NewInt(22), effectiveGasPrice(types.DynamicFeeTxType, 40, 2, 40, "0x14"))
+	// the tip is capped by the max fee
+	require.Equal(t, big.NewInt(21), effectiveGasPrice(types.DynamicFeeTxType, 21, 2, 21, "20"))
+	require.Nil(t, effectiveGasPrice(types.DynamicFeeTxType, 40, 2, 40, ""))
+}
@@ -0,0 +1,113 @@
+package requests
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/router/fees"
+	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
+	"github.com/status-im/status-go/services/wallet/router/sendtype"
+)
+
+type BatchTransferEntry struct {
+	AddrTo   common.Address `json:"addrTo" validate:"required"`
+	TokenID  string         `json:"tokenID" validate:"required"`
+	AmountIn *hexutil.Big   `json:"amountIn" validate:"required"`
+}
+
+// BatchRouteInputParams describes transfers to many recipients, possibly of different tokens, sent from the same account
+type BatchRouteInputParams struct {
+	Uuid                 string                `json:"uuid"`
+	AddrFrom             common.Address        `json:"addrFrom" validate:"required"`
+	Entries              []*BatchTransferEntry `json:"entries" validate:"required"`
+	DisabledFromChainIDs []uint64              `json:"disabledFromChainIDs"`
+	DisabledToChainIDs   []uint64              `json:"disabledToChainIDs"`
+	GasFeeMode           fees.GasFeeMode       `json:"gasFeeMode" validate:"required"`
+	TestnetMode          bool
+
+	// TODO: Remove two fields below once we implement a better solution for tests
+	// Currently used for tests only
+	TestsMode  bool
+	TestParams *RouterTestParams
+}
+
+func (i *BatchRouteInputParams) Validate() error {
+	if len(i.Entries) == 0 {
+		return ErrBatchTransferRequiresEntries
+	}
+
+	for _, entry := range i.Entries {
+		if entry.AddrTo == walletCommon.ZeroAddress() || entry.TokenID == "" {
+			return ErrBatchTransferEntryRequiresRecipient
+		}
+		if entry.AmountIn == nil || entry.AmountIn.ToInt().Sign() <= 0 {
+			return ErrBatchTransferAmountInMustBePositive
+		}
+	}
+
+	return nil
+}
+
+// EntriesByToken groups the entries sending the same token, groups are in order of the first entry of each token
+func (i *BatchRouteInputParams) EntriesByToken() [][]*BatchTransferEntry {
+	var groups [][]*BatchTransferEntry
+	groupIdx := make(map[string]int)
+	for _, entry := range i.Entries {
+		idx, ok := groupIdx[entry.TokenID]
+		if !ok {
+			idx = len(groups)
+			groupIdx[entry.TokenID] = idx
+			groups = append(groups, nil)
+		}
+		groups[idx] = append(groups[idx], entry)
+	}
+	return groups
+}
+
+// RouteInputParams returns the params for planning `entries` in a single route, all entries must send the same token
+func (i *BatchRouteInputParams) RouteInputParams(entries []*BatchTransferEntry) *RouteInputParams {
+	amountIn := big.NewInt(0)
+	recipients := make([]*pathprocessor.TransferRecipient, 0, len(entries))
+	for _, entry := range entries {
+		amountIn.Add(amountIn, entry.AmountIn.ToInt())
+		recipients = append(recipients, &pathprocessor.TransferRecipient{
+			Address: entry.AddrTo,
+			Amount:  entry.AmountIn,
+		})
+	}
+
+	routeInput := &RouteInputParams{
+		Uuid:                 i.Uuid,
+		SendType:             sendtype.BatchTransfer,
+		AddrFrom:             i.AddrFrom,
+		AmountIn:             (*hexutil.Big)(amountIn),
+		AmountOut:            (*hexutil.Big)(big.NewInt(0)),
+		DisabledFromChainIDs: i.DisabledFromChainIDs,
+		DisabledToChainIDs:   i.DisabledToChainIDs,
+		GasFeeMode:           i.GasFeeMode,
+		TestnetMode:          i.TestnetMode,
+		Recipients:           recipients,
+		TestsMode:            i.TestsMode,
+		TestParams:           i.TestParams,
+	}
+	if len(entries) > 0 {
+		routeInput.TokenID = entries[0].TokenID
+	}
+	if len(entries) == 1 {
+		routeInput.AddrTo = entries[0].AddrTo
+	}
+	return routeInput
+}
+
+// SummaryRouteInputParams returns the params describing the whole batch, used for the multi transaction of the batch
+func (i *BatchRouteInputParams) SummaryRouteInputParams() *RouteInputParams {
+	groups := i.EntriesByToken()
+	if len(groups) == 1 {
+		return i.RouteInputParams(groups[0])
+	}
+
+	// amounts of different tokens can't be summed up, so neither the token nor the amount are set
+	return i.RouteInputParams(nil)
+}
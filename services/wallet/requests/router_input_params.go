@@ -34,6 +34,9 @@ var (
 	ErrENSSetPubKeyInvalidUsername               = &errors.ErrorResponse{Code: errors.ErrorCode("WRR-017"), Details: "a valid username, ending in '.eth', is required for ENSSetPubKey"}
 	ErrLockedAmountExcludesAllSupported          = &errors.ErrorResponse{Code: errors.ErrorCode("WRR-018"), Details: "all supported chains are excluded, routing impossible"}
 	ErrCannotCheckLockedAmounts                  = &errors.ErrorResponse{Code: errors.ErrorCode("WRR-019"), Details: "cannot check locked amounts"}
+	ErrBatchTransferRequiresEntries              = &errors.ErrorResponse{Code: errors.ErrorCode("WRR-020"), Details: "at least one entry is required for BatchTransfer"}
+	ErrBatchTransferEntryRequiresRecipient       = &errors.ErrorResponse{Code: errors.ErrorCode("WRR-021"), Details: "addrTo and tokenID are required for each BatchTransfer entry"}
+	ErrBatchTransferAmountInMustBePositive       = &errors.ErrorResponse{Code: errors.ErrorCode("WRR-022"), Details: "amountIn must be positive for each BatchTransfer entry"}
 )
 
 type RouteInputParams struct {
@@ -57,6 +60,9 @@ type RouteInputParams struct {
 	PublicKey string       `json:"publicKey"`
 	PackID    *hexutil.Big `json:"packID"`
 
+	// For send type BatchTransfer, set when the batch entries are planned
+	Recipients []*pathprocessor.TransferRecipient `json:"-"`
+
 	// TODO: Remove two fields below once we implement a better solution for tests
 	// Currently used for tests only
 	TestsMode  bool
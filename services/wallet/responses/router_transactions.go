@@ -3,6 +3,7 @@ package responses
 import (
 	"github.com/status-im/status-go/errors"
 	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/services/wallet/simulation"
 	"github.com/status-im/status-go/transactions"
 )
 
@@ -29,6 +30,8 @@ type SigningDetails struct {
 	KeyUid        string        `json:"keyUid"`
 	SignOnKeycard bool          `json:"signOnKeycard"`
 	Hashes        []types.Hash  `json:"hashes"`
+	// predicted outcome of the transactions, by the hash to sign
+	Simulations map[types.Hash]*simulation.Result `json:"simulations,omitempty"`
 }
 
 type RouterTransactionsForSigning struct {
@@ -0,0 +1,49 @@
+package router
+
+import (
+	"github.com/status-im/status-go/services/wallet/requests"
+	"github.com/status-im/status-go/services/wallet/router/routes"
+	"github.com/status-im/status-go/services/wallet/router/sendtype"
+)
+
+// explainRoutes explains why each of the tried processors is or isn't part of the best route. `routablePaths` are the candidates
+// which can be combined into a route sending the requested amount, `balanceErrors` are the errors of the routes dropped because
+// of the balances, by their paths. The best route is returned even if the balances don't cover it, `bestRouteErr` tells why.
+func explainRoutes(input *requests.RouteInputParams, candidates routes.Route, excluded []*routes.PathExplanation, best routes.Route,
+	bestRouteErr error, routablePaths map[*routes.Path]bool, balanceErrors map[*routes.Path]error, prices map[string]float64) *routes.RouteExplanation {
+	explanation := routes.NewRouteExplanation(input.TokenID, input.ToTokenID, input.SendType == sendtype.Swap)
+
+	selected := make(map[*routes.Path]bool)
+	for _, path := range best {
+		selected[path] = true
+	}
+
+	for _, path := range candidates {
+		balanceErr := balanceErrors[path]
+		switch {
+		case selected[path]:
+			explanation.Add(routes.NewCandidatePathExplanation(path, true, "", bestRouteErr))
+		case balanceErr != nil:
+			explanation.Add(routes.NewCandidatePathExplanation(path, false, routes.ExclusionReasonInsufficientBalance, balanceErr))
+		case !routablePaths[path]:
+			explanation.Add(routes.NewCandidatePathExplanation(path, false, routes.ExclusionReasonNoValidRoute, nil))
+		default:
+			explanation.Add(routes.NewCandidatePathExplanation(path, false, routes.ExclusionReasonHigherCost, nil))
+		}
+	}
+	explanation.Add(excluded...)
+
+	explanation.Rank(prices, sendtype.PricesCurrency)
+	return explanation
+}
+
+// explainUnpricedRoutes explains the processors tried by the router when the prices to compare the candidates are not known
+func explainUnpricedRoutes(input *requests.RouteInputParams, candidates routes.Route, excluded []*routes.PathExplanation,
+	pricesErr error) *routes.RouteExplanation {
+	explanation := routes.NewRouteExplanation(input.TokenID, input.ToTokenID, input.SendType == sendtype.Swap)
+	for _, path := range candidates {
+		explanation.Add(routes.NewCandidatePathExplanation(path, false, routes.ExclusionReasonPricesUnavailable, pricesErr))
+	}
+	explanation.Add(excluded...)
+	return explanation
+}
@@ -83,7 +83,10 @@ const (
 )
 
 type FeeHistory struct {
-	BaseFeePerGas []string `json:"baseFeePerGas"`
+	OldestBlock   string     `json:"oldestBlock"`
+	BaseFeePerGas []string   `json:"baseFeePerGas"` // includes the base fee of the block after the newest one
+	GasUsedRatio  []float64  `json:"gasUsedRatio"`
+	Reward        [][]string `json:"reward,omitempty"` // priority fees paid at the requested percentiles, per block
 }
 
 type FeeManager struct {
@@ -257,6 +260,16 @@ func (f *FeeManager) getFeeHistorySorted(chainID uint64) ([]*big.Int, error) {
 	return fees, nil
 }
 
+// GetFeeHistory returns the fees of the `blockCount` latest blocks, with the priority fees paid at each of the reward percentiles
+func (f *FeeManager) GetFeeHistory(ctx context.Context, chainID uint64, blockCount uint64, rewardPercentiles []float64) (*FeeHistory, error) {
+	var feeHistory FeeHistory
+	err := f.RPCClient.CallContext(ctx, &feeHistory, chainID, "eth_feeHistory", hexutil.Uint64(blockCount), "latest", rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	return &feeHistory, nil
+}
+
 // Returns L1 fee for placing a transaction to L1 chain, appicable only for txs made from L2.
 func (f *FeeManager) GetL1Fee(ctx context.Context, chainID uint64, input []byte) (uint64, error) {
 	if chainID == common.EthereumMainnet || chainID == common.EthereumSepolia {
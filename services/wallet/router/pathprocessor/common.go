@@ -1,6 +1,7 @@
 package pathprocessor
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"strings"
@@ -15,8 +16,7 @@ import (
 
 func getSigner(chainID uint64, from types.Address, verifiedAccount *account.SelectedExtKey) bind.SignerFn {
 	return func(addr common.Address, tx *ethTypes.Transaction) (*ethTypes.Transaction, error) {
-		s := ethTypes.NewLondonSigner(new(big.Int).SetUint64(chainID))
-		return ethTypes.SignTx(tx, s, verifiedAccount.AccountKey.PrivateKey)
+		return verifiedAccount.Signer().SignTx(context.Background(), common.Address(from), tx, new(big.Int).SetUint64(chainID))
 	}
 }
 
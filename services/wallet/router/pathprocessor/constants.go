@@ -13,22 +13,26 @@ const (
 	UsdcSymbol = "USDC"
 	HopSymbol  = "HOP"
 
-	ProcessorTransferName     = "Transfer"
-	ProcessorBridgeHopName    = "Hop"
-	ProcessorBridgeCelerName  = "CBridge"
-	ProcessorSwapParaswapName = "Paraswap"
-	ProcessorERC721Name       = "ERC721Transfer"
-	ProcessorERC1155Name      = "ERC1155Transfer"
-	ProcessorENSRegisterName  = "ENSRegister"
-	ProcessorENSReleaseName   = "ENSRelease"
-	ProcessorENSPublicKeyName = "ENSPublicKey"
-	ProcessorStickersBuyName  = "StickersBuy"
+	ProcessorTransferName             = "Transfer"
+	ProcessorBridgeHopName            = "Hop"
+	ProcessorBridgeCelerName          = "CBridge"
+	ProcessorBridgeNativeName         = "NativeBridge"
+	ProcessorSwapParaswapName         = "Paraswap"
+	ProcessorSwapZeroExName           = "ZeroEx"
+	ProcessorERC721Name               = "ERC721Transfer"
+	ProcessorERC1155Name              = "ERC1155Transfer"
+	ProcessorENSRegisterName          = "ENSRegister"
+	ProcessorENSReleaseName           = "ENSRelease"
+	ProcessorENSPublicKeyName         = "ENSPublicKey"
+	ProcessorStickersBuyName          = "StickersBuy"
+	ProcessorSmartAccountTransferName = "SmartAccountTransfer"
+	ProcessorDisperseName             = "Disperse"
 )
 
 func IsProcessorBridge(name string) bool {
-	return name == ProcessorBridgeHopName || name == ProcessorBridgeCelerName
+	return name == ProcessorBridgeHopName || name == ProcessorBridgeCelerName || name == ProcessorBridgeNativeName
 }
 
 func IsProcessorSwap(name string) bool {
-	return name == ProcessorSwapParaswapName
+	return name == ProcessorSwapParaswapName || name == ProcessorSwapZeroExName
 }
@@ -19,13 +19,17 @@ func TestPlainError(t *testing.T) {
 		ProcessorTransferName,
 		ProcessorBridgeHopName,
 		ProcessorBridgeCelerName,
+		ProcessorBridgeNativeName,
 		ProcessorSwapParaswapName,
+		ProcessorSwapZeroExName,
 		ProcessorERC721Name,
 		ProcessorERC1155Name,
 		ProcessorENSRegisterName,
 		ProcessorENSReleaseName,
 		ProcessorENSPublicKeyName,
 		ProcessorStickersBuyName,
+		ProcessorSmartAccountTransferName,
+		ProcessorDisperseName,
 	}
 
 	for _, processorName := range processorNames {
@@ -43,6 +43,11 @@ type PathProcessorClearable interface {
 	Clear()
 }
 
+type PathProcessorAmountInCalculator interface {
+	// CalculateAmountIn calculates the amount in, for the processors quoting it for a fixed amount out
+	CalculateAmountIn(params ProcessorInputParams) (*big.Int, error)
+}
+
 type ProcessorInputParams struct {
 	FromChain *params.Network
 	ToChain   *params.Network
@@ -58,6 +63,8 @@ type ProcessorInputParams struct {
 	Username  string
 	PublicKey string
 	PackID    *big.Int
+	// set for batch transfers, the sum of the recipients amounts is `AmountIn`
+	Recipients []*TransferRecipient
 
 	// for testing purposes
 	TestsMode                 bool
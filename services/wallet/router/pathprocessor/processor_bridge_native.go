@@ -0,0 +1,330 @@
+package pathprocessor
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/params"
+	"github.com/status-im/status-go/rpc"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/nativebridge"
+	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/transactions"
+)
+
+const (
+	// L1StandardBridge and L2StandardBridge of the OP Stack chains
+	opStackStandardBridgeABI = `[
+		{"inputs":[{"name":"_to","type":"address"},{"name":"_minGasLimit","type":"uint32"},{"name":"_extraData","type":"bytes"}],"name":"depositETHTo","outputs":[],"stateMutability":"payable","type":"function"},
+		{"inputs":[{"name":"_l1Token","type":"address"},{"name":"_l2Token","type":"address"},{"name":"_to","type":"address"},{"name":"_amount","type":"uint256"},{"name":"_minGasLimit","type":"uint32"},{"name":"_extraData","type":"bytes"}],"name":"depositERC20To","outputs":[],"stateMutability":"nonpayable","type":"function"},
+		{"inputs":[{"name":"_l2Token","type":"address"},{"name":"_to","type":"address"},{"name":"_amount","type":"uint256"},{"name":"_minGasLimit","type":"uint32"},{"name":"_extraData","type":"bytes"}],"name":"withdrawTo","outputs":[],"stateMutability":"payable","type":"function"}
+	]`
+	// OptimismMintableERC20 tokens created for the L1 tokens bridged to the OP Stack chains
+	opStackMintableERC20ABI = `[
+		{"inputs":[],"name":"remoteToken","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"}
+	]`
+	// Inbox on L1 and ArbSys precompile on L2 of the Arbitrum chains
+	arbitrumBridgeABI = `[
+		{"inputs":[],"name":"depositEth","outputs":[{"name":"","type":"uint256"}],"stateMutability":"payable","type":"function"},
+		{"inputs":[{"name":"destination","type":"address"}],"name":"withdrawEth","outputs":[{"name":"","type":"uint256"}],"stateMutability":"payable","type":"function"}
+	]`
+
+	// gas limit of the message relayed to the other chain, the default used by the OP Stack SDK
+	opStackMinGasLimit = uint32(200000)
+	// used when the token deposit can't be estimated because the allowance is not placed yet
+	opStackERC20DepositGas = 250000
+)
+
+// NativeBridgeProcessor moves funds through the canonical bridges of the L2 chains. Deposits arrive in minutes,
+// withdrawals have to be proven and finalized on L1 after the challenge period, see the `nativebridge` package.
+type NativeBridgeProcessor struct {
+	rpcClient    *rpc.Client
+	transactor   transactions.TransactorIface
+	tokenManager *token.Manager
+
+	// [fromChain-toChain-symbol, bridgeable], whether the L2 token is the bridge representation of the L1 token
+	bridgeableTokens   map[string]bool
+	bridgeableTokensMu sync.Mutex
+}
+
+func NewNativeBridgeProcessor(rpcClient *rpc.Client, transactor transactions.TransactorIface, tokenManager *token.Manager) *NativeBridgeProcessor {
+	return &NativeBridgeProcessor{
+		rpcClient:        rpcClient,
+		transactor:       transactor,
+		tokenManager:     tokenManager,
+		bridgeableTokens: make(map[string]bool),
+	}
+}
+
+func createBridgeNativeErrorResponse(err error) error {
+	return createErrorResponse(ProcessorBridgeNativeName, err)
+}
+
+func (s *NativeBridgeProcessor) Name() string {
+	return ProcessorBridgeNativeName
+}
+
+// counterpartToken returns the token with the same symbol on the other side of the bridge
+func (s *NativeBridgeProcessor) counterpartToken(network *params.Network, fromToken *token.Token) *token.Token {
+	if s.tokenManager == nil {
+		return nil
+	}
+	counterpart := s.tokenManager.FindToken(network, fromToken.Symbol)
+	if counterpart == nil || counterpart.IsNative() {
+		return nil
+	}
+	return counterpart
+}
+
+// isBridgeableToken checks that the L2 token is minted by the standard bridge for the L1 token, tokens with the same symbol
+// issued natively on the L2 chain (like USDC) can't be bridged through it
+func (s *NativeBridgeProcessor) isBridgeableToken(params ProcessorInputParams, deposit bool) (bool, error) {
+	counterpart := s.counterpartToken(params.ToChain, params.FromToken)
+	if counterpart == nil {
+		return false, nil
+	}
+	if params.TestsMode {
+		return true, nil
+	}
+
+	key := makeKey(params.FromChain.ChainID, params.ToChain.ChainID, params.FromToken.Symbol, "")
+	s.bridgeableTokensMu.Lock()
+	bridgeable, ok := s.bridgeableTokens[key]
+	s.bridgeableTokensMu.Unlock()
+	if ok {
+		return bridgeable, nil
+	}
+
+	l1Token, l2Token, l2ChainID := params.FromToken.Address, counterpart.Address, params.ToChain.ChainID
+	if !deposit {
+		l1Token, l2Token, l2ChainID = counterpart.Address, params.FromToken.Address, params.FromChain.ChainID
+	}
+
+	mintableABI, err := abi.JSON(strings.NewReader(opStackMintableERC20ABI))
+	if err != nil {
+		return false, createBridgeNativeErrorResponse(err)
+	}
+	input, err := mintableABI.Pack("remoteToken")
+	if err != nil {
+		return false, createBridgeNativeErrorResponse(err)
+	}
+
+	ethClient, err := s.rpcClient.EthClient(l2ChainID)
+	if err != nil {
+		return false, createBridgeNativeErrorResponse(err)
+	}
+	output, err := ethClient.CallContract(context.Background(), ethereum.CallMsg{To: &l2Token, Data: input}, nil)
+	// tokens without `remoteToken` are not bridge tokens
+	bridgeable = err == nil && len(output) == common.HashLength && common.BytesToAddress(output) == l1Token
+
+	s.bridgeableTokensMu.Lock()
+	s.bridgeableTokens[key] = bridgeable
+	s.bridgeableTokensMu.Unlock()
+	return bridgeable, nil
+}
+
+func (s *NativeBridgeProcessor) AvailableFor(params ProcessorInputParams) (bool, error) {
+	if params.FromChain == nil || params.ToChain == nil {
+		return false, ErrNoChainSet
+	}
+	if params.FromToken == nil {
+		return false, ErrNoTokenSet
+	}
+	if params.ToToken != nil {
+		return false, ErrToTokenShouldNotBeSet
+	}
+	if params.FromChain.ChainID == params.ToChain.ChainID {
+		return false, ErrFromAndToChainsMustBeDifferent
+	}
+
+	bridge, deposit, found := nativebridge.FindBridge(params.FromChain.ChainID, params.ToChain.ChainID)
+	if !found {
+		return false, nil
+	}
+
+	switch bridge.Kind {
+	case nativebridge.KindOPStack:
+		if params.FromToken.IsNative() {
+			return true, nil
+		}
+		return s.isBridgeableToken(params, deposit)
+	case nativebridge.KindArbitrum:
+		// tokens go through the gateway router which requires paying for the L2 execution in advance, only ETH is supported.
+		// `depositEth` credits the sender on L2, so ETH can be deposited only to the same account.
+		return params.FromToken.IsNative() && (!deposit || params.FromAddr == params.ToAddr), nil
+	}
+	return false, nil
+}
+
+func (s *NativeBridgeProcessor) CalculateFees(params ProcessorInputParams) (*big.Int, *big.Int, error) {
+	return walletCommon.ZeroBigIntValue(), walletCommon.ZeroBigIntValue(), nil
+}
+
+func getNativeBridgeContractAddress(fromChainID uint64, toChainID uint64) (common.Address, error) {
+	bridge, deposit, found := nativebridge.FindBridge(fromChainID, toChainID)
+	if !found {
+		return common.Address{}, ErrTxForChainNotSupported
+	}
+
+	switch bridge.Kind {
+	case nativebridge.KindOPStack:
+		if deposit {
+			return bridge.L1StandardBridge, nil
+		}
+		return nativebridge.OPStackL2StandardBridge, nil
+	case nativebridge.KindArbitrum:
+		if deposit {
+			return bridge.Inbox, nil
+		}
+		return nativebridge.ArbitrumArbSys, nil
+	}
+	return common.Address{}, ErrTxForChainNotSupported
+}
+
+func (s *NativeBridgeProcessor) PackTxInputData(params ProcessorInputParams) ([]byte, error) {
+	bridge, deposit, found := nativebridge.FindBridge(params.FromChain.ChainID, params.ToChain.ChainID)
+	if !found {
+		return []byte{}, ErrTxForChainNotSupported
+	}
+
+	if bridge.Kind == nativebridge.KindArbitrum {
+		arbitrumABI, err := abi.JSON(strings.NewReader(arbitrumBridgeABI))
+		if err != nil {
+			return []byte{}, createBridgeNativeErrorResponse(err)
+		}
+		if deposit {
+			return arbitrumABI.Pack("depositEth")
+		}
+		return arbitrumABI.Pack("withdrawEth", params.ToAddr)
+	}
+
+	bridgeABI, err := abi.JSON(strings.NewReader(opStackStandardBridgeABI))
+	if err != nil {
+		return []byte{}, createBridgeNativeErrorResponse(err)
+	}
+
+	if params.FromToken.IsNative() {
+		if deposit {
+			return bridgeABI.Pack("depositETHTo", params.ToAddr, opStackMinGasLimit, []byte{})
+		}
+		return bridgeABI.Pack("withdrawTo", nativebridge.OPStackLegacyERC20ETH, params.ToAddr, params.AmountIn, opStackMinGasLimit, []byte{})
+	}
+
+	if !deposit {
+		return bridgeABI.Pack("withdrawTo", params.FromToken.Address, params.ToAddr, params.AmountIn, opStackMinGasLimit, []byte{})
+	}
+	counterpart := s.counterpartToken(params.ToChain, params.FromToken)
+	if counterpart == nil {
+		return []byte{}, ErrTokenNotFound
+	}
+	return bridgeABI.Pack("depositERC20To", params.FromToken.Address, counterpart.Address, params.ToAddr, params.AmountIn,
+		opStackMinGasLimit, []byte{})
+}
+
+func (s *NativeBridgeProcessor) EstimateGas(params ProcessorInputParams) (uint64, error) {
+	if params.TestsMode {
+		if params.TestEstimationMap != nil {
+			if val, ok := params.TestEstimationMap[s.Name()]; ok {
+				return val.Value, val.Err
+			}
+		}
+		return 0, ErrNoEstimationFound
+	}
+
+	contractAddress, err := getNativeBridgeContractAddress(params.FromChain.ChainID, params.ToChain.ChainID)
+	if err != nil {
+		return 0, err
+	}
+
+	input, err := s.PackTxInputData(params)
+	if err != nil {
+		return 0, createBridgeNativeErrorResponse(err)
+	}
+
+	value := big.NewInt(0)
+	if params.FromToken.IsNative() {
+		value = params.AmountIn
+	}
+
+	estimation, err := s.transactor.EstimateGas(params.FromChain, params.FromAddr, contractAddress, value, input)
+	if err != nil {
+		_, deposit, _ := nativebridge.FindBridge(params.FromChain.ChainID, params.ToChain.ChainID)
+		if params.FromToken.IsNative() || !deposit {
+			return 0, createBridgeNativeErrorResponse(err)
+		}
+		// the bridge can't pull the tokens before the approval is placed
+		estimation = opStackERC20DepositGas
+	}
+
+	increasedEstimation := float64(estimation) * IncreaseEstimatedGasFactor
+	return uint64(increasedEstimation), nil
+}
+
+// setBridgeAsRecipient makes the tx call the bridge contract, the recipient is part of the tx data
+func setBridgeAsRecipient(fromChainID uint64, toChainID uint64, sendArgs *transactions.SendTxArgs) error {
+	contractAddress, err := getNativeBridgeContractAddress(fromChainID, toChainID)
+	if err != nil {
+		return err
+	}
+	to := types.Address(contractAddress)
+	sendArgs.To = &to
+	return nil
+}
+
+// Send expects the bridge tx data packed by `PackTxInputData` to be set in the transfer tx
+func (s *NativeBridgeProcessor) Send(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64, verifiedAccount *account.SelectedExtKey) (types.Hash, uint64, error) {
+	if sendArgs.TransferTx == nil {
+		return types.Hash{}, 0, ErrNoTransferTxSet
+	}
+	err := setBridgeAsRecipient(sendArgs.ChainID, sendArgs.TransferTx.ToChainID, sendArgs.TransferTx)
+	if err != nil {
+		return types.Hash{}, 0, err
+	}
+	return s.transactor.SendTransactionWithChainID(sendArgs.ChainID, *sendArgs.TransferTx, lastUsedNonce, verifiedAccount)
+}
+
+func (s *NativeBridgeProcessor) BuildTransaction(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	if sendArgs.TransferTx == nil {
+		return nil, 0, ErrNoTransferTxSet
+	}
+	err := setBridgeAsRecipient(sendArgs.ChainID, sendArgs.TransferTx.ToChainID, sendArgs.TransferTx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.transactor.ValidateAndBuildTransaction(sendArgs.ChainID, *sendArgs.TransferTx, lastUsedNonce)
+}
+
+func (s *NativeBridgeProcessor) BuildTransactionV2(sendArgs *transactions.SendTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	err := setBridgeAsRecipient(sendArgs.FromChainID, sendArgs.ToChainID, sendArgs)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.transactor.ValidateAndBuildTransaction(sendArgs.FromChainID, *sendArgs, lastUsedNonce)
+}
+
+func (s *NativeBridgeProcessor) CalculateAmountOut(params ProcessorInputParams) (*big.Int, error) {
+	return params.AmountIn, nil
+}
+
+// GetContractAddress returns the L1 standard bridge for token deposits, withdrawals burn the tokens without an allowance
+func (s *NativeBridgeProcessor) GetContractAddress(params ProcessorInputParams) (common.Address, error) {
+	if params.FromToken != nil && params.FromToken.IsNative() {
+		return common.Address{}, nil
+	}
+	bridge, deposit, found := nativebridge.FindBridge(params.FromChain.ChainID, params.ToChain.ChainID)
+	if !found {
+		return common.Address{}, ErrContractNotFound
+	}
+	if !deposit || bridge.Kind != nativebridge.KindOPStack {
+		return common.Address{}, nil
+	}
+	return bridge.L1StandardBridge, nil
+}
@@ -0,0 +1,124 @@
+package pathprocessor
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/params"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/nativebridge"
+	"github.com/status-im/status-go/services/wallet/token"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNativeBridgeProcessorAvailableFor(t *testing.T) {
+	processor := NewNativeBridgeProcessor(nil, nil, nil)
+
+	testInputParams := ProcessorInputParams{
+		FromChain: &params.Network{ChainID: walletCommon.EthereumMainnet},
+		ToChain:   &params.Network{ChainID: walletCommon.OptimismMainnet},
+		FromToken: &token.Token{Symbol: EthSymbol},
+		FromAddr:  common.HexToAddress("0x1"),
+		ToAddr:    common.HexToAddress("0x2"),
+		AmountIn:  big.NewInt(100),
+	}
+
+	available, err := processor.AvailableFor(testInputParams)
+	require.NoError(t, err)
+	require.True(t, available)
+
+	// withdrawal
+	testInputParams.FromChain, testInputParams.ToChain = testInputParams.ToChain, testInputParams.FromChain
+	available, err = processor.AvailableFor(testInputParams)
+	require.NoError(t, err)
+	require.True(t, available)
+
+	// no canonical bridge between L2 chains
+	testInputParams.ToChain = &params.Network{ChainID: walletCommon.ArbitrumMainnet}
+	available, err = processor.AvailableFor(testInputParams)
+	require.NoError(t, err)
+	require.False(t, available)
+
+	// Arbitrum ETH deposits are credited to the sender
+	testInputParams.FromChain = &params.Network{ChainID: walletCommon.EthereumMainnet}
+	available, err = processor.AvailableFor(testInputParams)
+	require.NoError(t, err)
+	require.False(t, available)
+
+	testInputParams.ToAddr = testInputParams.FromAddr
+	available, err = processor.AvailableFor(testInputParams)
+	require.NoError(t, err)
+	require.True(t, available)
+
+	// only ETH can be bridged through the Arbitrum inbox
+	testInputParams.FromToken = &token.Token{Symbol: "USDC", Address: common.HexToAddress("0x3")}
+	available, err = processor.AvailableFor(testInputParams)
+	require.NoError(t, err)
+	require.False(t, available)
+
+	// ETH can be withdrawn from Arbitrum to any account, the message is executed on the L1 outbox
+	testInputParams.FromToken = &token.Token{Symbol: EthSymbol}
+	testInputParams.FromChain, testInputParams.ToChain = testInputParams.ToChain, testInputParams.FromChain
+	testInputParams.ToAddr = common.HexToAddress("0x4")
+	available, err = processor.AvailableFor(testInputParams)
+	require.NoError(t, err)
+	require.True(t, available)
+
+	testInputParams.ToChain = testInputParams.FromChain
+	_, err = processor.AvailableFor(testInputParams)
+	require.ErrorIs(t, err, ErrFromAndToChainsMustBeDifferent)
+
+	testInputParams.ToToken = &token.Token{Symbol: EthSymbol}
+	_, err = processor.AvailableFor(testInputParams)
+	require.ErrorIs(t, err, ErrToTokenShouldNotBeSet)
+}
+
+func TestNativeBridgeProcessorPackTxInputData(t *testing.T) {
+	processor := NewNativeBridgeProcessor(nil, nil, nil)
+	opStackABI, err := abi.JSON(strings.NewReader(opStackStandardBridgeABI))
+	require.NoError(t, err)
+	arbitrumABI, err := abi.JSON(strings.NewReader(arbitrumBridgeABI))
+	require.NoError(t, err)
+
+	testInputParams := ProcessorInputParams{
+		FromChain: &params.Network{ChainID: walletCommon.EthereumMainnet},
+		ToChain:   &params.Network{ChainID: walletCommon.BaseMainnet},
+		FromToken: &token.Token{Symbol: EthSymbol},
+		ToAddr:    common.HexToAddress("0x2"),
+		AmountIn:  big.NewInt(100),
+	}
+
+	data, err := processor.PackTxInputData(testInputParams)
+	require.NoError(t, err)
+	method, err := opStackABI.MethodById(data[:4])
+	require.NoError(t, err)
+	require.Equal(t, "depositETHTo", method.Name)
+
+	testInputParams.FromChain, testInputParams.ToChain = testInputParams.ToChain, testInputParams.FromChain
+	data, err = processor.PackTxInputData(testInputParams)
+	require.NoError(t, err)
+	method, err = opStackABI.MethodById(data[:4])
+	require.NoError(t, err)
+	require.Equal(t, "withdrawTo", method.Name)
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	require.Equal(t, nativebridge.OPStackLegacyERC20ETH, args[0])
+	require.Equal(t, testInputParams.ToAddr, args[1])
+	require.Equal(t, big.NewInt(100), args[2])
+
+	testInputParams.FromChain = &params.Network{ChainID: walletCommon.ArbitrumMainnet}
+	data, err = processor.PackTxInputData(testInputParams)
+	require.NoError(t, err)
+	method, err = arbitrumABI.MethodById(data[:4])
+	require.NoError(t, err)
+	require.Equal(t, "withdrawEth", method.Name)
+
+	contractAddress, err := getNativeBridgeContractAddress(testInputParams.FromChain.ChainID, testInputParams.ToChain.ChainID)
+	require.NoError(t, err)
+	require.Equal(t, nativebridge.ArbitrumArbSys, contractAddress)
+}
@@ -0,0 +1,209 @@
+package pathprocessor
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/rpc"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/transactions"
+)
+
+// disperseABI is the ABI of the Disperse contract (https://disperse.app)
+const disperseABI = `[
+	{"constant":false,"inputs":[{"name":"recipients","type":"address[]"},{"name":"values","type":"uint256[]"}],"name":"disperseEther","outputs":[],"payable":true,"stateMutability":"payable","type":"function"},
+	{"constant":false,"inputs":[{"name":"token","type":"address"},{"name":"recipients","type":"address[]"},{"name":"values","type":"uint256[]"}],"name":"disperseToken","outputs":[],"payable":false,"stateMutability":"nonpayable","type":"function"}
+]`
+
+const (
+	// used when the token disperse can't be estimated because the allowance is not placed yet
+	disperseTokenBaseGas         = 50000
+	disperseTokenGasPerRecipient = 35000
+)
+
+var disperseContractAddressByChainID = map[uint64]common.Address{
+	walletCommon.EthereumMainnet: common.HexToAddress("0xD152f549545093347A162Dce210e7293f1452150"),
+	walletCommon.OptimismMainnet: common.HexToAddress("0xD152f549545093347A162Dce210e7293f1452150"),
+	walletCommon.ArbitrumMainnet: common.HexToAddress("0xD152f549545093347A162Dce210e7293f1452150"),
+}
+
+func disperseContractAddress(chainID uint64) (common.Address, error) {
+	contractAddress, ok := disperseContractAddressByChainID[chainID]
+	if !ok {
+		return common.Address{}, ErrDisperseNotAvailableOnChain
+	}
+	return contractAddress, nil
+}
+
+// TransferRecipient is a recipient of a batch transfer
+type TransferRecipient struct {
+	Address common.Address `json:"address"`
+	Amount  *hexutil.Big   `json:"amount"`
+}
+
+func totalRecipientsAmount(recipients []*TransferRecipient) *big.Int {
+	total := big.NewInt(0)
+	for _, recipient := range recipients {
+		total.Add(total, recipient.Amount.ToInt())
+	}
+	return total
+}
+
+// DisperseProcessor sends the same token to many recipients in a single call of the Disperse contract
+type DisperseProcessor struct {
+	rpcClient  *rpc.Client
+	transactor transactions.TransactorIface
+}
+
+func NewDisperseProcessor(rpcClient *rpc.Client, transactor transactions.TransactorIface) *DisperseProcessor {
+	return &DisperseProcessor{rpcClient: rpcClient, transactor: transactor}
+}
+
+func createDisperseErrorResponse(err error) error {
+	return createErrorResponse(ProcessorDisperseName, err)
+}
+
+func (s *DisperseProcessor) Name() string {
+	return ProcessorDisperseName
+}
+
+func (s *DisperseProcessor) AvailableFor(params ProcessorInputParams) (bool, error) {
+	if params.FromChain == nil || params.ToChain == nil {
+		return false, ErrNoChainSet
+	}
+	if params.FromToken == nil {
+		return false, ErrNoTokenSet
+	}
+	if params.ToToken != nil {
+		return false, ErrToTokenShouldNotBeSet
+	}
+	if params.FromChain.ChainID != params.ToChain.ChainID {
+		return false, nil
+	}
+	// a disperse pays all the recipients, so it can't be combined with other paths
+	if len(params.Recipients) < 2 || params.AmountIn == nil || totalRecipientsAmount(params.Recipients).Cmp(params.AmountIn) != 0 {
+		return false, nil
+	}
+	_, err := disperseContractAddress(params.FromChain.ChainID)
+	return err == nil, nil
+}
+
+func (s *DisperseProcessor) CalculateFees(params ProcessorInputParams) (*big.Int, *big.Int, error) {
+	return walletCommon.ZeroBigIntValue(), walletCommon.ZeroBigIntValue(), nil
+}
+
+func (s *DisperseProcessor) PackTxInputData(params ProcessorInputParams) ([]byte, error) {
+	disperseABI, err := abi.JSON(strings.NewReader(disperseABI))
+	if err != nil {
+		return []byte{}, createDisperseErrorResponse(err)
+	}
+
+	recipients := make([]common.Address, 0, len(params.Recipients))
+	values := make([]*big.Int, 0, len(params.Recipients))
+	for _, recipient := range params.Recipients {
+		recipients = append(recipients, recipient.Address)
+		values = append(values, recipient.Amount.ToInt())
+	}
+
+	if params.FromToken.IsNative() {
+		return disperseABI.Pack("disperseEther", recipients, values)
+	}
+	return disperseABI.Pack("disperseToken", params.FromToken.Address, recipients, values)
+}
+
+func (s *DisperseProcessor) EstimateGas(params ProcessorInputParams) (uint64, error) {
+	if params.TestsMode {
+		if params.TestEstimationMap != nil {
+			if val, ok := params.TestEstimationMap[s.Name()]; ok {
+				return val.Value, val.Err
+			}
+		}
+		return 0, ErrNoEstimationFound
+	}
+
+	contractAddress, err := disperseContractAddress(params.FromChain.ChainID)
+	if err != nil {
+		return 0, err
+	}
+
+	input, err := s.PackTxInputData(params)
+	if err != nil {
+		return 0, createDisperseErrorResponse(err)
+	}
+
+	value := big.NewInt(0)
+	if params.FromToken.IsNative() {
+		value = params.AmountIn
+	}
+
+	estimation, err := s.transactor.EstimateGas(params.FromChain, params.FromAddr, contractAddress, value, input)
+	if err != nil {
+		if params.FromToken.IsNative() {
+			return 0, createDisperseErrorResponse(err)
+		}
+		// the contract can't pull the tokens before the approval is placed
+		estimation = disperseTokenBaseGas + disperseTokenGasPerRecipient*uint64(len(params.Recipients))
+	}
+
+	increasedEstimation := float64(estimation) * IncreaseEstimatedGasFactor
+	return uint64(increasedEstimation), nil
+}
+
+// setContractAsRecipient makes the tx call the Disperse contract, the recipients are part of the tx data
+func setContractAsRecipient(chainID uint64, sendArgs *transactions.SendTxArgs) error {
+	contractAddress, err := disperseContractAddress(chainID)
+	if err != nil {
+		return err
+	}
+	to := types.Address(contractAddress)
+	sendArgs.To = &to
+	return nil
+}
+
+func (s *DisperseProcessor) Send(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64, verifiedAccount *account.SelectedExtKey) (types.Hash, uint64, error) {
+	if sendArgs.TransferTx == nil {
+		return types.Hash{}, 0, ErrNoTransferTxSet
+	}
+	err := setContractAsRecipient(sendArgs.ChainID, sendArgs.TransferTx)
+	if err != nil {
+		return types.Hash{}, 0, err
+	}
+	return s.transactor.SendTransactionWithChainID(sendArgs.ChainID, *sendArgs.TransferTx, lastUsedNonce, verifiedAccount)
+}
+
+func (s *DisperseProcessor) BuildTransaction(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	if sendArgs.TransferTx == nil {
+		return nil, 0, ErrNoTransferTxSet
+	}
+	err := setContractAsRecipient(sendArgs.ChainID, sendArgs.TransferTx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.transactor.ValidateAndBuildTransaction(sendArgs.ChainID, *sendArgs.TransferTx, lastUsedNonce)
+}
+
+func (s *DisperseProcessor) BuildTransactionV2(sendArgs *transactions.SendTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	err := setContractAsRecipient(sendArgs.FromChainID, sendArgs)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.transactor.ValidateAndBuildTransaction(sendArgs.FromChainID, *sendArgs, lastUsedNonce)
+}
+
+func (s *DisperseProcessor) CalculateAmountOut(params ProcessorInputParams) (*big.Int, error) {
+	return params.AmountIn, nil
+}
+
+// GetContractAddress returns the Disperse contract, which needs an allowance to send tokens
+func (s *DisperseProcessor) GetContractAddress(params ProcessorInputParams) (common.Address, error) {
+	if params.FromToken != nil && params.FromToken.IsNative() {
+		return common.Address{}, nil
+	}
+	return disperseContractAddress(params.FromChain.ChainID)
+}
@@ -0,0 +1,107 @@
+package pathprocessor
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/status-im/status-go/params"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/token"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testRecipients() []*TransferRecipient {
+	return []*TransferRecipient{
+		{Address: common.HexToAddress("0x1"), Amount: (*hexutil.Big)(big.NewInt(100))},
+		{Address: common.HexToAddress("0x2"), Amount: (*hexutil.Big)(big.NewInt(250))},
+	}
+}
+
+func TestDisperseProcessorAvailableFor(t *testing.T) {
+	processor := NewDisperseProcessor(nil, nil)
+
+	testInputParams := ProcessorInputParams{
+		FromChain:  &params.Network{ChainID: walletCommon.EthereumMainnet},
+		ToChain:    &params.Network{ChainID: walletCommon.EthereumMainnet},
+		FromToken:  &token.Token{Symbol: EthSymbol},
+		AmountIn:   big.NewInt(350),
+		Recipients: testRecipients(),
+	}
+
+	available, err := processor.AvailableFor(testInputParams)
+	require.NoError(t, err)
+	require.True(t, available)
+
+	// the amount must be the sum of all the recipients amounts
+	testInputParams.AmountIn = big.NewInt(300)
+	available, err = processor.AvailableFor(testInputParams)
+	require.NoError(t, err)
+	require.False(t, available)
+
+	// a single recipient is sent as a plain transfer
+	testInputParams.AmountIn = big.NewInt(100)
+	testInputParams.Recipients = testRecipients()[:1]
+	available, err = processor.AvailableFor(testInputParams)
+	require.NoError(t, err)
+	require.False(t, available)
+
+	// no contract on the chain
+	testInputParams.AmountIn = big.NewInt(350)
+	testInputParams.Recipients = testRecipients()
+	testInputParams.FromChain = &params.Network{ChainID: walletCommon.EthereumSepolia}
+	testInputParams.ToChain = &params.Network{ChainID: walletCommon.EthereumSepolia}
+	available, err = processor.AvailableFor(testInputParams)
+	require.NoError(t, err)
+	require.False(t, available)
+}
+
+func TestDisperseProcessorPackTxInputData(t *testing.T) {
+	processor := NewDisperseProcessor(nil, nil)
+	parsedABI, err := abi.JSON(strings.NewReader(disperseABI))
+	require.NoError(t, err)
+
+	testInputParams := ProcessorInputParams{
+		FromChain:  &params.Network{ChainID: walletCommon.EthereumMainnet},
+		ToChain:    &params.Network{ChainID: walletCommon.EthereumMainnet},
+		FromToken:  &token.Token{Symbol: EthSymbol},
+		AmountIn:   big.NewInt(350),
+		Recipients: testRecipients(),
+	}
+
+	data, err := processor.PackTxInputData(testInputParams)
+	require.NoError(t, err)
+	method, err := parsedABI.MethodById(data[:4])
+	require.NoError(t, err)
+	require.Equal(t, "disperseEther", method.Name)
+
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{common.HexToAddress("0x1"), common.HexToAddress("0x2")}, args[0])
+	require.Equal(t, []*big.Int{big.NewInt(100), big.NewInt(250)}, args[1])
+
+	tokenAddress := common.HexToAddress("0x123")
+	testInputParams.FromToken = &token.Token{Symbol: UsdcSymbol, Address: tokenAddress}
+	data, err = processor.PackTxInputData(testInputParams)
+	require.NoError(t, err)
+	method, err = parsedABI.MethodById(data[:4])
+	require.NoError(t, err)
+	require.Equal(t, "disperseToken", method.Name)
+
+	args, err = method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	require.Equal(t, tokenAddress, args[0])
+
+	contractAddress, err := processor.GetContractAddress(testInputParams)
+	require.NoError(t, err)
+	require.Equal(t, disperseContractAddressByChainID[walletCommon.EthereumMainnet], contractAddress)
+
+	testInputParams.FromChain = &params.Network{ChainID: walletCommon.EthereumSepolia}
+	_, err = processor.GetContractAddress(testInputParams)
+	require.ErrorIs(t, err, ErrDisperseNotAvailableOnChain)
+}
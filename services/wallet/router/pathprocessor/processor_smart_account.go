@@ -0,0 +1,518 @@
+package pathprocessor
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/contracts/ierc20"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/services/wallet/bigint"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty/bundler"
+	"github.com/status-im/status-go/transactions"
+)
+
+// simpleAccountABI is the subset of the eth-infinitism SimpleAccount ABI used to build user operations
+const simpleAccountABI = `[
+	{"inputs":[{"internalType":"address","name":"dest","type":"address"},{"internalType":"uint256","name":"value","type":"uint256"},{"internalType":"bytes","name":"func","type":"bytes"}],"name":"execute","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[],"name":"owner","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"}
+]`
+
+// entryPointABI is the subset of the EntryPoint v0.6 ABI used to build user operations
+const entryPointABI = `[
+	{"inputs":[{"internalType":"address","name":"sender","type":"address"},{"internalType":"uint192","name":"key","type":"uint192"}],"name":"getNonce","outputs":[{"internalType":"uint256","name":"nonce","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+// dummySignature is a well formed ECDSA signature used for gas estimation, SimpleAccount rejects malformed signatures
+// with a revert instead of a validation failure, which would make the estimation fail
+var dummySignature = common.FromHex("0xfffffffffffffffffffffffffffffff0000000000000000000000000000000007aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa1c")
+
+// UserOperationProcessor is implemented by the processors sending ERC-4337 user operations instead of transactions
+type UserOperationProcessor interface {
+	// BuildUserOperation builds the user operation based on SendTxArgs, returns it and the hash to sign by the owner of the smart account
+	BuildUserOperation(ctx context.Context, sendArgs *transactions.SendTxArgs) (*bundler.UserOperation, types.Hash, error)
+	// UserOperationCallMsg returns the call the entry point makes to the smart account when executing the user operation,
+	// used to simulate the user operation before it's sent
+	UserOperationCallMsg(chainID uint64, userOp *bundler.UserOperation) (ethereum.CallMsg, error)
+	// SendUserOperationWithSignature sends the built user operation with the signature of its hash, returns the user operation hash
+	SendUserOperationWithSignature(ctx context.Context, sendArgs *transactions.SendTxArgs, userOp *bundler.UserOperation, signature []byte) (types.Hash, error)
+}
+
+// SmartAccountProcessor sends transfers from an ERC-4337 smart account (SimpleAccount compatible) owned by a wallet account.
+// The transfer is wrapped in a user operation, optionally sponsored by a paymaster, and sent through a bundler.
+type SmartAccountProcessor struct {
+	rpcClient      *rpc.Client
+	bundlers       *bundler.Clients
+	pendingTracker *transactions.PendingTxTracker
+}
+
+func NewSmartAccountProcessor(rpcClient *rpc.Client, bundlers *bundler.Clients, pendingTracker *transactions.PendingTxTracker) *SmartAccountProcessor {
+	return &SmartAccountProcessor{
+		rpcClient:      rpcClient,
+		bundlers:       bundlers,
+		pendingTracker: pendingTracker,
+	}
+}
+
+func createSmartAccountErrorResponse(err error) error {
+	return createErrorResponse(ProcessorSmartAccountTransferName, err)
+}
+
+func (s *SmartAccountProcessor) Name() string {
+	return ProcessorSmartAccountTransferName
+}
+
+func (s *SmartAccountProcessor) AvailableFor(params ProcessorInputParams) (bool, error) {
+	if params.FromChain == nil || params.ToChain == nil {
+		return false, ErrNoChainSet
+	}
+	if params.FromToken == nil {
+		return false, ErrNoTokenSet
+	}
+	if params.ToToken != nil {
+		return false, ErrToTokenShouldNotBeSet
+	}
+	if params.FromChain.ChainID != params.ToChain.ChainID {
+		return false, nil
+	}
+	_, ok := s.bundlers.Bundler(params.FromChain.ChainID)
+	return ok, nil
+}
+
+func (s *SmartAccountProcessor) CalculateFees(params ProcessorInputParams) (*big.Int, *big.Int, error) {
+	return walletCommon.ZeroBigIntValue(), walletCommon.ZeroBigIntValue(), nil
+}
+
+// PackTxInputData packs the smart account `execute` call performing the transfer
+func (s *SmartAccountProcessor) PackTxInputData(params ProcessorInputParams) ([]byte, error) {
+	if params.FromToken.IsNative() {
+		return packSmartAccountExecute(params.ToAddr, params.AmountIn, []byte{})
+	}
+
+	erc20ABI, err := abi.JSON(strings.NewReader(ierc20.IERC20ABI))
+	if err != nil {
+		return []byte{}, createSmartAccountErrorResponse(err)
+	}
+	transferData, err := erc20ABI.Pack("transfer", params.ToAddr, params.AmountIn)
+	if err != nil {
+		return []byte{}, createSmartAccountErrorResponse(err)
+	}
+	return packSmartAccountExecute(params.FromToken.Address, big.NewInt(0), transferData)
+}
+
+// EstimateGas returns the sum of the user operation gas limits
+func (s *SmartAccountProcessor) EstimateGas(params ProcessorInputParams) (uint64, error) {
+	if params.TestsMode {
+		if params.TestEstimationMap != nil {
+			if val, ok := params.TestEstimationMap[s.Name()]; ok {
+				return val.Value, val.Err
+			}
+		}
+		return 0, ErrNoEstimationFound
+	}
+
+	bundlerClient, ok := s.bundlers.Bundler(params.FromChain.ChainID)
+	if !ok {
+		return 0, ErrBundlerNotConfigured
+	}
+
+	callData, err := s.PackTxInputData(params)
+	if err != nil {
+		return 0, createSmartAccountErrorResponse(err)
+	}
+
+	ctx := context.Background()
+	nonce, err := s.getNonce(ctx, params.FromChain.ChainID, bundlerClient.EntryPoint(), params.FromAddr)
+	if err != nil {
+		return 0, createSmartAccountErrorResponse(err)
+	}
+
+	userOp := &bundler.UserOperation{
+		Sender:               params.FromAddr,
+		Nonce:                (*hexutil.Big)(nonce),
+		CallData:             callData,
+		CallGasLimit:         (*hexutil.Big)(big.NewInt(0)),
+		VerificationGasLimit: (*hexutil.Big)(big.NewInt(0)),
+		PreVerificationGas:   (*hexutil.Big)(big.NewInt(0)),
+		MaxFeePerGas:         (*hexutil.Big)(big.NewInt(0)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(big.NewInt(0)),
+		Signature:            dummySignature,
+	}
+
+	estimate, err := bundlerClient.EstimateUserOperationGas(ctx, userOp)
+	if err != nil {
+		return 0, createSmartAccountErrorResponse(err)
+	}
+
+	estimation := totalUserOperationGas(estimate.CallGasLimit, estimate.VerificationGasLimit, estimate.PreVerificationGas)
+	increasedEstimation := float64(estimation) * IncreaseEstimatedGasFactor
+	return uint64(increasedEstimation), nil
+}
+
+// Send builds, signs and sends the user operation. `verifiedAccount` must be the owner of the smart account set in `TransferTx.From`.
+// The returned hash is the user operation hash and the returned nonce is the smart account nonce.
+func (s *SmartAccountProcessor) Send(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64, verifiedAccount *account.SelectedExtKey) (types.Hash, uint64, error) {
+	hash, nonce, err := s.sendUserOperation(context.Background(), sendArgs, verifiedAccount)
+	if err != nil {
+		return types.Hash{}, 0, createSmartAccountErrorResponse(err)
+	}
+	return hash, nonce, nil
+}
+
+// BuildTransaction is not supported, user operations are not transactions, they are built with BuildUserOperation
+func (s *SmartAccountProcessor) BuildTransaction(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	return nil, 0, ErrUserOperationBuildNotSupported
+}
+
+// BuildTransactionV2 is not supported, user operations are not transactions, they are built with BuildUserOperation
+func (s *SmartAccountProcessor) BuildTransactionV2(sendArgs *transactions.SendTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	return nil, 0, ErrUserOperationBuildNotSupported
+}
+
+func (s *SmartAccountProcessor) CalculateAmountOut(params ProcessorInputParams) (*big.Int, error) {
+	return params.AmountIn, nil
+}
+
+func (s *SmartAccountProcessor) GetContractAddress(params ProcessorInputParams) (common.Address, error) {
+	return common.Address{}, nil
+}
+
+// Owner returns the address of the account which signs the user operations of `smartAccount`
+func (s *SmartAccountProcessor) Owner(ctx context.Context, chainID uint64, smartAccount common.Address) (common.Address, error) {
+	accountABI, err := abi.JSON(strings.NewReader(simpleAccountABI))
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	data, err := accountABI.Pack("owner")
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	res, err := s.callContract(ctx, chainID, smartAccount, data)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	var owner common.Address
+	err = accountABI.UnpackIntoInterface(&owner, "owner", res)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return owner, nil
+}
+
+// FetchUserOperationsStatus implements transactions.UserOperationStatusFetcher
+func (s *SmartAccountProcessor) FetchUserOperationsStatus(ctx context.Context, chainID walletCommon.ChainID, hashes []common.Hash) (map[common.Hash]transactions.TxStatus, error) {
+	bundlerClient, ok := s.bundlers.Bundler(uint64(chainID))
+	if !ok {
+		return nil, ErrBundlerNotConfigured
+	}
+
+	res := make(map[common.Hash]transactions.TxStatus)
+	for _, hash := range hashes {
+		receipt, err := bundlerClient.GetUserOperationReceipt(ctx, hash)
+		if err != nil {
+			// the operation stays pending and is checked again, the others are still resolved
+			log.Warn("failed to fetch user operation receipt", "chainID", chainID, "hash", hash, "error", err)
+			continue
+		}
+		if receipt == nil {
+			continue
+		}
+		if receipt.Success {
+			res[hash] = transactions.Success
+		} else {
+			res[hash] = transactions.Failed
+		}
+	}
+	return res, nil
+}
+
+func (s *SmartAccountProcessor) sendUserOperation(ctx context.Context, sendArgs *MultipathProcessorTxArgs, verifiedAccount *account.SelectedExtKey) (types.Hash, uint64, error) {
+	if sendArgs.TransferTx == nil || sendArgs.TransferTx.To == nil {
+		return types.Hash{}, 0, ErrNoTransferTxSet
+	}
+
+	chainID := sendArgs.ChainID
+	tx := sendArgs.TransferTx
+	sender := common.Address(tx.From)
+	owner, err := s.Owner(ctx, chainID, sender)
+	if err != nil {
+		return types.Hash{}, 0, err
+	}
+	if verifiedAccount == nil || owner != common.Address(verifiedAccount.Address) {
+		return types.Hash{}, 0, ErrSmartAccountOwnerMismatch
+	}
+
+	value := big.NewInt(0)
+	if tx.Value != nil {
+		value = tx.Value.ToInt()
+	}
+	callData, err := packSmartAccountExecute(common.Address(*tx.To), value, tx.GetInput())
+	if err != nil {
+		return types.Hash{}, 0, err
+	}
+
+	userOp, entryPoint, err := s.buildUserOperation(ctx, chainID, tx, callData)
+	if err != nil {
+		return types.Hash{}, 0, err
+	}
+
+	err = signUserOperation(ctx, userOp, entryPoint, chainID, verifiedAccount)
+	if err != nil {
+		return types.Hash{}, 0, err
+	}
+
+	hash, err := s.submitUserOperation(ctx, chainID, tx, userOp)
+	if err != nil {
+		return types.Hash{}, 0, err
+	}
+	return hash, userOp.Nonce.ToInt().Uint64(), nil
+}
+
+// BuildUserOperation builds the user operation of a path, `sendArgs.Data` being the smart account call packed by
+// PackTxInputData. The returned hash is the one the owner of the smart account signs, the EIP-191 hash of the user
+// operation hash, so it can be signed like a transaction hash, e.g. on a keycard.
+func (s *SmartAccountProcessor) BuildUserOperation(ctx context.Context, sendArgs *transactions.SendTxArgs) (*bundler.UserOperation, types.Hash, error) {
+	if sendArgs.To == nil {
+		return nil, types.Hash{}, ErrNoTransferTxSet
+	}
+
+	userOp, entryPoint, err := s.buildUserOperation(ctx, sendArgs.FromChainID, sendArgs, sendArgs.GetInput())
+	if err != nil {
+		return nil, types.Hash{}, createSmartAccountErrorResponse(err)
+	}
+
+	userOpHash, err := userOp.Hash(entryPoint, sendArgs.FromChainID)
+	if err != nil {
+		return nil, types.Hash{}, createSmartAccountErrorResponse(err)
+	}
+	return userOp, types.BytesToHash(accounts.TextHash(userOpHash.Bytes())), nil
+}
+
+// UserOperationCallMsg returns the call of the entry point executing `userOp.CallData` on the smart account, the signature
+// and the paymaster are checked by the entry point before that call, so they aren't part of the simulation
+func (s *SmartAccountProcessor) UserOperationCallMsg(chainID uint64, userOp *bundler.UserOperation) (ethereum.CallMsg, error) {
+	bundlerClient, ok := s.bundlers.Bundler(chainID)
+	if !ok {
+		return ethereum.CallMsg{}, ErrBundlerNotConfigured
+	}
+
+	sender := userOp.Sender
+	msg := ethereum.CallMsg{
+		From:  bundlerClient.EntryPoint(),
+		To:    &sender,
+		Value: big.NewInt(0),
+		Data:  userOp.CallData,
+	}
+	if userOp.CallGasLimit != nil {
+		msg.Gas = userOp.CallGasLimit.ToInt().Uint64()
+	}
+	return msg, nil
+}
+
+// SendUserOperationWithSignature sends the user operation built with BuildUserOperation with the signature of the hash it
+// returned, the returned hash is the user operation hash
+func (s *SmartAccountProcessor) SendUserOperationWithSignature(ctx context.Context, sendArgs *transactions.SendTxArgs, userOp *bundler.UserOperation, signature []byte) (types.Hash, error) {
+	if len(signature) != crypto.SignatureLength {
+		return types.Hash{}, ErrInvalidUserOperationSignature
+	}
+
+	// SimpleAccount recovers the signer with ecrecover, which expects the recovery id as 27 or 28
+	userOp.Signature = common.CopyBytes(signature)
+	if userOp.Signature[crypto.RecoveryIDOffset] < 27 {
+		userOp.Signature[crypto.RecoveryIDOffset] += 27
+	}
+
+	hash, err := s.submitUserOperation(ctx, sendArgs.FromChainID, sendArgs, userOp)
+	if err != nil {
+		return types.Hash{}, createSmartAccountErrorResponse(err)
+	}
+	return hash, nil
+}
+
+// buildUserOperation builds the unsigned user operation making the smart account `tx.From` execute `callData`, the fees are
+// the ones of `tx`. It returns the user operation and the entry point it's built for.
+func (s *SmartAccountProcessor) buildUserOperation(ctx context.Context, chainID uint64, tx *transactions.SendTxArgs, callData []byte) (*bundler.UserOperation, common.Address, error) {
+	bundlerClient, ok := s.bundlers.Bundler(chainID)
+	if !ok {
+		return nil, common.Address{}, ErrBundlerNotConfigured
+	}
+
+	sender := common.Address(tx.From)
+	entryPoint := bundlerClient.EntryPoint()
+	nonce, err := s.getNonce(ctx, chainID, entryPoint, sender)
+	if err != nil {
+		return nil, common.Address{}, err
+	}
+
+	userOp := &bundler.UserOperation{
+		Sender:    sender,
+		Nonce:     (*hexutil.Big)(nonce),
+		CallData:  callData,
+		Signature: dummySignature,
+	}
+	setUserOperationFees(userOp, tx)
+
+	if paymaster, ok := s.bundlers.Paymaster(chainID); ok {
+		sponsorship, err := paymaster.SponsorUserOperation(ctx, userOp, entryPoint)
+		if err != nil {
+			return nil, common.Address{}, err
+		}
+		userOp.PaymasterAndData = sponsorship.PaymasterAndData
+		userOp.CallGasLimit = sponsorship.CallGasLimit
+		userOp.VerificationGasLimit = sponsorship.VerificationGasLimit
+		userOp.PreVerificationGas = sponsorship.PreVerificationGas
+	} else {
+		estimate, err := bundlerClient.EstimateUserOperationGas(ctx, userOp)
+		if err != nil {
+			return nil, common.Address{}, err
+		}
+		userOp.CallGasLimit = estimate.CallGasLimit
+		userOp.VerificationGasLimit = estimate.VerificationGasLimit
+		userOp.PreVerificationGas = estimate.PreVerificationGas
+	}
+
+	return userOp, entryPoint, nil
+}
+
+// submitUserOperation sends the signed user operation to the bundler and tracks it as a pending transaction
+func (s *SmartAccountProcessor) submitUserOperation(ctx context.Context, chainID uint64, tx *transactions.SendTxArgs, userOp *bundler.UserOperation) (types.Hash, error) {
+	bundlerClient, ok := s.bundlers.Bundler(chainID)
+	if !ok {
+		return types.Hash{}, ErrBundlerNotConfigured
+	}
+
+	userOpHash, err := bundlerClient.SendUserOperation(ctx, userOp)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	var to common.Address
+	if tx.To != nil {
+		to = common.Address(*tx.To)
+	}
+	value := big.NewInt(0)
+	if tx.Value != nil {
+		value = tx.Value.ToInt()
+	}
+
+	autoDelete := transactions.AutoDelete
+	err = s.pendingTracker.StoreAndTrackPendingTx(&transactions.PendingTransaction{
+		Hash:               userOpHash,
+		Timestamp:          uint64(time.Now().Unix()),
+		Value:              bigint.BigInt{Int: value},
+		From:               userOp.Sender,
+		To:                 to,
+		Data:               tx.GetInput().String(),
+		Symbol:             tx.Symbol,
+		GasPrice:           bigint.BigInt{Int: userOp.MaxFeePerGas.ToInt()},
+		GasLimit:           bigint.BigInt{Int: new(big.Int).SetUint64(totalUserOperationGas(userOp.CallGasLimit, userOp.VerificationGasLimit, userOp.PreVerificationGas))},
+		Type:               transactions.WalletUserOperation,
+		ChainID:            walletCommon.ChainID(chainID),
+		MultiTransactionID: tx.MultiTransactionID,
+		Nonce:              userOp.Nonce.ToInt().Uint64(),
+		AutoDelete:         &autoDelete,
+	})
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	return types.Hash(userOpHash), nil
+}
+
+func (s *SmartAccountProcessor) getNonce(ctx context.Context, chainID uint64, entryPoint common.Address, sender common.Address) (*big.Int, error) {
+	epABI, err := abi.JSON(strings.NewReader(entryPointABI))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := epABI.Pack("getNonce", sender, big.NewInt(0))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.callContract(ctx, chainID, entryPoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce *big.Int
+	err = epABI.UnpackIntoInterface(&nonce, "getNonce", res)
+	if err != nil {
+		return nil, err
+	}
+	return nonce, nil
+}
+
+func (s *SmartAccountProcessor) callContract(ctx context.Context, chainID uint64, contract common.Address, data []byte) ([]byte, error) {
+	ethClient, err := s.rpcClient.EthClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ethClient.CallContract(ctx, ethereum.CallMsg{
+		To:   &contract,
+		Data: data,
+	}, nil)
+}
+
+func packSmartAccountExecute(to common.Address, value *big.Int, data []byte) ([]byte, error) {
+	accountABI, err := abi.JSON(strings.NewReader(simpleAccountABI))
+	if err != nil {
+		return []byte{}, err
+	}
+	return accountABI.Pack("execute", to, value, data)
+}
+
+// setUserOperationFees uses the transaction fees, legacy gas price is used for both fees on chains without EIP-1559
+func setUserOperationFees(userOp *bundler.UserOperation, tx *transactions.SendTxArgs) {
+	userOp.MaxFeePerGas = (*hexutil.Big)(big.NewInt(0))
+	userOp.MaxPriorityFeePerGas = (*hexutil.Big)(big.NewInt(0))
+	if tx.IsDynamicFeeTx() {
+		userOp.MaxFeePerGas = tx.MaxFeePerGas
+		userOp.MaxPriorityFeePerGas = tx.MaxPriorityFeePerGas
+	} else if tx.GasPrice != nil {
+		userOp.MaxFeePerGas = tx.GasPrice
+		userOp.MaxPriorityFeePerGas = tx.GasPrice
+	}
+}
+
+// signUserOperation signs the user operation hash as an EIP-191 message, which is what SimpleAccount validates
+func signUserOperation(ctx context.Context, userOp *bundler.UserOperation, entryPoint common.Address, chainID uint64, verifiedAccount *account.SelectedExtKey) error {
+	hash, err := userOp.Hash(entryPoint, chainID)
+	if err != nil {
+		return err
+	}
+
+	sig, err := verifiedAccount.Signer().SignPersonalMessage(ctx, common.Address(verifiedAccount.Address), hash.Bytes())
+	if err != nil {
+		return err
+	}
+
+	userOp.Signature = sig
+	return nil
+}
+
+func totalUserOperationGas(gasLimits ...*hexutil.Big) uint64 {
+	total := uint64(0)
+	for _, gas := range gasLimits {
+		if gas != nil {
+			total += gas.ToInt().Uint64()
+		}
+	}
+	return total
+}
@@ -0,0 +1,268 @@
+package pathprocessor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/eth-node/types"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty/bundler"
+	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/transactions"
+	"github.com/status-im/status-go/walletdatabase"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBundlerClient struct {
+	receipts      map[common.Hash]*bundler.UserOperationReceipt
+	receiptErrors map[common.Hash]error
+	sent          []*bundler.UserOperation
+}
+
+func (f *fakeBundlerClient) EntryPoint() common.Address {
+	return bundler.EntryPointV06Address
+}
+
+func (f *fakeBundlerClient) SendUserOperation(ctx context.Context, userOp *bundler.UserOperation) (common.Hash, error) {
+	f.sent = append(f.sent, userOp)
+	return userOp.Hash(bundler.EntryPointV06Address, walletCommon.EthereumMainnet)
+}
+
+func (f *fakeBundlerClient) EstimateUserOperationGas(ctx context.Context, userOp *bundler.UserOperation) (*bundler.UserOperationGasEstimate, error) {
+	return &bundler.UserOperationGasEstimate{}, nil
+}
+
+func (f *fakeBundlerClient) GetUserOperationReceipt(ctx context.Context, userOpHash common.Hash) (*bundler.UserOperationReceipt, error) {
+	return f.receipts[userOpHash], f.receiptErrors[userOpHash]
+}
+
+func newTestSmartAccountProcessor(client bundler.ClientInterface) *SmartAccountProcessor {
+	bundlers := bundler.NewClients(nil, nil)
+	bundlers.SetBundler(walletCommon.EthereumMainnet, client)
+	return NewSmartAccountProcessor(nil, bundlers, nil)
+}
+
+func TestSmartAccountProcessor_AvailableFor(t *testing.T) {
+	processor := newTestSmartAccountProcessor(&fakeBundlerClient{})
+	eth := &token.Token{Symbol: EthSymbol}
+
+	available, err := processor.AvailableFor(ProcessorInputParams{
+		FromChain: &mainnet,
+		ToChain:   &mainnet,
+		FromToken: eth,
+	})
+	require.NoError(t, err)
+	require.True(t, available)
+
+	// Cross chain transfers are not supported
+	available, err = processor.AvailableFor(ProcessorInputParams{
+		FromChain: &mainnet,
+		ToChain:   &optimism,
+		FromToken: eth,
+	})
+	require.NoError(t, err)
+	require.False(t, available)
+
+	// No bundler configured for the chain
+	available, err = processor.AvailableFor(ProcessorInputParams{
+		FromChain: &optimism,
+		ToChain:   &optimism,
+		FromToken: eth,
+	})
+	require.NoError(t, err)
+	require.False(t, available)
+
+	_, err = processor.AvailableFor(ProcessorInputParams{})
+	require.ErrorIs(t, err, ErrNoChainSet)
+}
+
+func TestSmartAccountProcessor_PackTxInputData(t *testing.T) {
+	processor := newTestSmartAccountProcessor(&fakeBundlerClient{})
+	accountABI, err := abi.JSON(strings.NewReader(simpleAccountABI))
+	require.NoError(t, err)
+
+	toAddr := common.HexToAddress("0x2")
+	tokenAddr := common.HexToAddress("0x3")
+	amount := big.NewInt(1000)
+
+	data, err := processor.PackTxInputData(ProcessorInputParams{
+		ToAddr:    toAddr,
+		FromToken: &token.Token{Symbol: EthSymbol},
+		AmountIn:  amount,
+	})
+	require.NoError(t, err)
+
+	args, err := accountABI.Methods["execute"].Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	require.Equal(t, toAddr, args[0])
+	require.Equal(t, amount, args[1])
+	require.Empty(t, args[2])
+
+	data, err = processor.PackTxInputData(ProcessorInputParams{
+		ToAddr:    toAddr,
+		FromToken: &token.Token{Symbol: UsdcSymbol, Address: tokenAddr},
+		AmountIn:  amount,
+	})
+	require.NoError(t, err)
+
+	args, err = accountABI.Methods["execute"].Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	require.Equal(t, tokenAddr, args[0])
+	require.Equal(t, big.NewInt(0), args[1])
+	require.NotEmpty(t, args[2])
+}
+
+func TestSmartAccountProcessor_SignUserOperation(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey)
+	verifiedAccount := &account.SelectedExtKey{
+		Address:    types.Address(owner),
+		AccountKey: &types.Key{PrivateKey: privateKey},
+	}
+
+	userOp := &bundler.UserOperation{
+		Sender:   common.HexToAddress("0x1"),
+		Nonce:    (*hexutil.Big)(big.NewInt(3)),
+		CallData: common.FromHex("0xb61d27f6"),
+	}
+	err = signUserOperation(context.Background(), userOp, bundler.EntryPointV06Address, walletCommon.EthereumMainnet, verifiedAccount)
+	require.NoError(t, err)
+	require.Len(t, userOp.Signature, 65)
+
+	hash, err := userOp.Hash(bundler.EntryPointV06Address, walletCommon.EthereumMainnet)
+	require.NoError(t, err)
+
+	sig := common.CopyBytes(userOp.Signature)
+	sig[64] -= 27
+	pubKey, err := crypto.SigToPub(accounts.TextHash(hash.Bytes()), sig)
+	require.NoError(t, err)
+	require.Equal(t, owner, crypto.PubkeyToAddress(*pubKey))
+}
+
+func TestSmartAccountProcessor_FetchUserOperationsStatus(t *testing.T) {
+	succeeded := common.HexToHash("0x1")
+	failed := common.HexToHash("0x2")
+	pending := common.HexToHash("0x3")
+	unavailable := common.HexToHash("0x4")
+
+	// the receipt failing to be fetched doesn't prevent the status of the other operations from being resolved
+	processor := newTestSmartAccountProcessor(&fakeBundlerClient{
+		receipts: map[common.Hash]*bundler.UserOperationReceipt{
+			succeeded: {UserOpHash: succeeded, Success: true},
+			failed:    {UserOpHash: failed, Success: false},
+		},
+		receiptErrors: map[common.Hash]error{
+			unavailable: errors.New("bundler unavailable"),
+		},
+	})
+
+	statuses, err := processor.FetchUserOperationsStatus(context.Background(), walletCommon.ChainID(walletCommon.EthereumMainnet), []common.Hash{succeeded, failed, pending, unavailable})
+	require.NoError(t, err)
+	require.Equal(t, map[common.Hash]transactions.TxStatus{
+		succeeded: transactions.Success,
+		failed:    transactions.Failed,
+	}, statuses)
+
+	_, err = processor.FetchUserOperationsStatus(context.Background(), walletCommon.ChainID(walletCommon.OptimismMainnet), []common.Hash{succeeded})
+	require.ErrorIs(t, err, ErrBundlerNotConfigured)
+}
+
+func TestSmartAccountProcessor_SendUserOperationWithSignature(t *testing.T) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	bundlerClient := &fakeBundlerClient{}
+	bundlers := bundler.NewClients(nil, nil)
+	bundlers.SetBundler(walletCommon.EthereumMainnet, bundlerClient)
+	pendingTracker := transactions.NewPendingTxTracker(db, nil, nil, &event.Feed{}, time.Hour)
+	defer func() {
+		require.NoError(t, pendingTracker.Stop())
+	}()
+	processor := NewSmartAccountProcessor(nil, bundlers, pendingTracker)
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	to := types.Address(common.HexToAddress("0x2"))
+	sendArgs := &transactions.SendTxArgs{
+		From:               types.Address(common.HexToAddress("0x1")),
+		To:                 &to,
+		Value:              (*hexutil.Big)(big.NewInt(1)),
+		FromChainID:        walletCommon.EthereumMainnet,
+		MultiTransactionID: 7,
+	}
+	userOp := &bundler.UserOperation{
+		Sender:               common.Address(sendArgs.From),
+		Nonce:                (*hexutil.Big)(big.NewInt(3)),
+		CallData:             common.FromHex("0xb61d27f6"),
+		MaxFeePerGas:         (*hexutil.Big)(big.NewInt(1)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(big.NewInt(1)),
+	}
+	userOpHash, err := userOp.Hash(bundler.EntryPointV06Address, walletCommon.EthereumMainnet)
+	require.NoError(t, err)
+
+	// the hash to sign is the EIP-191 hash of the user operation hash, signed externally the recovery id is 0 or 1
+	signature, err := crypto.Sign(accounts.TextHash(userOpHash.Bytes()), privateKey)
+	require.NoError(t, err)
+
+	_, err = processor.SendUserOperationWithSignature(context.Background(), sendArgs, userOp, signature[:64])
+	require.ErrorIs(t, err, ErrInvalidUserOperationSignature)
+
+	hash, err := processor.SendUserOperationWithSignature(context.Background(), sendArgs, userOp, signature)
+	require.NoError(t, err)
+	require.Equal(t, types.Hash(userOpHash), hash)
+	require.Len(t, bundlerClient.sent, 1)
+
+	sig := common.CopyBytes(bundlerClient.sent[0].Signature)
+	require.GreaterOrEqual(t, sig[64], byte(27))
+	sig[64] -= 27
+	pubKey, err := crypto.SigToPub(accounts.TextHash(userOpHash.Bytes()), sig)
+	require.NoError(t, err)
+	require.Equal(t, owner, crypto.PubkeyToAddress(*pubKey))
+
+	pendingTx, err := pendingTracker.GetPendingEntry(walletCommon.ChainID(walletCommon.EthereumMainnet), userOpHash)
+	require.NoError(t, err)
+	require.Equal(t, transactions.WalletUserOperation, pendingTx.Type)
+	require.Equal(t, sendArgs.MultiTransactionID, pendingTx.MultiTransactionID)
+}
+
+func TestSmartAccountProcessor_UserOperationCallMsg(t *testing.T) {
+	bundlers := bundler.NewClients(nil, nil)
+	bundlers.SetBundler(walletCommon.EthereumMainnet, &fakeBundlerClient{})
+	processor := NewSmartAccountProcessor(nil, bundlers, nil)
+
+	callData, err := packSmartAccountExecute(common.HexToAddress("0x2"), big.NewInt(1), []byte{})
+	require.NoError(t, err)
+	userOp := &bundler.UserOperation{
+		Sender:       common.HexToAddress("0x1"),
+		CallData:     callData,
+		CallGasLimit: (*hexutil.Big)(big.NewInt(50000)),
+	}
+
+	// the smart account only executes calls coming from the entry point
+	msg, err := processor.UserOperationCallMsg(walletCommon.EthereumMainnet, userOp)
+	require.NoError(t, err)
+	require.Equal(t, bundler.EntryPointV06Address, msg.From)
+	require.Equal(t, userOp.Sender, *msg.To)
+	require.Equal(t, callData, msg.Data)
+	require.Equal(t, uint64(50000), msg.Gas)
+
+	_, err = processor.UserOperationCallMsg(walletCommon.OptimismMainnet, userOp)
+	require.ErrorIs(t, err, ErrBundlerNotConfigured)
+}
@@ -0,0 +1,317 @@
+package pathprocessor
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/eth-node/types"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	"github.com/status-im/status-go/services/wallet/thirdparty/paraswap"
+	"github.com/status-im/status-go/services/wallet/thirdparty/zeroex"
+	"github.com/status-im/status-go/transactions"
+)
+
+type SwapParaswapTxArgs struct {
+	transactions.SendTxArgs
+	ChainID            uint64  `json:"chainId"`
+	ChainIDTo          uint64  `json:"chainIdTo"`
+	TokenIDFrom        string  `json:"tokenIdFrom"`
+	TokenIDTo          string  `json:"tokenIdTo"`
+	SlippagePercentage float32 `json:"slippagePercentage"`
+}
+
+// SwapProcessor is a swap path processor backed by a DEX aggregator
+type SwapProcessor struct {
+	name       string
+	provider   thirdparty.SwapQuoteProvider
+	transactor transactions.TransactorIface
+	quotes     sync.Map // [fromChainName-toChainName-fromTokenSymbol-toTokenSymbol, thirdparty.SwapQuote]
+}
+
+func NewSwapProcessor(name string, provider thirdparty.SwapQuoteProvider, transactor transactions.TransactorIface) *SwapProcessor {
+	return &SwapProcessor{
+		name:       name,
+		provider:   provider,
+		transactor: transactor,
+		quotes:     sync.Map{},
+	}
+}
+
+func NewSwapParaswapProcessor(transactor transactions.TransactorIface) *SwapProcessor {
+	return NewSwapProcessor(ProcessorSwapParaswapName, paraswap.NewSwapQuoteProvider(), transactor)
+}
+
+func NewSwapZeroExProcessor(apiKey string, transactor transactions.TransactorIface) *SwapProcessor {
+	return NewSwapProcessor(ProcessorSwapZeroExName, zeroex.NewClient(apiKey), transactor)
+}
+
+func (s *SwapProcessor) createSwapErrorResponse(err error) error {
+	switch err.Error() {
+	case "Price Timeout":
+		return ErrPriceTimeout
+	case "No routes found with enough liquidity":
+		return ErrNotEnoughLiquidity
+	case "ESTIMATED_LOSS_GREATER_THAN_MAX_IMPACT":
+		return ErrPriceImpactTooHigh
+	}
+	if strings.Contains(err.Error(), "INSUFFICIENT_ASSET_LIQUIDITY") {
+		return ErrNotEnoughLiquidity
+	}
+	return createErrorResponse(s.name, err)
+}
+
+// swapSide returns SwapSideBuy if the amount received is fixed
+func swapSide(params ProcessorInputParams) thirdparty.SwapSide {
+	if params.AmountOut != nil && params.AmountOut.Cmp(walletCommon.ZeroBigIntValue()) > 0 {
+		return thirdparty.SwapSideBuy
+	}
+	return thirdparty.SwapSideSell
+}
+
+func (s *SwapProcessor) Name() string {
+	return s.name
+}
+
+func (s *SwapProcessor) Clear() {
+	s.quotes = sync.Map{}
+}
+
+func (s *SwapProcessor) AvailableFor(params ProcessorInputParams) (bool, error) {
+	if params.FromChain == nil || params.ToChain == nil {
+		return false, ErrNoChainSet
+	}
+	if params.FromToken == nil || params.ToToken == nil {
+		return false, ErrToAndFromTokensMustBeSet
+	}
+
+	if params.FromChain.ChainID != params.ToChain.ChainID {
+		return false, ErrFromAndToChainsMustBeSame
+	}
+
+	if params.FromToken.Symbol == params.ToToken.Symbol {
+		return false, ErrFromAndToTokensMustBeDifferent
+	}
+
+	chainID := params.FromChain.ChainID
+	if !s.provider.IsChainSupported(chainID) {
+		return false, nil
+	}
+
+	if !s.provider.IsSwapSupported(swapSide(params), params.FromAddr, params.ToAddr) {
+		return false, nil
+	}
+
+	searchForToken := params.FromToken.Address == walletCommon.ZeroAddress()
+	searchForToToken := params.ToToken.Address == walletCommon.ZeroAddress()
+	if searchForToToken || searchForToken {
+		tokensList, err := s.provider.FetchTokensList(context.Background(), chainID)
+		if err != nil {
+			return false, s.createSwapErrorResponse(err)
+		}
+
+		for _, t := range tokensList {
+			if searchForToken && t.Symbol == params.FromToken.Symbol {
+				params.FromToken.Address = t.Address
+				params.FromToken.Decimals = t.Decimals
+				if !searchForToToken {
+					break
+				}
+			}
+
+			if searchForToToken && t.Symbol == params.ToToken.Symbol {
+				params.ToToken.Address = t.Address
+				params.ToToken.Decimals = t.Decimals
+				if !searchForToken {
+					break
+				}
+			}
+		}
+	}
+
+	if params.FromToken.Address == walletCommon.ZeroAddress() || params.ToToken.Address == walletCommon.ZeroAddress() {
+		return false, ErrCannotResolveTokens
+	}
+
+	return true, nil
+}
+
+func (s *SwapProcessor) CalculateFees(params ProcessorInputParams) (*big.Int, *big.Int, error) {
+	return walletCommon.ZeroBigIntValue(), walletCommon.ZeroBigIntValue(), nil
+}
+
+func (s *SwapProcessor) PackTxInputData(params ProcessorInputParams) ([]byte, error) {
+	// not sure what we can do here since we're using the api to build the transaction
+	return []byte{}, nil
+}
+
+func (s *SwapProcessor) EstimateGas(params ProcessorInputParams) (uint64, error) {
+	if params.TestsMode {
+		if params.TestEstimationMap != nil {
+			if val, ok := params.TestEstimationMap[s.Name()]; ok {
+				return val.Value, val.Err
+			}
+		}
+		return 0, ErrNoEstimationFound
+	}
+
+	quoteParams := thirdparty.SwapQuoteParams{
+		ChainID:           params.FromChain.ChainID,
+		SrcTokenAddress:   params.FromToken.Address,
+		SrcTokenDecimals:  params.FromToken.Decimals,
+		DestTokenAddress:  params.ToToken.Address,
+		DestTokenDecimals: params.ToToken.Decimals,
+		Amount:            params.AmountIn,
+		Side:              swapSide(params),
+		AddressFrom:       params.FromAddr,
+		AddressTo:         params.ToAddr,
+	}
+	if quoteParams.Side == thirdparty.SwapSideBuy {
+		quoteParams.Amount = params.AmountOut
+	}
+
+	quote, err := s.provider.FetchQuote(context.Background(), quoteParams)
+	if err != nil {
+		return 0, s.createSwapErrorResponse(err)
+	}
+
+	key := makeKey(params.FromChain.ChainID, params.ToChain.ChainID, params.FromToken.Symbol, params.ToToken.Symbol)
+	s.quotes.Store(key, quote)
+
+	return quote.GasCost, nil
+}
+
+func (s *SwapProcessor) loadQuote(fromChainID uint64, toChainID uint64, fromTokenSymbol string, toTokenSymbol string) (*thirdparty.SwapQuote, error) {
+	key := makeKey(fromChainID, toChainID, fromTokenSymbol, toTokenSymbol)
+	quoteIns, ok := s.quotes.Load(key)
+	if !ok {
+		return nil, ErrPriceRouteNotFound
+	}
+	return quoteIns.(*thirdparty.SwapQuote), nil
+}
+
+func (s *SwapProcessor) GetContractAddress(params ProcessorInputParams) (address common.Address, err error) {
+	quote, err := s.loadQuote(params.FromChain.ChainID, params.ToChain.ChainID, params.FromToken.Symbol, params.ToToken.Symbol)
+	if err != nil {
+		return
+	}
+
+	return quote.ApprovalAddress, nil
+}
+
+func (s *SwapProcessor) buildSwapTransaction(quote *thirdparty.SwapQuote, from types.Address, to *types.Address, slippagePercentage float32) (*thirdparty.SwapTransaction, error) {
+	slippageBP := uint(slippagePercentage * 100) // convert to basis points
+
+	addressTo := common.Address(from)
+	if to != nil {
+		addressTo = common.Address(*to)
+	}
+
+	tx, err := s.provider.BuildTransaction(context.Background(), quote, common.Address(from), addressTo, slippageBP)
+	if err != nil {
+		return nil, s.createSwapErrorResponse(err)
+	}
+	return tx, nil
+}
+
+// TODO: remove this struct once mobile switches to the new approach
+func (s *SwapProcessor) prepareTransaction(sendArgs *MultipathProcessorTxArgs) error {
+	quote, err := s.loadQuote(sendArgs.SwapTx.ChainID, sendArgs.SwapTx.ChainIDTo, sendArgs.SwapTx.TokenIDFrom, sendArgs.SwapTx.TokenIDTo)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.buildSwapTransaction(quote, sendArgs.SwapTx.From, sendArgs.SwapTx.To, sendArgs.SwapTx.SlippagePercentage)
+	if err != nil {
+		return err
+	}
+
+	sendArgs.ChainID = tx.ChainID
+	sendArgs.SwapTx.ChainID = tx.ChainID
+	toAddr := types.Address(tx.To)
+	sendArgs.SwapTx.From = types.Address(tx.From)
+	sendArgs.SwapTx.To = &toAddr
+	sendArgs.SwapTx.Value = (*hexutil.Big)(tx.Value)
+	sendArgs.SwapTx.Gas = (*hexutil.Uint64)(&tx.Gas)
+	sendArgs.SwapTx.GasPrice = (*hexutil.Big)(tx.GasPrice)
+	sendArgs.SwapTx.Data = tx.Data
+
+	return nil
+}
+
+func (s *SwapProcessor) prepareTransactionV2(sendArgs *transactions.SendTxArgs) error {
+	quote, err := s.loadQuote(sendArgs.FromChainID, sendArgs.ToChainID, sendArgs.FromTokenID, sendArgs.ToTokenID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.buildSwapTransaction(quote, sendArgs.From, sendArgs.To, sendArgs.SlippagePercentage)
+	if err != nil {
+		return err
+	}
+
+	sendArgs.FromChainID = tx.ChainID
+	toAddr := types.Address(tx.To)
+	sendArgs.From = types.Address(tx.From)
+	sendArgs.To = &toAddr
+	sendArgs.Value = (*hexutil.Big)(tx.Value)
+	sendArgs.Gas = (*hexutil.Uint64)(&tx.Gas)
+	sendArgs.GasPrice = (*hexutil.Big)(tx.GasPrice)
+	sendArgs.Data = tx.Data
+
+	return nil
+}
+
+func (s *SwapProcessor) BuildTransaction(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	err := s.prepareTransaction(sendArgs)
+	if err != nil {
+		return nil, 0, s.createSwapErrorResponse(err)
+	}
+	return s.transactor.ValidateAndBuildTransaction(sendArgs.ChainID, sendArgs.SwapTx.SendTxArgs, lastUsedNonce)
+}
+
+func (s *SwapProcessor) BuildTransactionV2(sendArgs *transactions.SendTxArgs, lastUsedNonce int64) (*ethTypes.Transaction, uint64, error) {
+	err := s.prepareTransactionV2(sendArgs)
+	if err != nil {
+		return nil, 0, s.createSwapErrorResponse(err)
+	}
+	return s.transactor.ValidateAndBuildTransaction(sendArgs.FromChainID, *sendArgs, lastUsedNonce)
+}
+
+func (s *SwapProcessor) Send(sendArgs *MultipathProcessorTxArgs, lastUsedNonce int64, verifiedAccount *account.SelectedExtKey) (types.Hash, uint64, error) {
+	err := s.prepareTransaction(sendArgs)
+	if err != nil {
+		return types.Hash{}, 0, s.createSwapErrorResponse(err)
+	}
+
+	return s.transactor.SendTransactionWithChainID(sendArgs.ChainID, sendArgs.SwapTx.SendTxArgs, lastUsedNonce, verifiedAccount)
+}
+
+// CalculateAmountOut returns the amount received net of the provider fees, so quotes from different providers are comparable
+func (s *SwapProcessor) CalculateAmountOut(params ProcessorInputParams) (*big.Int, error) {
+	quote, err := s.loadQuote(params.FromChain.ChainID, params.ToChain.ChainID, params.FromToken.Symbol, params.ToToken.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return quote.NetDestAmount(), nil
+}
+
+// CalculateAmountIn returns the quoted amount sent for a fixed amount received, otherwise the amount sent is the requested one
+func (s *SwapProcessor) CalculateAmountIn(params ProcessorInputParams) (*big.Int, error) {
+	quote, err := s.loadQuote(params.FromChain.ChainID, params.ToChain.ChainID, params.FromToken.Symbol, params.ToToken.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote.Side != thirdparty.SwapSideBuy || quote.SrcAmount == nil {
+		return params.AmountIn, nil
+	}
+	return new(big.Int).Set(quote.SrcAmount), nil
+}
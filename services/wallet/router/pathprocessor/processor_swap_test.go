@@ -0,0 +1,214 @@
+package pathprocessor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/params"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	"github.com/status-im/status-go/services/wallet/token"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSwapQuoteProvider struct {
+	tokens              []thirdparty.SwapToken
+	quote               *thirdparty.SwapQuote
+	quoteErr            error
+	quoteParams         thirdparty.SwapQuoteParams
+	senderRecipientOnly bool
+}
+
+func (f *fakeSwapQuoteProvider) ID() string {
+	return "fake"
+}
+
+func (f *fakeSwapQuoteProvider) IsChainSupported(chainID uint64) bool {
+	return chainID == walletCommon.EthereumMainnet
+}
+
+func (f *fakeSwapQuoteProvider) IsSwapSupported(side thirdparty.SwapSide, addressFrom common.Address, addressTo common.Address) bool {
+	return !f.senderRecipientOnly || addressTo == addressFrom
+}
+
+func (f *fakeSwapQuoteProvider) FetchTokensList(ctx context.Context, chainID uint64) ([]thirdparty.SwapToken, error) {
+	return f.tokens, nil
+}
+
+func (f *fakeSwapQuoteProvider) FetchQuote(ctx context.Context, params thirdparty.SwapQuoteParams) (*thirdparty.SwapQuote, error) {
+	f.quoteParams = params
+	return f.quote, f.quoteErr
+}
+
+func (f *fakeSwapQuoteProvider) BuildTransaction(ctx context.Context, quote *thirdparty.SwapQuote, addressFrom common.Address, addressTo common.Address,
+	slippageBasisPoints uint) (*thirdparty.SwapTransaction, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestSwapProcessorAvailableFor(t *testing.T) {
+	provider := &fakeSwapQuoteProvider{
+		tokens: []thirdparty.SwapToken{
+			{Symbol: EthSymbol, Address: common.HexToAddress("0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee"), Decimals: 18},
+			{Symbol: UsdcSymbol, Address: common.HexToAddress("0x123"), Decimals: 6},
+		},
+	}
+	processor := NewSwapProcessor(ProcessorSwapZeroExName, provider, nil)
+
+	fromToken := token.Token{Symbol: EthSymbol}
+	toToken := token.Token{Symbol: UsdcSymbol}
+	testInputParams := ProcessorInputParams{
+		FromChain: &params.Network{ChainID: walletCommon.EthereumMainnet},
+		ToChain:   &params.Network{ChainID: walletCommon.EthereumMainnet},
+		FromToken: &fromToken,
+		ToToken:   &toToken,
+	}
+
+	available, err := processor.AvailableFor(testInputParams)
+	require.NoError(t, err)
+	require.True(t, available)
+	require.Equal(t, common.HexToAddress("0x123"), toToken.Address)
+	require.Equal(t, uint(6), toToken.Decimals)
+
+	// Providers which can't send the bought tokens to a different recipient are skipped
+	provider.senderRecipientOnly = true
+	testInputParams.FromAddr = common.HexToAddress("0x1")
+	testInputParams.ToAddr = common.HexToAddress("0x2")
+	available, err = processor.AvailableFor(testInputParams)
+	require.NoError(t, err)
+	require.False(t, available)
+
+	testInputParams.ToAddr = testInputParams.FromAddr
+	available, err = processor.AvailableFor(testInputParams)
+	require.NoError(t, err)
+	require.True(t, available)
+
+	// Chains not supported by the provider are skipped
+	testInputParams.FromChain = &params.Network{ChainID: walletCommon.OptimismMainnet}
+	testInputParams.ToChain = &params.Network{ChainID: walletCommon.OptimismMainnet}
+	available, err = processor.AvailableFor(testInputParams)
+	require.NoError(t, err)
+	require.False(t, available)
+}
+
+func TestSwapProcessorAmountOutNetOfFees(t *testing.T) {
+	testQuote := &thirdparty.SwapQuote{
+		ChainID:         walletCommon.EthereumMainnet,
+		SrcAmount:       big.NewInt(1000),
+		DestAmount:      big.NewInt(2000),
+		FeeAmount:       big.NewInt(14),
+		GasCost:         500,
+		ApprovalAddress: common.HexToAddress("0xabc"),
+	}
+	processor := NewSwapProcessor(ProcessorSwapParaswapName, &fakeSwapQuoteProvider{quote: testQuote}, nil)
+
+	fromToken := token.Token{Symbol: EthSymbol}
+	toToken := token.Token{Symbol: UsdcSymbol}
+	testInputParams := ProcessorInputParams{
+		FromChain: &params.Network{ChainID: walletCommon.EthereumMainnet},
+		ToChain:   &params.Network{ChainID: walletCommon.EthereumMainnet},
+		FromToken: &fromToken,
+		ToToken:   &toToken,
+		AmountIn:  big.NewInt(1000),
+	}
+
+	_, err := processor.CalculateAmountOut(testInputParams)
+	require.Equal(t, ErrPriceRouteNotFound, err)
+
+	gas, err := processor.EstimateGas(testInputParams)
+	require.NoError(t, err)
+	require.Equal(t, testQuote.GasCost, gas)
+
+	amountOut, err := processor.CalculateAmountOut(testInputParams)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1986), amountOut)
+
+	contractAddress, err := processor.GetContractAddress(testInputParams)
+	require.NoError(t, err)
+	require.Equal(t, testQuote.ApprovalAddress, contractAddress)
+}
+
+func TestSwapProcessorErrors(t *testing.T) {
+	provider := &fakeSwapQuoteProvider{}
+	processor := NewSwapParaswapProcessor(nil)
+	processor.provider = provider
+
+	fromToken := token.Token{
+		Symbol: EthSymbol,
+	}
+	toToken := token.Token{
+		Symbol: UsdcSymbol,
+	}
+	chainID := walletCommon.EthereumMainnet
+
+	testInputParams := ProcessorInputParams{
+		FromChain: &params.Network{ChainID: chainID},
+		ToChain:   &params.Network{ChainID: chainID},
+		FromToken: &fromToken,
+		ToToken:   &toToken,
+	}
+
+	// Test Errors
+	type testCase struct {
+		clientError    string
+		processorError error
+	}
+
+	testCases := []testCase{
+		{"Price Timeout", ErrPriceTimeout},
+		{"No routes found with enough liquidity", ErrNotEnoughLiquidity},
+		{"ESTIMATED_LOSS_GREATER_THAN_MAX_IMPACT", ErrPriceImpactTooHigh},
+		{"Validation Failed (buyAmount: INSUFFICIENT_ASSET_LIQUIDITY)", ErrNotEnoughLiquidity},
+	}
+
+	for _, tc := range testCases {
+		provider.quoteErr = errors.New(tc.clientError)
+		_, err := processor.EstimateGas(testInputParams)
+		require.Equal(t, tc.processorError.Error(), err.Error())
+	}
+}
+
+func TestSwapProcessorBuySide(t *testing.T) {
+	testQuote := &thirdparty.SwapQuote{
+		ChainID:    walletCommon.EthereumMainnet,
+		Side:       thirdparty.SwapSideBuy,
+		SrcAmount:  big.NewInt(1010),
+		DestAmount: big.NewInt(2000),
+		GasCost:    500,
+	}
+	provider := &fakeSwapQuoteProvider{quote: testQuote}
+	processor := NewSwapProcessor(ProcessorSwapParaswapName, provider, nil)
+
+	fromToken := token.Token{Symbol: EthSymbol}
+	toToken := token.Token{Symbol: UsdcSymbol}
+	testInputParams := ProcessorInputParams{
+		FromChain: &params.Network{ChainID: walletCommon.EthereumMainnet},
+		ToChain:   &params.Network{ChainID: walletCommon.EthereumMainnet},
+		FromToken: &fromToken,
+		ToToken:   &toToken,
+		AmountIn:  big.NewInt(0),
+		AmountOut: big.NewInt(2000),
+	}
+
+	// the amount bought is quoted
+	_, err := processor.EstimateGas(testInputParams)
+	require.NoError(t, err)
+	require.Equal(t, thirdparty.SwapSideBuy, provider.quoteParams.Side)
+	require.Equal(t, big.NewInt(2000), provider.quoteParams.Amount)
+
+	amountIn, err := processor.CalculateAmountIn(testInputParams)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1010), amountIn)
+
+	// the amount sent is the one requested for a fixed amount sent
+	testQuote.Side = thirdparty.SwapSideSell
+	testInputParams.AmountIn = big.NewInt(1000)
+	testInputParams.AmountOut = big.NewInt(0)
+	amountIn, err = processor.CalculateAmountIn(testInputParams)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1000), amountIn)
+}
@@ -307,7 +307,7 @@ func TestPathProcessors(t *testing.T) {
 				} else if processorName == ProcessorBridgeHopName {
 					processor = NewHopBridgeProcessor(nil, nil, nil, nil)
 				} else if processorName == ProcessorSwapParaswapName {
-					processor = NewSwapParaswapProcessor(nil, nil, nil)
+					processor = NewSwapParaswapProcessor(nil)
 				}
 
 				assert.Equal(t, processorName, processor.Name())
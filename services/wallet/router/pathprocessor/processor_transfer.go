@@ -44,6 +44,10 @@ func (s *TransferProcessor) AvailableFor(params ProcessorInputParams) (bool, err
 	if params.ToToken != nil {
 		return false, ErrToTokenShouldNotBeSet
 	}
+	// a single transfer can't pay many batch recipients
+	if len(params.Recipients) > 1 {
+		return false, nil
+	}
 	return params.FromChain.ChainID == params.ToChain.ChainID, nil
 }
 
@@ -20,6 +20,7 @@ import (
 	"github.com/status-im/status-go/services/wallet/collectibles"
 	walletCommon "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/market"
+	"github.com/status-im/status-go/services/wallet/poisoning"
 	"github.com/status-im/status-go/services/wallet/requests"
 	"github.com/status-im/status-go/services/wallet/responses"
 	"github.com/status-im/status-go/services/wallet/router/fees"
@@ -59,6 +60,9 @@ type SuggestedRoutes struct {
 	Best          routes.Route
 	Candidates    routes.Route
 	UpdatedPrices map[string]float64
+	Explanations  []*routes.RouteExplanation // explains the choice of the best route, batches have one per planned group of entries
+	// RecipientWarnings is set when a recipient resembles a saved address or a previous counterparty without matching it
+	RecipientWarnings []*poisoning.RecipientWarning
 }
 
 type Router struct {
@@ -72,6 +76,7 @@ type Router struct {
 	feesManager         *fees.FeeManager
 	pathProcessors      map[string]pathprocessor.PathProcessor
 	scheduler           *async.Scheduler
+	poisoningDetector   *poisoning.Detector // optional
 
 	activeBalanceMap sync.Map // map[string]*big.Int
 
@@ -111,6 +116,11 @@ func (r *Router) AddPathProcessor(processor pathprocessor.PathProcessor) {
 	r.pathProcessors[processor.Name()] = processor
 }
 
+// SetPoisoningDetector enables the warnings about the recipients resembling known addresses
+func (r *Router) SetPoisoningDetector(detector *poisoning.Detector) {
+	r.poisoningDetector = detector
+}
+
 func (r *Router) Stop() {
 	r.scheduler.Stop()
 }
@@ -189,6 +199,8 @@ func sendRouterResult(uuid string, result interface{}, err error) {
 		routesResponse.Best = suggestedRoutes.Best
 		routesResponse.Candidates = suggestedRoutes.Candidates
 		routesResponse.UpdatedPrices = suggestedRoutes.UpdatedPrices
+		routesResponse.Explanations = suggestedRoutes.Explanations
+		routesResponse.RecipientWarnings = suggestedRoutes.RecipientWarnings
 	}
 
 	signal.SendWalletEvent(signal.SuggestedRoutes, routesResponse)
@@ -229,33 +241,14 @@ func (r *Router) StopSuggestedRoutesCalculation() {
 }
 
 func (r *Router) SuggestedRoutes(ctx context.Context, input *requests.RouteInputParams) (suggestedRoutes *SuggestedRoutes, err error) {
-	r.clearActiveRoute()
-	r.abortUpdates()
-	r.markRouteCanceled(false)
-
-	// clear all processors
-	for _, processor := range r.pathProcessors {
-		if clearable, ok := processor.(pathprocessor.PathProcessorClearable); ok {
-			clearable.Clear()
-		}
-	}
+	r.resetRoute()
 
 	r.lastInputParamsMutex.Lock()
 	r.lastInputParams = input
 	r.lastInputParamsMutex.Unlock()
 
 	defer func() {
-		r.activeRoutesMutex.Lock()
-		r.activeRoutes = suggestedRoutes
-		r.activeRoutesMutex.Unlock()
-		r.routeCanceledMutex.Lock()
-		if suggestedRoutes != nil && err == nil && !r.routeCanceled {
-			// subscribe for updates
-			for _, path := range suggestedRoutes.Best {
-				err = r.subscribeForUdates(path.FromChain.ChainID)
-			}
-		}
-		r.routeCanceledMutex.Unlock()
+		err = r.activateRoutes(suggestedRoutes, err)
 	}()
 
 	testnetMode, err := r.rpcClient.NetworkManager.GetTestNetworksEnabled()
@@ -277,71 +270,152 @@ func (r *Router) SuggestedRoutes(ctx context.Context, input *requests.RouteInput
 
 	err = r.prepareBalanceMapForTokenOnChains(ctx, input, selectedFromChains)
 	// return only if there are no balances, otherwise try to resolve the candidates for chains we know the balances for
-	noBalanceOnAnyChain := true
-	r.activeBalanceMap.Range(func(key, value interface{}) bool {
-		if value.(*big.Int).Cmp(walletCommon.ZeroBigIntValue()) > 0 {
-			noBalanceOnAnyChain = false
-			return false
-		}
-		return true
-	})
-	if noBalanceOnAnyChain {
+	if r.noBalanceOnAnyChain() {
 		if err != nil {
 			return nil, errors.CreateErrorResponseFromError(err)
 		}
 		return nil, ErrNoPositiveBalance
 	}
 
-	candidates, processorErrors, err := r.resolveCandidates(ctx, input, selectedFromChains, selectedToChains)
+	candidates, excluded, processorErrors, err := r.resolveCandidates(ctx, input, selectedFromChains, selectedToChains)
 	if err != nil {
 		return nil, errors.CreateErrorResponseFromError(err)
 	}
 
-	suggestedRoutes, err = r.resolveRoutes(ctx, input, candidates)
+	suggestedRoutes, err = r.resolveRoutes(ctx, input, candidates, excluded)
+	// the warnings are about the recipient, not the route, they're returned with the route errors too
+	if suggestedRoutes != nil {
+		suggestedRoutes.RecipientWarnings = r.checkRecipients(input)
+	}
 
 	if err == nil && (suggestedRoutes == nil || len(suggestedRoutes.Best) == 0) {
 		// No best route found, but no error given.
-		if len(processorErrors) > 0 {
-			// Return one of the path processor errors if present.
-			// Give precedence to the custom error message.
-			for _, processorError := range processorErrors {
-				if processorError.Error != nil && pathprocessor.IsCustomError(processorError.Error) {
-					err = processorError.Error
-					break
-				}
-			}
-			if err == nil {
-				err = errors.CreateErrorResponseFromError(processorErrors[0].Error)
-			}
-		} else {
-			err = ErrNoBestRouteFound
+		err = noBestRouteError(processorErrors)
+	}
+
+	// map some errors to more user-friendly messages
+	return suggestedRoutes, mapError(err)
+}
+
+// resetRoute stops the updates of the active route and clears the data cached for it
+func (r *Router) resetRoute() {
+	r.clearActiveRoute()
+	r.abortUpdates()
+	r.markRouteCanceled(false)
+
+	// clear all processors
+	for _, processor := range r.pathProcessors {
+		if clearable, ok := processor.(pathprocessor.PathProcessorClearable); ok {
+			clearable.Clear()
 		}
 	}
+}
 
-	mapError := func(err error) error {
-		if err == nil {
-			return nil
+// activateRoutes sets the routes which can be sent and subscribes for their updates, unless their calculation failed
+func (r *Router) activateRoutes(suggestedRoutes *SuggestedRoutes, err error) error {
+	r.activeRoutesMutex.Lock()
+	r.activeRoutes = suggestedRoutes
+	r.activeRoutesMutex.Unlock()
+	r.routeCanceledMutex.Lock()
+	if suggestedRoutes != nil && err == nil && !r.routeCanceled {
+		// subscribe for updates
+		for _, path := range suggestedRoutes.Best {
+			err = r.subscribeForUdates(path.FromChain.ChainID)
 		}
-		pattern := "insufficient funds for gas * price + value: address "
-		addressIndex := strings.Index(errors.DetailsFromError(err), pattern)
-		if addressIndex != -1 {
-			addressIndex += len(pattern) + walletCommon.HexAddressLength
-			return errors.CreateErrorResponseFromError(&errors.ErrorResponse{
-				Code:    errors.ErrorCodeFromError(err),
-				Details: errors.DetailsFromError(err)[:addressIndex],
-			})
+	}
+	r.routeCanceledMutex.Unlock()
+	return err
+}
+
+func (r *Router) noBalanceOnAnyChain() bool {
+	noBalanceOnAnyChain := true
+	r.activeBalanceMap.Range(func(key, value interface{}) bool {
+		if value.(*big.Int).Cmp(walletCommon.ZeroBigIntValue()) > 0 {
+			noBalanceOnAnyChain = false
+			return false
+		}
+		return true
+	})
+	return noBalanceOnAnyChain
+}
+
+// checkRecipients returns the warnings for the recipients resembling known addresses, a failed check is not an error
+func (r *Router) checkRecipients(input *requests.RouteInputParams) []*poisoning.RecipientWarning {
+	if r.poisoningDetector == nil || input.SendType.IsEnsTransfer() || input.SendType.IsStickersTransfer() ||
+		input.SendType == sendtype.Swap {
+		return nil
+	}
+
+	recipients := []common.Address{input.AddrTo}
+	for _, recipient := range input.Recipients {
+		recipients = append(recipients, recipient.Address)
+	}
+
+	warnings := make([]*poisoning.RecipientWarning, 0)
+	checked := make(map[common.Address]bool)
+	for _, recipient := range recipients {
+		if checked[recipient] || recipient == input.AddrFrom {
+			continue
 		}
-		return err
+		checked[recipient] = true
+
+		recipientWarnings, err := r.poisoningDetector.CheckRecipient(recipient)
+		if err != nil {
+			log.Error("failed to check the recipient", "recipient", recipient, "error", err)
+			continue
+		}
+		warnings = append(warnings, recipientWarnings...)
 	}
-	// map some errors to more user-friendly messages
-	return suggestedRoutes, mapError(err)
+	if len(warnings) == 0 {
+		return nil
+	}
+	return warnings
+}
+
+func noBestRouteError(processorErrors []*ProcessorError) error {
+	if len(processorErrors) == 0 {
+		return ErrNoBestRouteFound
+	}
+
+	// Return one of the path processor errors if present.
+	// Give precedence to the custom error message.
+	for _, processorError := range processorErrors {
+		if processorError.Error != nil && pathprocessor.IsCustomError(processorError.Error) {
+			return processorError.Error
+		}
+	}
+	return errors.CreateErrorResponseFromError(processorErrors[0].Error)
+}
+
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	pattern := "insufficient funds for gas * price + value: address "
+	addressIndex := strings.Index(errors.DetailsFromError(err), pattern)
+	if addressIndex != -1 {
+		addressIndex += len(pattern) + walletCommon.HexAddressLength
+		return errors.CreateErrorResponseFromError(&errors.ErrorResponse{
+			Code:    errors.ErrorCodeFromError(err),
+			Details: errors.DetailsFromError(err)[:addressIndex],
+		})
+	}
+	return err
+}
+
+// clearActiveBalanceMap removes the entries in place, replacing the map would race with its readers
+func (r *Router) clearActiveBalanceMap() {
+	r.activeBalanceMap.Range(func(k, _ interface{}) bool {
+		r.activeBalanceMap.Delete(k)
+		return true
+	})
 }
 
 // prepareBalanceMapForTokenOnChains prepares the balance map for passed address, where the key is in format "chainID-tokenSymbol" and
 // value is the balance of the token. Native token (EHT) is always added to the balance map.
 func (r *Router) prepareBalanceMapForTokenOnChains(ctx context.Context, input *requests.RouteInputParams, selectedFromChains []*params.Network) (err error) {
 	// clear the active balance map
-	r.activeBalanceMap = sync.Map{}
+	r.clearActiveBalanceMap()
 
 	if input.TestsMode {
 		for k, v := range input.TestParams.BalanceMap {
@@ -583,8 +657,9 @@ func (r *Router) getSelectedChains(input *requests.RouteInputParams) (selectedFr
 	return selectedFromChains, selectedToChains, nil
 }
 
+// resolveCandidates returns the candidate paths and the explanations of the processors which didn't produce a candidate
 func (r *Router) resolveCandidates(ctx context.Context, input *requests.RouteInputParams, selectedFromChains []*params.Network,
-	selectedToChains []*params.Network) (candidates routes.Route, processorErrors []*ProcessorError, err error) {
+	selectedToChains []*params.Network) (candidates routes.Route, excluded []*routes.PathExplanation, processorErrors []*ProcessorError, err error) {
 	var (
 		testsMode = input.TestsMode && input.TestParams != nil
 		group     = async.NewAtomicGroup(ctx)
@@ -593,7 +668,7 @@ func (r *Router) resolveCandidates(ctx context.Context, input *requests.RouteInp
 
 	crossChainAmountOptions, err := r.findOptionsForSendingAmount(input, selectedFromChains)
 	if err != nil {
-		return nil, nil, errors.CreateErrorResponseFromError(err)
+		return nil, nil, nil, errors.CreateErrorResponseFromError(err)
 	}
 
 	appendProcessorErrorFn := func(processorName string, sendType sendtype.SendType, fromChainID uint64, toChainID uint64, amount *big.Int, err error) {
@@ -604,6 +679,14 @@ func (r *Router) resolveCandidates(ctx context.Context, input *requests.RouteInp
 			ProcessorName: processorName,
 			Error:         err,
 		})
+		excluded = append(excluded, routes.NewExcludedPathExplanation(processorName, fromChainID, toChainID, amount,
+			routes.ExclusionReasonProcessorError, err))
+	}
+
+	appendExcludedFn := func(processorName string, fromChainID uint64, toChainID uint64, amount *big.Int, reason routes.ExclusionReason) {
+		mu.Lock()
+		defer mu.Unlock()
+		excluded = append(excluded, routes.NewExcludedPathExplanation(processorName, fromChainID, toChainID, amount, reason, nil))
 	}
 
 	appendPathFn := func(path *routes.Path) {
@@ -702,6 +785,8 @@ func (r *Router) resolveCandidates(ctx context.Context, input *requests.RouteInp
 							Username:  input.Username,
 							PublicKey: input.PublicKey,
 							PackID:    input.PackID.ToInt(),
+
+							Recipients: input.Recipients,
 						}
 						if input.TestsMode {
 							processorInputParams.TestsMode = input.TestsMode
@@ -717,6 +802,7 @@ func (r *Router) resolveCandidates(ctx context.Context, input *requests.RouteInp
 							continue
 						}
 						if !can {
+							appendExcludedFn(pProcessor.Name(), processorInputParams.FromChain.ChainID, processorInputParams.ToChain.ChainID, processorInputParams.AmountIn, routes.ExclusionReasonNotAvailable)
 							continue
 						}
 
@@ -762,6 +848,15 @@ func (r *Router) resolveCandidates(ctx context.Context, input *requests.RouteInp
 							continue
 						}
 
+						amountIn := amountOption.amount
+						if amountInCalculator, ok := pProcessor.(pathprocessor.PathProcessorAmountInCalculator); ok {
+							amountIn, err = amountInCalculator.CalculateAmountIn(processorInputParams)
+							if err != nil {
+								appendProcessorErrorFn(pProcessor.Name(), input.SendType, processorInputParams.FromChain.ChainID, processorInputParams.ToChain.ChainID, processorInputParams.AmountIn, err)
+								continue
+							}
+						}
+
 						maxFeesPerGas := fetchedFees.FeeFor(input.GasFeeMode)
 
 						estimatedTime := r.feesManager.TransactionEstimatedTime(ctx, network.ChainID, maxFeesPerGas)
@@ -775,7 +870,7 @@ func (r *Router) resolveCandidates(ctx context.Context, input *requests.RouteInp
 							ToChain:        dest,
 							FromToken:      token,
 							ToToken:        toToken,
-							AmountIn:       (*hexutil.Big)(amountOption.amount),
+							AmountIn:       (*hexutil.Big)(amountIn),
 							AmountInLocked: amountOption.locked,
 							AmountOut:      (*hexutil.Big)(amountOut),
 
@@ -815,7 +910,12 @@ func (r *Router) resolveCandidates(ctx context.Context, input *requests.RouteInp
 	})
 
 	group.Wait()
-	return candidates, processorErrors, nil
+
+	candidates, filteredOut := filterNativeBridgeWithdrawals(candidates)
+	for _, path := range filteredOut {
+		excluded = append(excluded, routes.NewCandidatePathExplanation(path, false, routes.ExclusionReasonFilteredOut, nil))
+	}
+	return candidates, excluded, processorErrors, nil
 }
 
 func (r *Router) checkBalancesForTheBestRoute(ctx context.Context, bestRoute routes.Route) (hasPositiveBalance bool, err error) {
@@ -877,14 +977,33 @@ func (r *Router) checkBalancesForTheBestRoute(ctx context.Context, bestRoute rou
 	return hasPositiveBalance, nil
 }
 
-func (r *Router) resolveRoutes(ctx context.Context, input *requests.RouteInputParams, candidates routes.Route) (suggestedRoutes *SuggestedRoutes, err error) {
+// nativeTokenSymbols returns the symbols of the tokens the fees of the candidates are paid in
+func nativeTokenSymbols(candidates routes.Route) []string {
+	symbols := make([]string, 0)
+	for _, path := range candidates {
+		if path.FromChain != nil && path.FromChain.NativeCurrencySymbol != "" {
+			symbols = append(symbols, path.FromChain.NativeCurrencySymbol)
+		}
+	}
+	return symbols
+}
+
+func (r *Router) resolveRoutes(ctx context.Context, input *requests.RouteInputParams, candidates routes.Route, excluded []*routes.PathExplanation) (
+	suggestedRoutes *SuggestedRoutes, err error) {
 	var prices map[string]float64
 	if input.TestsMode {
 		prices = input.TestParams.TokenPrices
 	} else {
-		prices, err = input.SendType.FetchPrices(r.marketManager, []string{input.TokenID, input.ToTokenID})
+		prices, err = input.SendType.FetchPrices(r.marketManager, []string{input.TokenID, input.ToTokenID}, nativeTokenSymbols(candidates))
 		if err != nil {
-			return nil, errors.CreateErrorResponseFromError(err)
+			// the paths can't be ranked without the prices, the explanations still tell which processors were tried
+			return &SuggestedRoutes{
+				Uuid:       input.Uuid,
+				Candidates: candidates,
+				Explanations: []*routes.RouteExplanation{
+					explainUnpricedRoutes(input, candidates, excluded, err),
+				},
+			}, errors.CreateErrorResponseFromError(err)
 		}
 	}
 
@@ -894,6 +1013,14 @@ func (r *Router) resolveRoutes(ctx context.Context, input *requests.RouteInputPa
 	var allRoutes []routes.Route
 	suggestedRoutes, allRoutes = newSuggestedRoutes(input, candidates, prices)
 
+	routablePaths := make(map[*routes.Path]bool)
+	for _, route := range allRoutes {
+		for _, path := range route {
+			routablePaths[path] = true
+		}
+	}
+	balanceErrors := make(map[*routes.Path]error)
+
 	defer func() {
 		if suggestedRoutes.Best != nil && len(suggestedRoutes.Best) > 0 {
 			sort.Slice(suggestedRoutes.Best, func(i, j int) bool {
@@ -911,15 +1038,28 @@ func (r *Router) resolveRoutes(ctx context.Context, input *requests.RouteInputPa
 	)
 
 	for len(allRoutes) > 0 {
-		bestRoute = routes.FindBestRoute(allRoutes, tokenPrice, nativeTokenPrice)
+		if input.SendType == sendtype.Swap && input.AmountOut != nil && input.AmountOut.ToInt().Sign() > 0 {
+			bestRoute = routes.FindBestBuySwapRoute(allRoutes, tokenPrice, nativeTokenPrice)
+		} else if input.SendType == sendtype.Swap {
+			bestRoute = routes.FindBestSwapRoute(allRoutes, tokenPrice, prices[input.ToTokenID], nativeTokenPrice)
+		} else {
+			bestRoute = routes.FindBestRoute(allRoutes, tokenPrice, nativeTokenPrice)
+		}
 		var hasPositiveBalance bool
 		hasPositiveBalance, err = r.checkBalancesForTheBestRoute(ctx, bestRoute)
 
 		if err != nil {
+			for _, path := range bestRoute {
+				if _, ok := balanceErrors[path]; !ok {
+					balanceErrors[path] = err
+				}
+			}
+
 			// If it's about transfer or bridge and there is more routes, but on the best (cheapest) one there is not enugh balance
 			// we shold check other routes even though there are not the cheapest ones
 			if input.SendType == sendtype.Transfer ||
-				input.SendType == sendtype.Bridge {
+				input.SendType == sendtype.Bridge ||
+				input.SendType == sendtype.BatchTransfer {
 				if hasPositiveBalance {
 					lastBestRouteWithPositiveBalance = bestRoute
 					lastBestRouteErr = err
@@ -961,6 +1101,9 @@ func (r *Router) resolveRoutes(ctx context.Context, input *requests.RouteInputPa
 		}
 	}
 	suggestedRoutes.Best = bestRoute
+	suggestedRoutes.Explanations = []*routes.RouteExplanation{
+		explainRoutes(input, candidates, excluded, bestRoute, err, routablePaths, balanceErrors, prices),
+	}
 
 	return suggestedRoutes, err
 }
@@ -0,0 +1,182 @@
+package router
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/status-im/status-go/errors"
+	"github.com/status-im/status-go/services/wallet/async"
+	"github.com/status-im/status-go/services/wallet/requests"
+	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
+)
+
+func (r *Router) SuggestedBatchRoutesAsync(input *requests.BatchRouteInputParams) {
+	r.scheduler.Enqueue(routerTask, func(ctx context.Context) (interface{}, error) {
+		return r.SuggestedBatchRoutes(ctx, input)
+	}, func(result interface{}, taskType async.TaskType, err error) {
+		sendRouterResult(input.Uuid, result, err)
+	})
+}
+
+// SuggestedBatchRoutes plans all the transfers of a batch in a single route. Entries sending the same token are planned
+// as a single Disperse contract call where possible, otherwise each entry is planned as a separate transfer and the
+// transactions are sent one after another. Balances used by the already planned entries are not available to the next ones.
+func (r *Router) SuggestedBatchRoutes(ctx context.Context, input *requests.BatchRouteInputParams) (suggestedRoutes *SuggestedRoutes, err error) {
+	r.resetRoute()
+
+	defer func() {
+		err = r.activateRoutes(suggestedRoutes, err)
+	}()
+
+	testnetMode, err := r.rpcClient.NetworkManager.GetTestNetworksEnabled()
+	if err != nil {
+		return nil, errors.CreateErrorResponseFromError(err)
+	}
+
+	input.TestnetMode = testnetMode
+
+	err = input.Validate()
+	if err != nil {
+		return nil, errors.CreateErrorResponseFromError(err)
+	}
+
+	r.lastInputParamsMutex.Lock()
+	r.lastInputParams = input.SummaryRouteInputParams()
+	r.lastInputParamsMutex.Unlock()
+
+	batchRoutes := &SuggestedRoutes{
+		Uuid:          input.Uuid,
+		UpdatedPrices: make(map[string]float64),
+	}
+	balances := make(map[string]*big.Int)      // balances before the batch
+	spentBalances := make(map[string]*big.Int) // balances used by the already planned entries
+
+	for _, entries := range input.EntriesByToken() {
+		if len(entries) > 1 {
+			var groupRoutes *SuggestedRoutes
+			groupRoutes, err = r.suggestedRoutesForBatchEntries(ctx, input.RouteInputParams(entries), balances, spentBalances)
+			if err == nil {
+				batchRoutes.merge(groupRoutes)
+				continue
+			}
+			log.Warn("router.SuggestedBatchRoutes cannot disperse, sending separate transfers", "tokenID", entries[0].TokenID, "err", err)
+		}
+
+		for _, entry := range entries {
+			var entryRoutes *SuggestedRoutes
+			entryRoutes, err = r.suggestedRoutesForBatchEntries(ctx, input.RouteInputParams([]*requests.BatchTransferEntry{entry}), balances, spentBalances)
+			if err != nil {
+				// the batch can't be sent without the entry, only the explanations are returned
+				failedRoutes := &SuggestedRoutes{
+					Uuid:         input.Uuid,
+					Explanations: batchRoutes.Explanations,
+				}
+				if entryRoutes != nil {
+					failedRoutes.Explanations = append(failedRoutes.Explanations, entryRoutes.Explanations...)
+				}
+				return failedRoutes, mapError(err)
+			}
+			batchRoutes.merge(entryRoutes)
+		}
+	}
+
+	// the batch as a whole must be covered by the balances it started from
+	r.clearActiveBalanceMap()
+	for key, balance := range balances {
+		r.activeBalanceMap.Store(key, balance)
+	}
+	_, err = r.checkBalancesForTheBestRoute(ctx, batchRoutes.Best)
+
+	return batchRoutes, mapError(err)
+}
+
+func (s *SuggestedRoutes) merge(other *SuggestedRoutes) {
+	s.Best = append(s.Best, other.Best...)
+	s.Candidates = append(s.Candidates, other.Candidates...)
+	s.Explanations = append(s.Explanations, other.Explanations...)
+	for symbol, price := range other.UpdatedPrices {
+		s.UpdatedPrices[symbol] = price
+	}
+}
+
+func addToBalanceMap(balanceMap map[string]*big.Int, key string, amount *big.Int) {
+	if amount == nil {
+		return
+	}
+	if balance, ok := balanceMap[key]; ok {
+		balance.Add(balance, amount)
+		return
+	}
+	balanceMap[key] = new(big.Int).Set(amount)
+}
+
+// suggestedRoutesForBatchEntries plans the entries of `input.Recipients` in a single route, the balances fetched for the planning
+// are added to `balances` and the ones used by the planned route to `spentBalances`
+func (r *Router) suggestedRoutesForBatchEntries(ctx context.Context, input *requests.RouteInputParams, balances map[string]*big.Int,
+	spentBalances map[string]*big.Int) (*SuggestedRoutes, error) {
+	selectedFromChains, selectedToChains, err := r.getSelectedChains(input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.prepareBalanceMapForTokenOnChains(ctx, input, selectedFromChains)
+	r.activeBalanceMap.Range(func(k, v interface{}) bool {
+		key := k.(string)
+		balance := v.(*big.Int)
+		if _, ok := balances[key]; !ok {
+			balances[key] = new(big.Int).Set(balance)
+		}
+		if spent, ok := spentBalances[key]; ok {
+			remaining := new(big.Int).Sub(balance, spent)
+			if remaining.Sign() < 0 {
+				remaining = big.NewInt(0)
+			}
+			r.activeBalanceMap.Store(key, remaining)
+		}
+		return true
+	})
+	if r.noBalanceOnAnyChain() {
+		if err != nil {
+			return nil, errors.CreateErrorResponseFromError(err)
+		}
+		return nil, ErrNoPositiveBalance
+	}
+
+	candidates, excluded, processorErrors, err := r.resolveCandidates(ctx, input, selectedFromChains, selectedToChains)
+	if err != nil {
+		return nil, err
+	}
+
+	// the routes are returned with the errors, their explanations tell why the entries can't be planned
+	suggestedRoutes, err := r.resolveRoutes(ctx, input, candidates, excluded)
+	if err != nil {
+		return suggestedRoutes, err
+	}
+	if len(suggestedRoutes.Best) == 0 {
+		return suggestedRoutes, noBestRouteError(processorErrors)
+	}
+
+	for _, path := range suggestedRoutes.Best {
+		if path.ProcessorName == pathprocessor.ProcessorDisperseName {
+			// the recipients amounts are fixed, the fees can't be taken from the sent amount
+			if path.SubtractFees {
+				return nil, ErrBatchAmountDoesNotCoverFees
+			}
+			path.Recipients = input.Recipients
+		} else {
+			path.Recipients = []*pathprocessor.TransferRecipient{
+				{
+					Address: input.AddrTo,
+					Amount:  (*hexutil.Big)(new(big.Int).Set(path.AmountIn.ToInt())),
+				},
+			}
+		}
+
+		addToBalanceMap(spentBalances, makeBalanceKey(path.FromChain.ChainID, path.FromToken.Symbol), path.RequiredTokenBalance)
+		addToBalanceMap(spentBalances, makeBalanceKey(path.FromChain.ChainID, pathprocessor.EthSymbol), path.RequiredNativeBalance)
+	}
+
+	return suggestedRoutes, nil
+}
@@ -0,0 +1,181 @@
+package router
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/google/uuid"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/requests"
+	"github.com/status-im/status-go/services/wallet/router/fees"
+	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
+	"github.com/status-im/status-go/services/wallet/router/routes"
+	"github.com/status-im/status-go/services/wallet/token"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupBatchRouter(t *testing.T) (*Router, func()) {
+	router, cleanTmpDb := setupRouter(t)
+
+	disperse := pathprocessor.NewDisperseProcessor(nil, nil)
+	router.AddPathProcessor(disperse)
+
+	return router, cleanTmpDb
+}
+
+func batchTestInput(entries []*requests.BatchTransferEntry) *requests.BatchRouteInputParams {
+	return &requests.BatchRouteInputParams{
+		Uuid:                 uuid.NewString(),
+		AddrFrom:             common.HexToAddress("0x1"),
+		Entries:              entries,
+		DisabledFromChainIDs: []uint64{walletCommon.OptimismMainnet, walletCommon.ArbitrumMainnet},
+		DisabledToChainIDs:   []uint64{walletCommon.OptimismMainnet, walletCommon.ArbitrumMainnet},
+		GasFeeMode:           fees.GasFeeMedium,
+
+		TestsMode: true,
+		TestParams: &requests.RouterTestParams{
+			TokenFrom: &token.Token{
+				ChainID:  1,
+				Symbol:   pathprocessor.EthSymbol,
+				Decimals: 18,
+			},
+			TokenPrices:   testTokenPrices,
+			SuggestedFees: testSuggestedFees,
+			BalanceMap:    testBalanceMapPerChain,
+			EstimationMap: map[string]pathprocessor.Estimation{
+				pathprocessor.ProcessorTransferName: {Value: uint64(1000), Err: nil},
+				pathprocessor.ProcessorDisperseName: {Value: uint64(2000), Err: nil},
+			},
+			BonderFeeMap:          testBBonderFeeMap,
+			ApprovalGasEstimation: testApprovalGasEstimation,
+			ApprovalL1Fee:         testApprovalL1Fee,
+		},
+	}
+}
+
+func TestBatchRouterDispersesSameTokenEntries(t *testing.T) {
+	router, cleanTmpDb := setupBatchRouter(t)
+	defer cleanTmpDb()
+
+	entries := []*requests.BatchTransferEntry{
+		{
+			AddrTo:   common.HexToAddress("0x2"),
+			TokenID:  pathprocessor.EthSymbol,
+			AmountIn: (*hexutil.Big)(big.NewInt(testAmount0Point1ETHInWei)),
+		},
+		{
+			AddrTo:   common.HexToAddress("0x3"),
+			TokenID:  pathprocessor.EthSymbol,
+			AmountIn: (*hexutil.Big)(big.NewInt(testAmount0Point2ETHInWei)),
+		},
+	}
+
+	suggestedRoutes, err := router.SuggestedBatchRoutes(context.Background(), batchTestInput(entries))
+	require.NoError(t, err)
+	require.Len(t, suggestedRoutes.Best, 1)
+
+	path := suggestedRoutes.Best[0]
+	require.Equal(t, pathprocessor.ProcessorDisperseName, path.ProcessorName)
+	require.Equal(t, walletCommon.EthereumMainnet, path.FromChain.ChainID)
+	require.Equal(t, big.NewInt(testAmount0Point3ETHInWei), path.AmountIn.ToInt())
+	require.Len(t, path.Recipients, len(entries))
+	for i, entry := range entries {
+		require.Equal(t, entry.AddrTo, path.Recipients[i].Address)
+		require.Equal(t, entry.AmountIn.ToInt(), path.Recipients[i].Amount.ToInt())
+	}
+}
+
+func TestBatchRouterTransfersSingleEntry(t *testing.T) {
+	router, cleanTmpDb := setupBatchRouter(t)
+	defer cleanTmpDb()
+
+	entry := &requests.BatchTransferEntry{
+		AddrTo:   common.HexToAddress("0x2"),
+		TokenID:  pathprocessor.EthSymbol,
+		AmountIn: (*hexutil.Big)(big.NewInt(testAmount0Point1ETHInWei)),
+	}
+
+	suggestedRoutes, err := router.SuggestedBatchRoutes(context.Background(), batchTestInput([]*requests.BatchTransferEntry{entry}))
+	require.NoError(t, err)
+	require.Len(t, suggestedRoutes.Best, 1)
+
+	path := suggestedRoutes.Best[0]
+	require.Equal(t, pathprocessor.ProcessorTransferName, path.ProcessorName)
+	require.Len(t, path.Recipients, 1)
+	require.Equal(t, entry.AddrTo, path.Recipients[0].Address)
+	require.Equal(t, entry.AmountIn.ToInt(), path.Recipients[0].Amount.ToInt())
+}
+
+func TestBatchRouterFailsWhenBatchExceedsBalance(t *testing.T) {
+	router, cleanTmpDb := setupBatchRouter(t)
+	defer cleanTmpDb()
+
+	entries := []*requests.BatchTransferEntry{
+		{
+			AddrTo:   common.HexToAddress("0x2"),
+			TokenID:  pathprocessor.EthSymbol,
+			AmountIn: (*hexutil.Big)(big.NewInt(testAmount1ETHInWei)),
+		},
+		{
+			AddrTo:   common.HexToAddress("0x3"),
+			TokenID:  pathprocessor.EthSymbol,
+			AmountIn: (*hexutil.Big)(big.NewInt(testAmount2ETHInWei)),
+		},
+	}
+
+	_, err := router.SuggestedBatchRoutes(context.Background(), batchTestInput(entries))
+	require.Error(t, err)
+}
+
+func TestSuggestedRoutesMerge(t *testing.T) {
+	first := &SuggestedRoutes{
+		Best:          routes.Route{{ProcessorName: pathprocessor.ProcessorDisperseName}},
+		Candidates:    routes.Route{{ProcessorName: pathprocessor.ProcessorDisperseName}},
+		UpdatedPrices: map[string]float64{pathprocessor.EthSymbol: 2000},
+	}
+	second := &SuggestedRoutes{
+		Best:          routes.Route{{ProcessorName: pathprocessor.ProcessorTransferName}},
+		Candidates:    routes.Route{{ProcessorName: pathprocessor.ProcessorTransferName}, {ProcessorName: pathprocessor.ProcessorBridgeHopName}},
+		UpdatedPrices: map[string]float64{pathprocessor.EthSymbol: 2100, pathprocessor.UsdcSymbol: 1},
+	}
+
+	first.merge(second)
+
+	require.Len(t, first.Best, 2)
+	require.Equal(t, pathprocessor.ProcessorDisperseName, first.Best[0].ProcessorName)
+	require.Equal(t, pathprocessor.ProcessorTransferName, first.Best[1].ProcessorName)
+	require.Len(t, first.Candidates, 3)
+	require.Equal(t, map[string]float64{pathprocessor.EthSymbol: 2100, pathprocessor.UsdcSymbol: 1}, first.UpdatedPrices)
+}
+
+func TestAddToBalanceMap(t *testing.T) {
+	key := makeBalanceKey(walletCommon.EthereumMainnet, pathprocessor.EthSymbol)
+	balanceMap := make(map[string]*big.Int)
+
+	addToBalanceMap(balanceMap, key, nil)
+	require.Empty(t, balanceMap)
+
+	amount := big.NewInt(testAmount0Point1ETHInWei)
+	addToBalanceMap(balanceMap, key, amount)
+	addToBalanceMap(balanceMap, key, big.NewInt(testAmount0Point2ETHInWei))
+	require.Equal(t, big.NewInt(testAmount0Point3ETHInWei), balanceMap[key])
+	// the added amount is copied, not aliased
+	require.Equal(t, big.NewInt(testAmount0Point1ETHInWei), amount)
+}
+
+func TestClearActiveBalanceMap(t *testing.T) {
+	router := &Router{}
+	key := makeBalanceKey(walletCommon.EthereumMainnet, pathprocessor.EthSymbol)
+	router.activeBalanceMap.Store(key, big.NewInt(testAmount1ETHInWei))
+
+	router.clearActiveBalanceMap()
+
+	_, ok := router.activeBalanceMap.Load(key)
+	require.False(t, ok)
+}
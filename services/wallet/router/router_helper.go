@@ -14,6 +14,7 @@ import (
 	"github.com/status-im/status-go/params"
 	"github.com/status-im/status-go/services/wallet/bigint"
 	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/nativebridge"
 	"github.com/status-im/status-go/services/wallet/router/fees"
 	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
 	routs "github.com/status-im/status-go/services/wallet/router/routes"
@@ -236,3 +237,31 @@ func (r *Router) cacluateFees(ctx context.Context, path *routs.Path, fetchedFees
 
 	return nil
 }
+
+// filterNativeBridgeWithdrawals drops the canonical bridge withdrawals between the chains other bridges can be used for,
+// the withdrawals are cheap but the funds are locked until the challenge period is over. The dropped paths are returned too.
+func filterNativeBridgeWithdrawals(candidates routs.Route) (filtered routs.Route, filteredOut routs.Route) {
+	type chainPair struct {
+		from uint64
+		to   uint64
+	}
+
+	otherBridges := make(map[chainPair]bool)
+	for _, path := range candidates {
+		if path.ProcessorName != pathprocessor.ProcessorBridgeNativeName && pathprocessor.IsProcessorBridge(path.ProcessorName) {
+			otherBridges[chainPair{path.FromChain.ChainID, path.ToChain.ChainID}] = true
+		}
+	}
+
+	filtered = make(routs.Route, 0, len(candidates))
+	for _, path := range candidates {
+		if path.ProcessorName == pathprocessor.ProcessorBridgeNativeName &&
+			nativebridge.IsWithdrawal(path.FromChain.ChainID, path.ToChain.ChainID) &&
+			otherBridges[chainPair{path.FromChain.ChainID, path.ToChain.ChainID}] {
+			filteredOut = append(filteredOut, path)
+			continue
+		}
+		filtered = append(filtered, path)
+	}
+	return filtered, filteredOut
+}
@@ -115,7 +115,7 @@ func setupRouter(t *testing.T) (*Router, func()) {
 	hop := pathprocessor.NewHopBridgeProcessor(nil, nil, nil, nil)
 	router.AddPathProcessor(hop)
 
-	paraswap := pathprocessor.NewSwapParaswapProcessor(nil, nil, nil)
+	paraswap := pathprocessor.NewSwapParaswapProcessor(nil)
 	router.AddPathProcessor(paraswap)
 
 	ensRegister := pathprocessor.NewENSReleaseProcessor(nil, nil, nil)
@@ -115,6 +115,8 @@ func (r *Router) subscribeForUdates(chainID uint64) error {
 							}
 						}
 
+						// the explanations aren't ranked again, only the fees of the best route are refreshed so the candidates
+						// wouldn't be compared with the same fees
 						_, err = r.checkBalancesForTheBestRoute(ctx, r.activeRoutes.Best)
 
 						sendRouterResult(uuid, r.activeRoutes, err)
@@ -0,0 +1,167 @@
+package routes
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/errors"
+)
+
+// ExclusionReason tells why a path is not part of the best route
+type ExclusionReason string
+
+const (
+	ExclusionReasonNotAvailable        ExclusionReason = "not-available"        // the processor can't handle the input (`AvailableFor` returned false)
+	ExclusionReasonProcessorError      ExclusionReason = "processor-error"      // calculating the fees, gas or amount out of the path failed
+	ExclusionReasonFilteredOut         ExclusionReason = "filtered-out"         // another processor is preferred between the chains
+	ExclusionReasonNoValidRoute        ExclusionReason = "no-valid-route"       // the path can't be combined into a route sending the requested amount
+	ExclusionReasonInsufficientBalance ExclusionReason = "insufficient-balance" // the balances don't cover the route containing the path
+	ExclusionReasonHigherCost          ExclusionReason = "higher-cost"          // another route scored better
+	ExclusionReasonPricesUnavailable   ExclusionReason = "prices-unavailable"   // the candidates can't be compared without the token prices
+)
+
+// FiatFees is the fee breakdown of a path in fiat, the prices are fetched in `Currency`
+type FiatFees struct {
+	Currency      string
+	TxFee         float64
+	TxL1Fee       float64
+	BonderFees    float64
+	TokenFees     float64
+	ApprovalFee   float64
+	ApprovalL1Fee float64
+	Total         float64
+}
+
+// PathExplanation explains the outcome of a processor tried by the router for a pair of chains and an amount. Fees, score
+// and rank are set only for the candidate paths, processors failing before a path is built have the reason set only.
+type PathExplanation struct {
+	ProcessorName string
+	FromChainID   uint64
+	ToChainID     uint64
+	AmountIn      *hexutil.Big
+	Selected      bool                  // the path is part of the best route
+	Reason        ExclusionReason       `json:"Reason,omitempty"`
+	Error         *errors.ErrorResponse `json:"Error,omitempty"`
+	Fees          *FiatFees             `json:"Fees,omitempty"`
+	Score         float64               // fees for transfers and bridges (lower is better), value received net of fees for swaps (higher is better)
+	Rank          int                   // position of the candidate by score, starting at 1, 0 for the paths without a score
+
+	path *Path
+}
+
+func newErrorResponse(err error) *errors.ErrorResponse {
+	if err == nil {
+		return nil
+	}
+	return errors.CreateErrorResponseFromError(err).(*errors.ErrorResponse)
+}
+
+// NewExcludedPathExplanation explains a processor which didn't produce a candidate path
+func NewExcludedPathExplanation(processorName string, fromChainID uint64, toChainID uint64, amountIn *big.Int, reason ExclusionReason,
+	err error) *PathExplanation {
+	return &PathExplanation{
+		ProcessorName: processorName,
+		FromChainID:   fromChainID,
+		ToChainID:     toChainID,
+		AmountIn:      (*hexutil.Big)(amountIn),
+		Reason:        reason,
+		Error:         newErrorResponse(err),
+	}
+}
+
+// NewCandidatePathExplanation explains a candidate path, the reason is empty for the selected paths
+func NewCandidatePathExplanation(path *Path, selected bool, reason ExclusionReason, err error) *PathExplanation {
+	return &PathExplanation{
+		ProcessorName: path.ProcessorName,
+		FromChainID:   path.FromChain.ChainID,
+		ToChainID:     path.ToChain.ChainID,
+		AmountIn:      path.AmountIn,
+		Selected:      selected,
+		Reason:        reason,
+		Error:         newErrorResponse(err),
+		path:          path,
+	}
+}
+
+// RouteExplanation explains how the best route was picked out of the processors tried by the router
+type RouteExplanation struct {
+	TokenID   string
+	ToTokenID string `json:"ToTokenID,omitempty"`
+	Paths     []*PathExplanation
+
+	swap bool
+}
+
+func NewRouteExplanation(tokenID string, toTokenID string, swap bool) *RouteExplanation {
+	return &RouteExplanation{
+		TokenID:   tokenID,
+		ToTokenID: toTokenID,
+		Paths:     make([]*PathExplanation, 0),
+		swap:      swap,
+	}
+}
+
+func (e *RouteExplanation) Add(paths ...*PathExplanation) {
+	e.Paths = append(e.Paths, paths...)
+}
+
+func bigFloatToFloat64(value *big.Float) float64 {
+	if value == nil {
+		return 0
+	}
+	result, _ := value.Float64()
+	return result
+}
+
+// nativeTokenPrice returns the price of the native token of the chain the fees of the path are paid on
+func nativeTokenPrice(prices map[string]float64, path *Path) float64 {
+	if path.FromChain != nil && path.FromChain.NativeCurrencySymbol != "" {
+		return prices[path.FromChain.NativeCurrencySymbol]
+	}
+	return prices["ETH"]
+}
+
+// Rank calculates the fees and the scores of the candidate paths with the given prices and orders the candidates by their score,
+// it is called again once the fees of the route are updated
+func (e *RouteExplanation) Rank(prices map[string]float64, currency string) {
+	tokenPrice := prices[e.TokenID]
+	toTokenPrice := prices[e.ToTokenID]
+
+	ranked := make([]*PathExplanation, 0, len(e.Paths))
+	for _, explanation := range e.Paths {
+		path := explanation.path
+		if path == nil || path.FromToken == nil || path.TxFee == nil || path.TxL1Fee == nil {
+			continue
+		}
+
+		cost := calculatePathCost(path, tokenPrice, nativeTokenPrice(prices, path))
+		total := cost.total()
+		explanation.Fees = &FiatFees{
+			Currency:      currency,
+			TxFee:         bigFloatToFloat64(cost.txFee),
+			TxL1Fee:       bigFloatToFloat64(cost.txL1Fee),
+			BonderFees:    bigFloatToFloat64(cost.bonderFees),
+			TokenFees:     bigFloatToFloat64(cost.tokenFees),
+			ApprovalFee:   bigFloatToFloat64(cost.approvalFee),
+			ApprovalL1Fee: bigFloatToFloat64(cost.approvalL1Fee),
+			Total:         bigFloatToFloat64(total),
+		}
+
+		explanation.Score = explanation.Fees.Total
+		if e.swap {
+			explanation.Score = bigFloatToFloat64(new(big.Float).Sub(swapValue(path, toTokenPrice), total))
+		}
+		ranked = append(ranked, explanation)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if e.swap {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Score < ranked[j].Score
+	})
+	for i, explanation := range ranked {
+		explanation.Rank = i + 1
+	}
+}
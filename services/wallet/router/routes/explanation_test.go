@@ -0,0 +1,112 @@
+package routes
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/params"
+	"github.com/status-im/status-go/services/wallet/token"
+)
+
+func newBridgePath(processorName string, txFee int64, bonderFees int64) *Path {
+	return &Path{
+		ProcessorName: processorName,
+		FromChain:     &params.Network{ChainID: 1},
+		ToChain:       &params.Network{ChainID: 10},
+		FromToken:     &token.Token{Symbol: "USDC", Decimals: 6},
+		AmountIn:      (*hexutil.Big)(big.NewInt(100e6)),
+		AmountOut:     (*hexutil.Big)(big.NewInt(100e6 - bonderFees)),
+		TxFee:         (*hexutil.Big)(big.NewInt(txFee)),
+		TxL1Fee:       (*hexutil.Big)(big.NewInt(0)),
+		TxBonderFees:  (*hexutil.Big)(big.NewInt(bonderFees)),
+	}
+}
+
+func TestRouteExplanationRank(t *testing.T) {
+	prices := map[string]float64{
+		"ETH":  2000,
+		"USDC": 1,
+	}
+
+	// 2 USD tx fee + 1 USD bonder fee
+	hop := newBridgePath("Hop", 1e15, 1e6)
+	// 4 USD tx fee
+	celer := newBridgePath("CBridge", 2e15, 0)
+
+	explanation := NewRouteExplanation("USDC", "", false)
+	explanation.Add(
+		NewCandidatePathExplanation(celer, false, ExclusionReasonHigherCost, nil),
+		NewCandidatePathExplanation(hop, true, "", nil),
+		NewExcludedPathExplanation("NativeBridge", 1, 10, big.NewInt(100e6), ExclusionReasonNotAvailable, nil),
+		NewExcludedPathExplanation("Transfer", 1, 10, big.NewInt(100e6), ExclusionReasonProcessorError, errors.New("estimation failed")),
+	)
+	explanation.Rank(prices, "USD")
+
+	celerExplanation, hopExplanation := explanation.Paths[0], explanation.Paths[1]
+	assert.Equal(t, 1, hopExplanation.Rank)
+	assert.True(t, hopExplanation.Selected)
+	assert.Equal(t, "USD", hopExplanation.Fees.Currency)
+	assert.InDelta(t, 2.0, hopExplanation.Fees.TxFee, 1e-9)
+	assert.InDelta(t, 1.0, hopExplanation.Fees.BonderFees, 1e-9)
+	assert.InDelta(t, 3.0, hopExplanation.Fees.Total, 1e-9)
+	assert.InDelta(t, 3.0, hopExplanation.Score, 1e-9)
+
+	assert.Equal(t, 2, celerExplanation.Rank)
+	assert.Equal(t, ExclusionReasonHigherCost, celerExplanation.Reason)
+	assert.InDelta(t, 4.0, celerExplanation.Fees.Total, 1e-9)
+
+	notAvailable, processorError := explanation.Paths[2], explanation.Paths[3]
+	assert.Equal(t, 0, notAvailable.Rank)
+	assert.Nil(t, notAvailable.Fees)
+	assert.Nil(t, notAvailable.Error)
+	assert.Equal(t, ExclusionReasonProcessorError, processorError.Reason)
+	assert.Equal(t, "estimation failed", processorError.Error.Details)
+
+	// the ranking follows the updated fees
+	hop.TxFee = (*hexutil.Big)(big.NewInt(3e15))
+	explanation.Rank(prices, "USD")
+	assert.Equal(t, 2, hopExplanation.Rank)
+	assert.Equal(t, 1, celerExplanation.Rank)
+}
+
+func TestRouteExplanationRankSwap(t *testing.T) {
+	prices := map[string]float64{
+		"ETH":  2000,
+		"USDC": 1,
+	}
+
+	explanation := NewRouteExplanation("ETH", "USDC", true)
+	explanation.Add(
+		NewCandidatePathExplanation(newSwapPath("Paraswap", 2000e6, 1e15), false, ExclusionReasonHigherCost, nil),
+		NewCandidatePathExplanation(newSwapPath("ZeroEx", 2005e6, 2e15), true, "", nil),
+	)
+	explanation.Rank(prices, "USD")
+
+	// the value received net of fees is compared for swaps
+	assert.InDelta(t, 1998.0, explanation.Paths[0].Score, 1e-9)
+	assert.InDelta(t, 2001.0, explanation.Paths[1].Score, 1e-9)
+	assert.Equal(t, 2, explanation.Paths[0].Rank)
+	assert.Equal(t, 1, explanation.Paths[1].Rank)
+}
+
+func TestRouteExplanationRankNativeTokenPrice(t *testing.T) {
+	prices := map[string]float64{
+		"ETH":  2000,
+		"POL":  0.5,
+		"USDC": 1,
+	}
+
+	// the fees are paid in the native token of the chain the path is sent from
+	path := newBridgePath("Hop", 1e18, 0)
+	path.FromChain = &params.Network{ChainID: 137, NativeCurrencySymbol: "POL"}
+
+	explanation := NewRouteExplanation("USDC", "", false)
+	explanation.Add(NewCandidatePathExplanation(path, true, "", nil))
+	explanation.Rank(prices, "USD")
+
+	assert.InDelta(t, 0.5, explanation.Paths[0].Fees.TxFee, 1e-9)
+}
@@ -21,47 +21,47 @@ func FindBestRoute(routes []Route, tokenPrice float64, nativeTokenPrice float64)
 	var best Route
 	bestCost := big.NewFloat(math.Inf(1))
 	for _, route := range routes {
-		currentCost := big.NewFloat(0)
-		for _, path := range route {
-			tokenDenominator := big.NewFloat(math.Pow(10, float64(path.FromToken.Decimals)))
-
-			// calculate the cost of the path
-			nativeTokenPrice := new(big.Float).SetFloat64(nativeTokenPrice)
-
-			// tx fee
-			txFeeInEth := common.GweiToEth(common.WeiToGwei(path.TxFee.ToInt()))
-			pathCost := new(big.Float).Mul(txFeeInEth, nativeTokenPrice)
-
-			if path.TxL1Fee.ToInt().Cmp(common.ZeroBigIntValue()) > 0 {
-				txL1FeeInEth := common.GweiToEth(common.WeiToGwei(path.TxL1Fee.ToInt()))
-				pathCost.Add(pathCost, new(big.Float).Mul(txL1FeeInEth, nativeTokenPrice))
-			}
-
-			if path.TxBonderFees != nil && path.TxBonderFees.ToInt().Cmp(common.ZeroBigIntValue()) > 0 {
-				pathCost.Add(pathCost, new(big.Float).Mul(
-					new(big.Float).Quo(new(big.Float).SetInt(path.TxBonderFees.ToInt()), tokenDenominator),
-					new(big.Float).SetFloat64(tokenPrice)))
+		currentCost := routeCost(route, tokenPrice, nativeTokenPrice)
+		if currentCost.Cmp(bestCost) == -1 {
+			best = route
+			bestCost = currentCost
+		}
+	}
 
-			}
+	return best
+}
 
-			if path.TxTokenFees != nil && path.TxTokenFees.ToInt().Cmp(common.ZeroBigIntValue()) > 0 && path.FromToken != nil {
-				pathCost.Add(pathCost, new(big.Float).Mul(
-					new(big.Float).Quo(new(big.Float).SetInt(path.TxTokenFees.ToInt()), tokenDenominator),
-					new(big.Float).SetFloat64(tokenPrice)))
+// FindBestSwapRoute returns the route with the highest value received, net of all the fees paid.
+// Different swap providers quote different amounts out, so picking the cheapest route is not enough.
+func FindBestSwapRoute(routes []Route, tokenPrice float64, toTokenPrice float64, nativeTokenPrice float64) Route {
+	var best Route
+	bestValue := big.NewFloat(math.Inf(-1))
+	for _, route := range routes {
+		currentValue := new(big.Float).Neg(routeCost(route, tokenPrice, nativeTokenPrice))
+		for _, path := range route {
+			if path.AmountOut == nil || path.ToToken == nil {
+				continue
 			}
+			currentValue.Add(currentValue, swapValue(path, toTokenPrice))
+		}
 
-			if path.ApprovalRequired {
-				// tx approval fee
-				approvalFeeInEth := common.GweiToEth(common.WeiToGwei(path.ApprovalFee.ToInt()))
-				pathCost.Add(pathCost, new(big.Float).Mul(approvalFeeInEth, nativeTokenPrice))
+		if currentValue.Cmp(bestValue) == 1 {
+			best = route
+			bestValue = currentValue
+		}
+	}
 
-				if path.ApprovalL1Fee.ToInt().Cmp(common.ZeroBigIntValue()) > 0 {
-					approvalL1FeeInEth := common.GweiToEth(common.WeiToGwei(path.ApprovalL1Fee.ToInt()))
-					pathCost.Add(pathCost, new(big.Float).Mul(approvalL1FeeInEth, nativeTokenPrice))
-				}
-			}
+	return best
+}
 
-			currentCost = new(big.Float).Add(currentCost, pathCost)
+// FindBestBuySwapRoute returns the route sending the lowest value, including all the fees paid, for a fixed amount received.
+func FindBestBuySwapRoute(routes []Route, tokenPrice float64, nativeTokenPrice float64) Route {
+	var best Route
+	bestCost := big.NewFloat(math.Inf(1))
+	for _, route := range routes {
+		currentCost := routeCost(route, tokenPrice, nativeTokenPrice)
+		for _, path := range route {
+			currentCost.Add(currentCost, amountInValue(path, tokenPrice))
 		}
 
 		if currentCost.Cmp(bestCost) == -1 {
@@ -72,3 +72,94 @@ func FindBestRoute(routes []Route, tokenPrice float64, nativeTokenPrice float64)
 
 	return best
 }
+
+func routeCost(route Route, tokenPrice float64, nativeTokenPrice float64) *big.Float {
+	currentCost := big.NewFloat(0)
+	for _, path := range route {
+		currentCost = new(big.Float).Add(currentCost, calculatePathCost(path, tokenPrice, nativeTokenPrice).total())
+	}
+
+	return currentCost
+}
+
+// pathCost is the cost of a path in fiat split by the fee type, fees not paid by the path are nil
+type pathCost struct {
+	txFee         *big.Float
+	txL1Fee       *big.Float
+	bonderFees    *big.Float
+	tokenFees     *big.Float
+	approvalFee   *big.Float
+	approvalL1Fee *big.Float
+}
+
+func (c *pathCost) total() *big.Float {
+	total := new(big.Float).Set(c.txFee)
+	for _, fee := range []*big.Float{c.txL1Fee, c.bonderFees, c.tokenFees, c.approvalFee, c.approvalL1Fee} {
+		if fee != nil {
+			total.Add(total, fee)
+		}
+	}
+	return total
+}
+
+func calculatePathCost(path *Path, tokenPrice float64, nativeTokenPrice float64) *pathCost {
+	cost := &pathCost{}
+	tokenDenominator := big.NewFloat(math.Pow(10, float64(path.FromToken.Decimals)))
+	nativeTokenPriceFloat := new(big.Float).SetFloat64(nativeTokenPrice)
+
+	// tx fee
+	txFeeInEth := common.GweiToEth(common.WeiToGwei(path.TxFee.ToInt()))
+	cost.txFee = new(big.Float).Mul(txFeeInEth, nativeTokenPriceFloat)
+
+	if path.TxL1Fee.ToInt().Cmp(common.ZeroBigIntValue()) > 0 {
+		txL1FeeInEth := common.GweiToEth(common.WeiToGwei(path.TxL1Fee.ToInt()))
+		cost.txL1Fee = new(big.Float).Mul(txL1FeeInEth, nativeTokenPriceFloat)
+	}
+
+	if path.TxBonderFees != nil && path.TxBonderFees.ToInt().Cmp(common.ZeroBigIntValue()) > 0 {
+		cost.bonderFees = new(big.Float).Mul(
+			new(big.Float).Quo(new(big.Float).SetInt(path.TxBonderFees.ToInt()), tokenDenominator),
+			new(big.Float).SetFloat64(tokenPrice))
+	}
+
+	if path.TxTokenFees != nil && path.TxTokenFees.ToInt().Cmp(common.ZeroBigIntValue()) > 0 && path.FromToken != nil {
+		cost.tokenFees = new(big.Float).Mul(
+			new(big.Float).Quo(new(big.Float).SetInt(path.TxTokenFees.ToInt()), tokenDenominator),
+			new(big.Float).SetFloat64(tokenPrice))
+	}
+
+	if path.ApprovalRequired {
+		// tx approval fee
+		approvalFeeInEth := common.GweiToEth(common.WeiToGwei(path.ApprovalFee.ToInt()))
+		cost.approvalFee = new(big.Float).Mul(approvalFeeInEth, nativeTokenPriceFloat)
+
+		if path.ApprovalL1Fee.ToInt().Cmp(common.ZeroBigIntValue()) > 0 {
+			approvalL1FeeInEth := common.GweiToEth(common.WeiToGwei(path.ApprovalL1Fee.ToInt()))
+			cost.approvalL1Fee = new(big.Float).Mul(approvalL1FeeInEth, nativeTokenPriceFloat)
+		}
+	}
+
+	return cost
+}
+
+// swapValue returns the value received by the swap path in fiat
+func swapValue(path *Path, toTokenPrice float64) *big.Float {
+	if path.AmountOut == nil || path.ToToken == nil {
+		return big.NewFloat(0)
+	}
+	toTokenDenominator := big.NewFloat(math.Pow(10, float64(path.ToToken.Decimals)))
+	return new(big.Float).Mul(
+		new(big.Float).Quo(new(big.Float).SetInt(path.AmountOut.ToInt()), toTokenDenominator),
+		new(big.Float).SetFloat64(toTokenPrice))
+}
+
+// amountInValue returns the value sent by the path in fiat
+func amountInValue(path *Path, tokenPrice float64) *big.Float {
+	if path.AmountIn == nil || path.FromToken == nil {
+		return big.NewFloat(0)
+	}
+	tokenDenominator := big.NewFloat(math.Pow(10, float64(path.FromToken.Decimals)))
+	return new(big.Float).Mul(
+		new(big.Float).Quo(new(big.Float).SetInt(path.AmountIn.ToInt()), tokenDenominator),
+		new(big.Float).SetFloat64(tokenPrice))
+}
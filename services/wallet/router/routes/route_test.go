@@ -0,0 +1,66 @@
+package routes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/status-go/params"
+	"github.com/status-im/status-go/services/wallet/token"
+)
+
+func newSwapPath(processorName string, amountOut int64, txFee int64) *Path {
+	return &Path{
+		ProcessorName: processorName,
+		FromChain:     &params.Network{ChainID: 1},
+		ToChain:       &params.Network{ChainID: 1},
+		FromToken:     &token.Token{Symbol: "ETH", Decimals: 18},
+		ToToken:       &token.Token{Symbol: "USDC", Decimals: 6},
+		AmountIn:      (*hexutil.Big)(big.NewInt(1e18)),
+		AmountOut:     (*hexutil.Big)(big.NewInt(amountOut)),
+		TxFee:         (*hexutil.Big)(big.NewInt(txFee)),
+		TxL1Fee:       (*hexutil.Big)(big.NewInt(0)),
+	}
+}
+
+func TestFindBestBuySwapRoute(t *testing.T) {
+	const ethPrice = 2000.0
+
+	// 0.001 ETH (2 USD) less sent for the same amount received, but 0.0005 ETH (1 USD) more paid in fees
+	lessIn := Route{newSwapPath("Paraswap", 2000e6, 15e14)}
+	lessIn[0].AmountIn = (*hexutil.Big)(big.NewInt(999e15))
+	cheaper := Route{newSwapPath("ZeroEx", 2000e6, 1e15)}
+
+	best := FindBestBuySwapRoute([]Route{cheaper, lessIn}, ethPrice, ethPrice)
+	assert.Equal(t, "Paraswap", best[0].ProcessorName)
+
+	// the lower fee outweighs the lower amount sent
+	lessIn[0].TxFee = (*hexutil.Big)(big.NewInt(25e14))
+	best = FindBestBuySwapRoute([]Route{lessIn, cheaper}, ethPrice, ethPrice)
+	assert.Equal(t, "ZeroEx", best[0].ProcessorName)
+}
+
+func TestFindBestSwapRoute(t *testing.T) {
+	const (
+		ethPrice  = 2000.0
+		usdcPrice = 1.0
+	)
+
+	// 1 USDC more received, but 0.001 ETH (2 USD) more paid in fees
+	cheaper := Route{newSwapPath("Paraswap", 2000e6, 1e15)}
+	moreOut := Route{newSwapPath("ZeroEx", 2001e6, 2e15)}
+
+	best := FindBestSwapRoute([]Route{moreOut, cheaper}, ethPrice, usdcPrice, ethPrice)
+	assert.Equal(t, "Paraswap", best[0].ProcessorName)
+
+	// 5 USDC more received outweighs the higher fee
+	moreOut = Route{newSwapPath("ZeroEx", 2005e6, 2e15)}
+	best = FindBestSwapRoute([]Route{cheaper, moreOut}, ethPrice, usdcPrice, ethPrice)
+	assert.Equal(t, "ZeroEx", best[0].ProcessorName)
+
+	// the cheapest route is picked when only fees are considered
+	best = FindBestRoute([]Route{moreOut, cheaper}, ethPrice, ethPrice)
+	assert.Equal(t, "Paraswap", best[0].ProcessorName)
+}
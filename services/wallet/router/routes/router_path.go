@@ -7,6 +7,8 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/status-im/status-go/params"
 	"github.com/status-im/status-go/services/wallet/router/fees"
+	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
+	"github.com/status-im/status-go/services/wallet/simulation"
 	walletToken "github.com/status-im/status-go/services/wallet/token"
 )
 
@@ -49,6 +51,11 @@ type Path struct {
 	RequiredTokenBalance  *big.Int // (in selected token)
 	RequiredNativeBalance *big.Int // (in ETH WEI)
 	SubtractFees          bool
+
+	Recipients []*pathprocessor.TransferRecipient // Recipients paid by the path, set for batch transfers only
+
+	ApprovalSimulation *simulation.Result // Predicted outcome of the approval transaction, set once the transactions are built
+	TxSimulation       *simulation.Result // Predicted outcome of the transaction, set once the transactions are built
 }
 
 func (p *Path) Equal(o *Path) bool {
@@ -64,6 +71,9 @@ func (p *Path) Copy() *Path {
 		ApprovalGasAmount: p.ApprovalGasAmount,
 		EstimatedTime:     p.EstimatedTime,
 		SubtractFees:      p.SubtractFees,
+		// simulation results are never modified once set, so they can be shared
+		ApprovalSimulation: p.ApprovalSimulation,
+		TxSimulation:       p.TxSimulation,
 	}
 
 	if p.FromChain != nil {
@@ -167,5 +177,15 @@ func (p *Path) Copy() *Path {
 		newPath.RequiredNativeBalance = big.NewInt(0).Set(p.RequiredNativeBalance)
 	}
 
+	if p.Recipients != nil {
+		newPath.Recipients = make([]*pathprocessor.TransferRecipient, 0, len(p.Recipients))
+		for _, recipient := range p.Recipients {
+			newPath.Recipients = append(newPath.Recipients, &pathprocessor.TransferRecipient{
+				Address: recipient.Address,
+				Amount:  (*hexutil.Big)(big.NewInt(0).Set(recipient.Amount.ToInt())),
+			})
+		}
+	}
+
 	return newPath
 }
@@ -11,6 +11,8 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/status-im/status-go/params"
 	"github.com/status-im/status-go/services/wallet/router/fees"
+	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
+	"github.com/status-im/status-go/services/wallet/simulation"
 	"github.com/status-im/status-go/services/wallet/token"
 )
 
@@ -51,6 +53,11 @@ func TestCopyPath(t *testing.T) {
 		RequiredTokenBalance:    big.NewInt(100),
 		RequiredNativeBalance:   big.NewInt(100),
 		SubtractFees:            true,
+		Recipients: []*pathprocessor.TransferRecipient{
+			{Address: common.HexToAddress("0x456"), Amount: (*hexutil.Big)(big.NewInt(100))},
+		},
+		ApprovalSimulation: &simulation.Result{Traced: true},
+		TxSimulation:       &simulation.Result{Reverted: true, RevertReason: "reason"},
 	}
 
 	newPath := path.Copy()
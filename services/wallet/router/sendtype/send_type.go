@@ -14,6 +14,9 @@ import (
 	"github.com/status-im/status-go/services/wallet/token"
 )
 
+// PricesCurrency is the currency of the prices used to compare the routes
+const PricesCurrency = "USD"
+
 type SendType int
 
 const (
@@ -26,6 +29,8 @@ const (
 	ERC721Transfer
 	ERC1155Transfer
 	Swap
+	SmartAccountTransfer
+	BatchTransfer
 )
 
 func (s SendType) IsCollectiblesTransfer() bool {
@@ -40,23 +45,24 @@ func (s SendType) IsStickersTransfer() bool {
 	return s == StickersBuy
 }
 
-func (s SendType) FetchPrices(marketManager *market.Manager, tokenIDs []string) (map[string]float64, error) {
-	nonUniqueSymbols := append(tokenIDs, "ETH")
+// FetchPrices fetches the prices of the tokens and of the native tokens of the chains, the fees are paid in the latter
+func (s SendType) FetchPrices(marketManager *market.Manager, tokenIDs []string, nativeSymbols []string) (map[string]float64, error) {
+	nonUniqueSymbols := append([]string{"ETH"}, nativeSymbols...)
+	if !s.IsCollectiblesTransfer() {
+		nonUniqueSymbols = append(nonUniqueSymbols, tokenIDs...)
+	}
 	// remove duplicate enteries
 	slices.Sort(nonUniqueSymbols)
 	symbols := slices.Compact(nonUniqueSymbols)
-	if s.IsCollectiblesTransfer() {
-		symbols = []string{"ETH"}
-	}
 
-	pricesMap, err := marketManager.GetOrFetchPrices(symbols, []string{"USD"}, market.MaxAgeInSecondsForFresh)
+	pricesMap, err := marketManager.GetOrFetchPrices(symbols, []string{PricesCurrency}, market.MaxAgeInSecondsForFresh)
 
 	if err != nil {
 		return nil, err
 	}
 	prices := make(map[string]float64, 0)
 	for symbol, pricePerCurrency := range pricesMap {
-		prices[symbol] = pricePerCurrency["USD"].Price
+		prices[symbol] = pricePerCurrency[PricesCurrency].Price
 	}
 	if s.IsCollectiblesTransfer() {
 		for _, tokenID := range tokenIDs {
@@ -113,6 +119,11 @@ func (s SendType) CanUseProcessor(p pathprocessor.PathProcessor) bool {
 		return pathProcessorName == pathprocessor.ProcessorENSPublicKeyName
 	case StickersBuy:
 		return pathProcessorName == pathprocessor.ProcessorStickersBuyName
+	case SmartAccountTransfer:
+		return pathProcessorName == pathprocessor.ProcessorSmartAccountTransferName
+	case BatchTransfer:
+		return pathProcessorName == pathprocessor.ProcessorTransferName ||
+			pathProcessorName == pathprocessor.ProcessorDisperseName
 	default:
 		return true
 	}
@@ -140,7 +151,9 @@ func (s SendType) IsAvailableBetween(from, to *params.Network) bool {
 	if s.IsCollectiblesTransfer() ||
 		s.IsEnsTransfer() ||
 		s.IsStickersTransfer() ||
-		s == Swap {
+		s == Swap ||
+		s == SmartAccountTransfer ||
+		s == BatchTransfer {
 		return from.ChainID == to.ChainID
 	}
 
@@ -175,7 +188,7 @@ func (s SendType) IsAvailableFor(network *params.Network) bool {
 	}
 
 	// Check for any SendType available for all networks
-	if s == Transfer || s == Bridge || s.IsCollectiblesTransfer() || allAllowedNetworks[network.ChainID] {
+	if s == Transfer || s == Bridge || s == SmartAccountTransfer || s == BatchTransfer || s.IsCollectiblesTransfer() || allAllowedNetworks[network.ChainID] {
 		return true
 	}
 
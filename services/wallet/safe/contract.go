@@ -0,0 +1,112 @@
+package safe
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// safeABI is the part of the Safe singleton ABI used by the wallet, it's the same for all the supported versions
+const safeABI = `[
+	{"inputs":[],"name":"VERSION","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"getOwners","outputs":[{"name":"","type":"address[]"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"getThreshold","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"nonce","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},{"name":"operation","type":"uint8"},{"name":"safeTxGas","type":"uint256"},{"name":"baseGas","type":"uint256"},{"name":"gasPrice","type":"uint256"},{"name":"gasToken","type":"address"},{"name":"refundReceiver","type":"address"},{"name":"signatures","type":"bytes"}],"name":"execTransaction","outputs":[{"name":"success","type":"bool"}],"stateMutability":"payable","type":"function"}
+]`
+
+var parsedSafeABI, _ = abi.JSON(strings.NewReader(safeABI))
+
+// Safe is a Safe contract account with its on-chain configuration
+type Safe struct {
+	ChainID   uint64           `json:"chainId"`
+	Address   common.Address   `json:"address"`
+	Version   string           `json:"version"`
+	Owners    []common.Address `json:"owners"`
+	Threshold uint64           `json:"threshold"`
+	Nonce     uint64           `json:"nonce"`
+	UpdatedAt int64            `json:"updatedAt"`
+}
+
+func (s *Safe) IsOwner(address common.Address) bool {
+	for _, owner := range s.Owners {
+		if owner == address {
+			return true
+		}
+	}
+	return false
+}
+
+func callSafe(ctx context.Context, caller bind.ContractCaller, safe common.Address, method string) ([]interface{}, error) {
+	input, err := parsedSafeABI.Pack(method)
+	if err != nil {
+		return nil, err
+	}
+	output, err := caller.CallContract(ctx, ethereum.CallMsg{To: &safe, Data: input}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return parsedSafeABI.Unpack(method, output)
+}
+
+// readSafe reads the configuration of the Safe deployed at the address
+func readSafe(ctx context.Context, caller bind.ContractCaller, chainID uint64, address common.Address) (*Safe, error) {
+	code, err := caller.CodeAt(ctx, address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) == 0 {
+		return nil, ErrNotASafe
+	}
+
+	values, err := callSafe(ctx, caller, address, "getThreshold")
+	if err != nil || len(values) == 0 {
+		return nil, ErrNotASafe
+	}
+	threshold := values[0].(*big.Int)
+
+	values, err = callSafe(ctx, caller, address, "VERSION")
+	if err != nil {
+		return nil, err
+	}
+	version := values[0].(string)
+	if _, err = domainHasChainID(version); err != nil {
+		return nil, err
+	}
+
+	values, err = callSafe(ctx, caller, address, "getOwners")
+	if err != nil {
+		return nil, err
+	}
+	owners := values[0].([]common.Address)
+
+	values, err = callSafe(ctx, caller, address, "nonce")
+	if err != nil {
+		return nil, err
+	}
+	nonce := values[0].(*big.Int)
+
+	return &Safe{
+		ChainID:   chainID,
+		Address:   address,
+		Version:   version,
+		Owners:    owners,
+		Threshold: threshold.Uint64(),
+		Nonce:     nonce.Uint64(),
+	}, nil
+}
+
+// packExecTransaction returns the data of the tx executing the Safe transaction with the given signatures
+func packExecTransaction(tx *Transaction, signatures []byte) ([]byte, error) {
+	data := tx.Data
+	if data == nil {
+		data = []byte{}
+	}
+	return parsedSafeABI.Pack("execTransaction", tx.To, tx.Value.ToInt(), []byte(data), uint8(tx.Operation), tx.SafeTxGas.ToInt(),
+		tx.BaseGas.ToInt(), tx.GasPrice.ToInt(), tx.GasToken, tx.RefundReceiver, signatures)
+}
@@ -0,0 +1,21 @@
+package safe
+
+import (
+	"github.com/status-im/status-go/errors"
+)
+
+// Abbreviation `WSF` for the error code stands for Wallet Safe
+var (
+	ErrNotASafe               = &errors.ErrorResponse{Code: errors.ErrorCode("WSF-001"), Details: "the address is not a Safe contract"}
+	ErrUnsupportedSafeVersion = &errors.ErrorResponse{Code: errors.ErrorCode("WSF-002"), Details: "the Safe version is not supported"}
+	ErrSafeNotFound           = &errors.ErrorResponse{Code: errors.ErrorCode("WSF-003"), Details: "the Safe is not imported"}
+	ErrTransactionNotFound    = &errors.ErrorResponse{Code: errors.ErrorCode("WSF-004"), Details: "Safe transaction not found"}
+	ErrTransactionNotPending  = &errors.ErrorResponse{Code: errors.ErrorCode("WSF-005"), Details: "the Safe transaction is not pending"}
+	ErrNotAnOwner             = &errors.ErrorResponse{Code: errors.ErrorCode("WSF-006"), Details: "the account is not an owner of the Safe"}
+	ErrInvalidSignature       = &errors.ErrorResponse{Code: errors.ErrorCode("WSF-007"), Details: "the signature wasn't made by the owner"}
+	ErrHashMismatch           = &errors.ErrorResponse{Code: errors.ErrorCode("WSF-008"), Details: "the Safe transaction hash doesn't match the transaction"}
+	ErrThresholdNotMet        = &errors.ErrorResponse{Code: errors.ErrorCode("WSF-009"), Details: "the Safe transaction doesn't have enough confirmations"}
+	ErrTxServiceNotSupported  = &errors.ErrorResponse{Code: errors.ErrorCode("WSF-010"), Details: "no Safe transaction service for the chain"}
+	ErrTransactionNotNext     = &errors.ErrorResponse{Code: errors.ErrorCode("WSF-011"), Details: "only the Safe transaction with the current Safe nonce can be executed"}
+	ErrNonceTaken             = &errors.ErrorResponse{Code: errors.ErrorCode("WSF-012"), Details: "another Safe transaction was proposed with the nonce while signing"}
+)
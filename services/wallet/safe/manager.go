@@ -0,0 +1,756 @@
+package safe
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/status-im/status-go/account"
+	gocommon "github.com/status-im/status-go/common"
+	ethTypes "github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+	"github.com/status-im/status-go/transactions"
+)
+
+const (
+	// EventSafeTransactionUpdated is sent when a Safe transaction is proposed, confirmed or executed
+	EventSafeTransactionUpdated walletevent.EventType = "wallet-safe-transaction-updated"
+
+	checkInterval = 5 * time.Minute
+)
+
+// ContractCallerProvider returns the caller used to read the Safe contracts of the chain
+type ContractCallerProvider func(chainID uint64) (bind.ContractCaller, error)
+
+// Manager keeps the transactions of the imported Safes, from their proposal to their execution. The confirmations of
+// the co-signers are exchanged through a Safe transaction service or imported from the shared transactions.
+type Manager struct {
+	rpcClient      rpc.ClientInterface
+	contractCaller ContractCallerProvider
+	transactor     transactions.TransactorIface
+	persistence    *Persistence
+	txService      *TxServiceClient
+	walletFeed     *event.Feed
+	cancelFn       context.CancelFunc
+	mu             sync.Mutex
+	now            func() time.Time
+}
+
+func NewManager(db *sql.DB, rpcClient rpc.ClientInterface, transactor transactions.TransactorIface, walletFeed *event.Feed) *Manager {
+	return &Manager{
+		rpcClient: rpcClient,
+		contractCaller: func(chainID uint64) (bind.ContractCaller, error) {
+			return rpcClient.EthClient(chainID)
+		},
+		transactor:  transactor,
+		persistence: NewPersistence(db),
+		txService:   NewTxServiceClient(),
+		walletFeed:  walletFeed,
+		now:         time.Now,
+	}
+}
+
+func (m *Manager) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelFn = cancel
+
+	go func() {
+		defer gocommon.LogOnPanic()
+		m.checkSafes(ctx)
+
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.checkSafes(ctx)
+			}
+		}
+	}()
+}
+
+func (m *Manager) Stop() {
+	if m.cancelFn != nil {
+		m.cancelFn()
+	}
+}
+
+// SetTxServiceURL sets the Safe transaction service used for the chain, e.g. a self-hosted one
+func (m *Manager) SetTxServiceURL(chainID uint64, serviceURL string) {
+	m.txService.SetURL(chainID, serviceURL)
+}
+
+// ImportSafe reads the configuration of the Safe deployed at the address and starts tracking its transactions,
+// the account itself is added with the `multisig` type
+func (m *Manager) ImportSafe(ctx context.Context, chainID uint64, address common.Address) (*Safe, error) {
+	caller, err := m.contractCaller(chainID)
+	if err != nil {
+		return nil, err
+	}
+	safe, err := readSafe(ctx, caller, chainID, address)
+	if err != nil {
+		return nil, err
+	}
+	safe.UpdatedAt = m.now().Unix()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	err = m.persistence.UpsertSafe(safe)
+	if err != nil {
+		return nil, err
+	}
+	return safe, nil
+}
+
+// RemoveSafe stops tracking the Safe and removes its transactions
+func (m *Manager) RemoveSafe(chainID uint64, address common.Address) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.persistence.DeleteSafe(chainID, address)
+}
+
+// GetSafes returns the imported Safes with the given address on all chains, all of them if the address is not set
+func (m *Manager) GetSafes(address *common.Address) ([]*Safe, error) {
+	return m.persistence.GetSafes(address)
+}
+
+// RefreshSafe reads the current owners, threshold and nonce of the Safe and updates the status of its transactions
+func (m *Manager) RefreshSafe(ctx context.Context, chainID uint64, address common.Address) (*Safe, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.refreshSafe(ctx, chainID, address)
+}
+
+func (m *Manager) refreshSafe(ctx context.Context, chainID uint64, address common.Address) (*Safe, error) {
+	_, err := m.persistence.GetSafe(chainID, address)
+	if err != nil {
+		return nil, err
+	}
+
+	caller, err := m.contractCaller(chainID)
+	if err != nil {
+		return nil, err
+	}
+	safe, err := readSafe(ctx, caller, chainID, address)
+	if err != nil {
+		return nil, err
+	}
+	safe.UpdatedAt = m.now().Unix()
+
+	err = m.persistence.UpsertSafe(safe)
+	if err != nil {
+		return nil, err
+	}
+	return safe, m.updateTransactions(ctx, safe)
+}
+
+func (m *Manager) GetTransaction(safeTxHash common.Hash) (*Transaction, error) {
+	return m.persistence.GetTransaction(safeTxHash)
+}
+
+// GetTransactions returns the transactions of the Safe ordered by nonce
+func (m *Manager) GetTransactions(chainID uint64, safe common.Address, pendingOnly bool) ([]*Transaction, error) {
+	return m.persistence.GetTransactions(chainID, safe, pendingOnly)
+}
+
+// nextNonce returns the nonce of a new transaction, after the pending ones
+func (m *Manager) nextNonce(safe *Safe) (uint64, error) {
+	maxNonce, found, err := m.persistence.GetMaxNonce(safe.ChainID, safe.Address)
+	if err != nil {
+		return 0, err
+	}
+	if found && maxNonce >= safe.Nonce {
+		return maxNonce + 1, nil
+	}
+	return safe.Nonce, nil
+}
+
+func (m *Manager) sign(ctx context.Context, tx *Transaction, owner *account.SelectedExtKey) (Confirmation, error) {
+	safe, err := m.persistence.GetSafe(tx.ChainID, tx.Safe)
+	if err != nil {
+		return Confirmation{}, err
+	}
+	signature, err := SignTransaction(ctx, owner.Signer(), common.Address(owner.Address), tx, safe.Version)
+	if err != nil {
+		return Confirmation{}, err
+	}
+	return Confirmation{
+		Owner:     common.Address(owner.Address),
+		Signature: signature,
+		CreatedAt: m.now().Unix(),
+	}, nil
+}
+
+// ProposeTransaction creates the Safe transaction executing the call, confirmed by the proposer. The transaction is
+// published to the Safe transaction service if `publish` is set
+func (m *Manager) ProposeTransaction(ctx context.Context, chainID uint64, safeAddress common.Address, call Call,
+	proposer *account.SelectedExtKey, publish bool) (*Transaction, error) {
+	tx, err := m.proposeTransaction(ctx, chainID, safeAddress, call, proposer)
+	if err != nil {
+		return nil, err
+	}
+	m.sendUpdated(tx)
+
+	if publish {
+		err = m.txService.ProposeTransaction(ctx, tx, tx.Confirmations[0])
+		if err != nil {
+			return tx, err
+		}
+	}
+	return tx, nil
+}
+
+// proposeTransaction signs without holding the lock, the owner may take minutes to sign on a hardware wallet
+func (m *Manager) proposeTransaction(ctx context.Context, chainID uint64, safeAddress common.Address, call Call,
+	proposer *account.SelectedExtKey) (*Transaction, error) {
+	tx, err := m.newProposedTransaction(ctx, chainID, safeAddress, call, common.Address(proposer.Address))
+	if err != nil {
+		return nil, err
+	}
+
+	confirmation, err := m.sign(ctx, tx, proposer)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	safe, err := m.persistence.GetSafe(chainID, safeAddress)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := m.nextNonce(safe)
+	if err != nil {
+		return nil, err
+	}
+	if nonce != tx.Nonce {
+		return nil, ErrNonceTaken
+	}
+
+	tx.Confirmations = []Confirmation{confirmation}
+	tx.CreatedAt = m.now().Unix()
+	tx.UpdatedAt = tx.CreatedAt
+
+	_, err = m.persistence.InsertTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// newProposedTransaction creates the unsigned transaction with the next nonce of the Safe
+func (m *Manager) newProposedTransaction(ctx context.Context, chainID uint64, safeAddress common.Address, call Call,
+	proposer common.Address) (*Transaction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	safe, err := m.refreshSafe(ctx, chainID, safeAddress)
+	if err != nil {
+		return nil, err
+	}
+	if !safe.IsOwner(proposer) {
+		return nil, ErrNotAnOwner
+	}
+
+	nonce, err := m.nextNonce(safe)
+	if err != nil {
+		return nil, err
+	}
+	return newTransaction(chainID, safe, call, nonce, proposer)
+}
+
+// ConfirmTransaction adds the confirmation of a local owner account, published to the Safe transaction service if
+// `publish` is set
+func (m *Manager) ConfirmTransaction(ctx context.Context, safeTxHash common.Hash, owner *account.SelectedExtKey, publish bool) (*Transaction, error) {
+	tx, confirmation, err := m.confirmTransaction(ctx, safeTxHash, owner)
+	if err != nil {
+		return nil, err
+	}
+	m.sendUpdated(tx)
+
+	if publish {
+		err = m.txService.ConfirmTransaction(ctx, tx.ChainID, tx.SafeTxHash, confirmation)
+		if err != nil {
+			return tx, err
+		}
+	}
+	return tx, nil
+}
+
+// confirmTransaction signs without holding the lock, the owner may take minutes to sign on a hardware wallet
+func (m *Manager) confirmTransaction(ctx context.Context, safeTxHash common.Hash, owner *account.SelectedExtKey) (*Transaction, Confirmation, error) {
+	tx, confirmation, err := m.ownerConfirmation(safeTxHash, common.Address(owner.Address))
+	if err != nil {
+		return nil, Confirmation{}, err
+	}
+	if confirmation != nil {
+		return tx, *confirmation, nil
+	}
+
+	signed, err := m.sign(ctx, tx, owner)
+	if err != nil {
+		return nil, Confirmation{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// the transaction may have been executed or replaced while signing
+	tx, _, err = m.pendingTransaction(safeTxHash)
+	if err != nil {
+		return nil, Confirmation{}, err
+	}
+	tx, err = m.addConfirmation(tx, signed)
+	return tx, signed, err
+}
+
+// ownerConfirmation returns the pending transaction and the confirmation of the owner if it was already added
+func (m *Manager) ownerConfirmation(safeTxHash common.Hash, owner common.Address) (*Transaction, *Confirmation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tx, safe, err := m.pendingTransaction(safeTxHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !safe.IsOwner(owner) {
+		return nil, nil, ErrNotAnOwner
+	}
+	return tx, tx.confirmedBy(owner), nil
+}
+
+// AddConfirmation adds the confirmation of a co-signer, e.g. received in a Status message
+func (m *Manager) AddConfirmation(safeTxHash common.Hash, owner common.Address, signature hexutil.Bytes) (*Transaction, error) {
+	tx, err := m.addCoSignerConfirmation(safeTxHash, owner, signature)
+	if err != nil {
+		return nil, err
+	}
+	m.sendUpdated(tx)
+	return tx, nil
+}
+
+func (m *Manager) addCoSignerConfirmation(safeTxHash common.Hash, owner common.Address, signature hexutil.Bytes) (*Transaction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tx, safe, err := m.pendingTransaction(safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+	confirmation := Confirmation{
+		Owner:     owner,
+		Signature: signature,
+		CreatedAt: m.now().Unix(),
+	}
+	err = verifyConfirmation(safe, tx.SafeTxHash, confirmation)
+	if err != nil {
+		return nil, err
+	}
+	return m.addConfirmation(tx, confirmation)
+}
+
+func (m *Manager) addConfirmation(tx *Transaction, confirmation Confirmation) (*Transaction, error) {
+	added, err := m.persistence.AddConfirmation(tx.SafeTxHash, confirmation)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return tx, nil
+	}
+	return m.persistence.GetTransaction(tx.SafeTxHash)
+}
+
+func verifyConfirmation(safe *Safe, safeTxHash common.Hash, c Confirmation) error {
+	if !safe.IsOwner(c.Owner) {
+		return ErrNotAnOwner
+	}
+	signer, err := RecoverOwner(safeTxHash, c.Signature)
+	if err != nil {
+		return err
+	}
+	if signer != c.Owner {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (m *Manager) pendingTransaction(safeTxHash common.Hash) (*Transaction, *Safe, error) {
+	tx, err := m.persistence.GetTransaction(safeTxHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tx.Status != StatusPending {
+		return nil, nil, ErrTransactionNotPending
+	}
+	safe, err := m.persistence.GetSafe(tx.ChainID, tx.Safe)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, safe, nil
+}
+
+// ImportTransaction stores a transaction shared by a co-signer, e.g. in a Status message, or merges its confirmations
+// if the transaction is already known. The transaction hash and the confirmations are verified against the Safe.
+func (m *Manager) ImportTransaction(shared *Transaction) (*Transaction, error) {
+	tx, changed, err := m.importTransaction(shared)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		m.sendUpdated(tx)
+	}
+	return tx, nil
+}
+
+func (m *Manager) importTransaction(shared *Transaction) (*Transaction, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	safe, err := m.persistence.GetSafe(shared.ChainID, shared.Safe)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, value := range []**hexutil.Big{&shared.Value, &shared.SafeTxGas, &shared.BaseGas, &shared.GasPrice} {
+		if *value == nil {
+			*value = (*hexutil.Big)(big.NewInt(0))
+		}
+	}
+	hash, err := shared.Hash(safe.Version)
+	if err != nil {
+		return nil, false, err
+	}
+	if hash != shared.SafeTxHash {
+		return nil, false, ErrHashMismatch
+	}
+
+	confirmations := make([]Confirmation, 0, len(shared.Confirmations))
+	for _, c := range shared.Confirmations {
+		err = verifyConfirmation(safe, hash, c)
+		if err != nil {
+			log.Warn("safe: ignoring confirmation", "safeTxHash", hash, "owner", c.Owner, "err", err)
+			continue
+		}
+		if c.CreatedAt == 0 {
+			c.CreatedAt = m.now().Unix()
+		}
+		confirmations = append(confirmations, c)
+	}
+
+	tx := &Transaction{
+		SafeTxHash:     hash,
+		ChainID:        shared.ChainID,
+		Safe:           shared.Safe,
+		To:             shared.To,
+		Value:          shared.Value,
+		Data:           shared.Data,
+		Operation:      shared.Operation,
+		SafeTxGas:      shared.SafeTxGas,
+		BaseGas:        shared.BaseGas,
+		GasPrice:       shared.GasPrice,
+		GasToken:       shared.GasToken,
+		RefundReceiver: shared.RefundReceiver,
+		Nonce:          shared.Nonce,
+		Proposer:       shared.Proposer,
+		Status:         StatusPending,
+		Confirmations:  confirmations,
+		CreatedAt:      m.now().Unix(),
+	}
+	tx.UpdatedAt = tx.CreatedAt
+	if shared.Nonce < safe.Nonce {
+		tx.Status = StatusReplaced
+	}
+
+	_, err = m.persistence.GetTransaction(hash)
+	if err == ErrTransactionNotFound {
+		_, err = m.persistence.InsertTransaction(tx)
+		if err != nil {
+			return nil, false, err
+		}
+		tx, err = m.persistence.GetTransaction(hash)
+		return tx, true, err
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	// the transaction is known, only the new confirmations are added
+	changed := false
+	for _, c := range tx.Confirmations {
+		added, err := m.persistence.AddConfirmation(hash, c)
+		if err != nil {
+			return nil, false, err
+		}
+		changed = changed || added
+	}
+	tx, err = m.persistence.GetTransaction(hash)
+	return tx, changed, err
+}
+
+// SyncWithTxService imports the pending transactions of the Safe and their confirmations from the Safe transaction service
+func (m *Manager) SyncWithTxService(ctx context.Context, chainID uint64, safeAddress common.Address) ([]*Transaction, error) {
+	safe, err := m.RefreshSafe(ctx, chainID, safeAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := m.txService.GetPendingTransactions(ctx, chainID, safeAddress, safe.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range txs {
+		_, err = m.ImportTransaction(tx)
+		if err != nil {
+			log.Warn("safe: failed to import transaction from the transaction service", "safeTxHash", tx.SafeTxHash, "err", err)
+		}
+	}
+
+	return m.persistence.GetTransactions(chainID, safeAddress, true)
+}
+
+// PublishTransaction publishes a transaction and its confirmations to the Safe transaction service, e.g. one received
+// in a Status message or one that failed to be published when proposed
+func (m *Manager) PublishTransaction(ctx context.Context, safeTxHash common.Hash) error {
+	tx, err := m.persistence.GetTransaction(safeTxHash)
+	if err != nil {
+		return err
+	}
+	if len(tx.Confirmations) == 0 {
+		return ErrInvalidSignature
+	}
+
+	proposer := tx.confirmedBy(tx.Proposer)
+	if proposer == nil {
+		proposer = &tx.Confirmations[0]
+	}
+	err = m.txService.ProposeTransaction(ctx, tx, *proposer)
+	if err != nil {
+		return err
+	}
+	for _, c := range tx.Confirmations {
+		if c.Owner == proposer.Owner {
+			continue
+		}
+		err = m.txService.ConfirmTransaction(ctx, tx.ChainID, tx.SafeTxHash, c)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExecTxArgs returns the args of the tx executing the Safe transaction, to be used with `BuildTransaction`. The executor
+// doesn't have to be an owner, if it is, its confirmation is not needed.
+func (m *Manager) ExecTxArgs(ctx context.Context, safeTxHash common.Hash, executor common.Address) (*transactions.SendTxArgs, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tx, err := m.persistence.GetTransaction(safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Status != StatusPending {
+		return nil, ErrTransactionNotPending
+	}
+	safe, err := m.refreshSafe(ctx, tx.ChainID, tx.Safe)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Nonce != safe.Nonce {
+		return nil, ErrTransactionNotNext
+	}
+
+	// the owners may have changed since the confirmations were collected
+	confirmations := make([]Confirmation, 0, len(tx.Confirmations)+1)
+	for _, c := range tx.Confirmations {
+		if safe.IsOwner(c.Owner) {
+			confirmations = append(confirmations, c)
+		}
+	}
+	if safe.IsOwner(executor) && tx.confirmedBy(executor) == nil {
+		confirmations = append(confirmations, Confirmation{Owner: executor, Signature: preValidatedSignature(executor)})
+	}
+	if uint64(len(confirmations)) < safe.Threshold {
+		return nil, ErrThresholdNotMet
+	}
+
+	data, err := packExecTransaction(tx, encodeSignatures(confirmations))
+	if err != nil {
+		return nil, err
+	}
+	to := ethTypes.Address(tx.Safe)
+	return &transactions.SendTxArgs{
+		From:  ethTypes.Address(executor),
+		To:    &to,
+		Value: (*hexutil.Big)(big.NewInt(0)),
+		Data:  data,
+	}, nil
+}
+
+// Execute sends the tx executing the Safe transaction
+func (m *Manager) Execute(ctx context.Context, safeTxHash common.Hash, executor *account.SelectedExtKey) (ethTypes.Hash, error) {
+	sendArgs, err := m.ExecTxArgs(ctx, safeTxHash, common.Address(executor.Address))
+	if err != nil {
+		return ethTypes.Hash{}, err
+	}
+	tx, err := m.persistence.GetTransaction(safeTxHash)
+	if err != nil {
+		return ethTypes.Hash{}, err
+	}
+
+	hash, _, err := m.transactor.SendTransactionWithChainID(tx.ChainID, *sendArgs, -1, executor)
+	if err != nil {
+		return ethTypes.Hash{}, err
+	}
+
+	_, err = m.SetExecutionTxHash(safeTxHash, common.Hash(hash))
+	return hash, err
+}
+
+// SetExecutionTxHash stores the hash of the execution tx sent with `SendTransactionWithSignature`, the transaction is
+// marked as executed once the tx is mined
+func (m *Manager) SetExecutionTxHash(safeTxHash common.Hash, hash common.Hash) (*Transaction, error) {
+	tx, err := m.setExecutionTxHash(safeTxHash, hash)
+	if err != nil {
+		return nil, err
+	}
+	m.sendUpdated(tx)
+	return tx, nil
+}
+
+func (m *Manager) setExecutionTxHash(safeTxHash common.Hash, hash common.Hash) (*Transaction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tx, err := m.persistence.GetTransaction(safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Status != StatusPending {
+		return nil, ErrTransactionNotPending
+	}
+
+	tx.Status = StatusExecuting
+	tx.ExecutionTxHash = hash
+	tx.UpdatedAt = m.now().Unix()
+	err = m.persistence.UpdateTransactionStatus(tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// receipt returns the receipt of a mined tx, nil if the tx is still pending
+func (m *Manager) receipt(ctx context.Context, chainID uint64, hash common.Hash) (*types.Receipt, error) {
+	client, err := m.rpcClient.EthClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := client.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	return receipt, err
+}
+
+// updateTransactions updates the status of the pending transactions of the Safe from its current nonce and the
+// receipts of the execution txs
+func (m *Manager) updateTransactions(ctx context.Context, safe *Safe) error {
+	txs, err := m.persistence.GetTransactions(safe.ChainID, safe.Address, true)
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range txs {
+		status, executionTxHash := tx.Status, tx.ExecutionTxHash
+		if tx.Status == StatusExecuting {
+			receipt, err := m.receipt(ctx, tx.ChainID, tx.ExecutionTxHash)
+			if err != nil {
+				log.Warn("safe: failed to get the execution receipt", "safeTxHash", tx.SafeTxHash, "err", err)
+				continue
+			}
+			if receipt != nil {
+				if receipt.Status == types.ReceiptStatusSuccessful {
+					tx.Status = StatusExecuted
+				} else {
+					tx.Status = StatusPending
+					tx.ExecutionTxHash = common.Hash{}
+				}
+			}
+		}
+		if tx.Status == StatusPending && tx.Nonce < safe.Nonce {
+			tx.Status = StatusReplaced
+		}
+
+		if tx.Status == status && tx.ExecutionTxHash == executionTxHash {
+			continue
+		}
+		tx.UpdatedAt = m.now().Unix()
+		err = m.persistence.UpdateTransactionStatus(tx)
+		if err != nil {
+			return err
+		}
+		m.sendUpdated(tx)
+	}
+	return nil
+}
+
+// checkSafes refreshes the Safes with pending transactions
+func (m *Manager) checkSafes(ctx context.Context) {
+	safes, err := m.persistence.GetSafes(nil)
+	if err != nil {
+		log.Error("safe: failed to get safes", "err", err)
+		return
+	}
+
+	for _, safe := range safes {
+		if ctx.Err() != nil {
+			return
+		}
+		txs, err := m.persistence.GetTransactions(safe.ChainID, safe.Address, true)
+		if err != nil || len(txs) == 0 {
+			continue
+		}
+		_, err = m.RefreshSafe(ctx, safe.ChainID, safe.Address)
+		if err != nil {
+			log.Warn("safe: failed to refresh safe", "chainID", safe.ChainID, "address", safe.Address, "err", err)
+		}
+	}
+}
+
+func (m *Manager) sendUpdated(tx *Transaction) {
+	if m.walletFeed == nil {
+		return
+	}
+
+	message, err := json.Marshal(tx)
+	if err != nil {
+		log.Error("safe: failed to marshal transaction", "safeTxHash", tx.SafeTxHash, "err", err)
+		return
+	}
+
+	m.walletFeed.Send(walletevent.Event{
+		Type:     EventSafeTransactionUpdated,
+		Accounts: []common.Address{tx.Safe},
+		ChainID:  tx.ChainID,
+		Message:  string(message),
+		At:       m.now().Unix(),
+	})
+}
@@ -0,0 +1,255 @@
+package safe
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/eth-node/types"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+const testChainID = uint64(10)
+
+var testSafeAddress = common.HexToAddress("0x5afe")
+
+// fakeSafeCaller answers the calls to the Safe deployed at testSafeAddress
+type fakeSafeCaller struct {
+	owners    []common.Address
+	threshold int64
+	nonce     int64
+}
+
+func (f *fakeSafeCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	if contract != testSafeAddress {
+		return nil, nil
+	}
+	return []byte{0x1}, nil
+}
+
+func (f *fakeSafeCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	method, err := parsedSafeABI.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "VERSION":
+		return method.Outputs.Pack("1.3.0")
+	case "getOwners":
+		return method.Outputs.Pack(f.owners)
+	case "getThreshold":
+		return method.Outputs.Pack(big.NewInt(f.threshold))
+	case "nonce":
+		return method.Outputs.Pack(big.NewInt(f.nonce))
+	}
+	return nil, errors.New("execution reverted")
+}
+
+type testOwner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+func (o testOwner) selectedKey() *account.SelectedExtKey {
+	return &account.SelectedExtKey{
+		Address:    types.Address(o.address),
+		AccountKey: &types.Key{Address: types.Address(o.address), PrivateKey: o.key},
+	}
+}
+
+func newTestOwners(t *testing.T, count int) []testOwner {
+	owners := make([]testOwner, 0, count)
+	for i := 0; i < count; i++ {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		owners = append(owners, testOwner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)})
+	}
+	return owners
+}
+
+func setupTestManager(t *testing.T, caller *fakeSafeCaller) *Manager {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	manager := NewManager(db, nil, nil, nil)
+	manager.contractCaller = func(chainID uint64) (bind.ContractCaller, error) {
+		return caller, nil
+	}
+	manager.now = func() time.Time {
+		return time.Unix(1000, 0)
+	}
+	return manager
+}
+
+func TestImportSafe(t *testing.T) {
+	owners := newTestOwners(t, 2)
+	manager := setupTestManager(t, &fakeSafeCaller{owners: []common.Address{owners[0].address, owners[1].address}, threshold: 2, nonce: 3})
+
+	safe, err := manager.ImportSafe(context.Background(), testChainID, testSafeAddress)
+	require.NoError(t, err)
+	require.Equal(t, "1.3.0", safe.Version)
+	require.Equal(t, uint64(2), safe.Threshold)
+	require.Equal(t, uint64(3), safe.Nonce)
+
+	safes, err := manager.GetSafes(&testSafeAddress)
+	require.NoError(t, err)
+	require.Equal(t, []*Safe{safe}, safes)
+
+	_, err = manager.ImportSafe(context.Background(), testChainID, common.HexToAddress("0x1234"))
+	require.ErrorIs(t, err, ErrNotASafe)
+
+	err = manager.RemoveSafe(testChainID, testSafeAddress)
+	require.NoError(t, err)
+	safes, err = manager.GetSafes(nil)
+	require.NoError(t, err)
+	require.Len(t, safes, 0)
+}
+
+func TestSafeTransactionFlow(t *testing.T) {
+	ctx := context.Background()
+	owners := newTestOwners(t, 4)
+	caller := &fakeSafeCaller{owners: []common.Address{owners[0].address, owners[1].address, owners[2].address}, threshold: 2, nonce: 5}
+	manager := setupTestManager(t, caller)
+	nonOwner := owners[3]
+
+	_, err := manager.ImportSafe(ctx, testChainID, testSafeAddress)
+	require.NoError(t, err)
+
+	call := Call{To: common.HexToAddress("0x1234"), Value: (*hexutil.Big)(big.NewInt(100))}
+	_, err = manager.ProposeTransaction(ctx, testChainID, testSafeAddress, call, nonOwner.selectedKey(), false)
+	require.ErrorIs(t, err, ErrNotAnOwner)
+
+	tx, err := manager.ProposeTransaction(ctx, testChainID, testSafeAddress, call, owners[0].selectedKey(), false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), tx.Nonce)
+	require.Equal(t, StatusPending, tx.Status)
+	require.Len(t, tx.Confirmations, 1)
+	require.Equal(t, owners[0].address, tx.Confirmations[0].Owner)
+
+	// the next proposal follows the pending one
+	nextTx, err := manager.ProposeTransaction(ctx, testChainID, testSafeAddress, call, owners[1].selectedKey(), false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), nextTx.Nonce)
+	_, err = manager.ExecTxArgs(ctx, nextTx.SafeTxHash, owners[1].address)
+	require.ErrorIs(t, err, ErrTransactionNotNext)
+
+	// one confirmation is missing, unless the executor is an owner
+	_, err = manager.ExecTxArgs(ctx, tx.SafeTxHash, nonOwner.address)
+	require.ErrorIs(t, err, ErrThresholdNotMet)
+	_, err = manager.ExecTxArgs(ctx, tx.SafeTxHash, owners[1].address)
+	require.NoError(t, err)
+
+	// the co-signer signature has to be made by the owner
+	signature, err := SignHash(tx.SafeTxHash, owners[2].key)
+	require.NoError(t, err)
+	_, err = manager.AddConfirmation(tx.SafeTxHash, owners[1].address, signature)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+	_, err = manager.AddConfirmation(tx.SafeTxHash, nonOwner.address, signature)
+	require.ErrorIs(t, err, ErrNotAnOwner)
+	tx, err = manager.AddConfirmation(tx.SafeTxHash, owners[2].address, signature)
+	require.NoError(t, err)
+	require.Len(t, tx.Confirmations, 2)
+
+	args, err := manager.ExecTxArgs(ctx, tx.SafeTxHash, nonOwner.address)
+	require.NoError(t, err)
+	require.Equal(t, types.Address(testSafeAddress), *args.To)
+	method, err := parsedSafeABI.MethodById(args.Data[:4])
+	require.NoError(t, err)
+	require.Equal(t, "execTransaction", method.Name)
+	values, err := method.Inputs.Unpack(args.Data[4:])
+	require.NoError(t, err)
+	require.Equal(t, call.To, values[0].(common.Address))
+	require.Equal(t, big.NewInt(100), values[1].(*big.Int))
+	require.Len(t, values[9].([]byte), 2*signatureLength)
+
+	// the nonce is used by a transaction executed outside of the wallet
+	caller.nonce = 6
+	_, err = manager.RefreshSafe(ctx, testChainID, testSafeAddress)
+	require.NoError(t, err)
+	tx, err = manager.GetTransaction(tx.SafeTxHash)
+	require.NoError(t, err)
+	require.Equal(t, StatusReplaced, tx.Status)
+
+	nextTx, err = manager.SetExecutionTxHash(nextTx.SafeTxHash, common.HexToHash("0xe"))
+	require.NoError(t, err)
+	require.Equal(t, StatusExecuting, nextTx.Status)
+	_, err = manager.ConfirmTransaction(ctx, nextTx.SafeTxHash, owners[2].selectedKey(), false)
+	require.ErrorIs(t, err, ErrTransactionNotPending)
+
+	pending, err := manager.GetTransactions(testChainID, testSafeAddress, true)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, nextTx.SafeTxHash, pending[0].SafeTxHash)
+}
+
+func TestImportSharedTransaction(t *testing.T) {
+	ctx := context.Background()
+	owners := newTestOwners(t, 3)
+	caller := &fakeSafeCaller{owners: []common.Address{owners[0].address, owners[1].address, owners[2].address}, threshold: 2, nonce: 0}
+	proposerManager := setupTestManager(t, caller)
+	coSignerManager := setupTestManager(t, caller)
+
+	for _, m := range []*Manager{proposerManager, coSignerManager} {
+		_, err := m.ImportSafe(ctx, testChainID, testSafeAddress)
+		require.NoError(t, err)
+	}
+
+	tx, err := proposerManager.ProposeTransaction(ctx, testChainID, testSafeAddress, Call{
+		To:   common.HexToAddress("0x1234"),
+		Data: hexutil.MustDecode("0xa9059cbb"),
+	}, owners[0].selectedKey(), false)
+	require.NoError(t, err)
+
+	// the transaction is shared as JSON, e.g. in a Status message
+	payload, err := json.Marshal(tx)
+	require.NoError(t, err)
+	var shared Transaction
+	require.NoError(t, json.Unmarshal(payload, &shared))
+
+	imported, err := coSignerManager.ImportTransaction(&shared)
+	require.NoError(t, err)
+	require.Equal(t, tx.SafeTxHash, imported.SafeTxHash)
+	require.Len(t, imported.Confirmations, 1)
+
+	imported, err = coSignerManager.ConfirmTransaction(ctx, tx.SafeTxHash, owners[1].selectedKey(), false)
+	require.NoError(t, err)
+	require.Len(t, imported.Confirmations, 2)
+
+	// the confirmation goes back to the proposer
+	payload, err = json.Marshal(imported)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(payload, &shared))
+	merged, err := proposerManager.ImportTransaction(&shared)
+	require.NoError(t, err)
+	require.Len(t, merged.Confirmations, 2)
+	_, err = proposerManager.ExecTxArgs(ctx, tx.SafeTxHash, owners[2].address)
+	require.NoError(t, err)
+
+	// forged confirmations are dropped
+	shared.Confirmations[1].Owner = owners[2].address
+	merged, err = proposerManager.ImportTransaction(&shared)
+	require.NoError(t, err)
+	require.Len(t, merged.Confirmations, 2)
+
+	// the hash has to match the transaction
+	shared.Value = (*hexutil.Big)(big.NewInt(1))
+	_, err = coSignerManager.ImportTransaction(&shared)
+	require.ErrorIs(t, err, ErrHashMismatch)
+}
@@ -0,0 +1,264 @@
+package safe
+
+import (
+	"database/sql"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type Persistence struct {
+	db *sql.DB
+}
+
+func NewPersistence(db *sql.DB) *Persistence {
+	return &Persistence{
+		db: db,
+	}
+}
+
+func (p *Persistence) UpsertSafe(s *Safe) error {
+	owners := make([]string, 0, len(s.Owners))
+	for _, owner := range s.Owners {
+		owners = append(owners, owner.Hex())
+	}
+
+	_, err := p.db.Exec(`INSERT OR REPLACE INTO safes (chain_id, address, version, owners, threshold, nonce, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`, s.ChainID, s.Address, s.Version, strings.Join(owners, ","), s.Threshold, s.Nonce, s.UpdatedAt)
+	return err
+}
+
+func (p *Persistence) GetSafe(chainID uint64, address common.Address) (*Safe, error) {
+	safes, err := p.querySafes("WHERE chain_id = ? AND address = ?", chainID, address)
+	if err != nil {
+		return nil, err
+	}
+	if len(safes) == 0 {
+		return nil, ErrSafeNotFound
+	}
+	return safes[0], nil
+}
+
+// GetSafes returns the Safes with the given address on all chains, all the imported Safes if the address is not set
+func (p *Persistence) GetSafes(address *common.Address) ([]*Safe, error) {
+	if address == nil {
+		return p.querySafes("")
+	}
+	return p.querySafes("WHERE address = ?", *address)
+}
+
+func (p *Persistence) DeleteSafe(chainID uint64, address common.Address) error {
+	_, err := p.db.Exec(`DELETE FROM safe_confirmations WHERE safe_tx_hash IN
+		(SELECT safe_tx_hash FROM safe_transactions WHERE chain_id = ? AND safe_address = ?)`, chainID, address)
+	if err != nil {
+		return err
+	}
+	_, err = p.db.Exec(`DELETE FROM safe_transactions WHERE chain_id = ? AND safe_address = ?`, chainID, address)
+	if err != nil {
+		return err
+	}
+	_, err = p.db.Exec(`DELETE FROM safes WHERE chain_id = ? AND address = ?`, chainID, address)
+	return err
+}
+
+func (p *Persistence) querySafes(where string, args ...interface{}) ([]*Safe, error) {
+	rows, err := p.db.Query(`SELECT chain_id, address, version, owners, threshold, nonce, updated_at FROM safes `+where+
+		` ORDER BY chain_id, address`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	safes := make([]*Safe, 0)
+	for rows.Next() {
+		s := &Safe{}
+		var owners string
+		err := rows.Scan(&s.ChainID, &s.Address, &s.Version, &owners, &s.Threshold, &s.Nonce, &s.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		s.Owners = make([]common.Address, 0)
+		for _, owner := range strings.Split(owners, ",") {
+			if owner != "" {
+				s.Owners = append(s.Owners, common.HexToAddress(owner))
+			}
+		}
+		safes = append(safes, s)
+	}
+	return safes, rows.Err()
+}
+
+const transactionColumns = `safe_tx_hash, chain_id, safe_address, to_address, value, data, operation, safe_tx_gas, base_gas, gas_price,
+	gas_token, refund_receiver, nonce, proposer, status, execution_tx_hash, created_at, updated_at`
+
+func hexBigString(value *hexutil.Big) string {
+	if value == nil {
+		return "0x0"
+	}
+	return value.String()
+}
+
+// InsertTransaction stores the transaction and its confirmations, returns false if the transaction is already stored
+func (p *Persistence) InsertTransaction(tx *Transaction) (inserted bool, err error) {
+	res, err := p.db.Exec(`INSERT OR IGNORE INTO safe_transactions (`+transactionColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		tx.SafeTxHash, tx.ChainID, tx.Safe, tx.To, hexBigString(tx.Value), []byte(tx.Data), tx.Operation, hexBigString(tx.SafeTxGas),
+		hexBigString(tx.BaseGas), hexBigString(tx.GasPrice), tx.GasToken, tx.RefundReceiver, tx.Nonce, tx.Proposer, tx.Status,
+		tx.ExecutionTxHash, tx.CreatedAt, tx.UpdatedAt)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	for _, c := range tx.Confirmations {
+		_, err = p.AddConfirmation(tx.SafeTxHash, c)
+		if err != nil {
+			return false, err
+		}
+	}
+	return affected > 0, nil
+}
+
+// UpdateTransactionStatus stores the progress of the transaction execution
+func (p *Persistence) UpdateTransactionStatus(tx *Transaction) error {
+	res, err := p.db.Exec(`UPDATE safe_transactions SET status = ?, execution_tx_hash = ?, updated_at = ? WHERE safe_tx_hash = ?`,
+		tx.Status, tx.ExecutionTxHash, tx.UpdatedAt, tx.SafeTxHash)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrTransactionNotFound
+	}
+	return nil
+}
+
+// AddConfirmation stores the confirmation of the owner, returns false if the owner already confirmed the transaction
+func (p *Persistence) AddConfirmation(safeTxHash common.Hash, c Confirmation) (bool, error) {
+	res, err := p.db.Exec(`INSERT OR IGNORE INTO safe_confirmations (safe_tx_hash, owner, signature, created_at) VALUES (?, ?, ?, ?)`,
+		safeTxHash, c.Owner, []byte(c.Signature), c.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (p *Persistence) GetTransaction(safeTxHash common.Hash) (*Transaction, error) {
+	txs, err := p.queryTransactions("WHERE safe_tx_hash = ?", safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(txs) == 0 {
+		return nil, ErrTransactionNotFound
+	}
+	return txs[0], nil
+}
+
+// GetTransactions returns the transactions of the Safe ordered by nonce, only the ones not executed yet if `pendingOnly` is set
+func (p *Persistence) GetTransactions(chainID uint64, safe common.Address, pendingOnly bool) ([]*Transaction, error) {
+	where := "WHERE chain_id = ? AND safe_address = ?"
+	args := []interface{}{chainID, safe}
+	if pendingOnly {
+		where += " AND status IN (?, ?)"
+		args = append(args, StatusPending, StatusExecuting)
+	}
+	return p.queryTransactions(where, args...)
+}
+
+// GetMaxNonce returns the highest nonce of the pending transactions of the Safe, false if there is none
+func (p *Persistence) GetMaxNonce(chainID uint64, safe common.Address) (uint64, bool, error) {
+	var nonce sql.NullInt64
+	err := p.db.QueryRow(`SELECT MAX(nonce) FROM safe_transactions WHERE chain_id = ? AND safe_address = ? AND status IN (?, ?)`,
+		chainID, safe, StatusPending, StatusExecuting).Scan(&nonce)
+	if err != nil {
+		return 0, false, err
+	}
+	return uint64(nonce.Int64), nonce.Valid, nil
+}
+
+func parseHexBig(value string) (*hexutil.Big, error) {
+	parsed, ok := new(big.Int).SetString(value, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid Safe transaction value %s", value)
+	}
+	return (*hexutil.Big)(parsed), nil
+}
+
+func (p *Persistence) queryTransactions(where string, args ...interface{}) ([]*Transaction, error) {
+	rows, err := p.db.Query(`SELECT `+transactionColumns+` FROM safe_transactions `+where+` ORDER BY nonce, created_at`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txs := make([]*Transaction, 0)
+	for rows.Next() {
+		tx := &Transaction{}
+		var value, safeTxGas, baseGas, gasPrice string
+		var data []byte
+		err := rows.Scan(&tx.SafeTxHash, &tx.ChainID, &tx.Safe, &tx.To, &value, &data, &tx.Operation, &safeTxGas, &baseGas, &gasPrice,
+			&tx.GasToken, &tx.RefundReceiver, &tx.Nonce, &tx.Proposer, &tx.Status, &tx.ExecutionTxHash, &tx.CreatedAt, &tx.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		tx.Data = data
+
+		for _, v := range []struct {
+			src string
+			dst **hexutil.Big
+		}{{value, &tx.Value}, {safeTxGas, &tx.SafeTxGas}, {baseGas, &tx.BaseGas}, {gasPrice, &tx.GasPrice}} {
+			*v.dst, err = parseHexBig(v.src)
+			if err != nil {
+				return nil, err
+			}
+		}
+		txs = append(txs, tx)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, tx := range txs {
+		tx.Confirmations, err = p.getConfirmations(tx.SafeTxHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return txs, nil
+}
+
+func (p *Persistence) getConfirmations(safeTxHash common.Hash) ([]Confirmation, error) {
+	rows, err := p.db.Query(`SELECT owner, signature, created_at FROM safe_confirmations WHERE safe_tx_hash = ? ORDER BY created_at, owner`,
+		safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	confirmations := make([]Confirmation, 0)
+	for rows.Next() {
+		var c Confirmation
+		var signature []byte
+		err := rows.Scan(&c.Owner, &signature, &c.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		c.Signature = signature
+		confirmations = append(confirmations, c)
+	}
+	return confirmations, rows.Err()
+}
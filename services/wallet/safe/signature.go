@@ -0,0 +1,105 @@
+package safe
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/status-im/status-go/account/signer"
+)
+
+const signatureLength = 65
+
+// SignHash returns the signature of the Safe transaction hash in the format expected by the Safe, v is 27 or 28
+func SignHash(safeTxHash common.Hash, key *ecdsa.PrivateKey) ([]byte, error) {
+	sig, err := signer.SignHash(context.Background(), signer.NewKeySigner(key), crypto.PubkeyToAddress(key.PublicKey), safeTxHash)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// SignTransaction signs the Safe transaction with the signer of the owner, v is 27 or 28. The signers which don't sign
+// hashes, e.g. the external and hardware ones, sign the EIP-712 typed data of the transaction so the owner can review it.
+func SignTransaction(ctx context.Context, s signer.Signer, owner common.Address, tx *Transaction, version string) ([]byte, error) {
+	hash, err := tx.Hash(version)
+	if err != nil {
+		return nil, err
+	}
+	if hash != tx.SafeTxHash {
+		return nil, ErrHashMismatch
+	}
+
+	sig, err := signer.SignHash(ctx, s, owner, hash)
+	if errors.Is(err, signer.ErrHashSigningUnsupported) {
+		typedData, err := tx.TypedData(version)
+		if err != nil {
+			return nil, err
+		}
+		return s.SignTypedData(ctx, owner, typedData)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27
+	return sig, nil
+}
+
+// RecoverOwner returns the address of the owner that signed the Safe transaction hash. Besides the EIP-712 signatures,
+// the `eth_sign` ones made by some wallets are accepted, their v is increased by 4. The contract and pre-validated
+// signatures can't be checked off-chain.
+func RecoverOwner(safeTxHash common.Hash, signature []byte) (common.Address, error) {
+	if len(signature) != signatureLength {
+		return common.Address{}, ErrInvalidSignature
+	}
+
+	v := signature[64]
+	hash := safeTxHash[:]
+	switch {
+	case v == 27 || v == 28:
+	case v == 31 || v == 32:
+		v -= 4
+		hash = crypto.Keccak256([]byte("\x19Ethereum Signed Message:\n32"), safeTxHash[:])
+	default:
+		return common.Address{}, ErrInvalidSignature
+	}
+
+	sig := make([]byte, signatureLength)
+	copy(sig, signature)
+	sig[64] = v - 27
+
+	pubKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return common.Address{}, ErrInvalidSignature
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// preValidatedSignature is accepted by the Safe for the owner sending the execution tx, it doesn't have to sign the hash
+func preValidatedSignature(owner common.Address) []byte {
+	sig := make([]byte, signatureLength)
+	copy(sig[12:32], owner[:])
+	sig[64] = 1
+	return sig
+}
+
+// encodeSignatures concatenates the signatures sorted by owner address, the order checked by the Safe
+func encodeSignatures(confirmations []Confirmation) []byte {
+	sorted := make([]Confirmation, len(confirmations))
+	copy(sorted, confirmations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Owner[:], sorted[j].Owner[:]) < 0
+	})
+
+	signatures := make([]byte, 0, len(sorted)*signatureLength)
+	for _, c := range sorted {
+		signatures = append(signatures, c.Signature...)
+	}
+	return signatures
+}
@@ -0,0 +1,193 @@
+package safe
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	signercore "github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/status-im/status-go/services/typeddata"
+)
+
+// Operation is the kind of call made by the Safe
+type Operation uint8
+
+const (
+	OperationCall         Operation = 0
+	OperationDelegateCall Operation = 1
+)
+
+// Status is the progress of a Safe transaction, a pending transaction waits for confirmations or for its execution
+type Status int
+
+const (
+	StatusPending   Status = iota
+	StatusExecuting        // the execution tx is sent
+	StatusExecuted
+	StatusReplaced // the nonce was used by another transaction, or the transaction was executed outside of the wallet
+)
+
+// Confirmation is the EIP-712 signature of the Safe transaction hash by one of the owners
+type Confirmation struct {
+	Owner     common.Address `json:"owner"`
+	Signature hexutil.Bytes  `json:"signature"`
+	CreatedAt int64          `json:"createdAt"`
+}
+
+// Call is what the Safe is asked to execute
+type Call struct {
+	To        common.Address `json:"to"`
+	Value     *hexutil.Big   `json:"value"`
+	Data      hexutil.Bytes  `json:"data"`
+	Operation Operation      `json:"operation"`
+}
+
+// Transaction is a Safe transaction, the `SafeTx` struct signed by the owners, with its confirmations
+type Transaction struct {
+	SafeTxHash      common.Hash    `json:"safeTxHash"`
+	ChainID         uint64         `json:"chainId"`
+	Safe            common.Address `json:"safe"`
+	To              common.Address `json:"to"`
+	Value           *hexutil.Big   `json:"value"`
+	Data            hexutil.Bytes  `json:"data"`
+	Operation       Operation      `json:"operation"`
+	SafeTxGas       *hexutil.Big   `json:"safeTxGas"`
+	BaseGas         *hexutil.Big   `json:"baseGas"`
+	GasPrice        *hexutil.Big   `json:"gasPrice"`
+	GasToken        common.Address `json:"gasToken"`
+	RefundReceiver  common.Address `json:"refundReceiver"`
+	Nonce           uint64         `json:"nonce"`
+	Proposer        common.Address `json:"proposer"`
+	Status          Status         `json:"status"`
+	ExecutionTxHash common.Hash    `json:"executionTxHash"`
+	Confirmations   []Confirmation `json:"confirmations"`
+	CreatedAt       int64          `json:"createdAt"`
+	UpdatedAt       int64          `json:"updatedAt"`
+}
+
+// newTransaction returns the transaction executing the call, no gas refund is paid to the executor
+func newTransaction(chainID uint64, safe *Safe, call Call, nonce uint64, proposer common.Address) (*Transaction, error) {
+	value := call.Value
+	if value == nil {
+		value = (*hexutil.Big)(big.NewInt(0))
+	}
+	tx := &Transaction{
+		ChainID:   chainID,
+		Safe:      safe.Address,
+		To:        call.To,
+		Value:     value,
+		Data:      call.Data,
+		Operation: call.Operation,
+		SafeTxGas: (*hexutil.Big)(big.NewInt(0)),
+		BaseGas:   (*hexutil.Big)(big.NewInt(0)),
+		GasPrice:  (*hexutil.Big)(big.NewInt(0)),
+		Nonce:     nonce,
+		Proposer:  proposer,
+		Status:    StatusPending,
+	}
+
+	var err error
+	tx.SafeTxHash, err = tx.Hash(safe.Version)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// domainHasChainID returns true if the EIP-712 domain of the Safe version includes the chain ID, it was added in v1.3.0
+func domainHasChainID(version string) (bool, error) {
+	var major, minor int
+	_, err := fmt.Sscanf(version, "%d.%d", &major, &minor)
+	if err != nil {
+		return false, ErrUnsupportedSafeVersion
+	}
+	// v0.x signed `dataGas` instead of `baseGas`
+	if major < 1 {
+		return false, ErrUnsupportedSafeVersion
+	}
+	return major > 1 || minor >= 3, nil
+}
+
+func bigString(value *hexutil.Big) string {
+	if value == nil {
+		return "0"
+	}
+	return value.ToInt().String()
+}
+
+// TypedData returns the EIP-712 typed data signed by the owners for the given Safe version
+func (tx *Transaction) TypedData(version string) (signercore.TypedData, error) {
+	hasChainID, err := domainHasChainID(version)
+	if err != nil {
+		return signercore.TypedData{}, err
+	}
+
+	domainType := []signercore.Type{{Name: "verifyingContract", Type: "address"}}
+	domain := signercore.TypedDataDomain{
+		VerifyingContract: tx.Safe.Hex(),
+	}
+	if hasChainID {
+		domainType = append([]signercore.Type{{Name: "chainId", Type: "uint256"}}, domainType...)
+		domain.ChainId = (*math.HexOrDecimal256)(new(big.Int).SetUint64(tx.ChainID))
+	}
+
+	data := tx.Data
+	if data == nil {
+		data = hexutil.Bytes{}
+	}
+
+	return signercore.TypedData{
+		Types: signercore.Types{
+			"EIP712Domain": domainType,
+			"SafeTx": {
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"},
+				{Name: "safeTxGas", Type: "uint256"},
+				{Name: "baseGas", Type: "uint256"},
+				{Name: "gasPrice", Type: "uint256"},
+				{Name: "gasToken", Type: "address"},
+				{Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: "SafeTx",
+		Domain:      domain,
+		Message: signercore.TypedDataMessage{
+			"to":             tx.To.Hex(),
+			"value":          bigString(tx.Value),
+			"data":           data.String(),
+			"operation":      strconv.Itoa(int(tx.Operation)),
+			"safeTxGas":      bigString(tx.SafeTxGas),
+			"baseGas":        bigString(tx.BaseGas),
+			"gasPrice":       bigString(tx.GasPrice),
+			"gasToken":       tx.GasToken.Hex(),
+			"refundReceiver": tx.RefundReceiver.Hex(),
+			"nonce":          strconv.FormatUint(tx.Nonce, 10),
+		},
+	}, nil
+}
+
+// Hash returns the Safe transaction hash, the EIP-712 hash of the `SafeTx` struct
+func (tx *Transaction) Hash(version string) (common.Hash, error) {
+	typed, err := tx.TypedData(version)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return typeddata.HashTypedDataV4(typed, new(big.Int).SetUint64(tx.ChainID))
+}
+
+// confirmedBy returns the confirmation of the owner, nil if the owner didn't confirm the transaction
+func (tx *Transaction) confirmedBy(owner common.Address) *Confirmation {
+	for i := range tx.Confirmations {
+		if tx.Confirmations[i].Owner == owner {
+			return &tx.Confirmations[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package safe
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/status-im/status-go/account/signer"
+)
+
+var (
+	// the type hashes hardcoded in the Safe contracts
+	domainSeparatorTypeHash = common.HexToHash("0x47e79534a245952e8b16893a336b85a3d9ea9fa8c573f3d803afb92a79469218")
+	safeTxTypeHash          = common.HexToHash("0xbb8310d486368db6bd6f849402fdd73ad53d316b5a4b2644ad6efe0f941286d8")
+)
+
+func word(value *big.Int) []byte {
+	return common.LeftPadBytes(value.Bytes(), 32)
+}
+
+// expectedSafeTxHash encodes the transaction the way `Safe.getTransactionHash` does
+func expectedSafeTxHash(tx *Transaction) common.Hash {
+	domainSeparator := crypto.Keccak256(domainSeparatorTypeHash[:], word(new(big.Int).SetUint64(tx.ChainID)),
+		common.LeftPadBytes(tx.Safe[:], 32))
+	structHash := crypto.Keccak256(safeTxTypeHash[:], common.LeftPadBytes(tx.To[:], 32), word(tx.Value.ToInt()),
+		crypto.Keccak256(tx.Data), word(big.NewInt(int64(tx.Operation))), word(tx.SafeTxGas.ToInt()), word(tx.BaseGas.ToInt()),
+		word(tx.GasPrice.ToInt()), common.LeftPadBytes(tx.GasToken[:], 32), common.LeftPadBytes(tx.RefundReceiver[:], 32),
+		word(new(big.Int).SetUint64(tx.Nonce)))
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator, structHash)
+}
+
+func TestTypeHashes(t *testing.T) {
+	require.Equal(t, domainSeparatorTypeHash, crypto.Keccak256Hash([]byte("EIP712Domain(uint256 chainId,address verifyingContract)")))
+	require.Equal(t, safeTxTypeHash, crypto.Keccak256Hash([]byte("SafeTx(address to,uint256 value,bytes data,uint8 operation,"+
+		"uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)")))
+}
+
+func TestTransactionHash(t *testing.T) {
+	tx := &Transaction{
+		ChainID:        10,
+		Safe:           common.HexToAddress("0x5afe"),
+		To:             common.HexToAddress("0x1234"),
+		Value:          (*hexutil.Big)(big.NewInt(1000000000000000000)),
+		Data:           hexutil.MustDecode("0xa9059cbb"),
+		Operation:      OperationDelegateCall,
+		SafeTxGas:      (*hexutil.Big)(big.NewInt(21000)),
+		BaseGas:        (*hexutil.Big)(big.NewInt(0)),
+		GasPrice:       (*hexutil.Big)(big.NewInt(0)),
+		GasToken:       common.Address{},
+		RefundReceiver: common.HexToAddress("0x99"),
+		Nonce:          7,
+	}
+
+	hash, err := tx.Hash("1.3.0")
+	require.NoError(t, err)
+	require.Equal(t, expectedSafeTxHash(tx), hash)
+
+	hash141, err := tx.Hash("1.4.1")
+	require.NoError(t, err)
+	require.Equal(t, hash, hash141)
+
+	// empty data
+	tx.Data = nil
+	hash, err = tx.Hash("1.4.1")
+	require.NoError(t, err)
+	require.Equal(t, expectedSafeTxHash(tx), hash)
+
+	// the domain of the versions before v1.3.0 has no chain ID
+	legacyHash, err := tx.Hash("1.1.1")
+	require.NoError(t, err)
+	require.NotEqual(t, hash, legacyHash)
+	tx.ChainID = 1
+	legacyHashOtherChain, err := tx.Hash("1.1.1")
+	require.NoError(t, err)
+	require.Equal(t, legacyHash, legacyHashOtherChain)
+
+	_, err = tx.Hash("0.1.0")
+	require.ErrorIs(t, err, ErrUnsupportedSafeVersion)
+	_, err = tx.Hash("")
+	require.ErrorIs(t, err, ErrUnsupportedSafeVersion)
+}
+
+func TestSignatures(t *testing.T) {
+	hash := common.HexToHash("0xabcdef")
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+
+	sig, err := SignHash(hash, key)
+	require.NoError(t, err)
+	require.Len(t, sig, 65)
+	require.True(t, sig[64] == 27 || sig[64] == 28)
+
+	recovered, err := RecoverOwner(hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, owner, recovered)
+
+	// eth_sign signatures sign the prefixed hash and have v increased by 4
+	ethSig, err := crypto.Sign(crypto.Keccak256([]byte("\x19Ethereum Signed Message:\n32"), hash[:]), key)
+	require.NoError(t, err)
+	ethSig[64] += 31
+	recovered, err = RecoverOwner(hash, ethSig)
+	require.NoError(t, err)
+	require.Equal(t, owner, recovered)
+
+	// the external signers sign the typed data of the transaction, the signature is the same as the one of the hash
+	tx := &Transaction{
+		ChainID:        10,
+		Safe:           common.HexToAddress("0x5afe"),
+		To:             common.HexToAddress("0x1234"),
+		Value:          (*hexutil.Big)(big.NewInt(1000000000000000000)),
+		Data:           hexutil.MustDecode("0xa9059cbb"),
+		Operation:      OperationCall,
+		SafeTxGas:      (*hexutil.Big)(big.NewInt(0)),
+		BaseGas:        (*hexutil.Big)(big.NewInt(0)),
+		GasPrice:       (*hexutil.Big)(big.NewInt(0)),
+		RefundReceiver: common.Address{},
+		Nonce:          3,
+	}
+	tx.SafeTxHash = expectedSafeTxHash(tx)
+	externalSigner, err := signer.NewMockExternalService(key).Signer()
+	require.NoError(t, err)
+	defer externalSigner.Close()
+	typedSig, err := SignTransaction(context.Background(), externalSigner, owner, tx, "1.3.0")
+	require.NoError(t, err)
+	require.True(t, typedSig[64] == 27 || typedSig[64] == 28)
+	recovered, err = RecoverOwner(tx.SafeTxHash, typedSig)
+	require.NoError(t, err)
+	require.Equal(t, owner, recovered)
+
+	keySig, err := SignTransaction(context.Background(), signer.NewKeySigner(key), owner, tx, "1.3.0")
+	require.NoError(t, err)
+	require.Equal(t, typedSig, keySig)
+
+	_, err = SignTransaction(context.Background(), externalSigner, owner, tx, "1.1.1")
+	require.ErrorIs(t, err, ErrHashMismatch)
+
+	recovered, err = RecoverOwner(common.HexToHash("0x1"), sig)
+	require.NoError(t, err)
+	require.NotEqual(t, owner, recovered)
+
+	_, err = RecoverOwner(hash, preValidatedSignature(owner))
+	require.ErrorIs(t, err, ErrInvalidSignature)
+	_, err = RecoverOwner(hash, sig[:64])
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestEncodeSignatures(t *testing.T) {
+	owner1 := common.HexToAddress("0x01")
+	owner2 := common.HexToAddress("0x02")
+	owner3 := common.HexToAddress("0x03")
+
+	sig := func(b byte) []byte {
+		s := make([]byte, 65)
+		s[0] = b
+		return s
+	}
+
+	// the signatures are ordered by owner
+	encoded := encodeSignatures([]Confirmation{
+		{Owner: owner3, Signature: sig(3)},
+		{Owner: owner1, Signature: sig(1)},
+		{Owner: owner2, Signature: preValidatedSignature(owner2)},
+	})
+	require.Len(t, encoded, 3*65)
+	require.Equal(t, sig(1), encoded[:65])
+	require.Equal(t, common.LeftPadBytes(owner2[:], 32), encoded[65:97])
+	require.Equal(t, byte(1), encoded[129])
+	require.Equal(t, sig(3), encoded[130:])
+}
@@ -0,0 +1,300 @@
+package safe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+)
+
+const (
+	txServiceRequestTimeout = 30 * time.Second
+	txServiceOrigin         = "Status"
+	txServicePageLimit      = 100
+)
+
+// defaultTxServiceURLs are the Safe transaction services run by Safe, any service implementing the same API can be set instead
+var defaultTxServiceURLs = map[uint64]string{
+	walletCommon.EthereumMainnet: "https://safe-transaction-mainnet.safe.global",
+	walletCommon.OptimismMainnet: "https://safe-transaction-optimism.safe.global",
+	walletCommon.ArbitrumMainnet: "https://safe-transaction-arbitrum.safe.global",
+	walletCommon.BaseMainnet:     "https://safe-transaction-base.safe.global",
+	walletCommon.EthereumSepolia: "https://safe-transaction-sepolia.safe.global",
+}
+
+// TxServiceClient exchanges the Safe transactions and confirmations with the co-signers through a Safe transaction service
+type TxServiceClient struct {
+	httpClient *http.Client
+	urls       map[uint64]string
+	urlsLock   sync.RWMutex
+}
+
+func NewTxServiceClient() *TxServiceClient {
+	urls := make(map[uint64]string, len(defaultTxServiceURLs))
+	for chainID, u := range defaultTxServiceURLs {
+		urls[chainID] = u
+	}
+	return &TxServiceClient{
+		httpClient: &http.Client{
+			Timeout: txServiceRequestTimeout,
+		},
+		urls: urls,
+	}
+}
+
+// SetURL sets the transaction service used for the chain, the default one is removed if the URL is empty
+func (c *TxServiceClient) SetURL(chainID uint64, serviceURL string) {
+	c.urlsLock.Lock()
+	defer c.urlsLock.Unlock()
+
+	if serviceURL == "" {
+		delete(c.urls, chainID)
+		return
+	}
+	c.urls[chainID] = strings.TrimSuffix(serviceURL, "/")
+}
+
+func (c *TxServiceClient) IsChainSupported(chainID uint64) bool {
+	c.urlsLock.RLock()
+	defer c.urlsLock.RUnlock()
+	_, ok := c.urls[chainID]
+	return ok
+}
+
+func (c *TxServiceClient) endpoint(chainID uint64, path string) (string, error) {
+	c.urlsLock.RLock()
+	defer c.urlsLock.RUnlock()
+
+	baseURL, ok := c.urls[chainID]
+	if !ok {
+		return "", ErrTxServiceNotSupported
+	}
+	return baseURL + path, nil
+}
+
+// apiNumber is a number the transaction service returns either as a JSON number or as a string, depending on its version
+type apiNumber big.Int
+
+func (n *apiNumber) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "null" || value == "" {
+		(*big.Int)(n).SetUint64(0)
+		return nil
+	}
+	if _, ok := (*big.Int)(n).SetString(value, 10); !ok {
+		return fmt.Errorf("invalid number %s", value)
+	}
+	return nil
+}
+
+func (n *apiNumber) toHexBig() *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int).Set((*big.Int)(n)))
+}
+
+type apiConfirmation struct {
+	Owner     common.Address `json:"owner"`
+	Signature hexutil.Bytes  `json:"signature"`
+}
+
+type apiTransaction struct {
+	Safe           common.Address    `json:"safe"`
+	To             common.Address    `json:"to"`
+	Value          apiNumber         `json:"value"`
+	Data           *hexutil.Bytes    `json:"data"`
+	Operation      Operation         `json:"operation"`
+	SafeTxGas      apiNumber         `json:"safeTxGas"`
+	BaseGas        apiNumber         `json:"baseGas"`
+	GasPrice       apiNumber         `json:"gasPrice"`
+	GasToken       *common.Address   `json:"gasToken"`
+	RefundReceiver *common.Address   `json:"refundReceiver"`
+	Nonce          apiNumber         `json:"nonce"`
+	SafeTxHash     common.Hash       `json:"safeTxHash"`
+	Proposer       *common.Address   `json:"proposer"`
+	Confirmations  []apiConfirmation `json:"confirmations"`
+}
+
+func (t *apiTransaction) toTransaction(chainID uint64) *Transaction {
+	tx := &Transaction{
+		SafeTxHash: t.SafeTxHash,
+		ChainID:    chainID,
+		Safe:       t.Safe,
+		To:         t.To,
+		Value:      t.Value.toHexBig(),
+		Operation:  t.Operation,
+		SafeTxGas:  t.SafeTxGas.toHexBig(),
+		BaseGas:    t.BaseGas.toHexBig(),
+		GasPrice:   t.GasPrice.toHexBig(),
+		Nonce:      (*big.Int)(&t.Nonce).Uint64(),
+		Status:     StatusPending,
+	}
+	if t.Data != nil {
+		tx.Data = *t.Data
+	}
+	if t.GasToken != nil {
+		tx.GasToken = *t.GasToken
+	}
+	if t.RefundReceiver != nil {
+		tx.RefundReceiver = *t.RefundReceiver
+	}
+	if t.Proposer != nil {
+		tx.Proposer = *t.Proposer
+	}
+	for _, c := range t.Confirmations {
+		tx.Confirmations = append(tx.Confirmations, Confirmation{
+			Owner:     c.Owner,
+			Signature: c.Signature,
+		})
+	}
+	return tx
+}
+
+type apiProposal struct {
+	To                      string  `json:"to"`
+	Value                   string  `json:"value"`
+	Data                    *string `json:"data"`
+	Operation               int     `json:"operation"`
+	SafeTxGas               string  `json:"safeTxGas"`
+	BaseGas                 string  `json:"baseGas"`
+	GasPrice                string  `json:"gasPrice"`
+	GasToken                string  `json:"gasToken"`
+	RefundReceiver          string  `json:"refundReceiver"`
+	Nonce                   uint64  `json:"nonce"`
+	ContractTransactionHash string  `json:"contractTransactionHash"`
+	Sender                  string  `json:"sender"`
+	Signature               string  `json:"signature"`
+	Origin                  string  `json:"origin"`
+}
+
+type apiPage struct {
+	Next    *string          `json:"next"`
+	Results []apiTransaction `json:"results"`
+}
+
+func (c *TxServiceClient) do(ctx context.Context, method string, endpoint string, body interface{}) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("safe transaction service returned status code %d: %s", resp.StatusCode, string(respBody))
+	}
+	return respBody, nil
+}
+
+// ProposeTransaction publishes the transaction with the confirmation of the proposer
+func (c *TxServiceClient) ProposeTransaction(ctx context.Context, tx *Transaction, proposer Confirmation) error {
+	endpoint, err := c.endpoint(tx.ChainID, fmt.Sprintf("/api/v1/safes/%s/multisig-transactions/", tx.Safe.Hex()))
+	if err != nil {
+		return err
+	}
+
+	var data *string
+	if len(tx.Data) > 0 {
+		encoded := tx.Data.String()
+		data = &encoded
+	}
+
+	_, err = c.do(ctx, http.MethodPost, endpoint, apiProposal{
+		To:                      tx.To.Hex(),
+		Value:                   bigString(tx.Value),
+		Data:                    data,
+		Operation:               int(tx.Operation),
+		SafeTxGas:               bigString(tx.SafeTxGas),
+		BaseGas:                 bigString(tx.BaseGas),
+		GasPrice:                bigString(tx.GasPrice),
+		GasToken:                tx.GasToken.Hex(),
+		RefundReceiver:          tx.RefundReceiver.Hex(),
+		Nonce:                   tx.Nonce,
+		ContractTransactionHash: tx.SafeTxHash.Hex(),
+		Sender:                  proposer.Owner.Hex(),
+		Signature:               proposer.Signature.String(),
+		Origin:                  txServiceOrigin,
+	})
+	return err
+}
+
+// ConfirmTransaction publishes the confirmation of an owner
+func (c *TxServiceClient) ConfirmTransaction(ctx context.Context, chainID uint64, safeTxHash common.Hash, confirmation Confirmation) error {
+	endpoint, err := c.endpoint(chainID, fmt.Sprintf("/api/v1/multisig-transactions/%s/confirmations/", safeTxHash.Hex()))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, http.MethodPost, endpoint, map[string]string{
+		"signature": confirmation.Signature.String(),
+	})
+	return err
+}
+
+// GetPendingTransactions returns the transactions of the Safe not executed yet, starting from the given nonce
+func (c *TxServiceClient) GetPendingTransactions(ctx context.Context, chainID uint64, safe common.Address, fromNonce uint64) ([]*Transaction, error) {
+	endpoint, err := c.endpoint(chainID, fmt.Sprintf("/api/v1/safes/%s/multisig-transactions/", safe.Hex()))
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("executed", "false")
+	params.Set("nonce__gte", fmt.Sprintf("%d", fromNonce))
+	params.Set("limit", fmt.Sprintf("%d", txServicePageLimit))
+	next := endpoint + "?" + params.Encode()
+
+	txs := make([]*Transaction, 0)
+	for next != "" {
+		body, err := c.do(ctx, http.MethodGet, next, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page apiPage
+		err = json.Unmarshal(body, &page)
+		if err != nil {
+			return nil, err
+		}
+		for i := range page.Results {
+			txs = append(txs, page.Results[i].toTransaction(chainID))
+		}
+
+		next = ""
+		if page.Next != nil {
+			next = *page.Next
+		}
+	}
+	return txs, nil
+}
@@ -0,0 +1,17 @@
+package scheduledtransfer
+
+import (
+	"github.com/status-im/status-go/errors"
+)
+
+// Abbreviation `WST` for the error code stands for Wallet Scheduled Transfer
+var (
+	ErrScheduledTransferNotFound      = &errors.ErrorResponse{Code: errors.ErrorCode("WST-001"), Details: "scheduled transfer not found"}
+	ErrUnsupportedSendType            = &errors.ErrorResponse{Code: errors.ErrorCode("WST-002"), Details: "only transfers, bridges and collectible transfers can be scheduled"}
+	ErrInvalidRecurrence              = &errors.ErrorResponse{Code: errors.ErrorCode("WST-003"), Details: "invalid recurrence"}
+	ErrExecutionTimeInPast            = &errors.ErrorResponse{Code: errors.ErrorCode("WST-004"), Details: "execution time must be in the future"}
+	ErrScheduledTransferNotDue        = &errors.ErrorResponse{Code: errors.ErrorCode("WST-005"), Details: "scheduled transfer is not due"}
+	ErrScheduledTransferNotCancelable = &errors.ErrorResponse{Code: errors.ErrorCode("WST-006"), Details: "scheduled transfer is already executed or cancelled"}
+	ErrRouteInputParamsRequired       = &errors.ErrorResponse{Code: errors.ErrorCode("WST-007"), Details: "route input params are required"}
+	ErrScheduledTransferExecuting     = &errors.ErrorResponse{Code: errors.ErrorCode("WST-008"), Details: "scheduled transfer is being executed"}
+)
@@ -0,0 +1,255 @@
+package scheduledtransfer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+
+	gocommon "github.com/status-im/status-go/common"
+	"github.com/status-im/status-go/services/wallet/requests"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+)
+
+const (
+	// EventScheduledTransferDue is sent when a scheduled transfer reaches its execution time. Keys aren't kept unlocked,
+	// so the transfer is sent only once the user confirms it with the account password.
+	EventScheduledTransferDue walletevent.EventType = "wallet-scheduled-transfer-due"
+	// EventScheduledTransferExecuted is sent when the transactions of a scheduled transfer are sent
+	EventScheduledTransferExecuted walletevent.EventType = "wallet-scheduled-transfer-executed"
+	// EventScheduledTransferFailed is sent when sending a confirmed transfer fails, the transfer stays due so it can be retried
+	EventScheduledTransferFailed walletevent.EventType = "wallet-scheduled-transfer-failed"
+
+	dueCheckInterval = 1 * time.Minute
+)
+
+// Manager persists the scheduled transfers and notifies the client when they're due. Sending the transfer is done by the
+// caller of `Execute`, since the route has to be calculated and the transactions signed at execution time.
+type Manager struct {
+	persistence *Persistence
+	walletFeed  *event.Feed
+	cancelFn    context.CancelFunc
+	executingMu sync.Mutex
+	executing   map[int64]bool // IDs of the transfers being sent, guarded by executingMu
+	now         func() time.Time
+}
+
+func NewManager(db *sql.DB, walletFeed *event.Feed) *Manager {
+	return &Manager{
+		persistence: NewPersistence(db),
+		walletFeed:  walletFeed,
+		executing:   make(map[int64]bool),
+		now:         time.Now,
+	}
+}
+
+func (m *Manager) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelFn = cancel
+
+	// notify again about the transfers which became due before the restart
+	due, err := m.persistence.GetScheduledTransfers([]Status{StatusDue})
+	if err != nil {
+		log.Error("failed to get due scheduled transfers", "err", err)
+	}
+	for _, st := range due {
+		m.sendEvent(EventScheduledTransferDue, st)
+	}
+
+	go func() {
+		defer gocommon.LogOnPanic()
+		m.checkDue()
+
+		ticker := time.NewTicker(dueCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.checkDue()
+			}
+		}
+	}()
+}
+
+func (m *Manager) Stop() {
+	if m.cancelFn != nil {
+		m.cancelFn()
+	}
+}
+
+// Schedule stores a transfer which is executed at `executeAt` and then repeated based on the recurrence
+func (m *Manager) Schedule(params *requests.RouteInputParams, executeAt int64, recurrence Recurrence) (*ScheduledTransfer, error) {
+	if params == nil {
+		return nil, ErrRouteInputParamsRequired
+	}
+	if !isSchedulable(params.SendType) {
+		return nil, ErrUnsupportedSendType
+	}
+	if !recurrence.IsValid() {
+		return nil, ErrInvalidRecurrence
+	}
+	now := m.now().Unix()
+	if executeAt <= now {
+		return nil, ErrExecutionTimeInPast
+	}
+
+	stParams := *params
+	stParams.Uuid = ""
+	stParams.TestsMode = false
+	stParams.TestParams = nil
+
+	st := &ScheduledTransfer{
+		Params:        &stParams,
+		Recurrence:    recurrence,
+		StartAt:       executeAt,
+		NextExecution: executeAt,
+		Status:        StatusActive,
+		CreatedAt:     now,
+	}
+
+	id, err := m.persistence.InsertScheduledTransfer(st)
+	if err != nil {
+		return nil, err
+	}
+	st.ID = id
+	return st, nil
+}
+
+func (m *Manager) GetScheduledTransfers(statuses []Status) ([]*ScheduledTransfer, error) {
+	return m.persistence.GetScheduledTransfers(statuses)
+}
+
+func (m *Manager) GetScheduledTransfer(id int64) (*ScheduledTransfer, error) {
+	return m.persistence.GetScheduledTransfer(id)
+}
+
+func (m *Manager) Cancel(id int64) error {
+	m.executingMu.Lock()
+	defer m.executingMu.Unlock()
+
+	st, err := m.persistence.GetScheduledTransfer(id)
+	if err != nil {
+		return err
+	}
+	if st.Status == StatusExecuted || st.Status == StatusCancelled {
+		return ErrScheduledTransferNotCancelable
+	}
+	if m.executing[id] {
+		return ErrScheduledTransferExecuting
+	}
+
+	st.Status = StatusCancelled
+	return m.persistence.UpdateScheduledTransfer(st)
+}
+
+// Execute runs `send` for a due scheduled transfer and moves the schedule to the next execution if it succeeds.
+// The transfer is marked as executing while it's sent, so a transfer confirmed twice is sent once.
+func (m *Manager) Execute(id int64, send func(st *ScheduledTransfer) error) (*ScheduledTransfer, error) {
+	st, err := m.startExecution(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the lock isn't held while sending, so the due check and the other transfers don't wait for the RPC calls
+	err = send(st)
+
+	m.executingMu.Lock()
+	defer m.executingMu.Unlock()
+	delete(m.executing, id)
+
+	if err != nil {
+		st.LastError = err.Error()
+		if updateErr := m.persistence.UpdateScheduledTransfer(st); updateErr != nil {
+			log.Error("failed to update scheduled transfer", "id", st.ID, "err", updateErr)
+		}
+		m.sendEvent(EventScheduledTransferFailed, st)
+		return nil, err
+	}
+
+	now := m.now()
+	st.LastExecution = now.Unix()
+	st.Executions++
+	st.LastError = ""
+	next, ok := nextExecution(time.Unix(st.StartAt, 0), st.Recurrence, now)
+	if ok {
+		st.NextExecution = next.Unix()
+		st.Status = StatusActive
+	} else {
+		st.Status = StatusExecuted
+	}
+
+	err = m.persistence.UpdateScheduledTransfer(st)
+	if err != nil {
+		return nil, err
+	}
+
+	m.sendEvent(EventScheduledTransferExecuted, st)
+	return st, nil
+}
+
+func (m *Manager) startExecution(id int64) (*ScheduledTransfer, error) {
+	m.executingMu.Lock()
+	defer m.executingMu.Unlock()
+
+	if m.executing[id] {
+		return nil, ErrScheduledTransferExecuting
+	}
+
+	st, err := m.persistence.GetScheduledTransfer(id)
+	if err != nil {
+		return nil, err
+	}
+	if st.Status != StatusDue {
+		return nil, ErrScheduledTransferNotDue
+	}
+
+	m.executing[id] = true
+	return st, nil
+}
+
+func (m *Manager) checkDue() {
+	m.executingMu.Lock()
+	defer m.executingMu.Unlock()
+
+	due, err := m.persistence.GetDueScheduledTransfers(m.now().Unix())
+	if err != nil {
+		log.Error("failed to get due scheduled transfers", "err", err)
+		return
+	}
+
+	for _, st := range due {
+		st.Status = StatusDue
+		err = m.persistence.UpdateScheduledTransfer(st)
+		if err != nil {
+			log.Error("failed to update scheduled transfer", "id", st.ID, "err", err)
+			continue
+		}
+		m.sendEvent(EventScheduledTransferDue, st)
+	}
+}
+
+func (m *Manager) sendEvent(eventType walletevent.EventType, st *ScheduledTransfer) {
+	if m.walletFeed == nil {
+		return
+	}
+
+	message, err := json.Marshal(st)
+	if err != nil {
+		log.Error("failed to marshal scheduled transfer", "id", st.ID, "err", err)
+		return
+	}
+
+	m.walletFeed.Send(walletevent.Event{
+		Type:     eventType,
+		Accounts: []common.Address{st.Params.AddrFrom},
+		Message:  string(message),
+		At:       m.now().Unix(),
+	})
+}
@@ -0,0 +1,145 @@
+package scheduledtransfer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/event"
+
+	"github.com/status-im/status-go/services/wallet/requests"
+	"github.com/status-im/status-go/services/wallet/router/sendtype"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestManager(t *testing.T) (*Manager, chan walletevent.Event, func()) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+
+	feed := &event.Feed{}
+	ch := make(chan walletevent.Event, 10)
+	sub := feed.Subscribe(ch)
+
+	return NewManager(db, feed), ch, func() {
+		sub.Unsubscribe()
+		require.NoError(t, db.Close())
+	}
+}
+
+func testRouteInputParams() *requests.RouteInputParams {
+	return &requests.RouteInputParams{
+		Uuid:     "uuid",
+		SendType: sendtype.Transfer,
+		AddrFrom: common.HexToAddress("0x1"),
+		AddrTo:   common.HexToAddress("0x2"),
+		AmountIn: (*hexutil.Big)(big.NewInt(100)),
+		TokenID:  "ETH",
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	m, _, cleanup := setupTestManager(t)
+	defer cleanup()
+
+	now := time.Now()
+
+	_, err := m.Schedule(testRouteInputParams(), now.Add(-time.Minute).Unix(), RecurrenceNone)
+	require.ErrorIs(t, err, ErrExecutionTimeInPast)
+
+	params := testRouteInputParams()
+	params.SendType = sendtype.Swap
+	_, err = m.Schedule(params, now.Add(time.Hour).Unix(), RecurrenceNone)
+	require.ErrorIs(t, err, ErrUnsupportedSendType)
+
+	_, err = m.Schedule(testRouteInputParams(), now.Add(time.Hour).Unix(), Recurrence(10))
+	require.ErrorIs(t, err, ErrInvalidRecurrence)
+
+	st, err := m.Schedule(testRouteInputParams(), now.Add(time.Hour).Unix(), RecurrenceWeekly)
+	require.NoError(t, err)
+	require.NotZero(t, st.ID)
+
+	stored, err := m.GetScheduledTransfer(st.ID)
+	require.NoError(t, err)
+	require.Equal(t, StatusActive, stored.Status)
+	require.Equal(t, RecurrenceWeekly, stored.Recurrence)
+	require.Equal(t, "", stored.Params.Uuid)
+	require.Equal(t, common.HexToAddress("0x2"), stored.Params.AddrTo)
+	require.Equal(t, big.NewInt(100), stored.Params.AmountIn.ToInt())
+
+	require.NoError(t, m.Cancel(st.ID))
+	require.ErrorIs(t, m.Cancel(st.ID), ErrScheduledTransferNotCancelable)
+
+	_, err = m.GetScheduledTransfer(st.ID + 1)
+	require.ErrorIs(t, err, ErrScheduledTransferNotFound)
+}
+
+func TestExecuteScheduledTransfer(t *testing.T) {
+	m, ch, cleanup := setupTestManager(t)
+	defer cleanup()
+
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	oneOff, err := m.Schedule(testRouteInputParams(), now.Add(time.Hour).Unix(), RecurrenceNone)
+	require.NoError(t, err)
+	weekly, err := m.Schedule(testRouteInputParams(), now.Add(2*time.Hour).Unix(), RecurrenceWeekly)
+	require.NoError(t, err)
+
+	send := func(st *ScheduledTransfer) error { return nil }
+	_, err = m.Execute(oneOff.ID, send)
+	require.ErrorIs(t, err, ErrScheduledTransferNotDue)
+
+	now = now.Add(3 * time.Hour)
+	m.checkDue()
+	for _, id := range []int64{oneOff.ID, weekly.ID} {
+		event := <-ch
+		require.Equal(t, EventScheduledTransferDue, event.Type)
+		st, err := walletevent.GetPayload[ScheduledTransfer](event)
+		require.NoError(t, err)
+		require.Equal(t, id, st.ID)
+		require.Equal(t, StatusDue, st.Status)
+	}
+
+	// failed transfers stay due
+	_, err = m.Execute(oneOff.ID, func(st *ScheduledTransfer) error { return errors.New("no route") })
+	require.Error(t, err)
+	event := <-ch
+	require.Equal(t, EventScheduledTransferFailed, event.Type)
+	stored, err := m.GetScheduledTransfer(oneOff.ID)
+	require.NoError(t, err)
+	require.Equal(t, StatusDue, stored.Status)
+	require.Equal(t, "no route", stored.LastError)
+
+	// the transfer can't be executed twice or cancelled while it's sent, the due check doesn't wait for it
+	st, err := m.Execute(oneOff.ID, func(st *ScheduledTransfer) error {
+		_, err := m.Execute(oneOff.ID, send)
+		require.ErrorIs(t, err, ErrScheduledTransferExecuting)
+		require.ErrorIs(t, m.Cancel(oneOff.ID), ErrScheduledTransferExecuting)
+		m.checkDue()
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, StatusExecuted, st.Status)
+	require.Equal(t, 1, st.Executions)
+	require.Empty(t, st.LastError)
+	event = <-ch
+	require.Equal(t, EventScheduledTransferExecuted, event.Type)
+
+	st, err = m.Execute(weekly.ID, send)
+	require.NoError(t, err)
+	require.Equal(t, StatusActive, st.Status)
+	require.Equal(t, weekly.StartAt+7*24*60*60, st.NextExecution)
+	require.Equal(t, now.Unix(), st.LastExecution)
+
+	active, err := m.GetScheduledTransfers([]Status{StatusActive, StatusDue})
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	require.Equal(t, weekly.ID, active[0].ID)
+}
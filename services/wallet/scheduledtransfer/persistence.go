@@ -0,0 +1,136 @@
+package scheduledtransfer
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/status-im/status-go/services/wallet/requests"
+)
+
+type Persistence struct {
+	db *sql.DB
+}
+
+func NewPersistence(db *sql.DB) *Persistence {
+	return &Persistence{
+		db: db,
+	}
+}
+
+const scheduledTransferColumns = "id, route_params, recurrence, start_at, next_execution, last_execution, executions, status, last_error, created_at"
+
+func (p *Persistence) InsertScheduledTransfer(st *ScheduledTransfer) (int64, error) {
+	params, err := json.Marshal(st.Params)
+	if err != nil {
+		return 0, err
+	}
+
+	amountIn := "0x0"
+	if st.Params.AmountIn != nil {
+		amountIn = st.Params.AmountIn.String()
+	}
+
+	res, err := p.db.Exec(`INSERT INTO scheduled_transfers (from_address, to_address, send_type, token_id, amount_in, route_params,
+		recurrence, start_at, next_execution, last_execution, executions, status, last_error, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		st.Params.AddrFrom, st.Params.AddrTo, st.Params.SendType, st.Params.TokenID, amountIn, string(params),
+		st.Recurrence, st.StartAt, st.NextExecution, st.LastExecution, st.Executions, st.Status, st.LastError, st.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// UpdateScheduledTransfer stores the execution state of the scheduled transfer, the params can't be changed
+func (p *Persistence) UpdateScheduledTransfer(st *ScheduledTransfer) error {
+	res, err := p.db.Exec(`UPDATE scheduled_transfers SET next_execution = ?, last_execution = ?, executions = ?, status = ?, last_error = ?
+		WHERE id = ?`, st.NextExecution, st.LastExecution, st.Executions, st.Status, st.LastError, st.ID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrScheduledTransferNotFound
+	}
+	return nil
+}
+
+func (p *Persistence) GetScheduledTransfer(id int64) (*ScheduledTransfer, error) {
+	rows, err := p.db.Query(fmt.Sprintf("SELECT %s FROM scheduled_transfers WHERE id = ?", scheduledTransferColumns), id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scheduledTransfers, err := rowsToScheduledTransfers(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(scheduledTransfers) == 0 {
+		return nil, ErrScheduledTransferNotFound
+	}
+	return scheduledTransfers[0], nil
+}
+
+// GetScheduledTransfers returns the scheduled transfers with any of the statuses, all if no status is given
+func (p *Persistence) GetScheduledTransfers(statuses []Status) ([]*ScheduledTransfer, error) {
+	query := fmt.Sprintf("SELECT %s FROM scheduled_transfers", scheduledTransferColumns)
+	args := make([]interface{}, 0, len(statuses))
+	if len(statuses) > 0 {
+		placeholders := make([]string, 0, len(statuses))
+		for _, status := range statuses {
+			placeholders = append(placeholders, "?")
+			args = append(args, status)
+		}
+		query += fmt.Sprintf(" WHERE status IN (%s)", strings.Join(placeholders, ","))
+	}
+	query += " ORDER BY next_execution ASC, id ASC"
+
+	rows, err := p.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return rowsToScheduledTransfers(rows)
+}
+
+// GetDueScheduledTransfers returns the active scheduled transfers whose execution time is not after `now`
+func (p *Persistence) GetDueScheduledTransfers(now int64) ([]*ScheduledTransfer, error) {
+	rows, err := p.db.Query(fmt.Sprintf("SELECT %s FROM scheduled_transfers WHERE status = ? AND next_execution <= ? ORDER BY next_execution ASC, id ASC",
+		scheduledTransferColumns), StatusActive, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return rowsToScheduledTransfers(rows)
+}
+
+func rowsToScheduledTransfers(rows *sql.Rows) ([]*ScheduledTransfer, error) {
+	scheduledTransfers := make([]*ScheduledTransfer, 0)
+	for rows.Next() {
+		st := &ScheduledTransfer{}
+		var params string
+		err := rows.Scan(&st.ID, &params, &st.Recurrence, &st.StartAt, &st.NextExecution, &st.LastExecution, &st.Executions,
+			&st.Status, &st.LastError, &st.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		st.Params = &requests.RouteInputParams{}
+		err = json.Unmarshal([]byte(params), st.Params)
+		if err != nil {
+			return nil, err
+		}
+
+		scheduledTransfers = append(scheduledTransfers, st)
+	}
+	return scheduledTransfers, rows.Err()
+}
@@ -0,0 +1,83 @@
+package scheduledtransfer
+
+import (
+	"time"
+
+	"github.com/status-im/status-go/services/wallet/requests"
+	"github.com/status-im/status-go/services/wallet/router/sendtype"
+)
+
+type Recurrence int
+
+const (
+	RecurrenceNone Recurrence = iota
+	RecurrenceWeekly
+	RecurrenceMonthly
+)
+
+func (r Recurrence) IsValid() bool {
+	return r == RecurrenceNone || r == RecurrenceWeekly || r == RecurrenceMonthly
+}
+
+type Status int
+
+const (
+	StatusActive    Status = iota // waiting for the next execution time
+	StatusDue                     // execution time passed, waiting for the user to confirm the transfer
+	StatusExecuted                // one-off transfer sent
+	StatusCancelled               // cancelled by the user
+)
+
+// ScheduledTransfer is a transfer sent at a later time, once or recurring. The route is calculated when the transfer is
+// executed, so fees and chains are chosen based on the state at that time.
+type ScheduledTransfer struct {
+	ID            int64                      `json:"id"`
+	Params        *requests.RouteInputParams `json:"params"`
+	Recurrence    Recurrence                 `json:"recurrence"`
+	StartAt       int64                      `json:"startAt"` // first execution time, anchors the recurring executions
+	NextExecution int64                      `json:"nextExecution"`
+	LastExecution int64                      `json:"lastExecution"`
+	Executions    int                        `json:"executions"`
+	Status        Status                     `json:"status"`
+	LastError     string                     `json:"lastError,omitempty"`
+	CreatedAt     int64                      `json:"createdAt"`
+}
+
+func isSchedulable(sendType sendtype.SendType) bool {
+	return sendType == sendtype.Transfer || sendType == sendtype.Bridge ||
+		sendType == sendtype.ERC721Transfer || sendType == sendtype.ERC1155Transfer
+}
+
+// nextExecution returns the first execution time of the recurrence started at `start` which is after `after`. Executions
+// missed while the node was not running are skipped, not sent in bulk. Monthly executions scheduled for a day which
+// doesn't exist in a month are executed on the last day of that month.
+func nextExecution(start time.Time, recurrence Recurrence, after time.Time) (time.Time, bool) {
+	if start.After(after) {
+		return start, true
+	}
+
+	switch recurrence {
+	case RecurrenceWeekly:
+		week := 7 * 24 * time.Hour
+		weeks := after.Sub(start)/week + 1
+		return start.Add(weeks * week), true
+	case RecurrenceMonthly:
+		for months := 1; ; months++ {
+			next := addMonths(start, months)
+			if next.After(after) {
+				return next, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func addMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	firstOfMonth := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
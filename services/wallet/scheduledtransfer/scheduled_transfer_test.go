@@ -0,0 +1,41 @@
+package scheduledtransfer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextExecution(t *testing.T) {
+	start := time.Date(2024, time.January, 31, 10, 0, 0, 0, time.UTC)
+
+	next, ok := nextExecution(start, RecurrenceNone, start.Add(-time.Hour))
+	require.True(t, ok)
+	require.Equal(t, start, next)
+
+	_, ok = nextExecution(start, RecurrenceNone, start)
+	require.False(t, ok)
+
+	next, ok = nextExecution(start, RecurrenceWeekly, start)
+	require.True(t, ok)
+	require.Equal(t, time.Date(2024, time.February, 7, 10, 0, 0, 0, time.UTC), next)
+
+	// missed executions are skipped
+	next, ok = nextExecution(start, RecurrenceWeekly, start.Add(15*24*time.Hour))
+	require.True(t, ok)
+	require.Equal(t, time.Date(2024, time.February, 21, 10, 0, 0, 0, time.UTC), next)
+
+	// the day is clamped to the end of shorter months, but the recurrence keeps the original day
+	next, ok = nextExecution(start, RecurrenceMonthly, start)
+	require.True(t, ok)
+	require.Equal(t, time.Date(2024, time.February, 29, 10, 0, 0, 0, time.UTC), next)
+
+	next, ok = nextExecution(start, RecurrenceMonthly, next)
+	require.True(t, ok)
+	require.Equal(t, time.Date(2024, time.March, 31, 10, 0, 0, 0, time.UTC), next)
+
+	next, ok = nextExecution(start, RecurrenceMonthly, time.Date(2024, time.December, 31, 11, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	require.Equal(t, time.Date(2025, time.January, 31, 10, 0, 0, 0, time.UTC), next)
+}
@@ -14,6 +14,7 @@ import (
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/account/signer"
 	"github.com/status-im/status-go/multiaccounts/accounts"
 	"github.com/status-im/status-go/params"
 	protocolCommon "github.com/status-im/status-go/protocol/common"
@@ -22,23 +23,36 @@ import (
 	"github.com/status-im/status-go/services/ens"
 	"github.com/status-im/status-go/services/stickers"
 	"github.com/status-im/status-go/services/wallet/activity"
+	"github.com/status-im/status-go/services/wallet/allowance"
 	"github.com/status-im/status-go/services/wallet/balance"
 	"github.com/status-im/status-go/services/wallet/blockchainstate"
 	"github.com/status-im/status-go/services/wallet/collectibles"
 	"github.com/status-im/status-go/services/wallet/community"
 	"github.com/status-im/status-go/services/wallet/currency"
+	"github.com/status-im/status-go/services/wallet/feemonitor"
 	"github.com/status-im/status-go/services/wallet/history"
 	"github.com/status-im/status-go/services/wallet/market"
+	"github.com/status-im/status-go/services/wallet/nativebridge"
 	"github.com/status-im/status-go/services/wallet/onramp"
+	"github.com/status-im/status-go/services/wallet/poisoning"
+	"github.com/status-im/status-go/services/wallet/portfolio"
+	"github.com/status-im/status-go/services/wallet/safe"
+	"github.com/status-im/status-go/services/wallet/scheduledtransfer"
+	"github.com/status-im/status-go/services/wallet/simulation"
+	"github.com/status-im/status-go/services/wallet/spam"
 	"github.com/status-im/status-go/services/wallet/thirdparty"
 	"github.com/status-im/status-go/services/wallet/thirdparty/alchemy"
+	"github.com/status-im/status-go/services/wallet/thirdparty/bundler"
+	"github.com/status-im/status-go/services/wallet/thirdparty/chainlink"
 	"github.com/status-im/status-go/services/wallet/thirdparty/coingecko"
 	"github.com/status-im/status-go/services/wallet/thirdparty/cryptocompare"
+	"github.com/status-im/status-go/services/wallet/thirdparty/onchain"
 	"github.com/status-im/status-go/services/wallet/thirdparty/opensea"
 	"github.com/status-im/status-go/services/wallet/thirdparty/rarible"
 	"github.com/status-im/status-go/services/wallet/token"
 	"github.com/status-im/status-go/services/wallet/transfer"
 	"github.com/status-im/status-go/services/wallet/walletevent"
+	"github.com/status-im/status-go/signal"
 	"github.com/status-im/status-go/transactions"
 )
 
@@ -125,8 +139,23 @@ func NewService(
 		User:     config.WalletConfig.StatusProxyMarketUser,
 		Password: config.WalletConfig.StatusProxyMarketPassword,
 	})
-	marketManager := market.NewManager([]thirdparty.MarketDataProvider{cryptoCompare, coingecko, cryptoCompareProxy}, feed)
+	chainlinkClient := chainlink.NewClient(rpcClient)
+	marketManager := market.NewManager([]thirdparty.MarketDataProvider{cryptoCompare, coingecko, cryptoCompareProxy, chainlinkClient}, feed)
+	// The proxy serves the CryptoCompare data, it's the same source as CryptoCompare
+	marketManager.SetReconciliationSources([][]thirdparty.MarketDataProvider{
+		{cryptoCompare, cryptoCompareProxy},
+		{coingecko},
+		{chainlinkClient},
+	})
 	reader := NewReader(tokenManager, marketManager, token.NewPersistence(db), feed)
+	exchange := history.NewExchange(marketManager)
+	// The transfers of the accounts are assessed for address poisoning once loaded
+	poisoningDetector := poisoning.NewDetector(db)
+	poisoningWatcher := history.NewWatcher(feed, poisoningDetector.OnTransfersLoaded)
+	// The client signs with the Keycard and submits the signatures through the API
+	keycardSignRequests := signer.NewKeycardRequests(func(request signer.KeycardSignRequest) {
+		signal.SendWalletEvent(signal.KeycardSignRequest, request)
+	})
 	history := history.NewService(db, accountsDB, accountFeed, feed, rpcClient, tokenManager, marketManager, balanceCacher.Cache())
 	currency := currency.NewService(db, feed, tokenManager, marketManager)
 
@@ -134,6 +163,8 @@ func NewService(
 	openseaV2Client := opensea.NewClientV2(config.WalletConfig.OpenseaAPIKey, openseaHTTPClient)
 	raribleClient := rarible.NewClient(config.WalletConfig.RaribleMainnetAPIKey, config.WalletConfig.RaribleTestnetAPIKey)
 	alchemyClient := alchemy.NewClient(config.WalletConfig.AlchemyAPIKeys)
+	// Reads the contracts directly, the fallback when the API based providers are unavailable
+	onchainClient := onchain.NewClient(rpcClient, db)
 
 	// Collectible providers in priority order (i.e. provider N+1 will be tried only if provider N fails)
 	contractOwnershipProviders := []thirdparty.CollectibleContractOwnershipProvider{
@@ -145,12 +176,14 @@ func NewService(
 		raribleClient,
 		alchemyClient,
 		openseaV2Client,
+		onchainClient,
 	}
 
 	collectibleDataProviders := []thirdparty.CollectibleDataProvider{
 		raribleClient,
 		alchemyClient,
 		openseaV2Client,
+		onchainClient,
 	}
 
 	collectionDataProviders := []thirdparty.CollectionDataProvider{
@@ -180,8 +213,19 @@ func NewService(
 		feed,
 	)
 	collectibles := collectibles.NewService(db, feed, accountsDB, accountFeed, settingsFeed, communityManager, rpcClient.NetworkManager, collectiblesManager)
+	// NFT avatars of ENS names are loaded through the collectible providers
+	if ens != nil {
+		ens.API().SetCollectiblesProvider(collectiblesManager)
+	}
 
-	activity := activity.NewService(db, accountsDB, tokenManager, collectiblesManager, feed, pendingTxManager)
+	activityNameResolver := &activityNameResolver{
+		appDB:                 appDB,
+		savedAddressesManager: savedAddressesManager,
+		tokenManager:          tokenManager,
+	}
+	activity := activity.NewService(db, accountsDB, tokenManager, collectiblesManager, feed, pendingTxManager, exchange, activityNameResolver)
+
+	bundlers := bundler.NewClients(config.WalletConfig.BundlerURLs, config.WalletConfig.PaymasterURLs)
 
 	featureFlags := &protocolCommon.FeatureFlags{}
 	if config.WalletConfig.EnableCelerBridge {
@@ -215,8 +259,20 @@ func NewService(
 		decoder:               NewDecoder(),
 		blockChainState:       blockChainState,
 		keycardPairings:       NewKeycardPairings(),
+		keycardSignRequests:   keycardSignRequests,
 		config:                config,
 		featureFlags:          featureFlags,
+		bundlers:              bundlers,
+		simulator:             simulation.NewSimulator(rpcClient),
+		allowanceManager:      allowance.NewManager(db, rpcClient, transactor),
+		scheduledTransfers:    scheduledtransfer.NewManager(db, feed),
+		feeMonitor:            feemonitor.NewMonitor(db, rpcClient, feed),
+		nativeBridge:          nativebridge.NewManager(db, rpcClient, transactor, feed),
+		safes:                 safe.NewManager(db, rpcClient, transactor, feed),
+		spamClassifier:        spam.NewClassifier(db, tokenManager, spam.NewMarketLiquidityChecker(marketManager)),
+		poisoningDetector:     poisoningDetector,
+		poisoningWatcher:      poisoningWatcher,
+		portfolio:             portfolio.NewManager(db, tokenManager, exchange, marketManager),
 	}
 }
 
@@ -249,8 +305,20 @@ type Service struct {
 	decoder               *Decoder
 	blockChainState       *blockchainstate.BlockChainState
 	keycardPairings       *KeycardPairings
+	keycardSignRequests   *signer.KeycardRequests
 	config                *params.NodeConfig
 	featureFlags          *protocolCommon.FeatureFlags
+	bundlers              *bundler.Clients
+	simulator             *simulation.Simulator
+	allowanceManager      *allowance.Manager
+	scheduledTransfers    *scheduledtransfer.Manager
+	feeMonitor            *feemonitor.Monitor
+	nativeBridge          *nativebridge.Manager
+	safes                 *safe.Manager
+	spamClassifier        *spam.Classifier
+	poisoningDetector     *poisoning.Detector
+	poisoningWatcher      *history.Watcher
+	portfolio             *portfolio.Manager
 }
 
 // Start signals transmitter.
@@ -260,6 +328,11 @@ func (s *Service) Start() error {
 	err := s.signals.Start()
 	s.history.Start()
 	s.collectibles.Start()
+	s.scheduledTransfers.Start()
+	s.feeMonitor.Start()
+	s.nativeBridge.Start()
+	s.safes.Start()
+	s.poisoningWatcher.Start()
 	s.started = true
 	return err
 }
@@ -279,6 +352,11 @@ func (s *Service) Stop() error {
 	s.history.Stop()
 	s.activity.Stop()
 	s.collectibles.Stop()
+	s.scheduledTransfers.Stop()
+	s.feeMonitor.Stop()
+	s.nativeBridge.Stop()
+	s.safes.Stop()
+	s.poisoningWatcher.Stop()
 	s.tokenManager.Stop()
 	s.started = false
 	log.Info("wallet stopped")
@@ -342,6 +420,10 @@ func (s *Service) GetCollectiblesManager() *collectibles.Manager {
 	return s.collectiblesManager
 }
 
+func (s *Service) GetBundlers() *bundler.Clients {
+	return s.bundlers
+}
+
 func (s *Service) GetEnsService() *ens.Service {
 	return s.ens
 }
@@ -0,0 +1,73 @@
+package simulation
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+)
+
+const methodIDLength = 4
+
+var (
+	erc20TransferMethodID     = crypto.Keccak256([]byte("transfer(address,uint256)"))[:methodIDLength]
+	erc20TransferFromMethodID = crypto.Keccak256([]byte("transferFrom(address,address,uint256)"))[:methodIDLength]
+	erc20ApproveMethodID      = crypto.Keccak256([]byte("approve(address,uint256)"))[:methodIDLength]
+	setApprovalForAllMethodID = crypto.Keccak256([]byte("setApprovalForAll(address,bool)"))[:methodIDLength]
+)
+
+// Predict returns the changes of the tx derived from its value and data only, without executing it. Only the native
+// token transfers and the well known ERC20 calls are recognized.
+func Predict(msg ethereum.CallMsg) *Result {
+	c := &changes{}
+
+	if msg.To == nil {
+		return c.result()
+	}
+
+	c.addTransfer(msg.From, *msg.To, walletCommon.EthTransfer, common.Address{}, nil, msg.Value)
+
+	if len(msg.Data) < methodIDLength {
+		return c.result()
+	}
+
+	methodID := msg.Data[:methodIDLength]
+	params := splitParams(msg.Data[methodIDLength:])
+
+	switch {
+	case bytes.Equal(methodID, erc20TransferMethodID) && len(params) == 2:
+		c.addTransfer(msg.From, common.BytesToAddress(params[0]), walletCommon.Erc20Transfer, *msg.To, nil, new(big.Int).SetBytes(params[1]))
+	case bytes.Equal(methodID, erc20TransferFromMethodID) && len(params) == 3:
+		c.addTransfer(common.BytesToAddress(params[0]), common.BytesToAddress(params[1]), walletCommon.Erc20Transfer, *msg.To, nil,
+			new(big.Int).SetBytes(params[2]))
+	case bytes.Equal(methodID, erc20ApproveMethodID) && len(params) == 2:
+		c.addApproval(newERC20Approval(*msg.To, msg.From, common.BytesToAddress(params[0]), new(big.Int).SetBytes(params[1])))
+	case bytes.Equal(methodID, setApprovalForAllMethodID) && len(params) == 2:
+		c.addApproval(&Approval{
+			Type:     walletCommon.Erc721Transfer,
+			Token:    *msg.To,
+			Owner:    msg.From,
+			Spender:  common.BytesToAddress(params[0]),
+			ForAll:   true,
+			Approved: new(big.Int).SetBytes(params[1]).Sign() != 0,
+		})
+	}
+
+	return c.result()
+}
+
+func splitParams(data []byte) [][]byte {
+	if len(data)%common.HashLength != 0 {
+		return nil
+	}
+
+	params := make([][]byte, 0, len(data)/common.HashLength)
+	for i := 0; i < len(data); i += common.HashLength {
+		params = append(params, data[i:i+common.HashLength])
+	}
+	return params
+}
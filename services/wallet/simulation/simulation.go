@@ -0,0 +1,204 @@
+package simulation
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/status-go/rpc"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+)
+
+const executionRevertedMsg = "execution reverted"
+
+// BalanceChange is a change of the balance of an account caused by a transaction
+type BalanceChange struct {
+	Address common.Address    `json:"address"`
+	Type    walletCommon.Type `json:"type"`
+	Token   common.Address    `json:"token"`             // zero address for the native token
+	TokenID *hexutil.Big      `json:"tokenId,omitempty"` // set for collectibles
+	Amount  *hexutil.Big      `json:"amount"`            // negative if the balance decreases
+}
+
+// Approval is an allowance set by a transaction
+type Approval struct {
+	Type      walletCommon.Type `json:"type"`
+	Token     common.Address    `json:"token"`
+	Owner     common.Address    `json:"owner"`
+	Spender   common.Address    `json:"spender"`
+	Amount    *hexutil.Big      `json:"amount,omitempty"`  // set for ERC20 allowances
+	TokenID   *hexutil.Big      `json:"tokenId,omitempty"` // set for a single collectible approval
+	ForAll    bool              `json:"forAll"`            // set if the spender is approved for all the collectibles of the owner
+	Approved  bool              `json:"approved"`          // false if the approval is revoked
+	Unlimited bool              `json:"unlimited"`         // set if the max ERC20 allowance is approved
+}
+
+// Result is the predicted outcome of a transaction
+type Result struct {
+	Reverted       bool             `json:"reverted"`
+	RevertReason   string           `json:"revertReason,omitempty"`
+	GasUsed        uint64           `json:"gasUsed,omitempty"`
+	Traced         bool             `json:"traced"` // false if the changes are predicted from the tx data only, without executing it
+	BalanceChanges []*BalanceChange `json:"balanceChanges"`
+	Approvals      []*Approval      `json:"approvals"`
+}
+
+type caller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// Simulator runs transactions against the latest chain state without sending them
+type Simulator struct {
+	rpcClient rpc.ClientInterface
+}
+
+func NewSimulator(rpcClient rpc.ClientInterface) *Simulator {
+	return &Simulator{
+		rpcClient: rpcClient,
+	}
+}
+
+// Simulate traces the tx using `debug_traceCall`, if the node doesn't support tracing the tx is executed using `eth_call`
+// and the changes are predicted from the tx data
+func (s *Simulator) Simulate(ctx context.Context, chainID uint64, msg ethereum.CallMsg) (*Result, error) {
+	ethClient, err := s.rpcClient.EthClient(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return simulate(ctx, ethClient, msg)
+}
+
+type callArgs struct {
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to,omitempty"`
+	Gas   hexutil.Uint64  `json:"gas,omitempty"`
+	Value *hexutil.Big    `json:"value,omitempty"`
+	Data  hexutil.Bytes   `json:"data,omitempty"`
+}
+
+func toCallArgs(msg ethereum.CallMsg) callArgs {
+	args := callArgs{
+		From: msg.From,
+		To:   msg.To,
+		Gas:  hexutil.Uint64(msg.Gas),
+		Data: msg.Data,
+	}
+	if msg.Value != nil {
+		args.Value = (*hexutil.Big)(msg.Value)
+	}
+	return args
+}
+
+type traceConfig struct {
+	Tracer       string          `json:"tracer"`
+	TracerConfig map[string]bool `json:"tracerConfig"`
+}
+
+func simulate(ctx context.Context, c caller, msg ethereum.CallMsg) (*Result, error) {
+	args := toCallArgs(msg)
+
+	var frame callFrame
+	err := c.CallContext(ctx, &frame, "debug_traceCall", args, "latest", traceConfig{
+		Tracer:       "callTracer",
+		TracerConfig: map[string]bool{"withLog": true},
+	})
+	if err == nil {
+		return resultFromTrace(&frame), nil
+	}
+	log.Debug("simulation: tracing not available, falling back to eth_call", "err", err)
+
+	var output hexutil.Bytes
+	err = c.CallContext(ctx, &output, "eth_call", args, "latest")
+	if err != nil {
+		reason, reverted := revertReasonFromError(err)
+		if !reverted {
+			return nil, err
+		}
+		return &Result{
+			Reverted:     true,
+			RevertReason: reason,
+		}, nil
+	}
+
+	return Predict(msg), nil
+}
+
+func revertReasonFromError(err error) (string, bool) {
+	if dataErr, ok := err.(gethrpc.DataError); ok {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if reason, unpackErr := abi.UnpackRevert(common.FromHex(data)); unpackErr == nil {
+				return reason, true
+			}
+		}
+	}
+
+	msg := err.Error()
+	if !strings.Contains(msg, executionRevertedMsg) {
+		return "", false
+	}
+	reason := strings.TrimPrefix(msg[strings.Index(msg, executionRevertedMsg)+len(executionRevertedMsg):], ":")
+	return strings.TrimSpace(reason), true
+}
+
+// changes accumulates the balance changes of a transaction, keeping the order in which the accounts were first changed
+type changes struct {
+	balances  []*BalanceChange
+	approvals []*Approval
+}
+
+func (c *changes) addBalanceChange(address common.Address, txType walletCommon.Type, token common.Address, tokenID *big.Int, amount *big.Int) {
+	for _, change := range c.balances {
+		if change.Address == address && change.Type == txType && change.Token == token &&
+			((change.TokenID == nil && tokenID == nil) || (change.TokenID != nil && tokenID != nil && change.TokenID.ToInt().Cmp(tokenID) == 0)) {
+			change.Amount.ToInt().Add(change.Amount.ToInt(), amount)
+			return
+		}
+	}
+
+	change := &BalanceChange{
+		Address: address,
+		Type:    txType,
+		Token:   token,
+		Amount:  (*hexutil.Big)(new(big.Int).Set(amount)),
+	}
+	if tokenID != nil {
+		change.TokenID = (*hexutil.Big)(new(big.Int).Set(tokenID))
+	}
+	c.balances = append(c.balances, change)
+}
+
+func (c *changes) addTransfer(from common.Address, to common.Address, txType walletCommon.Type, token common.Address, tokenID *big.Int, amount *big.Int) {
+	if amount == nil || amount.Sign() == 0 || from == to {
+		return
+	}
+	c.addBalanceChange(from, txType, token, tokenID, new(big.Int).Neg(amount))
+	c.addBalanceChange(to, txType, token, tokenID, amount)
+}
+
+func (c *changes) addApproval(approval *Approval) {
+	c.approvals = append(c.approvals, approval)
+}
+
+func (c *changes) result() *Result {
+	result := &Result{
+		BalanceChanges: make([]*BalanceChange, 0, len(c.balances)),
+		Approvals:      c.approvals,
+	}
+	for _, change := range c.balances {
+		if change.Amount.ToInt().Sign() != 0 {
+			result.BalanceChanges = append(result.BalanceChanges, change)
+		}
+	}
+	if result.Approvals == nil {
+		result.Approvals = []*Approval{}
+	}
+	return result
+}
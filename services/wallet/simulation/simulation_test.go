@@ -0,0 +1,162 @@
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCaller struct {
+	responses map[string]string
+	errors    map[string]error
+	calls     []string
+}
+
+func (f *fakeCaller) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	f.calls = append(f.calls, method)
+	if err, ok := f.errors[method]; ok {
+		return err
+	}
+	return json.Unmarshal([]byte(f.responses[method]), result)
+}
+
+var (
+	testFrom    = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	testTo      = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	testToken   = common.HexToAddress("0x3000000000000000000000000000000000000003")
+	testSpender = common.HexToAddress("0x4000000000000000000000000000000000000004")
+)
+
+func addressTopic(address common.Address) common.Hash {
+	return common.BytesToHash(address.Bytes())
+}
+
+func TestSimulateTrace(t *testing.T) {
+	transferTopic := walletCommon.GetEventSignatureHash(walletCommon.Erc20_721TransferEventSignature)
+
+	trace := callFrame{
+		Type:    "CALL",
+		From:    testFrom,
+		To:      &testToken,
+		Value:   (*hexutil.Big)(big.NewInt(0)),
+		GasUsed: 50000,
+		Logs: []*callLog{
+			{
+				Address: testToken,
+				Topics:  []common.Hash{transferTopic, addressTopic(testFrom), addressTopic(testTo)},
+				Data:    common.BigToHash(big.NewInt(100)).Bytes(),
+			},
+			{
+				Address: testToken,
+				Topics:  []common.Hash{erc20_721ApprovalEventSignatureHash, addressTopic(testFrom), addressTopic(testSpender)},
+				Data:    common.BigToHash(walletCommon.MaxUint256).Bytes(),
+			},
+		},
+		Calls: []*callFrame{
+			{
+				Type:  "CALL",
+				From:  testToken,
+				To:    &testTo,
+				Value: (*hexutil.Big)(big.NewInt(5)),
+			},
+			// changes of reverted calls are ignored
+			{
+				Type:  "CALL",
+				From:  testToken,
+				To:    &testSpender,
+				Value: (*hexutil.Big)(big.NewInt(7)),
+				Error: "execution reverted",
+			},
+		},
+	}
+	traceJSON, err := json.Marshal(trace)
+	require.NoError(t, err)
+
+	c := &fakeCaller{responses: map[string]string{"debug_traceCall": string(traceJSON)}}
+	result, err := simulate(context.Background(), c, ethereum.CallMsg{From: testFrom, To: &testToken})
+	require.NoError(t, err)
+	require.Equal(t, []string{"debug_traceCall"}, c.calls)
+
+	require.True(t, result.Traced)
+	require.False(t, result.Reverted)
+	require.Equal(t, uint64(50000), result.GasUsed)
+
+	require.Len(t, result.BalanceChanges, 4)
+	require.Equal(t, testFrom, result.BalanceChanges[0].Address)
+	require.Equal(t, walletCommon.Erc20Transfer, result.BalanceChanges[0].Type)
+	require.Equal(t, big.NewInt(-100), result.BalanceChanges[0].Amount.ToInt())
+	require.Equal(t, testTo, result.BalanceChanges[1].Address)
+	require.Equal(t, big.NewInt(100), result.BalanceChanges[1].Amount.ToInt())
+	require.Equal(t, testToken, result.BalanceChanges[2].Address)
+	require.Equal(t, walletCommon.EthTransfer, result.BalanceChanges[2].Type)
+	require.Equal(t, big.NewInt(-5), result.BalanceChanges[2].Amount.ToInt())
+	require.Equal(t, big.NewInt(5), result.BalanceChanges[3].Amount.ToInt())
+
+	require.Len(t, result.Approvals, 1)
+	require.Equal(t, testSpender, result.Approvals[0].Spender)
+	require.True(t, result.Approvals[0].Approved)
+	require.True(t, result.Approvals[0].Unlimited)
+}
+
+func TestSimulateTraceReverted(t *testing.T) {
+	c := &fakeCaller{responses: map[string]string{
+		"debug_traceCall": `{"type":"CALL","from":"0x1000000000000000000000000000000000000001","gasUsed":"0x5208","error":"execution reverted","revertReason":"insufficient balance"}`,
+	}}
+	result, err := simulate(context.Background(), c, ethereum.CallMsg{From: testFrom, To: &testToken})
+	require.NoError(t, err)
+	require.True(t, result.Reverted)
+	require.Equal(t, "insufficient balance", result.RevertReason)
+}
+
+func TestSimulateFallbackToCall(t *testing.T) {
+	data := append([]byte{}, erc20TransferMethodID...)
+	data = append(data, common.BytesToHash(testTo.Bytes()).Bytes()...)
+	data = append(data, common.BigToHash(big.NewInt(100)).Bytes()...)
+	msg := ethereum.CallMsg{From: testFrom, To: &testToken, Data: data}
+
+	c := &fakeCaller{
+		responses: map[string]string{"eth_call": `"0x"`},
+		errors:    map[string]error{"debug_traceCall": errors.New("the method debug_traceCall does not exist/is not available")},
+	}
+	result, err := simulate(context.Background(), c, msg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"debug_traceCall", "eth_call"}, c.calls)
+	require.False(t, result.Traced)
+	require.False(t, result.Reverted)
+	require.Len(t, result.BalanceChanges, 2)
+	require.Equal(t, testToken, result.BalanceChanges[0].Token)
+	require.Equal(t, big.NewInt(-100), result.BalanceChanges[0].Amount.ToInt())
+
+	c.errors["eth_call"] = errors.New("execution reverted: ERC20: transfer amount exceeds balance")
+	result, err = simulate(context.Background(), c, msg)
+	require.NoError(t, err)
+	require.True(t, result.Reverted)
+	require.Equal(t, "ERC20: transfer amount exceeds balance", result.RevertReason)
+
+	c.errors["eth_call"] = errors.New("connection refused")
+	_, err = simulate(context.Background(), c, msg)
+	require.Error(t, err)
+}
+
+func TestPredictApprove(t *testing.T) {
+	data := append([]byte{}, erc20ApproveMethodID...)
+	data = append(data, common.BytesToHash(testSpender.Bytes()).Bytes()...)
+	data = append(data, common.BigToHash(big.NewInt(0)).Bytes()...)
+
+	result := Predict(ethereum.CallMsg{From: testFrom, To: &testToken, Data: data})
+	require.Empty(t, result.BalanceChanges)
+	require.Len(t, result.Approvals, 1)
+	require.Equal(t, testFrom, result.Approvals[0].Owner)
+	require.Equal(t, testSpender, result.Approvals[0].Spender)
+	require.False(t, result.Approvals[0].Approved)
+}
@@ -0,0 +1,184 @@
+package simulation
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+)
+
+const (
+	// Approval (index_topic_1 address owner, index_topic_2 address spender, uint256 value)
+	// Approval (index_topic_1 address owner, index_topic_2 address approved, index_topic_3 uint256 tokenId)
+	erc20_721ApprovalEventSignature = "Approval(address,address,uint256)"
+	// ApprovalForAll (index_topic_1 address owner, index_topic_2 address operator, bool approved)
+	approvalForAllEventSignature = "ApprovalForAll(address,address,bool)"
+
+	erc20ApprovalEventIndexedParameters  = 3 // signature, owner, spender
+	erc721ApprovalEventIndexedParameters = 4 // signature, owner, approved, tokenId
+)
+
+var (
+	erc20_721ApprovalEventSignatureHash = walletCommon.GetEventSignatureHash(erc20_721ApprovalEventSignature)
+	approvalForAllEventSignatureHash    = walletCommon.GetEventSignatureHash(approvalForAllEventSignature)
+)
+
+type callLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+// callFrame is a call traced by the geth `callTracer`
+type callFrame struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []*callFrame    `json:"calls,omitempty"`
+	Logs         []*callLog      `json:"logs,omitempty"`
+}
+
+// transfersValue returns true if the value of the call is moved to the callee
+func (f *callFrame) transfersValue() bool {
+	switch f.Type {
+	case "CALL", "CREATE", "CREATE2", "SELFDESTRUCT":
+		return f.To != nil && f.Value != nil && f.Value.ToInt().Sign() > 0
+	}
+	return false
+}
+
+func resultFromTrace(frame *callFrame) *Result {
+	if frame.Error != "" {
+		reason := frame.RevertReason
+		if reason == "" {
+			if unpacked, err := abi.UnpackRevert(frame.Output); err == nil {
+				reason = unpacked
+			} else {
+				reason = frame.Error
+			}
+		}
+		return &Result{
+			Reverted:     true,
+			RevertReason: reason,
+			GasUsed:      uint64(frame.GasUsed),
+			Traced:       true,
+		}
+	}
+
+	c := &changes{}
+	collectFrameChanges(frame, c)
+
+	result := c.result()
+	result.GasUsed = uint64(frame.GasUsed)
+	result.Traced = true
+	return result
+}
+
+func collectFrameChanges(frame *callFrame, c *changes) {
+	// changes of a reverted call are discarded, even if the caller handles the revert
+	if frame.Error != "" {
+		return
+	}
+
+	if frame.transfersValue() {
+		c.addTransfer(frame.From, *frame.To, walletCommon.EthTransfer, common.Address{}, nil, frame.Value.ToInt())
+	}
+
+	for _, l := range frame.Logs {
+		collectLogChanges(&ethTypes.Log{
+			Address: l.Address,
+			Topics:  l.Topics,
+			Data:    l.Data,
+		}, c)
+	}
+
+	for _, call := range frame.Calls {
+		collectFrameChanges(call, c)
+	}
+}
+
+func collectLogChanges(ethlog *ethTypes.Log, c *changes) {
+	if len(ethlog.Topics) == 0 {
+		return
+	}
+
+	switch walletCommon.GetEventType(ethlog) {
+	case walletCommon.Erc20TransferEventType:
+		from, to, amount := walletCommon.ParseErc20TransferLog(ethlog)
+		c.addTransfer(from, to, walletCommon.Erc20Transfer, ethlog.Address, nil, amount)
+		return
+	case walletCommon.Erc721TransferEventType:
+		from, to, tokenID := walletCommon.ParseErc721TransferLog(ethlog)
+		c.addTransfer(from, to, walletCommon.Erc721Transfer, ethlog.Address, tokenID, big.NewInt(1))
+		return
+	case walletCommon.Erc1155TransferSingleEventType, walletCommon.Erc1155TransferBatchEventType:
+		_, from, to, ids, amounts, err := walletCommon.ParseErc1155TransferLog(ethlog, walletCommon.GetEventType(ethlog))
+		if err != nil {
+			return
+		}
+		for i := range ids {
+			c.addTransfer(from, to, walletCommon.Erc1155Transfer, ethlog.Address, ids[i], amounts[i])
+		}
+		return
+	}
+
+	switch ethlog.Topics[0] {
+	case erc20_721ApprovalEventSignatureHash:
+		switch len(ethlog.Topics) {
+		case erc20ApprovalEventIndexedParameters:
+			if len(ethlog.Data) != common.HashLength {
+				return
+			}
+			c.addApproval(newERC20Approval(ethlog.Address, topicToAddress(ethlog.Topics[1]), topicToAddress(ethlog.Topics[2]),
+				new(big.Int).SetBytes(ethlog.Data)))
+		case erc721ApprovalEventIndexedParameters:
+			spender := topicToAddress(ethlog.Topics[2])
+			c.addApproval(&Approval{
+				Type:     walletCommon.Erc721Transfer,
+				Token:    ethlog.Address,
+				Owner:    topicToAddress(ethlog.Topics[1]),
+				Spender:  spender,
+				TokenID:  (*hexutil.Big)(new(big.Int).SetBytes(ethlog.Topics[3][:])),
+				Approved: spender != common.Address{},
+			})
+		}
+	case approvalForAllEventSignatureHash:
+		if len(ethlog.Topics) != 3 || len(ethlog.Data) != common.HashLength {
+			return
+		}
+		// the event is shared by ERC721 and ERC1155, the collectibles type can't be told from it
+		c.addApproval(&Approval{
+			Type:     walletCommon.Erc721Transfer,
+			Token:    ethlog.Address,
+			Owner:    topicToAddress(ethlog.Topics[1]),
+			Spender:  topicToAddress(ethlog.Topics[2]),
+			ForAll:   true,
+			Approved: new(big.Int).SetBytes(ethlog.Data).Sign() != 0,
+		})
+	}
+}
+
+func newERC20Approval(token common.Address, owner common.Address, spender common.Address, amount *big.Int) *Approval {
+	return &Approval{
+		Type:      walletCommon.Erc20Transfer,
+		Token:     token,
+		Owner:     owner,
+		Spender:   spender,
+		Amount:    (*hexutil.Big)(amount),
+		Approved:  amount.Sign() > 0,
+		Unlimited: amount.Cmp(walletCommon.MaxUint256) == 0,
+	}
+}
+
+func topicToAddress(topic common.Hash) common.Address {
+	return common.BytesToAddress(topic[common.HashLength-common.AddressLength:])
+}
@@ -0,0 +1,170 @@
+package siwe
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	headerSuffix = " wants you to sign in with your Ethereum account:"
+
+	uriTag            = "URI: "
+	versionTag        = "Version: "
+	chainIDTag        = "Chain ID: "
+	nonceTag          = "Nonce: "
+	issuedAtTag       = "Issued At: "
+	expirationTimeTag = "Expiration Time: "
+	notBeforeTag      = "Not Before: "
+	requestIDTag      = "Request ID: "
+	resourcesTag      = "Resources:"
+	resourcePrefix    = "- "
+
+	supportedVersion = "1"
+)
+
+var (
+	ErrNotSIWEMessage      = errors.New("not a sign-in with ethereum message")
+	ErrInvalidAddress      = errors.New("invalid address in sign-in with ethereum message")
+	ErrUnsupportedVersion  = errors.New("unsupported sign-in with ethereum message version")
+	ErrUnexpectedLine      = errors.New("unexpected line in sign-in with ethereum message")
+	ErrMissingRequiredTags = errors.New("sign-in with ethereum message misses required fields")
+)
+
+// Message is an EIP-4361 Sign-In With Ethereum message
+type Message struct {
+	Scheme         string         `json:"scheme,omitempty"`
+	Domain         string         `json:"domain"`
+	Address        common.Address `json:"address"`
+	Statement      string         `json:"statement,omitempty"`
+	URI            string         `json:"uri"`
+	Version        string         `json:"version"`
+	ChainID        uint64         `json:"chainId"`
+	Nonce          string         `json:"nonce"`
+	IssuedAt       time.Time      `json:"issuedAt"`
+	ExpirationTime *time.Time     `json:"expirationTime,omitempty"`
+	NotBefore      *time.Time     `json:"notBefore,omitempty"`
+	RequestID      string         `json:"requestId,omitempty"`
+	Resources      []string       `json:"resources,omitempty"`
+}
+
+// DecodeChallenge returns the text of a "personal_sign" challenge, dApps send it either hex encoded or as plain text
+func DecodeChallenge(challenge string) string {
+	if data, err := hexutil.Decode(challenge); err == nil {
+		return string(data)
+	}
+	return challenge
+}
+
+// IsSIWEMessage checks only the header of the message, `Parse` validates the whole message
+func IsSIWEMessage(message string) bool {
+	header, _, _ := strings.Cut(message, "\n")
+	return strings.HasSuffix(strings.TrimSuffix(header, "\r"), headerSuffix)
+}
+
+func parseTime(value string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// Parse parses a message formatted as defined by EIP-4361
+func Parse(message string) (*Message, error) {
+	lines := strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n")
+	if len(lines) < 2 || !strings.HasSuffix(lines[0], headerSuffix) {
+		return nil, ErrNotSIWEMessage
+	}
+
+	result := &Message{}
+	result.Domain = strings.TrimSuffix(lines[0], headerSuffix)
+	if scheme, domain, found := strings.Cut(result.Domain, "://"); found {
+		result.Scheme = scheme
+		result.Domain = domain
+	}
+	if result.Domain == "" {
+		return nil, ErrNotSIWEMessage
+	}
+
+	if !common.IsHexAddress(lines[1]) {
+		return nil, ErrInvalidAddress
+	}
+	result.Address = common.HexToAddress(lines[1])
+
+	i := 2
+	// the statement is optional and surrounded by empty lines
+	for ; i < len(lines) && !strings.HasPrefix(lines[i], uriTag); i++ {
+		if lines[i] == "" {
+			continue
+		}
+		if result.Statement != "" {
+			return nil, ErrUnexpectedLine
+		}
+		result.Statement = lines[i]
+	}
+
+	var (
+		hasURI, hasVersion, hasChainID, hasNonce, hasIssuedAt bool
+		err                                                   error
+	)
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, uriTag):
+			result.URI = strings.TrimPrefix(line, uriTag)
+			hasURI = true
+		case strings.HasPrefix(line, versionTag):
+			result.Version = strings.TrimPrefix(line, versionTag)
+			if result.Version != supportedVersion {
+				return nil, ErrUnsupportedVersion
+			}
+			hasVersion = true
+		case strings.HasPrefix(line, chainIDTag):
+			result.ChainID, err = strconv.ParseUint(strings.TrimPrefix(line, chainIDTag), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid chain id: %w", err)
+			}
+			hasChainID = true
+		case strings.HasPrefix(line, nonceTag):
+			result.Nonce = strings.TrimPrefix(line, nonceTag)
+			hasNonce = true
+		case strings.HasPrefix(line, issuedAtTag):
+			result.IssuedAt, err = parseTime(strings.TrimPrefix(line, issuedAtTag))
+			if err != nil {
+				return nil, fmt.Errorf("invalid issued at: %w", err)
+			}
+			hasIssuedAt = true
+		case strings.HasPrefix(line, expirationTimeTag):
+			expirationTime, err := parseTime(strings.TrimPrefix(line, expirationTimeTag))
+			if err != nil {
+				return nil, fmt.Errorf("invalid expiration time: %w", err)
+			}
+			result.ExpirationTime = &expirationTime
+		case strings.HasPrefix(line, notBeforeTag):
+			notBefore, err := parseTime(strings.TrimPrefix(line, notBeforeTag))
+			if err != nil {
+				return nil, fmt.Errorf("invalid not before: %w", err)
+			}
+			result.NotBefore = &notBefore
+		case strings.HasPrefix(line, requestIDTag):
+			result.RequestID = strings.TrimPrefix(line, requestIDTag)
+		case line == resourcesTag:
+			for i+1 < len(lines) && strings.HasPrefix(lines[i+1], resourcePrefix) {
+				i++
+				result.Resources = append(result.Resources, strings.TrimPrefix(lines[i], resourcePrefix))
+			}
+		case line == "" && i == len(lines)-1:
+			// trailing new line
+		default:
+			return nil, ErrUnexpectedLine
+		}
+	}
+
+	if !hasURI || !hasVersion || !hasChainID || !hasNonce || !hasIssuedAt {
+		return nil, ErrMissingRequiredTags
+	}
+
+	return result, nil
+}
@@ -0,0 +1,161 @@
+package siwe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/status-im/status-go/eth-node/types"
+)
+
+const testMessage = `https://app.example.com wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+I accept the Example Terms of Service: https://app.example.com/tos
+
+URI: https://app.example.com/login
+Version: 1
+Chain ID: 1
+Nonce: 32891756ab
+Issued At: 2024-08-01T10:00:00Z
+Expiration Time: 2024-08-01T11:00:00Z
+Not Before: 2024-08-01T09:59:00Z
+Request ID: some-request
+Resources:
+- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/
+- https://example.com/my-web2-claim.json`
+
+func TestParse(t *testing.T) {
+	require.True(t, IsSIWEMessage(testMessage))
+
+	message, err := Parse(testMessage)
+	require.NoError(t, err)
+	require.Equal(t, "https", message.Scheme)
+	require.Equal(t, "app.example.com", message.Domain)
+	require.Equal(t, common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"), message.Address)
+	require.Equal(t, "I accept the Example Terms of Service: https://app.example.com/tos", message.Statement)
+	require.Equal(t, "https://app.example.com/login", message.URI)
+	require.Equal(t, "1", message.Version)
+	require.Equal(t, uint64(1), message.ChainID)
+	require.Equal(t, "32891756ab", message.Nonce)
+	require.Equal(t, time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC), message.IssuedAt)
+	require.Equal(t, time.Date(2024, 8, 1, 11, 0, 0, 0, time.UTC), *message.ExpirationTime)
+	require.Equal(t, time.Date(2024, 8, 1, 9, 59, 0, 0, time.UTC), *message.NotBefore)
+	require.Equal(t, "some-request", message.RequestID)
+	require.Len(t, message.Resources, 2)
+
+	// the statement and the optional fields can be omitted
+	message, err = Parse(`example.com wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+
+URI: https://example.com
+Version: 1
+Chain ID: 10
+Nonce: abcdefgh12
+Issued At: 2024-08-01T10:00:00.000Z`)
+	require.NoError(t, err)
+	require.Empty(t, message.Scheme)
+	require.Equal(t, "example.com", message.Domain)
+	require.Empty(t, message.Statement)
+	require.Equal(t, uint64(10), message.ChainID)
+	require.Nil(t, message.ExpirationTime)
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse("Please sign this message to confirm your identity.")
+	require.Equal(t, ErrNotSIWEMessage, err)
+
+	_, err = Parse(`example.com wants you to sign in with your Ethereum account:
+0xinvalid
+
+URI: https://example.com`)
+	require.Equal(t, ErrInvalidAddress, err)
+
+	_, err = Parse(`example.com wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+URI: https://example.com
+Version: 2
+Chain ID: 1
+Nonce: abcdefgh12
+Issued At: 2024-08-01T10:00:00Z`)
+	require.Equal(t, ErrUnsupportedVersion, err)
+
+	_, err = Parse(`example.com wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+URI: https://example.com
+Version: 1
+Chain ID: 1`)
+	require.Equal(t, ErrMissingRequiredTags, err)
+}
+
+func TestValidate(t *testing.T) {
+	message, err := Parse(testMessage)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 8, 1, 10, 30, 0, 0, time.UTC)
+	expectations := Expectations{
+		Origin:  "https://app.example.com",
+		ChainID: 1,
+		Address: common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+	}
+	require.Empty(t, Validate(message, expectations, now))
+
+	// a message requested by a phishing site for another domain
+	expectations.Origin = "https://app.examp1e.com"
+	expectations.ChainID = 10
+	expectations.Address = common.HexToAddress("0x01")
+	warnings := Validate(message, expectations, now)
+	require.Equal(t, []Warning{WarningDomainMismatch, WarningChainIDMismatch, WarningAddressMismatch}, warnings)
+
+	require.Equal(t, []Warning{WarningExpired}, Validate(message, Expectations{}, now.Add(time.Hour)))
+	require.Equal(t, []Warning{WarningNotYetValid}, Validate(message, Expectations{}, now.Add(-time.Hour)))
+
+	message.Nonce = "1234"
+	require.Equal(t, []Warning{WarningInvalidNonce}, Validate(message, Expectations{}, now))
+}
+
+func TestAnalyze(t *testing.T) {
+	result, err := Analyze(hexutil.Encode([]byte("Please sign this message")), Expectations{})
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	result, err = Analyze(hexutil.Encode([]byte(testMessage)), Expectations{Origin: "https://evil.com"})
+	require.NoError(t, err)
+	require.Equal(t, "app.example.com", result.Message.Domain)
+	require.True(t, result.HasWarning(WarningDomainMismatch))
+}
+
+func TestVerify(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	message, err := Parse(testMessage)
+	require.NoError(t, err)
+	text := testMessage[:len("https://app.example.com wants you to sign in with your Ethereum account:\n")] + address.Hex() +
+		testMessage[len("https://app.example.com wants you to sign in with your Ethereum account:\n")+len(message.Address.Hex()):]
+
+	signature, err := crypto.Sign(accounts.TextHash([]byte(text)), key)
+	require.NoError(t, err)
+	signature[crypto.RecoveryIDOffset] += 27
+
+	result, err := Verify(text, types.HexBytes(signature), Expectations{Origin: "app.example.com"})
+	require.NoError(t, err)
+	require.Equal(t, address, result.Message.Address)
+	require.False(t, result.HasWarning(WarningDomainMismatch))
+
+	_, err = Verify(testMessage, types.HexBytes(signature), Expectations{})
+	require.Equal(t, ErrSignerMismatch, err)
+
+	_, err = Verify(text, types.HexBytes(signature[:10]), Expectations{})
+	require.Equal(t, ErrInvalidSignature, err)
+}
@@ -0,0 +1,163 @@
+package siwe
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/status-im/status-go/eth-node/types"
+)
+
+const minNonceLength = 8
+
+var (
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrSignerMismatch   = errors.New("message is not signed by the sign-in address")
+)
+
+// Warning is a problem found in a message, it is up to the user to sign it anyway
+type Warning string
+
+const (
+	WarningMalformed       Warning = "malformed"       // the message looks like a sign-in message but can't be parsed, so it can't be checked
+	WarningDomainMismatch  Warning = "domain-mismatch" // the message was requested by another domain, likely phishing
+	WarningChainIDMismatch Warning = "chain-id-mismatch"
+	WarningAddressMismatch Warning = "address-mismatch"
+	WarningInvalidNonce    Warning = "invalid-nonce" // EIP-4361 requires at least 8 alphanumeric characters
+	WarningExpired         Warning = "expired"
+	WarningNotYetValid     Warning = "not-yet-valid"
+)
+
+// Expectations are the values a message is checked against, the empty values are not checked
+type Expectations struct {
+	Origin  string         // URL of the dApp requesting the signature
+	ChainID uint64         // chain the dApp is connected to
+	Address common.Address // account asked to sign the message
+}
+
+// Result is the breakdown of a message with the warnings found
+type Result struct {
+	Message  *Message  `json:"message"`
+	Warnings []Warning `json:"warnings"`
+}
+
+func (r *Result) HasWarning(warning Warning) bool {
+	for _, w := range r.Warnings {
+		if w == warning {
+			return true
+		}
+	}
+	return false
+}
+
+func originHost(origin string) string {
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		// origins are sometimes provided without a scheme
+		return origin
+	}
+	return u.Host
+}
+
+func isValidNonce(nonce string) bool {
+	if len(nonce) < minNonceLength {
+		return false
+	}
+	for _, c := range nonce {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks the message against the expectations at the given time
+func Validate(message *Message, expectations Expectations, now time.Time) []Warning {
+	warnings := make([]Warning, 0)
+
+	if expectations.Origin != "" && !strings.EqualFold(message.Domain, originHost(expectations.Origin)) {
+		warnings = append(warnings, WarningDomainMismatch)
+	}
+	if expectations.ChainID != 0 && message.ChainID != expectations.ChainID {
+		warnings = append(warnings, WarningChainIDMismatch)
+	}
+	if expectations.Address != (common.Address{}) && message.Address != expectations.Address {
+		warnings = append(warnings, WarningAddressMismatch)
+	}
+	if !isValidNonce(message.Nonce) {
+		warnings = append(warnings, WarningInvalidNonce)
+	}
+	if message.ExpirationTime != nil && !now.Before(*message.ExpirationTime) {
+		warnings = append(warnings, WarningExpired)
+	}
+	if message.NotBefore != nil && now.Before(*message.NotBefore) {
+		warnings = append(warnings, WarningNotYetValid)
+	}
+
+	return warnings
+}
+
+// Analyze returns the breakdown of a "personal_sign" challenge, nil is returned if the challenge is not a SIWE message
+func Analyze(challenge string, expectations Expectations) (*Result, error) {
+	text := DecodeChallenge(challenge)
+	if !IsSIWEMessage(text) {
+		return nil, nil
+	}
+
+	message, err := Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Message:  message,
+		Warnings: Validate(message, expectations, time.Now()),
+	}, nil
+}
+
+// RecoverSigner returns the address which signed the message with "personal_sign"
+func RecoverSigner(message string, signature types.HexBytes) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, ErrInvalidSignature
+	}
+
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, signature)
+	// wallets use 27/28 for the recovery id
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	publicKey, err := crypto.SigToPub(accounts.TextHash([]byte(message)), sig)
+	if err != nil {
+		return common.Address{}, ErrInvalidSignature
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// Verify parses the message, checks it was signed by its sign-in address and validates it against the expectations
+func Verify(challenge string, signature types.HexBytes, expectations Expectations) (*Result, error) {
+	text := DecodeChallenge(challenge)
+	message, err := Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := RecoverSigner(text, signature)
+	if err != nil {
+		return nil, err
+	}
+	if signer != message.Address {
+		return nil, ErrSignerMismatch
+	}
+
+	return &Result{
+		Message:  message,
+		Warnings: Validate(message, expectations, time.Now()),
+	}, nil
+}
@@ -0,0 +1,169 @@
+package spam
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type AssetType string
+
+const (
+	AssetTypeToken       AssetType = "token"
+	AssetTypeCollectible AssetType = "collectible" // classified by contract
+)
+
+type Verdict string
+
+const (
+	VerdictNotSpam    Verdict = "not-spam"
+	VerdictSuspicious Verdict = "suspicious"
+	VerdictSpam       Verdict = "spam"
+)
+
+// Reason is a signal contributing to the spam score of an asset
+type Reason string
+
+const (
+	ReasonNotListed     Reason = "not-listed"     // the token is not part of any token list
+	ReasonImpersonation Reason = "impersonation"  // the symbol imitates the one of a listed token
+	ReasonNoLiquidity   Reason = "no-liquidity"   // there is no market for the token
+	ReasonKnownScam     Reason = "known-scam"     // the contract is part of an imported scam list
+	ReasonSuspiciousURL Reason = "suspicious-url" // the name or the symbol contain a link, a common lure of airdropped scams
+)
+
+var reasonScores = map[Reason]int{
+	ReasonNotListed:     20,
+	ReasonNoLiquidity:   25,
+	ReasonSuspiciousURL: 60,
+	ReasonImpersonation: 70,
+	ReasonKnownScam:     100,
+}
+
+const (
+	maxScore        = 100
+	spamScore       = 60
+	suspiciousScore = 40
+)
+
+var suspiciousURLRegex = regexp.MustCompile(`(?i)(https?://|www\.|t\.me/|\b[a-z0-9-]+\.(com|io|net|org|xyz|app|site|online|finance|fi|gift|claims?|top|club|live|pro|vip|cc|ly|to|link|click|website|info)\b)`)
+
+// confusables maps the Cyrillic and Greek letters used to imitate Latin symbols
+var confusables = map[rune]rune{
+	'А': 'A', 'В': 'B', 'С': 'C', 'Е': 'E', 'Н': 'H', 'І': 'I', 'Ј': 'J', 'К': 'K', 'М': 'M', 'О': 'O', 'Р': 'P', 'Ѕ': 'S', 'Т': 'T', 'Х': 'X', 'У': 'Y',
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K', 'Μ': 'M', 'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+}
+
+// normalizeSymbol returns the symbol as it is displayed to users, upper case Latin letters and digits only
+func normalizeSymbol(symbol string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(symbol) {
+		if latin, ok := confusables[r]; ok {
+			r = latin
+		}
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Asset is the asset to classify, the name and symbol of collectibles are the ones of the collection
+type Asset struct {
+	ChainID   uint64
+	Address   common.Address
+	AssetType AssetType
+	Name      string
+	Symbol    string
+	Listed    bool // part of a token list
+	Community bool // minted by a community, never spam
+}
+
+// Classification is the spam verdict of an asset, `UserVerdict` overrides the computed verdict when set
+type Classification struct {
+	ChainID     uint64         `json:"chainId"`
+	Address     common.Address `json:"address"`
+	AssetType   AssetType      `json:"assetType"`
+	Score       int            `json:"score"`
+	Verdict     Verdict        `json:"verdict"`
+	Reasons     []Reason       `json:"reasons"`
+	UserVerdict Verdict        `json:"userVerdict,omitempty"`
+	UpdatedAt   int64          `json:"updatedAt"`
+}
+
+func (c *Classification) EffectiveVerdict() Verdict {
+	if c.UserVerdict != "" {
+		return c.UserVerdict
+	}
+	return c.Verdict
+}
+
+func (c *Classification) IsSpam() bool {
+	return c.EffectiveVerdict() == VerdictSpam
+}
+
+// classificationInputs are the data shared by the classification of a batch of assets
+type classificationInputs struct {
+	listedSymbols map[string]bool // normalized symbols of the listed tokens
+	knownScams    map[assetKey]bool
+	liquidity     map[string]bool // liquidity by symbol, missing symbols are not checked
+}
+
+type assetKey struct {
+	chainID uint64
+	address common.Address
+}
+
+func verdictForScore(score int) Verdict {
+	switch {
+	case score >= spamScore:
+		return VerdictSpam
+	case score >= suspiciousScore:
+		return VerdictSuspicious
+	default:
+		return VerdictNotSpam
+	}
+}
+
+func classify(asset Asset, inputs *classificationInputs) *Classification {
+	reasons := make([]Reason, 0)
+
+	if inputs.knownScams[assetKey{asset.ChainID, asset.Address}] {
+		reasons = append(reasons, ReasonKnownScam)
+	}
+
+	if !asset.Community {
+		if suspiciousURLRegex.MatchString(asset.Name) || suspiciousURLRegex.MatchString(asset.Symbol) {
+			reasons = append(reasons, ReasonSuspiciousURL)
+		}
+
+		if asset.AssetType == AssetTypeToken && !asset.Listed {
+			reasons = append(reasons, ReasonNotListed)
+
+			if inputs.listedSymbols[normalizeSymbol(asset.Symbol)] {
+				reasons = append(reasons, ReasonImpersonation)
+			} else if hasLiquidity, checked := inputs.liquidity[asset.Symbol]; checked && !hasLiquidity {
+				reasons = append(reasons, ReasonNoLiquidity)
+			}
+		}
+	}
+
+	score := 0
+	for _, reason := range reasons {
+		score += reasonScores[reason]
+	}
+	if score > maxScore {
+		score = maxScore
+	}
+
+	return &Classification{
+		ChainID:   asset.ChainID,
+		Address:   asset.Address,
+		AssetType: asset.AssetType,
+		Score:     score,
+		Verdict:   verdictForScore(score),
+		Reasons:   reasons,
+	}
+}
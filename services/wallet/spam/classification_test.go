@@ -0,0 +1,94 @@
+package spam
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestNormalizeSymbol(t *testing.T) {
+	require.Equal(t, "USDC", normalizeSymbol("usdc"))
+	require.Equal(t, "USDC", normalizeSymbol("U.S.D.C"))
+	require.Equal(t, "USDT", normalizeSymbol("USDТ")) // Cyrillic Т
+	require.Equal(t, "ETH", normalizeSymbol("ΕΤΗ"))   // Greek letters
+	require.Equal(t, "", normalizeSymbol("🚀"))
+}
+
+func TestClassify(t *testing.T) {
+	scamAddress := common.HexToAddress("0x1")
+	inputs := &classificationInputs{
+		listedSymbols: map[string]bool{"USDC": true, "ETH": true},
+		knownScams:    map[assetKey]bool{{1, scamAddress}: true},
+		liquidity:     map[string]bool{"LIQ": true, "DEAD": false},
+	}
+
+	tests := []struct {
+		name    string
+		asset   Asset
+		verdict Verdict
+		reasons []Reason
+	}{
+		{
+			name:    "listed token",
+			asset:   Asset{ChainID: 1, Address: common.HexToAddress("0x2"), AssetType: AssetTypeToken, Name: "USD Coin", Symbol: "USDC", Listed: true},
+			verdict: VerdictNotSpam,
+			reasons: []Reason{},
+		},
+		{
+			name:    "unlisted token with liquidity",
+			asset:   Asset{ChainID: 1, Address: common.HexToAddress("0x3"), AssetType: AssetTypeToken, Name: "Liquid", Symbol: "LIQ"},
+			verdict: VerdictNotSpam,
+			reasons: []Reason{ReasonNotListed},
+		},
+		{
+			name:    "unlisted token without liquidity",
+			asset:   Asset{ChainID: 1, Address: common.HexToAddress("0x4"), AssetType: AssetTypeToken, Name: "Dead", Symbol: "DEAD"},
+			verdict: VerdictSuspicious,
+			reasons: []Reason{ReasonNotListed, ReasonNoLiquidity},
+		},
+		{
+			name:    "impersonation",
+			asset:   Asset{ChainID: 1, Address: common.HexToAddress("0x5"), AssetType: AssetTypeToken, Name: "USD Coin", Symbol: "USDС"}, // Cyrillic С
+			verdict: VerdictSpam,
+			reasons: []Reason{ReasonNotListed, ReasonImpersonation},
+		},
+		{
+			name:    "suspicious url",
+			asset:   Asset{ChainID: 1, Address: common.HexToAddress("0x6"), AssetType: AssetTypeCollectible, Name: "Claim your reward at www.free-nft.xyz"},
+			verdict: VerdictSpam,
+			reasons: []Reason{ReasonSuspiciousURL},
+		},
+		{
+			name:    "known scam",
+			asset:   Asset{ChainID: 1, Address: scamAddress, AssetType: AssetTypeToken, Name: "Scam", Symbol: "LIQ", Listed: true},
+			verdict: VerdictSpam,
+			reasons: []Reason{ReasonKnownScam},
+		},
+		{
+			name:    "community token",
+			asset:   Asset{ChainID: 1, Address: common.HexToAddress("0x7"), AssetType: AssetTypeToken, Name: "community.eth", Symbol: "ETH", Community: true},
+			verdict: VerdictNotSpam,
+			reasons: []Reason{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classification := classify(tt.asset, inputs)
+			require.Equal(t, tt.verdict, classification.Verdict)
+			require.Equal(t, tt.reasons, classification.Reasons)
+			require.LessOrEqual(t, classification.Score, maxScore)
+		})
+	}
+}
+
+func TestEffectiveVerdict(t *testing.T) {
+	classification := &Classification{Verdict: VerdictSpam}
+	require.True(t, classification.IsSpam())
+
+	classification.UserVerdict = VerdictNotSpam
+	require.False(t, classification.IsSpam())
+	require.Equal(t, VerdictNotSpam, classification.EffectiveVerdict())
+}
@@ -0,0 +1,260 @@
+package spam
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/status-im/status-go/services/wallet/token"
+)
+
+const classificationMaxAge = 24 * time.Hour
+
+type TokenProvider interface {
+	GetAllTokens() ([]*token.Token, error)
+	FindTokenByAddress(chainID uint64, address common.Address) *token.Token
+	DiscoverToken(ctx context.Context, chainID uint64, address common.Address) (*token.Token, error)
+}
+
+type LiquidityChecker interface {
+	// HasLiquidity tells by symbol if there is a market for the tokens, the symbols missing from the result are not checked
+	HasLiquidity(symbols []string) (map[string]bool, error)
+}
+
+// Classifier scores the tokens and the collectibles contracts held or received by the accounts and keeps their spam verdicts
+type Classifier struct {
+	persistence   *Persistence
+	tokenProvider TokenProvider
+	liquidity     LiquidityChecker // optional
+}
+
+func NewClassifier(db *sql.DB, tokenProvider TokenProvider, liquidity LiquidityChecker) *Classifier {
+	return &Classifier{
+		persistence:   NewPersistence(db),
+		tokenProvider: tokenProvider,
+		liquidity:     liquidity,
+	}
+}
+
+func (c *Classifier) classificationInputs(assets []Asset) (*classificationInputs, error) {
+	knownScams, err := c.persistence.getKnownScamContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := &classificationInputs{
+		listedSymbols: make(map[string]bool),
+		knownScams:    knownScams,
+		liquidity:     make(map[string]bool),
+	}
+
+	tokens, err := c.tokenProvider.GetAllTokens()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		if t.Verified {
+			inputs.listedSymbols[normalizeSymbol(t.Symbol)] = true
+		}
+	}
+
+	if c.liquidity == nil {
+		return inputs, nil
+	}
+
+	symbols := make([]string, 0)
+	for _, asset := range assets {
+		if asset.AssetType == AssetTypeToken && !asset.Listed && !asset.Community && asset.Symbol != "" {
+			symbols = append(symbols, asset.Symbol)
+		}
+	}
+	if len(symbols) > 0 {
+		liquidity, err := c.liquidity.HasLiquidity(symbols)
+		if err != nil {
+			// the liquidity is not taken into account, the classification is done again once stale
+			log.Warn("failed to check tokens liquidity", "error", err)
+		} else {
+			inputs.liquidity = liquidity
+		}
+	}
+	return inputs, nil
+}
+
+// Classify computes and stores the classifications of the assets, the user verdicts are kept
+func (c *Classifier) Classify(assets []Asset) ([]*Classification, error) {
+	if len(assets) == 0 {
+		return []*Classification{}, nil
+	}
+
+	inputs, err := c.classificationInputs(assets)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	classifications := make([]*Classification, 0, len(assets))
+	for _, asset := range assets {
+		classification := classify(asset, inputs)
+		classification.UpdatedAt = now
+		err = c.persistence.SaveClassification(classification)
+		if err != nil {
+			return nil, err
+		}
+
+		stored, err := c.persistence.GetClassification(asset.ChainID, asset.Address, asset.AssetType)
+		if err != nil {
+			return nil, err
+		}
+		classifications = append(classifications, stored)
+	}
+	return classifications, nil
+}
+
+// tokenAsset fills the token details from the token lists, unknown tokens are discovered on chain
+func (c *Classifier) tokenAsset(ctx context.Context, asset Asset) Asset {
+	t := c.tokenProvider.FindTokenByAddress(asset.ChainID, asset.Address)
+	if t == nil {
+		discovered, err := c.tokenProvider.DiscoverToken(ctx, asset.ChainID, asset.Address)
+		if err != nil {
+			log.Debug("failed to discover token", "chainID", asset.ChainID, "address", asset.Address, "error", err)
+			return asset
+		}
+		t = discovered
+	}
+
+	asset.Name = t.Name
+	asset.Symbol = t.Symbol
+	asset.Listed = t.Verified
+	asset.Community = asset.Community || t.CommunityData != nil
+	return asset
+}
+
+func classifiedBefore() int64 {
+	return time.Now().Add(-classificationMaxAge).Unix()
+}
+
+// ClassifyTransferredAssets classifies the tokens and collectibles transferred to or from the accounts which are not
+// classified yet or whose classification is stale, the activity hides the spam transfers once classified. It returns
+// the number of assets classified.
+func (c *Classifier) ClassifyTransferredAssets(ctx context.Context) (int, error) {
+	assets, err := c.persistence.unclassifiedTransferredAssets(classifiedBefore())
+	if err != nil {
+		return 0, err
+	}
+
+	for i, asset := range assets {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		if asset.AssetType == AssetTypeToken {
+			assets[i] = c.tokenAsset(ctx, asset)
+		}
+	}
+
+	classifications, err := c.Classify(assets)
+	return len(classifications), err
+}
+
+// ClassifyOwnedCollectibles classifies the contracts of the owned collectibles which are not classified yet or whose
+// classification is stale. It returns the number of contracts classified.
+func (c *Classifier) ClassifyOwnedCollectibles(ctx context.Context) (int, error) {
+	assets, err := c.persistence.unclassifiedOwnedCollectibles(classifiedBefore())
+	if err != nil {
+		return 0, err
+	}
+
+	classifications, err := c.Classify(assets)
+	return len(classifications), err
+}
+
+// classifyBalances returns the classifications of the tokens held, the missing or stale ones are computed
+func (c *Classifier) classifyBalances(balances map[common.Address][]token.StorageToken) (map[assetKey]*Classification, error) {
+	result := make(map[assetKey]*Classification)
+	toClassify := make([]Asset, 0)
+	minUpdatedAt := classifiedBefore()
+
+	for _, storageTokens := range balances {
+		for _, storageToken := range storageTokens {
+			for chainID, balance := range storageToken.BalancesPerChain {
+				key := assetKey{chainID, balance.Address}
+				if _, ok := result[key]; ok || balance.Address == (common.Address{}) {
+					continue
+				}
+
+				classification, err := c.persistence.GetClassification(chainID, balance.Address, AssetTypeToken)
+				if err != nil {
+					return nil, err
+				}
+				result[key] = classification
+
+				if classification == nil || classification.UpdatedAt < minUpdatedAt {
+					toClassify = append(toClassify, Asset{
+						ChainID:   chainID,
+						Address:   balance.Address,
+						AssetType: AssetTypeToken,
+						Name:      storageToken.Name,
+						Symbol:    storageToken.Symbol,
+						Listed:    storageToken.Verified,
+						Community: storageToken.CommunityData != nil,
+					})
+				}
+			}
+		}
+	}
+
+	classifications, err := c.Classify(toClassify)
+	if err != nil {
+		return nil, err
+	}
+	for _, classification := range classifications {
+		result[assetKey{classification.ChainID, classification.Address}] = classification
+	}
+	return result, nil
+}
+
+// FilterSpamBalances removes the balances of the spam tokens, the tokens without balances left are removed
+func (c *Classifier) FilterSpamBalances(balances map[common.Address][]token.StorageToken) (map[common.Address][]token.StorageToken, error) {
+	classifications, err := c.classifyBalances(balances)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[common.Address][]token.StorageToken, len(balances))
+	for account, storageTokens := range balances {
+		filtered := make([]token.StorageToken, 0, len(storageTokens))
+		for _, storageToken := range storageTokens {
+			balancesPerChain := make(map[uint64]token.ChainBalance, len(storageToken.BalancesPerChain))
+			for chainID, balance := range storageToken.BalancesPerChain {
+				classification := classifications[assetKey{chainID, balance.Address}]
+				if classification != nil && classification.IsSpam() {
+					continue
+				}
+				balancesPerChain[chainID] = balance
+			}
+
+			if len(balancesPerChain) == 0 && len(storageToken.BalancesPerChain) > 0 {
+				continue
+			}
+			storageToken.BalancesPerChain = balancesPerChain
+			filtered = append(filtered, storageToken)
+		}
+		result[account] = filtered
+	}
+	return result, nil
+}
+
+func (c *Classifier) GetClassifications(assetType AssetType, onlySpam bool) ([]*Classification, error) {
+	return c.persistence.GetClassifications(assetType, onlySpam)
+}
+
+// SetUserVerdict overrides the computed verdict of an asset, an empty verdict restores the computed one
+func (c *Classifier) SetUserVerdict(chainID uint64, address common.Address, assetType AssetType, verdict Verdict) error {
+	return c.persistence.SetUserVerdict(chainID, address, assetType, verdict, time.Now().Unix())
+}
+
+func (c *Classifier) AddKnownScamContracts(source string, contracts []KnownScamContract) error {
+	return c.persistence.AddKnownScamContracts(source, contracts)
+}
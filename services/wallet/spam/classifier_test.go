@@ -0,0 +1,165 @@
+package spam
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/token"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+type testTokenProvider struct {
+	tokens []*token.Token
+}
+
+func (p *testTokenProvider) GetAllTokens() ([]*token.Token, error) {
+	return p.tokens, nil
+}
+
+func (p *testTokenProvider) FindTokenByAddress(chainID uint64, address common.Address) *token.Token {
+	for _, t := range p.tokens {
+		if t.ChainID == chainID && t.Address == address {
+			return t
+		}
+	}
+	return nil
+}
+
+func (p *testTokenProvider) DiscoverToken(ctx context.Context, chainID uint64, address common.Address) (*token.Token, error) {
+	return nil, errors.New("not found")
+}
+
+type testLiquidityChecker struct {
+	liquidity map[string]bool
+}
+
+func (c *testLiquidityChecker) HasLiquidity(symbols []string) (map[string]bool, error) {
+	return c.liquidity, nil
+}
+
+func setupClassifierTest(t *testing.T) (*Classifier, func()) {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+
+	tokenProvider := &testTokenProvider{
+		tokens: []*token.Token{
+			{ChainID: 1, Address: common.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"), Symbol: "USDC", Name: "USD Coin", Verified: true},
+		},
+	}
+	liquidity := &testLiquidityChecker{liquidity: map[string]bool{"LIQ": true}}
+
+	return NewClassifier(db, tokenProvider, liquidity), func() {
+		require.NoError(t, db.Close())
+	}
+}
+
+func storageToken(chainID uint64, address common.Address, symbol string, verified bool) token.StorageToken {
+	return token.StorageToken{
+		Token: token.Token{
+			ChainID:  chainID,
+			Address:  address,
+			Symbol:   symbol,
+			Name:     symbol,
+			Verified: verified,
+		},
+		BalancesPerChain: map[uint64]token.ChainBalance{
+			chainID: {
+				RawBalance: "1",
+				Balance:    big.NewFloat(1),
+				Address:    address,
+				ChainID:    chainID,
+			},
+		},
+	}
+}
+
+func TestFilterSpamBalances(t *testing.T) {
+	classifier, cleanup := setupClassifierTest(t)
+	defer cleanup()
+
+	account := common.HexToAddress("0x1")
+	usdc := storageToken(1, common.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"), "USDC", true)
+	fakeUSDC := storageToken(1, common.HexToAddress("0x2"), "USDС", false) // Cyrillic С
+	liquid := storageToken(1, common.HexToAddress("0x3"), "LIQ", false)
+	eth := storageToken(1, common.Address{}, "ETH", true)
+
+	balances := map[common.Address][]token.StorageToken{
+		account: {usdc, fakeUSDC, liquid, eth},
+	}
+
+	filtered, err := classifier.FilterSpamBalances(balances)
+	require.NoError(t, err)
+	require.Len(t, filtered[account], 3)
+	for _, storageToken := range filtered[account] {
+		require.NotEqual(t, fakeUSDC.Address, storageToken.Address)
+	}
+
+	spam, err := classifier.GetClassifications(AssetTypeToken, true)
+	require.NoError(t, err)
+	require.Len(t, spam, 1)
+	require.Equal(t, fakeUSDC.Address, spam[0].Address)
+	require.Equal(t, []Reason{ReasonNotListed, ReasonImpersonation}, spam[0].Reasons)
+
+	// The user verdict overrides the computed one and survives the classification
+	err = classifier.SetUserVerdict(1, fakeUSDC.Address, AssetTypeToken, VerdictNotSpam)
+	require.NoError(t, err)
+
+	filtered, err = classifier.FilterSpamBalances(balances)
+	require.NoError(t, err)
+	require.Len(t, filtered[account], 4)
+
+	// The user can flag a token not classified as spam
+	err = classifier.SetUserVerdict(1, liquid.Address, AssetTypeToken, VerdictSpam)
+	require.NoError(t, err)
+
+	filtered, err = classifier.FilterSpamBalances(balances)
+	require.NoError(t, err)
+	require.Len(t, filtered[account], 3)
+}
+
+func TestKnownScamContracts(t *testing.T) {
+	classifier, cleanup := setupClassifierTest(t)
+	defer cleanup()
+
+	account := common.HexToAddress("0x1")
+	liquid := storageToken(1, common.HexToAddress("0x3"), "LIQ", false)
+	balances := map[common.Address][]token.StorageToken{
+		account: {liquid},
+	}
+
+	filtered, err := classifier.FilterSpamBalances(balances)
+	require.NoError(t, err)
+	require.Len(t, filtered[account], 1)
+
+	err = classifier.AddKnownScamContracts("test", []KnownScamContract{{ChainID: 1, Address: liquid.Address}})
+	require.NoError(t, err)
+
+	filtered, err = classifier.FilterSpamBalances(balances)
+	require.NoError(t, err)
+	require.Len(t, filtered[account], 0)
+}
+
+func TestClassifyOwnedCollectibles(t *testing.T) {
+	classifier, cleanup := setupClassifierTest(t)
+	defer cleanup()
+
+	_, err := classifier.persistence.db.Exec(`INSERT INTO collectibles_ownership_cache (chain_id, contract_address, token_id,
+		owner_address, balance) VALUES (?, ?, ?, ?, ?)`, 1, common.HexToAddress("0x4"), []byte{1}, common.HexToAddress("0x1"), "1")
+	require.NoError(t, err)
+
+	classified, err := classifier.ClassifyOwnedCollectibles(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, classified)
+
+	// nothing is classified again while the classification is fresh, so the collectibles aren't filtered again
+	classified, err = classifier.ClassifyOwnedCollectibles(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 0, classified)
+}
@@ -0,0 +1,31 @@
+package spam
+
+import (
+	"github.com/status-im/status-go/services/wallet/market"
+)
+
+const liquidityCurrency = "USD"
+
+// MarketLiquidityChecker considers a token liquid when the market data providers know a price for it
+type MarketLiquidityChecker struct {
+	marketManager *market.Manager
+}
+
+func NewMarketLiquidityChecker(marketManager *market.Manager) *MarketLiquidityChecker {
+	return &MarketLiquidityChecker{
+		marketManager: marketManager,
+	}
+}
+
+func (c *MarketLiquidityChecker) HasLiquidity(symbols []string) (map[string]bool, error) {
+	prices, err := c.marketManager.GetOrFetchPrices(symbols, []string{liquidityCurrency}, market.MaxAgeInSecondsForBalances)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		result[symbol] = prices[symbol][liquidityCurrency].Price > 0
+	}
+	return result, nil
+}
@@ -0,0 +1,220 @@
+package spam
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const classificationColumns = "chain_id, address, asset_type, score, verdict, reasons, user_verdict, updated_at"
+
+// SpamCondition matches the asset_spam_classifications rows of the spam assets, the user verdict takes precedence.
+// The activity and collectibles queries use it to hide spam, filter.sql of the activity embeds the same condition.
+const SpamCondition = "(CASE WHEN user_verdict != '' THEN user_verdict ELSE verdict END) = 'spam'"
+
+type KnownScamContract struct {
+	ChainID uint64         `json:"chainId"`
+	Address common.Address `json:"address"`
+}
+
+type Persistence struct {
+	db *sql.DB
+}
+
+func NewPersistence(db *sql.DB) *Persistence {
+	return &Persistence{db: db}
+}
+
+func joinReasons(reasons []Reason) string {
+	values := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		values = append(values, string(reason))
+	}
+	return strings.Join(values, ",")
+}
+
+func splitReasons(value string) []Reason {
+	reasons := make([]Reason, 0)
+	if value == "" {
+		return reasons
+	}
+	for _, reason := range strings.Split(value, ",") {
+		reasons = append(reasons, Reason(reason))
+	}
+	return reasons
+}
+
+// SaveClassification stores the computed verdict, the user verdict is kept
+func (p *Persistence) SaveClassification(c *Classification) error {
+	_, err := p.db.Exec(`INSERT INTO asset_spam_classifications (`+classificationColumns+`) VALUES (?, ?, ?, ?, ?, ?, '', ?)
+		ON CONFLICT (chain_id, address, asset_type) DO UPDATE SET score = excluded.score, verdict = excluded.verdict,
+		reasons = excluded.reasons, updated_at = excluded.updated_at`,
+		c.ChainID, c.Address, c.AssetType, c.Score, c.Verdict, joinReasons(c.Reasons), c.UpdatedAt)
+	return err
+}
+
+// SetUserVerdict overrides the verdict of an asset, it is created if not classified yet, an empty verdict clears the override
+func (p *Persistence) SetUserVerdict(chainID uint64, address common.Address, assetType AssetType, verdict Verdict, updatedAt int64) error {
+	_, err := p.db.Exec(`INSERT INTO asset_spam_classifications (`+classificationColumns+`) VALUES (?, ?, ?, 0, ?, '', ?, ?)
+		ON CONFLICT (chain_id, address, asset_type) DO UPDATE SET user_verdict = excluded.user_verdict`,
+		chainID, address, assetType, VerdictNotSpam, verdict, updatedAt)
+	return err
+}
+
+func rowsToClassifications(rows *sql.Rows) ([]*Classification, error) {
+	defer rows.Close()
+
+	classifications := make([]*Classification, 0)
+	for rows.Next() {
+		c := &Classification{}
+		var reasons string
+		err := rows.Scan(&c.ChainID, &c.Address, &c.AssetType, &c.Score, &c.Verdict, &reasons, &c.UserVerdict, &c.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		c.Reasons = splitReasons(reasons)
+		classifications = append(classifications, c)
+	}
+	return classifications, rows.Err()
+}
+
+func (p *Persistence) GetClassification(chainID uint64, address common.Address, assetType AssetType) (*Classification, error) {
+	rows, err := p.db.Query(`SELECT `+classificationColumns+` FROM asset_spam_classifications WHERE chain_id = ? AND address = ? AND asset_type = ?`,
+		chainID, address, assetType)
+	if err != nil {
+		return nil, err
+	}
+
+	classifications, err := rowsToClassifications(rows)
+	if err != nil || len(classifications) == 0 {
+		return nil, err
+	}
+	return classifications[0], nil
+}
+
+// GetClassifications returns the classifications of the given type, the spam assets only if `onlySpam` is set
+func (p *Persistence) GetClassifications(assetType AssetType, onlySpam bool) ([]*Classification, error) {
+	query := `SELECT ` + classificationColumns + ` FROM asset_spam_classifications WHERE asset_type = ?`
+	if onlySpam {
+		query += ` AND ` + SpamCondition
+	}
+	rows, err := p.db.Query(query, assetType)
+	if err != nil {
+		return nil, err
+	}
+	return rowsToClassifications(rows)
+}
+
+// AddKnownScamContracts imports the contracts of a scam list, `source` identifies the list
+func (p *Persistence) AddKnownScamContracts(source string, contracts []KnownScamContract) (err error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+			return
+		}
+		_ = tx.Rollback()
+	}()
+
+	for _, contract := range contracts {
+		_, err = tx.Exec(`INSERT OR REPLACE INTO known_scam_contracts (chain_id, address, source) VALUES (?, ?, ?)`,
+			contract.ChainID, contract.Address, source)
+		if err != nil {
+			return err
+		}
+
+		// the contract is classified again on the next classification
+		_, err = tx.Exec(`UPDATE asset_spam_classifications SET updated_at = 0 WHERE chain_id = ? AND address = ?`,
+			contract.ChainID, contract.Address)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Persistence) getKnownScamContracts() (map[assetKey]bool, error) {
+	rows, err := p.db.Query(`SELECT chain_id, address FROM known_scam_contracts`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contracts := make(map[assetKey]bool)
+	for rows.Next() {
+		var key assetKey
+		err := rows.Scan(&key.chainID, &key.address)
+		if err != nil {
+			return nil, err
+		}
+		contracts[key] = true
+	}
+	return contracts, rows.Err()
+}
+
+// unclassifiedTransferredAssets returns the contracts of the tokens and collectibles transferred which are not classified yet
+// or were classified before `classifiedBefore`, the names of the collections are set when known
+func (p *Persistence) unclassifiedTransferredAssets(classifiedBefore int64) ([]Asset, error) {
+	rows, err := p.db.Query(`
+		SELECT DISTINCT transfers.network_id, transfers.token_address,
+			CASE WHEN transfers.type = 'erc20' THEN 'token' ELSE 'collectible' END AS transfer_asset_type,
+			COALESCE(collection_data_cache.name, ''),
+			COALESCE(collection_data_cache.community_id, '') != '' OR COALESCE(tokens.community_id, '') != ''
+		FROM transfers
+		LEFT JOIN tokens ON tokens.network_id = transfers.network_id AND tokens.address = transfers.token_address
+		LEFT JOIN collection_data_cache ON collection_data_cache.chain_id = transfers.network_id
+			AND collection_data_cache.contract_address = transfers.token_address
+		LEFT JOIN asset_spam_classifications spam ON spam.chain_id = transfers.network_id
+			AND spam.address = transfers.token_address
+			AND spam.asset_type = (CASE WHEN transfers.type = 'erc20' THEN 'token' ELSE 'collectible' END)
+		WHERE transfers.type IN ('erc20', 'erc721', 'erc1155') AND transfers.token_address IS NOT NULL
+			AND (spam.chain_id IS NULL OR spam.updated_at < ?)`, classifiedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assets := make([]Asset, 0)
+	for rows.Next() {
+		var asset Asset
+		err := rows.Scan(&asset.ChainID, &asset.Address, &asset.AssetType, &asset.Name, &asset.Community)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, asset)
+	}
+	return assets, rows.Err()
+}
+
+// unclassifiedOwnedCollectibles returns the contracts of the owned collectibles which are not classified yet
+// or were classified before `classifiedBefore`
+func (p *Persistence) unclassifiedOwnedCollectibles(classifiedBefore int64) ([]Asset, error) {
+	rows, err := p.db.Query(`
+		SELECT DISTINCT ownership.chain_id, ownership.contract_address, COALESCE(collection_data_cache.name, ''),
+			COALESCE(collection_data_cache.community_id, '') != ''
+		FROM collectibles_ownership_cache ownership
+		LEFT JOIN collection_data_cache ON collection_data_cache.chain_id = ownership.chain_id
+			AND collection_data_cache.contract_address = ownership.contract_address
+		LEFT JOIN asset_spam_classifications spam ON spam.chain_id = ownership.chain_id
+			AND spam.address = ownership.contract_address AND spam.asset_type = 'collectible'
+		WHERE spam.chain_id IS NULL OR spam.updated_at < ?`, classifiedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assets := make([]Asset, 0)
+	for rows.Next() {
+		asset := Asset{AssetType: AssetTypeCollectible}
+		err := rows.Scan(&asset.ChainID, &asset.Address, &asset.Name, &asset.Community)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, asset)
+	}
+	return assets, rows.Err()
+}
@@ -0,0 +1,96 @@
+package bundler
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	sendUserOperationMethod        = "eth_sendUserOperation"
+	estimateUserOperationGasMethod = "eth_estimateUserOperationGas"
+	getUserOperationReceiptMethod  = "eth_getUserOperationReceipt"
+	sponsorUserOperationMethod     = "pm_sponsorUserOperation"
+)
+
+var ErrEmptyUserOperationHash = errors.New("bundler returned an empty user operation hash")
+
+// Client is a JSON-RPC client for an ERC-4337 bundler
+type Client struct {
+	rpcClient  *gethrpc.Client
+	entryPoint common.Address
+}
+
+func NewClient(url string, entryPoint common.Address) (*Client, error) {
+	rpcClient, err := gethrpc.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		rpcClient:  rpcClient,
+		entryPoint: entryPoint,
+	}, nil
+}
+
+func (c *Client) EntryPoint() common.Address {
+	return c.entryPoint
+}
+
+func (c *Client) SendUserOperation(ctx context.Context, userOp *UserOperation) (common.Hash, error) {
+	var hash common.Hash
+	err := c.rpcClient.CallContext(ctx, &hash, sendUserOperationMethod, userOp, c.entryPoint)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if hash == (common.Hash{}) {
+		return common.Hash{}, ErrEmptyUserOperationHash
+	}
+	return hash, nil
+}
+
+func (c *Client) EstimateUserOperationGas(ctx context.Context, userOp *UserOperation) (*UserOperationGasEstimate, error) {
+	var estimate UserOperationGasEstimate
+	err := c.rpcClient.CallContext(ctx, &estimate, estimateUserOperationGasMethod, userOp, c.entryPoint)
+	if err != nil {
+		return nil, err
+	}
+	return &estimate, nil
+}
+
+// GetUserOperationReceipt returns nil, without an error, while the user operation is not yet included in a block
+func (c *Client) GetUserOperationReceipt(ctx context.Context, userOpHash common.Hash) (*UserOperationReceipt, error) {
+	var receipt *UserOperationReceipt
+	err := c.rpcClient.CallContext(ctx, &receipt, getUserOperationReceiptMethod, userOpHash)
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// PaymasterClient is a JSON-RPC client for a verifying paymaster service which sponsors the user operations gas
+type PaymasterClient struct {
+	rpcClient *gethrpc.Client
+}
+
+func NewPaymasterClient(url string) (*PaymasterClient, error) {
+	rpcClient, err := gethrpc.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaymasterClient{
+		rpcClient: rpcClient,
+	}, nil
+}
+
+func (c *PaymasterClient) SponsorUserOperation(ctx context.Context, userOp *UserOperation, entryPoint common.Address) (*SponsorshipResult, error) {
+	var result SponsorshipResult
+	err := c.rpcClient.CallContext(ctx, &result, sponsorUserOperationMethod, userOp, entryPoint)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,170 @@
+package bundler
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// mockBundler implements the bundler "eth" and the paymaster "pm" JSON-RPC namespaces
+type mockBundler struct {
+	chainID  uint64
+	received []UserOperation
+	included map[common.Hash]bool
+}
+
+func (b *mockBundler) SendUserOperation(userOp UserOperation, entryPoint common.Address) (common.Hash, error) {
+	if entryPoint != EntryPointV06Address {
+		return common.Hash{}, errors.New("unsupported entry point")
+	}
+	if len(userOp.Signature) == 0 {
+		return common.Hash{}, errors.New("missing signature")
+	}
+	b.received = append(b.received, userOp)
+	return userOp.Hash(entryPoint, b.chainID)
+}
+
+func (b *mockBundler) EstimateUserOperationGas(userOp UserOperation, entryPoint common.Address) (*UserOperationGasEstimate, error) {
+	return &UserOperationGasEstimate{
+		PreVerificationGas:   (*hexutil.Big)(big.NewInt(45000)),
+		VerificationGasLimit: (*hexutil.Big)(big.NewInt(70000)),
+		CallGasLimit:         (*hexutil.Big)(big.NewInt(35000)),
+	}, nil
+}
+
+func (b *mockBundler) GetUserOperationReceipt(userOpHash common.Hash) (*UserOperationReceipt, error) {
+	success, ok := b.included[userOpHash]
+	if !ok {
+		return nil, nil
+	}
+	return &UserOperationReceipt{
+		UserOpHash: userOpHash,
+		EntryPoint: EntryPointV06Address,
+		Success:    success,
+		Receipt: TransactionReceipt{
+			TransactionHash: common.HexToHash("0x1234"),
+			BlockNumber:     (*hexutil.Big)(big.NewInt(100)),
+		},
+	}, nil
+}
+
+type mockPaymaster struct{}
+
+func (p *mockPaymaster) SponsorUserOperation(userOp UserOperation, entryPoint common.Address) (*SponsorshipResult, error) {
+	return &SponsorshipResult{
+		PaymasterAndData:     common.FromHex("0xabcdef"),
+		PreVerificationGas:   (*hexutil.Big)(big.NewInt(50000)),
+		VerificationGasLimit: (*hexutil.Big)(big.NewInt(100000)),
+		CallGasLimit:         (*hexutil.Big)(big.NewInt(40000)),
+	}, nil
+}
+
+func setupMockBundler(t *testing.T, bundler *mockBundler) string {
+	server := gethrpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", bundler))
+	require.NoError(t, server.RegisterName("pm", &mockPaymaster{}))
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return httpServer.URL
+}
+
+func testUserOperation() *UserOperation {
+	return &UserOperation{
+		Sender:               common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		Nonce:                (*hexutil.Big)(big.NewInt(1)),
+		CallData:             common.FromHex("0xb61d27f6"),
+		CallGasLimit:         (*hexutil.Big)(big.NewInt(35000)),
+		VerificationGasLimit: (*hexutil.Big)(big.NewInt(70000)),
+		PreVerificationGas:   (*hexutil.Big)(big.NewInt(45000)),
+		MaxFeePerGas:         (*hexutil.Big)(big.NewInt(2000000000)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(big.NewInt(1000000000)),
+		Signature:            common.FromHex("0x01"),
+	}
+}
+
+func TestClient_UserOperationFlow(t *testing.T) {
+	bundler := &mockBundler{chainID: 1, included: make(map[common.Hash]bool)}
+	client, err := NewClient(setupMockBundler(t, bundler), EntryPointV06Address)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	userOp := testUserOperation()
+
+	estimate, err := client.EstimateUserOperationGas(ctx, userOp)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(45000), estimate.PreVerificationGas.ToInt())
+	require.Equal(t, big.NewInt(70000), estimate.VerificationGasLimit.ToInt())
+	require.Equal(t, big.NewInt(35000), estimate.CallGasLimit.ToInt())
+
+	hash, err := client.SendUserOperation(ctx, userOp)
+	require.NoError(t, err)
+	expectedHash, err := userOp.Hash(EntryPointV06Address, 1)
+	require.NoError(t, err)
+	require.Equal(t, expectedHash, hash)
+	require.Len(t, bundler.received, 1)
+	require.Equal(t, userOp.Sender, bundler.received[0].Sender)
+
+	receipt, err := client.GetUserOperationReceipt(ctx, hash)
+	require.NoError(t, err)
+	require.Nil(t, receipt)
+
+	bundler.included[hash] = true
+	receipt, err = client.GetUserOperationReceipt(ctx, hash)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	require.True(t, receipt.Success)
+	require.Equal(t, common.HexToHash("0x1234"), receipt.Receipt.TransactionHash)
+}
+
+func TestClient_SendUserOperationError(t *testing.T) {
+	client, err := NewClient(setupMockBundler(t, &mockBundler{chainID: 1}), EntryPointV06Address)
+	require.NoError(t, err)
+
+	userOp := testUserOperation()
+	userOp.Signature = nil
+	_, err = client.SendUserOperation(context.Background(), userOp)
+	require.Error(t, err)
+}
+
+func TestPaymasterClient_SponsorUserOperation(t *testing.T) {
+	client, err := NewPaymasterClient(setupMockBundler(t, &mockBundler{chainID: 1}))
+	require.NoError(t, err)
+
+	result, err := client.SponsorUserOperation(context.Background(), testUserOperation(), EntryPointV06Address)
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Bytes(common.FromHex("0xabcdef")), result.PaymasterAndData)
+	require.Equal(t, big.NewInt(100000), result.VerificationGasLimit.ToInt())
+}
+
+func TestUserOperation_Hash(t *testing.T) {
+	userOp := testUserOperation()
+	hash, err := userOp.Hash(EntryPointV06Address, 1)
+	require.NoError(t, err)
+
+	// The signature is not part of the hash
+	userOp.Signature = common.FromHex("0x02")
+	sameHash, err := userOp.Hash(EntryPointV06Address, 1)
+	require.NoError(t, err)
+	require.Equal(t, hash, sameHash)
+
+	otherChainHash, err := userOp.Hash(EntryPointV06Address, 10)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, otherChainHash)
+
+	userOp.Nonce = (*hexutil.Big)(big.NewInt(2))
+	otherNonceHash, err := userOp.Hash(EntryPointV06Address, 1)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, otherNonceHash)
+}
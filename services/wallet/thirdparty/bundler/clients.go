@@ -0,0 +1,61 @@
+package bundler
+
+import (
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Clients holds the bundler and the optional paymaster clients configured for each chain
+type Clients struct {
+	bundlers   map[uint64]ClientInterface
+	paymasters map[uint64]PaymasterClientInterface
+}
+
+// NewClients creates the clients for the configured chains. Chains whose endpoint can't be used are skipped.
+func NewClients(bundlerURLs map[uint64]string, paymasterURLs map[uint64]string) *Clients {
+	c := &Clients{
+		bundlers:   make(map[uint64]ClientInterface),
+		paymasters: make(map[uint64]PaymasterClientInterface),
+	}
+
+	for chainID, url := range bundlerURLs {
+		client, err := NewClient(url, EntryPointV06Address)
+		if err != nil {
+			log.Error("failed to create bundler client", "chainID", chainID, "err", err)
+			continue
+		}
+		c.bundlers[chainID] = client
+	}
+
+	for chainID, url := range paymasterURLs {
+		client, err := NewPaymasterClient(url)
+		if err != nil {
+			log.Error("failed to create paymaster client", "chainID", chainID, "err", err)
+			continue
+		}
+		c.paymasters[chainID] = client
+	}
+
+	return c
+}
+
+func (c *Clients) SetBundler(chainID uint64, client ClientInterface) {
+	c.bundlers[chainID] = client
+}
+
+func (c *Clients) SetPaymaster(chainID uint64, client PaymasterClientInterface) {
+	c.paymasters[chainID] = client
+}
+
+func (c *Clients) Bundler(chainID uint64) (ClientInterface, bool) {
+	client, ok := c.bundlers[chainID]
+	return client, ok
+}
+
+func (c *Clients) Paymaster(chainID uint64) (PaymasterClientInterface, bool) {
+	client, ok := c.paymasters[chainID]
+	return client, ok
+}
+
+func (c *Clients) HasBundlers() bool {
+	return len(c.bundlers) > 0
+}
@@ -0,0 +1,73 @@
+package bundler
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// EntryPointV06Address is the address of the ERC-4337 EntryPoint v0.6 contract, it is the same on all chains
+var EntryPointV06Address = common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+
+// UserOperation in the format expected by the EntryPoint v0.6 and by the bundlers JSON-RPC API
+type UserOperation struct {
+	Sender               common.Address `json:"sender"`
+	Nonce                *hexutil.Big   `json:"nonce"`
+	InitCode             hexutil.Bytes  `json:"initCode"`
+	CallData             hexutil.Bytes  `json:"callData"`
+	CallGasLimit         *hexutil.Big   `json:"callGasLimit"`
+	VerificationGasLimit *hexutil.Big   `json:"verificationGasLimit"`
+	PreVerificationGas   *hexutil.Big   `json:"preVerificationGas"`
+	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	PaymasterAndData     hexutil.Bytes  `json:"paymasterAndData"`
+	Signature            hexutil.Bytes  `json:"signature"`
+}
+
+type UserOperationGasEstimate struct {
+	PreVerificationGas   *hexutil.Big `json:"preVerificationGas"`
+	VerificationGasLimit *hexutil.Big `json:"verificationGasLimit"`
+	CallGasLimit         *hexutil.Big `json:"callGasLimit"`
+}
+
+// SponsorshipResult is returned by the paymaster, the gas limits must be used as they are covered by the signature in PaymasterAndData
+type SponsorshipResult struct {
+	PaymasterAndData     hexutil.Bytes `json:"paymasterAndData"`
+	PreVerificationGas   *hexutil.Big  `json:"preVerificationGas"`
+	VerificationGasLimit *hexutil.Big  `json:"verificationGasLimit"`
+	CallGasLimit         *hexutil.Big  `json:"callGasLimit"`
+}
+
+type TransactionReceipt struct {
+	TransactionHash common.Hash  `json:"transactionHash"`
+	BlockHash       common.Hash  `json:"blockHash"`
+	BlockNumber     *hexutil.Big `json:"blockNumber"`
+}
+
+// UserOperationReceipt is nil until the user operation is included in a block
+type UserOperationReceipt struct {
+	UserOpHash    common.Hash        `json:"userOpHash"`
+	EntryPoint    common.Address     `json:"entryPoint"`
+	Sender        common.Address     `json:"sender"`
+	Nonce         *hexutil.Big       `json:"nonce"`
+	Paymaster     common.Address     `json:"paymaster"`
+	ActualGasCost *hexutil.Big       `json:"actualGasCost"`
+	ActualGasUsed *hexutil.Big       `json:"actualGasUsed"`
+	Success       bool               `json:"success"`
+	Reason        string             `json:"reason"`
+	Receipt       TransactionReceipt `json:"receipt"`
+}
+
+//go:generate mockgen -package=mock_bundler -source=types.go -destination=mock/types.go
+
+type ClientInterface interface {
+	EntryPoint() common.Address
+	SendUserOperation(ctx context.Context, userOp *UserOperation) (common.Hash, error)
+	EstimateUserOperationGas(ctx context.Context, userOp *UserOperation) (*UserOperationGasEstimate, error)
+	GetUserOperationReceipt(ctx context.Context, userOpHash common.Hash) (*UserOperationReceipt, error)
+}
+
+type PaymasterClientInterface interface {
+	SponsorUserOperation(ctx context.Context, userOp *UserOperation, entryPoint common.Address) (*SponsorshipResult, error)
+}
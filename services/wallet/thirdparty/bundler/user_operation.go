@@ -0,0 +1,73 @@
+package bundler
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	uint256Type, _ = abi.NewType("uint256", "", nil)
+	addressType, _ = abi.NewType("address", "", nil)
+	bytes32Type, _ = abi.NewType("bytes32", "", nil)
+
+	userOperationPackArgs = abi.Arguments{
+		{Type: addressType}, // sender
+		{Type: uint256Type}, // nonce
+		{Type: bytes32Type}, // keccak256(initCode)
+		{Type: bytes32Type}, // keccak256(callData)
+		{Type: uint256Type}, // callGasLimit
+		{Type: uint256Type}, // verificationGasLimit
+		{Type: uint256Type}, // preVerificationGas
+		{Type: uint256Type}, // maxFeePerGas
+		{Type: uint256Type}, // maxPriorityFeePerGas
+		{Type: bytes32Type}, // keccak256(paymasterAndData)
+	}
+
+	userOperationHashArgs = abi.Arguments{
+		{Type: bytes32Type}, // keccak256(packed user operation)
+		{Type: addressType}, // entry point
+		{Type: uint256Type}, // chain ID
+	}
+)
+
+func bigOrZero(v *hexutil.Big) *big.Int {
+	if v == nil {
+		return big.NewInt(0)
+	}
+	return v.ToInt()
+}
+
+// Hash returns the user operation hash, as computed by EntryPoint v0.6 getUserOpHash. This is the hash the account owner signs
+// and the one bundlers use to identify the user operation.
+func (u *UserOperation) Hash(entryPoint common.Address, chainID uint64) (common.Hash, error) {
+	packed, err := userOperationPackArgs.Pack(
+		u.Sender,
+		bigOrZero(u.Nonce),
+		crypto.Keccak256Hash(u.InitCode),
+		crypto.Keccak256Hash(u.CallData),
+		bigOrZero(u.CallGasLimit),
+		bigOrZero(u.VerificationGasLimit),
+		bigOrZero(u.PreVerificationGas),
+		bigOrZero(u.MaxFeePerGas),
+		bigOrZero(u.MaxPriorityFeePerGas),
+		crypto.Keccak256Hash(u.PaymasterAndData),
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	encoded, err := userOperationHashArgs.Pack(
+		crypto.Keccak256Hash(packed),
+		entryPoint,
+		new(big.Int).SetUint64(chainID),
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return crypto.Keccak256Hash(encoded), nil
+}
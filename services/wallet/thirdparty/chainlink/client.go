@@ -0,0 +1,203 @@
+package chainlink
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/rpc"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+const ChainlinkID = "chainlink"
+
+const (
+	requestTimeout = 10 * time.Second
+	// maxFeedAge is above the longest heartbeat of the feeds used, older answers are not trusted
+	maxFeedAge  = 25 * time.Hour
+	usdCurrency = "USD"
+)
+
+// AggregatorV3Interface subset used to read the feeds
+const aggregatorV3ABI = `[{"inputs":[],"name":"decimals","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"latestRoundData","outputs":[{"internalType":"uint80","name":"roundId","type":"uint80"},{"internalType":"int256","name":"answer","type":"int256"},{"internalType":"uint256","name":"startedAt","type":"uint256"},{"internalType":"uint256","name":"updatedAt","type":"uint256"},{"internalType":"uint80","name":"answeredInRound","type":"uint80"}],"stateMutability":"view","type":"function"}]`
+
+// Ethereum mainnet price feeds of the tokens, quoted in USD
+var tokenFeeds = map[string]common.Address{
+	"ETH":  common.HexToAddress("0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419"),
+	"BTC":  common.HexToAddress("0xF4030086522a5bEEa4988F8cA5B36dbC97BeE88c"),
+	"LINK": common.HexToAddress("0x2c1d072e956AFFC0D435Cb7AC38EF18d24d9127c"),
+	"USDC": common.HexToAddress("0x8fFfFfd4AfB6115b954Bd326cbe7B4BA576818f6"),
+	"USDT": common.HexToAddress("0x3E7d1eAB13ad0104d2750B8863b489D65364e32D"),
+	"DAI":  common.HexToAddress("0xAed0c38402a5d19df6E4c03F4E2DceD6e29c1ee9"),
+}
+
+// Ethereum mainnet price feeds of the fiat currencies, quoted in USD. Used to convert the USD prices
+var currencyFeeds = map[string]common.Address{
+	"EUR": common.HexToAddress("0xb49f677943BC038e9857d61E7d053CaA2C1734C1"),
+	"GBP": common.HexToAddress("0x5c0Ab2d9b5a7ed9f470386e82BB36A3613cDd4b5"),
+}
+
+var errStaleAnswer = errors.New("stale or invalid feed answer")
+
+type ContractCallerProvider func() (bind.ContractCaller, error)
+
+// Client is a MarketDataProvider reading the Chainlink price feeds through the chain client. It only provides
+// the current prices of a few tokens, but doesn't depend on any third party API
+type Client struct {
+	contractCaller ContractCallerProvider
+	aggregatorABI  abi.ABI
+	// Feed address -> decimals, they never change
+	decimals sync.Map
+}
+
+func NewClient(rpcClient rpc.ClientInterface) *Client {
+	return NewClientWithContractCaller(func() (bind.ContractCaller, error) {
+		return rpcClient.EthClient(walletCommon.EthereumMainnet)
+	})
+}
+
+func NewClientWithContractCaller(contractCaller ContractCallerProvider) *Client {
+	aggregatorABI, err := abi.JSON(strings.NewReader(aggregatorV3ABI))
+	if err != nil {
+		panic(err)
+	}
+
+	return &Client{
+		contractCaller: contractCaller,
+		aggregatorABI:  aggregatorABI,
+	}
+}
+
+func (c *Client) ID() string {
+	return ChainlinkID
+}
+
+func (c *Client) feedDecimals(opts *bind.CallOpts, feed *bind.BoundContract, address common.Address) (uint8, error) {
+	if decimals, ok := c.decimals.Load(address); ok {
+		return decimals.(uint8), nil
+	}
+
+	var out []interface{}
+	err := feed.Call(opts, &out, "decimals")
+	if err != nil {
+		return 0, err
+	}
+	decimals := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+	c.decimals.Store(address, decimals)
+	return decimals, nil
+}
+
+// readFeed returns the latest answer of the feed
+func (c *Client) readFeed(ctx context.Context, caller bind.ContractCaller, address common.Address) (float64, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	feed := bind.NewBoundContract(address, c.aggregatorABI, caller, nil, nil)
+
+	decimals, err := c.feedDecimals(opts, feed, address)
+	if err != nil {
+		return 0, err
+	}
+
+	var out []interface{}
+	err = feed.Call(opts, &out, "latestRoundData")
+	if err != nil {
+		return 0, err
+	}
+	answer := *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	updatedAt := *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+
+	if answer.Sign() <= 0 || time.Since(time.Unix(updatedAt.Int64(), 0)) > maxFeedAge {
+		return 0, errStaleAnswer
+	}
+
+	price, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(answer),
+		new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)),
+	).Float64()
+	return price, nil
+}
+
+// FetchPrices returns the prices of the tokens having a feed, in USD and the currencies having a feed.
+// The other tokens and currencies are left out of the result
+func (c *Client) FetchPrices(symbols []string, currencies []string) (map[string]map[string]float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	caller, err := c.contractCaller()
+	if err != nil {
+		return nil, err
+	}
+
+	// USD value of one unit of each currency
+	currencyRates := make(map[string]float64)
+	for _, currency := range currencies {
+		if currency == usdCurrency {
+			currencyRates[currency] = 1
+			continue
+		}
+		address, ok := currencyFeeds[currency]
+		if !ok {
+			continue
+		}
+		rate, err := c.readFeed(ctx, caller, address)
+		if err == errStaleAnswer {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		currencyRates[currency] = rate
+	}
+
+	prices := make(map[string]map[string]float64)
+	if len(currencyRates) == 0 {
+		return prices, nil
+	}
+
+	for _, symbol := range symbols {
+		address, ok := tokenFeeds[symbol]
+		if !ok {
+			continue
+		}
+		if _, ok := prices[symbol]; ok {
+			continue
+		}
+		usdPrice, err := c.readFeed(ctx, caller, address)
+		if err == errStaleAnswer {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		prices[symbol] = make(map[string]float64)
+		for currency, rate := range currencyRates {
+			prices[symbol][currency] = usdPrice / rate
+		}
+	}
+
+	return prices, nil
+}
+
+func (c *Client) FetchHistoricalDailyPrices(symbol string, currency string, limit int, allData bool, aggregate int) ([]thirdparty.HistoricalPrice, error) {
+	return nil, thirdparty.ErrEndpointNotSupported
+}
+
+func (c *Client) FetchHistoricalHourlyPrices(symbol string, currency string, limit int, aggregate int) ([]thirdparty.HistoricalPrice, error) {
+	return nil, thirdparty.ErrEndpointNotSupported
+}
+
+func (c *Client) FetchTokenMarketValues(symbols []string, currency string) (map[string]thirdparty.TokenMarketValues, error) {
+	return nil, thirdparty.ErrEndpointNotSupported
+}
+
+func (c *Client) FetchTokenDetails(symbols []string) (map[string]thirdparty.TokenDetails, error) {
+	return nil, thirdparty.ErrEndpointNotSupported
+}
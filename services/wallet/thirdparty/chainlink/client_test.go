@@ -0,0 +1,96 @@
+package chainlink
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type testAnswer struct {
+	answer    int64
+	updatedAt time.Time
+}
+
+// fakeAggregators answers the AggregatorV3Interface calls of the feeds it knows, with 8 decimals
+type fakeAggregators struct {
+	aggregatorABI abi.ABI
+	answers       map[common.Address]testAnswer
+	err           error
+}
+
+func (f *fakeAggregators) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (f *fakeAggregators) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	answer, ok := f.answers[*call.To]
+	if !ok {
+		return nil, errors.New("execution reverted")
+	}
+	method, err := f.aggregatorABI.MethodById(call.Data[:4])
+	if err != nil {
+		return nil, err
+	}
+	if method.Name == "decimals" {
+		return method.Outputs.Pack(uint8(8))
+	}
+	return method.Outputs.Pack(big.NewInt(1), big.NewInt(answer.answer), big.NewInt(answer.updatedAt.Unix()),
+		big.NewInt(answer.updatedAt.Unix()), big.NewInt(1))
+}
+
+func setupTestClient(t *testing.T, answers map[common.Address]testAnswer) (*Client, *fakeAggregators) {
+	aggregatorABI, err := abi.JSON(strings.NewReader(aggregatorV3ABI))
+	require.NoError(t, err)
+	caller := &fakeAggregators{
+		aggregatorABI: aggregatorABI,
+		answers:       answers,
+	}
+	return NewClientWithContractCaller(func() (bind.ContractCaller, error) {
+		return caller, nil
+	}), caller
+}
+
+func TestFetchPrices(t *testing.T) {
+	now := time.Now()
+	client, _ := setupTestClient(t, map[common.Address]testAnswer{
+		tokenFeeds["ETH"]:    {answer: 250000000000, updatedAt: now},
+		tokenFeeds["LINK"]:   {answer: 1500000000, updatedAt: now.Add(-48 * time.Hour)},
+		currencyFeeds["EUR"]: {answer: 125000000, updatedAt: now},
+	})
+
+	prices, err := client.FetchPrices([]string{"ETH", "LINK", "SNT"}, []string{"USD", "EUR", "ARS"})
+	require.NoError(t, err)
+	// LINK answer is stale, SNT and ARS have no feed
+	require.Equal(t, map[string]map[string]float64{
+		"ETH": {
+			"USD": 2500,
+			"EUR": 2000,
+		},
+	}, prices)
+}
+
+func TestFetchPricesError(t *testing.T) {
+	client, caller := setupTestClient(t, map[common.Address]testAnswer{
+		tokenFeeds["ETH"]: {answer: 250000000000, updatedAt: time.Now()},
+	})
+	caller.err = errors.New("connection refused")
+
+	_, err := client.FetchPrices([]string{"ETH"}, []string{"USD"})
+	require.Error(t, err)
+
+	_, err = client.FetchTokenDetails([]string{"ETH"})
+	require.Error(t, err)
+}
@@ -181,6 +181,7 @@ type CollectibleData struct {
 	Permalink          string                `json:"permalink"`
 	ImageURL           string                `json:"image_url"`
 	ImagePayload       []byte
+	ThumbnailURL       string             `json:"thumbnail_url"`
 	AnimationURL       string             `json:"animation_url"`
 	AnimationMediaType string             `json:"animation_media_type"`
 	Traits             []CollectibleTrait `json:"traits"`
@@ -30,7 +30,26 @@ func NewHTTPClient() *HTTPClient {
 	}
 }
 
+// NewHTTPClientWithTransport is used to trust the certificate of a test server
+func NewHTTPClientWithTransport(transport http.RoundTripper) *HTTPClient {
+	return &HTTPClient{
+		client: &http.Client{
+			Timeout:   requestTimeout,
+			Transport: transport,
+		},
+	}
+}
+
 func (c *HTTPClient) DoGetRequest(ctx context.Context, url string, params netUrl.Values, creds *BasicCreds) ([]byte, error) {
+	return c.doGetRequest(ctx, url, params, creds, nil)
+}
+
+// DoGetRequestWithHeaders is used for APIs authenticating with a custom header instead of basic auth
+func (c *HTTPClient) DoGetRequestWithHeaders(ctx context.Context, url string, params netUrl.Values, headers map[string]string) ([]byte, error) {
+	return c.doGetRequest(ctx, url, params, nil, headers)
+}
+
+func (c *HTTPClient) doGetRequest(ctx context.Context, url string, params netUrl.Values, creds *BasicCreds, headers map[string]string) ([]byte, error) {
 	if len(params) > 0 {
 		url = url + "?" + params.Encode()
 	}
@@ -44,6 +63,10 @@ func (c *HTTPClient) DoGetRequest(ctx context.Context, url string, params netUrl
 		req.SetBasicAuth(creds.User, creds.Password)
 	}
 
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
 	var resp *http.Response
 	for i := 0; i < maxNumOfRequestRetries; i++ {
 		resp, err = c.client.Do(req)
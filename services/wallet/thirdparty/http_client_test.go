@@ -54,6 +54,29 @@ func TestHTTPClient_DoGetRequest(t *testing.T) {
 	require.Equal(t, expectedResponse, response)
 }
 
+func TestHTTPClient_DoGetRequestWithHeaders(t *testing.T) {
+	client := NewHTTPClient()
+
+	expectedResponse := []byte("test response")
+
+	server := createMockServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "GET", r.Method)
+		require.Equal(t, "value1", r.URL.Query().Get("param1"))
+		require.Equal(t, "api-key", r.Header.Get("X-Api-Key"))
+		require.Empty(t, r.Header.Get("Authorization"))
+
+		_, _ = w.Write(expectedResponse)
+	}))
+	defer server.Close()
+
+	params := url.Values{}
+	params.Set("param1", "value1")
+
+	response, err := client.DoGetRequestWithHeaders(context.Background(), server.URL, params, map[string]string{"X-Api-Key": "api-key"})
+	require.NoError(t, err)
+	require.Equal(t, expectedResponse, response)
+}
+
 func createMockServer(handler http.HandlerFunc) *httptest.Server {
 	return httptest.NewServer(handler)
 }
@@ -0,0 +1,325 @@
+package onchain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/status-im/status-go/contracts/erc721"
+	"github.com/status-im/status-go/rpc"
+	"github.com/status-im/status-go/services/wallet/bigint"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/connection"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+const OnchainID = "onchain"
+
+const (
+	metadataRequestTimeout = 10 * time.Second
+	// metadataMaxSize caps the metadata documents downloaded, images are never downloaded by the provider
+	metadataMaxSize = 1 << 20
+)
+
+// ERC-1155 metadata extension, not part of the IERC1155 bindings
+const erc1155MetadataURIABI = `[{"inputs":[{"internalType":"uint256","name":"id","type":"uint256"}],"name":"uri","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"}]`
+
+// A contract not implementing the called method returns an error starting with one of these strings
+var methodNotSupportedErrorPrefixes = []string{
+	"execution reverted",
+	"abi: attempting to unmarshall",
+}
+
+var ErrNoTokenURI = errors.New("contract has no token URI")
+
+// ContractCallerProvider returns the contract caller of a chain, a local chain can be used for testing
+type ContractCallerProvider func(chainID uint64) (bind.ContractCaller, error)
+
+// Client is a collectibles provider reading the metadata from the contracts and deriving the ownership from the
+// Transfer logs found by the transfer downloader. It doesn't need any API key, and is used as a fallback
+// when the third party providers are not available
+type Client struct {
+	db               *sql.DB
+	contractCaller   ContractCallerProvider
+	httpClient       *http.Client
+	erc1155ABI       abi.ABI
+	connectionStatus *connection.Status
+}
+
+func NewClient(rpcClient rpc.ClientInterface, db *sql.DB) *Client {
+	return NewClientWithContractCaller(func(chainID uint64) (bind.ContractCaller, error) {
+		return rpcClient.EthClient(chainID)
+	}, db)
+}
+
+func NewClientWithContractCaller(contractCaller ContractCallerProvider, db *sql.DB) *Client {
+	erc1155ABI, err := abi.JSON(strings.NewReader(erc1155MetadataURIABI))
+	if err != nil {
+		panic(err)
+	}
+
+	return &Client{
+		db:               db,
+		contractCaller:   contractCaller,
+		httpClient:       &http.Client{Timeout: metadataRequestTimeout},
+		erc1155ABI:       erc1155ABI,
+		connectionStatus: connection.NewStatus(),
+	}
+}
+
+func (o *Client) ID() string {
+	return OnchainID
+}
+
+func (o *Client) IsChainSupported(chainID walletCommon.ChainID) bool {
+	_, err := o.contractCaller(uint64(chainID))
+	return err == nil
+}
+
+func (o *Client) IsConnected() bool {
+	return o.connectionStatus.IsConnected()
+}
+
+func isMethodNotSupportedError(err error) bool {
+	for _, errorPrefix := range methodNotSupportedErrorPrefixes {
+		if strings.Contains(err.Error(), errorPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchTokenURI returns the metadata URI of the token, trying the ERC-721 tokenURI method then the ERC-1155 uri method
+func (o *Client) fetchTokenURI(ctx context.Context, caller bind.ContractCaller, id thirdparty.CollectibleUniqueID) (string, walletCommon.ContractType, error) {
+	opts := &bind.CallOpts{Context: ctx}
+
+	erc721Caller, err := erc721.NewErc721Caller(id.ContractID.Address, caller)
+	if err != nil {
+		return "", walletCommon.ContractTypeUnknown, err
+	}
+	tokenURI, err := erc721Caller.TokenURI(opts, id.TokenID.Int)
+	if err == nil {
+		return tokenURI, walletCommon.ContractTypeERC721, nil
+	}
+	if !isMethodNotSupportedError(err) {
+		return "", walletCommon.ContractTypeUnknown, err
+	}
+
+	contract := bind.NewBoundContract(id.ContractID.Address, o.erc1155ABI, caller, nil, nil)
+	var out []interface{}
+	err = contract.Call(opts, &out, "uri", id.TokenID.Int)
+	if err != nil {
+		if isMethodNotSupportedError(err) {
+			return "", walletCommon.ContractTypeUnknown, ErrNoTokenURI
+		}
+		return "", walletCommon.ContractTypeUnknown, err
+	}
+	uri := *abi.ConvertType(out[0], new(string)).(*string)
+	return substituteTokenID(uri, id.TokenID.Int), walletCommon.ContractTypeERC1155, nil
+}
+
+func (o *Client) fetchCollectionName(ctx context.Context, caller bind.ContractCaller, contractAddress common.Address) string {
+	erc721Caller, err := erc721.NewErc721Caller(contractAddress, caller)
+	if err != nil {
+		return ""
+	}
+	name, err := erc721Caller.Name(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+// fetchMetadata downloads the metadata document of the URI, data: URIs are decoded in place
+func (o *Client) fetchMetadata(ctx context.Context, uri string) (*metadata, error) {
+	resolved, err := resolveURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(strings.ToLower(resolved), "data:") {
+		data, err := decodeDataURI(resolved)
+		if err != nil {
+			return nil, err
+		}
+		return parseMetadata(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, resolved, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d fetching metadata from %s", resp.StatusCode, resolved)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, metadataMaxSize))
+	if err != nil {
+		return nil, err
+	}
+	return parseMetadata(data)
+}
+
+func (o *Client) fetchCollectible(ctx context.Context, id thirdparty.CollectibleUniqueID) (*thirdparty.FullCollectibleData, error) {
+	if id.TokenID == nil {
+		return nil, errors.New("empty token ID")
+	}
+
+	caller, err := o.contractCaller(uint64(id.ContractID.ChainID))
+	if err != nil {
+		return nil, err
+	}
+
+	tokenURI, contractType, err := o.fetchTokenURI(ctx, caller, id)
+	if err != nil && err != ErrNoTokenURI {
+		if ctx.Err() == nil {
+			o.connectionStatus.SetIsConnected(false)
+		}
+		return nil, err
+	}
+	o.connectionStatus.SetIsConnected(true)
+
+	collectionName := o.fetchCollectionName(ctx, caller, id.ContractID.Address)
+
+	item := &thirdparty.FullCollectibleData{
+		CollectibleData: thirdparty.CollectibleData{
+			ID:           id,
+			ContractType: contractType,
+			Provider:     o.ID(),
+			TokenURI:     tokenURI,
+		},
+		CollectionData: &thirdparty.CollectionData{
+			ID:           id.ContractID,
+			ContractType: contractType,
+			Provider:     o.ID(),
+			Name:         collectionName,
+		},
+	}
+
+	if tokenURI != "" {
+		m, err := o.fetchMetadata(ctx, tokenURI)
+		if err != nil {
+			log.Warn("onchain collectible metadata not available", "id", id.HashKey(), "uri", tokenURI, "err", err)
+		} else {
+			m.fill(&item.CollectibleData)
+		}
+	}
+
+	if item.CollectibleData.Name == "" && collectionName != "" {
+		item.CollectibleData.Name = fmt.Sprintf("%s #%s", collectionName, id.TokenID.String())
+	}
+
+	return item, nil
+}
+
+func (o *Client) FetchAssetsByCollectibleUniqueID(ctx context.Context, uniqueIDs []thirdparty.CollectibleUniqueID) ([]thirdparty.FullCollectibleData, error) {
+	ret := make([]thirdparty.FullCollectibleData, 0, len(uniqueIDs))
+	for _, id := range uniqueIDs {
+		item, err := o.fetchCollectible(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, *item)
+	}
+	return ret, nil
+}
+
+func (o *Client) FetchCollectionSocials(ctx context.Context, contractID thirdparty.ContractID) (*thirdparty.CollectionSocials, error) {
+	return nil, thirdparty.ErrEndpointNotSupported
+}
+
+func (o *Client) FetchAllAssetsByOwner(ctx context.Context, chainID walletCommon.ChainID, owner common.Address, cursor string, limit int) (*thirdparty.FullCollectibleDataContainer, error) {
+	return o.fetchOwnedAssets(ctx, chainID, owner, nil, cursor, limit)
+}
+
+func (o *Client) FetchAllAssetsByOwnerAndContractAddress(ctx context.Context, chainID walletCommon.ChainID, owner common.Address, contractAddresses []common.Address, cursor string, limit int) (*thirdparty.FullCollectibleDataContainer, error) {
+	return o.fetchOwnedAssets(ctx, chainID, owner, contractAddresses, cursor, limit)
+}
+
+// fetchOwnedAssets pages the collectibles derived from the transfers, the cursor is the offset of the page
+func (o *Client) fetchOwnedAssets(ctx context.Context, chainID walletCommon.ChainID, owner common.Address, contractAddresses []common.Address, cursor string, limit int) (*thirdparty.FullCollectibleDataContainer, error) {
+	if !o.IsChainSupported(chainID) {
+		return nil, thirdparty.ErrChainIDNotSupported
+	}
+
+	offset := 0
+	if cursor != thirdparty.FetchFromStartCursor {
+		var err error
+		offset, err = strconv.Atoi(cursor)
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("invalid cursor %q", cursor)
+		}
+	}
+
+	owned, err := getOwnedCollectibles(ctx, o.db, chainID, owner, contractAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	assets := &thirdparty.FullCollectibleDataContainer{
+		Items:          make([]thirdparty.FullCollectibleData, 0),
+		PreviousCursor: cursor,
+		Provider:       o.ID(),
+	}
+	if offset >= len(owned) {
+		return assets, nil
+	}
+
+	end := len(owned)
+	if limit != thirdparty.FetchNoLimit && offset+limit < end {
+		end = offset + limit
+		assets.NextCursor = strconv.Itoa(end)
+	}
+
+	for _, c := range owned[offset:end] {
+		item, err := o.fetchCollectible(ctx, c.id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// The ownership is known even if the contract can't be read
+			log.Warn("onchain collectible data not available", "id", c.id.HashKey(), "err", err)
+			item = &thirdparty.FullCollectibleData{
+				CollectibleData: thirdparty.CollectibleData{
+					ID:           c.id,
+					ContractType: c.contractType,
+					Provider:     o.ID(),
+				},
+			}
+		}
+		if item.CollectibleData.ContractType == walletCommon.ContractTypeUnknown {
+			item.CollectibleData.ContractType = c.contractType
+		}
+
+		balance := &bigint.BigInt{Int: new(big.Int).Set(c.balance)}
+		item.AccountBalance = balance
+		item.Ownership = []thirdparty.AccountBalance{
+			{
+				Address:     owner,
+				Balance:     balance,
+				TxTimestamp: c.timestamp,
+			},
+		}
+		assets.Items = append(assets.Items, *item)
+	}
+
+	return assets, nil
+}
@@ -0,0 +1,206 @@
+package onchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	"github.com/status-im/status-go/services/wallet/transfer"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+const testChainID = walletCommon.ChainID(1)
+
+var (
+	tokenURISelector = []byte{0xc8, 0x7b, 0x56, 0xdd}
+	uriSelector      = []byte{0x0e, 0x89, 0x34, 0x1c}
+	nameSelector     = []byte{0x06, 0xfd, 0xde, 0x03}
+)
+
+// fakeContractCaller answers the metadata calls of the contracts it knows, the others revert
+type fakeContractCaller struct {
+	tokenURIs   map[common.Address]string
+	uris        map[common.Address]string
+	names       map[common.Address]string
+	unavailable bool
+}
+
+func (f *fakeContractCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x1}, nil
+}
+
+func (f *fakeContractCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if f.unavailable {
+		return nil, errors.New("connection refused")
+	}
+
+	var values map[common.Address]string
+	switch {
+	case strings.HasPrefix(string(call.Data), string(tokenURISelector)):
+		values = f.tokenURIs
+	case strings.HasPrefix(string(call.Data), string(uriSelector)):
+		values = f.uris
+	case strings.HasPrefix(string(call.Data), string(nameSelector)):
+		values = f.names
+	}
+	value, ok := values[*call.To]
+	if !ok {
+		return nil, errors.New("execution reverted")
+	}
+
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return abi.Arguments{{Type: stringType}}.Pack(value)
+}
+
+func setupTestClient(t *testing.T, caller *fakeContractCaller) *Client {
+	db, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	return NewClientWithContractCaller(func(chainID uint64) (bind.ContractCaller, error) {
+		if walletCommon.ChainID(chainID) != testChainID {
+			return nil, errors.New("chain not supported")
+		}
+		return caller, nil
+	}, db)
+}
+
+func TestFetchAssetsByCollectibleUniqueID(t *testing.T) {
+	erc721Address := common.HexToAddress("0x721")
+	erc1155Address := common.HexToAddress("0x1155")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/0000000000000000000000000000000000000000000000000000000000000002.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name": "Sword", "image": "ipfs://QmSword", "attributes": [{"trait_type": "Damage", "value": 12}]}`))
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, &fakeContractCaller{
+		tokenURIs: map[common.Address]string{
+			// {"description":"A dragon"}
+			erc721Address: "data:application/json;base64,eyJkZXNjcmlwdGlvbiI6IkEgZHJhZ29uIn0=",
+		},
+		uris: map[common.Address]string{
+			erc1155Address: server.URL + "/{id}.json",
+		},
+		names: map[common.Address]string{
+			erc721Address: "Dragons",
+		},
+	})
+
+	ids := []thirdparty.CollectibleUniqueID{
+		{
+			ContractID: thirdparty.ContractID{ChainID: testChainID, Address: erc721Address},
+			TokenID:    &bigint.BigInt{Int: big.NewInt(1)},
+		},
+		{
+			ContractID: thirdparty.ContractID{ChainID: testChainID, Address: erc1155Address},
+			TokenID:    &bigint.BigInt{Int: big.NewInt(2)},
+		},
+	}
+	items, err := client.FetchAssetsByCollectibleUniqueID(context.Background(), ids)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.True(t, client.IsConnected())
+
+	// The name falls back to the collection name
+	require.Equal(t, walletCommon.ContractTypeERC721, items[0].CollectibleData.ContractType)
+	require.Equal(t, "Dragons #1", items[0].CollectibleData.Name)
+	require.Equal(t, "A dragon", items[0].CollectibleData.Description)
+	require.Equal(t, "Dragons", items[0].CollectionData.Name)
+	require.Equal(t, OnchainID, items[0].CollectibleData.Provider)
+
+	require.Equal(t, walletCommon.ContractTypeERC1155, items[1].CollectibleData.ContractType)
+	require.Equal(t, server.URL+"/0000000000000000000000000000000000000000000000000000000000000002.json", items[1].CollectibleData.TokenURI)
+	require.Equal(t, "Sword", items[1].CollectibleData.Name)
+	require.Equal(t, "https://ipfs.io/ipfs/QmSword", items[1].CollectibleData.ImageURL)
+	require.Equal(t, []thirdparty.CollectibleTrait{{TraitType: "Damage", Value: "12"}}, items[1].CollectibleData.Traits)
+
+	// A contract without metadata is still returned
+	items, err = client.FetchAssetsByCollectibleUniqueID(context.Background(), []thirdparty.CollectibleUniqueID{
+		{
+			ContractID: thirdparty.ContractID{ChainID: testChainID, Address: common.HexToAddress("0x1234")},
+			TokenID:    &bigint.BigInt{Int: big.NewInt(3)},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, "", items[0].CollectibleData.TokenURI)
+	require.Equal(t, walletCommon.ContractTypeUnknown, items[0].CollectibleData.ContractType)
+}
+
+func TestFetchAllAssetsByOwner(t *testing.T) {
+	contract := common.HexToAddress("0x721")
+	caller := &fakeContractCaller{
+		tokenURIs: map[common.Address]string{
+			contract: `data:application/json,{"name":"Token"}`,
+		},
+	}
+	client := setupTestClient(t, caller)
+
+	trs, _, _ := transfer.GenerateTestTransfers(t, client.db, 1, 3)
+	owner := trs[0].To
+	// Token 1 is received then sent, token 2 is received
+	trs[1].From = owner
+	trs[2].To = owner
+	tokenIDs := []int64{1, 1, 2}
+	for i := range trs {
+		trs[i].ChainID = testChainID
+		transfer.InsertTestTransferWithOptions(t, client.db, owner, &trs[i], &transfer.TestTransferOptions{
+			TokenAddress: contract,
+			TokenID:      big.NewInt(tokenIDs[i]),
+		})
+	}
+
+	assets, err := client.FetchAllAssetsByOwner(context.Background(), testChainID, owner, thirdparty.FetchFromStartCursor, thirdparty.FetchNoLimit)
+	require.NoError(t, err)
+	require.Len(t, assets.Items, 1)
+	require.Equal(t, "", assets.NextCursor)
+
+	item := assets.Items[0]
+	require.Equal(t, contract, item.CollectibleData.ID.ContractID.Address)
+	require.Equal(t, int64(2), item.CollectibleData.ID.TokenID.Int64())
+	require.Equal(t, "Token", item.CollectibleData.Name)
+	require.Equal(t, int64(1), item.AccountBalance.Int64())
+	require.Len(t, item.Ownership, 1)
+	require.Equal(t, owner, item.Ownership[0].Address)
+	require.Equal(t, trs[2].Timestamp, item.Ownership[0].TxTimestamp)
+
+	// The ownership is returned even if the contract can't be read
+	caller.unavailable = true
+	assets, err = client.FetchAllAssetsByOwnerAndContractAddress(context.Background(), testChainID, owner, []common.Address{contract}, thirdparty.FetchFromStartCursor, thirdparty.FetchNoLimit)
+	require.NoError(t, err)
+	require.Len(t, assets.Items, 1)
+	require.Equal(t, walletCommon.ContractTypeERC721, assets.Items[0].CollectibleData.ContractType)
+	require.False(t, client.IsConnected())
+
+	assets, err = client.FetchAllAssetsByOwnerAndContractAddress(context.Background(), testChainID, owner, []common.Address{common.HexToAddress("0x1234")}, thirdparty.FetchFromStartCursor, thirdparty.FetchNoLimit)
+	require.NoError(t, err)
+	require.Len(t, assets.Items, 0)
+
+	_, err = client.FetchAllAssetsByOwner(context.Background(), walletCommon.ChainID(10), owner, thirdparty.FetchFromStartCursor, thirdparty.FetchNoLimit)
+	require.ErrorIs(t, err, thirdparty.ErrChainIDNotSupported)
+}
@@ -0,0 +1,175 @@
+package onchain
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+const (
+	ipfsGatewayURL    = "https://ipfs.io/ipfs/"
+	arweaveGatewayURL = "https://arweave.net/"
+)
+
+var (
+	ErrUnsupportedURIScheme = errors.New("unsupported metadata URI scheme")
+	ErrInvalidDataURI       = errors.New("invalid data URI")
+)
+
+// substituteTokenID replaces the {id} placeholder of ERC-1155 URIs by the lowercase hex token ID padded to 64 characters
+func substituteTokenID(uri string, tokenID *big.Int) string {
+	if tokenID == nil || !strings.Contains(uri, "{id}") {
+		return uri
+	}
+	return strings.ReplaceAll(uri, "{id}", fmt.Sprintf("%064x", tokenID))
+}
+
+// resolveURI returns the URI the content can be fetched from, IPFS and Arweave URIs are served through a gateway.
+// data: and http(s) URIs are returned unchanged
+func resolveURI(uri string) (string, error) {
+	uri = strings.TrimSpace(uri)
+	lowerURI := strings.ToLower(uri)
+
+	switch {
+	case strings.HasPrefix(lowerURI, "data:"), strings.HasPrefix(lowerURI, "http://"), strings.HasPrefix(lowerURI, "https://"):
+		return uri, nil
+	case strings.HasPrefix(lowerURI, "ipfs://"):
+		path := uri[len("ipfs://"):]
+		path = strings.TrimPrefix(path, "ipfs/")
+		return ipfsGatewayURL + path, nil
+	case strings.HasPrefix(lowerURI, "/ipfs/"):
+		return ipfsGatewayURL + uri[len("/ipfs/"):], nil
+	case strings.HasPrefix(lowerURI, "ar://"):
+		return arweaveGatewayURL + uri[len("ar://"):], nil
+	}
+	return "", ErrUnsupportedURIScheme
+}
+
+// decodeDataURI returns the content of a RFC 2397 data URI
+func decodeDataURI(uri string) ([]byte, error) {
+	header, data, found := strings.Cut(uri[len("data:"):], ",")
+	if !found {
+		return nil, ErrInvalidDataURI
+	}
+
+	if strings.HasSuffix(strings.ToLower(header), ";base64") {
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return base64.RawStdEncoding.DecodeString(data)
+		}
+		return decoded, nil
+	}
+
+	decoded, err := url.PathUnescape(data)
+	if err != nil {
+		// Some contracts return raw JSON without percent-encoding it
+		return []byte(data), nil
+	}
+	return []byte(decoded), nil
+}
+
+// metadata is the ERC-721 and ERC-1155 metadata JSON schema including the widely used OpenSea extensions
+type metadata struct {
+	Name            string            `json:"name"`
+	Description     string            `json:"description"`
+	Image           string            `json:"image"`
+	ImageURL        string            `json:"image_url"`
+	ImageData       string            `json:"image_data"`
+	AnimationURL    string            `json:"animation_url"`
+	ExternalURL     string            `json:"external_url"`
+	BackgroundColor string            `json:"background_color"`
+	Attributes      []metadataTrait   `json:"attributes"`
+	Properties      map[string]jValue `json:"properties"`
+}
+
+type metadataTrait struct {
+	TraitType   string `json:"trait_type"`
+	Value       jValue `json:"value"`
+	DisplayType string `json:"display_type"`
+	MaxValue    jValue `json:"max_value"`
+}
+
+// jValue is a JSON value of any type kept in its textual form
+type jValue string
+
+func (v *jValue) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*v = jValue(s)
+		return nil
+	}
+	if bytes.Equal(data, []byte("null")) {
+		*v = ""
+		return nil
+	}
+	*v = jValue(data)
+	return nil
+}
+
+func parseMetadata(data []byte) (*metadata, error) {
+	m := &metadata{}
+	err := json.Unmarshal(bytes.TrimSpace(data), m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func resolveMediaURI(uri string) string {
+	if uri == "" {
+		return ""
+	}
+	resolved, err := resolveURI(uri)
+	if err != nil {
+		return uri
+	}
+	return resolved
+}
+
+// fill sets the collectible fields from the metadata
+func (m *metadata) fill(c *thirdparty.CollectibleData) {
+	c.Name = m.Name
+	c.Description = m.Description
+	c.Permalink = m.ExternalURL
+	c.BackgroundColor = strings.TrimPrefix(m.BackgroundColor, "#")
+
+	image := m.Image
+	if image == "" {
+		image = m.ImageURL
+	}
+	if image == "" && m.ImageData != "" {
+		image = "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte(m.ImageData))
+	}
+	c.ImageURL = resolveMediaURI(image)
+	c.AnimationURL = resolveMediaURI(m.AnimationURL)
+
+	traits := make([]thirdparty.CollectibleTrait, 0, len(m.Attributes)+len(m.Properties))
+	for _, attribute := range m.Attributes {
+		traits = append(traits, thirdparty.CollectibleTrait{
+			TraitType:   attribute.TraitType,
+			Value:       string(attribute.Value),
+			DisplayType: attribute.DisplayType,
+			MaxValue:    string(attribute.MaxValue),
+		})
+	}
+	names := make([]string, 0, len(m.Properties))
+	for name := range m.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		traits = append(traits, thirdparty.CollectibleTrait{
+			TraitType: name,
+			Value:     string(m.Properties[name]),
+		})
+	}
+	c.Traits = traits
+}
@@ -0,0 +1,100 @@
+package onchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+func TestResolveURI(t *testing.T) {
+	testCases := []struct {
+		uri      string
+		expected string
+	}{
+		{"ipfs://QmHash/1.json", "https://ipfs.io/ipfs/QmHash/1.json"},
+		{"ipfs://ipfs/QmHash/1.json", "https://ipfs.io/ipfs/QmHash/1.json"},
+		{"/ipfs/QmHash", "https://ipfs.io/ipfs/QmHash"},
+		{"ar://TxID", "https://arweave.net/TxID"},
+		{"https://example.com/1.json", "https://example.com/1.json"},
+		{" data:application/json,{} ", "data:application/json,{}"},
+	}
+	for _, tc := range testCases {
+		resolved, err := resolveURI(tc.uri)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, resolved)
+	}
+
+	_, err := resolveURI("ftp://example.com/1.json")
+	require.ErrorIs(t, err, ErrUnsupportedURIScheme)
+}
+
+func TestSubstituteTokenID(t *testing.T) {
+	require.Equal(t, "https://example.com/000000000000000000000000000000000000000000000000000000000004cce0.json",
+		substituteTokenID("https://example.com/{id}.json", big.NewInt(314592)))
+	require.Equal(t, "https://example.com/1.json", substituteTokenID("https://example.com/1.json", big.NewInt(314592)))
+}
+
+func TestDecodeDataURI(t *testing.T) {
+	data, err := decodeDataURI("data:application/json;base64,eyJuYW1lIjoiVGVzdCJ9")
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"Test"}`, string(data))
+
+	data, err = decodeDataURI("data:application/json;utf8,%7B%22name%22%3A%22Test%22%7D")
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"Test"}`, string(data))
+
+	data, err = decodeDataURI(`data:application/json,{"name":"Test 100%"}`)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"Test 100%"}`, string(data))
+
+	_, err = decodeDataURI("data:application/json")
+	require.ErrorIs(t, err, ErrInvalidDataURI)
+}
+
+func TestParseMetadata(t *testing.T) {
+	m, err := parseMetadata([]byte(`{
+		"name": "Dragon #1",
+		"description": "A dragon",
+		"image": "ipfs://QmImage",
+		"animation_url": "ar://Animation",
+		"external_url": "https://example.com/1",
+		"background_color": "#FF00FF",
+		"attributes": [
+			{"trait_type": "Color", "value": "Red"},
+			{"trait_type": "Level", "value": 5, "display_type": "number", "max_value": 10},
+			{"trait_type": "Legendary", "value": true},
+			{"value": null}
+		],
+		"properties": {"size": "large", "power": 9000}
+	}`))
+	require.NoError(t, err)
+
+	var c thirdparty.CollectibleData
+	m.fill(&c)
+	require.Equal(t, "Dragon #1", c.Name)
+	require.Equal(t, "A dragon", c.Description)
+	require.Equal(t, "https://ipfs.io/ipfs/QmImage", c.ImageURL)
+	require.Equal(t, "https://arweave.net/Animation", c.AnimationURL)
+	require.Equal(t, "https://example.com/1", c.Permalink)
+	require.Equal(t, "FF00FF", c.BackgroundColor)
+	require.Equal(t, []thirdparty.CollectibleTrait{
+		{TraitType: "Color", Value: "Red"},
+		{TraitType: "Level", Value: "5", DisplayType: "number", MaxValue: "10"},
+		{TraitType: "Legendary", Value: "true"},
+		{},
+		{TraitType: "power", Value: "9000"},
+		{TraitType: "size", Value: "large"},
+	}, c.Traits)
+
+	// On-chain SVG images
+	m, err = parseMetadata([]byte(`{"name": "SVG", "image_data": "<svg></svg>"}`))
+	require.NoError(t, err)
+	m.fill(&c)
+	require.Equal(t, "data:image/svg+xml;base64,PHN2Zz48L3N2Zz4=", c.ImageURL)
+
+	_, err = parseMetadata([]byte(`not json`))
+	require.Error(t, err)
+}
@@ -0,0 +1,136 @@
+package onchain
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+	w_common "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+// ownedCollectible is a collectible held by an account according to its transfers
+type ownedCollectible struct {
+	id           thirdparty.CollectibleUniqueID
+	contractType w_common.ContractType
+	balance      *big.Int
+	// timestamp of the last transfer received
+	timestamp int64
+}
+
+// getOwnedCollectibles derives the collectibles held by the owner from the Transfer logs found by the transfer
+// downloader. Only the contracts provided are considered, all of them if none is provided.
+// The most recently received collectibles come first
+func getOwnedCollectibles(ctx context.Context, db *sql.DB, chainID w_common.ChainID, owner common.Address, contractAddresses []common.Address) ([]*ownedCollectible, error) {
+	query := `SELECT type, token_address, token_id, amount_padded128hex, tx_from_address, tx_to_address, timestamp
+		FROM transfers
+		WHERE network_id = ? AND address = ? AND type IN ('erc721', 'erc1155') AND loaded = 1
+			AND (status IS NULL OR status = 1) AND token_address IS NOT NULL AND token_id IS NOT NULL`
+	args := []interface{}{chainID, owner}
+	if len(contractAddresses) > 0 {
+		placeholders := make([]string, len(contractAddresses))
+		for i, address := range contractAddresses {
+			placeholders[i] = "?"
+			args = append(args, address)
+		}
+		query += ` AND token_address IN (` + strings.Join(placeholders, ",") + `)`
+	}
+	query += ` ORDER BY timestamp ASC, blk_number ASC, log_index ASC`
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collectibles := make(map[string]*ownedCollectible)
+	for rows.Next() {
+		var trType string
+		var tokenAddress, fromDB, toDB []byte
+		var amountDB sql.NullString
+		var timestamp int64
+		tokenID := new(big.Int)
+		err := rows.Scan(&trType, &tokenAddress, (*bigint.SQLBigIntBytes)(tokenID), &amountDB, &fromDB, &toDB, &timestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		id := thirdparty.CollectibleUniqueID{
+			ContractID: thirdparty.ContractID{
+				ChainID: chainID,
+				Address: common.BytesToAddress(tokenAddress),
+			},
+			TokenID: &bigint.BigInt{Int: tokenID},
+		}
+		from := common.BytesToAddress(fromDB)
+		to := common.BytesToAddress(toDB)
+		if from == to {
+			continue
+		}
+
+		c, ok := collectibles[id.HashKey()]
+		if !ok {
+			c = &ownedCollectible{
+				id:      id,
+				balance: big.NewInt(0),
+			}
+			collectibles[id.HashKey()] = c
+		}
+
+		// ERC-721 tokens are unique, the last transfer tells the owner
+		amount := big.NewInt(1)
+		c.contractType = w_common.ContractTypeERC721
+		if trType == "erc1155" {
+			c.contractType = w_common.ContractTypeERC1155
+			amount = parseAmount(amountDB)
+		}
+
+		if to == owner {
+			if c.contractType == w_common.ContractTypeERC721 {
+				c.balance.SetInt64(1)
+			} else {
+				c.balance.Add(c.balance, amount)
+			}
+			c.timestamp = timestamp
+		} else if from == owner {
+			if c.contractType == w_common.ContractTypeERC721 {
+				c.balance.SetInt64(0)
+			} else {
+				c.balance.Sub(c.balance, amount)
+			}
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	owned := make([]*ownedCollectible, 0, len(collectibles))
+	for _, c := range collectibles {
+		if c.balance.Sign() > 0 {
+			owned = append(owned, c)
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		if owned[i].timestamp != owned[j].timestamp {
+			return owned[i].timestamp > owned[j].timestamp
+		}
+		return owned[i].id.HashKey() < owned[j].id.HashKey()
+	})
+	return owned, nil
+}
+
+func parseAmount(amountDB sql.NullString) *big.Int {
+	if !amountDB.Valid {
+		return big.NewInt(0)
+	}
+	amount, ok := new(big.Int).SetString(amountDB.String, 16)
+	if !ok {
+		return big.NewInt(0)
+	}
+	return amount
+}
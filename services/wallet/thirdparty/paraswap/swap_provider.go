@@ -0,0 +1,213 @@
+package paraswap
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+const (
+	ParaswapID = "paraswap"
+
+	partnerID = "status.app"
+)
+
+var ErrConvertingAmountToBigInt = errors.New("converting amount to big.Int")
+
+func getPartnerAddressAndFeePcnt(chainID uint64) (common.Address, float64) {
+	const partnerFeePcnt = 0.7
+
+	switch chainID {
+	case walletCommon.EthereumMainnet:
+		return common.HexToAddress("0xd9abc564bfabefa88a6C2723d78124579600F568"), partnerFeePcnt
+	case walletCommon.OptimismMainnet:
+		return common.HexToAddress("0xE9B59dC0b30cd4646430c25de0111D651c395775"), partnerFeePcnt
+	case walletCommon.ArbitrumMainnet:
+		return common.HexToAddress("0x9a8278e856C0B191B9daa2d7DD1f7B28268E4DA2"), partnerFeePcnt
+	}
+	return common.Address{}, 0
+}
+
+func calcReceivedAmountAndFee(baseDestAmount *big.Int, feePcnt float64) (destAmount *big.Int, destFee *big.Int) {
+	destAmount = new(big.Int).Set(baseDestAmount)
+	destFee = new(big.Int).SetUint64(0)
+
+	if feePcnt > 0 {
+		baseDestAmountFloat := new(big.Float).SetInt(baseDestAmount)
+		feePcntFloat := big.NewFloat(feePcnt / 100.0)
+
+		destFeeFloat := new(big.Float).Set(baseDestAmountFloat)
+		destFeeFloat = destFeeFloat.Mul(destFeeFloat, feePcntFloat)
+		destFeeFloat.Int(destFee)
+
+		destAmount = destAmount.Sub(destAmount, destFee)
+	}
+	return
+}
+
+// SwapQuoteProvider implements thirdparty.SwapQuoteProvider using the Paraswap API
+type SwapQuoteProvider struct {
+	// the client is configured per chain, so requests are serialized
+	clientMutex sync.Mutex
+	client      ClientInterface
+}
+
+func NewSwapQuoteProvider() *SwapQuoteProvider {
+	defaultChainID := walletCommon.EthereumMainnet
+	partnerAddress, partnerFeePcnt := getPartnerAddressAndFeePcnt(defaultChainID)
+
+	return NewSwapQuoteProviderWithClient(NewClientV5(
+		defaultChainID,
+		partnerID,
+		partnerAddress,
+		partnerFeePcnt,
+	))
+}
+
+func NewSwapQuoteProviderWithClient(client ClientInterface) *SwapQuoteProvider {
+	return &SwapQuoteProvider{
+		client: client,
+	}
+}
+
+func (p *SwapQuoteProvider) ID() string {
+	return ParaswapID
+}
+
+func (p *SwapQuoteProvider) IsChainSupported(chainID uint64) bool {
+	return true
+}
+
+func (p *SwapQuoteProvider) IsSwapSupported(side thirdparty.SwapSide, addressFrom common.Address, addressTo common.Address) bool {
+	return true
+}
+
+// setChainID must be called with clientMutex locked
+func (p *SwapQuoteProvider) setChainID(chainID uint64) {
+	partnerAddress, partnerFeePcnt := getPartnerAddressAndFeePcnt(chainID)
+	p.client.SetChainID(chainID)
+	p.client.SetPartnerAddress(partnerAddress)
+	p.client.SetPartnerFeePcnt(partnerFeePcnt)
+}
+
+func (p *SwapQuoteProvider) FetchTokensList(ctx context.Context, chainID uint64) ([]thirdparty.SwapToken, error) {
+	p.clientMutex.Lock()
+	defer p.clientMutex.Unlock()
+
+	p.setChainID(chainID)
+	tokens, err := p.client.FetchTokensList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]thirdparty.SwapToken, 0, len(tokens))
+	for _, t := range tokens {
+		res = append(res, thirdparty.SwapToken{
+			Symbol:   t.Symbol,
+			Address:  common.HexToAddress(t.Address),
+			Decimals: t.Decimals,
+		})
+	}
+	return res, nil
+}
+
+func (p *SwapQuoteProvider) FetchQuote(ctx context.Context, params thirdparty.SwapQuoteParams) (*thirdparty.SwapQuote, error) {
+	p.clientMutex.Lock()
+	defer p.clientMutex.Unlock()
+
+	swapSide := SellSide
+	if params.Side == thirdparty.SwapSideBuy {
+		swapSide = BuySide
+	}
+
+	p.setChainID(params.ChainID)
+	route, err := p.client.FetchPriceRoute(ctx, params.SrcTokenAddress, params.SrcTokenDecimals,
+		params.DestTokenAddress, params.DestTokenDecimals, params.Amount, params.AddressFrom, params.AddressTo, swapSide)
+	if err != nil {
+		return nil, err
+	}
+
+	return routeToSwapQuote(params.ChainID, &route), nil
+}
+
+func routeToSwapQuote(chainID uint64, route *Route) *thirdparty.SwapQuote {
+	side := thirdparty.SwapSideSell
+	if route.Side == BuySide {
+		side = thirdparty.SwapSideBuy
+	}
+
+	quote := &thirdparty.SwapQuote{
+		ProviderID:        ParaswapID,
+		ChainID:           chainID,
+		Side:              side,
+		SrcTokenAddress:   route.SrcTokenAddress,
+		SrcTokenDecimals:  route.SrcTokenDecimals,
+		DestTokenAddress:  route.DestTokenAddress,
+		DestTokenDecimals: route.DestTokenDecimals,
+		ApprovalAddress:   route.TokenTransferProxy,
+		RawQuote:          route.RawPriceRoute,
+	}
+	if route.SrcAmount != nil {
+		quote.SrcAmount = route.SrcAmount.Int
+	}
+	if route.DestAmount != nil {
+		quote.DestAmount = route.DestAmount.Int
+		_, partnerFeePcnt := getPartnerAddressAndFeePcnt(chainID)
+		_, quote.FeeAmount = calcReceivedAmountAndFee(route.DestAmount.Int, partnerFeePcnt)
+	}
+	if route.GasCost != nil {
+		quote.GasCost = route.GasCost.Uint64()
+	}
+	return quote
+}
+
+func (p *SwapQuoteProvider) BuildTransaction(ctx context.Context, quote *thirdparty.SwapQuote, addressFrom common.Address, addressTo common.Address,
+	slippageBasisPoints uint) (*thirdparty.SwapTransaction, error) {
+	p.clientMutex.Lock()
+	defer p.clientMutex.Unlock()
+
+	swapSide := SellSide
+	if quote.Side == thirdparty.SwapSideBuy {
+		swapSide = BuySide
+	}
+
+	p.setChainID(quote.ChainID)
+	tx, err := p.client.BuildTransaction(ctx, quote.SrcTokenAddress, quote.SrcTokenDecimals, quote.SrcAmount,
+		quote.DestTokenAddress, quote.DestTokenDecimals, quote.DestAmount, slippageBasisPoints,
+		addressFrom, addressTo, quote.RawQuote, swapSide)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := new(big.Int).SetString(tx.Value, 10)
+	if !ok {
+		return nil, ErrConvertingAmountToBigInt
+	}
+
+	gas, err := strconv.ParseUint(tx.Gas, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPrice, ok := new(big.Int).SetString(tx.GasPrice, 10)
+	if !ok {
+		return nil, ErrConvertingAmountToBigInt
+	}
+
+	return &thirdparty.SwapTransaction{
+		ChainID:  tx.ChainID,
+		From:     common.HexToAddress(tx.From),
+		To:       common.HexToAddress(tx.To),
+		Value:    value,
+		Data:     common.FromHex(tx.Data),
+		Gas:      gas,
+		GasPrice: gasPrice,
+	}, nil
+}
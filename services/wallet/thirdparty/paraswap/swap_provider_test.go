@@ -0,0 +1,121 @@
+package paraswap
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+type fakeClient struct {
+	chainID     uint64
+	route       Route
+	transaction Transaction
+	tokens      []Token
+}
+
+func (c *fakeClient) SetChainID(chainID uint64) {
+	c.chainID = chainID
+}
+
+func (c *fakeClient) SetPartnerAddress(partnerAddress common.Address) {}
+
+func (c *fakeClient) SetPartnerFeePcnt(partnerFeePcnt float64) {}
+
+func (c *fakeClient) BuildTransaction(ctx context.Context, srcTokenAddress common.Address, srcTokenDecimals uint, srcAmountWei *big.Int,
+	destTokenAddress common.Address, destTokenDecimals uint, destAmountWei *big.Int, slippageBasisPoints uint,
+	addressFrom common.Address, addressTo common.Address, priceRoute json.RawMessage, side SwapSide) (Transaction, error) {
+	return c.transaction, nil
+}
+
+func (c *fakeClient) FetchPriceRoute(ctx context.Context, srcTokenAddress common.Address, srcTokenDecimals uint,
+	destTokenAddress common.Address, destTokenDecimals uint, amountWei *big.Int, addressFrom common.Address,
+	addressTo common.Address, side SwapSide) (Route, error) {
+	return c.route, nil
+}
+
+func (c *fakeClient) FetchTokensList(ctx context.Context) ([]Token, error) {
+	return c.tokens, nil
+}
+
+func TestSwapQuoteProviderWithPartnerFee(t *testing.T) {
+	testPriceRoute := Route{
+		GasCost:            &bigint.BigInt{Int: big.NewInt(500)},
+		SrcAmount:          &bigint.BigInt{Int: big.NewInt(1000)},
+		SrcTokenAddress:    common.HexToAddress("0x123"),
+		SrcTokenDecimals:   18,
+		DestAmount:         &bigint.BigInt{Int: big.NewInt(2000)},
+		DestTokenAddress:   common.HexToAddress("0x465"),
+		DestTokenDecimals:  6,
+		Side:               SellSide,
+		ContractAddress:    common.HexToAddress("0x789"),
+		TokenTransferProxy: common.HexToAddress("0xabc"),
+	}
+
+	client := &fakeClient{route: testPriceRoute}
+	provider := NewSwapQuoteProviderWithClient(client)
+
+	chainIDs := []uint64{walletCommon.EthereumMainnet, walletCommon.ArbitrumMainnet, walletCommon.OptimismMainnet, walletCommon.UnknownChainID}
+	for _, chainID := range chainIDs {
+		quote, err := provider.FetchQuote(context.Background(), thirdparty.SwapQuoteParams{
+			ChainID: chainID,
+			Amount:  big.NewInt(1000),
+			Side:    thirdparty.SwapSideSell,
+		})
+		require.NoError(t, err)
+		require.Equal(t, chainID, client.chainID)
+		require.Equal(t, uint64(500), quote.GasCost)
+		require.Equal(t, testPriceRoute.TokenTransferProxy, quote.ApprovalAddress)
+		require.Equal(t, testPriceRoute.DestAmount.Int, quote.DestAmount)
+
+		partnerAddress, partnerFeePcnt := getPartnerAddressAndFeePcnt(chainID)
+		if partnerAddress != walletCommon.ZeroAddress() {
+			require.Greater(t, partnerFeePcnt, 0.0)
+
+			expectedFee := uint64(float64(testPriceRoute.DestAmount.Uint64()) * partnerFeePcnt / 100.0)
+			expectedDestAmount := testPriceRoute.DestAmount.Uint64() - expectedFee
+			require.InEpsilon(t, expectedDestAmount, quote.NetDestAmount().Uint64(), 2.0)
+		} else {
+			require.Equal(t, 0.0, partnerFeePcnt)
+			require.Equal(t, testPriceRoute.DestAmount.Uint64(), quote.NetDestAmount().Uint64())
+		}
+	}
+}
+
+func TestSwapQuoteProviderBuildTransaction(t *testing.T) {
+	client := &fakeClient{
+		transaction: Transaction{
+			From:     "0x1",
+			To:       "0x2",
+			Value:    "100",
+			Data:     "0x1234",
+			GasPrice: "3",
+			Gas:      "21000",
+			ChainID:  walletCommon.OptimismMainnet,
+		},
+	}
+	provider := NewSwapQuoteProviderWithClient(client)
+
+	tx, err := provider.BuildTransaction(context.Background(), &thirdparty.SwapQuote{ChainID: walletCommon.OptimismMainnet},
+		common.HexToAddress("0x1"), common.HexToAddress("0x1"), 50)
+	require.NoError(t, err)
+	require.Equal(t, walletCommon.OptimismMainnet, client.chainID)
+	require.Equal(t, common.HexToAddress("0x2"), tx.To)
+	require.Equal(t, big.NewInt(100), tx.Value)
+	require.Equal(t, []byte{0x12, 0x34}, tx.Data)
+	require.Equal(t, uint64(21000), tx.Gas)
+	require.Equal(t, big.NewInt(3), tx.GasPrice)
+
+	client.transaction.Value = "not a number"
+	_, err = provider.BuildTransaction(context.Background(), &thirdparty.SwapQuote{ChainID: walletCommon.OptimismMainnet},
+		common.HexToAddress("0x1"), common.HexToAddress("0x1"), 50)
+	require.ErrorIs(t, err, ErrConvertingAmountToBigInt)
+}
@@ -0,0 +1,85 @@
+package thirdparty
+
+//go:generate mockgen -package=mock_thirdparty -source=swap_types.go -destination=mock/swap_types.go
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type SwapSide int
+
+const (
+	// SwapSideSell quotes the amount received for an exact amount sent
+	SwapSideSell SwapSide = iota
+	// SwapSideBuy quotes the amount sent for an exact amount received
+	SwapSideBuy
+)
+
+type SwapToken struct {
+	Symbol   string
+	Address  common.Address
+	Decimals uint
+}
+
+type SwapQuoteParams struct {
+	ChainID           uint64
+	SrcTokenAddress   common.Address
+	SrcTokenDecimals  uint
+	DestTokenAddress  common.Address
+	DestTokenDecimals uint
+	Amount            *big.Int // source amount for SwapSideSell, destination amount for SwapSideBuy
+	Side              SwapSide
+	AddressFrom       common.Address
+	AddressTo         common.Address
+}
+
+type SwapQuote struct {
+	ProviderID        string
+	ChainID           uint64
+	Side              SwapSide
+	SrcTokenAddress   common.Address
+	SrcTokenDecimals  uint
+	SrcAmount         *big.Int
+	DestTokenAddress  common.Address
+	DestTokenDecimals uint
+	DestAmount        *big.Int // amount quoted by the provider
+	FeeAmount         *big.Int // fees taken from the quoted amount (in destination token), nil if there are none
+	GasCost           uint64
+	// ApprovalAddress is the address which has to be allowed to spend the source token
+	ApprovalAddress common.Address
+	// RawQuote is the provider specific quote, needed to build the transaction
+	RawQuote json.RawMessage
+}
+
+// NetDestAmount returns the amount received, net of fees
+func (q *SwapQuote) NetDestAmount() *big.Int {
+	if q.FeeAmount == nil {
+		return new(big.Int).Set(q.DestAmount)
+	}
+	return new(big.Int).Sub(q.DestAmount, q.FeeAmount)
+}
+
+type SwapTransaction struct {
+	ChainID  uint64
+	From     common.Address
+	To       common.Address
+	Value    *big.Int
+	Data     []byte
+	Gas      uint64
+	GasPrice *big.Int
+}
+
+// SwapQuoteProvider is implemented by DEX aggregators
+type SwapQuoteProvider interface {
+	ID() string
+	IsChainSupported(chainID uint64) bool
+	// IsSwapSupported returns false if the provider can't quote or build the swap, e.g. for a recipient other than the sender
+	IsSwapSupported(side SwapSide, addressFrom common.Address, addressTo common.Address) bool
+	FetchTokensList(ctx context.Context, chainID uint64) ([]SwapToken, error)
+	FetchQuote(ctx context.Context, params SwapQuoteParams) (*SwapQuote, error)
+	BuildTransaction(ctx context.Context, quote *SwapQuote, addressFrom common.Address, addressTo common.Address, slippageBasisPoints uint) (*SwapTransaction, error)
+}
@@ -0,0 +1,196 @@
+package zeroex
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	netUrl "net/url"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+const (
+	ZeroExID = "0x"
+
+	baseURL = "https://api.0x.org"
+
+	apiKeyHeader  = "0x-api-key"
+	versionHeader = "0x-version"
+	apiVersion    = "v2"
+
+	quoteEndpoint = "/swap/allowance-holder/quote"
+
+	// quoteSlippageBasisPoints is the slippage tolerance of the quotes, the transaction is part of the quote so
+	// it can't be built with a tighter tolerance afterwards
+	quoteSlippageBasisPoints = 50
+)
+
+// nativeTokenAddress is the address 0x uses for the native token of the chain
+var nativeTokenAddress = common.HexToAddress("0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee")
+
+var supportedChains = map[uint64]bool{
+	walletCommon.EthereumMainnet: true,
+	walletCommon.EthereumSepolia: true,
+	walletCommon.OptimismMainnet: true,
+	walletCommon.ArbitrumMainnet: true,
+}
+
+// Client implements thirdparty.SwapQuoteProvider using the 0x Swap API v2
+type Client struct {
+	httpClient *thirdparty.HTTPClient
+	apiKey     string
+	baseURL    string
+}
+
+func NewClient(apiKey string) *Client {
+	return &Client{
+		httpClient: thirdparty.NewHTTPClient(),
+		apiKey:     apiKey,
+		baseURL:    baseURL,
+	}
+}
+
+func (c *Client) ID() string {
+	return ZeroExID
+}
+
+func (c *Client) IsChainSupported(chainID uint64) bool {
+	return supportedChains[chainID]
+}
+
+// IsSwapSupported returns false for a fixed amount bought or a recipient other than the sender, 0x quotes neither
+func (c *Client) IsSwapSupported(side thirdparty.SwapSide, addressFrom common.Address, addressTo common.Address) bool {
+	return side == thirdparty.SwapSideSell && isSenderRecipient(addressFrom, addressTo)
+}
+
+func isSenderRecipient(addressFrom common.Address, addressTo common.Address) bool {
+	return addressTo == walletCommon.ZeroAddress() || addressTo == addressFrom
+}
+
+func (c *Client) doGetRequest(ctx context.Context, endpoint string, params netUrl.Values, result interface{}) error {
+	response, err := c.httpClient.DoGetRequestWithHeaders(ctx, c.baseURL+endpoint, params, map[string]string{
+		apiKeyHeader:  c.apiKey,
+		versionHeader: apiVersion,
+	})
+	if err != nil {
+		return err
+	}
+
+	return handleResponse(response, result)
+}
+
+func handleResponse(response []byte, result interface{}) error {
+	var errorResponse ErrorResponse
+	err := json.Unmarshal(response, &errorResponse)
+	if err == nil && errorResponse.Name != "" {
+		return &errorResponse
+	}
+
+	return json.Unmarshal(response, result)
+}
+
+// FetchTokensList returns the native token only, 0x has no tokens list and quotes the ERC-20 tokens by address
+func (c *Client) FetchTokensList(ctx context.Context, chainID uint64) ([]thirdparty.SwapToken, error) {
+	if !c.IsChainSupported(chainID) {
+		return nil, thirdparty.ErrChainIDNotSupported
+	}
+
+	return []thirdparty.SwapToken{
+		{
+			Symbol:   "ETH",
+			Address:  nativeTokenAddress,
+			Decimals: 18,
+		},
+	}, nil
+}
+
+// FetchQuote fetches a firm quote, it includes the transaction of the swap
+func (c *Client) FetchQuote(ctx context.Context, params thirdparty.SwapQuoteParams) (*thirdparty.SwapQuote, error) {
+	if !c.IsChainSupported(params.ChainID) {
+		return nil, thirdparty.ErrChainIDNotSupported
+	}
+	if params.Side == thirdparty.SwapSideBuy {
+		return nil, ErrBuySideNotSupported
+	}
+
+	requestParams := netUrl.Values{}
+	requestParams.Add("chainId", strconv.FormatUint(params.ChainID, 10))
+	requestParams.Add("sellToken", params.SrcTokenAddress.Hex())
+	requestParams.Add("buyToken", params.DestTokenAddress.Hex())
+	requestParams.Add("sellAmount", params.Amount.String())
+	requestParams.Add("taker", params.AddressFrom.Hex())
+	requestParams.Add("slippageBps", strconv.Itoa(quoteSlippageBasisPoints))
+
+	var quote Quote
+	err := c.doGetRequest(ctx, quoteEndpoint, requestParams, &quote)
+	if err != nil {
+		return nil, err
+	}
+
+	if !quote.LiquidityAvailable {
+		return nil, ErrNotEnoughLiquidity
+	}
+	if quote.SellAmount == nil || quote.BuyAmount == nil || quote.Transaction == nil || quote.Transaction.Gas == nil {
+		return nil, ErrIncompleteQuote
+	}
+
+	rawQuote, err := json.Marshal(firmQuote{
+		Quote:               quote,
+		SlippageBasisPoints: quoteSlippageBasisPoints,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &thirdparty.SwapQuote{
+		ProviderID:        ZeroExID,
+		ChainID:           params.ChainID,
+		Side:              params.Side,
+		SrcTokenAddress:   params.SrcTokenAddress,
+		SrcTokenDecimals:  params.SrcTokenDecimals,
+		SrcAmount:         quote.SellAmount.Int,
+		DestTokenAddress:  params.DestTokenAddress,
+		DestTokenDecimals: params.DestTokenDecimals,
+		DestAmount:        quote.BuyAmount.Int,
+		GasCost:           quote.Transaction.Gas.Uint64(),
+		ApprovalAddress:   quote.AllowanceTarget,
+		RawQuote:          rawQuote,
+	}, nil
+}
+
+// BuildTransaction returns the transaction of the firm quote, the quote isn't fetched again
+func (c *Client) BuildTransaction(ctx context.Context, quote *thirdparty.SwapQuote, addressFrom common.Address, addressTo common.Address,
+	slippageBasisPoints uint) (*thirdparty.SwapTransaction, error) {
+	if !isSenderRecipient(addressFrom, addressTo) {
+		return nil, ErrRecipientNotSupported
+	}
+
+	var firm firmQuote
+	err := json.Unmarshal(quote.RawQuote, &firm)
+	if err != nil {
+		return nil, err
+	}
+
+	if slippageBasisPoints > 0 && slippageBasisPoints < firm.SlippageBasisPoints {
+		return nil, ErrSlippageBelowQuote
+	}
+
+	tx := firm.Quote.Transaction
+	if tx == nil || tx.Value == nil || tx.Gas == nil || tx.GasPrice == nil {
+		return nil, ErrIncompleteQuote
+	}
+
+	return &thirdparty.SwapTransaction{
+		ChainID:  quote.ChainID,
+		From:     addressFrom,
+		To:       tx.To,
+		Value:    new(big.Int).Set(tx.Value.Int),
+		Data:     common.FromHex(tx.Data),
+		Gas:      tx.Gas.Uint64(),
+		GasPrice: new(big.Int).Set(tx.GasPrice.Int),
+	}, nil
+}
@@ -0,0 +1,166 @@
+package zeroex
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/stretchr/testify/require"
+
+	walletCommon "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+)
+
+const testQuoteResponse = `{
+	"liquidityAvailable": true,
+	"sellToken": "0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee",
+	"buyToken": "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48",
+	"sellAmount": "1000000000000000000",
+	"buyAmount": "3000500000",
+	"minBuyAmount": "2985497500",
+	"allowanceTarget": "0x0000000000001ff3684f28c67538d4d072c22734",
+	"transaction": {
+		"to": "0x0000000000001ff3684f28c67538d4d072c22734",
+		"data": "0xd9627aa4",
+		"value": "1000000000000000000",
+		"gas": "150000",
+		"gasPrice": "20000000000"
+	}
+}`
+
+const testNoLiquidityResponse = `{
+	"liquidityAvailable": false
+}`
+
+const testErrorResponse = `{
+	"name": "INPUT_INVALID",
+	"message": "The input is invalid",
+	"data": {"details": [{"field": "sellAmount", "reason": "Invalid ethereum amount"}]}
+}`
+
+func setupTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := NewClient("test-key")
+	client.baseURL = srv.URL
+	return client
+}
+
+func testQuoteParams() thirdparty.SwapQuoteParams {
+	return thirdparty.SwapQuoteParams{
+		ChainID:           walletCommon.EthereumMainnet,
+		SrcTokenAddress:   common.HexToAddress("0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee"),
+		SrcTokenDecimals:  18,
+		DestTokenAddress:  common.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"),
+		DestTokenDecimals: 6,
+		Amount:            big.NewInt(1000000000000000000),
+		Side:              thirdparty.SwapSideSell,
+		AddressFrom:       common.HexToAddress("0x1"),
+	}
+}
+
+func TestFetchQuote(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, quoteEndpoint, r.URL.Path)
+		require.Equal(t, "test-key", r.Header.Get(apiKeyHeader))
+		require.Equal(t, apiVersion, r.Header.Get(versionHeader))
+		require.Equal(t, "1", r.URL.Query().Get("chainId"))
+		require.Equal(t, "1000000000000000000", r.URL.Query().Get("sellAmount"))
+		require.Equal(t, common.HexToAddress("0x1").Hex(), r.URL.Query().Get("taker"))
+		require.Equal(t, "50", r.URL.Query().Get("slippageBps"))
+		_, _ = w.Write([]byte(testQuoteResponse))
+	})
+
+	quote, err := client.FetchQuote(context.Background(), testQuoteParams())
+	require.NoError(t, err)
+	require.Equal(t, ZeroExID, quote.ProviderID)
+	require.Equal(t, big.NewInt(1000000000000000000), quote.SrcAmount)
+	require.Equal(t, big.NewInt(3000500000), quote.DestAmount)
+	require.Equal(t, big.NewInt(3000500000), quote.NetDestAmount())
+	require.Equal(t, uint64(150000), quote.GasCost)
+	require.Equal(t, common.HexToAddress("0x0000000000001ff3684f28c67538d4d072c22734"), quote.ApprovalAddress)
+}
+
+func TestFetchQuoteError(t *testing.T) {
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(testErrorResponse))
+	})
+
+	_, err := client.FetchQuote(context.Background(), testQuoteParams())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "sellAmount: Invalid ethereum amount")
+
+	client = setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testNoLiquidityResponse))
+	})
+	_, err = client.FetchQuote(context.Background(), testQuoteParams())
+	require.ErrorIs(t, err, ErrNotEnoughLiquidity)
+
+	params := testQuoteParams()
+	params.Side = thirdparty.SwapSideBuy
+	_, err = client.FetchQuote(context.Background(), params)
+	require.ErrorIs(t, err, ErrBuySideNotSupported)
+}
+
+func TestBuildTransaction(t *testing.T) {
+	requests := 0
+	client := setupTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(testQuoteResponse))
+	})
+
+	quote, err := client.FetchQuote(context.Background(), testQuoteParams())
+	require.NoError(t, err)
+
+	// the transaction of the firm quote is used, no other quote is fetched
+	from := common.HexToAddress("0x1")
+	tx, err := client.BuildTransaction(context.Background(), quote, from, from, 100)
+	require.NoError(t, err)
+	require.Equal(t, 1, requests)
+	require.Equal(t, from, tx.From)
+	require.Equal(t, common.HexToAddress("0x0000000000001ff3684f28c67538d4d072c22734"), tx.To)
+	require.Equal(t, big.NewInt(1000000000000000000), tx.Value)
+	require.Equal(t, uint64(150000), tx.Gas)
+	require.Equal(t, big.NewInt(20000000000), tx.GasPrice)
+	require.Equal(t, []byte{0xd9, 0x62, 0x7a, 0xa4}, tx.Data)
+
+	_, err = client.BuildTransaction(context.Background(), quote, from, from, 10)
+	require.ErrorIs(t, err, ErrSlippageBelowQuote)
+
+	_, err = client.BuildTransaction(context.Background(), quote, from, common.HexToAddress("0x2"), 100)
+	require.ErrorIs(t, err, ErrRecipientNotSupported)
+}
+
+func TestFetchTokensList(t *testing.T) {
+	client := NewClient("")
+
+	tokens, err := client.FetchTokensList(context.Background(), walletCommon.EthereumMainnet)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	require.Equal(t, "ETH", tokens[0].Symbol)
+	require.Equal(t, nativeTokenAddress, tokens[0].Address)
+
+	_, err = client.FetchTokensList(context.Background(), walletCommon.UnknownChainID)
+	require.ErrorIs(t, err, thirdparty.ErrChainIDNotSupported)
+}
+
+func TestIsChainSupported(t *testing.T) {
+	client := NewClient("")
+	require.True(t, client.IsChainSupported(walletCommon.EthereumMainnet))
+	require.False(t, client.IsChainSupported(walletCommon.UnknownChainID))
+}
+
+func TestIsSwapSupported(t *testing.T) {
+	client := NewClient("")
+	from := common.HexToAddress("0x1")
+	require.True(t, client.IsSwapSupported(thirdparty.SwapSideSell, from, from))
+	require.True(t, client.IsSwapSupported(thirdparty.SwapSideSell, from, common.Address{}))
+	require.False(t, client.IsSwapSupported(thirdparty.SwapSideSell, from, common.HexToAddress("0x2")))
+	require.False(t, client.IsSwapSupported(thirdparty.SwapSideBuy, from, from))
+}
@@ -0,0 +1,71 @@
+package zeroex
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/status-go/services/wallet/bigint"
+)
+
+var (
+	ErrRecipientNotSupported = errors.New("0x swaps can't send the bought tokens to a different recipient")
+	ErrBuySideNotSupported   = errors.New("0x swaps can't quote a fixed amount bought")
+	ErrNotEnoughLiquidity    = errors.New("INSUFFICIENT_ASSET_LIQUIDITY")
+	ErrSlippageBelowQuote    = errors.New("the slippage tolerance is lower than the one of the 0x quote")
+	ErrIncompleteQuote       = errors.New("incomplete 0x quote")
+)
+
+// Quote is the response of the /swap/allowance-holder/quote endpoint
+type Quote struct {
+	LiquidityAvailable bool           `json:"liquidityAvailable"`
+	SellToken          common.Address `json:"sellToken"`
+	BuyToken           common.Address `json:"buyToken"`
+	SellAmount         *bigint.BigInt `json:"sellAmount"`
+	BuyAmount          *bigint.BigInt `json:"buyAmount"`
+	MinBuyAmount       *bigint.BigInt `json:"minBuyAmount"`
+	AllowanceTarget    common.Address `json:"allowanceTarget"`
+	Transaction        *Transaction   `json:"transaction"`
+}
+
+type Transaction struct {
+	To       common.Address `json:"to"`
+	Data     string         `json:"data"`
+	Value    *bigint.BigInt `json:"value"`
+	Gas      *bigint.BigInt `json:"gas"`
+	GasPrice *bigint.BigInt `json:"gasPrice"`
+}
+
+// firmQuote is kept as the raw quote of thirdparty.SwapQuote, the transaction is built from it
+type firmQuote struct {
+	Quote               Quote `json:"quote"`
+	SlippageBasisPoints uint  `json:"slippageBps"`
+}
+
+type ValidationError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+type ErrorData struct {
+	Details []ValidationError `json:"details"`
+}
+
+type ErrorResponse struct {
+	Name    string    `json:"name"`
+	Message string    `json:"message"`
+	Data    ErrorData `json:"data"`
+}
+
+func (e *ErrorResponse) Error() string {
+	reasons := make([]string, 0, len(e.Data.Details))
+	for _, v := range e.Data.Details {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", v.Field, v.Reason))
+	}
+	if len(reasons) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, strings.Join(reasons, ", "))
+}
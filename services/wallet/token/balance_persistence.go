@@ -19,6 +19,7 @@ type TokenMarketValues struct {
 	ChangePct24hour float64 `json:"changePct24hour"`
 	Change24hour    float64 `json:"change24hour"`
 	Price           float64 `json:"price"`
+	PriceConfidence float64 `json:"priceConfidence"`
 	HasError        bool    `json:"hasError"`
 }
 
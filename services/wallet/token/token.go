@@ -34,6 +34,7 @@ import (
 	"github.com/status-im/status-go/services/utils"
 	"github.com/status-im/status-go/services/wallet/bigint"
 	"github.com/status-im/status-go/services/wallet/community"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
 	"github.com/status-im/status-go/services/wallet/token/balancefetcher"
 	"github.com/status-im/status-go/services/wallet/walletevent"
 )
@@ -104,6 +105,7 @@ type Manager struct {
 	ContractMaker        *contracts.ContractMaker
 	networkManager       network.ManagerInterface
 	stores               []store // Set on init, not changed afterwards
+	remoteStores         []store // Token lists subscribed to by the user, guarded by tokenLock
 	communityTokensDB    *communitytokensdatabase.Database
 	communityManager     *community.Manager
 	mediaServer          *server.MediaServer
@@ -112,10 +114,13 @@ type Manager struct {
 	accountWatcher       *accountsevent.Watcher
 	accountsDB           *accounts.Database
 	tokenBalancesStorage TokenBalancesStorage
+	httpClient           *thirdparty.HTTPClient
 
 	tokens []*Token
 
-	tokenLock sync.RWMutex
+	tokenLock        sync.RWMutex
+	tokenListsLock   sync.Mutex // serializes the token list subscription changes
+	tokenListsCancel context.CancelFunc
 }
 
 func mergeTokens(sliceLists [][]*Token) []*Token {
@@ -133,7 +138,7 @@ func mergeTokens(sliceLists [][]*Token) []*Token {
 	return res
 }
 
-func prepareTokens(networkManager network.ManagerInterface, stores []store) []*Token {
+func prepareTokens(networkManager network.ManagerInterface, stores []store, verified bool) []*Token {
 	tokens := make([]*Token, 0)
 
 	networks, err := networkManager.GetAll()
@@ -144,7 +149,7 @@ func prepareTokens(networkManager network.ManagerInterface, stores []store) []*T
 	for _, store := range stores {
 		validTokens := make([]*Token, 0)
 		for _, token := range store.GetTokens() {
-			token.Verified = true
+			token.Verified = verified
 
 			for _, network := range networks {
 				if network.ChainID == token.ChainID {
@@ -159,6 +164,15 @@ func prepareTokens(networkManager network.ManagerInterface, stores []store) []*T
 	return tokens
 }
 
+// prepareAllTokens merges the tokens of the stores, the compiled-in stores take precedence over the token lists
+// subscribed to by the user, whose tokens are not verified
+func prepareAllTokens(networkManager network.ManagerInterface, stores []store, remoteStores []store) []*Token {
+	return mergeTokens([][]*Token{
+		prepareTokens(networkManager, stores, true),
+		prepareTokens(networkManager, remoteStores, false),
+	})
+}
+
 func NewTokenManager(
 	db *sql.DB,
 	RPCClient rpc.ClientInterface,
@@ -173,9 +187,9 @@ func NewTokenManager(
 ) *Manager {
 	maker, _ := contracts.NewContractMaker(RPCClient)
 	stores := []store{newUniswapStore(), newDefaultStore()}
-	tokens := prepareTokens(networkManager, stores)
+	tokens := prepareTokens(networkManager, stores, true)
 
-	return &Manager{
+	tm := &Manager{
 		BalanceFetcher:       balancefetcher.NewDefaultBalanceFetcher(maker),
 		db:                   db,
 		RPCClient:            RPCClient,
@@ -190,11 +204,22 @@ func NewTokenManager(
 		accountFeed:          accountFeed,
 		accountsDB:           accountsDB,
 		tokenBalancesStorage: tokenBalancesStorage,
+		httpClient:           thirdparty.NewHTTPClient(),
+	}
+
+	if db != nil {
+		err := tm.loadTokenLists()
+		if err != nil {
+			log.Error("failed to load token lists", "error", err)
+		}
 	}
+
+	return tm
 }
 
 func (tm *Manager) Start() {
 	tm.startAccountsWatcher()
+	tm.startTokenListsRefresh()
 }
 
 func (tm *Manager) startAccountsWatcher() {
@@ -208,6 +233,7 @@ func (tm *Manager) startAccountsWatcher() {
 
 func (tm *Manager) Stop() {
 	tm.stopAccountsWatcher()
+	tm.stopTokenListsRefresh()
 }
 
 func (tm *Manager) stopAccountsWatcher() {
@@ -573,6 +599,17 @@ func (tm *Manager) GetList() *ListWrapper {
 			Version: store.GetVersion(),
 		})
 	}
+	for _, store := range tm.getRemoteStores() {
+		if store.GetUpdatedAt() > updatedAt {
+			updatedAt = store.GetUpdatedAt()
+		}
+		data = append(data, &List{
+			Name:    store.GetName(),
+			Tokens:  store.GetTokens(),
+			Source:  store.GetSource(),
+			Version: store.GetVersion(),
+		})
+	}
 	return &ListWrapper{
 		Data:      data,
 		UpdatedAt: updatedAt,
@@ -0,0 +1,221 @@
+package token
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	maxTokenListTokens     = 10000
+	maxTokenListNameLength = 30
+	maxTokenNameLength     = 60
+	maxTokenSymbolLength   = 20
+	maxTokenDecimals       = 255
+)
+
+var (
+	ErrInvalidTokenList      = errors.New("invalid token list")
+	ErrTokenListNotFound     = errors.New("token list subscription not found")
+	ErrTokenListAlreadyAdded = errors.New("token list already subscribed")
+)
+
+// TokenListVersion is the semantic version of a token list, bumped by the list maintainers on every change
+type TokenListVersion struct {
+	Major uint64 `json:"major"`
+	Minor uint64 `json:"minor"`
+	Patch uint64 `json:"patch"`
+}
+
+func (v TokenListVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1, 0 or 1 if the version is lower, equal or greater than the other one
+func (v TokenListVersion) Compare(other TokenListVersion) int {
+	for _, diff := range [][2]uint64{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if diff[0] < diff[1] {
+			return -1
+		}
+		if diff[0] > diff[1] {
+			return 1
+		}
+	}
+	return 0
+}
+
+func parseTokenListVersion(version string) (TokenListVersion, error) {
+	var v TokenListVersion
+	_, err := fmt.Sscanf(version, "%d.%d.%d", &v.Major, &v.Minor, &v.Patch)
+	return v, err
+}
+
+type tokenListToken struct {
+	ChainID  uint64 `json:"chainId"`
+	Address  string `json:"address"`
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint   `json:"decimals"`
+	LogoURI  string `json:"logoURI"`
+}
+
+// tokenList is a token list following the Uniswap token list schema https://uniswap.org/tokenlist.schema.json
+type tokenList struct {
+	Name      string           `json:"name"`
+	Timestamp string           `json:"timestamp"`
+	Version   TokenListVersion `json:"version"`
+	Tokens    []tokenListToken `json:"tokens"`
+}
+
+func invalidTokenList(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidTokenList, fmt.Sprintf(format, args...))
+}
+
+// validate checks the fields required by the schema, unknown fields like `tags` and `extensions` are ignored
+func (l *tokenList) validate() (time.Time, error) {
+	if l.Name == "" || len(l.Name) > maxTokenListNameLength {
+		return time.Time{}, invalidTokenList("name must have 1 to %d characters", maxTokenListNameLength)
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, l.Timestamp)
+	if err != nil {
+		return time.Time{}, invalidTokenList("timestamp is not a RFC3339 date")
+	}
+
+	if len(l.Tokens) == 0 || len(l.Tokens) > maxTokenListTokens {
+		return time.Time{}, invalidTokenList("list must have 1 to %d tokens", maxTokenListTokens)
+	}
+
+	for i, token := range l.Tokens {
+		if token.ChainID == 0 {
+			return time.Time{}, invalidTokenList("token %d has no chain id", i)
+		}
+		if !common.IsHexAddress(token.Address) {
+			return time.Time{}, invalidTokenList("token %d has an invalid address", i)
+		}
+		if token.Name == "" || len(token.Name) > maxTokenNameLength {
+			return time.Time{}, invalidTokenList("token %d name must have 1 to %d characters", i, maxTokenNameLength)
+		}
+		if token.Symbol == "" || len(token.Symbol) > maxTokenSymbolLength {
+			return time.Time{}, invalidTokenList("token %d symbol must have 1 to %d characters", i, maxTokenSymbolLength)
+		}
+		if token.Decimals > maxTokenDecimals {
+			return time.Time{}, invalidTokenList("token %d decimals must be at most %d", i, maxTokenDecimals)
+		}
+	}
+
+	return timestamp, nil
+}
+
+func parseTokenList(data []byte) (*tokenList, time.Time, error) {
+	var list tokenList
+	err := json.Unmarshal(data, &list)
+	if err != nil {
+		return nil, time.Time{}, invalidTokenList("%v", err)
+	}
+
+	timestamp, err := list.validate()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return &list, timestamp, nil
+}
+
+// toTokens converts the list tokens, the tokens are identified by the list URL
+func (l *tokenList) toTokens(tokenListID string) []*Token {
+	tokens := make([]*Token, 0, len(l.Tokens))
+	for _, t := range l.Tokens {
+		tokens = append(tokens, &Token{
+			Address:     common.HexToAddress(t.Address),
+			Name:        t.Name,
+			Symbol:      t.Symbol,
+			Decimals:    t.Decimals,
+			ChainID:     t.ChainID,
+			PegSymbol:   GetTokenPegSymbol(t.Symbol),
+			Image:       t.LogoURI,
+			TokenListID: tokenListID,
+		})
+	}
+	return tokens
+}
+
+// TokenListDiff lists the tokens added, removed or changed by a new version of a token list
+type TokenListDiff struct {
+	Added   []*Token `json:"added"`
+	Removed []*Token `json:"removed"`
+	Changed []*Token `json:"changed"`
+}
+
+func (d *TokenListDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+func tokenKey(token *Token) string {
+	return strconv.FormatUint(token.ChainID, 10) + token.Address.String()
+}
+
+func diffTokens(previous []*Token, current []*Token) *TokenListDiff {
+	diff := &TokenListDiff{
+		Added:   make([]*Token, 0),
+		Removed: make([]*Token, 0),
+		Changed: make([]*Token, 0),
+	}
+
+	previousByKey := make(map[string]*Token, len(previous))
+	for _, token := range previous {
+		previousByKey[tokenKey(token)] = token
+	}
+
+	currentKeys := make(map[string]bool, len(current))
+	for _, token := range current {
+		key := tokenKey(token)
+		currentKeys[key] = true
+
+		previousToken, ok := previousByKey[key]
+		if !ok {
+			diff.Added = append(diff.Added, token)
+			continue
+		}
+		if previousToken.Name != token.Name || previousToken.Symbol != token.Symbol ||
+			previousToken.Decimals != token.Decimals || previousToken.Image != token.Image {
+			diff.Changed = append(diff.Changed, token)
+		}
+	}
+
+	for _, token := range previous {
+		if !currentKeys[tokenKey(token)] {
+			diff.Removed = append(diff.Removed, token)
+		}
+	}
+
+	return diff
+}
+
+// remoteStore is a token list the user subscribed to, as last fetched
+type remoteStore struct {
+	subscription *TokenListSubscription
+}
+
+func (s *remoteStore) GetTokens() []*Token {
+	return s.subscription.Tokens
+}
+
+func (s *remoteStore) GetName() string {
+	return s.subscription.Name
+}
+
+func (s *remoteStore) GetVersion() string {
+	return s.subscription.Version
+}
+
+func (s *remoteStore) GetUpdatedAt() int64 {
+	return s.subscription.ListTimestamp
+}
+
+func (s *remoteStore) GetSource() string {
+	return s.subscription.URL
+}
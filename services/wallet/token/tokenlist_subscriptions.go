@@ -0,0 +1,356 @@
+package token
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	gocommon "github.com/status-im/status-go/common"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+)
+
+const (
+	EventTokenListUpdated walletevent.EventType = "wallet-token-list-updated"
+
+	tokenListsRefreshInterval = 6 * time.Hour
+)
+
+// TokenListSubscription is a remote token list the user subscribed to, the tokens of the last fetched version are kept
+type TokenListSubscription struct {
+	URL           string   `json:"url"`
+	Name          string   `json:"name"`
+	Version       string   `json:"version"`
+	ListTimestamp int64    `json:"listTimestamp"`
+	Tokens        []*Token `json:"tokens"`
+	LastFetchedAt int64    `json:"lastFetchedAt"`
+	LastError     string   `json:"lastError,omitempty"`
+	CreatedAt     int64    `json:"createdAt"`
+}
+
+// TokenListUpdate is the message of the `EventTokenListUpdated` event
+type TokenListUpdate struct {
+	URL             string         `json:"url"`
+	Name            string         `json:"name"`
+	PreviousVersion string         `json:"previousVersion,omitempty"`
+	Version         string         `json:"version,omitempty"`
+	Diff            *TokenListDiff `json:"diff"`
+}
+
+const tokenListSubscriptionColumns = "url, name, version, list_timestamp, tokens, last_fetched_at, last_error, created_at"
+
+func upsertTokenListSubscription(db *sql.DB, subscription *TokenListSubscription) error {
+	tokens, err := json.Marshal(subscription.Tokens)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`INSERT OR REPLACE INTO token_list_subscriptions (`+tokenListSubscriptionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		subscription.URL, subscription.Name, subscription.Version, subscription.ListTimestamp, string(tokens),
+		subscription.LastFetchedAt, subscription.LastError, subscription.CreatedAt)
+	return err
+}
+
+func deleteTokenListSubscription(db *sql.DB, listURL string) error {
+	_, err := db.Exec(`DELETE FROM token_list_subscriptions WHERE url = ?`, listURL)
+	return err
+}
+
+func getTokenListSubscriptions(db *sql.DB, listURL string) ([]*TokenListSubscription, error) {
+	query := `SELECT ` + tokenListSubscriptionColumns + ` FROM token_list_subscriptions`
+	args := []interface{}{}
+	if listURL != "" {
+		query += ` WHERE url = ?`
+		args = append(args, listURL)
+	}
+	query += ` ORDER BY created_at`
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := make([]*TokenListSubscription, 0)
+	for rows.Next() {
+		subscription := &TokenListSubscription{}
+		var tokens string
+		err := rows.Scan(&subscription.URL, &subscription.Name, &subscription.Version, &subscription.ListTimestamp, &tokens,
+			&subscription.LastFetchedAt, &subscription.LastError, &subscription.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal([]byte(tokens), &subscription.Tokens)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, rows.Err()
+}
+
+func getTokenListSubscription(db *sql.DB, listURL string) (*TokenListSubscription, error) {
+	subscriptions, err := getTokenListSubscriptions(db, listURL)
+	if err != nil {
+		return nil, err
+	}
+	if len(subscriptions) == 0 {
+		return nil, ErrTokenListNotFound
+	}
+	return subscriptions[0], nil
+}
+
+func validateTokenListURL(listURL string) error {
+	u, err := url.Parse(listURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return invalidTokenList("only https URLs are supported")
+	}
+	return nil
+}
+
+// loadTokenLists sets the token lists subscribed to as stores and rebuilds the tokens
+func (tm *Manager) loadTokenLists() error {
+	subscriptions, err := getTokenListSubscriptions(tm.db, "")
+	if err != nil {
+		return err
+	}
+
+	remoteStores := make([]store, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		remoteStores = append(remoteStores, &remoteStore{subscription: subscription})
+	}
+
+	tm.tokenLock.Lock()
+	tm.remoteStores = remoteStores
+	tm.tokenLock.Unlock()
+
+	tm.SetTokens(prepareAllTokens(tm.networkManager, tm.stores, remoteStores))
+	return nil
+}
+
+func (tm *Manager) getRemoteStores() []store {
+	tm.tokenLock.RLock()
+	defer tm.tokenLock.RUnlock()
+
+	return tm.remoteStores
+}
+
+func (tm *Manager) sendTokenListUpdated(update *TokenListUpdate) {
+	if tm.walletFeed == nil {
+		return
+	}
+
+	message, err := json.Marshal(update)
+	if err != nil {
+		log.Error("failed to marshal token list update", "error", err)
+		return
+	}
+
+	tm.walletFeed.Send(walletevent.Event{
+		Type:    EventTokenListUpdated,
+		Message: string(message),
+	})
+}
+
+func (tm *Manager) fetchTokenList(ctx context.Context, listURL string) (*tokenList, time.Time, error) {
+	data, err := tm.httpClient.DoGetRequest(ctx, listURL, nil, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return parseTokenList(data)
+}
+
+// SubscribeTokenList fetches the Uniswap format token list at the given URL and adds its tokens to the token list,
+// the list is refreshed periodically
+func (tm *Manager) SubscribeTokenList(ctx context.Context, listURL string) (*TokenListSubscription, error) {
+	err := validateTokenListURL(listURL)
+	if err != nil {
+		return nil, err
+	}
+
+	tm.tokenListsLock.Lock()
+	defer tm.tokenListsLock.Unlock()
+
+	_, err = getTokenListSubscription(tm.db, listURL)
+	if err == nil {
+		return nil, ErrTokenListAlreadyAdded
+	}
+	if err != ErrTokenListNotFound {
+		return nil, err
+	}
+
+	list, timestamp, err := tm.fetchTokenList(ctx, listURL)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	subscription := &TokenListSubscription{
+		URL:           listURL,
+		Name:          list.Name,
+		Version:       list.Version.String(),
+		ListTimestamp: timestamp.Unix(),
+		Tokens:        list.toTokens(listURL),
+		LastFetchedAt: now,
+		CreatedAt:     now,
+	}
+	err = upsertTokenListSubscription(tm.db, subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tm.loadTokenLists()
+	if err != nil {
+		return nil, err
+	}
+
+	tm.sendTokenListUpdated(&TokenListUpdate{
+		URL:     listURL,
+		Name:    subscription.Name,
+		Version: subscription.Version,
+		Diff:    diffTokens(nil, subscription.Tokens),
+	})
+	return subscription, nil
+}
+
+// UnsubscribeTokenList removes the token list and its tokens
+func (tm *Manager) UnsubscribeTokenList(listURL string) error {
+	tm.tokenListsLock.Lock()
+	defer tm.tokenListsLock.Unlock()
+
+	subscription, err := getTokenListSubscription(tm.db, listURL)
+	if err != nil {
+		return err
+	}
+
+	err = deleteTokenListSubscription(tm.db, listURL)
+	if err != nil {
+		return err
+	}
+
+	err = tm.loadTokenLists()
+	if err != nil {
+		return err
+	}
+
+	tm.sendTokenListUpdated(&TokenListUpdate{
+		URL:             listURL,
+		Name:            subscription.Name,
+		PreviousVersion: subscription.Version,
+		Diff:            diffTokens(subscription.Tokens, nil),
+	})
+	return nil
+}
+
+func (tm *Manager) GetTokenListSubscriptions() ([]*TokenListSubscription, error) {
+	return getTokenListSubscriptions(tm.db, "")
+}
+
+// updateTokenList fetches the list again, the tokens are replaced only if the list version was bumped.
+// It returns the changes applied, nil if the list was not updated.
+func (tm *Manager) updateTokenList(ctx context.Context, subscription *TokenListSubscription) (*TokenListUpdate, error) {
+	subscription.LastFetchedAt = time.Now().Unix()
+
+	list, timestamp, err := tm.fetchTokenList(ctx, subscription.URL)
+	if err != nil {
+		subscription.LastError = err.Error()
+		return nil, upsertTokenListSubscription(tm.db, subscription)
+	}
+	subscription.LastError = ""
+
+	var update *TokenListUpdate
+	currentVersion, err := parseTokenListVersion(subscription.Version)
+	if err != nil || list.Version.Compare(currentVersion) > 0 {
+		tokens := list.toTokens(subscription.URL)
+		update = &TokenListUpdate{
+			URL:             subscription.URL,
+			Name:            list.Name,
+			PreviousVersion: subscription.Version,
+			Version:         list.Version.String(),
+			Diff:            diffTokens(subscription.Tokens, tokens),
+		}
+
+		subscription.Name = list.Name
+		subscription.Version = list.Version.String()
+		subscription.ListTimestamp = timestamp.Unix()
+		subscription.Tokens = tokens
+	}
+
+	return update, upsertTokenListSubscription(tm.db, subscription)
+}
+
+// UpdateTokenLists refreshes all the token lists subscribed to, a `EventTokenListUpdated` event is sent for every
+// list with a new version. Fetching errors are kept in the subscriptions and don't stop the update of the other lists.
+func (tm *Manager) UpdateTokenLists(ctx context.Context) error {
+	tm.tokenListsLock.Lock()
+	defer tm.tokenListsLock.Unlock()
+
+	subscriptions, err := getTokenListSubscriptions(tm.db, "")
+	if err != nil {
+		return err
+	}
+
+	updates := make([]*TokenListUpdate, 0)
+	for _, subscription := range subscriptions {
+		update, err := tm.updateTokenList(ctx, subscription)
+		if err != nil {
+			return err
+		}
+		if update != nil {
+			updates = append(updates, update)
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	err = tm.loadTokenLists()
+	if err != nil {
+		return err
+	}
+
+	for _, update := range updates {
+		tm.sendTokenListUpdated(update)
+	}
+	return nil
+}
+
+func (tm *Manager) startTokenListsRefresh() {
+	if tm.tokenListsCancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	tm.tokenListsCancel = cancel
+
+	go func() {
+		defer gocommon.LogOnPanic()
+		ticker := time.NewTicker(tokenListsRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			err := tm.UpdateTokenLists(ctx)
+			if err != nil {
+				log.Error("failed to update token lists", "error", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (tm *Manager) stopTokenListsRefresh() {
+	if tm.tokenListsCancel != nil {
+		tm.tokenListsCancel()
+		tm.tokenListsCancel = nil
+	}
+}
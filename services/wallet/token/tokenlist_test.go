@@ -0,0 +1,197 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+
+	"github.com/status-im/status-go/appdatabase"
+	"github.com/status-im/status-go/params"
+	"github.com/status-im/status-go/rpc/network"
+	"github.com/status-im/status-go/services/wallet/thirdparty"
+	"github.com/status-im/status-go/services/wallet/walletevent"
+	"github.com/status-im/status-go/t/helpers"
+	"github.com/status-im/status-go/walletdatabase"
+)
+
+func testTokenListJSON(version string, tokens ...string) string {
+	var major, minor, patch int
+	_, _ = fmt.Sscanf(version, "%d.%d.%d", &major, &minor, &patch)
+	list := fmt.Sprintf(`{"name":"Test List","timestamp":"2024-08-05T10:00:00.000Z","version":{"major":%d,"minor":%d,"patch":%d},"tokens":[`, major, minor, patch)
+	for i, token := range tokens {
+		if i > 0 {
+			list += ","
+		}
+		list += token
+	}
+	return list + "]}"
+}
+
+func testListToken(address string, symbol string, decimals int) string {
+	return fmt.Sprintf(`{"chainId":1,"address":"%s","name":"%s Token","symbol":"%s","decimals":%d,"logoURI":"https://example.com/%s.png"}`,
+		address, symbol, symbol, decimals, symbol)
+}
+
+func TestParseTokenList(t *testing.T) {
+	list, timestamp, err := parseTokenList([]byte(testTokenListJSON("1.2.3",
+		testListToken("0x0000000000000000000000000000000000000001", "AAA", 18))))
+	require.NoError(t, err)
+	require.Equal(t, "Test List", list.Name)
+	require.Equal(t, "1.2.3", list.Version.String())
+	require.Equal(t, int64(1722852000), timestamp.Unix())
+
+	tokens := list.toTokens("https://example.com/list.json")
+	require.Len(t, tokens, 1)
+	require.Equal(t, common.HexToAddress("0x01"), tokens[0].Address)
+	require.Equal(t, "https://example.com/list.json", tokens[0].TokenListID)
+	require.Equal(t, "https://example.com/AAA.png", tokens[0].Image)
+
+	invalidLists := []string{
+		`{"name":"Test List"}`,
+		testTokenListJSON("1.0.0"),
+		testTokenListJSON("1.0.0", testListToken("0xinvalid", "AAA", 18)),
+		testTokenListJSON("1.0.0", testListToken("0x0000000000000000000000000000000000000001", "", 18)),
+		testTokenListJSON("1.0.0", testListToken("0x0000000000000000000000000000000000000001", "AAA", 256)),
+		`{"name":"Test List","timestamp":"yesterday","version":{"major":1},"tokens":[` + testListToken("0x0000000000000000000000000000000000000001", "AAA", 18) + `]}`,
+	}
+	for _, invalidList := range invalidLists {
+		_, _, err = parseTokenList([]byte(invalidList))
+		require.True(t, errors.Is(err, ErrInvalidTokenList), invalidList)
+	}
+}
+
+func TestTokenListVersionCompare(t *testing.T) {
+	v, err := parseTokenListVersion("1.2.3")
+	require.NoError(t, err)
+
+	require.Equal(t, 0, v.Compare(TokenListVersion{1, 2, 3}))
+	require.Equal(t, 1, v.Compare(TokenListVersion{1, 2, 2}))
+	require.Equal(t, -1, v.Compare(TokenListVersion{1, 10, 0}))
+	require.Equal(t, -1, v.Compare(TokenListVersion{2, 0, 0}))
+}
+
+func TestDiffTokens(t *testing.T) {
+	kept := &Token{ChainID: 1, Address: common.Address{1}, Symbol: "AAA", Decimals: 18}
+	removed := &Token{ChainID: 1, Address: common.Address{2}, Symbol: "BBB", Decimals: 18}
+	changed := &Token{ChainID: 1, Address: common.Address{3}, Symbol: "CCC", Decimals: 18}
+	added := &Token{ChainID: 10, Address: common.Address{1}, Symbol: "AAA", Decimals: 18}
+
+	changedNewVersion := *changed
+	changedNewVersion.Decimals = 6
+
+	diff := diffTokens([]*Token{kept, removed, changed}, []*Token{kept, &changedNewVersion, added})
+	require.Equal(t, []*Token{added}, diff.Added)
+	require.Equal(t, []*Token{removed}, diff.Removed)
+	require.Equal(t, []*Token{&changedNewVersion}, diff.Changed)
+
+	require.True(t, diffTokens([]*Token{kept}, []*Token{kept}).IsEmpty())
+}
+
+func setupTokenListManager(t *testing.T) (*Manager, *event.Feed) {
+	appDB, err := helpers.SetupTestMemorySQLDB(appdatabase.DbInitializer{})
+	require.NoError(t, err)
+	walletDB, err := helpers.SetupTestMemorySQLDB(walletdatabase.DbInitializer{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, appDB.Close())
+		require.NoError(t, walletDB.Close())
+	})
+
+	nm := network.NewManager(appDB)
+	err = nm.Init([]params.Network{{ChainID: 1, ChainName: "Mainnet"}})
+	require.NoError(t, err)
+
+	walletFeed := &event.Feed{}
+	return &Manager{
+		db:             walletDB,
+		networkManager: nm,
+		walletFeed:     walletFeed,
+		httpClient:     thirdparty.NewHTTPClient(),
+	}, walletFeed
+}
+
+func TestTokenListSubscriptions(t *testing.T) {
+	manager, walletFeed := setupTokenListManager(t)
+
+	ch := make(chan walletevent.Event, 10)
+	sub := walletFeed.Subscribe(ch)
+	defer sub.Unsubscribe()
+
+	listJSON := testTokenListJSON("1.0.0",
+		testListToken("0x0000000000000000000000000000000000000001", "AAA", 18),
+		testListToken("0x0000000000000000000000000000000000000002", "BBB", 18))
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(listJSON))
+	}))
+	defer server.Close()
+	manager.httpClient = thirdparty.NewHTTPClientWithTransport(server.Client().Transport)
+
+	_, err := manager.SubscribeTokenList(context.Background(), "ftp://example.com/list.json")
+	require.True(t, errors.Is(err, ErrInvalidTokenList))
+	_, err = manager.SubscribeTokenList(context.Background(), "http://example.com/list.json")
+	require.True(t, errors.Is(err, ErrInvalidTokenList))
+
+	subscription, err := manager.SubscribeTokenList(context.Background(), server.URL)
+	require.NoError(t, err)
+	require.Equal(t, "Test List", subscription.Name)
+	require.Equal(t, "1.0.0", subscription.Version)
+	require.Len(t, subscription.Tokens, 2)
+
+	_, err = manager.SubscribeTokenList(context.Background(), server.URL)
+	require.Equal(t, ErrTokenListAlreadyAdded, err)
+
+	token := manager.FindTokenByAddress(1, common.HexToAddress("0x01"))
+	require.NotNil(t, token)
+	require.False(t, token.Verified)
+
+	update := <-ch
+	require.Equal(t, EventTokenListUpdated, update.Type)
+	var message TokenListUpdate
+	require.NoError(t, json.Unmarshal([]byte(update.Message), &message))
+	require.Len(t, message.Diff.Added, 2)
+
+	list := manager.GetList()
+	require.Equal(t, server.URL, list.Data[len(list.Data)-1].Source)
+
+	// the same version is not applied again
+	listJSON = testTokenListJSON("1.0.0", testListToken("0x0000000000000000000000000000000000000003", "CCC", 18))
+	require.NoError(t, manager.UpdateTokenLists(context.Background()))
+	require.Nil(t, manager.FindTokenByAddress(1, common.HexToAddress("0x03")))
+
+	listJSON = testTokenListJSON("1.1.0",
+		testListToken("0x0000000000000000000000000000000000000001", "AAA", 6),
+		testListToken("0x0000000000000000000000000000000000000003", "CCC", 18))
+	require.NoError(t, manager.UpdateTokenLists(context.Background()))
+	require.NotNil(t, manager.FindTokenByAddress(1, common.HexToAddress("0x03")))
+	require.Nil(t, manager.FindTokenByAddress(1, common.HexToAddress("0x02")))
+
+	update = <-ch
+	require.NoError(t, json.Unmarshal([]byte(update.Message), &message))
+	require.Equal(t, "1.0.0", message.PreviousVersion)
+	require.Equal(t, "1.1.0", message.Version)
+	require.Len(t, message.Diff.Added, 1)
+	require.Len(t, message.Diff.Removed, 1)
+	require.Len(t, message.Diff.Changed, 1)
+
+	// fetching errors are kept in the subscription
+	listJSON = "not a token list"
+	require.NoError(t, manager.UpdateTokenLists(context.Background()))
+	subscriptions, err := manager.GetTokenListSubscriptions()
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 1)
+	require.NotEmpty(t, subscriptions[0].LastError)
+	require.Equal(t, "1.1.0", subscriptions[0].Version)
+
+	require.NoError(t, manager.UnsubscribeTokenList(server.URL))
+	require.Nil(t, manager.FindTokenByAddress(1, common.HexToAddress("0x01")))
+	require.Equal(t, ErrTokenListNotFound, manager.UnsubscribeTokenList(server.URL))
+}
@@ -10,4 +10,5 @@ var (
 	ErrNoTrsansactionsBeingBuilt = &errors.ErrorResponse{Code: errors.ErrorCode("WT-002"), Details: "no transactions being built"}
 	ErrMissingSignatureForTx     = &errors.ErrorResponse{Code: errors.ErrorCode("WT-003"), Details: "missing signature for transaction %s"}
 	ErrInvalidSignatureDetails   = &errors.ErrorResponse{Code: errors.ErrorCode("WT-004"), Details: "invalid signature details"}
+	ErrRouterTransactionsInUse   = &errors.ErrorResponse{Code: errors.ErrorCode("WT-005"), Details: "transactions of another route are being sent"}
 )
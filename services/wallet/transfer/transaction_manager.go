@@ -1,8 +1,10 @@
 package transfer
 
 import (
+	"context"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
@@ -11,13 +13,14 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/status-im/status-go/account"
-	"github.com/status-im/status-go/eth-node/crypto"
+	"github.com/status-im/status-go/account/signer"
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/multiaccounts/accounts"
 	"github.com/status-im/status-go/params"
 	wallet_common "github.com/status-im/status-go/services/wallet/common"
 	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
 	"github.com/status-im/status-go/services/wallet/router/routes"
+	"github.com/status-im/status-go/services/wallet/thirdparty/bundler"
 	"github.com/status-im/status-go/transactions"
 )
 
@@ -50,6 +53,8 @@ type RouterTransactionDetails struct {
 	txHashToSign       types.Hash
 	txSignature        []byte
 	txSentHash         types.Hash
+	userOp             *bundler.UserOperation // set instead of tx for the paths sending a user operation
+	userOpProcessor    pathprocessor.UserOperationProcessor
 	approvalTxArgs     *transactions.SendTxArgs
 	approvalTx         *ethTypes.Transaction
 	approvalHashToSign types.Hash
@@ -72,7 +77,8 @@ type TransactionManager struct {
 	transactionsForKeycardSigning     map[common.Hash]*TransactionDescription
 
 	// used in a new approach
-	routerTransactions []*RouterTransactionDetails
+	routerTransactionsMu sync.Mutex // serializes the flows building and sending the router transactions
+	routerTransactions   []*RouterTransactionDetails
 }
 
 type MultiTransactionStorage interface {
@@ -189,14 +195,21 @@ func NewMultiTransaction(timestamp uint64, fromNetworkID, toNetworkID uint64, fr
 	}
 }
 
-func (tm *TransactionManager) SignMessage(message types.HexBytes, account *types.Key) (string, error) {
-	if account == nil || account.PrivateKey == nil {
-		return "", fmt.Errorf("account or private key is nil")
+// SignMessage signs the 32 bytes hash with the signer of the account, V of the signature is 0 or 1. The external signers
+// don't sign hashes.
+func (tm *TransactionManager) SignMessage(ctx context.Context, message types.HexBytes, selectedAccount *account.SelectedExtKey) (string, error) {
+	if selectedAccount == nil {
+		return "", fmt.Errorf("account is nil")
+	}
+	if len(message) != common.HashLength {
+		return "", fmt.Errorf("the message must be a 32 bytes hash")
 	}
 
-	signature, err := crypto.Sign(message[:], account.PrivateKey)
-
-	return types.EncodeHex(signature), err
+	signature, err := signer.SignHash(ctx, selectedAccount.Signer(), common.Address(selectedAccount.Address), common.BytesToHash(message))
+	if err != nil {
+		return "", err
+	}
+	return types.EncodeHex(signature), nil
 }
 
 func (tm *TransactionManager) BuildTransaction(chainID uint64, sendArgs transactions.SendTxArgs) (response *TxResponse, err error) {
@@ -0,0 +1,88 @@
+package transfer
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/eth-node/types"
+	wallet_common "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/router/fees"
+	"github.com/status-im/status-go/transactions"
+)
+
+// ReplacePendingTransaction speeds up or cancels a pending transaction by sending a new one with the same nonce
+// and higher fees. The replacement inherits the multi transaction of the replaced one, so both are shown as a single entry.
+func (tm *TransactionManager) ReplacePendingTransaction(ctx context.Context, feeManager *fees.FeeManager, pendingTx *transactions.PendingTransaction,
+	replacementType transactions.ReplacementType, account *account.SelectedExtKey) (types.Hash, error) {
+	if pendingTx.Status != nil && *pendingTx.Status != transactions.Pending {
+		return types.Hash{}, transactions.ErrTransactionNotPending
+	}
+
+	chainID := uint64(pendingTx.ChainID)
+	tx, isPending, err := tm.transactor.GetTransactionByHash(chainID, pendingTx.Hash)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	if !isPending {
+		return types.Hash{}, transactions.ErrTransactionNotPending
+	}
+
+	suggestedFees, err := feeManager.SuggestedFees(ctx, chainID)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	replacementFees := &transactions.ReplacementFees{
+		GasPrice: suggestedFees.GasPrice,
+	}
+	if suggestedFees.EIP1559Enabled {
+		replacementFees.MaxFeePerGas = suggestedFees.FeeFor(fees.GasFeeHigh)
+		replacementFees.MaxPriorityFeePerGas = suggestedFees.MaxPriorityFeePerGas
+	}
+
+	sendArgs := transactions.BuildReplacementTxArgs(tx, pendingTx.From, replacementType, replacementFees)
+	sendArgs.Symbol = pendingTx.Symbol
+	sendArgs.MultiTransactionID = pendingTx.MultiTransactionID
+
+	hash, err := tm.transactor.SendReplacementTransaction(pendingTx, sendArgs, account)
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	if pendingTx.MultiTransactionID != wallet_common.NoMultiTransactionID {
+		err = tm.replaceMultiTransactionTxHash(pendingTx.MultiTransactionID, pendingTx.Hash, common.Hash(hash))
+		if err != nil {
+			log.Error("failed to update multi transaction with the replacement hash", "err", err) // not critical
+		}
+	}
+
+	return hash, nil
+}
+
+func (tm *TransactionManager) replaceMultiTransactionTxHash(multiTxID wallet_common.MultiTransactionIDType, oldHash common.Hash, newHash common.Hash) error {
+	multiTxs, err := tm.storage.ReadMultiTransactions(&MultiTxDetails{IDs: []wallet_common.MultiTransactionIDType{multiTxID}})
+	if err != nil {
+		return err
+	}
+
+	for _, multiTx := range multiTxs {
+		updated := false
+		if multiTx.FromTxHash == oldHash {
+			multiTx.FromTxHash = newHash
+			updated = true
+		}
+		if multiTx.ToTxHash == oldHash {
+			multiTx.ToTxHash = newHash
+			updated = true
+		}
+		if updated {
+			if err = tm.storage.UpdateMultiTransaction(multiTx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
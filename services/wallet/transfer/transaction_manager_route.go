@@ -5,10 +5,15 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/status-im/status-go/account"
+	"github.com/status-im/status-go/account/signer"
 	"github.com/status-im/status-go/errors"
 	"github.com/status-im/status-go/eth-node/crypto"
 	"github.com/status-im/status-go/eth-node/types"
@@ -16,9 +21,18 @@ import (
 	"github.com/status-im/status-go/services/wallet/responses"
 	"github.com/status-im/status-go/services/wallet/router/pathprocessor"
 	"github.com/status-im/status-go/services/wallet/router/routes"
+	"github.com/status-im/status-go/services/wallet/simulation"
 	"github.com/status-im/status-go/transactions"
 )
 
+// routerSimulationTimeout bounds the simulation of the built transactions
+const routerSimulationTimeout = 30 * time.Second
+
+// RouterSimulator simulates the built transactions, it's implemented by simulation.Simulator
+type RouterSimulator interface {
+	Simulate(ctx context.Context, chainID uint64, msg ethereum.CallMsg) (*simulation.Result, error)
+}
+
 type BuildRouteExtraParams struct {
 	AddressFrom        common.Address
 	AddressTo          common.Address
@@ -28,10 +42,44 @@ type BuildRouteExtraParams struct {
 	SlippagePercentage float32
 }
 
+// LockRouterTransactions waits for the flow using the router transactions to finish and locks them, the returned
+// function unlocks them
+func (tm *TransactionManager) LockRouterTransactions() (unlock func()) {
+	tm.routerTransactionsMu.Lock()
+	return tm.routerTransactionsMu.Unlock
+}
+
+// TryLockUnusedRouterTransactions locks the router transactions for a flow which must neither wait for nor clobber the
+// transactions of another route, it fails if they are locked or built and not sent yet
+func (tm *TransactionManager) TryLockUnusedRouterTransactions() (unlock func(), err error) {
+	if !tm.routerTransactionsMu.TryLock() {
+		return nil, ErrRouterTransactionsInUse
+	}
+	if len(tm.routerTransactions) > 0 {
+		tm.routerTransactionsMu.Unlock()
+		return nil, ErrRouterTransactionsInUse
+	}
+	return tm.routerTransactionsMu.Unlock, nil
+}
+
 func (tm *TransactionManager) ClearLocalRouterTransactionsData() {
 	tm.routerTransactions = nil
 }
 
+// GetBuiltRouterTransactions returns the unsigned txs built from the route, each approval before the tx needing it
+func (tm *TransactionManager) GetBuiltRouterTransactions() []*ethTypes.Transaction {
+	txs := make([]*ethTypes.Transaction, 0, len(tm.routerTransactions))
+	for _, desc := range tm.routerTransactions {
+		if desc.approvalTx != nil {
+			txs = append(txs, desc.approvalTx)
+		}
+		if desc.tx != nil {
+			txs = append(txs, desc.tx)
+		}
+	}
+	return txs
+}
+
 func (tm *TransactionManager) ApprovalRequiredForPath(pathProcessorName string) bool {
 	for _, desc := range tm.routerTransactions {
 		if desc.routerPath.ProcessorName == pathProcessorName &&
@@ -62,6 +110,18 @@ func (tm *TransactionManager) TxPlacedForPath(pathProcessorName string) bool {
 	return false
 }
 
+// SentTxsForPath returns the sent (non approval) txs of the paths handled by the given processor mapped to their paths
+func (tm *TransactionManager) SentTxsForPath(pathProcessorName string) map[types.Hash]*routes.Path {
+	sentTxs := make(map[types.Hash]*routes.Path)
+	for _, desc := range tm.routerTransactions {
+		if desc.routerPath.ProcessorName == pathProcessorName &&
+			desc.txSentHash != (types.Hash{}) {
+			sentTxs[desc.txSentHash] = desc.routerPath
+		}
+	}
+	return sentTxs
+}
+
 func (tm *TransactionManager) buildApprovalTxForPath(path *routes.Path, addressFrom common.Address,
 	usedNonces map[uint64]int64, signer ethTypes.Signer) (types.Hash, error) {
 	lastUsedNonce := int64(-1)
@@ -111,16 +171,22 @@ func (tm *TransactionManager) buildApprovalTxForPath(path *routes.Path, addressF
 	return types.Hash(approvalTxHash), nil
 }
 
-func (tm *TransactionManager) buildTxForPath(path *routes.Path, pathProcessors map[string]pathprocessor.PathProcessor,
+func (tm *TransactionManager) buildTxForPath(ctx context.Context, path *routes.Path, pathProcessors map[string]pathprocessor.PathProcessor,
 	usedNonces map[uint64]int64, signer ethTypes.Signer, params BuildRouteExtraParams) (types.Hash, error) {
 	lastUsedNonce := int64(-1)
 	if nonce, ok := usedNonces[path.FromChain.ChainID]; ok {
 		lastUsedNonce = nonce
 	}
 
+	addressTo := params.AddressTo
+	// a batch transfer path paying a single recipient is a plain transfer to it
+	if len(path.Recipients) == 1 {
+		addressTo = path.Recipients[0].Address
+	}
+
 	processorInputParams := pathprocessor.ProcessorInputParams{
 		FromAddr:  params.AddressFrom,
-		ToAddr:    params.AddressTo,
+		ToAddr:    addressTo,
 		FromChain: path.FromChain,
 		ToChain:   path.ToChain,
 		FromToken: path.FromToken,
@@ -128,9 +194,10 @@ func (tm *TransactionManager) buildTxForPath(path *routes.Path, pathProcessors m
 		AmountIn:  path.AmountIn.ToInt(),
 		AmountOut: path.AmountOut.ToInt(),
 
-		Username:  params.Username,
-		PublicKey: params.PublicKey,
-		PackID:    params.PackID,
+		Username:   params.Username,
+		PublicKey:  params.PublicKey,
+		PackID:     params.PackID,
+		Recipients: path.Recipients,
 	}
 
 	data, err := pathProcessors[path.ProcessorName].PackTxInputData(processorInputParams)
@@ -138,7 +205,7 @@ func (tm *TransactionManager) buildTxForPath(path *routes.Path, pathProcessors m
 		return types.Hash{}, err
 	}
 
-	addrTo := types.Address(params.AddressTo)
+	addrTo := types.Address(addressTo)
 	sendArgs := &transactions.SendTxArgs{
 		Version: transactions.SendTxArgsVersion1,
 
@@ -182,6 +249,23 @@ func (tm *TransactionManager) buildTxForPath(path *routes.Path, pathProcessors m
 		sendArgs.ToTokenID = path.ToToken.Symbol
 	}
 
+	// user operations are sent from the smart account, its nonce isn't the one of a transaction
+	if userOpProcessor, ok := pathProcessors[path.ProcessorName].(pathprocessor.UserOperationProcessor); ok {
+		userOp, hashToSign, err := userOpProcessor.BuildUserOperation(ctx, sendArgs)
+		if err != nil {
+			return types.Hash{}, err
+		}
+
+		tm.routerTransactions = append(tm.routerTransactions, &RouterTransactionDetails{
+			routerPath:      path,
+			txArgs:          sendArgs,
+			txHashToSign:    hashToSign,
+			userOp:          userOp,
+			userOpProcessor: userOpProcessor,
+		})
+		return hashToSign, nil
+	}
+
 	builtTx, usedNonce, err := pathProcessors[path.ProcessorName].BuildTransactionV2(sendArgs, lastUsedNonce)
 	if err != nil {
 		return types.Hash{}, err
@@ -199,7 +283,7 @@ func (tm *TransactionManager) buildTxForPath(path *routes.Path, pathProcessors m
 	return types.Hash(txHash), nil
 }
 
-func (tm *TransactionManager) BuildTransactionsFromRoute(route routes.Route, pathProcessors map[string]pathprocessor.PathProcessor,
+func (tm *TransactionManager) BuildTransactionsFromRoute(ctx context.Context, route routes.Route, pathProcessors map[string]pathprocessor.PathProcessor,
 	params BuildRouteExtraParams) (*responses.SigningDetails, error) {
 	if len(route) == 0 {
 		return nil, ErrNoRoute
@@ -235,13 +319,13 @@ func (tm *TransactionManager) BuildTransactionsFromRoute(route routes.Route, pat
 			response.Hashes = append(response.Hashes, approvalTxHash)
 
 			// if approval is needed for swap, we cannot build the swap tx before the approval tx is mined
-			if path.ProcessorName == pathprocessor.ProcessorSwapParaswapName {
+			if pathprocessor.IsProcessorSwap(path.ProcessorName) {
 				continue
 			}
 		}
 
 		// build tx for the path
-		txHash, err := tm.buildTxForPath(path, pathProcessors, usedNonces, signer, params)
+		txHash, err := tm.buildTxForPath(ctx, path, pathProcessors, usedNonces, signer, params)
 		if err != nil {
 			return nil, err
 		}
@@ -251,6 +335,76 @@ func (tm *TransactionManager) BuildTransactionsFromRoute(route routes.Route, pat
 	return response, nil
 }
 
+// SimulateRouterTransactions predicts the outcome of the built transactions and attaches the results to their paths.
+// The simulation is best effort, transactions which can't be simulated have no result.
+// It isn't cancelled with the caller's context, as the one of an RPC request is cancelled as soon as the method returns,
+// but it's limited by routerSimulationTimeout.
+func (tm *TransactionManager) SimulateRouterTransactions(ctx context.Context, simulator RouterSimulator) map[types.Hash]*simulation.Result {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), routerSimulationTimeout)
+	defer cancel()
+
+	results := make(map[types.Hash]*simulation.Result)
+	for _, desc := range tm.routerTransactions {
+		chainID := desc.routerPath.FromChain.ChainID
+
+		if desc.approvalTx != nil {
+			result, err := simulator.Simulate(ctx, chainID, callMsgFromTx(desc.approvalTxArgs, desc.approvalTx))
+			if err != nil {
+				log.Warn("failed to simulate approval tx", "chainID", chainID, "err", err)
+			} else {
+				desc.routerPath.ApprovalSimulation = result
+				results[desc.approvalHashToSign] = result
+			}
+		}
+
+		if desc.tx != nil {
+			var (
+				result *simulation.Result
+				err    error
+			)
+			if desc.approvalTx != nil {
+				// the tx would revert before the approval is placed, so the changes can only be predicted from the tx data
+				result = simulation.Predict(callMsgFromTx(desc.txArgs, desc.tx))
+			} else {
+				result, err = simulator.Simulate(ctx, chainID, callMsgFromTx(desc.txArgs, desc.tx))
+			}
+			if err != nil {
+				log.Warn("failed to simulate tx", "chainID", chainID, "err", err)
+			} else {
+				desc.routerPath.TxSimulation = result
+				results[desc.txHashToSign] = result
+			}
+		}
+
+		if desc.userOp != nil {
+			msg, err := desc.userOpProcessor.UserOperationCallMsg(chainID, desc.userOp)
+			if err != nil {
+				log.Warn("failed to build user operation call", "chainID", chainID, "err", err)
+				continue
+			}
+			result, err := simulator.Simulate(ctx, chainID, msg)
+			if err != nil {
+				log.Warn("failed to simulate user operation", "chainID", chainID, "err", err)
+			} else {
+				desc.routerPath.TxSimulation = result
+				results[desc.txHashToSign] = result
+			}
+		}
+	}
+
+	return results
+}
+
+func callMsgFromTx(sendArgs *transactions.SendTxArgs, tx *ethTypes.Transaction) ethereum.CallMsg {
+	return ethereum.CallMsg{
+		From:  common.Address(sendArgs.From),
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+}
+
 func getSignatureForTxHash(txHash string, signatures map[string]SignatureDetails) ([]byte, error) {
 	sigDetails, ok := signatures[txHash]
 	if !ok {
@@ -281,6 +435,28 @@ func getSignatureForTxHash(txHash string, signatures map[string]SignatureDetails
 	return signature, nil
 }
 
+// SignRouterTransactions signs the hashes of the built router transactions with the signer of the account, the result can be
+// passed to `ValidateAndAddSignaturesToRouterTransactions`. The external signers don't sign hashes.
+func (tm *TransactionManager) SignRouterTransactions(ctx context.Context, hashes []types.Hash, selectedAccount *account.SelectedExtKey) (map[string]SignatureDetails, error) {
+	if selectedAccount == nil {
+		return nil, fmt.Errorf("account is nil")
+	}
+
+	signatures := make(map[string]SignatureDetails, len(hashes))
+	for _, hash := range hashes {
+		signature, err := signer.SignHash(ctx, selectedAccount.Signer(), common.Address(selectedAccount.Address), common.Hash(hash))
+		if err != nil {
+			return nil, err
+		}
+		signatures[hash.String()] = SignatureDetails{
+			R: hex.EncodeToString(signature[:32]),
+			S: hex.EncodeToString(signature[32:64]),
+			V: hex.EncodeToString(signature[64:]),
+		}
+	}
+	return signatures, nil
+}
+
 func (tm *TransactionManager) ValidateAndAddSignaturesToRouterTransactions(signatures map[string]SignatureDetails) error {
 	if len(tm.routerTransactions) == 0 {
 		return ErrNoTrsansactionsBeingBuilt
@@ -296,7 +472,7 @@ func (tm *TransactionManager) ValidateAndAddSignaturesToRouterTransactions(signa
 			desc.approvalSignature = sig
 		}
 
-		if desc.tx != nil && desc.txSentHash == (types.Hash{}) {
+		if (desc.tx != nil || desc.userOp != nil) && desc.txSentHash == (types.Hash{}) {
 			sig, err := getSignatureForTxHash(desc.txHashToSign.String(), signatures)
 			if err != nil {
 				return err
@@ -328,7 +504,7 @@ func (tm *TransactionManager) SendRouterTransactions(ctx context.Context, multiT
 			transactions = append(transactions, responses.NewRouterSentTransaction(desc.approvalTxArgs, desc.approvalTxSentHash, true))
 
 			// if approval is needed for swap, then we need to wait for the approval tx to be mined before sending the swap tx
-			if desc.routerPath.ProcessorName == pathprocessor.ProcessorSwapParaswapName {
+			if pathprocessor.IsProcessorSwap(desc.routerPath.ProcessorName) {
 				continue
 			}
 		}
@@ -347,6 +523,16 @@ func (tm *TransactionManager) SendRouterTransactions(ctx context.Context, multiT
 
 			transactions = append(transactions, responses.NewRouterSentTransaction(desc.txArgs, desc.txSentHash, false))
 		}
+
+		if desc.userOp != nil && desc.txSentHash == (types.Hash{}) {
+			desc.txArgs.MultiTransactionID = multiTx.ID
+			desc.txSentHash, err = desc.userOpProcessor.SendUserOperationWithSignature(ctx, desc.txArgs, desc.userOp, desc.txSignature)
+			if err != nil {
+				return nil, err
+			}
+
+			transactions = append(transactions, responses.NewRouterSentTransaction(desc.txArgs, desc.txSentHash, false))
+		}
 	}
 
 	return
@@ -2,21 +2,26 @@ package transfer
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
 	"fmt"
 	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/ethereum/go-ethereum"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/status-im/status-go/account"
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/multiaccounts/accounts"
+	"github.com/status-im/status-go/params"
 	wallet_common "github.com/status-im/status-go/services/wallet/common"
+	"github.com/status-im/status-go/services/wallet/router/routes"
+	"github.com/status-im/status-go/services/wallet/simulation"
+	"github.com/status-im/status-go/services/wallet/thirdparty/bundler"
 	"github.com/status-im/status-go/transactions"
 	mock_transactor "github.com/status-im/status-go/transactions/mock"
 
@@ -223,13 +228,14 @@ func TestSignMessage(t *testing.T) {
 	tm, _ := setupTestSuite(t)
 
 	message := (types.HexBytes)(make([]byte, 32))
-	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	privateKey, err := crypto.GenerateKey()
 	require.NoError(t, err)
-	account := &types.Key{
-		PrivateKey: privateKey,
+	selectedAccount := &account.SelectedExtKey{
+		Address:    types.Address(crypto.PubkeyToAddress(privateKey.PublicKey)),
+		AccountKey: &types.Key{PrivateKey: privateKey},
 	}
 
-	signature, err := tm.SignMessage(message, account)
+	signature, err := tm.SignMessage(context.Background(), message, selectedAccount)
 	require.NoError(t, err)
 	require.NotEmpty(t, signature)
 }
@@ -238,11 +244,13 @@ func TestSignMessage_InvalidAccount(t *testing.T) {
 	tm, _ := setupTestSuite(t)
 
 	message := (types.HexBytes)(make([]byte, 32))
-	account := &types.Key{
-		PrivateKey: nil,
+	selectedAccount := &account.SelectedExtKey{
+		AccountKey: &types.Key{
+			PrivateKey: nil,
+		},
 	}
 
-	signature, err := tm.SignMessage(message, account)
+	signature, err := tm.SignMessage(context.Background(), message, selectedAccount)
 	require.Error(t, err)
 	require.Empty(t, signature)
 }
@@ -251,15 +259,16 @@ func TestSignMessage_InvalidMessage(t *testing.T) {
 	tm, _ := setupTestSuite(t)
 
 	message := types.HexBytes{}
-	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	privateKey, err := crypto.GenerateKey()
 	require.NoError(t, err)
-	account := &types.Key{
-		PrivateKey: privateKey,
+	selectedAccount := &account.SelectedExtKey{
+		Address:    types.Address(crypto.PubkeyToAddress(privateKey.PublicKey)),
+		AccountKey: &types.Key{PrivateKey: privateKey},
 	}
 
-	signature, err := tm.SignMessage(message, account)
+	signature, err := tm.SignMessage(context.Background(), message, selectedAccount)
 	require.Error(t, err)
-	require.Equal(t, "0x", signature)
+	require.Empty(t, signature)
 }
 
 func TestBuildTransaction(t *testing.T) {
@@ -373,3 +382,170 @@ func TestBuildRawTransaction(t *testing.T) {
 	require.Equal(t, types.EncodeHex(expectedData), response.RawTx)
 	require.Equal(t, expectedHash, response.TxHash)
 }
+
+// slowSimulator returns its result once released, failing if the simulation context is done by then
+type slowSimulator struct {
+	release chan struct{}
+}
+
+func (s *slowSimulator) Simulate(ctx context.Context, chainID uint64, msg ethereum.CallMsg) (*simulation.Result, error) {
+	<-s.release
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &simulation.Result{GasUsed: 21000}, nil
+}
+
+func TestSimulateRouterTransactions_CallerContextCancelled(t *testing.T) {
+	manager, _ := setupTestSuite(t)
+
+	to := common.HexToAddress("0x2")
+	txHash := types.HexToHash("0x1234")
+	path := &routes.Path{FromChain: &params.Network{ChainID: 1}}
+	manager.routerTransactions = []*RouterTransactionDetails{
+		{
+			routerPath:   path,
+			txArgs:       &transactions.SendTxArgs{From: types.Address{1}},
+			tx:           gethtypes.NewTransaction(0, to, big.NewInt(1), 21000, big.NewInt(1), nil),
+			txHashToSign: txHash,
+		},
+	}
+
+	// the context of the RPC request is cancelled once the method returns, before the simulation finishes
+	ctx, cancel := context.WithCancel(context.Background())
+	simulator := &slowSimulator{release: make(chan struct{})}
+	go func() {
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+		close(simulator.release)
+	}()
+
+	results := manager.SimulateRouterTransactions(ctx, simulator)
+	require.Len(t, results, 1)
+	require.Equal(t, uint64(21000), results[txHash].GasUsed)
+	require.Equal(t, results[txHash], path.TxSimulation)
+}
+
+func TestTryLockUnusedRouterTransactions(t *testing.T) {
+	manager, _ := setupTestSuite(t)
+
+	// locked by the send flow of the user
+	unlock := manager.LockRouterTransactions()
+	_, err := manager.TryLockUnusedRouterTransactions()
+	require.ErrorIs(t, err, ErrRouterTransactionsInUse)
+	unlock()
+
+	// built, waiting for the signatures of the user
+	manager.routerTransactions = []*RouterTransactionDetails{{txHashToSign: types.HexToHash("0x1234")}}
+	_, err = manager.TryLockUnusedRouterTransactions()
+	require.ErrorIs(t, err, ErrRouterTransactionsInUse)
+	require.Len(t, manager.routerTransactions, 1)
+
+	manager.ClearLocalRouterTransactionsData()
+	unlock, err = manager.TryLockUnusedRouterTransactions()
+	require.NoError(t, err)
+	require.False(t, manager.routerTransactionsMu.TryLock())
+	unlock()
+	require.True(t, manager.routerTransactionsMu.TryLock())
+	manager.routerTransactionsMu.Unlock()
+}
+
+type fakeUserOperationProcessor struct {
+	signatures [][]byte
+}
+
+func (p *fakeUserOperationProcessor) BuildUserOperation(ctx context.Context, sendArgs *transactions.SendTxArgs) (*bundler.UserOperation, types.Hash, error) {
+	return &bundler.UserOperation{Sender: common.Address(sendArgs.From), CallData: []byte{1}}, types.HexToHash("0x5"), nil
+}
+
+func (p *fakeUserOperationProcessor) UserOperationCallMsg(chainID uint64, userOp *bundler.UserOperation) (ethereum.CallMsg, error) {
+	return ethereum.CallMsg{From: common.HexToAddress("0x7"), To: &userOp.Sender, Data: userOp.CallData}, nil
+}
+
+func (p *fakeUserOperationProcessor) SendUserOperationWithSignature(ctx context.Context, sendArgs *transactions.SendTxArgs, userOp *bundler.UserOperation, signature []byte) (types.Hash, error) {
+	p.signatures = append(p.signatures, signature)
+	return types.HexToHash("0x6"), nil
+}
+
+func TestSendRouterTransactions_UserOperation(t *testing.T) {
+	manager, _ := setupTestSuite(t)
+
+	processor := &fakeUserOperationProcessor{}
+	txArgs := &transactions.SendTxArgs{From: types.Address{1}, Value: (*hexutil.Big)(big.NewInt(1)), FromChainID: 1}
+	userOp, hashToSign, err := processor.BuildUserOperation(context.Background(), txArgs)
+	require.NoError(t, err)
+
+	manager.routerTransactions = []*RouterTransactionDetails{
+		{
+			routerPath:      &routes.Path{FromChain: &params.Network{ChainID: 1}},
+			txArgs:          txArgs,
+			txHashToSign:    hashToSign,
+			userOp:          userOp,
+			userOpProcessor: processor,
+		},
+	}
+
+	// the user operation hash is signed like the hash of a transaction
+	err = manager.ValidateAndAddSignaturesToRouterTransactions(map[string]SignatureDetails{})
+	require.Error(t, err)
+
+	signature := SignatureDetails{
+		R: "1111111111111111111111111111111111111111111111111111111111111111",
+		S: "2222222222222222222222222222222222222222222222222222222222222222",
+		V: "01",
+	}
+	err = manager.ValidateAndAddSignaturesToRouterTransactions(map[string]SignatureDetails{hashToSign.String(): signature})
+	require.NoError(t, err)
+
+	sent, err := manager.SendRouterTransactions(context.Background(), &MultiTransaction{ID: 7})
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	require.Equal(t, types.HexToHash("0x6"), sent[0].Hash)
+	require.Equal(t, wallet_common.MultiTransactionIDType(7), txArgs.MultiTransactionID)
+	require.Len(t, processor.signatures, 1)
+	require.Equal(t, byte(1), processor.signatures[0][64])
+	require.True(t, manager.TxPlacedForPath(""))
+
+	// the user operation is sent only once
+	sent, err = manager.SendRouterTransactions(context.Background(), &MultiTransaction{ID: 7})
+	require.NoError(t, err)
+	require.Empty(t, sent)
+}
+
+// recordingSimulator records the simulated calls
+type recordingSimulator struct {
+	calls []ethereum.CallMsg
+}
+
+func (s *recordingSimulator) Simulate(ctx context.Context, chainID uint64, msg ethereum.CallMsg) (*simulation.Result, error) {
+	s.calls = append(s.calls, msg)
+	return &simulation.Result{GasUsed: 50000}, nil
+}
+
+func TestSimulateRouterTransactions_UserOperation(t *testing.T) {
+	manager, _ := setupTestSuite(t)
+
+	processor := &fakeUserOperationProcessor{}
+	txArgs := &transactions.SendTxArgs{From: types.Address{1}, FromChainID: 1}
+	userOp, hashToSign, err := processor.BuildUserOperation(context.Background(), txArgs)
+	require.NoError(t, err)
+
+	path := &routes.Path{FromChain: &params.Network{ChainID: 1}}
+	manager.routerTransactions = []*RouterTransactionDetails{
+		{
+			routerPath:      path,
+			txArgs:          txArgs,
+			txHashToSign:    hashToSign,
+			userOp:          userOp,
+			userOpProcessor: processor,
+		},
+	}
+
+	simulator := &recordingSimulator{}
+	results := manager.SimulateRouterTransactions(context.Background(), simulator)
+	require.Len(t, simulator.calls, 1)
+	require.Equal(t, userOp.Sender, *simulator.calls[0].To)
+	require.Equal(t, userOp.CallData, simulator.calls[0].Data)
+	require.Equal(t, uint64(50000), results[hashToSign].GasUsed)
+	require.Equal(t, results[hashToSign], path.TxSimulation)
+}
@@ -1,6 +1,7 @@
 package walletconnect
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"database/sql"
 	"encoding/json"
@@ -11,10 +12,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	signercore "github.com/ethereum/go-ethereum/signer/core/apitypes"
 
+	"github.com/status-im/status-go/account/signer"
 	"github.com/status-im/status-go/eth-node/types"
 	"github.com/status-im/status-go/multiaccounts/accounts"
 	"github.com/status-im/status-go/params"
@@ -255,6 +259,11 @@ func caip10Accounts(accounts []*accounts.Account, chains []uint64) []string {
 }
 
 func SafeSignTypedDataForDApps(typedJson string, privateKey *ecdsa.PrivateKey, chainID uint64, legacy bool) (types.HexBytes, error) {
+	return SafeSignTypedDataForDAppsWithSigner(context.Background(), typedJson, signer.NewKeySigner(privateKey), crypto.PubkeyToAddress(privateKey.PublicKey), chainID, legacy)
+}
+
+// SafeSignTypedDataForDAppsWithSigner is SafeSignTypedDataForDApps signing with the signer of the account
+func SafeSignTypedDataForDAppsWithSigner(ctx context.Context, typedJson string, s signer.Signer, address common.Address, chainID uint64, legacy bool) (types.HexBytes, error) {
 	// Parse the data for both legacy and non-legacy cases to validate the chain
 	var typed typeddata.TypedData
 	err := json.Unmarshal([]byte(typedJson), &typed)
@@ -266,7 +275,7 @@ func SafeSignTypedDataForDApps(typedJson string, privateKey *ecdsa.PrivateKey, c
 
 	var sig hexutil.Bytes
 	if legacy {
-		sig, err = typeddata.Sign(typed, privateKey, chain)
+		sig, err = typeddata.SignWithSigner(ctx, typed, s, address, chain)
 	} else {
 		// Validate chainID if part of the typed data
 		if _, exist := typed.Domain[typeddata.ChainIDKey]; exist {
@@ -281,7 +290,7 @@ func SafeSignTypedDataForDApps(typedJson string, privateKey *ecdsa.PrivateKey, c
 			return types.HexBytes{}, err
 		}
 
-		sig, err = typeddata.SignTypedDataV4(typedV4, privateKey, chain)
+		sig, err = s.SignTypedData(ctx, address, typedV4)
 	}
 	if err != nil {
 		return types.HexBytes{}, err
@@ -246,6 +246,10 @@ func (api *API) getVerifiedWalletAccount(address, password string) (*account.Sel
 		return nil, transactions.ErrAccountDoesntExist
 	}
 
+	if selectedAccount, ok := api.s.accountsManager.ExternalSignerAccount(address); ok {
+		return selectedAccount, nil
+	}
+
 	key, err := api.s.accountsManager.VerifyAccountPassword(api.s.config.KeyStoreDir, address, password)
 	if err != nil {
 		log.Error("failed to verify account", "account", address, "error", err)
@@ -1,6 +1,7 @@
 package web3provider
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
@@ -32,15 +33,11 @@ func (api *API) signMessage(data interface{}, address string, password string) (
 		dBytes = []byte{d}
 	}
 
-	hash := crypto.TextHash(dBytes)
-
-	sig, err := crypto.Sign(hash, account.AccountKey.PrivateKey)
+	sig, err := account.Signer().SignPersonalMessage(context.Background(), common.Address(account.Address), dBytes)
 	if err != nil {
 		return types.HexBytes{}, err
 	}
 
-	sig[64] += 27 // Transform V from 0/1 to 27/28 according to the yellow paper
-
 	return types.HexBytes(sig), err
 }
 
@@ -51,7 +48,7 @@ func (api *API) signTypedData(typed typeddata.TypedData, address string, passwor
 		return types.HexBytes{}, err
 	}
 	chain := new(big.Int).SetUint64(api.s.config.NetworkID)
-	sig, err := typeddata.Sign(typed, account.AccountKey.PrivateKey, chain)
+	sig, err := typeddata.SignWithSigner(context.Background(), typed, account.Signer(), common.Address(account.Address), chain)
 	if err != nil {
 		return types.HexBytes{}, err
 	}
@@ -64,8 +61,7 @@ func (api *API) signTypedDataV4(typed signercore.TypedData, address string, pass
 	if err != nil {
 		return types.HexBytes{}, err
 	}
-	chain := new(big.Int).SetUint64(api.s.config.NetworkID)
-	sig, err := typeddata.SignTypedDataV4(typed, account.AccountKey.PrivateKey, chain)
+	sig, err := account.Signer().SignTypedData(context.Background(), common.Address(account.Address), typed)
 	if err != nil {
 		return types.HexBytes{}, err
 	}
@@ -4,6 +4,9 @@ const (
 	EventConnectorSendRequestAccounts   = "connector.sendRequestAccounts"
 	EventConnectorSendTransaction       = "connector.sendTransaction"
 	EventConnectorPersonalSign          = "connector.personalSign"
+	EventConnectorSignTypedData         = "connector.signTypedData"
+	EventConnectorAddEthereumChain      = "connector.addEthereumChain"
+	EventConnectorWatchAsset            = "connector.watchAsset"
 	EventConnectorDAppPermissionGranted = "connector.dAppPermissionGranted"
 	EventConnectorDAppPermissionRevoked = "connector.dAppPermissionRevoked"
 	EventConnectorDAppChainIdSwitched   = "connector.dAppChainIdSwitched"
@@ -27,6 +30,8 @@ type ConnectorSendTransactionSignal struct {
 	RequestID string `json:"requestId"`
 	ChainID   uint64 `json:"chainId"`
 	TxArgs    string `json:"txArgs"`
+	// predicted outcome of the transaction, empty if it couldn't be simulated
+	TxSimulation string `json:"txSimulation,omitempty"`
 }
 
 type ConnectorPersonalSignSignal struct {
@@ -34,6 +39,38 @@ type ConnectorPersonalSignSignal struct {
 	RequestID string `json:"requestId"`
 	Challenge string `json:"challenge"`
 	Address   string `json:"address"`
+	// breakdown and warnings of an EIP-4361 sign-in message, empty if the challenge is not a sign-in message
+	SIWE string `json:"siwe,omitempty"`
+}
+
+// ConnectorSignTypedDataSignal is triggered when EIP-712 typed data is requested to be signed, the client signs it
+// with `wallet_safeSignTypedDataForDApps`
+type ConnectorSignTypedDataSignal struct {
+	ConnectorDApp
+	RequestID string `json:"requestId"`
+	ChainID   uint64 `json:"chainId"`
+	Address   string `json:"address"`
+	TypedData string `json:"typedData"`
+}
+
+// ConnectorAddEthereumChainSignal is triggered when a dApp asks to add a chain, the chain is known to the wallet
+// and accepting the request switches the dApp to it
+type ConnectorAddEthereumChainSignal struct {
+	ConnectorDApp
+	RequestID string `json:"requestId"`
+	ChainID   uint64 `json:"chainId"`
+	ChainName string `json:"chainName"`
+}
+
+// ConnectorWatchAssetSignal is triggered when a dApp suggests a token to be added to the wallet
+type ConnectorWatchAssetSignal struct {
+	ConnectorDApp
+	RequestID string `json:"requestId"`
+	ChainID   uint64 `json:"chainId"`
+	Address   string `json:"address"`
+	Symbol    string `json:"symbol"`
+	Decimals  uint   `json:"decimals"`
+	Image     string `json:"image,omitempty"`
 }
 
 type ConnectorDAppChainIdSwitchedSignal struct {
@@ -48,24 +85,49 @@ func SendConnectorSendRequestAccounts(dApp ConnectorDApp, requestID string) {
 	})
 }
 
-func SendConnectorSendTransaction(dApp ConnectorDApp, chainID uint64, txArgs string, requestID string) {
+func SendConnectorSendTransaction(dApp ConnectorDApp, chainID uint64, txArgs string, txSimulation string, requestID string) {
 	send(EventConnectorSendTransaction, ConnectorSendTransactionSignal{
 		ConnectorDApp: dApp,
 		RequestID:     requestID,
 		ChainID:       chainID,
 		TxArgs:        txArgs,
+		TxSimulation:  txSimulation,
 	})
 }
 
-func SendConnectorPersonalSign(dApp ConnectorDApp, requestID, challenge, address string) {
+func SendConnectorPersonalSign(dApp ConnectorDApp, requestID, challenge, address, siwe string) {
 	send(EventConnectorPersonalSign, ConnectorPersonalSignSignal{
 		ConnectorDApp: dApp,
 		RequestID:     requestID,
 		Challenge:     challenge,
 		Address:       address,
+		SIWE:          siwe,
+	})
+}
+
+func SendConnectorSignTypedData(dApp ConnectorDApp, requestID string, chainID uint64, address string, typedData string) {
+	send(EventConnectorSignTypedData, ConnectorSignTypedDataSignal{
+		ConnectorDApp: dApp,
+		RequestID:     requestID,
+		ChainID:       chainID,
+		Address:       address,
+		TypedData:     typedData,
 	})
 }
 
+func SendConnectorAddEthereumChain(dApp ConnectorDApp, requestID string, chainID uint64, chainName string) {
+	send(EventConnectorAddEthereumChain, ConnectorAddEthereumChainSignal{
+		ConnectorDApp: dApp,
+		RequestID:     requestID,
+		ChainID:       chainID,
+		ChainName:     chainName,
+	})
+}
+
+func SendConnectorWatchAsset(payload ConnectorWatchAssetSignal) {
+	send(EventConnectorWatchAsset, payload)
+}
+
 func SendConnectorDAppPermissionGranted(dApp ConnectorDApp) {
 	send(EventConnectorDAppPermissionGranted, dApp)
 }
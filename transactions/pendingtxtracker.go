@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	eth "github.com/ethereum/go-ethereum/common"
@@ -47,6 +48,8 @@ const (
 	Pending TxStatus = "Pending"
 	Success TxStatus = "Success"
 	Failed  TxStatus = "Failed"
+	// Replaced transactions are still tracked while their replacement is pending, either of them can be mined
+	Replaced TxStatus = "Replaced"
 )
 
 type AutoDeleteType = bool
@@ -71,11 +74,20 @@ type StatusChangedPayload struct {
 	Status TxStatus `json:"status"`
 }
 
+// UserOperationStatusFetcher resolves the status of ERC-4337 user operations. Their hashes are not known to the
+// chain nodes, so they have to be resolved through a bundler. Only the completed operations are returned.
+type UserOperationStatusFetcher interface {
+	FetchUserOperationsStatus(ctx context.Context, chainID common.ChainID, hashes []eth.Hash) (map[eth.Hash]TxStatus, error)
+}
+
 // PendingTxTracker implements StatusService in common/status_node_service.go
 type PendingTxTracker struct {
 	db        *sql.DB
 	rpcClient rpc.ClientInterface
 
+	userOpFetcherMutex sync.RWMutex
+	userOpFetcher      UserOperationStatusFetcher
+
 	rpcFilter *rpcfilters.Service
 	eventFeed *event.Feed
 
@@ -97,6 +109,19 @@ func NewPendingTxTracker(db *sql.DB, rpcClient rpc.ClientInterface, rpcFilter *r
 	return tm
 }
 
+// SetUserOperationStatusFetcher sets the fetcher used for pending entries of type WalletUserOperation
+func (tm *PendingTxTracker) SetUserOperationStatusFetcher(fetcher UserOperationStatusFetcher) {
+	tm.userOpFetcherMutex.Lock()
+	defer tm.userOpFetcherMutex.Unlock()
+	tm.userOpFetcher = fetcher
+}
+
+func (tm *PendingTxTracker) getUserOperationStatusFetcher() UserOperationStatusFetcher {
+	tm.userOpFetcherMutex.RLock()
+	defer tm.userOpFetcherMutex.RUnlock()
+	return tm.userOpFetcher
+}
+
 type txStatusRes struct {
 	Status TxStatus
 	hash   eth.Hash
@@ -113,8 +138,13 @@ func (tm *PendingTxTracker) fetchAndUpdateDB(ctx context.Context) bool {
 	tm.log.Debug("Checking for PT status", "count", len(txs))
 
 	txsMap := make(map[common.ChainID][]eth.Hash)
+	userOpsMap := make(map[common.ChainID][]eth.Hash)
 	for _, tx := range txs {
 		chainID := tx.ChainID
+		if tx.Type == WalletUserOperation {
+			userOpsMap[chainID] = append(userOpsMap[chainID], tx.Hash)
+			continue
+		}
 		txsMap[chainID] = append(txsMap[chainID], tx.Hash)
 	}
 
@@ -123,25 +153,18 @@ func (tm *PendingTxTracker) fetchAndUpdateDB(ctx context.Context) bool {
 	for chainID, txs := range txsMap {
 		tm.log.Debug("Processing PTs", "chainID", chainID, "count", len(txs))
 		batchRes, err := fetchBatchTxStatus(ctx, tm.rpcClient, chainID, txs, tm.log)
-		if err != nil {
-			tm.log.Error("Failed to batch fetch pending transactions status for", "chainID", chainID, "error", err)
-			continue
-		}
-		if len(batchRes) == 0 {
-			tm.log.Debug("No change to PTs status", "chainID", chainID)
-			continue
-		}
-		tm.log.Debug("PTs done", "chainID", chainID, "count", len(batchRes))
-		doneCount += len(batchRes)
+		doneCount += tm.processBatchTxStatus(ctx, chainID, batchRes, err)
+	}
 
-		updateRes, err := tm.updateDBStatus(ctx, chainID, batchRes)
-		if err != nil {
-			tm.log.Error("Failed to update pending transactions status for", "chainID", chainID, "error", err)
+	userOpFetcher := tm.getUserOperationStatusFetcher()
+	for chainID, hashes := range userOpsMap {
+		if userOpFetcher == nil {
+			tm.log.Warn("No user operation status fetcher set", "chainID", chainID, "count", len(hashes))
 			continue
 		}
-
-		tm.log.Debug("Emit notifications for PTs", "chainID", chainID, "count", len(updateRes))
-		tm.emitNotifications(chainID, updateRes)
+		tm.log.Debug("Processing user operation PTs", "chainID", chainID, "count", len(hashes))
+		batchRes, err := fetchUserOperationsStatus(ctx, userOpFetcher, chainID, hashes)
+		doneCount += tm.processBatchTxStatus(ctx, chainID, batchRes, err)
 	}
 
 	if len(txs) == doneCount {
@@ -153,6 +176,48 @@ func (tm *PendingTxTracker) fetchAndUpdateDB(ctx context.Context) bool {
 	return res
 }
 
+// processBatchTxStatus stores the completed transactions status and returns their count
+func (tm *PendingTxTracker) processBatchTxStatus(ctx context.Context, chainID common.ChainID, batchRes []txStatusRes, err error) int {
+	if err != nil {
+		tm.log.Error("Failed to batch fetch pending transactions status for", "chainID", chainID, "error", err)
+		return 0
+	}
+	if len(batchRes) == 0 {
+		tm.log.Debug("No change to PTs status", "chainID", chainID)
+		return 0
+	}
+	tm.log.Debug("PTs done", "chainID", chainID, "count", len(batchRes))
+
+	updateRes, err := tm.updateDBStatus(ctx, chainID, batchRes)
+	if err != nil {
+		tm.log.Error("Failed to update pending transactions status for", "chainID", chainID, "error", err)
+		return len(batchRes)
+	}
+
+	tm.log.Debug("Emit notifications for PTs", "chainID", chainID, "count", len(updateRes))
+	tm.emitNotifications(chainID, updateRes)
+
+	return len(batchRes)
+}
+
+func fetchUserOperationsStatus(ctx context.Context, fetcher UserOperationStatusFetcher, chainID common.ChainID, hashes []eth.Hash) ([]txStatusRes, error) {
+	statuses, err := fetcher.FetchUserOperationsStatus(ctx, chainID, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]txStatusRes, 0, len(statuses))
+	for _, hash := range hashes {
+		if status, ok := statuses[hash]; ok && status != Pending {
+			res = append(res, txStatusRes{
+				hash:   hash,
+				Status: status,
+			})
+		}
+	}
+	return res, nil
+}
+
 type nullableReceipt struct {
 	*types.Receipt
 }
@@ -274,6 +339,13 @@ func (tm *PendingTxTracker) updateDBStatus(ctx context.Context, chainID common.C
 			continue
 		}
 
+		replacementNotifyFns, err := tm.dropUnminableReplacementsBySQLTx(tx, chainID, br.hash)
+		if err != nil {
+			tm.log.Error("Failed to drop the replacements of a mined transaction", "error", err, "hash", br.hash)
+			continue
+		}
+		notifyFunctions = append(notifyFunctions, replacementNotifyFns...)
+
 		if autoDel {
 			notifyFn, err := tm.DeleteBySQLTx(tx, chainID, br.hash)
 			if err != nil && err != ErrStillPending {
@@ -396,6 +468,8 @@ const (
 	RegisterENS               PendingTrxType = "RegisterENS"
 	ReleaseENS                PendingTrxType = "ReleaseENS"
 	SetPubKey                 PendingTrxType = "SetPubKey"
+	SetENSRecords             PendingTrxType = "SetENSRecords"
+	SetENSSubname             PendingTrxType = "SetENSSubname"
 	BuyStickerPack            PendingTrxType = "BuyStickerPack"
 	WalletTransfer            PendingTrxType = "WalletTransfer"
 	DeployCommunityToken      PendingTrxType = "DeployCommunityToken"
@@ -405,6 +479,8 @@ const (
 	DeployOwnerToken          PendingTrxType = "DeployOwnerToken"
 	SetSignerPublicKey        PendingTrxType = "SetSignerPublicKey"
 	WalletConnectTransfer     PendingTrxType = "WalletConnectTransfer"
+	// WalletUserOperation entries are ERC-4337 user operations, their hash is the user operation hash
+	WalletUserOperation PendingTrxType = "WalletUserOperation"
 )
 
 type PendingTransaction struct {
@@ -427,11 +503,16 @@ type PendingTransaction struct {
 	Status *TxStatus `json:"status,omitempty"`
 	// nil will insert the default value (true) in DB
 	AutoDelete *bool `json:"autoDelete,omitempty"`
+	// ReplacedHash is set if this transaction replaced (sped up or cancelled) another one with the same nonce
+	ReplacedHash *eth.Hash `json:"replacedHash,omitempty"`
+	// ReplacedByHash is set if this transaction was replaced by another one with the same nonce
+	ReplacedByHash *eth.Hash `json:"replacedByHash,omitempty"`
 }
 
 const selectFromPending = `SELECT hash, timestamp, value, from_address, to_address, data,
 								symbol, gas_price, gas_limit, type, additional_data,
-								network_id, COALESCE(multi_transaction_id, 0), status, auto_delete, nonce
+								network_id, COALESCE(multi_transaction_id, 0), status, auto_delete, nonce,
+								replaced_hash, replaced_by_hash
 							FROM pending_transactions
 							`
 
@@ -461,6 +542,8 @@ func rowsToTransactions(rows *sql.Rows) (transactions []*PendingTransaction, err
 			transaction.Status,
 			transaction.AutoDelete,
 			&transaction.Nonce,
+			&transaction.ReplacedHash,
+			&transaction.ReplacedByHash,
 		)
 		if err != nil {
 			return nil, err
@@ -475,7 +558,11 @@ func (tm *PendingTxTracker) GetAllPending() ([]*PendingTransaction, error) {
 	if tm.db == nil {
 		return nil, errors.New("database is not initialized")
 	}
-	rows, err := tm.db.Query(selectFromPending+"WHERE status = ?", Pending)
+	// replaced transactions are tracked as long as their replacement is pending
+	rows, err := tm.db.Query(selectFromPending+`WHERE status = ? OR (status = ? AND replaced_by_hash IN (
+		SELECT hash FROM pending_transactions AS replacements
+		WHERE replacements.network_id = pending_transactions.network_id AND replacements.status = ?))`,
+		Pending, Replaced, Pending)
 	if err != nil {
 		return nil, err
 	}
@@ -584,10 +671,13 @@ func (tm *PendingTxTracker) addPending(transaction *PendingTransaction) error {
 			from_address = ?
 		AND
 			nonce = ?
+		AND
+			status != ?
 		`,
 		transaction.ChainID,
 		transaction.From,
-		transaction.Nonce).
+		transaction.Nonce,
+		Replaced).
 		Scan(&hash)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -597,7 +687,14 @@ func (tm *PendingTxTracker) addPending(transaction *PendingTransaction) error {
 		}
 	}
 
-	if exists {
+	if exists && transaction.ReplacedHash != nil && *transaction.ReplacedHash == hash {
+		// keep the replaced transaction, it may still be the one that gets mined
+		_, err = tx.Exec(`UPDATE pending_transactions SET status = ?, replaced_by_hash = ? WHERE network_id = ? AND hash = ?`,
+			Replaced, transaction.Hash, transaction.ChainID, hash)
+		if err != nil {
+			return err
+		}
+	} else if exists {
 		notifyFn, err = tm.DeleteBySQLTx(tx, transaction.ChainID, hash)
 		if err != nil && err != ErrStillPending {
 			return err
@@ -609,9 +706,9 @@ func (tm *PendingTxTracker) addPending(transaction *PendingTransaction) error {
 	insert, err = tx.Prepare(`INSERT OR REPLACE INTO pending_transactions
                                       (network_id, hash, timestamp, value, from_address, to_address,
                                        data, symbol, gas_price, gas_limit, type, additional_data, multi_transaction_id, status,
-																			 auto_delete, nonce)
+																			 auto_delete, nonce, replaced_hash)
                                       VALUES
-                                      (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? , ?, ?)`)
+                                      (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? , ?, ?, ?)`)
 	if err != nil {
 		return err
 	}
@@ -634,6 +731,7 @@ func (tm *PendingTxTracker) addPending(transaction *PendingTransaction) error {
 		transaction.Status,
 		transaction.AutoDelete,
 		transaction.Nonce,
+		transaction.ReplacedHash,
 	)
 	// Notify listeners of new pending transaction (used in activity history)
 	if err == nil {
@@ -674,6 +772,46 @@ func (tm *PendingTxTracker) notifyPendingTransactionListeners(payload PendingTxU
 	}
 }
 
+// dropUnminableReplacementsBySQLTx deletes the auto delete entries sharing the nonce of the mined transaction `hash`,
+// either the ones it replaced or the one that replaced it
+func (tm *PendingTxTracker) dropUnminableReplacementsBySQLTx(tx *sql.Tx, chainID common.ChainID, hash eth.Hash) ([]func(), error) {
+	rows, err := tx.Query(`
+		SELECT hash FROM pending_transactions
+		WHERE network_id = ? AND auto_delete = 1 AND (
+			replaced_by_hash = ?
+		OR
+			hash = (SELECT replaced_by_hash FROM pending_transactions WHERE network_id = ? AND hash = ?)
+		)`, chainID, hash, chainID, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashes []eth.Hash
+	for rows.Next() {
+		var h eth.Hash
+		err = rows.Scan(&h)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		hashes = append(hashes, h)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	notifyFns := make([]func(), 0, len(hashes))
+	for _, h := range hashes {
+		notifyFn, err := tm.DeleteBySQLTx(tx, chainID, h)
+		if err != nil && err != ErrStillPending {
+			return nil, err
+		}
+		notifyFns = append(notifyFns, notifyFn)
+	}
+	return notifyFns, nil
+}
+
 // DeleteBySQLTx returns ErrStillPending if the transaction is still pending
 func (tm *PendingTxTracker) DeleteBySQLTx(tx *sql.Tx, chainID common.ChainID, hash eth.Hash) (notify func(), err error) {
 	row := tx.QueryRow(`SELECT from_address, to_address, timestamp, status FROM pending_transactions WHERE network_id = ? AND hash = ?`, chainID, hash)
@@ -591,3 +591,95 @@ func TestPendingTransactions(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 0, len(rst))
 }
+
+func TestPendingTransactions_Replacement(t *testing.T) {
+	manager, stop, _, _ := setupTestTransactionDB(t, nil)
+	defer stop()
+
+	txs := GenerateTestPendingTransactions(0, 2)
+	replacedTx := txs[0]
+	err := manager.addPending(&replacedTx)
+	require.NoError(t, err)
+
+	replacementTx := txs[1]
+	replacementTx.From = replacedTx.From
+	replacementTx.Nonce = replacedTx.Nonce
+	replacementTx.ReplacedHash = &replacedTx.Hash
+	err = manager.addPending(&replacementTx)
+	require.NoError(t, err)
+
+	// The replaced transaction is kept, linked to its replacement, as it can still be the one that gets mined
+	rst, err := manager.GetPendingByAddress([]uint64{777}, replacedTx.From)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(rst))
+
+	replacement, err := manager.GetPendingEntry(common.ChainID(777), replacementTx.Hash)
+	require.NoError(t, err)
+	require.Equal(t, Pending, *replacement.Status)
+	require.NotNil(t, replacement.ReplacedHash)
+	require.Equal(t, replacedTx.Hash, *replacement.ReplacedHash)
+
+	replaced, err := manager.GetPendingEntry(common.ChainID(777), replacedTx.Hash)
+	require.NoError(t, err)
+	require.Equal(t, Replaced, *replaced.Status)
+	require.NotNil(t, replaced.ReplacedByHash)
+	require.Equal(t, replacementTx.Hash, *replaced.ReplacedByHash)
+
+	// Both are tracked until one of them is mined
+	all, err := manager.GetAllPending()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(all))
+
+	// Once the original is mined the replacement can't be mined anymore
+	_, err = manager.updateDBStatus(context.Background(), common.ChainID(777), []txStatusRes{{hash: replacedTx.Hash, Status: Success}})
+	require.NoError(t, err)
+
+	_, err = manager.GetPendingEntry(common.ChainID(777), replacementTx.Hash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = manager.GetPendingEntry(common.ChainID(777), replacedTx.Hash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+type fakeUserOperationStatusFetcher struct {
+	statuses map[eth.Hash]TxStatus
+	calls    int
+}
+
+func (f *fakeUserOperationStatusFetcher) FetchUserOperationsStatus(ctx context.Context, chainID common.ChainID, hashes []eth.Hash) (map[eth.Hash]TxStatus, error) {
+	f.calls++
+	return f.statuses, nil
+}
+
+func TestPendingTxTracker_UserOperation(t *testing.T) {
+	m, stop, _, _ := setupTestTransactionDB(t, nil)
+	defer stop()
+
+	txs := GenerateTestPendingTransactions(0, 2)
+	for i := range txs {
+		txs[i].Type = WalletUserOperation
+		*txs[i].AutoDelete = false
+		err := m.addPending(&txs[i])
+		require.NoError(t, err)
+	}
+
+	// Without a fetcher the user operations stay pending and the chain is not queried for their hashes
+	require.Equal(t, WorkNotDone, m.fetchAndUpdateDB(context.Background()))
+
+	fetcher := &fakeUserOperationStatusFetcher{
+		statuses: map[eth.Hash]TxStatus{
+			txs[0].Hash: Success,
+		},
+	}
+	m.SetUserOperationStatusFetcher(fetcher)
+
+	require.Equal(t, WorkNotDone, m.fetchAndUpdateDB(context.Background()))
+	require.Equal(t, 1, fetcher.calls)
+
+	entry, err := m.GetPendingEntry(txs[0].ChainID, txs[0].Hash)
+	require.NoError(t, err)
+	require.Equal(t, Success, *entry.Status)
+
+	entry, err = m.GetPendingEntry(txs[1].ChainID, txs[1].Hash)
+	require.NoError(t, err)
+	require.Equal(t, Pending, *entry.Status)
+}
@@ -0,0 +1,94 @@
+package transactions
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/status-im/status-go/eth-node/types"
+)
+
+// replacementFeeBumpPercent is the minimal fee increase the nodes' transaction pool requires
+// to accept a transaction replacing another one with the same nonce.
+const replacementFeeBumpPercent = 10
+
+// ErrTransactionNotPending is returned when trying to replace a transaction which is already mined or dropped
+var ErrTransactionNotPending = errors.New("transaction is not pending anymore")
+
+type ReplacementType int
+
+const (
+	// ReplacementSpeedUp resends the same transaction with higher fees
+	ReplacementSpeedUp ReplacementType = iota
+	// ReplacementCancel sends a 0 value transaction to self with higher fees, so the original one is dropped
+	ReplacementCancel
+)
+
+// ReplacementFees are the currently suggested fees for the chain, the replacement transaction fees are never lower than these.
+// If MaxFeePerGas and MaxPriorityFeePerGas are nil a legacy transaction is built using GasPrice.
+type ReplacementFees struct {
+	GasPrice             *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+}
+
+func (f *ReplacementFees) isDynamicFee() bool {
+	return f.MaxFeePerGas != nil && f.MaxPriorityFeePerGas != nil
+}
+
+// bumpFee returns the current fee increased by replacementFeeBumpPercent (rounded up), or the suggested one if higher
+func bumpFee(current *big.Int, suggested *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(current, big.NewInt(100+replacementFeeBumpPercent))
+	bumped.Add(bumped, big.NewInt(99))
+	bumped.Div(bumped, big.NewInt(100))
+	if suggested != nil && suggested.Cmp(bumped) > 0 {
+		return new(big.Int).Set(suggested)
+	}
+	return bumped
+}
+
+// BuildReplacementTxArgs builds the arguments for a transaction using the same nonce as the pending transaction `tx`.
+// Fees are bumped so that nodes accept the replacement.
+func BuildReplacementTxArgs(tx *gethtypes.Transaction, from common.Address, replacementType ReplacementType, fees *ReplacementFees) SendTxArgs {
+	nonce := hexutil.Uint64(tx.Nonce())
+	args := SendTxArgs{
+		From:  types.Address(from),
+		Nonce: &nonce,
+	}
+
+	if replacementType == ReplacementCancel {
+		to := types.Address(from)
+		gas := hexutil.Uint64(params.TxGas)
+		args.To = &to
+		args.Gas = &gas
+		args.Value = (*hexutil.Big)(big.NewInt(0))
+	} else {
+		gas := hexutil.Uint64(tx.Gas())
+		args.Gas = &gas
+		args.Value = (*hexutil.Big)(tx.Value())
+		args.Data = tx.Data()
+		if tx.To() != nil {
+			to := types.Address(*tx.To())
+			args.To = &to
+		}
+	}
+
+	if fees.isDynamicFee() {
+		// For legacy transactions GasFeeCap and GasTipCap both return the gas price, which is what nodes compare against
+		maxFeePerGas := bumpFee(tx.GasFeeCap(), fees.MaxFeePerGas)
+		maxPriorityFeePerGas := bumpFee(tx.GasTipCap(), fees.MaxPriorityFeePerGas)
+		if maxPriorityFeePerGas.Cmp(maxFeePerGas) > 0 {
+			maxFeePerGas = maxPriorityFeePerGas
+		}
+		args.MaxFeePerGas = (*hexutil.Big)(maxFeePerGas)
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(maxPriorityFeePerGas)
+	} else {
+		args.GasPrice = (*hexutil.Big)(bumpFee(tx.GasPrice(), fees.GasPrice))
+	}
+
+	return args
+}
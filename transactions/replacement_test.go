@@ -0,0 +1,75 @@
+package transactions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/status-im/status-go/eth-node/types"
+)
+
+func TestBuildReplacementTxArgs_SpeedUp(t *testing.T) {
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	tx := gethtypes.NewTx(&gethtypes.DynamicFeeTx{
+		Nonce:     5,
+		Gas:       50000,
+		GasTipCap: big.NewInt(100),
+		GasFeeCap: big.NewInt(1000),
+		To:        &to,
+		Value:     big.NewInt(42),
+		Data:      []byte{0x01, 0x02},
+	})
+
+	// Suggested fees lower than the original ones, minimal bump is used
+	args := BuildReplacementTxArgs(tx, from, ReplacementSpeedUp, &ReplacementFees{
+		MaxFeePerGas:         big.NewInt(500),
+		MaxPriorityFeePerGas: big.NewInt(50),
+	})
+	require.Equal(t, uint64(5), uint64(*args.Nonce))
+	require.Equal(t, uint64(50000), uint64(*args.Gas))
+	require.Equal(t, types.Address(to), *args.To)
+	require.Equal(t, big.NewInt(42), args.Value.ToInt())
+	require.Equal(t, types.HexBytes{0x01, 0x02}, args.GetInput())
+	require.Equal(t, big.NewInt(1100), args.MaxFeePerGas.ToInt())
+	require.Equal(t, big.NewInt(110), args.MaxPriorityFeePerGas.ToInt())
+	require.True(t, args.IsDynamicFeeTx())
+
+	// Suggested fees higher than the bumped ones are used as they are
+	args = BuildReplacementTxArgs(tx, from, ReplacementSpeedUp, &ReplacementFees{
+		MaxFeePerGas:         big.NewInt(3000),
+		MaxPriorityFeePerGas: big.NewInt(200),
+	})
+	require.Equal(t, big.NewInt(3000), args.MaxFeePerGas.ToInt())
+	require.Equal(t, big.NewInt(200), args.MaxPriorityFeePerGas.ToInt())
+}
+
+func TestBuildReplacementTxArgs_Cancel(t *testing.T) {
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+	tx := gethtypes.NewTx(&gethtypes.LegacyTx{
+		Nonce:    7,
+		GasPrice: big.NewInt(15),
+		Gas:      80000,
+		To:       &to,
+		Value:    big.NewInt(42),
+		Data:     []byte{0x01},
+	})
+
+	args := BuildReplacementTxArgs(tx, from, ReplacementCancel, &ReplacementFees{
+		GasPrice: big.NewInt(10),
+	})
+	require.Equal(t, uint64(7), uint64(*args.Nonce))
+	require.Equal(t, types.Address(from), *args.To)
+	require.Equal(t, params.TxGas, uint64(*args.Gas))
+	require.Equal(t, int64(0), args.Value.ToInt().Int64())
+	require.Empty(t, args.GetInput())
+	require.False(t, args.IsDynamicFeeTx())
+	// 15 * 1.1 rounded up
+	require.Equal(t, big.NewInt(17), args.GasPrice.ToInt())
+}
@@ -58,6 +58,8 @@ type TransactorIface interface {
 	BuildTransactionWithSignature(chainID uint64, args SendTxArgs, sig []byte) (*gethtypes.Transaction, error)
 	SendTransactionWithSignature(from common.Address, symbol string, multiTransactionID wallet_common.MultiTransactionIDType, tx *gethtypes.Transaction) (hash types.Hash, err error)
 	StoreAndTrackPendingTx(from common.Address, symbol string, chainID uint64, multiTransactionID wallet_common.MultiTransactionIDType, tx *gethtypes.Transaction) error
+	GetTransactionByHash(chainID uint64, hash common.Hash) (tx *gethtypes.Transaction, isPending bool, err error)
+	SendReplacementTransaction(replacedTx *PendingTransaction, sendArgs SendTxArgs, verifiedAccount *account.SelectedExtKey) (hash types.Hash, err error)
 }
 
 // Transactor validates, signs transactions.
@@ -217,6 +219,71 @@ func (t *Transactor) StoreAndTrackPendingTx(from common.Address, symbol string,
 	return t.pendingTracker.StoreAndTrackPendingTx(pTx)
 }
 
+func (t *Transactor) GetTransactionByHash(chainID uint64, hash common.Hash) (tx *gethtypes.Transaction, isPending bool, err error) {
+	client, err := t.rpcWrapper.RPCClient.EthClient(chainID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.rpcCallTimeout)
+	defer cancel()
+
+	return client.TransactionByHash(ctx, hash)
+}
+
+// SendReplacementTransaction signs and sends a transaction replacing the pending `replacedTx`.
+// sendArgs must use the nonce of the replaced transaction, see BuildReplacementTxArgs.
+// The new pending entry keeps the details of the replaced one and links to it, so that both are shown as a single entry.
+func (t *Transactor) SendReplacementTransaction(replacedTx *PendingTransaction, sendArgs SendTxArgs, verifiedAccount *account.SelectedExtKey) (hash types.Hash, err error) {
+	if sendArgs.Nonce == nil || uint64(*sendArgs.Nonce) != replacedTx.Nonce {
+		return hash, &ErrBadNonce{nonce: nonceOrZero(sendArgs.Nonce), expectedNonce: replacedTx.Nonce}
+	}
+
+	if err = t.validateAccount(sendArgs, verifiedAccount); err != nil {
+		return hash, err
+	}
+
+	wrapper := newRPCWrapper(t.rpcWrapper.RPCClient, uint64(replacedTx.ChainID))
+	tx, err := t.validateAndBuildTransaction(wrapper, sendArgs, -1)
+	if err != nil {
+		return hash, err
+	}
+
+	chainID := big.NewInt(int64(wrapper.chainID))
+	signedTx, err := verifiedAccount.Signer().SignTx(context.Background(), common.Address(verifiedAccount.Address), tx, chainID)
+	if err != nil {
+		return hash, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.rpcCallTimeout)
+	defer cancel()
+
+	if err = wrapper.SendTransaction(ctx, signedTx); err != nil {
+		return hash, err
+	}
+
+	if t.pendingTracker != nil {
+		pTx := createPendingTransaction(replacedTx.From, replacedTx.Symbol, wrapper.chainID, replacedTx.MultiTransactionID, signedTx)
+		pTx.Type = replacedTx.Type
+		pTx.AdditionalData = replacedTx.AdditionalData
+		pTx.AutoDelete = replacedTx.AutoDelete
+		pTx.ReplacedHash = &replacedTx.Hash
+		err = t.pendingTracker.StoreAndTrackPendingTx(pTx)
+		if err != nil {
+			return hash, err
+		}
+	}
+
+	return types.Hash(signedTx.Hash()), nil
+}
+
+func nonceOrZero(nonce *hexutil.Uint64) uint64 {
+	if nonce == nil {
+		return 0
+	}
+	return uint64(*nonce)
+}
+
 func (t *Transactor) sendTransaction(rpcWrapper *rpcWrapper, from common.Address, symbol string,
 	multiTransactionID wallet_common.MultiTransactionIDType, tx *gethtypes.Transaction) (hash types.Hash, err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), t.rpcCallTimeout)
@@ -459,7 +526,7 @@ func (t *Transactor) validateAndPropagate(rpcWrapper *rpcWrapper, selectedAccoun
 	}
 
 	chainID := big.NewInt(int64(rpcWrapper.chainID))
-	signedTx, err := gethtypes.SignTx(tx, gethtypes.NewLondonSigner(chainID), selectedAccount.AccountKey.PrivateKey)
+	signedTx, err := selectedAccount.Signer().SignTx(context.Background(), common.Address(selectedAccount.Address), tx, chainID)
 	if err != nil {
 		return hash, nonce, err
 	}
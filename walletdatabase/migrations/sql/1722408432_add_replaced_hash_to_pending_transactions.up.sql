@@ -0,0 +1,2 @@
+ALTER TABLE pending_transactions ADD COLUMN replaced_hash BLOB;
+ALTER TABLE pending_transactions ADD COLUMN replaced_by_hash BLOB;
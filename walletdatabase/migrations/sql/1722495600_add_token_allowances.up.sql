@@ -0,0 +1,20 @@
+-- token_allowances keeps the last known ERC20 allowances given by the wallet accounts
+CREATE TABLE IF NOT EXISTS token_allowances (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    owner BLOB NOT NULL,
+    token BLOB NOT NULL,
+    spender BLOB NOT NULL,
+    amount BLOB NOT NULL,
+    unlimited BOOLEAN NOT NULL DEFAULT FALSE,
+    block_number UNSIGNED BIGINT NOT NULL DEFAULT 0,
+    updated_at INT NOT NULL,
+    PRIMARY KEY (chain_id, owner, token, spender)
+) WITHOUT ROWID;
+
+-- token_allowances_scan keeps the last block scanned for Approval logs of an account
+CREATE TABLE IF NOT EXISTS token_allowances_scan (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    owner BLOB NOT NULL,
+    last_block UNSIGNED BIGINT NOT NULL,
+    PRIMARY KEY (chain_id, owner)
+) WITHOUT ROWID;
@@ -0,0 +1,21 @@
+-- scheduled_transfers keeps the transfers scheduled for a later time, route_params holds the JSON encoded route input
+-- params used to calculate the route when the transfer is executed
+CREATE TABLE IF NOT EXISTS scheduled_transfers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    from_address BLOB NOT NULL,
+    to_address BLOB NOT NULL,
+    send_type INTEGER NOT NULL,
+    token_id TEXT NOT NULL,
+    amount_in TEXT NOT NULL,
+    route_params TEXT NOT NULL,
+    recurrence INTEGER NOT NULL DEFAULT 0,
+    start_at INT NOT NULL,
+    next_execution INT NOT NULL,
+    last_execution INT NOT NULL DEFAULT 0,
+    executions INTEGER NOT NULL DEFAULT 0,
+    status INTEGER NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at INT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_transfers_status_next_execution ON scheduled_transfers (status, next_execution);
@@ -0,0 +1,12 @@
+-- fee_alerts keeps the fee thresholds the user wants to be notified about, triggered is set while the condition is met
+CREATE TABLE IF NOT EXISTS fee_alerts (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    chain_id UNSIGNED BIGINT NOT NULL,
+    metric INTEGER NOT NULL,
+    condition INTEGER NOT NULL,
+    threshold BLOB NOT NULL,
+    repeat BOOLEAN NOT NULL DEFAULT FALSE,
+    triggered BOOLEAN NOT NULL DEFAULT FALSE,
+    triggered_at INT NOT NULL DEFAULT 0,
+    created_at INT NOT NULL
+);
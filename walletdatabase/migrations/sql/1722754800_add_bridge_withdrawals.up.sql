@@ -0,0 +1,25 @@
+-- bridge_withdrawals tracks the withdrawals sent through the canonical L2 bridges until they are finalized on L1,
+-- message holds the JSON encoded L2 to L1 message needed to prove and finalize the withdrawal
+CREATE TABLE IF NOT EXISTS bridge_withdrawals (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    multi_transaction_id INTEGER NOT NULL,
+    kind INTEGER NOT NULL,
+    l1_chain_id UNSIGNED BIGINT NOT NULL,
+    l2_chain_id UNSIGNED BIGINT NOT NULL,
+    tx_hash BLOB NOT NULL,
+    from_address BLOB NOT NULL,
+    to_address BLOB NOT NULL,
+    token_id TEXT NOT NULL,
+    amount TEXT NOT NULL,
+    stage INTEGER NOT NULL,
+    prove_tx_hash BLOB NOT NULL,
+    finalize_tx_hash BLOB NOT NULL,
+    ready_at INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL DEFAULT '',
+    created_at INT NOT NULL,
+    updated_at INT NOT NULL,
+    UNIQUE(l2_chain_id, tx_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bridge_withdrawals_stage ON bridge_withdrawals (stage);
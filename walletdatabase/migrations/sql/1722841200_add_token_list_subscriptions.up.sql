@@ -0,0 +1,12 @@
+-- token_list_subscriptions holds the Uniswap format token lists the user subscribed to,
+-- tokens holds the JSON encoded tokens of the last fetched version
+CREATE TABLE IF NOT EXISTS token_list_subscriptions (
+    url TEXT PRIMARY KEY NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    version TEXT NOT NULL DEFAULT '',
+    list_timestamp INT NOT NULL DEFAULT 0,
+    tokens TEXT NOT NULL DEFAULT '[]',
+    last_fetched_at INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at INT NOT NULL
+);
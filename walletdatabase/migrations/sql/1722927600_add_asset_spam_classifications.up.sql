@@ -0,0 +1,21 @@
+-- asset_spam_classifications holds the spam verdict computed for tokens and collectibles contracts,
+-- user_verdict overrides the computed verdict when set
+CREATE TABLE IF NOT EXISTS asset_spam_classifications (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    address BLOB NOT NULL,
+    asset_type TEXT NOT NULL,
+    score INT NOT NULL,
+    verdict TEXT NOT NULL,
+    reasons TEXT NOT NULL DEFAULT '',
+    user_verdict TEXT NOT NULL DEFAULT '',
+    updated_at INT NOT NULL,
+    PRIMARY KEY (chain_id, address, asset_type)
+);
+
+-- known_scam_contracts holds the contracts reported as scams by the imported lists
+CREATE TABLE IF NOT EXISTS known_scam_contracts (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    address BLOB NOT NULL,
+    source TEXT NOT NULL,
+    PRIMARY KEY (chain_id, address)
+);
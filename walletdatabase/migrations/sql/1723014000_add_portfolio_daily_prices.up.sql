@@ -0,0 +1,9 @@
+-- portfolio_daily_prices keeps the daily fiat prices used to value the portfolio lots,
+-- the cost basis and P&L are computed offline once the prices are known
+CREATE TABLE IF NOT EXISTS portfolio_daily_prices (
+    symbol TEXT NOT NULL,
+    currency TEXT NOT NULL,
+    day INT NOT NULL,
+    price REAL NOT NULL,
+    PRIMARY KEY (symbol, currency, day)
+);
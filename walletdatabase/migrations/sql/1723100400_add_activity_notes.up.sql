@@ -0,0 +1,14 @@
+-- activity_notes keeps the private notes and labels the user attached to transactions.
+-- The notes are synced between paired devices, removed notes are kept as tombstones so
+-- the removal is propagated; update_clock orders the concurrent updates
+CREATE TABLE IF NOT EXISTS activity_notes (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    tx_hash BLOB NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    label TEXT NOT NULL DEFAULT '',
+    removed BOOLEAN NOT NULL DEFAULT FALSE,
+    update_clock INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (chain_id, tx_hash)
+) WITHOUT ROWID;
+
+CREATE INDEX IF NOT EXISTS idx_activity_notes_tx_hash ON activity_notes (tx_hash);
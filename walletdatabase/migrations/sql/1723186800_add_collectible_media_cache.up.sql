@@ -0,0 +1,19 @@
+-- collectible_media_cache keeps the collectible images and animations downloaded from the NFT
+-- hosts, served to the client by the media server. Raster images also keep a thumbnail.
+-- The least recently accessed entries are evicted when the cache grows over its size limit
+CREATE TABLE IF NOT EXISTS collectible_media_cache (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    contract_address VARCHAR NOT NULL,
+    token_id BLOB NOT NULL,
+    media_type VARCHAR NOT NULL,
+    source_url VARCHAR NOT NULL,
+    mime_type VARCHAR NOT NULL,
+    payload BLOB NOT NULL,
+    thumbnail BLOB,
+    size INTEGER NOT NULL,
+    fetched_at INTEGER NOT NULL,
+    last_accessed_at INTEGER NOT NULL,
+    PRIMARY KEY (chain_id, contract_address, token_id, media_type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_collectible_media_cache_last_accessed_at ON collectible_media_cache (last_accessed_at);
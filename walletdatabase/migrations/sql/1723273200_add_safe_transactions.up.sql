@@ -0,0 +1,46 @@
+-- safes keeps the on-chain configuration of the imported Safe multisig accounts, owners is the comma separated list
+-- of the owner addresses
+CREATE TABLE IF NOT EXISTS safes (
+    chain_id UNSIGNED BIGINT NOT NULL,
+    address BLOB NOT NULL,
+    version TEXT NOT NULL,
+    owners TEXT NOT NULL,
+    threshold INTEGER NOT NULL,
+    nonce INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL,
+    PRIMARY KEY (chain_id, address)
+);
+
+-- safe_transactions keeps the transactions proposed to the Safes, the big numbers are stored as hex strings
+CREATE TABLE IF NOT EXISTS safe_transactions (
+    safe_tx_hash BLOB PRIMARY KEY,
+    chain_id UNSIGNED BIGINT NOT NULL,
+    safe_address BLOB NOT NULL,
+    to_address BLOB NOT NULL,
+    value TEXT NOT NULL,
+    data BLOB,
+    operation INTEGER NOT NULL,
+    safe_tx_gas TEXT NOT NULL,
+    base_gas TEXT NOT NULL,
+    gas_price TEXT NOT NULL,
+    gas_token BLOB NOT NULL,
+    refund_receiver BLOB NOT NULL,
+    nonce INTEGER NOT NULL,
+    proposer BLOB NOT NULL,
+    status INTEGER NOT NULL,
+    execution_tx_hash BLOB NOT NULL,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_safe_transactions_safe ON safe_transactions (chain_id, safe_address, nonce);
+
+-- safe_confirmations keeps the signatures of the Safe transaction hash by the owners
+CREATE TABLE IF NOT EXISTS safe_confirmations (
+    safe_tx_hash BLOB NOT NULL,
+    owner BLOB NOT NULL,
+    signature BLOB NOT NULL,
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (safe_tx_hash, owner),
+    FOREIGN KEY (safe_tx_hash) REFERENCES safe_transactions (safe_tx_hash) ON DELETE CASCADE
+);
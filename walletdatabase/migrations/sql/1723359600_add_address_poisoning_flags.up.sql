@@ -0,0 +1,11 @@
+-- address_poisoning_flags keeps the transfers suspected to be address poisoning, flags is the bitmask of the detected
+-- patterns and resembles the known address imitated by the counterparty, if any
+CREATE TABLE IF NOT EXISTS address_poisoning_flags (
+    network_id UNSIGNED BIGINT NOT NULL,
+    hash VARCHAR NOT NULL,
+    address VARCHAR NOT NULL,
+    counterparty BLOB NOT NULL,
+    resembles BLOB,
+    flags INTEGER NOT NULL,
+    PRIMARY KEY (network_id, hash, address)
+);